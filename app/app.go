@@ -55,6 +55,7 @@ import (
 	v9 "github.com/osmosis-labs/osmosis/v13/app/upgrades/v9"
 	_ "github.com/osmosis-labs/osmosis/v13/client/docs/statik"
 	ibc_hooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	twaptypes "github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 const appName = "OsmosisApp"
@@ -132,6 +133,9 @@ type OsmosisApp struct {
 
 	mm           *module.Manager
 	configurator module.Configurator
+
+	// queryGasLimit is the gas limit of the contexts returned by NewQueryContext.
+	queryGasLimit uint64
 }
 
 // init sets DefaultNodeHome to default osmosisd install location.
@@ -184,6 +188,8 @@ func NewOsmosisApp(
 	if err != nil {
 		panic(fmt.Sprintf("error while reading wasm config: %s", err))
 	}
+	// the node's query gas limit, which also bounds the smart queries of contracts
+	app.queryGasLimit = wasmConfig.SmartQueryGasLimit
 	app.InitSpecialKeepers(
 		appCodec,
 		bApp,
@@ -207,6 +213,17 @@ func NewOsmosisApp(
 
 	app.SetupHooks()
 
+	// The twap record cache only serves queries, so each node can size it in its app.toml
+	app.TwapKeeper.SetRecordCacheSize(twaptypes.ParseRecordCacheSize(appOpts))
+	// The twap archive is a database of the node, outside of the multistore, so enabling it doesn't affect consensus
+	if twaptypes.ParseArchive(appOpts) {
+		archiveDB, err := sdk.NewLevelDB("twap_archive", filepath.Join(homePath, "data"))
+		if err != nil {
+			panic(fmt.Sprintf("error opening the twap archive: %s", err))
+		}
+		app.TwapKeeper.SetArchive(archiveDB, app.GetTKey(twaptypes.ArchiveTransientStoreKey))
+	}
+
 	/****  Module Options ****/
 
 	// NOTE: we may consider parsing `appOpts` inside module constructors. For the moment
@@ -343,6 +360,26 @@ func (app *OsmosisApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
 }
 
+// NewQueryContext returns a read-only context on the state committed at the given height,
+// or at the latest height if it is 0. Its block header only has the height set.
+// Its gas is limited by the node's query gas limit (query_gas_limit of the wasm config).
+func (app *OsmosisApp) NewQueryContext(height int64) (sdk.Context, error) {
+	lastBlockHeight := app.LastBlockHeight()
+	if height == 0 {
+		height = lastBlockHeight
+	}
+	if height < 0 || height > lastBlockHeight {
+		return sdk.Context{}, fmt.Errorf("cannot query state at height %d, latest height is %d", height, lastBlockHeight)
+	}
+	cacheMS, err := app.CommitMultiStore().CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, fmt.Errorf("failed to load state at height %d: %w", height, err)
+	}
+	ctx := sdk.NewContext(cacheMS, tmproto.Header{Height: height}, true, app.Logger()).
+		WithGasMeter(sdk.NewGasMeter(app.queryGasLimit))
+	return ctx, nil
+}
+
 // LegacyAmino returns SimApp's amino codec.
 //
 // NOTE: This is solely to be used for testing purposes as it may be desirable
@@ -368,6 +405,11 @@ func (app *OsmosisApp) ModuleManager() module.Manager {
 	return *app.mm
 }
 
+// Configurator returns the configurator the module services and migrations are registered with.
+func (app *OsmosisApp) Configurator() module.Configurator {
+	return app.configurator
+}
+
 // RegisterAPIRoutes registers all application module routes with the provided
 // API server.
 func (app *OsmosisApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APIConfig) {
@@ -170,8 +170,8 @@ func (s *KeeperTestHelper) ModifySpotPrice(poolID uint64, targetSpotPrice sdk.De
 	var quoteDenom string
 	int64Max := int64(^uint64(0) >> 1)
 
-	s.Require().Positive(targetSpotPrice)
-	s.Require().Greater(gammtypes.MaxSpotPrice, targetSpotPrice)
+	s.Require().True(targetSpotPrice.IsPositive())
+	s.Require().True(gammtypes.MaxSpotPrice.GT(targetSpotPrice))
 	pool, _ := s.App.GAMMKeeper.GetPoolAndPoke(s.Ctx, poolID)
 	denoms, err := s.App.GAMMKeeper.GetPoolDenoms(s.Ctx, poolID)
 	s.Require().NoError(err)
@@ -293,7 +293,16 @@ func (s *KeeperTestHelper) CalcAmoutOfTokenToGetTargetPrice(ctx sdk.Context, poo
 	ratioPrice := targetSpotPrice.Quo(spotPriceNow)
 	ratioWeight := (baseAsset.Weight.ToDec()).Quo(baseAsset.Weight.ToDec().Add(quoteAsset.Weight.ToDec()))
 
-	amountTrade = quoteAsset.Token.Amount.ToDec().Mul(osmomath.Pow(ratioPrice, ratioWeight).Sub(sdk.OneDec()))
+	// osmomath.Pow only takes bases lesser than two, so larger ratios are raised to the power in factors of 3/2
+	powRatio := sdk.OneDec()
+	factor := sdk.NewDecWithPrec(15, 1)
+	for ratioPrice.GTE(sdk.NewDec(2)) {
+		powRatio = powRatio.Mul(osmomath.Pow(factor, ratioWeight))
+		ratioPrice = ratioPrice.Quo(factor)
+	}
+	powRatio = powRatio.Mul(osmomath.Pow(ratioPrice, ratioWeight))
+
+	amountTrade = quoteAsset.Token.Amount.ToDec().Mul(powRatio.Sub(sdk.OneDec()))
 
 	return amountTrade
 }
@@ -228,6 +228,10 @@ func (appKeepers *AppKeepers) InitNormalKeepers(
 	// Configure the hooks keeper
 	hooksKeeper := ibchookskeeper.NewKeeper(
 		appKeepers.keys[ibchookstypes.StoreKey],
+		appKeepers.tkeys[ibchookstypes.TransientStoreKey],
+		appKeepers.GetSubspace(ibchookstypes.ModuleName),
+		appKeepers.IBCKeeper.ChannelKeeper,
+		appKeepers.DistrKeeper,
 	)
 	appKeepers.IBCHooksKeeper = &hooksKeeper
 
@@ -273,7 +277,8 @@ func (appKeepers *AppKeepers) InitNormalKeepers(
 		appKeepers.keys[twaptypes.StoreKey],
 		appKeepers.tkeys[twaptypes.TransientStoreKey],
 		appKeepers.GetSubspace(twaptypes.ModuleName),
-		appKeepers.GAMMKeeper)
+		appKeepers.GAMMKeeper,
+		appKeepers.UpgradeKeeper)
 
 	appKeepers.SwapRouterKeeper = swaprouter.NewKeeper(
 		appKeepers.keys[swaproutertypes.StoreKey],
@@ -371,7 +376,7 @@ func (appKeepers *AppKeepers) InitNormalKeepers(
 	// if we want to allow any custom callbacks
 	supportedFeatures := "iterator,staking,stargate,osmosis,cosmwasm_1_1"
 
-	wasmOpts = append(owasm.RegisterCustomPlugins(appKeepers.GAMMKeeper, appKeepers.BankKeeper, appKeepers.TwapKeeper, appKeepers.TokenFactoryKeeper), wasmOpts...)
+	wasmOpts = append(owasm.RegisterCustomPlugins(appKeepers.GAMMKeeper, appKeepers.BankKeeper, appKeepers.TwapKeeper, appKeepers.TokenFactoryKeeper, appKeepers.IBCHooksKeeper), wasmOpts...)
 	wasmOpts = append(owasm.RegisterStargateQueries(*bApp.GRPCQueryRouter(), appCodec), wasmOpts...)
 
 	wasmKeeper := wasm.NewKeeper(
@@ -394,6 +399,7 @@ func (appKeepers *AppKeepers) InitNormalKeepers(
 		wasmOpts...,
 	)
 	appKeepers.WasmKeeper = &wasmKeeper
+	appKeepers.IBCHooksKeeper.SetContractKeeper(appKeepers.WasmKeeper)
 
 	// Pass the contract keeper to all the structs (generally ICS4Wrappers for ibc middlewares) that need it
 	appKeepers.ContractKeeper = wasmkeeper.NewDefaultPermissionKeeper(appKeepers.WasmKeeper)
@@ -442,7 +448,7 @@ func (appKeepers *AppKeepers) WireICS20PreWasmKeeper(
 	bApp *baseapp.BaseApp,
 	hooksKeeper *ibchookskeeper.Keeper) {
 	// Setup the ICS4Wrapper used by the hooks middleware
-	wasmHooks := ibchooks.NewWasmHooks(hooksKeeper, nil) // The contract keeper needs to be set later
+	wasmHooks := ibchooks.NewWasmHooks(hooksKeeper, nil, appKeepers.AccountKeeper, appKeepers.BankKeeper) // The contract keeper needs to be set later
 	appKeepers.Ics20WasmHooks = &wasmHooks
 	appKeepers.HooksICS4Wrapper = ibchooks.NewICS4Middleware(
 		appKeepers.IBCKeeper.ChannelKeeper,
@@ -476,6 +482,8 @@ func (appKeepers *AppKeepers) WireICS20PreWasmKeeper(
 		appKeepers.ScopedTransferKeeper,
 	)
 	appKeepers.TransferKeeper = &transferKeeper
+	// The hooks send the results of the hooked executions back to their senders through the transfer keeper
+	appKeepers.Ics20WasmHooks.TransferKeeper = appKeepers.TransferKeeper
 	appKeepers.RawIcs20TransferAppModule = transfer.NewAppModule(*appKeepers.TransferKeeper)
 	transferIBCModule := transfer.NewIBCModule(*appKeepers.TransferKeeper)
 
@@ -484,6 +492,11 @@ func (appKeepers *AppKeepers) WireICS20PreWasmKeeper(
 	// Hooks Middleware
 	hooksTransferModule := ibchooks.NewIBCMiddleware(&rateLimitingTransferModule, &appKeepers.HooksICS4Wrapper)
 	appKeepers.TransferStack = &hooksTransferModule
+
+	// The hooked packets deferred after a failed ICS20 receive are received again through the whole transfer stack
+	hooksKeeper.SetRecvRetrier(ibchooks.NewRecvRetrier(appKeepers.TransferStack, appKeepers.Ics20WasmHooks, appKeepers.ScopedTransferKeeper))
+	hooksKeeper.SetHookSimulator(ibchooks.NewHookSimulator(appKeepers.TransferStack, appKeepers.Ics20WasmHooks))
+	hooksKeeper.SetCallbackDeliverer(appKeepers.Ics20WasmHooks)
 }
 
 // InitSpecialKeepers initiates special keepers (crisis appkeeper, upgradekeeper, params keeper)
@@ -554,6 +567,7 @@ func (appKeepers *AppKeepers) initParamsKeeper(appCodec codec.BinaryCodec, legac
 	paramsKeeper.Subspace(tokenfactorytypes.ModuleName)
 	paramsKeeper.Subspace(twaptypes.ModuleName)
 	paramsKeeper.Subspace(ibcratelimittypes.ModuleName)
+	paramsKeeper.Subspace(ibchookstypes.ModuleName)
 
 	return paramsKeeper
 }
@@ -610,6 +624,7 @@ func (appKeepers *AppKeepers) SetupHooks() {
 			appKeepers.IncentivesKeeper.Hooks(),
 			appKeepers.MintKeeper.Hooks(),
 			appKeepers.ProtoRevKeeper.EpochHooks(),
+			appKeepers.IBCHooksKeeper.EpochHooks(),
 		),
 	)
 
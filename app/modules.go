@@ -143,7 +143,7 @@ func appModules(
 		app.RawIcs20TransferAppModule,
 		gamm.NewAppModule(appCodec, *app.GAMMKeeper, app.AccountKeeper, app.BankKeeper),
 		swaprouter.NewAppModule(*app.SwapRouterKeeper, app.GAMMKeeper),
-		twapmodule.NewAppModule(*app.TwapKeeper),
+		twapmodule.NewAppModule(*app.TwapKeeper, app.NewQueryContext),
 		protorev.NewAppModule(appCodec, *app.ProtoRevKeeper, app.AccountKeeper, app.BankKeeper, app.EpochsKeeper, app.GAMMKeeper),
 		txfees.NewAppModule(*app.TxFeesKeeper),
 		incentives.NewAppModule(*app.IncentivesKeeper, app.AccountKeeper, app.BankKeeper, app.EpochsKeeper),
@@ -161,7 +161,7 @@ func appModules(
 		),
 		tokenfactory.NewAppModule(*app.TokenFactoryKeeper, app.AccountKeeper, app.BankKeeper),
 		valsetprefmodule.NewAppModule(appCodec, *app.ValidatorSetPreferenceKeeper),
-		ibc_hooks.NewAppModule(app.AccountKeeper),
+		ibc_hooks.NewAppModule(app.AccountKeeper, *app.IBCHooksKeeper),
 	}
 }
 
@@ -184,6 +184,7 @@ func orderBeginBlockers(allModuleNames []string) []string {
 	// TODO: Come back and delete this line after testing the base change.
 	ord.Sequence(stakingtypes.ModuleName, ibchost.ModuleName, superfluidtypes.ModuleName)
 	// We leave downtime-detector un-constrained.
+	// ibc-hooks' begin block only deletes expired packet callbacks, so it is left un-constrained too.
 	// every remaining module's begin block is a no-op.
 	return ord.TotalOrdering()
 }
@@ -194,10 +195,13 @@ func OrderEndBlockers(allModuleNames []string) []string {
 
 	// Staking must be after gov.
 	ord.FirstElements(govtypes.ModuleName)
-	ord.LastElements(stakingtypes.ModuleName)
+	// twap's EndBlock updates the records of the pools changed in the block. It must run after every EndBlock that
+	// can change pool prices (e.g. ibc-hooks executes contracts in it), so that the records of a block reflect all
+	// of its pool changes. Rather than tracking which modules can, twap runs after all of them but staking.
+	ord.LastElements(twaptypes.ModuleName, stakingtypes.ModuleName)
 
-	// only Osmosis modules with endblock code are: twap, crisis, govtypes, staking
-	// we don't care about the relative ordering between them.
+	// only Osmosis modules with endblock code are: twap, crisis, govtypes, staking, lockup, ibc-hooks
+	// we don't care about the relative ordering between the others.
 	return ord.TotalOrdering()
 }
 
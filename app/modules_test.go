@@ -8,6 +8,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	twaptypes "github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 func TestOrderEndBlockers_Determinism(t *testing.T) {
@@ -20,3 +24,14 @@ func TestOrderEndBlockers_Determinism(t *testing.T) {
 		require.True(t, reflect.DeepEqual(a, b))
 	}
 }
+
+// twap's EndBlock must run after every EndBlock that can change pool prices. As staking is the only module
+// after it, this holds for the modules added later too.
+func TestOrderEndBlockers_TwapAfterPoolChanges(t *testing.T) {
+	db := dbm.NewMemDB()
+	app := NewOsmosisApp(log.NewNopLogger(), db, nil, true, map[int64]bool{}, DefaultNodeHome, 5, simapp.EmptyAppOptions{}, GetWasmEnabledProposals(), EmptyWasmOpts)
+
+	order := app.mm.OrderEndBlockers
+	require.Equal(t, len(app.mm.Modules), len(order))
+	require.Equal(t, []string{twaptypes.ModuleName, stakingtypes.ModuleName}, order[len(order)-2:])
+}
@@ -27,6 +27,23 @@ func CreateUpgradeHandler(
 		// Instead,it is moved to swaprouter.
 		migrateNextPoolId(ctx, keepers.GAMMKeeper, keepers.SwapRouterKeeper)
 
+		// The twap record schema version is migrated by RunMigrations, as twap's consensus version was bumped.
+		// The record pinning params are new, pinning stays disabled until governance sets a pin authority.
+		keepers.TwapKeeper.MigratePinParams(ctx)
+		// The EndBlock gas budget param is new, the twap EndBlock has no gas budget until governance sets one.
+		keepers.TwapKeeper.MigrateEndBlockGasBudgetParam(ctx)
+		// The spot deviation alert params are new, the alerts are disabled until governance sets a threshold.
+		keepers.TwapKeeper.MigrateSpotDeviationAlertParams(ctx)
+		// The median tracked pools param is new, no pool's spot prices are sampled until governance opts pools in.
+		keepers.TwapKeeper.MigrateMedianTrackedPoolsParam(ctx)
+		// The canonical route params are new, no canonical route can be set until governance sets an authority.
+		keepers.TwapKeeper.MigrateCanonicalRouteParams(ctx)
+		// The max pruned records per block param is new, the records are pruned over several blocks from now on.
+		keepers.TwapKeeper.MigrateMaxPrunedRecordsPerBlockParam(ctx)
+		// The params authority param is new, the twap params can't be updated with MsgUpdateParams until governance
+		// sets one.
+		keepers.TwapKeeper.MigrateParamsAuthorityParam(ctx)
+
 		//  N.B.: this is done to avoid initializing genesis for swaprouter module.
 		// Otherwise, it would overwrite migrations with InitGenesis().
 		// See RunMigrations() for details.
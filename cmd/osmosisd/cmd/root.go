@@ -130,6 +130,20 @@ arbitrage-min-gas-fee = ".005"
 # This is the minimum gas fee any tx with high gas demand should have, denominated in uosmo per gas
 # Default value of ".0025" then means that a tx with 1 million gas costs (.0025 uosmo/gas) * 1_000_000 gas = .0025 osmo
 min-gas-price-for-high-gas-tx = ".0025"
+
+###############################################################################
+###                        Osmosis TWAP Configuration                       ###
+###############################################################################
+
+[osmosis-twap]
+# This is the number of most recent TWAP records cached in memory for TWAP-to-now queries.
+# It does not affect consensus, and is only worth enabling on nodes serving many queries. 0 disables the cache.
+record-cache-size = "0"
+
+# When enabled, the node copies every TWAP record it writes into a database of its data directory, which pruning
+# never touches, and serves the archived TWAP queries from it. It does not affect consensus.
+# The archive only holds the records written while it is enabled.
+archive = "false"
 `
 
 	return OsmosisAppTemplate, OsmosisAppCfg
@@ -1,6 +1,7 @@
 package osmoutils
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -82,6 +83,23 @@ func GetFirstValueInRange[T any](storeObj store.KVStore, keyStart []byte, keyEnd
 	return parseValue(iterator.Value())
 }
 
+// GetLastValueBeforeOrAtKey returns the value of the greatest key under prefix that is at or before key.
+// key must start with prefix, so that keys of adjacent prefixes are never returned.
+// Returns an error if there is no such key.
+func GetLastValueBeforeOrAtKey[T any](storeObj store.KVStore, prefix []byte, key []byte, parseValue func([]byte) (T, error)) (T, error) {
+	if !bytes.HasPrefix(key, prefix) {
+		var blankValue T
+		return blankValue, fmt.Errorf("key %q does not start with prefix %q", key, prefix)
+	}
+	// The end of the range is exclusive, so we append a zero byte to get the smallest key after key.
+	keyEnd := append(append([]byte{}, key...), 0)
+	// iterators reject empty, non-nil start keys
+	if len(prefix) == 0 {
+		prefix = nil
+	}
+	return GetFirstValueInRange(storeObj, prefix, keyEnd, true, parseValue)
+}
+
 func gatherValuesFromIterator[T any](iterator db.Iterator, parseValue func([]byte) (T, error), stopFn func([]byte) bool) ([]T, error) {
 	values := []T{}
 	for ; iterator.Valid(); iterator.Next() {
@@ -422,6 +422,154 @@ func (s *TestSuite) TestGetFirstValueAfterPrefixInclusive() {
 	}
 }
 
+func (s *TestSuite) TestGetLastValueBeforeOrAtKey() {
+	testcases := map[string]struct {
+		preSetKeys []string
+		prefix     []byte
+		key        []byte
+		parseFn    func(b []byte) (string, error)
+
+		expectedErr    error
+		expectedValues string
+	}{
+		"exact match key": {
+			preSetKeys: oneABC,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + keyB),
+			parseFn:    mockParseValue,
+
+			expectedValues: "1",
+		},
+		"key between entries": {
+			preSetKeys: []string{prefixOne + keyA, prefixOne + keyC},
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + keyB),
+			parseFn:    mockParseValue,
+
+			expectedValues: "0",
+		},
+		"key after last entry": {
+			preSetKeys: oneABC,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + "z"),
+			parseFn:    mockParseValue,
+
+			expectedValues: "2",
+		},
+		"entries extending the key are after it": {
+			preSetKeys: []string{prefixOne + keyA, prefixOne + keyA + keyA},
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + keyA),
+			parseFn:    mockParseValue,
+
+			expectedValues: "0",
+		},
+		"adjacent prefixes, key after last entry of prefix one": {
+			preSetKeys: oneABtwoAB,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + "z"),
+			parseFn:    mockParseValue,
+
+			// the entries of prefix two are after the key
+			expectedValues: "1",
+		},
+		"adjacent prefixes out of order, prefix two requested": {
+			preSetKeys: oneBtwoAoneAtwoB,
+			prefix:     []byte(prefixTwo),
+			key:        []byte(prefixTwo + keyB),
+			parseFn:    mockParseValue,
+
+			expectedValues: "3",
+		},
+		"empty prefix": {
+			preSetKeys: oneABtwoAB,
+			prefix:     []byte{},
+			key:        []byte(prefixTwo),
+			parseFn:    mockParseValue,
+
+			expectedValues: "1",
+		},
+
+		// error catching
+		"empty store": {
+			preSetKeys: []string{},
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + keyA),
+			parseFn:    mockParseValue,
+
+			expectedErr:    errors.New("No values in range"),
+			expectedValues: "",
+		},
+		"key before first entry": {
+			preSetKeys: oneABC,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + "0"),
+			parseFn:    mockParseValue,
+
+			expectedErr:    errors.New("No values in range"),
+			expectedValues: "",
+		},
+		"key equal to prefix": {
+			preSetKeys: oneABC,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne),
+			parseFn:    mockParseValue,
+
+			expectedErr:    errors.New("No values in range"),
+			expectedValues: "",
+		},
+		"adjacent prefixes, key before first entry of prefix two": {
+			preSetKeys: oneABtwoAB,
+			prefix:     []byte(prefixTwo),
+			key:        []byte(prefixTwo + "0"),
+			parseFn:    mockParseValue,
+
+			// the entries of prefix one must not be returned
+			expectedErr:    errors.New("No values in range"),
+			expectedValues: "",
+		},
+		"key does not start with prefix": {
+			preSetKeys: oneABtwoAB,
+			prefix:     []byte(prefixTwo),
+			key:        []byte(prefixOne + keyB),
+			parseFn:    mockParseValue,
+
+			expectedErr:    errors.New("does not start with prefix"),
+			expectedValues: "",
+		},
+		"parse with error": {
+			preSetKeys: oneABC,
+			prefix:     []byte(prefixOne),
+			key:        []byte(prefixOne + keyC),
+			parseFn:    mockParseValueWithError,
+
+			expectedErr:    errors.New("mock error"),
+			expectedValues: "",
+		},
+	}
+
+	for name, tc := range testcases {
+		s.Run(name, func() {
+			s.SetupStoreWithBasePrefix()
+
+			for i, key := range tc.preSetKeys {
+				s.store.Set([]byte(key), []byte(fmt.Sprintf("%v", i)))
+			}
+
+			actualValues, err := osmoutils.GetLastValueBeforeOrAtKey(s.store, tc.prefix, tc.key, tc.parseFn)
+
+			if tc.expectedErr != nil {
+				s.Require().ErrorContains(err, tc.expectedErr.Error())
+				s.Require().Equal(tc.expectedValues, actualValues)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedValues, actualValues)
+		})
+	}
+}
+
 func (s *TestSuite) TestGatherValuesFromIterator() {
 	testcases := map[string]struct {
 		// if prefix is set, startValue and endValue are ignored.
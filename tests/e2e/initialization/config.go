@@ -382,6 +382,8 @@ func updateEpochGenesis(epochGenState *epochtypes.GenesisState) {
 func updateTWAPGenesis(twapGenState *twaptypes.GenesisState) {
 	// Lower keep period from defaults to allos us to test pruning.
 	twapGenState.Params.RecordHistoryKeepPeriod = time.Second * 15
+	// The spot deviation alert window can't exceed the keep period.
+	twapGenState.Params.SpotDeviationAlertWindow = time.Second * 15
 }
 
 func updateCrisisGenesis(crisisGenState *crisistypes.GenesisState) {
@@ -21,6 +21,13 @@ type OsmosisQuery struct {
 	EstimateSwap *EstimateSwap `json:"estimate_swap,omitempty"`
 	/// Returns the admin of a denom, if the denom is a Token Factory denom.
 	DenomAdmin *DenomAdmin `json:"denom_admin,omitempty"`
+	/// Returns whether the given contract is currently being executed by an IBC hook on behalf of sender.
+	/// Contracts can use this to verify that the call they are handling (with sender = info.sender)
+	/// originated from a packet.
+	IsInHookExecution *IsInHookExecution `json:"is_in_hook_execution,omitempty"`
+	/// Returns the time weighted average price of the base asset in units of the quote asset of a pool.
+	/// Unlike the spot price, it can be used as a price oracle.
+	Twap *Twap `json:"twap,omitempty"`
 }
 
 type FullDenom struct {
@@ -36,6 +43,15 @@ type DenomAdminResponse struct {
 	Admin string `json:"admin"`
 }
 
+type IsInHookExecution struct {
+	Contract string `json:"contract"`
+	Sender   string `json:"sender"`
+}
+
+type IsInHookExecutionResponse struct {
+	InHookExecution bool `json:"in_hook_execution"`
+}
+
 type PoolState struct {
 	PoolId uint64 `json:"id"`
 }
@@ -70,6 +86,28 @@ type ArithmeticTwapToNow struct {
 	StartTime int64 `json:"start_time"`
 }
 
+// Twap types
+const (
+	TwapTypeArithmetic = "arithmetic"
+	TwapTypeGeometric  = "geometric"
+)
+
+type Twap struct {
+	PoolId     uint64 `json:"pool_id"`
+	BaseAsset  string `json:"base_asset"`
+	QuoteAsset string `json:"quote_asset"`
+	// NOTE: StartTime is expected to be in Unix time milliseconds.
+	StartTime int64 `json:"start_time"`
+	// NOTE: EndTime is expected to be in Unix time milliseconds. If it is not set, the TWAP ends at the block time.
+	EndTime *int64 `json:"end_time,omitempty"`
+	// TwapType is either "arithmetic" or "geometric". It is arithmetic if not set.
+	TwapType string `json:"twap_type,omitempty"`
+}
+
+type TwapResponse struct {
+	Twap string `json:"twap"`
+}
+
 func (e *EstimateSwap) ToSwapMsg() *SwapMsg {
 	return &SwapMsg{
 		First:  e.First,
@@ -11,6 +11,7 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/wasmbinding/bindings"
 	gammkeeper "github.com/osmosis-labs/osmosis/v13/x/gamm/keeper"
 	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
+	ibchookskeeper "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
 	tokenfactorykeeper "github.com/osmosis-labs/osmosis/v13/x/tokenfactory/keeper"
 	twapkeeper "github.com/osmosis-labs/osmosis/v13/x/twap"
 )
@@ -19,14 +20,16 @@ type QueryPlugin struct {
 	gammKeeper         *gammkeeper.Keeper
 	twapKeeper         *twapkeeper.Keeper
 	tokenFactoryKeeper *tokenfactorykeeper.Keeper
+	ibcHooksKeeper     *ibchookskeeper.Keeper
 }
 
 // NewQueryPlugin returns a reference to a new QueryPlugin.
-func NewQueryPlugin(gk *gammkeeper.Keeper, tk *twapkeeper.Keeper, tfk *tokenfactorykeeper.Keeper) *QueryPlugin {
+func NewQueryPlugin(gk *gammkeeper.Keeper, tk *twapkeeper.Keeper, tfk *tokenfactorykeeper.Keeper, ihk *ibchookskeeper.Keeper) *QueryPlugin {
 	return &QueryPlugin{
 		gammKeeper:         gk,
 		twapKeeper:         tk,
 		tokenFactoryKeeper: tfk,
+		ibcHooksKeeper:     ihk,
 	}
 }
 
@@ -144,3 +147,59 @@ func (qp QueryPlugin) ArithmeticTwapToNow(ctx sdk.Context, arithmeticTwap *bindi
 
 	return &twap, nil
 }
+
+// Twap returns the TWAP of the twap query, as the TWAP queries of the twap module would.
+func (qp QueryPlugin) Twap(ctx sdk.Context, twap *bindings.Twap) (*sdk.Dec, error) {
+	if twap == nil {
+		return nil, wasmvmtypes.InvalidRequest{Err: "twap null"}
+	}
+
+	startTime := time.UnixMilli(twap.StartTime)
+	switch twap.TwapType {
+	case "", bindings.TwapTypeArithmetic:
+		if twap.EndTime == nil {
+			return qp.ArithmeticTwapToNow(ctx, &bindings.ArithmeticTwapToNow{
+				PoolId:          twap.PoolId,
+				QuoteAssetDenom: twap.QuoteAsset,
+				BaseAssetDenom:  twap.BaseAsset,
+				StartTime:       twap.StartTime,
+			})
+		}
+		return qp.ArithmeticTwap(ctx, &bindings.ArithmeticTwap{
+			PoolId:          twap.PoolId,
+			QuoteAssetDenom: twap.QuoteAsset,
+			BaseAssetDenom:  twap.BaseAsset,
+			StartTime:       twap.StartTime,
+			EndTime:         *twap.EndTime,
+		})
+	case bindings.TwapTypeGeometric:
+		var geometricTwap sdk.Dec
+		var err error
+		if twap.EndTime == nil {
+			geometricTwap, err = qp.twapKeeper.GetGeometricTwapToNow(ctx, twap.PoolId, twap.BaseAsset, twap.QuoteAsset, startTime)
+		} else {
+			geometricTwap, err = qp.twapKeeper.GetGeometricTwap(ctx, twap.PoolId, twap.BaseAsset, twap.QuoteAsset, startTime, time.UnixMilli(*twap.EndTime))
+		}
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "gamm geometric twap")
+		}
+		return &geometricTwap, nil
+	default:
+		return nil, wasmvmtypes.InvalidRequest{Err: fmt.Sprintf("unknown twap type %s", twap.TwapType)}
+	}
+}
+
+// IsInHookExecution returns whether the contract is currently being executed by an ibc hook on behalf of the sender.
+func (qp QueryPlugin) IsInHookExecution(ctx sdk.Context, isInHookExecution *bindings.IsInHookExecution) (bool, error) {
+	if isInHookExecution == nil {
+		return false, wasmvmtypes.InvalidRequest{Err: "is in hook execution null"}
+	}
+	if _, err := sdk.AccAddressFromBech32(isInHookExecution.Contract); err != nil {
+		return false, sdkerrors.Wrap(err, "is in hook execution contract address")
+	}
+	if _, err := sdk.AccAddressFromBech32(isInHookExecution.Sender); err != nil {
+		return false, sdkerrors.Wrap(err, "is in hook execution sender address")
+	}
+
+	return qp.ibcHooksKeeper.IsInHookExecution(ctx, isInHookExecution.Contract, isInHookExecution.Sender), nil
+}
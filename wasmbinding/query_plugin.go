@@ -137,6 +137,34 @@ func CustomQuerier(qp *QueryPlugin) func(ctx sdk.Context, request json.RawMessag
 
 			return bz, nil
 
+		case contractQuery.IsInHookExecution != nil:
+			inHookExecution, err := qp.IsInHookExecution(ctx, contractQuery.IsInHookExecution)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "ibc hooks is in hook execution query")
+			}
+
+			res := bindings.IsInHookExecutionResponse{InHookExecution: inHookExecution}
+			bz, err := json.Marshal(res)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "ibc hooks is in hook execution query response")
+			}
+
+			return bz, nil
+
+		case contractQuery.Twap != nil:
+			twap, err := qp.Twap(ctx, contractQuery.Twap)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "osmo twap query")
+			}
+
+			res := bindings.TwapResponse{Twap: twap.String()}
+			bz, err := json.Marshal(res)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "osmo twap query response")
+			}
+
+			return bz, nil
+
 		default:
 			return nil, wasmvmtypes.UnsupportedRequest{Kind: "unknown osmosis query variant"}
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/app"
 	epochtypes "github.com/osmosis-labs/osmosis/v13/x/epochs/types"
 	lockuptypes "github.com/osmosis-labs/osmosis/v13/x/lockup/types"
+	twapquerytypes "github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
 
 	"github.com/osmosis-labs/osmosis/v13/wasmbinding"
 )
@@ -98,6 +99,35 @@ func (suite *StargateTestSuite) TestStargateQuerier() {
 				SpotPrice: sdk.NewDecWithPrec(5, 1).String(),
 			},
 		},
+		{
+			name: "happy path twap",
+			path: "/osmosis.twap.v1beta1.Query/GeometricTwapToNow",
+			testSetup: func() {
+				pk := ed25519.GenPrivKey().PubKey()
+				sender := sdk.AccAddress(pk.Address())
+				err := simapp.FundAccount(suite.app.BankKeeper, suite.ctx, sender, apptesting.DefaultAcctFunds)
+				suite.Require().NoError(err)
+				msg := balancer.NewMsgCreateBalancerPool(sender,
+					balancer.NewPoolParams(sdk.ZeroDec(), sdk.ZeroDec(), nil),
+					apptesting.DefaultPoolAssets, "")
+				_, err = suite.app.SwapRouterKeeper.CreatePool(suite.ctx, msg)
+				suite.NoError(err)
+				// the twap records of the pool are written at its creation
+				suite.ctx = suite.ctx.WithBlockTime(suite.ctx.BlockTime().Add(time.Minute))
+			},
+			requestData: func() []byte {
+				queryrequest := twapquerytypes.GeometricTwapToNowRequest{
+					PoolId:     1,
+					BaseAsset:  "bar",
+					QuoteAsset: "uosmo",
+					StartTime:  suite.ctx.BlockTime().Add(-30 * time.Second),
+				}
+				bz, err := proto.Marshal(&queryrequest)
+				suite.Require().NoError(err)
+				return bz
+			},
+			responseProtoStruct: &twapquerytypes.GeometricTwapToNowResponse{},
+		},
 		{
 			name: "unregistered path(not whitelisted)",
 			path: "/osmosis.lockup.Query/AccountLockedLongerDuration",
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -257,6 +259,135 @@ func TestQueryEstimateSwap(t *testing.T) {
 	require.InEpsilonf(t, expected, cost, epsilon, fmt.Sprintf("Outside of tolerance (%f)", epsilon))
 }
 
+func TestQueryTwap(t *testing.T) {
+	actor := RandomAccountAddress()
+	osmosis, ctx := SetupCustomApp(t, actor)
+	epsilon := 1e-6
+
+	fundAccount(t, ctx, osmosis, actor, defaultFunds)
+
+	poolFunds := []sdk.Coin{
+		sdk.NewInt64Coin("uosmo", 12000000),
+		sdk.NewInt64Coin("ustar", 240000000),
+	}
+	// 20 star to 1 osmo
+	starPool := preparePool(t, ctx, osmosis, actor, poolFunds)
+	poolCreationTime := ctx.BlockTime()
+
+	reflect := instantiateReflectContract(t, ctx, osmosis, actor)
+	require.NotEmpty(t, reflect)
+
+	ctx = ctx.WithBlockTime(poolCreationTime.Add(time.Minute))
+	// the window starts after the record of the pool creation, which is more precise than milliseconds
+	startTime := poolCreationTime.UnixMilli() + 1
+	endTime := poolCreationTime.Add(30 * time.Second).UnixMilli()
+
+	// query the arithmetic twap to now, the default twap type
+	query := bindings.OsmosisQuery{
+		Twap: &bindings.Twap{
+			PoolId:     starPool,
+			BaseAsset:  "uosmo",
+			QuoteAsset: "ustar",
+			StartTime:  startTime,
+		},
+	}
+	skipUnlessReflectHandles(t, ctx, osmosis, reflect, query)
+	resp := bindings.TwapResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+
+	expected, err := osmosis.TwapKeeper.GetArithmeticTwapToNow(ctx, starPool, "uosmo", "ustar", time.UnixMilli(startTime))
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resp.Twap)
+
+	twap, err := strconv.ParseFloat(resp.Twap, 64)
+	require.NoError(t, err)
+	require.InEpsilonf(t, 20., twap, epsilon, fmt.Sprintf("Outside of tolerance (%f)", epsilon))
+
+	// and the arithmetic twap of a window in the past
+	query.Twap.EndTime = &endTime
+	resp = bindings.TwapResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+
+	expected, err = osmosis.TwapKeeper.GetArithmeticTwap(ctx, starPool, "uosmo", "ustar", time.UnixMilli(startTime), time.UnixMilli(endTime))
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resp.Twap)
+
+	// and the geometric twaps of the reverse conversion
+	query = bindings.OsmosisQuery{
+		Twap: &bindings.Twap{
+			PoolId:     starPool,
+			BaseAsset:  "ustar",
+			QuoteAsset: "uosmo",
+			StartTime:  startTime,
+			TwapType:   bindings.TwapTypeGeometric,
+		},
+	}
+	resp = bindings.TwapResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+
+	expected, err = osmosis.TwapKeeper.GetGeometricTwapToNow(ctx, starPool, "ustar", "uosmo", time.UnixMilli(startTime))
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resp.Twap)
+
+	twap, err = strconv.ParseFloat(resp.Twap, 64)
+	require.NoError(t, err)
+	require.InEpsilonf(t, 1./20., twap, epsilon, fmt.Sprintf("Outside of tolerance (%f)", epsilon))
+
+	query.Twap.EndTime = &endTime
+	resp = bindings.TwapResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+
+	expected, err = osmosis.TwapKeeper.GetGeometricTwap(ctx, starPool, "ustar", "uosmo", time.UnixMilli(startTime), time.UnixMilli(endTime))
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resp.Twap)
+}
+
+func TestQueryIsInHookExecution(t *testing.T) {
+	actor := RandomAccountAddress()
+	osmosis, ctx := SetupCustomApp(t, actor)
+
+	reflect := instantiateReflectContract(t, ctx, osmosis, actor)
+	require.NotEmpty(t, reflect)
+
+	hookSender := RandomAccountAddress()
+	query := bindings.OsmosisQuery{
+		IsInHookExecution: &bindings.IsInHookExecution{Contract: reflect.String(), Sender: hookSender.String()},
+	}
+	skipUnlessReflectHandles(t, ctx, osmosis, reflect, query)
+
+	// A direct call is not a hook execution
+	resp := bindings.IsInHookExecutionResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+	require.False(t, resp.InHookExecution)
+
+	// While the hook is executing the contract, the flag is visible to it
+	osmosis.IBCHooksKeeper.SetHookExecution(ctx, reflect.String(), hookSender.String())
+	resp = bindings.IsInHookExecutionResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+	require.True(t, resp.InHookExecution)
+
+	// Calls from other senders during the hook are not hook executions
+	resp = bindings.IsInHookExecutionResponse{}
+	queryCustom(t, ctx, osmosis, reflect, bindings.OsmosisQuery{
+		IsInHookExecution: &bindings.IsInHookExecution{Contract: reflect.String(), Sender: actor.String()},
+	}, &resp)
+	require.False(t, resp.InHookExecution)
+
+	// Other contracts are not affected by the flag
+	other := instantiateReflectContract(t, ctx, osmosis, actor)
+	resp = bindings.IsInHookExecutionResponse{}
+	queryCustom(t, ctx, osmosis, other, bindings.OsmosisQuery{
+		IsInHookExecution: &bindings.IsInHookExecution{Contract: other.String(), Sender: hookSender.String()},
+	}, &resp)
+	require.False(t, resp.InHookExecution)
+
+	// Once the execution finishes, the flag is cleared
+	osmosis.IBCHooksKeeper.ClearHookExecution(ctx, reflect.String(), hookSender.String())
+	resp = bindings.IsInHookExecutionResponse{}
+	queryCustom(t, ctx, osmosis, reflect, query, &resp)
+	require.False(t, resp.InHookExecution)
+}
+
 type ReflectQuery struct {
 	Chain *ChainRequest `json:"chain,omitempty"`
 }
@@ -270,18 +401,7 @@ type ChainResponse struct {
 }
 
 func queryCustom(t *testing.T, ctx sdk.Context, osmosis *app.OsmosisApp, contract sdk.AccAddress, request bindings.OsmosisQuery, response interface{}) {
-	msgBz, err := json.Marshal(request)
-	require.NoError(t, err)
-
-	query := ReflectQuery{
-		Chain: &ChainRequest{
-			Request: wasmvmtypes.QueryRequest{Custom: msgBz},
-		},
-	}
-	queryBz, err := json.Marshal(query)
-	require.NoError(t, err)
-
-	resBz, err := osmosis.WasmKeeper.QuerySmart(ctx, contract, queryBz)
+	resBz, err := osmosis.WasmKeeper.QuerySmart(ctx, contract, reflectQueryBz(t, request))
 	require.NoError(t, err)
 	var resp ChainResponse
 	err = json.Unmarshal(resBz, &resp)
@@ -356,3 +476,28 @@ func preparePool(t *testing.T, ctx sdk.Context, osmosis *app.OsmosisApp, addr sd
 	require.NoError(t, err)
 	return poolId
 }
+
+// reflectQueryBz returns the query of the reflect contract that makes it run request as a custom chain query
+func reflectQueryBz(t *testing.T, request bindings.OsmosisQuery) []byte {
+	msgBz, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	query := ReflectQuery{
+		Chain: &ChainRequest{
+			Request: wasmvmtypes.QueryRequest{Custom: msgBz},
+		},
+	}
+	queryBz, err := json.Marshal(query)
+	require.NoError(t, err)
+	return queryBz
+}
+
+// skipUnlessReflectHandles skips the test if the reflect contract doesn't know the variant of request. The checked in
+// testdata/osmo_reflect.wasm is the osmosis-bindings release of version.txt, and has to be replaced by a release whose
+// query msg has the variants of the newer custom queries for the tests using them to run.
+func skipUnlessReflectHandles(t *testing.T, ctx sdk.Context, osmosis *app.OsmosisApp, contract sdk.AccAddress, request bindings.OsmosisQuery) {
+	_, err := osmosis.WasmKeeper.QuerySmart(ctx, contract, reflectQueryBz(t, request))
+	if err != nil && strings.Contains(err.Error(), "unknown variant") {
+		t.Skipf("the reflect contract doesn't handle the query: %s", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 
 	gammkeeper "github.com/osmosis-labs/osmosis/v13/x/gamm/keeper"
+	ibchookskeeper "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
 	tokenfactorykeeper "github.com/osmosis-labs/osmosis/v13/x/tokenfactory/keeper"
 	twap "github.com/osmosis-labs/osmosis/v13/x/twap"
 )
@@ -19,8 +20,9 @@ func RegisterCustomPlugins(
 	bank *bankkeeper.BaseKeeper,
 	twap *twap.Keeper,
 	tokenFactory *tokenfactorykeeper.Keeper,
+	ibcHooks *ibchookskeeper.Keeper,
 ) []wasmkeeper.Option {
-	wasmQueryPlugin := NewQueryPlugin(gammKeeper, twap, tokenFactory)
+	wasmQueryPlugin := NewQueryPlugin(gammKeeper, twap, tokenFactory, ibcHooks)
 
 	queryPluginOpt := wasmkeeper.WithQueryPlugins(&wasmkeeper.QueryPlugins{
 		Custom: CustomQuerier(wasmQueryPlugin),
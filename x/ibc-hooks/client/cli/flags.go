@@ -0,0 +1,25 @@
+package cli
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	FlagChannel       = "channel"
+	FlagReceiver      = "receiver"
+	FlagExecuteDryRun = "execute-dry-run"
+)
+
+func FlagSetChannel() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(FlagChannel, "", "Only return the callbacks of packets sent on this channel, e.g. channel-0")
+	return fs
+}
+
+func FlagSetSimulateMemo() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Bool(FlagExecuteDryRun, false, "Receive the packet and execute its hook without committing anything, and return the ack it would get")
+	fs.String(FlagChannel, "", "Destination channel of the packet, from which the intermediate sender is derived. Required with --execute-dry-run")
+	fs.String(FlagReceiver, "", "Receiver of the packet. Defaults to the contract of the memo")
+	return fs
+}
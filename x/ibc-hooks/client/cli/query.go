@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils/osmocli"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd() *cobra.Command {
+	cmd := osmocli.QueryIndexCmd(types.ModuleName)
+
+	osmocli.AddQueryCmd(cmd, types.NewQueryClient, GetCmdPacketCallbacks)
+	osmocli.AddQueryCmd(cmd, types.NewQueryClient, GetCmdChannelHookStats)
+	osmocli.AddQueryCmd(cmd, types.NewQueryClient, GetCmdAckSubscriptions)
+	osmocli.AddQueryCmd(cmd, types.NewQueryClient, GetCmdPrivilegedContracts)
+	cmd.AddCommand(GetCmdSimulateMemo())
+
+	return cmd
+}
+
+func GetCmdPacketCallbacks() (*osmocli.QueryDescriptor, *types.QueryPacketCallbacksRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "packet-callbacks",
+		Short: "Query the ack callbacks that are still pending, with the height and time they were registered at",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} packet-callbacks --channel=channel-0`,
+		CustomFlagOverrides: map[string]string{
+			"channel": FlagChannel,
+		},
+		Flags: osmocli.FlagDesc{OptionalFlags: []*flag.FlagSet{FlagSetChannel()}},
+	}, &types.QueryPacketCallbacksRequest{}
+}
+
+func GetCmdChannelHookStats() (*osmocli.QueryDescriptor, *types.QueryChannelHookStatsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "channel-hook-stats [channel]",
+		Short: "Query the hooked packet counters of a destination channel, for the current and the recent stats epochs",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} channel-hook-stats channel-0`,
+	}, &types.QueryChannelHookStatsRequest{}
+}
+
+func GetCmdAckSubscriptions() (*osmocli.QueryDescriptor, *types.QueryAckSubscriptionsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "ack-subscriptions [channel]",
+		Short: "Query the contracts subscribed to the acks of all the packets sent on a channel",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} ack-subscriptions channel-0`,
+	}, &types.QueryAckSubscriptionsRequest{}
+}
+
+func GetCmdPrivilegedContracts() (*osmocli.QueryDescriptor, *types.QueryPrivilegedContractsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "privileged-contracts",
+		Short: "Query the privileged contracts, which are exempt from some of the limits of the hooked packets",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} privileged-contracts`,
+	}, &types.QueryPrivilegedContractsRequest{}
+}
+
+// GetCmdSimulateMemo validates a hook memo, and optionally executes the hook without committing anything.
+// The memo is read from a file, as it is usually too long to be passed as an argument.
+func GetCmdSimulateMemo() *cobra.Command {
+	short := "Validate the memo of a hooked packet, and with --execute-dry-run simulate executing its hook"
+	cmd := &cobra.Command{
+		Use:   "simulate-memo [memo.json] [amount] [sender]",
+		Short: short,
+		Long: osmocli.FormatLongDesc(`{{.Short}}
+The amount is in the denom of the packet as it is on the sender chain, and the sender is the sender of the packet
+on that chain. Nothing is committed: the simulated execution is discarded along with its state changes.{{.ExampleHeader}}
+{{.CommandPrefix}} simulate-memo memo.json 1000uatom cosmos1... --execute-dry-run --channel=channel-0`,
+			osmocli.NewLongMetadata(types.ModuleName).WithShort(short)),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			memo, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return err
+			}
+			execute, err := cmd.Flags().GetBool(FlagExecuteDryRun)
+			if err != nil {
+				return err
+			}
+			channel, err := cmd.Flags().GetString(FlagChannel)
+			if err != nil {
+				return err
+			}
+			receiver, err := cmd.Flags().GetString(FlagReceiver)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.SimulateHook(cmd.Context(), &types.QuerySimulateHookRequest{
+				Memo:     string(memo),
+				Denom:    amount.Denom,
+				Amount:   amount.Amount.String(),
+				Sender:   args[2],
+				Receiver: receiver,
+				Channel:  channel,
+				Execute:  execute,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().AddFlagSet(FlagSetSimulateMemo())
+	return cmd
+}
@@ -0,0 +1,261 @@
+package ibc_hooks_test
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibctesting "github.com/cosmos/ibc-go/v3/testing"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/osmosis-labs/osmosis/v13/app"
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/testutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// hookStep delivers a hooked packet, or the ack of one, to an app instance and returns the resulting ack, if any
+type hookStep func(osmosisApp *app.OsmosisApp, ctx sdk.Context) []byte
+
+// recvStep receives packet on chain A's transfer stack the way IBC core does: the state changes of a packet
+// whose ack is an error are discarded.
+func (suite *HooksTestSuite) recvStep(packet channeltypes.Packet) hookStep {
+	return func(osmosisApp *app.OsmosisApp, ctx sdk.Context) []byte {
+		cacheCtx, writeFn := ctx.CacheContext()
+		ack := osmosisApp.TransferStack.OnRecvPacket(cacheCtx, packet, suite.chainB.SenderAccount.GetAddress())
+		suite.Require().NotNil(ack)
+		if ack.Success() {
+			writeFn()
+		}
+		return ack.Acknowledgement()
+	}
+}
+
+// ackStep acknowledges packet, sent by chain A, with ack
+func (suite *HooksTestSuite) ackStep(packet channeltypes.Packet, ack []byte) hookStep {
+	return func(osmosisApp *app.OsmosisApp, ctx sdk.Context) []byte {
+		err := osmosisApp.TransferStack.OnAcknowledgementPacket(ctx, packet, ack, suite.chainB.SenderAccount.GetAddress())
+		suite.Require().NoError(err)
+		return nil
+	}
+}
+
+// runBlock runs a block with the given header on osmosisApp, delivering the steps in order, and commits it.
+// Returns the acks of the steps.
+func runBlock(osmosisApp *app.OsmosisApp, header tmproto.Header, steps []hookStep) [][]byte {
+	osmosisApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := osmosisApp.NewContext(false, header)
+	acks := [][]byte{}
+	for _, step := range steps {
+		acks = append(acks, step(osmosisApp, ctx))
+	}
+	osmosisApp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	osmosisApp.Commit()
+	return acks
+}
+
+// exportAndRestart finishes chain A's current block and starts a second app from its export. Returns chain A's app
+// and the restarted one.
+func (suite *HooksTestSuite) exportAndRestart() (fresh, restarted *app.OsmosisApp) {
+	fresh = suite.chainA.GetOsmosisApp()
+	fresh.EndBlock(abci.RequestEndBlock{Height: suite.chainA.CurrentHeader.Height})
+	fresh.Commit()
+	exported, err := fresh.ExportAppStateAndValidators(false, nil, nil)
+	suite.Require().NoError(err)
+
+	restarted = app.Setup(true)
+	restarted.InitChain(abci.RequestInitChain{
+		Time:            suite.chainA.CurrentHeader.Time,
+		ChainId:         suite.chainA.ChainID,
+		ConsensusParams: exported.ConsensusParams,
+		AppStateBytes:   exported.AppState,
+		InitialHeight:   exported.Height,
+	})
+	return fresh, restarted
+}
+
+// perAppStep runs the setup of each app, for the inputs that only differ between apps in their order
+func perAppStep(setups map[*app.OsmosisApp]func(osmosisApp *app.OsmosisApp, ctx sdk.Context)) hookStep {
+	return func(osmosisApp *app.OsmosisApp, ctx sdk.Context) []byte {
+		setups[osmosisApp](osmosisApp, ctx)
+		return nil
+	}
+}
+
+// TestImportExportDeterminism processes the same hooked packets on chain A and on an app restarted from an
+// export of chain A, and checks that both end up with the same state.
+func (suite *HooksTestSuite) TestImportExportDeterminism() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	incrementMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}}}}`, addr)
+	failingMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"not_a_method":{}}}}`, addr)
+	callbackMemo := fmt.Sprintf(`{"ibc_callback":"%s"}`, addr)
+
+	// Before the export: executed hooks, failed hooks, an acked callback, and callbacks still waiting for their ack
+	suite.Require().Contains(string(suite.receivePacketWithSequence(addr.String(), incrementMemo, 0)), "result")
+	suite.Require().Contains(string(suite.receivePacketWithSequence(addr.String(), failingMemo, 1)), "error")
+
+	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
+	_, _, _, err := suite.FullSend(transferMsg, AtoB)
+	suite.Require().NoError(err)
+
+	pendingPackets := []channeltypes.Packet{}
+	for i := 0; i < 2; i++ {
+		sendResult, err := suite.chainA.SendMsgsNoCheck(transferMsg)
+		suite.Require().NoError(err)
+		packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+		suite.Require().NoError(err)
+		pendingPackets = append(pendingPackets, packet)
+	}
+
+	// Finish chain A's current block, and restart a second app from its export
+	fresh, restarted := suite.exportAndRestart()
+
+	// As with a node started from a genesis file, the imported state is committed with the first block
+	importCtx := restarted.NewContext(false, tmproto.Header{Height: fresh.LastBlockHeight() + 1})
+	for _, packet := range pendingPackets {
+		_, found := restarted.IBCHooksKeeper.GetPacketCallbackInfo(importCtx, packet.GetSourceChannel(), packet.GetSequence())
+		suite.Require().True(found)
+	}
+
+	// After the export: the same blocks on both apps
+	blocks := [][]hookStep{
+		{
+			suite.recvStep(suite.makeMockPacket(addr.String(), incrementMemo, 100)),
+			suite.recvStep(suite.makeMockPacket(addr.String(), failingMemo, 101)),
+		},
+		{
+			suite.ackStep(pendingPackets[0], channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()),
+			suite.ackStep(pendingPackets[1], channeltypes.NewErrorAcknowledgement("failed").Acknowledgement()),
+			suite.recvStep(suite.makeMockPacket(addr.String(), incrementMemo, 102)),
+		},
+	}
+	header := suite.chainA.CurrentHeader
+	header.AppHash = nil
+	for i, steps := range blocks {
+		header.Height = fresh.LastBlockHeight() + 1
+		header.Time = header.Time.Add(5 * time.Second)
+
+		freshAcks := runBlock(fresh, header, steps)
+		restartedAcks := runBlock(restarted, header, steps)
+		suite.Require().Equal(freshAcks, restartedAcks, "acks of block %d", i)
+	}
+
+	// Both callbacks were delivered on both apps
+	query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr))
+	for _, osmosisApp := range []*app.OsmosisApp{fresh, restarted} {
+		ctx := osmosisApp.NewContext(true, tmproto.Header{Height: osmosisApp.LastBlockHeight(), Time: header.Time})
+		state, err := osmosisApp.WasmKeeper.QuerySmart(ctx, addr, query)
+		suite.Require().NoError(err)
+		suite.Require().Equal(`{"count":3}`, string(state))
+		suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(ctx, ""))
+	}
+
+	// The IAVL node hashes include the version at which each node was written, which differs between a chain
+	// and one imported from its export, so the app hashes can't match. The state itself is compared instead.
+	freshState := fresh.ExportState(fresh.NewContext(true, tmproto.Header{Height: fresh.LastBlockHeight()}))
+	restartedState := restarted.ExportState(restarted.NewContext(true, tmproto.Header{Height: restarted.LastBlockHeight()}))
+	for _, module := range []string{banktypes.ModuleName, wasmtypes.ModuleName, types.ModuleName, transfertypes.ModuleName} {
+		suite.Require().JSONEq(string(freshState[module]), string(restartedState[module]), "state of module %s", module)
+	}
+}
+
+// TestErrorAckDeterminism fails the same hooked packets, in the ways whose error comes from another module, on two
+// app instances whose params and privileged contracts were set from inputs in a different order, and checks that
+// both write byte-identical error acks holding none of the text of those errors.
+func (suite *HooksTestSuite) TestErrorAckDeterminism() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	incrementMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}}}}`, addr)
+	failingMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"not_a_method":{}}}}`, addr)
+	badMsgMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":"increment"}}`, addr)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+
+	fresh, restarted := suite.exportAndRestart()
+
+	// The same params and privileged contracts on both apps, with their lists in a different order
+	privileged := []string{authtypes.NewModuleAddress("privileged-a").String(), authtypes.NewModuleAddress("privileged-b").String()}
+	setup := func(allowedHookDenoms []string, maxHookGas uint64) hookStep {
+		setupApp := func(reverse bool) func(osmosisApp *app.OsmosisApp, ctx sdk.Context) {
+			return func(osmosisApp *app.OsmosisApp, ctx sdk.Context) {
+				denoms := append([]string{}, allowedHookDenoms...)
+				contracts := append([]string{}, privileged...)
+				if reverse {
+					sort.Sort(sort.Reverse(sort.StringSlice(denoms)))
+					sort.Sort(sort.Reverse(sort.StringSlice(contracts)))
+				}
+				params := types.DefaultParams()
+				params.AllowedHookDenoms = denoms
+				params.HookFeesEnabled = true
+				params.HookFeeDenoms = denoms
+				params.MaxHookGas = maxHookGas
+				osmosisApp.IBCHooksKeeper.SetParams(ctx, params)
+				for _, contract := range contracts {
+					if !osmosisApp.IBCHooksKeeper.IsPrivilegedContract(ctx, contract) {
+						suite.Require().NoError(osmosisApp.IBCHooksKeeper.AddPrivilegedContract(ctx, contract))
+					}
+				}
+			}
+		}
+		return perAppStep(map[*app.OsmosisApp]func(osmosisApp *app.OsmosisApp, ctx sdk.Context){
+			fresh:     setupApp(false),
+			restarted: setupApp(true),
+		})
+	}
+
+	blocks := [][]hookStep{
+		{
+			setup([]string{"uion", localDenom, "uosmo"}, 0),
+			suite.recvStep(suite.makeMockPacket(addr.String(), failingMemo, 200)),
+			suite.recvStep(suite.makeMockPacket(addr.String(), badMsgMemo, 201)),
+			suite.recvStep(suite.makeMockPacketWithAmount(addr.String(), incrementMemo, 202, "0")),
+		},
+		{
+			setup([]string{"uion", localDenom, "uosmo"}, 1),
+			suite.recvStep(suite.makeMockPacket(addr.String(), incrementMemo, 203)),
+		},
+		{
+			setup([]string{"uion", "uosmo"}, 0),
+			suite.recvStep(suite.makeMockPacket(addr.String(), incrementMemo, 204)),
+		},
+	}
+	header := suite.chainA.CurrentHeader
+	header.AppHash = nil
+	acks := [][]byte{}
+	for i, steps := range blocks {
+		header.Height = fresh.LastBlockHeight() + 1
+		header.Time = header.Time.Add(5 * time.Second)
+
+		freshAcks := runBlock(fresh, header, steps)
+		restartedAcks := runBlock(restarted, header, steps)
+		suite.Require().Equal(freshAcks, restartedAcks, "acks of block %d", i)
+		for _, ack := range freshAcks {
+			if ack != nil {
+				acks = append(acks, ack)
+			}
+		}
+	}
+
+	// Each ack only holds the failure's code and fixed message, or a message built from the packet and the params
+	suite.Require().Len(acks, 5)
+	expected := []string{
+		wasmtypes.ErrExecuteFailed.Error(),
+		`wasm["msg"] is not a map object`,
+		types.ErrorAckMessage(types.FailureTransfer, nil),
+		fmt.Sprintf(types.ErrHookOutOfGas, 1),
+		fmt.Sprintf(types.ErrDenomNotAllowed, localDenom),
+	}
+	for i, ack := range acks {
+		testutils.RequireErrorAck(suite.T(), ack, expected[i])
+	}
+	// the wasmvm error of the failed execution quotes the msg of the memo
+	suite.Require().NotContains(string(acks[0]), "not_a_method")
+	suite.Require().Equal(channeltypes.NewErrorAcknowledgement(types.ErrorAckMessage(types.FailureTransfer, nil)).Acknowledgement(), acks[2])
+}
@@ -3,6 +3,7 @@ package ibc_hooks
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
 
@@ -11,7 +12,12 @@ import (
 
 var WasmHookModuleAccountAddr sdk.AccAddress = address.Module(types.ModuleName, []byte("wasm-hook intermediary account"))
 
+// IbcHooksInitGenesis creates the module account of the wasm hooks, unless it was imported with the accounts of an
+// exported chain.
 func IbcHooksInitGenesis(ctx sdk.Context, ak osmoutils.AccountKeeper) {
+	if _, ok := ak.GetAccount(ctx, WasmHookModuleAccountAddr).(authtypes.ModuleAccountI); ok {
+		return
+	}
 	err := osmoutils.CreateModuleAccount(ctx, ak, WasmHookModuleAccountAddr)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,47 @@
+package ibc_hooks
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var _ types.HookSimulator = HookSimulator{}
+
+// HookSimulator validates memos and receives packets through the transfer stack for the SimulateHook query
+type HookSimulator struct {
+	im    *IBCMiddleware
+	hooks *WasmHooks
+}
+
+func NewHookSimulator(im *IBCMiddleware, hooks *WasmHooks) HookSimulator {
+	return HookSimulator{
+		im:    im,
+		hooks: hooks,
+	}
+}
+
+// ValidateMemo validates memo as the memo of a packet sent to receiver on chain chainID. An empty receiver is taken
+// to be the contract of the memo, as it is for any packet the memo would be valid on.
+// Memos exceeding types.DefaultMemoLimits are rejected before being parsed.
+func (s HookSimulator) ValidateMemo(memo, receiver, chainID string) (isWasmRouted bool, contract sdk.AccAddress, err error) {
+	if err := types.QuickRejectMemo(memo, types.DefaultMemoLimits); err != nil {
+		return false, nil, err
+	}
+	if receiver == "" {
+		_, metadata := jsonStringHasKey(memo, "wasm")
+		if wasm, ok := metadata["wasm"].(map[string]interface{}); ok {
+			receiver, _ = wasm["contract"].(string)
+		}
+	}
+	isWasmRouted, parsed, err := ValidateAndParseMemo(memo, receiver, chainID)
+	return isWasmRouted, parsed.Contract, err
+}
+
+// SimulateRecv receives packet as a hooked packet that can't be deferred, so that a failed ICS20 receive gets
+// its error ack
+func (s HookSimulator) SimulateRecv(ctx sdk.Context, packet channeltypes.Packet) ibcexported.Acknowledgement {
+	return s.hooks.onRecvPacket(*s.im, ctx, packet, sdk.AccAddress{}, false)
+}
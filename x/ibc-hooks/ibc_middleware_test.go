@@ -1,9 +1,16 @@
 package ibc_hooks_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
 
@@ -12,17 +19,33 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/app/apptesting"
 
 	"github.com/stretchr/testify/suite"
-
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/simapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 
 	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
 	ibctesting "github.com/cosmos/ibc-go/v3/testing"
 
 	osmosisibctesting "github.com/osmosis-labs/osmosis/v13/x/ibc-rate-limit/testutil"
 
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
 	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/testutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
 )
 
 type HooksTestSuite struct {
@@ -144,24 +167,22 @@ func (suite *HooksTestSuite) TestOnRecvPacketHooks() {
 }
 
 func (suite *HooksTestSuite) makeMockPacket(receiver, memo string, prevSequence uint64) channeltypes.Packet {
-	packetData := transfertypes.FungibleTokenPacketData{
+	return suite.makeMockPacketWithAmount(receiver, memo, prevSequence, "1")
+}
+
+func (suite *HooksTestSuite) makeMockPacketWithAmount(receiver, memo string, prevSequence uint64, amount string) channeltypes.Packet {
+	return suite.makeMockPacketOnPath(suite.path, receiver, memo, prevSequence, amount)
+}
+
+// makeMockPacketOnPath makes a packet sent from chain B to chain A over path
+func (suite *HooksTestSuite) makeMockPacketOnPath(path *ibctesting.Path, receiver, memo string, prevSequence uint64, amount string) channeltypes.Packet {
+	return testutils.NewTransferPacket(path.EndpointB.ChannelID, path.EndpointA.ChannelID, prevSequence+1, transfertypes.FungibleTokenPacketData{
 		Denom:    sdk.DefaultBondDenom,
-		Amount:   "1",
+		Amount:   amount,
 		Sender:   suite.chainB.SenderAccount.GetAddress().String(),
 		Receiver: receiver,
 		Memo:     memo,
-	}
-
-	return channeltypes.NewPacket(
-		packetData.GetBytes(),
-		prevSequence+1,
-		suite.path.EndpointB.ChannelConfig.PortID,
-		suite.path.EndpointB.ChannelID,
-		suite.path.EndpointA.ChannelConfig.PortID,
-		suite.path.EndpointA.ChannelID,
-		clienttypes.NewHeight(0, 100),
-		0,
-	)
+	})
 }
 
 func (suite *HooksTestSuite) receivePacket(receiver, memo string) []byte {
@@ -169,48 +190,86 @@ func (suite *HooksTestSuite) receivePacket(receiver, memo string) []byte {
 }
 
 func (suite *HooksTestSuite) receivePacketWithSequence(receiver, memo string, prevSequence uint64) []byte {
+	return suite.receivePacketOnPath(suite.path, receiver, memo, prevSequence)
+}
+
+// receivePacketOnPath sends a packet from chain B and receives it on chain A over path, and returns its ack
+func (suite *HooksTestSuite) receivePacketOnPath(path *ibctesting.Path, receiver, memo string, prevSequence uint64) []byte {
+	return suite.receivePacketOnPathWithAmount(path, receiver, memo, prevSequence, "1")
+}
+
+// receivePacketOnPathWithAmount sends a packet of amount from chain B and receives it on chain A over path, and
+// returns its ack
+func (suite *HooksTestSuite) receivePacketOnPathWithAmount(path *ibctesting.Path, receiver, memo string, prevSequence uint64, amount string) []byte {
 	channelCap := suite.chainB.GetChannelCapability(
-		suite.path.EndpointB.ChannelConfig.PortID,
-		suite.path.EndpointB.ChannelID)
+		path.EndpointB.ChannelConfig.PortID,
+		path.EndpointB.ChannelID)
 
-	packet := suite.makeMockPacket(receiver, memo, prevSequence)
+	packet := suite.makeMockPacketOnPath(path, receiver, memo, prevSequence, amount)
 
 	err := suite.chainB.GetOsmosisApp().HooksICS4Wrapper.SendPacket(
 		suite.chainB.GetContext(), channelCap, packet)
 	suite.Require().NoError(err, "IBC send failed. Expected success. %s", err)
 
 	// Update both clients
-	err = suite.path.EndpointB.UpdateClient()
+	err = path.EndpointB.UpdateClient()
 	suite.Require().NoError(err)
-	err = suite.path.EndpointA.UpdateClient()
+	err = path.EndpointA.UpdateClient()
 	suite.Require().NoError(err)
 
 	// recv in chain a
-	res, err := suite.path.EndpointA.RecvPacketWithResult(packet)
+	res, err := path.EndpointA.RecvPacketWithResult(packet)
 
 	// get the ack from the chain a's response
 	ack, err := ibctesting.ParseAckFromEvents(res.GetEvents())
 	suite.Require().NoError(err)
 
 	// manually send the acknowledgement to chain b
-	err = suite.path.EndpointA.AcknowledgePacket(packet, ack)
+	err = path.EndpointA.AcknowledgePacket(packet, ack)
 	suite.Require().NoError(err)
 	return ack
 }
 
+// hookedCount returns the count the counter contract at addr keeps for the intermediate sender of the packets sent
+// from chain B's sender account, or "" if none of them executed it yet. The first execution sets the count to 0.
+func (suite *HooksTestSuite) hookedCount(addr sdk.AccAddress) string {
+	sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender))
+	state, err := suite.chainA.GetOsmosisApp().WasmKeeper.QuerySmart(suite.chainA.GetContext(), addr, query)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		return ""
+	}
+	suite.Require().NoError(err)
+	return string(state)
+}
+
+// skipUnlessCounterHandles skips the test if the counter contract at addr doesn't know the msg variant of its execute
+// entry point, or of its sudo one if sudo is set. The checked in bytecode/counter.wasm predates some of the variants
+// of testutils/contracts/counter, and has to be rebuilt from it for the tests using them to run.
+func (suite *HooksTestSuite) skipUnlessCounterHandles(addr sdk.AccAddress, variant string, sudo bool) {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx, _ := suite.chainA.GetContext().CacheContext()
+	msg := []byte(fmt.Sprintf(`{"%s": {}}`, variant))
+	var err error
+	if sudo {
+		_, err = osmosisApp.WasmKeeper.Sudo(ctx, addr, msg)
+	} else {
+		_, err = wasmkeeper.NewDefaultPermissionKeeper(osmosisApp.WasmKeeper).Execute(ctx, addr, suite.chainA.SenderAccount.GetAddress(), msg, sdk.NewCoins())
+	}
+	if err != nil && strings.Contains(err.Error(), "unknown variant") {
+		suite.T().Skipf("bytecode/counter.wasm doesn't handle %s, rebuild it from testutils/contracts/counter", variant)
+	}
+}
+
 func (suite *HooksTestSuite) TestRecvTransferWithMetadata() {
 	// Setup contract
 	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
 	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
 
-	ackBytes := suite.receivePacket(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr))
-	ackStr := string(ackBytes)
-	fmt.Println(ackStr)
-	var ack map[string]string // This can't be unmarshalled to Acknowledgement because it's fetched from the events
-	err := json.Unmarshal(ackBytes, &ack)
-	suite.Require().NoError(err)
-	suite.Require().NotContains(ack, "error")
-	suite.Require().Equal(ack["result"], "eyJjb250cmFjdF9yZXN1bHQiOiJkR2hwY3lCemFHOTFiR1FnWldOb2J3PT0iLCJpYmNfYWNrIjoiZXlKeVpYTjFiSFFpT2lKQlVUMDlJbjA9In0=")
+	ackBytes := suite.receivePacket(addr.String(), testutils.WasmMemo(addr.String(), `{"echo": {"msg": "test"}}`))
+	contractAck := testutils.RequireContractAck(suite.T(), ackBytes)
+	suite.Require().Equal("this should echo", string(contractAck.ContractResult))
+	suite.Require().JSONEq(`{"result":"AQ=="}`, string(contractAck.IbcAck))
 }
 
 // After successfully executing a wasm call, the contract should have the funds sent via IBC
@@ -218,6 +277,8 @@ func (suite *HooksTestSuite) TestFundsAreTransferredToTheContract() {
 	// Setup contract
 	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
 	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	recorder := &testutils.TestIBCHooksRecorder{}
+	suite.chainA.GetOsmosisApp().IBCHooksKeeper.SetHooks(recorder)
 
 	// Check that the contract has no funds
 	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
@@ -225,18 +286,30 @@ func (suite *HooksTestSuite) TestFundsAreTransferredToTheContract() {
 	suite.Require().Equal(sdk.NewInt(0), balance.Amount)
 
 	// Execute the contract via IBC
-	ackBytes := suite.receivePacket(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr))
-	ackStr := string(ackBytes)
-	fmt.Println(ackStr)
-	var ack map[string]string // This can't be unmarshalled to Acknowledgement because it's fetched from the events
-	err := json.Unmarshal(ackBytes, &ack)
-	suite.Require().NoError(err)
-	suite.Require().NotContains(ack, "error")
-	suite.Require().Equal(ack["result"], "eyJjb250cmFjdF9yZXN1bHQiOiJkR2hwY3lCemFHOTFiR1FnWldOb2J3PT0iLCJpYmNfYWNrIjoiZXlKeVpYTjFiSFFpT2lKQlVUMDlJbjA9In0=")
+	ackBytes := suite.receivePacket(addr.String(), testutils.WasmMemo(addr.String(), `{"echo": {"msg": "test"}}`))
+	contractAck := testutils.RequireContractAck(suite.T(), ackBytes)
+	suite.Require().Equal("this should echo", string(contractAck.ContractResult))
+	suite.Require().JSONEq(`{"result":"AQ=="}`, string(contractAck.IbcAck))
 
 	// Check that the token has now been transferred to the contract
 	balance = suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
 	suite.Require().Equal(sdk.NewInt(1), balance.Amount)
+
+	// The keeper hooks were notified of the execution
+	suite.Require().Len(recorder.HookExecutions, 1)
+	execution := recorder.HookExecutions[0]
+	suite.Require().Equal(types.HookedPacketInfo{
+		Channel:  suite.path.EndpointA.ChannelID,
+		Sequence: 1,
+		Sender:   suite.chainB.SenderAccount.GetAddress().String(),
+		Contract: addr.String(),
+		Denom:    localDenom,
+		Amount:   "1",
+	}, execution.Packet)
+	suite.Require().True(execution.Result.Success)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewCoin(localDenom, sdk.NewInt(1))), execution.Result.Funds)
+	suite.Require().JSONEq(string(ackBytes), string(execution.Result.Ack))
+	suite.Require().Empty(recorder.CallbackExecutions)
 }
 
 // If the wasm call wails, the contract acknowledgement should be an error and the funds returned
@@ -244,6 +317,8 @@ func (suite *HooksTestSuite) TestFundsAreReturnedOnFailedContractExec() {
 	// Setup contract
 	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
 	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	recorder := &testutils.TestIBCHooksRecorder{}
+	suite.chainA.GetOsmosisApp().IBCHooksKeeper.SetHooks(recorder)
 
 	// Check that the contract has no funds
 	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
@@ -251,214 +326,3027 @@ func (suite *HooksTestSuite) TestFundsAreReturnedOnFailedContractExec() {
 	suite.Require().Equal(sdk.NewInt(0), balance.Amount)
 
 	// Execute the contract via IBC with a message that the contract will reject
-	ackBytes := suite.receivePacket(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"not_echo": {"msg": "test"} } } }`, addr))
-	ackStr := string(ackBytes)
-	fmt.Println(ackStr)
-	var ack map[string]string // This can't be unmarshalled to Acknowledgement because it's fetched from the events
-	err := json.Unmarshal(ackBytes, &ack)
-	suite.Require().NoError(err)
-	suite.Require().Contains(ack, "error")
+	ackBytes := suite.receivePacket(addr.String(), testutils.WasmMemo(addr.String(), `{"not_echo": {"msg": "test"}}`))
+	testutils.RequireErrorAck(suite.T(), ackBytes, wasmtypes.ErrExecuteFailed.Error())
 
-	// Check that the token has now been transferred to the contract
+	// Check that the token has not been transferred to the contract
 	balance = suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
-	fmt.Println(balance)
 	suite.Require().Equal(sdk.NewInt(0), balance.Amount)
+
+	// The keeper hooks were notified of the failure, although its state changes were reverted
+	suite.Require().Len(recorder.HookExecutions, 1)
+	execution := recorder.HookExecutions[0]
+	suite.Require().Equal(addr.String(), execution.Packet.Contract)
+	suite.Require().Equal(localDenom, execution.Packet.Denom)
+	suite.Require().False(execution.Result.Success)
+	suite.Require().Empty(execution.Result.Funds)
+	suite.Require().JSONEq(string(ackBytes), string(execution.Result.Ack))
 }
 
-func (suite *HooksTestSuite) TestPacketsThatShouldBeSkipped() {
-	var sequence uint64
-	receiver := suite.chainB.SenderAccount.GetAddress().String()
+func (suite *HooksTestSuite) TestParseHookRejection() {
+	longReason := strings.Repeat("a", types.MaxRejectionReasonLength+10)
+	// a 3 bytes character straddling the size cap
+	multiByteReason := strings.Repeat("a", types.MaxRejectionReasonLength-1) + "€"
 
 	testCases := []struct {
-		memo           string
-		expPassthrough bool
+		name           string
+		contractErr    string
+		expectRejected bool
+		expectReason   string
 	}{
-		{"", true},
-		{"{01]", true}, // bad json
-		{"{}", true},
-		{`{"something": ""}`, true},
-		{`{"wasm": "test"}`, false},
-		{`{"wasm": []`, true}, // invalid top level JSON
-		{`{"wasm": {}`, true}, // invalid top level JSON
-		{`{"wasm": []}`, false},
-		{`{"wasm": {}}`, false},
-		{`{"wasm": {"contract": "something"}}`, false},
-		{`{"wasm": {"contract": "osmo1clpqr4nrk4khgkxj78fcwwh6dl3uw4epasmvnj"}}`, false},
-		{`{"wasm": {"msg": "something"}}`, false},
-		// invalid receiver
-		{`{"wasm": {"contract": "osmo1clpqr4nrk4khgkxj78fcwwh6dl3uw4epasmvnj", "msg": {}}}`, false},
-		// msg not an object
-		{fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": 1}}`, receiver), false},
+		{
+			name:           "generic error",
+			contractErr:    `Generic error: {"hook_rejection": {"reason": "deposits closed"}}`,
+			expectRejected: true,
+			expectReason:   "deposits closed",
+		},
+		{
+			name:           "custom error without spaces",
+			contractErr:    `{"hook_rejection":{"reason":"deposits closed"}}`,
+			expectRejected: true,
+			expectReason:   "deposits closed",
+		},
+		{
+			name:           "reason with braces",
+			contractErr:    `{"hook_rejection": {"reason": "pool {1} is paused"}}`,
+			expectRejected: true,
+			expectReason:   "pool {1} is paused",
+		},
+		{
+			name:           "non printable characters are removed",
+			contractErr:    `{"hook_rejection": {"reason": "deposits\nclosed\u0000\u001b[31m"}}`,
+			expectRejected: true,
+			expectReason:   "depositsclosed[31m",
+		},
+		{
+			name:           "reason is truncated",
+			contractErr:    fmt.Sprintf(`{"hook_rejection": {"reason": "%s"}}`, longReason),
+			expectRejected: true,
+			expectReason:   longReason[:types.MaxRejectionReasonLength],
+		},
+		{
+			name:           "multi byte character is not cut",
+			contractErr:    fmt.Sprintf(`{"hook_rejection": {"reason": "%s"}}`, multiByteReason),
+			expectRejected: true,
+			expectReason:   multiByteReason[:types.MaxRejectionReasonLength-1],
+		},
+		{
+			name:           "empty reason",
+			contractErr:    `{"hook_rejection": {}}`,
+			expectRejected: true,
+			expectReason:   "",
+		},
+		{
+			name:        "generic failure",
+			contractErr: "Error parsing into type echo::msg::ExecuteMsg: unknown variant `not_echo`, expected `echo`",
+		},
+		{
+			name:        "rejection is not an object",
+			contractErr: `Generic error: {"hook_rejection": "deposits closed"}`,
+		},
+		{
+			name:        "malformed rejection",
+			contractErr: `Generic error: {"hook_rejection": {"reason": "deposits closed"`,
+		},
 	}
 
 	for _, tc := range testCases {
-		ackBytes := suite.receivePacketWithSequence(receiver, tc.memo, sequence)
-		ackStr := string(ackBytes)
-		fmt.Println(ackStr)
-		var ack map[string]string // This can't be unmarshalled to Acknowledgement because it's fetched from the events
-		err := json.Unmarshal(ackBytes, &ack)
-		suite.Require().NoError(err)
-		if tc.expPassthrough {
-			suite.Require().Equal("AQ==", ack["result"], tc.memo)
-		} else {
-			suite.Require().Contains(ackStr, "error", tc.memo)
-		}
-		sequence += 1
+		suite.Run(tc.name, func() {
+			// wasmd wraps the contract's error, appending its own message
+			execErr := sdkerrors.Wrap(wasmtypes.ErrExecuteFailed, tc.contractErr)
+			reason, rejected := ibchooks.ParseHookRejection(execErr)
+			suite.Require().Equal(tc.expectRejected, rejected)
+			suite.Require().Equal(tc.expectReason, reason)
+		})
 	}
 }
 
-// After successfully executing a wasm call, the contract should have the funds sent via IBC
-func (suite *HooksTestSuite) TestFundTracking() {
-	// Setup contract
-	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
-	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+// A contract failing without a hook rejection gets the wasmd error in the ack
+func (suite *HooksTestSuite) TestFailedContractExecIsNotRejection() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
 
-	// Check that the contract has no funds
-	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
-	balance := suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
-	suite.Require().Equal(sdk.NewInt(0), balance.Amount)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx := suite.chainA.GetContext().WithEventManager(sdk.NewEventManager())
+	memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"not_echo": {"msg": "test"} } } }`, addr)
+	ack := osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 0), suite.chainB.SenderAccount.GetAddress())
 
-	// Execute the contract via IBC
-	suite.receivePacket(
-		addr.String(),
-		fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {} } } }`, addr))
+	suite.Require().False(ack.Success())
+	suite.Require().NotContains(string(ack.Acknowledgement()), "REJECTED_BY_CONTRACT")
+	suite.Require().Contains(string(ack.Acknowledgement()), wasmtypes.ErrExecuteFailed.Error())
+	suite.AssertEventEmitted(ctx, types.TypeEvtHookedPacketRejected, 0)
+}
 
-	state := suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, ibchooks.WasmHookModuleAccountAddr)))
-	suite.Require().Equal(`{"count":0}`, state)
+// hookLogLines returns the lines the ibc-hooks module logged in JSON to logs. They are consumed, so the next call only
+// returns the lines logged since.
+func (suite *HooksTestSuite) hookLogLines(logs *bytes.Buffer) []map[string]interface{} {
+	lines := []map[string]interface{}{}
+	decoder := json.NewDecoder(logs)
+	for decoder.More() {
+		line := map[string]interface{}{}
+		suite.Require().NoError(decoder.Decode(&line))
+		if line["module"] == "ibc-hooks" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
 
-	state = suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_total_funds": {"addr": "%s"}}`, ibchooks.WasmHookModuleAccountAddr)))
-	suite.Require().Equal(`{"total_funds":[{"denom":"ibc/C053D637CCA2A2BA030E2C5EE1B28A16F71CCB0E45E8BE52766DC1B241B77878","amount":"1"}]}`, state)
+// Hook failures are logged with the fields operators filter on, up to a limit per block
+func (suite *HooksTestSuite) TestHookFailureLogs() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
 
-	suite.receivePacketWithSequence(
-		addr.String(),
-		fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {} } } }`, addr), 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	logs := &bytes.Buffer{}
+	ctx := suite.chainA.GetContext().WithLogger(tmlog.NewTMJSONLogger(tmlog.NewSyncWriter(logs)))
+	memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"not_echo": {"msg": "test"} } } }`, addr)
+	packet := suite.makeMockPacket(addr.String(), memo, 0)
+	ack := osmosisApp.TransferStack.OnRecvPacket(ctx, packet, suite.chainB.SenderAccount.GetAddress())
+	suite.Require().False(ack.Success())
+
+	lines := suite.hookLogLines(logs)
+	suite.Require().Len(lines, 1)
+	suite.Require().Equal("hook failed", lines[0]["_msg"])
+	suite.Require().Equal(packet.GetDestChannel(), lines[0]["channel"])
+	suite.Require().Equal("1", fmt.Sprint(lines[0]["sequence"]))
+	suite.Require().Equal(keeper.PacketFingerprint(packet.GetData()), lines[0]["fingerprint"])
+	suite.Require().Equal(addr.String(), lines[0]["contract"])
+	suite.Require().Equal(types.FailureExecution, lines[0]["error_code"])
+	suite.Require().Equal(osmoutils.MustExtractDenomFromPacketOnRecv(packet), lines[0]["denom"])
+	suite.Require().Equal("1", lines[0]["amount"])
+	suite.Require().Contains(lines[0]["error"], wasmtypes.ErrExecuteFailed.Error())
+
+	// Over the limit, the failures of the block are not logged anymore, after a line saying so
+	for i := uint64(1); i < types.MaxHookFailureLogsPerBlock+5; i++ {
+		ack := osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, i), suite.chainB.SenderAccount.GetAddress())
+		suite.Require().False(ack.Success())
+	}
+	lines = suite.hookLogLines(logs)
+	suite.Require().Len(lines, types.MaxHookFailureLogsPerBlock)
+	suite.Require().Equal("too many hook failures in this block, the next ones are not logged", lines[len(lines)-1]["_msg"])
+
+	// The failures of the next block are logged again
+	nextBlockCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	osmosisApp.TransferStack.OnRecvPacket(nextBlockCtx, suite.makeMockPacket(addr.String(), memo, 100), suite.chainB.SenderAccount.GetAddress())
+	lines = suite.hookLogLines(logs)
+	suite.Require().Len(lines, 1)
+	suite.Require().Equal("101", fmt.Sprint(lines[0]["sequence"]))
+}
 
-	state = suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, ibchooks.WasmHookModuleAccountAddr)))
-	suite.Require().Equal(`{"count":1}`, state)
+// The hook execution flag should only be set while the contract is being executed by the hook
+func (suite *HooksTestSuite) TestHookExecutionFlagIsCleared() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
 
-	state = suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_total_funds": {"addr": "%s"}}`, ibchooks.WasmHookModuleAccountAddr)))
-	suite.Require().Equal(`{"total_funds":[{"denom":"ibc/C053D637CCA2A2BA030E2C5EE1B28A16F71CCB0E45E8BE52766DC1B241B77878","amount":"2"}]}`, state)
+	hooksKeeper := suite.chainA.GetOsmosisApp().IBCHooksKeeper
+	sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	suite.Require().False(hooksKeeper.IsInHookExecution(suite.chainA.GetContext(), addr.String(), sender.String()))
 
-	// Check that the token has now been transferred to the contract
-	balance = suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
-	suite.Require().Equal(sdk.NewInt(2), balance.Amount)
+	// Successful execution
+	ackBytes := suite.receivePacket(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr))
+	suite.Require().NotContains(string(ackBytes), "error")
+	suite.Require().False(hooksKeeper.IsInHookExecution(suite.chainA.GetContext(), addr.String(), sender.String()))
+
+	// Failed execution
+	ackBytes = suite.receivePacketWithSequence(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"not_echo": {"msg": "test"} } } }`, addr), 1)
+	suite.Require().Contains(string(ackBytes), "error")
+	suite.Require().False(hooksKeeper.IsInHookExecution(suite.chainA.GetContext(), addr.String(), sender.String()))
 }
 
-// custom MsgTransfer constructor that supports Memo
-func NewMsgTransfer(
-	token sdk.Coin, sender, receiver string, memo string,
-) *transfertypes.MsgTransfer {
-	return &transfertypes.MsgTransfer{
-		SourcePort:       "transfer",
-		SourceChannel:    "channel-0",
-		Token:            token,
-		Sender:           sender,
-		Receiver:         receiver,
-		TimeoutHeight:    clienttypes.NewHeight(0, 100),
-		TimeoutTimestamp: 0,
-		Memo:             memo,
-	}
+// A contract that only accepts calls made by the hook can tell them apart from direct executions
+// through the is_in_hook_execution query
+func (suite *HooksTestSuite) TestHookProvenance() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	suite.skipUnlessCounterHandles(addr, "increment_from_hook", false)
+	msg := []byte(`{"increment_from_hook": {}}`)
+
+	// inside the hook, the query returns true for the intermediate sender
+	ack := suite.receivePacket(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": %s } }`, addr, msg))
+	suite.Require().NotContains(string(ack), "error")
+	sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	state := suite.chainA.QueryContract(&suite.Suite, addr, []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender)))
+	suite.Require().Equal(`{"count":0}`, state)
+
+	// a direct MsgExecuteContract is rejected, as the query returns false
+	contractKeeper := wasmkeeper.NewDefaultPermissionKeeper(suite.chainA.GetOsmosisApp().WasmKeeper)
+	_, err := contractKeeper.Execute(suite.chainA.GetContext(), addr, suite.chainA.SenderAccount.GetAddress(), msg, sdk.NewCoins())
+	suite.Require().ErrorContains(err, "Unauthorized")
 }
 
-type Direction int64
+// Hooked packets over the per block limit get an error ack, until the next block.
+// Packets whose execution failed are not counted, as their state changes are reverted.
+func (suite *HooksTestSuite) TestMaxHookedPacketsPerBlock() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
 
-const (
-	AtoB Direction = iota
-	BtoA
-)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+	const maxHookedPackets = 3
+	osmosisApp.IBCHooksKeeper.SetParams(suite.chainA.GetContext(), types.NewParams("", nil, nil, maxHookedPackets, nil, types.DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, ""))
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+
+	ctx := suite.chainA.GetContext().WithEventManager(sdk.NewEventManager())
+	// recvPacket discards the state changes of the packets that get an error ack, but keeps their events, as IBC does
+	sequence := uint64(0)
+	recvPacket := func(memo string) ibcexported.Acknowledgement {
+		cacheCtx, write := ctx.CacheContext()
+		ack := osmosisApp.TransferStack.OnRecvPacket(cacheCtx, suite.makeMockPacket(addr.String(), memo, sequence), relayer)
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		if ack.Success() {
+			write()
+		}
+		sequence++
+		return ack
+	}
 
-func (suite *HooksTestSuite) GetEndpoints(direction Direction) (sender *ibctesting.Endpoint, receiver *ibctesting.Endpoint) {
-	switch direction {
-	case AtoB:
-		sender = suite.path.EndpointA
-		receiver = suite.path.EndpointB
-	case BtoA:
-		sender = suite.path.EndpointB
-		receiver = suite.path.EndpointA
+	// a failed execution doesn't count towards the limit
+	failingMemo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"unknown": {} } } }`, addr)
+	suite.Require().False(recvPacket(failingMemo).Success())
+	suite.Require().Equal(uint64(0), osmosisApp.IBCHooksKeeper.GetBlockHookedPacketCount(ctx))
+	for i := 0; i < maxHookedPackets; i++ {
+		suite.Require().True(recvPacket(memo).Success())
 	}
-	return sender, receiver
+	suite.Require().Equal(uint64(maxHookedPackets), osmosisApp.IBCHooksKeeper.GetBlockHookedPacketCount(ctx))
+
+	// packets that aren't hooked are not limited
+	suite.Require().True(recvPacket("").Success())
+
+	// the next hooked packet is throttled, and its funds are not received, unlike those of the packet that isn't hooked
+	ack := recvPacket(memo)
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "throttled:")
+	suite.Require().Equal(sdk.NewInt(maxHookedPackets+1), osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom).Amount)
+	suite.AssertEventEmitted(ctx, types.TypeEvtHookedPacketThrottled, 1)
+
+	// The counter is reset on the next block
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+	ctx = suite.chainA.GetContext()
+	suite.Require().Equal(uint64(0), osmosisApp.IBCHooksKeeper.GetBlockHookedPacketCount(ctx))
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, sequence), relayer)
+	suite.Require().True(ack.Success())
 }
 
-func (suite *HooksTestSuite) RelayPacket(packet channeltypes.Packet, direction Direction) (*sdk.Result, []byte) {
-	sender, receiver := suite.GetEndpoints(direction)
+// Privileged contracts execute with the privileged gas limit and are exempt from the amount caps and the per block
+// limit, while the other contracts are bound by them. Only the authority manages the privileged contracts.
+func (suite *HooksTestSuite) TestPrivilegedContracts() {
+	// Setup contracts
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	privileged := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	unprivileged := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	echoMemo := func(contract sdk.AccAddress) string {
+		return fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, contract)
+	}
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
 
-	err := receiver.UpdateClient()
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+	authority := suite.chainA.SenderAccount.GetAddress().String()
+	// No execution fits in maxHookGas
+	const maxHookGas, privilegedMaxHookGas = 10_000, 10_000_000
+	maxHookAmounts := sdk.NewCoins(sdk.NewInt64Coin(localDenom, 10))
+	osmosisApp.IBCHooksKeeper.SetParams(suite.chainA.GetContext(), types.NewParams("", nil, nil, 1, nil, types.DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, maxHookGas, privilegedMaxHookGas, maxHookAmounts, authority))
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+
+	ctx := suite.chainA.GetContext().WithEventManager(sdk.NewEventManager())
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+
+	// Only the authority can add a privileged contract, and only a contract
+	_, err := msgServer.AddPrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgAddPrivilegedContract(relayer.String(), privileged.String()))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.AddPrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgAddPrivilegedContract(authority, relayer.String()))
+	suite.Require().ErrorIs(err, sdkerrors.ErrInvalidRequest)
+	_, err = msgServer.AddPrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgAddPrivilegedContract(authority, privileged.String()))
 	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgAddPrivilegedContract, 1)
+	_, err = msgServer.AddPrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgAddPrivilegedContract(authority, privileged.String()))
+	suite.Require().Error(err)
 
-	// receiver Receives
-	receiveResult, err := receiver.RecvPacketWithResult(packet)
+	res, err := osmosisApp.IBCHooksKeeper.PrivilegedContracts(sdk.WrapSDKContext(ctx), &types.QueryPrivilegedContractsRequest{})
 	suite.Require().NoError(err)
+	suite.Require().Equal([]string{privileged.String()}, res.Contracts)
+
+	// recvPacket discards the state changes of the packets that get an error ack, as IBC does
+	sequence := uint64(0)
+	recvPacket := func(contract sdk.AccAddress, amount string) ibcexported.Acknowledgement {
+		cacheCtx, write := ctx.CacheContext()
+		ack := osmosisApp.TransferStack.OnRecvPacket(cacheCtx, suite.makeMockPacketWithAmount(contract.String(), echoMemo(contract), sequence, amount), relayer)
+		if ack.Success() {
+			write()
+		}
+		sequence++
+		return ack
+	}
 
-	ack, err := ibctesting.ParseAckFromEvents(receiveResult.GetEvents())
+	// The execution of the unprivileged contract runs out of gas
+	ack := recvPacket(unprivileged, "1")
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "out of gas")
+
+	// The privileged contract uses more than maxHookGas and fills the per block limit, then executes over it and
+	// over the max amount
+	gasBefore := ctx.GasMeter().GasConsumed()
+	suite.Require().True(recvPacket(privileged, "1").Success())
+	suite.Require().Greater(ctx.GasMeter().GasConsumed()-gasBefore, uint64(maxHookGas))
+	suite.Require().Equal(uint64(1), osmosisApp.IBCHooksKeeper.GetBlockHookedPacketCount(ctx))
+	suite.Require().True(recvPacket(privileged, "100").Success())
+	suite.Require().Equal(sdk.NewInt(101), osmosisApp.BankKeeper.GetBalance(ctx, privileged, localDenom).Amount)
+
+	// The unprivileged contract is throttled, and capped before that
+	ack = recvPacket(unprivileged, "1")
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "throttled:")
+	ack = recvPacket(unprivileged, "100")
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "exceeds the max amount")
+	suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, unprivileged, localDenom).Amount.IsZero())
+
+	// Once removed, the contract is bound by the limits again
+	_, err = msgServer.RemovePrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgRemovePrivilegedContract(relayer.String(), privileged.String()))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.RemovePrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgRemovePrivilegedContract(authority, privileged.String()))
 	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgRemovePrivilegedContract, 1)
+	_, err = msgServer.RemovePrivilegedContract(sdk.WrapSDKContext(ctx), types.NewMsgRemovePrivilegedContract(authority, privileged.String()))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllPrivilegedContracts(ctx))
+
+	ack = recvPacket(privileged, "100")
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "exceeds the max amount")
+}
 
-	// sender Acknowledges
-	err = sender.AcknowledgePacket(packet, ack)
+// setupSwaprouter creates a pool between the denom received from chain B and uosmo on chain A,
+// and a swaprouter contract routing swaps between them through it.
+func (suite *HooksTestSuite) setupSwaprouter(receivedDenom string) sdk.AccAddress {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	// the pool helpers act on the suite's app
+	suite.App, suite.Ctx = osmosisApp, suite.chainA.GetContext()
+	poolId := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin(receivedDenom, 1_000_000), sdk.NewInt64Coin("uosmo", 1_000_000))
+
+	owner := suite.chainA.SenderAccount.GetAddress()
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/swaprouter.wasm")
+	swaprouter := suite.chainA.InstantiateContract(&suite.Suite, fmt.Sprintf(`{"owner": "%s"}`, owner), 1)
+
+	contractKeeper := wasmkeeper.NewDefaultPermissionKeeper(osmosisApp.WasmKeeper)
+	setRoute := fmt.Sprintf(`{"set_route": {"input_denom": "%s", "output_denom": "uosmo", "pool_route": [{"pool_id": "%d", "token_out_denom": "uosmo"}]}}`,
+		receivedDenom, poolId)
+	_, err := contractKeeper.Execute(suite.chainA.GetContext(), swaprouter, owner, []byte(setRoute), sdk.NewCoins())
 	suite.Require().NoError(err)
+	return swaprouter
+}
 
-	err = sender.UpdateClient()
+func (suite *HooksTestSuite) TestPostTransfer() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+	receivedDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	swaprouter := suite.setupSwaprouter(receivedDenom)
+
+	intermediateSender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	user := secp256k1.GenPrivKey().PubKey().Address().Bytes()
+	// The swaprouter sends the output of the swap back to its sender, the intermediate sender,
+	// where it would stay without a post transfer
+	swapMemo := func(postTransfer string) string {
+		return fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"swap": {"input_coin": {"denom": "%s", "amount": "1000"}, "output_denom": "uosmo", "slipage": {"min_output_amount": "1"}}}%s}}`,
+			swaprouter, receivedDenom, postTransfer)
+	}
+
+	// Without a post transfer, the output of the swap is left on the intermediate sender
+	ctx := suite.chainA.GetContext()
+	ack := osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacketWithAmount(swaprouter.String(), swapMemo(""), 0, "1000"), relayer)
+	suite.Require().True(ack.Success(), string(ack.Acknowledgement()))
+	leftOver := osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, "uosmo")
+	suite.Require().True(leftOver.IsPositive())
+
+	// The intermediate sender also holds funds that were not received by the hook
+	err := simapp.FundAccount(osmosisApp.BankKeeper, ctx, intermediateSender, sdk.NewCoins(sdk.NewInt64Coin("uion", 5)))
 	suite.Require().NoError(err)
-	err = receiver.UpdateClient()
+
+	// With a post transfer, the received denoms are forwarded: the output of this swap,
+	// along with the balance the previous one left behind
+	ctx = suite.chainA.GetContext()
+	memo := swapMemo(fmt.Sprintf(`, "post_transfer_to": "%s"`, sdk.AccAddress(user)))
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacketWithAmount(swaprouter.String(), memo, 1, "1000"), relayer)
+	suite.Require().True(ack.Success(), string(ack.Acknowledgement()))
+	suite.AssertEventEmitted(ctx, types.TypeEvtPostTransfer, 1)
+
+	forwarded := osmosisApp.BankKeeper.GetBalance(ctx, user, "uosmo")
+	suite.Require().True(forwarded.Amount.GT(leftOver.Amount))
+	suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, "uosmo").IsZero())
+	suite.Require().Equal(sdk.NewInt(5), osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, "uion").Amount)
+	suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, user, "uion").IsZero())
+
+	// With a post transfer denom, only that denom is forwarded
+	memo = swapMemo(fmt.Sprintf(`, "post_transfer_to": "%s", "post_transfer_denom": "uion"`, sdk.AccAddress(user)))
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacketWithAmount(swaprouter.String(), memo, 2, "1000"), relayer)
+	suite.Require().True(ack.Success(), string(ack.Acknowledgement()))
+	suite.Require().Equal(sdk.NewInt(5), osmosisApp.BankKeeper.GetBalance(ctx, user, "uion").Amount)
+	suite.Require().Equal(forwarded, osmosisApp.BankKeeper.GetBalance(ctx, user, "uosmo"))
+	suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, "uosmo").IsPositive())
+
+	// Forwarding to an address that can't receive funds fails the whole hook, so the funds are refunded
+	blocked := osmosisApp.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	memo = swapMemo(fmt.Sprintf(`, "post_transfer_to": "%s"`, blocked))
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacketWithAmount(swaprouter.String(), memo, 3, "1000"), relayer)
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "cannot forward the remaining funds")
+}
+
+// Contracts that opt in to per block serialization should only execute the first hooked packet of each block
+func (suite *HooksTestSuite) TestSerializePerBlock() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+
+	// Without opting in, all the packets in a block are executed
+	ctx := suite.chainA.GetContext()
+	ack := osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 0), relayer)
+	suite.Require().True(ack.Success())
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 1), relayer)
+	suite.Require().True(ack.Success())
+	suite.Require().Equal(sdk.NewInt(2), osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom).Amount)
+
+	// The contract opts in
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+	ctx = suite.chainA.GetContext()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+	_, err := msgServer.SetSerializePerBlock(sdk.WrapSDKContext(ctx), types.NewMsgSetSerializePerBlock(addr.String(), true))
 	suite.Require().NoError(err)
 
-	return receiveResult, ack
+	// Accounts that aren't contracts can't opt in
+	user := suite.chainA.SenderAccount.GetAddress().String()
+	_, err = msgServer.SetSerializePerBlock(sdk.WrapSDKContext(ctx), types.NewMsgSetSerializePerBlock(user, true))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	suite.Require().False(osmosisApp.IBCHooksKeeper.IsSerializedPerBlock(ctx, user))
+	suite.Require().True(osmosisApp.IBCHooksKeeper.IsSerializedPerBlock(ctx, addr.String()))
+
+	// Only the first packet of the block is executed. The second one gets an error ack and its funds are not received
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 2), relayer)
+	suite.Require().True(ack.Success())
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 3), relayer)
+	suite.Require().False(ack.Success())
+	suite.Require().Contains(string(ack.Acknowledgement()), "only accepts one hooked packet per block")
+	suite.Require().Equal(sdk.NewInt(3), osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom).Amount)
+
+	// The counter is reset on the next block
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+	ctx = suite.chainA.GetContext()
+	suite.Require().Equal(uint64(0), osmosisApp.IBCHooksKeeper.GetHookedPacketCount(ctx, addr.String()))
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 4), relayer)
+	suite.Require().True(ack.Success())
+
+	// Opting out allows several packets per block again
+	_, err = msgServer.SetSerializePerBlock(sdk.WrapSDKContext(ctx), types.NewMsgSetSerializePerBlock(addr.String(), false))
+	suite.Require().NoError(err)
+	ack = osmosisApp.TransferStack.OnRecvPacket(ctx, suite.makeMockPacket(addr.String(), memo, 5), relayer)
+	suite.Require().True(ack.Success())
 }
 
-func (suite *HooksTestSuite) FullSend(msg sdk.Msg, direction Direction) (*sdk.Result, *sdk.Result, string, error) {
-	var sender *osmosisibctesting.TestChain
-	switch direction {
-	case AtoB:
-		sender = suite.chainA
-	case BtoA:
-		sender = suite.chainB
+// Callbacks record when they were registered, can be listed with the paginated query, are migrated from the
+// unprefixed v1 keys by the registered store migration, and are pruned by age
+func (suite *HooksTestSuite) TestPacketCallbackRegistration() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	hooksKeeper := osmosisApp.IBCHooksKeeper
+	contract := suite.chainA.SenderAccount.GetAddress().String()
+
+	ctx := suite.chainA.GetContext()
+	startTime := ctx.BlockTime()
+
+	// Callbacks stored at the v1 unprefixed keys: as a bare contract address, from before registration heights
+	// were tracked, and in the current format
+	store := ctx.KVStore(osmosisApp.GetKey(types.StoreKey))
+	store.Set(keeper.GetLegacyPacketKey("channel-0", 1), []byte(contract))
+	v1Callback := types.PacketCallback{
+		Contract:           contract,
+		RegistrationHeight: ctx.BlockHeight() - 1,
+		RegistrationTime:   startTime.Add(-time.Minute),
+		Entry:              types.CallbackEntryExecute,
 	}
-	sendResult, err := sender.SendMsgsNoCheck(msg)
+	osmoutils.MustSet(store, keeper.GetLegacyPacketKey("channel-0", 2), &v1Callback)
+	// Other keys of the module must not be mistaken for callbacks
+	hooksKeeper.SetSerializePerBlock(ctx, contract, true)
+
+	// New callbacks record when they were registered
+	hooksKeeper.StorePacketCallback(ctx, "channel-1", 2, contract, types.CallbackEntrySudo, 0)
+	callback, found := hooksKeeper.GetPacketCallbackInfo(ctx, "channel-1", 2)
+	suite.Require().True(found)
+	suite.Require().Equal(contract, callback.Contract)
+	suite.Require().Equal(ctx.BlockHeight(), callback.RegistrationHeight)
+	suite.Require().Equal(startTime, callback.RegistrationTime)
+	suite.Require().Equal(contract, hooksKeeper.GetPacketCallback(ctx, "channel-1", 2))
+
+	_, found = hooksKeeper.GetPacketCallbackInfo(ctx, "channel-1", 3)
+	suite.Require().False(found)
+
+	// Run the registered migrations, as an upgrade handler does
+	migrationCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 10).WithBlockTime(startTime.Add(time.Hour))
+	fromVM := osmosisApp.UpgradeKeeper.GetModuleVersionMap(migrationCtx)
+	fromVM[types.ModuleName] = 1
+	toVM, err := osmosisApp.ModuleManager().RunMigrations(migrationCtx, osmosisApp.Configurator(), fromVM)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(2), toVM[types.ModuleName])
+
+	// Callbacks stored as a bare contract address are stamped with the migration block, the others keep theirs
+	legacy, found := hooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 1)
+	suite.Require().True(found)
+	suite.Require().Equal(types.PacketCallback{
+		Contract:           contract,
+		RegistrationHeight: migrationCtx.BlockHeight(),
+		RegistrationTime:   migrationCtx.BlockTime(),
+	}, legacy)
+	migrated, found := hooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 2)
+	suite.Require().True(found)
+	suite.Require().Equal(v1Callback, migrated)
+	unchanged, found := hooksKeeper.GetPacketCallbackInfo(ctx, "channel-1", 2)
+	suite.Require().True(found)
+	suite.Require().Equal(callback, unchanged)
+	suite.Require().False(store.Has(keeper.GetLegacyPacketKey("channel-0", 1)))
+	suite.Require().False(store.Has(keeper.GetLegacyPacketKey("channel-0", 2)))
+
+	// The query returns the callbacks page by page
+	res, err := hooksKeeper.PacketCallbacks(sdk.WrapSDKContext(ctx), &types.QueryPacketCallbacksRequest{Pagination: &query.PageRequest{Limit: 2}})
 	suite.Require().NoError(err)
+	suite.Require().Equal([]types.PendingPacketCallback{
+		{Channel: "channel-0", Sequence: 1, Callback: legacy},
+		{Channel: "channel-0", Sequence: 2, Callback: migrated},
+	}, res.Callbacks)
+	res, err = hooksKeeper.PacketCallbacks(sdk.WrapSDKContext(ctx), &types.QueryPacketCallbacksRequest{Pagination: &query.PageRequest{Key: res.Pagination.NextKey}})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.PendingPacketCallback{{Channel: "channel-1", Sequence: 2, Callback: callback}}, res.Callbacks)
+	suite.Require().Nil(res.Pagination.NextKey)
 
-	packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+	res, err = hooksKeeper.PacketCallbacks(sdk.WrapSDKContext(ctx), &types.QueryPacketCallbacksRequest{Channel: "channel-1"})
 	suite.Require().NoError(err)
+	suite.Require().Equal([]types.PendingPacketCallback{{Channel: "channel-1", Sequence: 2, Callback: callback}}, res.Callbacks)
+
+	// Pruning goes by age, oldest first and up to the limit: the callbacks registered before the migration are stale
+	pruneCtx := ctx.WithBlockTime(startTime.Add(90 * time.Minute))
+	suite.Require().Equal(1, hooksKeeper.PruneStaleCallbacks(pruneCtx, time.Hour, 1))
+	suite.Require().Equal(1, hooksKeeper.PruneStaleCallbacks(pruneCtx, time.Hour, 10))
+	suite.Require().Equal(0, hooksKeeper.PruneStaleCallbacks(pruneCtx, time.Hour, 10))
+	suite.Require().Equal([]types.PendingPacketCallback{
+		{Channel: "channel-0", Sequence: 1, Callback: legacy},
+	}, hooksKeeper.GetAllPacketCallbacks(ctx, ""))
+	suite.Require().True(hooksKeeper.IsSerializedPerBlock(ctx, contract))
+
+	// The migrated callback is pruned by the end blocker once it is older than the max age
+	endBlockCtx := ctx.WithBlockTime(migrationCtx.BlockTime().Add(types.PacketCallbackMaxAge + time.Second))
+	ibchooks.NewAppModule(osmosisApp.AccountKeeper, *hooksKeeper).EndBlock(endBlockCtx, abci.RequestEndBlock{})
+	suite.Require().Empty(hooksKeeper.GetAllPacketCallbacks(ctx, ""))
+}
 
-	receiveResult, ack := suite.RelayPacket(packet, direction)
+func (suite *HooksTestSuite) TestMinAmount() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
 
-	return sendResult, receiveResult, string(ack), err
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+
+	testCases := []struct {
+		name      string
+		amount    string
+		minAmount string
+		expPass   bool
+	}{
+		{"amount equal to the minimum", "10", "10", true},
+		{"amount above the minimum", "11", "10", true},
+		{"amount below the minimum", "9", "10", false},
+	}
+
+	for i, tc := range testCases {
+		suite.Run(tc.name, func() {
+			ctx := suite.chainA.GetContext()
+			balanceBefore := osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom)
+
+			memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} }, "min_amount": "%s" } }`, addr, tc.minAmount)
+			packet := suite.makeMockPacketWithAmount(addr.String(), memo, uint64(i), tc.amount)
+			ack := osmosisApp.TransferStack.OnRecvPacket(ctx, packet, relayer)
+
+			balanceAfter := osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom)
+			if tc.expPass {
+				suite.Require().True(ack.Success())
+				amount, _ := sdk.NewIntFromString(tc.amount)
+				suite.Require().Equal(balanceBefore.Amount.Add(amount), balanceAfter.Amount)
+			} else {
+				suite.Require().False(ack.Success())
+				suite.Require().Contains(string(ack.Acknowledgement()), "is below the minimum amount")
+				// The contract was not executed, so it didn't get the funds
+				suite.Require().Equal(balanceBefore.Amount, balanceAfter.Amount)
+			}
+		})
+	}
 }
 
-func (suite *HooksTestSuite) TestAcks() {
-	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
-	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+func (suite *HooksTestSuite) TestFundsAmount() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
 
-	// Generate swap instructions for the contract
-	callbackMemo := fmt.Sprintf(`{"ibc_callback":"%s"}`, addr)
-	// Send IBC transfer with the memo with crosschain-swap instructions
-	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
-	suite.FullSend(transferMsg, AtoB)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+	intermediateSender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	user := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
 
-	// The test contract will increment the counter for itself every time it receives an ack
-	state := suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
-	suite.Require().Equal(`{"count":1}`, state)
+	testCases := []struct {
+		name         string
+		fundsAmount  string
+		postTransfer bool
+		expPass      bool
+		// the amounts the contract and the intermediate sender, or the post transfer address, get out of 10
+		expContract     int64
+		expIntermediate int64
+		expForwarded    int64
+	}{
+		{"exact allocation", "10", false, true, 10, 0, 0},
+		{"partial allocation", "4", false, true, 4, 6, 0},
+		{"zero allocation", "0", false, true, 0, 10, 0},
+		{"partial allocation with a post transfer", "4", true, true, 4, 0, 6},
+		{"over allocation", "11", false, false, 0, 0, 0},
+	}
 
-	suite.FullSend(transferMsg, AtoB)
-	state = suite.chainA.QueryContract(
-		&suite.Suite, addr,
-		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
-	suite.Require().Equal(`{"count":2}`, state)
+	for i, tc := range testCases {
+		suite.Run(tc.name, func() {
+			ctx := suite.chainA.GetContext()
+			balance := func(addr sdk.AccAddress) sdk.Int {
+				return osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom).Amount
+			}
+			contractBefore, intermediateBefore, userBefore := balance(addr), balance(intermediateSender), balance(user)
+
+			postTransfer := ""
+			if tc.postTransfer {
+				postTransfer = fmt.Sprintf(`, "post_transfer_to": "%s"`, user)
+			}
+			memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} }, "funds_amount": "%s"%s } }`, addr, tc.fundsAmount, postTransfer)
+			packet := suite.makeMockPacketWithAmount(addr.String(), memo, uint64(i), "10")
+			ack := osmosisApp.TransferStack.OnRecvPacket(ctx, packet, relayer)
 
+			if !tc.expPass {
+				suite.Require().False(ack.Success())
+				suite.Require().Contains(string(ack.Acknowledgement()), "exceeds the received amount")
+				return
+			}
+			suite.Require().True(ack.Success(), string(ack.Acknowledgement()))
+			suite.Require().Equal(contractBefore.AddRaw(tc.expContract), balance(addr))
+			if tc.postTransfer {
+				// The post transfer forwards the whole balance of the intermediate sender in the packet's denom
+				suite.Require().True(balance(intermediateSender).IsZero())
+				suite.Require().Equal(userBefore.Add(intermediateBefore).AddRaw(tc.expForwarded), balance(user))
+				return
+			}
+			suite.Require().Equal(intermediateBefore.AddRaw(tc.expIntermediate), balance(intermediateSender))
+			suite.Require().Equal(userBefore, balance(user))
+		})
+	}
 }
 
-func (suite *HooksTestSuite) TestSendWithoutMemo() {
-	// Sending a packet without memo to ensure that the ibc_callback middleware doesn't interfere with a regular send
-	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), suite.chainA.SenderAccount.GetAddress().String(), "")
-	_, _, ack, err := suite.FullSend(transferMsg, AtoB)
-	suite.Require().NoError(err)
-	suite.Require().Contains(ack, "result")
+// A hook fee is paid to the relayer of the packet out of its funds. The error ack of a failed hook reverts the payment
+// along with the receive, so the sender is refunded in full.
+func (suite *HooksTestSuite) TestHookFeeFromFunds() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	params := types.DefaultParams()
+	params.HookFeesEnabled = true
+	params.HookFeeDenoms = []string{localDenom}
+	osmosisApp.IBCHooksKeeper.SetParams(suite.chainA.GetContext(), params)
+	// The packets are received by chain A's sender account
+	relayer := suite.chainA.SenderAccount.GetAddress()
+	intermediateSender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+
+	testCases := []struct {
+		name    string
+		msg     string
+		expPass bool
+		// the amounts the relayer and the contract get out of 10
+		expRelayer  int64
+		expContract int64
+	}{
+		{"executed", `{"echo": {"msg": "test"}}`, true, 3, 7},
+		{"failed execution", `{"not_echo": {"msg": "test"}}`, false, 0, 0},
+	}
+
+	for i, tc := range testCases {
+		suite.Run(tc.name, func() {
+			balance := func(addr sdk.AccAddress) sdk.Int {
+				return osmosisApp.BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom).Amount
+			}
+			relayerBefore, contractBefore, intermediateBefore := balance(relayer), balance(addr), balance(intermediateSender)
+
+			memo := testutils.WasmMemoWithFields(addr.String(), tc.msg, `"fee_from_funds": "3"`)
+			ackBytes := suite.receivePacketOnPathWithAmount(suite.path, addr.String(), memo, uint64(i), "10")
+			if tc.expPass {
+				testutils.RequireContractAck(suite.T(), ackBytes)
+			} else {
+				testutils.RequireErrorAck(suite.T(), ackBytes, wasmtypes.ErrExecuteFailed.Error())
+			}
+
+			suite.Require().Equal(relayerBefore.AddRaw(tc.expRelayer), balance(relayer))
+			suite.Require().Equal(contractBefore.AddRaw(tc.expContract), balance(addr))
+			suite.Require().Equal(intermediateBefore, balance(intermediateSender))
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateFeeFromFunds() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+
+	testCases := []struct {
+		name         string
+		feeFromFunds string
+		expAmount    sdk.Int
+		expErr       bool
+	}{
+		{"no fee", "", sdk.Int{}, false},
+		{"positive fee", `"10"`, sdk.NewInt(10), false},
+		{"zero fee", `"0"`, sdk.Int{}, true},
+		{"negative fee", `"-1"`, sdk.Int{}, true},
+		{"decimal fee", `"1.5"`, sdk.Int{}, true},
+		{"non string fee", `10`, sdk.Int{}, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			fields := ""
+			if tc.feeFromFunds != "" {
+				fields = fmt.Sprintf(`"fee_from_funds": %s`, tc.feeFromFunds)
+			}
+			memo := testutils.WasmMemoWithFields(addr.String(), `{"echo": {"msg": "test"}}`, fields)
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, addr.String(), suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expAmount.IsNil(), parsed.FeeFromFunds.IsNil())
+			if !tc.expAmount.IsNil() {
+				suite.Require().Equal(tc.expAmount, parsed.FeeFromFunds)
+			}
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateFundsAmount() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+
+	testCases := []struct {
+		name        string
+		fundsAmount string
+		expAmount   sdk.Int
+		expErr      bool
+	}{
+		{"no funds amount", "", sdk.Int{}, false},
+		{"positive funds amount", `"10"`, sdk.NewInt(10), false},
+		{"zero funds amount", `"0"`, sdk.ZeroInt(), false},
+		{"negative funds amount", `"-1"`, sdk.Int{}, true},
+		{"decimal funds amount", `"1.5"`, sdk.Int{}, true},
+		{"non string funds amount", `10`, sdk.Int{}, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			fundsAmountField := ""
+			if tc.fundsAmount != "" {
+				fundsAmountField = fmt.Sprintf(`"funds_amount": %s`, tc.fundsAmount)
+			}
+			memo := testutils.WasmMemoWithFields(addr.String(), `{"echo": {"msg": "test"}}`, fundsAmountField)
+
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, addr.String(), suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			if tc.expAmount.IsNil() {
+				suite.Require().True(parsed.FundsAmount.IsNil())
+			} else {
+				suite.Require().Equal(tc.expAmount, parsed.FundsAmount)
+			}
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateMinAmount() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+
+	testCases := []struct {
+		name      string
+		minAmount string
+		expErr    bool
+	}{
+		{"no minimum amount", "", false},
+		{"positive minimum amount", `"10"`, false},
+		{"zero minimum amount", `"0"`, true},
+		{"negative minimum amount", `"-1"`, true},
+		{"decimal minimum amount", `"1.5"`, true},
+		{"non string minimum amount", `10`, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			minAmountField := ""
+			if tc.minAmount != "" {
+				minAmountField = fmt.Sprintf(`"min_amount": %s`, tc.minAmount)
+			}
+			memo := testutils.WasmMemoWithFields(addr.String(), `{"echo": {"msg": "test"}}`, minAmountField)
+
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, addr.String(), suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			if tc.minAmount == "" {
+				suite.Require().True(parsed.MinAmount.IsNil())
+			} else {
+				suite.Require().Equal(sdk.NewInt(10), parsed.MinAmount)
+			}
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidatePostTransfer() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+	user := suite.chainB.SenderAccount.GetAddress()
+	cosmosAddr, err := bech32.ConvertAndEncode("cosmos", user)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name         string
+		postTransfer string
+		expTo        sdk.AccAddress
+		expDenom     string
+		expErr       bool
+	}{
+		{"no post transfer", "", nil, "", false},
+		{"post transfer of all received denoms", fmt.Sprintf(`"post_transfer_to": "%s"`, user), user, "", false},
+		{"post transfer of a denom", fmt.Sprintf(`"post_transfer_to": "%s", "post_transfer_denom": "uosmo"`, user), user, "uosmo", false},
+		{"address of another chain", fmt.Sprintf(`"post_transfer_to": "%s"`, cosmosAddr), nil, "", true},
+		{"invalid address", `"post_transfer_to": "osmo1invalid"`, nil, "", true},
+		{"non string address", `"post_transfer_to": 1`, nil, "", true},
+		{"invalid denom", fmt.Sprintf(`"post_transfer_to": "%s", "post_transfer_denom": "1"`, user), nil, "", true},
+		{"denom without address", `"post_transfer_denom": "uosmo"`, nil, "", true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			memo := testutils.WasmMemoWithFields(addr.String(), `{"echo": {"msg": "test"}}`, tc.postTransfer)
+
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, addr.String(), suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expTo, parsed.PostTransfer.To)
+			suite.Require().Equal(tc.expDenom, parsed.PostTransfer.Denom)
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateAckTransfer() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+	user := suite.chainB.SenderAccount.GetAddress().String()
+
+	testCases := []struct {
+		name           string
+		ackTransfer    string
+		expAckTransfer ibchooks.AckTransfer
+		expErr         bool
+	}{
+		{"no ack transfer", "", ibchooks.AckTransfer{}, false},
+		{"ack transfer", fmt.Sprintf(`"ack_transfer": {"channel": "channel-0", "to": "%s"}`, user), ibchooks.AckTransfer{Channel: "channel-0", To: user}, false},
+		{"non object ack transfer", `"ack_transfer": "channel-0"`, ibchooks.AckTransfer{}, true},
+		{"invalid channel", fmt.Sprintf(`"ack_transfer": {"channel": "transfer", "to": "%s"}`, user), ibchooks.AckTransfer{}, true},
+		{"no channel", fmt.Sprintf(`"ack_transfer": {"to": "%s"}`, user), ibchooks.AckTransfer{}, true},
+		{"no receiver", `"ack_transfer": {"channel": "channel-0"}`, ibchooks.AckTransfer{}, true},
+		{"blank receiver", `"ack_transfer": {"channel": "channel-0", "to": " "}`, ibchooks.AckTransfer{}, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			memo := testutils.WasmMemoWithFields(addr.String(), `{"echo": {"msg": "test"}}`, tc.ackTransfer)
+
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, addr.String(), suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expAckTransfer, parsed.AckTransfer)
+		})
+	}
+}
+
+// A hooked packet asking for its result to be sent back gets a return transfer of 1 unit of the received denom to
+// the remote address, with the result in its memo, once the contract was executed successfully.
+func (suite *HooksTestSuite) TestAckTransfer() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+
+	channel := suite.path.EndpointA.ChannelID
+	user := suite.chainB.SenderAccount.GetAddress()
+	intermediateSender := ibchooks.DeriveIntermediateSender(channel, user.String())
+	receivedDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	balance := func(addr sdk.AccAddress) sdk.Int {
+		return osmosisApp.BankKeeper.GetBalance(suite.chainA.GetContext(), addr, receivedDenom).Amount
+	}
+	userBalance := func() sdk.Int {
+		return suite.chainB.GetOsmosisApp().BankKeeper.GetBalance(suite.chainB.GetContext(), user, sdk.DefaultBondDenom).Amount
+	}
+	ackTransferField := fmt.Sprintf(`"ack_transfer": {"channel": "%s", "to": "%s"}`, channel, user)
+
+	testCases := []struct {
+		name        string
+		msg         string
+		enabled     bool
+		expErr      string
+		expContract int64
+	}{
+		{"executed", `{"echo": {"msg": "test"}}`, true, "", 999},
+		{"failed execution", `{"not_echo": {"msg": "test"}}`, true, wasmtypes.ErrExecuteFailed.Error(), 0},
+		{"disabled", `{"echo": {"msg": "test"}}`, false, types.ErrAckTransferNotAllowed, 0},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			params := types.DefaultParams()
+			params.AckTransferEnabled = tc.enabled
+			osmosisApp.IBCHooksKeeper.SetParams(suite.chainA.GetContext(), params)
+			contractBefore, userBefore := balance(addr), userBalance()
+
+			memo := testutils.WasmMemoWithFields(addr.String(), tc.msg, ackTransferField)
+			hookMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), user.String(), addr.String(), memo)
+			sendResult, receiveResult, ack, err := suite.FullSend(hookMsg, BtoA)
+			suite.Require().NoError(err)
+			received, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+			suite.Require().NoError(err)
+			suite.Require().Equal(contractBefore.AddRaw(tc.expContract), balance(addr))
+			suite.Require().True(balance(intermediateSender).IsZero())
+
+			returned, err := ibctesting.ParsePacketFromEvents(receiveResult.GetEvents())
+			if tc.expErr != "" {
+				// The packet is refunded, without a return transfer
+				testutils.RequireErrorAck(suite.T(), []byte(ack), tc.expErr)
+				suite.Require().Error(err)
+				suite.Require().Empty(resultEvents(receiveResult, types.TypeEvtAckTransfer))
+				suite.Require().Equal(userBefore, userBalance())
+				return
+			}
+			contractAck := testutils.RequireContractAck(suite.T(), []byte(ack))
+			suite.Require().NoError(err)
+			suite.Require().Equal(channel, returned.GetSourceChannel())
+			suite.Require().Equal([]map[string]string{{
+				types.AttributeContract:  addr.String(),
+				types.AttributeChannel:   channel,
+				types.AttributeRecipient: user.String(),
+				sdk.AttributeKeyAmount:   sdk.NewCoin(receivedDenom, ibchooks.AckTransferAmount).String(),
+			}}, resultEvents(receiveResult, types.TypeEvtAckTransfer))
+
+			// The return transfer carries the result of the execution to the user
+			var data transfertypes.FungibleTokenPacketData
+			suite.Require().NoError(transfertypes.ModuleCdc.UnmarshalJSON(returned.GetData(), &data))
+			suite.Require().Equal(user.String(), data.Receiver)
+			suite.Require().Equal(intermediateSender.String(), data.Sender)
+			suite.Require().Equal(ibchooks.AckTransferAmount.String(), data.Amount)
+			var returnedMemo ibchooks.AckTransferMemo
+			suite.Require().NoError(json.Unmarshal([]byte(data.GetMemo()), &returnedMemo))
+			resultHash := sha256.Sum256(contractAck.ContractResult)
+			suite.Require().Equal(ibchooks.HookResult{
+				Channel:    channel,
+				Sequence:   fmt.Sprint(received.GetSequence()),
+				Contract:   addr.String(),
+				Success:    true,
+				ResultHash: hex.EncodeToString(resultHash[:]),
+			}, returnedMemo.HookResult)
+
+			// The user gets the return transfer back on chain B, after paying 1000 for the hooked packet
+			_, returnAck := suite.RelayPacket(returned, AtoB)
+			suite.Require().JSONEq(`{"result":"AQ=="}`, string(returnAck))
+			suite.Require().Equal(userBefore.SubRaw(1000).Add(ibchooks.AckTransferAmount), userBalance())
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateContractPrefix() {
+	addr := suite.chainA.SenderAccount.GetAddress()
+	withPrefix := func(hrp string) string {
+		bech32Addr, err := bech32.ConvertAndEncode(hrp, addr)
+		suite.Require().NoError(err)
+		return bech32Addr
+	}
+	// flip the last character, which is part of the checksum
+	osmoAddr := addr.String()
+	lastChar := byte('q')
+	if osmoAddr[len(osmoAddr)-1] == lastChar {
+		lastChar = 'p'
+	}
+	badChecksum := osmoAddr[:len(osmoAddr)-1] + string(lastChar)
+
+	testCases := []struct {
+		name     string
+		contract string
+		expErr   string
+	}{
+		{"osmo prefix", withPrefix("osmo"), ""},
+		{"cosmos prefix", withPrefix("cosmos"), "has bech32 prefix cosmos, expected osmo"},
+		{"juno prefix", withPrefix("juno"), "has bech32 prefix juno, expected osmo"},
+		{"invalid checksum", badChecksum, "is not a valid bech32 address"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			memo := testutils.WasmMemo(tc.contract, `{"echo": {"msg": "test"}}`)
+
+			isWasmRouted, parsed, err := ibchooks.ValidateAndParseMemo(memo, tc.contract, suite.chainA.ChainID)
+			suite.Require().True(isWasmRouted)
+			if tc.expErr != "" {
+				suite.Require().ErrorContains(err, tc.expErr)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(addr, parsed.Contract)
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestValidateMemoWasmValue() {
+	testCases := []struct {
+		name            string
+		wasm            string
+		expIsWasmRouted bool
+	}{
+		{"null", `null`, false},
+		{"empty object", `{}`, true},
+		{"string", `"test"`, true},
+		{"number", `1`, true},
+		{"array", `[]`, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			memo := fmt.Sprintf(`{"wasm": %s}`, tc.wasm)
+			isWasmRouted, _, err := ibchooks.ValidateAndParseMemo(memo, "", suite.chainA.ChainID)
+			suite.Require().Equal(tc.expIsWasmRouted, isWasmRouted)
+			// none of them is a valid hook, so they either pass through or are rejected
+			if tc.expIsWasmRouted {
+				suite.Require().ErrorContains(err, "wasm metadata")
+			} else {
+				suite.Require().NoError(err)
+			}
+		})
+	}
+}
+
+// A packet with a null wasm key should be received as if it had no memo
+func (suite *HooksTestSuite) TestNullWasmMemoPassthrough() {
+	receiver := suite.chainB.SenderAccount.GetAddress()
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+	bankKeeper := suite.chainA.GetOsmosisApp().BankKeeper
+
+	balanceBefore := bankKeeper.GetBalance(suite.chainA.GetContext(), receiver, localDenom)
+	ackBytes := suite.receivePacket(receiver.String(), `{"wasm": null}`)
+	var ack map[string]string
+	suite.Require().NoError(json.Unmarshal(ackBytes, &ack))
+	suite.Require().Equal("AQ==", ack["result"])
+
+	// the funds went to the receiver of the packet, not to the intermediate sender
+	balanceAfter := bankKeeper.GetBalance(suite.chainA.GetContext(), receiver, localDenom)
+	suite.Require().Equal(balanceBefore.Amount.AddRaw(1), balanceAfter.Amount)
+	suite.Require().True(bankKeeper.GetBalance(suite.chainA.GetContext(), sender, localDenom).IsZero())
+}
+
+// A hook scoped to another chain, or meant for the final hop of a forwarded packet, is treated as absent.
+// wasm["chain"] takes precedence over the forward key.
+func (suite *HooksTestSuite) TestValidateMemoHookScope() {
+	addr := suite.chainA.SenderAccount.GetAddress().String()
+	localChain := suite.chainA.ChainID
+	forward := `"forward": {"receiver": "juno1receiver", "port": "transfer", "channel": "channel-42"}`
+
+	testCases := []struct {
+		name            string
+		chain           string
+		forward         bool
+		expIsWasmRouted bool
+		expErr          string
+	}{
+		{"unscoped", ``, false, true, ""},
+		{"this chain", fmt.Sprintf(`"%s"`, localChain), false, true, ""},
+		{"other chain", `"juno-1"`, false, false, ""},
+		{"forwarded", ``, true, false, ""},
+		{"forwarded, scoped to this chain", fmt.Sprintf(`"%s"`, localChain), true, true, ""},
+		{"forwarded, scoped to the final hop", `"juno-1"`, true, false, ""},
+		{"chain is not a string", `1`, false, true, `wasm["chain"] is not a chain id`},
+		{"empty chain", `""`, false, true, `wasm["chain"] is not a chain id`},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			wasm := fmt.Sprintf(`"contract": "%s", "msg": {"echo": {"msg": "test"}}`, addr)
+			if tc.chain != "" {
+				wasm += fmt.Sprintf(`, "chain": %s`, tc.chain)
+			}
+			memo := fmt.Sprintf(`{"wasm": {%s}}`, wasm)
+			if tc.forward {
+				memo = fmt.Sprintf(`{"wasm": {%s}, %s}`, wasm, forward)
+			}
+
+			isWasmRouted, _, err := ibchooks.ValidateAndParseMemo(memo, addr, localChain)
+			suite.Require().Equal(tc.expIsWasmRouted, isWasmRouted)
+			if tc.expErr != "" {
+				suite.Require().ErrorContains(err, tc.expErr)
+				return
+			}
+			suite.Require().NoError(err)
+		})
+	}
+}
+
+// On an intermediate hop, a packet whose hook is meant for the final hop is received as a plain transfer, while
+// on the terminal chain the hook is executed
+func (suite *HooksTestSuite) TestForwardedHookIntermediateHop() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	receiver := suite.chainB.SenderAccount.GetAddress()
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	bankKeeper := suite.chainA.GetOsmosisApp().BankKeeper
+
+	// the funds stay with the receiver of the packet on the intermediate hop, and nothing is executed
+	balanceBefore := bankKeeper.GetBalance(suite.chainA.GetContext(), receiver, localDenom)
+	intermediateMemo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"}}}, "forward": {"receiver": "%s", "port": "transfer", "channel": "channel-42"}}`, addr, addr)
+	ack := suite.receivePacketWithSequence(receiver.String(), intermediateMemo, 0)
+	suite.Require().JSONEq(`{"result":"AQ=="}`, string(ack))
+	balanceAfter := bankKeeper.GetBalance(suite.chainA.GetContext(), receiver, localDenom)
+	suite.Require().Equal(balanceBefore.Amount.AddRaw(1), balanceAfter.Amount)
+
+	// a hook scoped to this chain is executed
+	terminalMemo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"}}, "chain": "%s"}}`, addr, suite.chainA.ChainID)
+	ack = suite.receivePacketWithSequence(addr.String(), terminalMemo, 1)
+	suite.Require().NotContains(string(ack), "error")
+}
+
+// encodePayloadMemo returns the memo holding payload
+func (suite *HooksTestSuite) encodePayloadMemo(payload types.WasmHookPayload) string {
+	memo, err := payload.EncodeMemo()
+	suite.Require().NoError(err)
+	return memo
+}
+
+func (suite *HooksTestSuite) TestProtoPayloadMemo() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+
+	// A proto payload is executed as the equivalent JSON memo
+	jsonAck := suite.receivePacketWithSequence(addr.String(), fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"}}}}`, addr), 0)
+	protoAck := suite.receivePacketWithSequence(addr.String(), suite.encodePayloadMemo(types.WasmHookPayload{
+		Contract: addr.String(),
+		Msg:      []byte(`{"echo": {"msg": "test"}}`),
+	}), 1)
+	suite.Require().NotContains(string(jsonAck), "error")
+	suite.Require().Equal(jsonAck, protoAck)
+
+	otherMessage, err := codectypes.NewAnyWithValue(&types.PacketCallback{Contract: addr.String()})
+	suite.Require().NoError(err)
+	otherMessageBz, err := otherMessage.Marshal()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name           string
+		memo           string
+		expPassthrough bool
+	}{
+		{"empty payload", suite.encodePayloadMemo(types.WasmHookPayload{}), true},
+		{"other proto message", base64.StdEncoding.EncodeToString(otherMessageBz), true},
+		{"base64 but not proto", base64.StdEncoding.EncodeToString([]byte("test")), true},
+		{"neither JSON nor base64", "test", true},
+		{"msg not JSON", suite.encodePayloadMemo(types.WasmHookPayload{Contract: addr.String(), Msg: []byte("test")}), false},
+		{"msg not an object", suite.encodePayloadMemo(types.WasmHookPayload{Contract: addr.String(), Msg: []byte("1")}), false},
+		{"no msg", suite.encodePayloadMemo(types.WasmHookPayload{Contract: addr.String()}), false},
+		{"contract is not the receiver", suite.encodePayloadMemo(types.WasmHookPayload{
+			Contract: suite.chainA.SenderAccount.GetAddress().String(),
+			Msg:      []byte(`{"echo": {"msg": "test"}}`),
+		}), false},
+	}
+
+	sequence := uint64(2)
+	for _, tc := range testCases {
+		ackBytes := suite.receivePacketWithSequence(addr.String(), tc.memo, sequence)
+		var ack map[string]string
+		suite.Require().NoError(json.Unmarshal(ackBytes, &ack), tc.name)
+		if tc.expPassthrough {
+			suite.Require().Equal("AQ==", ack["result"], tc.name)
+		} else {
+			suite.Require().Contains(string(ackBytes), "error", tc.name)
+		}
+		sequence += 1
+	}
+}
+
+func (suite *HooksTestSuite) TestProtoPayloadCallback() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	receiver := suite.chainB.SenderAccount.GetAddress().String()
+
+	testCases := []struct {
+		name    string
+		payload types.WasmHookPayload
+		// expMemo is the memo of the packet sent once the callback is removed
+		expMemo string
+	}{
+		{"callback only", types.WasmHookPayload{Callback: addr.String()}, ""},
+		{
+			"callback and contract",
+			types.WasmHookPayload{Contract: receiver, Msg: []byte(`{"echo": {}}`), Callback: addr.String()},
+			suite.encodePayloadMemo(types.WasmHookPayload{Contract: receiver, Msg: []byte(`{"echo": {}}`)}),
+		},
+	}
+
+	for _, tc := range testCases {
+		transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), receiver, suite.encodePayloadMemo(tc.payload))
+		sendResult, err := suite.chainA.SendMsgsNoCheck(transferMsg)
+		suite.Require().NoError(err)
+		packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+		suite.Require().NoError(err)
+
+		var data transfertypes.FungibleTokenPacketData
+		suite.Require().NoError(json.Unmarshal(packet.GetData(), &data))
+		suite.Require().Equal(tc.expMemo, data.Memo, tc.name)
+
+		// The callback is registered as for the ibc_callback key of a JSON memo
+		callback, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), packet.GetSourceChannel(), packet.GetSequence())
+		suite.Require().True(found, tc.name)
+		suite.Require().Equal(addr.String(), callback.Contract)
+		suite.Require().Equal(types.CallbackEntrySudo, callback.Entry)
+	}
+
+	// and the contract is notified of the ack
+	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), receiver,
+		suite.encodePayloadMemo(types.WasmHookPayload{Callback: addr.String()}))
+	suite.FullSend(transferMsg, AtoB)
+	state := suite.chainA.QueryContract(&suite.Suite, addr, []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
+	suite.Require().Equal(`{"count":1}`, state)
+}
+
+func (suite *HooksTestSuite) TestPacketsThatShouldBeSkipped() {
+	var sequence uint64
+	receiver := suite.chainB.SenderAccount.GetAddress().String()
+
+	testCases := []struct {
+		memo           string
+		expPassthrough bool
+	}{
+		{"", true},
+		{"{01]", true}, // bad json
+		{"{}", true},
+		{`{"something": ""}`, true},
+		{`{"wasm": null}`, true}, // null is treated as absent
+		{`{"wasm": "test"}`, false},
+		{`{"wasm": 1}`, false},
+		{`{"wasm": []`, true}, // invalid top level JSON
+		{`{"wasm": {}`, true}, // invalid top level JSON
+		{`{"wasm": []}`, false},
+		{`{"wasm": {}}`, false},
+		{`{"wasm": {"contract": "something"}}`, false},
+		{`{"wasm": {"contract": "osmo1clpqr4nrk4khgkxj78fcwwh6dl3uw4epasmvnj"}}`, false},
+		{`{"wasm": {"msg": "something"}}`, false},
+		// invalid receiver
+		{`{"wasm": {"contract": "osmo1clpqr4nrk4khgkxj78fcwwh6dl3uw4epasmvnj", "msg": {}}}`, false},
+		// msg not an object
+		{fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": 1}}`, receiver), false},
+	}
+
+	for _, tc := range testCases {
+		ackBytes := suite.receivePacketWithSequence(receiver, tc.memo, sequence)
+		ackStr := string(ackBytes)
+		fmt.Println(ackStr)
+		var ack map[string]string // This can't be unmarshalled to Acknowledgement because it's fetched from the events
+		err := json.Unmarshal(ackBytes, &ack)
+		suite.Require().NoError(err)
+		if tc.expPassthrough {
+			suite.Require().Equal("AQ==", ack["result"], tc.memo)
+		} else {
+			suite.Require().Contains(ackStr, "error", tc.memo)
+		}
+		sequence += 1
+	}
+}
+
+// After successfully executing a wasm call, the contract should have the funds sent via IBC
+func (suite *HooksTestSuite) TestFundTracking() {
+	// Setup contract
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+
+	// Check that the contract has no funds
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	balance := suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
+	suite.Require().Equal(sdk.NewInt(0), balance.Amount)
+
+	// Execute the contract via IBC
+	suite.receivePacket(
+		addr.String(),
+		fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {} } } }`, addr))
+
+	// the contract is executed by the intermediate sender derived from the remote sender
+	sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+
+	state := suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender)))
+	suite.Require().Equal(`{"count":0}`, state)
+
+	state = suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_total_funds": {"addr": "%s"}}`, sender)))
+	suite.Require().Equal(`{"total_funds":[{"denom":"ibc/C053D637CCA2A2BA030E2C5EE1B28A16F71CCB0E45E8BE52766DC1B241B77878","amount":"1"}]}`, state)
+
+	suite.receivePacketWithSequence(
+		addr.String(),
+		fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {} } } }`, addr), 1)
+
+	state = suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender)))
+	suite.Require().Equal(`{"count":1}`, state)
+
+	state = suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_total_funds": {"addr": "%s"}}`, sender)))
+	suite.Require().Equal(`{"total_funds":[{"denom":"ibc/C053D637CCA2A2BA030E2C5EE1B28A16F71CCB0E45E8BE52766DC1B241B77878","amount":"2"}]}`, state)
+
+	// Check that the token has now been transferred to the contract
+	balance = suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
+	suite.Require().Equal(sdk.NewInt(2), balance.Amount)
+}
+
+// Only packets of the allowed denoms are routed into contracts, the others are rejected and refunded
+func (suite *HooksTestSuite) TestAllowedHookDenoms() {
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+
+	testCases := []struct {
+		name              string
+		allowedHookDenoms []string
+		// malformedMemo sets a memo routed to wasm that fails validation
+		malformedMemo bool
+		expErr        string
+	}{
+		{"empty list allows all denoms", nil, false, ""},
+		{"allowed denom", []string{"uosmo", localDenom}, false, ""},
+		{"disallowed denom", []string{"uosmo"}, false, fmt.Sprintf(types.ErrDenomNotAllowed, localDenom)},
+		{"disallowed denom is checked before the memo", []string{"uosmo"}, true, fmt.Sprintf(types.ErrDenomNotAllowed, localDenom)},
+		{"allowed denom, malformed memo", []string{localDenom}, true, "wasm metadata not properly formatted"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			osmosisApp := suite.chainA.GetOsmosisApp()
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+			addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+			osmosisApp.IBCHooksKeeper.SetParams(suite.chainA.GetContext(), types.NewParams("", nil, tc.allowedHookDenoms, 0, nil, types.DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, ""))
+
+			memo := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"echo": {"msg": "test"} } } }`, addr)
+			if tc.malformedMemo {
+				memo = `{"wasm": {"msg": {"echo": {"msg": "test"} } } }`
+			}
+			balanceBefore := osmosisApp.BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
+			ackBytes := suite.receivePacket(addr.String(), memo)
+			var ack map[string]string
+			suite.Require().NoError(json.Unmarshal(ackBytes, &ack))
+
+			balanceAfter := osmosisApp.BankKeeper.GetBalance(suite.chainA.GetContext(), addr, localDenom)
+			if tc.expErr != "" {
+				suite.Require().Contains(ack["error"], tc.expErr)
+				// the funds are refunded on the sender chain instead of being left on the contract
+				suite.Require().Equal(balanceBefore, balanceAfter)
+				return
+			}
+
+			suite.Require().NotContains(ack, "error")
+			suite.Require().Equal(balanceBefore.Amount.AddRaw(1), balanceAfter.Amount)
+			suite.Require().NotEqual("AQ==", ack["result"])
+		})
+	}
+}
+
+// The observer contract is notified of hooked executions on the observed channels, without affecting the packets.
+// Failed executions are notified at the end of the block, as the state changes of their packet are reverted.
+func (suite *HooksTestSuite) TestObserverContract() {
+	testCases := []struct {
+		name string
+		// observer is "counter" for an observer that counts notifications per executed contract,
+		// "echo" for one that has no sudo entry point and always errors, or empty for no observer
+		observer         string
+		observedChannels []string
+		hookedMsg        string
+
+		expAckSuccess bool
+		expNotified   bool
+	}{
+		{"no observer", "", nil, `{"increment": {}}`, true, false},
+		{"counting observer", "counter", nil, `{"increment": {}}`, true, true},
+		{"counting observer on the packet's channel", "counter", []string{"channel-0"}, `{"increment": {}}`, true, true},
+		{"counting observer on another channel", "counter", []string{"channel-9"}, `{"increment": {}}`, true, false},
+		{"reverting observer", "echo", nil, `{"increment": {}}`, true, false},
+		{"reverting observer, failed execution", "echo", nil, `{"unknown": {}}`, false, false},
+		{"counting observer, failed execution", "counter", nil, `{"unknown": {}}`, false, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+			addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+			suite.Require().Equal("channel-0", suite.path.EndpointA.ChannelID)
+
+			var observer sdk.AccAddress
+			switch tc.observer {
+			case "counter":
+				observer = suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+				if tc.expNotified {
+					suite.skipUnlessCounterHandles(observer, "hook_executed", true)
+				}
+			case "echo":
+				observer = suite.chainA.InstantiateContract(&suite.Suite, `{}`, 2)
+			}
+			if observer != nil {
+				suite.chainA.GetOsmosisApp().IBCHooksKeeper.SetParams(
+					suite.chainA.GetContext(), types.NewParams(observer.String(), tc.observedChannels, nil, 0, nil, types.DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, ""))
+			}
+
+			ack := suite.receivePacket(
+				addr.String(),
+				fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": %s } }`, addr, tc.hookedMsg))
+			suite.Require().Equal(tc.expAckSuccess, !strings.Contains(string(ack), "error"), string(ack))
+
+			if tc.expAckSuccess {
+				sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+				state := suite.chainA.QueryContract(
+					&suite.Suite, addr,
+					[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender)))
+				suite.Require().Equal(`{"count":0}`, state)
+			}
+
+			// deliver the notifications of failed executions
+			osmosisApp := suite.chainA.GetOsmosisApp()
+			ibchooks.NewAppModule(osmosisApp.AccountKeeper, *osmosisApp.IBCHooksKeeper).EndBlock(suite.chainA.GetContext(), abci.RequestEndBlock{})
+
+			if tc.observer != "counter" {
+				return
+			}
+			query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr))
+			if !tc.expNotified {
+				_, err := suite.chainA.GetOsmosisApp().WasmKeeper.QuerySmart(suite.chainA.GetContext(), observer, query)
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().Equal(`{"count":1}`, suite.chainA.QueryContract(&suite.Suite, observer, query))
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestChannelHookStats() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+
+	secondPath := NewTransferPath(suite.chainA, suite.chainB)
+	suite.coordinator.Setup(secondPath)
+	suite.Require().Equal("channel-0", suite.path.EndpointA.ChannelID)
+	suite.Require().Equal("channel-1", secondPath.EndpointA.ChannelID)
+
+	succeeding := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {}} } }`, addr)
+	failing := fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"unknown": {}} } }`, addr)
+	packets := []struct {
+		path *ibctesting.Path
+		memo string
+	}{
+		{suite.path, succeeding},
+		{secondPath, failing},
+		{suite.path, failing},
+		{secondPath, succeeding},
+		{suite.path, succeeding},
+		{secondPath, failing},
+		// not a hooked packet
+		{suite.path, ""},
+	}
+	sequences := map[*ibctesting.Path]uint64{}
+	for _, p := range packets {
+		receiver := addr.String()
+		if p.memo == "" {
+			receiver = suite.chainA.SenderAccount.GetAddress().String()
+		}
+		suite.receivePacketOnPath(p.path, receiver, p.memo, sequences[p.path])
+		sequences[p.path]++
+	}
+
+	// the failures are counted at the end of the block
+	ibchooks.NewAppModule(osmosisApp.AccountKeeper, *osmosisApp.IBCHooksKeeper).EndBlock(suite.chainA.GetContext(), abci.RequestEndBlock{})
+
+	queryStats := func(channel string) types.ChannelHookStats {
+		res, err := osmosisApp.IBCHooksKeeper.ChannelHookStats(sdk.WrapSDKContext(suite.chainA.GetContext()), &types.QueryChannelHookStatsRequest{Channel: channel})
+		suite.Require().NoError(err)
+		return res.Stats
+	}
+	receivedDenom := func(path *ibctesting.Path) string {
+		return transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(path.EndpointA.ChannelConfig.PortID, path.EndpointA.ChannelID, sdk.DefaultBondDenom)).IBCDenom()
+	}
+
+	stats := queryStats("channel-0")
+	suite.Require().Equal("channel-0", stats.Channel)
+	suite.Require().Equal(uint64(3), stats.Current.HookedPackets)
+	suite.Require().Equal(uint64(1), stats.Current.ErrorAcks)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(receivedDenom(suite.path), 2)), stats.Current.Routed)
+	suite.Require().Empty(stats.History)
+
+	stats = queryStats("channel-1")
+	suite.Require().Equal(uint64(3), stats.Current.HookedPackets)
+	suite.Require().Equal(uint64(2), stats.Current.ErrorAcks)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(receivedDenom(secondPath), 1)), stats.Current.Routed)
+
+	stats = queryStats("channel-2")
+	suite.Require().Equal(types.ChannelHookStats{Channel: "channel-2"}, stats)
+	_, err := osmosisApp.IBCHooksKeeper.ChannelHookStats(sdk.WrapSDKContext(suite.chainA.GetContext()), &types.QueryChannelHookStatsRequest{Channel: "invalid"})
+	suite.Require().Error(err)
+
+	// the stats only roll over at the end of the stats epoch
+	hooks := osmosisApp.IBCHooksKeeper.EpochHooks()
+	suite.Require().NoError(hooks.AfterEpochEnd(suite.chainA.GetContext(), "week", 4))
+	suite.Require().Equal(uint64(3), queryStats("channel-0").Current.HookedPackets)
+
+	suite.Require().NoError(hooks.AfterEpochEnd(suite.chainA.GetContext(), types.StatsEpochIdentifier, 4))
+	for _, channel := range []string{"channel-0", "channel-1"} {
+		rolled := queryStats(channel)
+		suite.Require().Equal(types.ChannelHookStatsPeriod{}, rolled.Current)
+		suite.Require().Len(rolled.History, 1)
+		suite.Require().Equal(int64(4), rolled.History[0].EpochNumber)
+		suite.Require().Equal(uint64(3), rolled.History[0].HookedPackets)
+	}
+	suite.Require().Equal(uint64(1), queryStats("channel-0").History[0].ErrorAcks)
+	suite.Require().Equal(uint64(2), queryStats("channel-1").History[0].ErrorAcks)
+}
+
+// The routed amounts only keep the top denoms and the history only keeps the most recent periods
+func (suite *HooksTestSuite) TestChannelHookStatsBounds() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx := suite.chainA.GetContext()
+	packet := channeltypes.Packet{DestinationPort: "transfer", DestinationChannel: "channel-0", Sequence: 1}
+
+	routed := sdk.NewCoins()
+	for i := 1; i <= types.MaxChannelHookStatsDenoms+2; i++ {
+		routed = routed.Add(sdk.NewInt64Coin(fmt.Sprintf("denom%02d", i), int64(i)))
+	}
+	osmosisApp.IBCHooksKeeper.RecordHookedPacket(ctx, packet, routed, true)
+	stats := osmosisApp.IBCHooksKeeper.GetChannelHookStats(ctx, "channel-0")
+	suite.Require().Len(stats.Current.Routed, types.MaxChannelHookStatsDenoms)
+	suite.Require().True(stats.Current.Routed.AmountOf("denom01").IsZero())
+	suite.Require().True(stats.Current.Routed.AmountOf("denom02").IsZero())
+	suite.Require().Equal(sdk.NewInt(3), stats.Current.Routed.AmountOf("denom03"))
+
+	// an epoch without hooked packets doesn't add a period
+	hooks := osmosisApp.IBCHooksKeeper.EpochHooks()
+	suite.Require().NoError(hooks.AfterEpochEnd(ctx, types.StatsEpochIdentifier, 1))
+	suite.Require().NoError(hooks.AfterEpochEnd(ctx, types.StatsEpochIdentifier, 2))
+	suite.Require().Len(osmosisApp.IBCHooksKeeper.GetChannelHookStats(ctx, "channel-0").History, 1)
+
+	for epoch := int64(3); epoch < 3+types.ChannelHookStatsHistoryLength; epoch++ {
+		osmosisApp.IBCHooksKeeper.RecordHookedPacket(ctx, packet, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, epoch)), true)
+		suite.Require().NoError(hooks.AfterEpochEnd(ctx, types.StatsEpochIdentifier, epoch))
+	}
+	stats = osmosisApp.IBCHooksKeeper.GetChannelHookStats(ctx, "channel-0")
+	suite.Require().Len(stats.History, types.ChannelHookStatsHistoryLength)
+	suite.Require().Equal(int64(2+types.ChannelHookStatsHistoryLength), stats.History[0].EpochNumber)
+	suite.Require().Equal(int64(3), stats.History[types.ChannelHookStatsHistoryLength-1].EpochNumber)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 3)), stats.History[types.ChannelHookStatsHistoryLength-1].Routed)
+}
+
+// Deferred notifications are only delivered for packets that were received, i.e. whose tx didn't fail altogether
+func (suite *HooksTestSuite) TestQueuedObserverNotificationWithoutReceipt() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	observer := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	contract := suite.chainA.SenderAccount.GetAddress()
+
+	ctx := suite.chainA.GetContext()
+	notification := types.HookExecutedNotification{
+		Port:     suite.path.EndpointA.ChannelConfig.PortID,
+		Channel:  suite.path.EndpointA.ChannelID,
+		Sequence: 100,
+		Contract: contract.String(),
+		Funds:    sdk.NewInt64Coin(sdk.DefaultBondDenom, 1),
+	}
+	gasBefore := ctx.GasMeter().GasConsumed()
+	osmosisApp.IBCHooksKeeper.QueueObserverNotification(ctx, observer, notification)
+	suite.Require().Equal(types.ObserverGasLimit, ctx.GasMeter().GasConsumed()-gasBefore)
+
+	ibchooks.NewAppModule(osmosisApp.AccountKeeper, *osmosisApp.IBCHooksKeeper).EndBlock(ctx, abci.RequestEndBlock{})
+	query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, contract))
+	_, err := osmosisApp.WasmKeeper.QuerySmart(suite.chainA.GetContext(), observer, query)
+	suite.Require().Error(err)
+}
+
+func (suite *HooksTestSuite) TestDeriveIntermediateSender() {
+	channel, sender := "channel-0", suite.chainB.SenderAccount.GetAddress().String()
+	derived := ibchooks.DeriveIntermediateSender(channel, sender)
+
+	suite.Require().Equal(derived, ibchooks.DeriveIntermediateSender(channel, sender))
+	suite.Require().NotEqual(derived, ibchooks.DeriveIntermediateSender("channel-1", sender))
+	suite.Require().NotEqual(derived, ibchooks.DeriveIntermediateSender(channel, suite.chainA.SenderAccount.GetAddress().String()))
+	suite.Require().NotEqual(derived, ibchooks.WasmHookModuleAccountAddr)
+}
+
+// The intermediate sender of a hooked packet is a module account, so that nobody can sign for it.
+// Addresses squatted by base accounts that never signed a tx are taken over, while accounts that did and vesting
+// accounts, whose locked coins would be released by the takeover, are rejected.
+func (suite *HooksTestSuite) TestIntermediateSenderAccount() {
+	testCases := []struct {
+		name string
+		// preCreate sets up an account at the intermediate sender address before the packet is received
+		preCreate func(ctx sdk.Context, sender sdk.AccAddress)
+		expPass   bool
+	}{
+		{
+			name:    "no account",
+			expPass: true,
+		},
+		{
+			name: "base account without pubkey, e.g. created by a bank send",
+			preCreate: func(ctx sdk.Context, sender sdk.AccAddress) {
+				coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1))
+				err := suite.chainA.GetOsmosisApp().BankKeeper.SendCoins(ctx, suite.chainA.SenderAccount.GetAddress(), sender, coins)
+				suite.Require().NoError(err)
+			},
+			expPass: true,
+		},
+		{
+			name: "account with a pubkey that has signed txs",
+			preCreate: func(ctx sdk.Context, sender sdk.AccAddress) {
+				accountKeeper := suite.chainA.GetOsmosisApp().AccountKeeper
+				acc := accountKeeper.NewAccountWithAddress(ctx, sender)
+				suite.Require().NoError(acc.SetPubKey(secp256k1.GenPrivKey().PubKey()))
+				suite.Require().NoError(acc.SetSequence(1))
+				accountKeeper.SetAccount(ctx, acc)
+			},
+			expPass: false,
+		},
+		{
+			name: "delayed vesting account",
+			preCreate: func(ctx sdk.Context, sender sdk.AccAddress) {
+				suite.createVestingAccount(ctx, sender, func(base *authtypes.BaseAccount, coins sdk.Coins) authtypes.AccountI {
+					return vestingtypes.NewDelayedVestingAccount(base, coins, ctx.BlockTime().Add(time.Hour).Unix())
+				})
+			},
+			expPass: false,
+		},
+		{
+			name: "continuous vesting account",
+			preCreate: func(ctx sdk.Context, sender sdk.AccAddress) {
+				suite.createVestingAccount(ctx, sender, func(base *authtypes.BaseAccount, coins sdk.Coins) authtypes.AccountI {
+					return vestingtypes.NewContinuousVestingAccount(base, coins, ctx.BlockTime().Unix(), ctx.BlockTime().Add(time.Hour).Unix())
+				})
+			},
+			expPass: false,
+		},
+		{
+			name: "permanent locked account",
+			preCreate: func(ctx sdk.Context, sender sdk.AccAddress) {
+				suite.createVestingAccount(ctx, sender, func(base *authtypes.BaseAccount, coins sdk.Coins) authtypes.AccountI {
+					return vestingtypes.NewPermanentLockedAccount(base, coins)
+				})
+			},
+			expPass: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+			addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+
+			sender := ibchooks.DeriveIntermediateSender(suite.path.EndpointA.ChannelID, suite.chainB.SenderAccount.GetAddress().String())
+			if tc.preCreate != nil {
+				tc.preCreate(suite.chainA.GetContext(), sender)
+			}
+			preExisting := suite.chainA.GetOsmosisApp().AccountKeeper.GetAccount(suite.chainA.GetContext(), sender)
+
+			ack := suite.receivePacket(
+				addr.String(),
+				fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": {"increment": {} } } }`, addr))
+
+			ctx := suite.chainA.GetContext()
+			localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+			contractBalance := suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(ctx, addr, localDenom)
+			acc := suite.chainA.GetOsmosisApp().AccountKeeper.GetAccount(ctx, sender)
+			if !tc.expPass {
+				suite.Require().Contains(string(ack), "error")
+				// the squatted account is left untouched and neither it nor the contract received the funds
+				suite.Require().Equal(preExisting, acc)
+				suite.Require().True(suite.chainA.GetOsmosisApp().BankKeeper.GetBalance(ctx, sender, localDenom).IsZero())
+				suite.Require().True(contractBalance.IsZero())
+				return
+			}
+
+			suite.Require().NotContains(string(ack), "error")
+			suite.Require().Equal(sdk.NewInt(1), contractBalance.Amount)
+
+			_, isModuleAccount := acc.(authtypes.ModuleAccountI)
+			suite.Require().True(isModuleAccount)
+			suite.Require().Error(acc.SetPubKey(secp256k1.GenPrivKey().PubKey()))
+		})
+	}
+}
+
+// createVestingAccount creates the vesting account returned by newAccount at addr, vesting coins that are sent to it
+func (suite *HooksTestSuite) createVestingAccount(ctx sdk.Context, addr sdk.AccAddress, newAccount func(*authtypes.BaseAccount, sdk.Coins) authtypes.AccountI) {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	base := osmosisApp.AccountKeeper.NewAccountWithAddress(ctx, addr).(*authtypes.BaseAccount)
+	osmosisApp.AccountKeeper.SetAccount(ctx, newAccount(base, coins))
+	err := osmosisApp.BankKeeper.SendCoins(ctx, suite.chainA.SenderAccount.GetAddress(), addr, coins)
+	suite.Require().NoError(err)
+}
+
+// custom MsgTransfer constructor that supports Memo
+func NewMsgTransfer(
+	token sdk.Coin, sender, receiver string, memo string,
+) *transfertypes.MsgTransfer {
+	return &transfertypes.MsgTransfer{
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		Token:            token,
+		Sender:           sender,
+		Receiver:         receiver,
+		TimeoutHeight:    clienttypes.NewHeight(0, 100),
+		TimeoutTimestamp: 0,
+		Memo:             memo,
+	}
+}
+
+type Direction int64
+
+const (
+	AtoB Direction = iota
+	BtoA
+)
+
+func (suite *HooksTestSuite) GetEndpoints(direction Direction) (sender *ibctesting.Endpoint, receiver *ibctesting.Endpoint) {
+	switch direction {
+	case AtoB:
+		sender = suite.path.EndpointA
+		receiver = suite.path.EndpointB
+	case BtoA:
+		sender = suite.path.EndpointB
+		receiver = suite.path.EndpointA
+	}
+	return sender, receiver
+}
+
+func (suite *HooksTestSuite) RelayPacket(packet channeltypes.Packet, direction Direction) (*sdk.Result, []byte) {
+	sender, receiver := suite.GetEndpoints(direction)
+
+	err := receiver.UpdateClient()
+	suite.Require().NoError(err)
+
+	// receiver Receives
+	receiveResult, err := receiver.RecvPacketWithResult(packet)
+	suite.Require().NoError(err)
+
+	ack, err := ibctesting.ParseAckFromEvents(receiveResult.GetEvents())
+	suite.Require().NoError(err)
+
+	// sender Acknowledges
+	err = sender.AcknowledgePacket(packet, ack)
+	suite.Require().NoError(err)
+
+	err = sender.UpdateClient()
+	suite.Require().NoError(err)
+	err = receiver.UpdateClient()
+	suite.Require().NoError(err)
+
+	return receiveResult, ack
+}
+
+func (suite *HooksTestSuite) FullSend(msg sdk.Msg, direction Direction) (*sdk.Result, *sdk.Result, string, error) {
+	var sender *osmosisibctesting.TestChain
+	switch direction {
+	case AtoB:
+		sender = suite.chainA
+	case BtoA:
+		sender = suite.chainB
+	}
+	sendResult, err := sender.SendMsgsNoCheck(msg)
+	suite.Require().NoError(err)
+
+	packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+	suite.Require().NoError(err)
+
+	receiveResult, ack := suite.RelayPacket(packet, direction)
+
+	return sendResult, receiveResult, string(ack), err
+}
+
+func (suite *HooksTestSuite) TestAcks() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	recorder := &testutils.TestIBCHooksRecorder{}
+	suite.chainA.GetOsmosisApp().IBCHooksKeeper.SetHooks(recorder)
+
+	// Generate swap instructions for the contract
+	callbackMemo := fmt.Sprintf(`{"ibc_callback":"%s"}`, addr)
+	// Send IBC transfer with the memo with crosschain-swap instructions
+	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
+	suite.FullSend(transferMsg, AtoB)
+
+	// The test contract will increment the counter for itself every time it receives an ack
+	state := suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
+	suite.Require().Equal(`{"count":1}`, state)
+
+	suite.FullSend(transferMsg, AtoB)
+	state = suite.chainA.QueryContract(
+		&suite.Suite, addr,
+		[]byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
+	suite.Require().Equal(`{"count":2}`, state)
+
+	// The keeper hooks were notified of both callbacks
+	suite.Require().Len(recorder.CallbackExecutions, 2)
+	for i, execution := range recorder.CallbackExecutions {
+		suite.Require().Equal(types.CallbackPacketInfo{
+			Channel:  suite.path.EndpointA.ChannelID,
+			Sequence: uint64(i + 1),
+			Contract: addr.String(),
+			Entry:    types.CallbackEntrySudo,
+		}, execution.Packet)
+		suite.Require().True(execution.Ack.AckSuccess)
+		suite.Require().Empty(execution.Ack.Error)
+	}
+}
+
+// The keeper hooks are notified of callbacks that fail too
+func (suite *HooksTestSuite) TestFailedAckCallbackHooks() {
+	// The echo contract has no sudo entry point
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	recorder := &testutils.TestIBCHooksRecorder{}
+	osmosisApp.IBCHooksKeeper.SetHooks(recorder)
+
+	ctx := suite.chainA.GetContext()
+	channel := suite.path.EndpointA.ChannelID
+	osmosisApp.IBCHooksKeeper.StorePacketCallback(ctx, channel, 1, addr.String(), types.CallbackEntrySudo, 0)
+	data := transfertypes.NewFungibleTokenPacketData(sdk.DefaultBondDenom, "1", suite.chainA.SenderAccount.GetAddress().String(), addr.String())
+	packet := channeltypes.NewPacket(data.GetBytes(), 1, transfertypes.PortID, channel, transfertypes.PortID, suite.path.EndpointB.ChannelID, clienttypes.NewHeight(0, 100), 0)
+	ack := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+
+	err := osmosisApp.TransferStack.OnAcknowledgementPacket(ctx, packet, ack, suite.chainA.SenderAccount.GetAddress())
+	suite.Require().Error(err)
+
+	suite.Require().Len(recorder.CallbackExecutions, 1)
+	execution := recorder.CallbackExecutions[0]
+	suite.Require().Equal(types.CallbackPacketInfo{Channel: channel, Sequence: 1, Contract: addr.String(), Entry: types.CallbackEntrySudo}, execution.Packet)
+	suite.Require().Equal(ack, execution.Ack.Ack)
+	suite.Require().True(execution.Ack.AckSuccess)
+	suite.Require().NotEmpty(execution.Ack.Error)
+	suite.Require().Empty(recorder.HookExecutions)
+}
+
+func (suite *HooksTestSuite) TestAckCallbackEntry() {
+	testCases := []struct {
+		name         string
+		callbackMemo string
+		// the counter contract counts received acks under their sender: the contract itself for sudo,
+		// and the hooks module account for execute
+		expCountSudo    string
+		expCountExecute string
+	}{
+		{"legacy string callback", `{"ibc_callback":"%s"}`, `{"count":1}`, ""},
+		{"object without entry", `{"ibc_callback":{"contract":"%s"}}`, `{"count":1}`, ""},
+		{"sudo entry", `{"ibc_callback":{"contract":"%s","entry":"sudo"}}`, `{"count":1}`, ""},
+		{"execute entry", `{"ibc_callback":{"contract":"%s","entry":"execute"}}`, "", `{"count":1}`},
+		{"unknown entry is ignored", `{"ibc_callback":{"contract":"%s","entry":"instantiate"}}`, "", ""},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+			addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+			if tc.expCountExecute != "" {
+				suite.skipUnlessCounterHandles(addr, "receive_ack", false)
+			}
+
+			callbackMemo := fmt.Sprintf(tc.callbackMemo, addr)
+			transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
+			_, _, _, err := suite.FullSend(transferMsg, AtoB)
+			suite.Require().NoError(err)
+
+			for sender, expCount := range map[string]string{
+				addr.String(): tc.expCountSudo,
+				ibchooks.WasmHookModuleAccountAddr.String(): tc.expCountExecute,
+			} {
+				query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender))
+				if expCount == "" {
+					_, err := suite.chainA.GetOsmosisApp().WasmKeeper.QuerySmart(suite.chainA.GetContext(), addr, query)
+					suite.Require().Error(err)
+					continue
+				}
+				suite.Require().Equal(expCount, suite.chainA.QueryContract(&suite.Suite, addr, query))
+			}
+		})
+	}
+}
+
+// selfCallbackForwarder is a contract keeper that makes contract, once executed with funds, send them to receiver over
+// channel with an ack callback to itself, as a swap and forward contract sends its output on
+type selfCallbackForwarder struct {
+	types.ContractExecutor
+	transfer func(goCtx context.Context, msg *transfertypes.MsgTransfer) (*transfertypes.MsgTransferResponse, error)
+	contract sdk.AccAddress
+	channel  string
+	receiver string
+}
+
+func (f *selfCallbackForwarder) Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+	data, err := f.ContractExecutor.Execute(ctx, contractAddress, caller, msg, coins)
+	if err != nil || !contractAddress.Equals(f.contract) || coins.Empty() {
+		return data, err
+	}
+	transferMsg := NewMsgTransfer(coins[0], contractAddress.String(), f.receiver, fmt.Sprintf(`{"ibc_callback":"%s"}`, contractAddress))
+	transferMsg.SourceChannel = f.channel
+	if _, err := f.transfer(sdk.WrapSDKContext(ctx), transferMsg); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// relayFromChainA relays packet, sent from chain A, to chain B, and its ack back to chain A in a tx of its own.
+// It returns the ack, and the result of chain A's tx.
+func (suite *HooksTestSuite) relayFromChainA(packet channeltypes.Packet) ([]byte, *sdk.Result) {
+	err := suite.path.EndpointB.UpdateClient()
+	suite.Require().NoError(err)
+	receiveResult, err := suite.path.EndpointB.RecvPacketWithResult(packet)
+	suite.Require().NoError(err)
+	ack, err := ibctesting.ParseAckFromEvents(receiveResult.GetEvents())
+	suite.Require().NoError(err)
+
+	err = suite.path.EndpointA.UpdateClient()
+	suite.Require().NoError(err)
+	ackKey := host.PacketAcknowledgementKey(packet.GetDestPort(), packet.GetDestChannel(), packet.GetSequence())
+	proof, proofHeight := suite.path.EndpointB.QueryProof(ackKey)
+	ackMsg := channeltypes.NewMsgAcknowledgement(packet, ack, proof, proofHeight, suite.chainA.SenderAccount.GetAddress().String())
+	ackResult, err := suite.chainA.SendMsgsNoCheck(ackMsg)
+	suite.Require().NoError(err)
+	return ack, ackResult
+}
+
+// resultEvents returns the attributes of the events of type eventType in res, in the order they were emitted
+func resultEvents(res *sdk.Result, eventType string) []map[string]string {
+	events := []map[string]string{}
+	for _, event := range res.GetEvents() {
+		if event.Type != eventType {
+			continue
+		}
+		attributes := map[string]string{}
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+		events = append(events, attributes)
+	}
+	return events
+}
+
+// A contract executed by hooked packets sends their funds on with callbacks to itself. Each callback is stored under
+// its own packet, and delivered with that packet's ack, in whatever order the acks are relayed.
+func (suite *HooksTestSuite) TestHookedExecutionSendsPacketWithCallback() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	recorder := &testutils.TestIBCHooksRecorder{}
+	osmosisApp.IBCHooksKeeper.SetHooks(recorder)
+
+	channel := suite.path.EndpointA.ChannelID
+	user := suite.chainB.SenderAccount.GetAddress()
+	forwarder := &selfCallbackForwarder{
+		ContractExecutor: osmosisApp.Ics20WasmHooks.ContractKeeper,
+		transfer:         osmosisApp.TransferKeeper.Transfer,
+		contract:         addr,
+		channel:          channel,
+		receiver:         user.String(),
+	}
+	osmosisApp.Ics20WasmHooks.ContractKeeper = forwarder
+
+	intermediateSender := ibchooks.DeriveIntermediateSender(channel, user.String())
+	receivedDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+	userBalance := func() sdk.Int {
+		return suite.chainB.GetOsmosisApp().BankKeeper.GetBalance(suite.chainB.GetContext(), user, sdk.DefaultBondDenom).Amount
+	}
+	balanceBefore := userBalance()
+	count := func(sender sdk.AccAddress) string {
+		return suite.chainA.QueryContract(&suite.Suite, addr, []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, sender)))
+	}
+	// sendHooked receives a hooked packet from the user on chain B, relays its ack back, and returns the packet the
+	// contract sent on while executing it
+	sendHooked := func() channeltypes.Packet {
+		hookMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), user.String(), addr.String(), testutils.WasmMemo(addr.String(), `{"increment": {}}`))
+		_, receiveResult, ack, err := suite.FullSend(hookMsg, BtoA)
+		suite.Require().NoError(err)
+		testutils.RequireContractAck(suite.T(), []byte(ack))
+		sent, err := ibctesting.ParsePacketFromEvents(receiveResult.GetEvents())
+		suite.Require().NoError(err)
+
+		// The callback is registered on the contract's packet, which the contract sent
+		suite.Require().Equal([]map[string]string{{
+			types.AttributeChannel:  channel,
+			types.AttributeSequence: fmt.Sprint(sent.GetSequence()),
+			types.AttributeSender:   addr.String(),
+			types.AttributeContract: addr.String(),
+		}}, resultEvents(receiveResult, types.TypeEvtPacketCallbackRegistered))
+		// The funds went from the intermediate sender to the contract, and on with the contract's packet
+		ctx := suite.chainA.GetContext()
+		suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, receivedDenom).IsZero())
+		suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, addr, receivedDenom).IsZero())
+		return sent
+	}
+
+	// Two hooked packets make the contract send two packets, whose callbacks are both pending. The counter's first
+	// increment sets the count to 0.
+	sent := []channeltypes.Packet{sendHooked(), sendHooked()}
+	suite.Require().Equal(`{"count":1}`, count(intermediateSender))
+	suite.Require().Equal([]string{channel + "/1", channel + "/2"}, pendingCallbackIDs(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(suite.chainA.GetContext(), channel)))
+	for _, packet := range sent {
+		callback, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), channel, packet.GetSequence())
+		suite.Require().True(found)
+		suite.Require().Equal(addr.String(), callback.Contract)
+	}
+
+	// The acks are relayed in the reverse order. Each one delivers the callback of its own packet.
+	for i, packet := range []channeltypes.Packet{sent[1], sent[0]} {
+		ack, ackResult := suite.relayFromChainA(packet)
+		suite.Require().JSONEq(`{"result":"AQ=="}`, string(ack))
+		suite.Require().Equal([]map[string]string{{
+			types.AttributeChannel:  channel,
+			types.AttributeSequence: fmt.Sprint(packet.GetSequence()),
+			types.AttributeContract: addr.String(),
+			types.AttributeSuccess:  "true",
+		}}, resultEvents(ackResult, types.TypeEvtPacketCallbackDelivered))
+		suite.Require().Equal(fmt.Sprintf(`{"count":%d}`, i+1), count(addr))
+	}
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(suite.chainA.GetContext(), ""))
+	suite.Require().Len(recorder.CallbackExecutions, 2)
+	for i, execution := range recorder.CallbackExecutions {
+		suite.Require().Equal(types.CallbackPacketInfo{
+			Channel:  channel,
+			Sequence: uint64(2 - i),
+			Contract: addr.String(),
+			Entry:    types.CallbackEntrySudo,
+		}, execution.Packet)
+		suite.Require().True(execution.Ack.AckSuccess)
+		suite.Require().Empty(execution.Ack.Error)
+	}
+	// The user got its funds back through the contract
+	suite.Require().Equal(balanceBefore, userBalance())
+
+	// A packet of the contract that fails on chain B refunds the contract, which sent it, rather than the
+	// intermediate sender, and the contract's callback is told of the failure
+	forwarder.receiver = suite.chainB.GetOsmosisApp().AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName).String()
+	failed := sendHooked()
+	ack, ackResult := suite.relayFromChainA(failed)
+	_, err := testutils.ParseErrorAck(ack)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]map[string]string{{
+		types.AttributeChannel:  channel,
+		types.AttributeSequence: fmt.Sprint(failed.GetSequence()),
+		types.AttributeContract: addr.String(),
+		types.AttributeSuccess:  "false",
+	}}, resultEvents(ackResult, types.TypeEvtPacketCallbackDelivered))
+	ctx := suite.chainA.GetContext()
+	suite.Require().Equal(sdk.NewInt(1000), osmosisApp.BankKeeper.GetBalance(ctx, addr, receivedDenom).Amount)
+	suite.Require().True(osmosisApp.BankKeeper.GetBalance(ctx, intermediateSender, receivedDenom).IsZero())
+	suite.Require().Equal(`{"count":3}`, count(addr))
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(ctx, ""))
+}
+
+func (suite *HooksTestSuite) TestCancelPacketCallback() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+
+	callbackMemo := fmt.Sprintf(`{"ibc_callback":"%s"}`, addr)
+	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
+	sendResult, err := suite.chainA.SendMsgsNoCheck(transferMsg)
+	suite.Require().NoError(err)
+	packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+	suite.Require().NoError(err)
+
+	ctx := suite.chainA.GetContext()
+	channel, sequence := packet.GetSourceChannel(), packet.GetSequence()
+	_, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, channel, sequence)
+	suite.Require().True(found)
+
+	// Only the contract the callback notifies can cancel it, not even the packet's sender
+	_, err = msgServer.CancelPacketCallback(sdk.WrapSDKContext(ctx),
+		types.NewMsgCancelPacketCallback(suite.chainA.SenderAccount.GetAddress().String(), channel, sequence))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, found = osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, channel, sequence)
+	suite.Require().True(found)
+
+	// Packets without a callback can't be cancelled
+	_, err = msgServer.CancelPacketCallback(sdk.WrapSDKContext(ctx), types.NewMsgCancelPacketCallback(addr.String(), channel, sequence+1))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+
+	_, err = msgServer.CancelPacketCallback(sdk.WrapSDKContext(ctx), types.NewMsgCancelPacketCallback(addr.String(), channel, sequence))
+	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgCancelPacketCallback, 1)
+	_, found = osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, channel, sequence)
+	suite.Require().False(found)
+
+	// The ack arrives, but the contract is not notified
+	_, ack := suite.RelayPacket(packet, AtoB)
+	suite.Require().Contains(string(ack), "result")
+	query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr))
+	_, err = osmosisApp.WasmKeeper.QuerySmart(suite.chainA.GetContext(), addr, query)
+	suite.Require().Error(err)
+}
+
+func (suite *HooksTestSuite) TestForceCallbackMessages() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+	authority := suite.chainA.SenderAccount.GetAddress().String()
+	other := suite.chainB.SenderAccount.GetAddress().String()
+	ack := []byte(`{"result":"AQ=="}`)
+
+	ctx := suite.chainA.GetContext()
+	osmosisApp.IBCHooksKeeper.StorePacketCallback(ctx, "channel-0", 1, addr.String(), types.CallbackEntrySudo, 0)
+	osmosisApp.IBCHooksKeeper.StorePacketCallback(ctx, "channel-0", 2, addr.String(), types.CallbackEntrySudo, 0)
+	// a callback to an address that is not a contract can never be delivered
+	osmosisApp.IBCHooksKeeper.StorePacketCallback(ctx, "channel-0", 3, other, types.CallbackEntrySudo, 0)
+	countQuery := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr))
+
+	// No one can force callbacks until governance sets the callback authority
+	_, err := msgServer.ForceEmitCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceEmitCallback(authority, "channel-0", 1, ack, true))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.ForceDeleteCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceDeleteCallback(authority, "channel-0", 1))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+
+	params := types.DefaultParams()
+	params.CallbackAuthority = authority
+	osmosisApp.IBCHooksKeeper.SetParams(ctx, params)
+
+	// Only the callback authority can force callbacks, not even the contract they notify
+	for _, sender := range []string{other, addr.String()} {
+		_, err = msgServer.ForceEmitCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceEmitCallback(sender, "channel-0", 1, ack, true))
+		suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+		_, err = msgServer.ForceDeleteCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceDeleteCallback(sender, "channel-0", 1))
+		suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	}
+	suite.Require().Equal([]string{"channel-0/1", "channel-0/2", "channel-0/3"}, pendingCallbackIDs(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(ctx, "")))
+	_, err = osmosisApp.WasmKeeper.QuerySmart(ctx, addr, countQuery)
+	suite.Require().Error(err)
+
+	// Packets without a callback can't be forced
+	_, err = msgServer.ForceEmitCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceEmitCallback(authority, "channel-0", 4, ack, true))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+	_, err = msgServer.ForceDeleteCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceDeleteCallback(authority, "channel-1", 1))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+
+	// The forced callback is delivered to the contract like a received ack, and deleted
+	_, err = msgServer.ForceEmitCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceEmitCallback(authority, "channel-0", 1, ack, false))
+	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgForceEmitCallback, 1)
+	suite.Require().Equal(`{"count":1}`, suite.chainA.QueryContract(&suite.Suite, addr, countQuery))
+	_, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 1)
+	suite.Require().False(found)
+
+	// A callback that can't be delivered is kept, and can only be deleted
+	_, err = msgServer.ForceEmitCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceEmitCallback(authority, "channel-0", 3, ack, true))
+	suite.Require().Error(err)
+	_, found = osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 3)
+	suite.Require().True(found)
+	_, err = msgServer.ForceDeleteCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceDeleteCallback(authority, "channel-0", 3))
+	suite.Require().NoError(err)
+
+	// Deleted callbacks are not delivered
+	_, err = msgServer.ForceDeleteCallback(sdk.WrapSDKContext(ctx), types.NewMsgForceDeleteCallback(authority, "channel-0", 2))
+	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgForceDeleteCallback, 2)
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllPacketCallbacks(ctx, ""))
+	suite.Require().Equal(`{"count":1}`, suite.chainA.QueryContract(&suite.Suite, addr, countQuery))
+}
+
+func (suite *HooksTestSuite) TestValidateForceCallbackMsgs() {
+	authority := suite.chainA.SenderAccount.GetAddress().String()
+	suite.Require().NoError(types.NewMsgForceEmitCallback(authority, "channel-0", 1, []byte(`{"result":"AQ=="}`), true).ValidateBasic())
+	suite.Require().Error(types.NewMsgForceEmitCallback(authority, "channel-0", 1, nil, true).ValidateBasic())
+	suite.Require().Error(types.NewMsgForceEmitCallback("", "channel-0", 1, []byte(`{"result":"AQ=="}`), true).ValidateBasic())
+	suite.Require().Error(types.NewMsgForceEmitCallback(authority, "not a channel", 1, []byte(`{"result":"AQ=="}`), true).ValidateBasic())
+	suite.Require().NoError(types.NewMsgForceDeleteCallback(authority, "channel-0", 1).ValidateBasic())
+	suite.Require().Error(types.NewMsgForceDeleteCallback("", "channel-0", 1).ValidateBasic())
+	suite.Require().Error(types.NewMsgForceDeleteCallback(authority, "not a channel", 1).ValidateBasic())
+}
+
+// sendFromContract sends an ICS20 transfer without a callback from the contract at addr, as if the contract had sent
+// it, and commits the block so that the packet can be relayed.
+func (suite *HooksTestSuite) sendFromContract(addr sdk.AccAddress) channeltypes.Packet {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx := suite.chainA.GetContext()
+	amount := sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000))
+	err := osmosisApp.BankKeeper.SendCoins(ctx, suite.chainA.SenderAccount.GetAddress(), addr, sdk.NewCoins(amount))
+	suite.Require().NoError(err)
+
+	transferMsg := NewMsgTransfer(amount, addr.String(), suite.chainB.SenderAccount.GetAddress().String(), "")
+	_, err = osmosisApp.TransferKeeper.Transfer(sdk.WrapSDKContext(ctx), transferMsg)
+	suite.Require().NoError(err)
+	packet, err := ibctesting.ParsePacketFromEvents(ctx.EventManager().Events())
+	suite.Require().NoError(err)
+
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+	return packet
+}
+
+func (suite *HooksTestSuite) TestCallbackRegistrationGrant() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	otherContract := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+	grantee := suite.chainA.SenderAccount.GetAddress().String()
+
+	packet := suite.sendFromContract(addr)
+	ctx := suite.chainA.GetContext()
+	expiration := ctx.BlockTime().Add(time.Hour)
+	register := types.NewMsgRegisterPacketCallback(grantee, addr.String(), packet, types.CallbackEntrySudo)
+
+	// Without a grant, the callback can't be registered
+	_, err := msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), register)
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+
+	// Only contracts can grant, and only until a later time
+	_, err = msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx),
+		types.NewMsgGrantCallbackRegistration(grantee, addr.String(), expiration))
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx),
+		types.NewMsgGrantCallbackRegistration(addr.String(), grantee, ctx.BlockTime()))
+	suite.Require().ErrorIs(err, sdkerrors.ErrInvalidRequest)
+
+	_, err = msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx), types.NewMsgGrantCallbackRegistration(addr.String(), grantee, expiration))
+	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgGrantCallbackRegistration, 1)
+
+	// The grant can't be used once expired
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx.WithBlockTime(expiration)), register)
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+
+	// nor once revoked, and it can only be revoked once
+	_, err = msgServer.RevokeCallbackRegistration(sdk.WrapSDKContext(ctx), types.NewMsgRevokeCallbackRegistration(addr.String(), grantee))
+	suite.Require().NoError(err)
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), register)
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.RevokeCallbackRegistration(sdk.WrapSDKContext(ctx), types.NewMsgRevokeCallbackRegistration(addr.String(), grantee))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+
+	_, err = msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx), types.NewMsgGrantCallbackRegistration(addr.String(), grantee, expiration))
+	suite.Require().NoError(err)
+
+	// The packet must match its commitment, be waiting for its ack, and have been sent by the contract
+	mismatched := *register
+	mismatched.TimeoutTimestamp++
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), &mismatched)
+	suite.Require().ErrorIs(err, sdkerrors.ErrInvalidRequest)
+	unknown := *register
+	unknown.Sequence++
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), &unknown)
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+	_, err = msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx), types.NewMsgGrantCallbackRegistration(otherContract.String(), grantee, expiration))
+	suite.Require().NoError(err)
+	notSender := *register
+	notSender.Contract = otherContract.String()
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), &notSender)
+	suite.Require().ErrorIs(err, sdkerrors.ErrUnauthorized)
+
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), register)
+	suite.Require().NoError(err)
+	suite.AssertEventEmitted(ctx, types.TypeMsgRegisterPacketCallback, 1)
+	callback, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, packet.GetSourceChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	suite.Require().Equal(addr.String(), callback.Contract)
+
+	// A packet has at most one callback
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx), register)
+	suite.Require().ErrorIs(err, sdkerrors.ErrInvalidRequest)
+
+	// The contract is notified of the ack
+	suite.RelayPacket(packet, AtoB)
+	state := suite.chainA.QueryContract(&suite.Suite, addr, []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr)))
+	suite.Require().Equal(`{"count":1}`, state)
+}
+
+func (suite *HooksTestSuite) TestRegisterPacketCallbackAfterAck() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+	grantee := suite.chainA.SenderAccount.GetAddress().String()
+
+	packet := suite.sendFromContract(addr)
+	ctx := suite.chainA.GetContext()
+	_, err := msgServer.GrantCallbackRegistration(sdk.WrapSDKContext(ctx),
+		types.NewMsgGrantCallbackRegistration(addr.String(), grantee, ctx.BlockTime().Add(time.Hour)))
+	suite.Require().NoError(err)
+
+	// The ack wins the race: the packet's commitment is deleted, so the callback can't be registered anymore
+	suite.RelayPacket(packet, AtoB)
+	ctx = suite.chainA.GetContext()
+	_, err = msgServer.RegisterPacketCallback(sdk.WrapSDKContext(ctx),
+		types.NewMsgRegisterPacketCallback(grantee, addr.String(), packet, types.CallbackEntrySudo))
+	suite.Require().ErrorIs(err, sdkerrors.ErrNotFound)
+	_, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, packet.GetSourceChannel(), packet.GetSequence())
+	suite.Require().False(found)
+}
+
+func (suite *HooksTestSuite) TestPacketCallbackExpiry() {
+	testCases := []struct {
+		name         string
+		expiryOffset int64
+		// expireBeforeAck commits blocks on chainA until the callback's expiry height before relaying the packet
+		expireBeforeAck bool
+		expCount        string
+	}{
+		{"ack before expiry", 100, false, `{"count":1}`},
+		{"expiry before ack", 3, true, ""},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+			addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+			osmosisApp := suite.chainA.GetOsmosisApp()
+			// the store is read from the context of the current block, as blocks are committed along the way
+			hasExpiryKey := func(key []byte) bool {
+				return suite.chainA.GetContext().KVStore(osmosisApp.GetKey(types.StoreKey)).Has(key)
+			}
+
+			expiryHeight := suite.chainA.GetContext().BlockHeight() + tc.expiryOffset
+			callbackMemo := fmt.Sprintf(`{"ibc_callback":{"contract":"%s","expiry_height":%d}}`, addr, expiryHeight)
+			transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), addr.String(), callbackMemo)
+			sendResult, err := suite.chainA.SendMsgsNoCheck(transferMsg)
+			suite.Require().NoError(err)
+			packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+			suite.Require().NoError(err)
+
+			channel, sequence := packet.GetSourceChannel(), packet.GetSequence()
+			callback, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), channel, sequence)
+			suite.Require().True(found)
+			suite.Require().Equal(expiryHeight, callback.ExpiryHeight)
+			suite.Require().True(hasExpiryKey(keeper.GetPacketCallbackExpiryKey(expiryHeight, channel, sequence)))
+
+			// The callback is deleted by the begin blocker of its expiry height
+			if tc.expireBeforeAck {
+				for suite.chainA.GetContext().BlockHeight() < expiryHeight {
+					suite.coordinator.CommitBlock(suite.chainA.TestChain)
+				}
+				_, found = osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), channel, sequence)
+				suite.Require().False(found)
+			}
+
+			_, ack := suite.RelayPacket(packet, AtoB)
+			suite.Require().Contains(string(ack), "result")
+
+			query := []byte(fmt.Sprintf(`{"get_count": {"addr": "%s"}}`, addr))
+			if tc.expCount == "" {
+				_, err = osmosisApp.WasmKeeper.QuerySmart(suite.chainA.GetContext(), addr, query)
+				suite.Require().Error(err)
+			} else {
+				suite.Require().Equal(tc.expCount, suite.chainA.QueryContract(&suite.Suite, addr, query))
+			}
+
+			// Either way, the callback is gone along with its expiry index entry
+			_, found = osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), channel, sequence)
+			suite.Require().False(found)
+			suite.Require().False(hasExpiryKey(keeper.GetPacketCallbackExpiryKey(expiryHeight, channel, sequence)))
+		})
+	}
+}
+
+func (suite *HooksTestSuite) TestParseCallbackExpiry() {
+	ctx := suite.chainA.GetContext()
+	addr := suite.chainA.SenderAccount.GetAddress()
+
+	testCases := []struct {
+		name       string
+		expiry     string
+		registered bool
+	}{
+		// the packet is sent in the current block, and the begin blocker of the next one would already expire it
+		{"future height", fmt.Sprint(ctx.BlockHeight() + 2), true},
+		{"height already reached", fmt.Sprint(ctx.BlockHeight()), false},
+		{"zero", "0", false},
+		{"negative", "-1", false},
+		{"not an integer", "10.5", false},
+		{"string", `"100"`, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			memo := fmt.Sprintf(`{"ibc_callback":{"contract":"%s","expiry_height":%s}}`, addr, tc.expiry)
+			transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), addr.String(), addr.String(), memo)
+			sendResult, err := suite.chainA.SendMsgsNoCheck(transferMsg)
+			suite.Require().NoError(err)
+			packet, err := ibctesting.ParsePacketFromEvents(sendResult.GetEvents())
+			suite.Require().NoError(err)
+
+			_, found := suite.chainA.GetOsmosisApp().IBCHooksKeeper.GetPacketCallbackInfo(suite.chainA.GetContext(), packet.GetSourceChannel(), packet.GetSequence())
+			suite.Require().Equal(tc.registered, found)
+		})
+	}
+}
+
+// sudoRecorder is a contract keeper that records the sudo messages it gets instead of delivering them
+type sudoRecorder struct {
+	types.ContractKeeper
+	sudoed []string
+}
+
+func (r *sudoRecorder) Sudo(_ sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	r.sudoed = append(r.sudoed, fmt.Sprintf("%s %s", contractAddress, msg))
+	return nil, nil
+}
+
+func (suite *HooksTestSuite) TestExpiredCallbackNotification() {
+	for _, notify := range []bool{false, true} {
+		suite.Run(fmt.Sprintf("notify %t", notify), func() {
+			suite.SetupTest()
+			osmosisApp := suite.chainA.GetOsmosisApp()
+			hooksKeeper := *osmosisApp.IBCHooksKeeper
+			recorder := &sudoRecorder{ContractKeeper: osmosisApp.WasmKeeper}
+			hooksKeeper.SetContractKeeper(recorder)
+			contract := suite.chainA.SenderAccount.GetAddress().String()
+
+			ctx := suite.chainA.GetContext()
+			params := types.DefaultParams()
+			params.MaxExpiredCallbacksPerBlock = 2
+			params.NotifyExpiredCallbacks = notify
+			hooksKeeper.SetParams(ctx, params)
+
+			height := ctx.BlockHeight()
+			hooksKeeper.StorePacketCallback(ctx, "channel-0", 1, contract, types.CallbackEntrySudo, height+2)
+			hooksKeeper.StorePacketCallback(ctx, "channel-0", 2, contract, types.CallbackEntryExecute, height+1)
+			hooksKeeper.StorePacketCallback(ctx, "channel-1", 1, contract, types.CallbackEntrySudo, height+1)
+			hooksKeeper.StorePacketCallback(ctx, "channel-1", 2, contract, types.CallbackEntrySudo, 0)
+			hooksKeeper.StorePacketCallback(ctx, "channel-1", 3, contract, types.CallbackEntrySudo, height+10)
+
+			// Nothing has expired yet
+			hooksKeeper.SweepExpiredCallbacks(ctx)
+			suite.Require().Len(hooksKeeper.GetAllPacketCallbacks(ctx, ""), 5)
+
+			// Three callbacks have expired, but only two are deleted per block, earliest expiry first
+			sweepCtx := ctx.WithBlockHeight(height + 2).WithEventManager(sdk.NewEventManager())
+			hooksKeeper.SweepExpiredCallbacks(sweepCtx)
+			suite.AssertEventEmitted(sweepCtx, types.TypeEvtPacketCallbackExpired, 2)
+			suite.Require().Len(hooksKeeper.GetAllPacketCallbacks(ctx, ""), 3)
+			_, found := hooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 1)
+			suite.Require().True(found)
+
+			// The one left over is deleted in the next block. Callbacks without an expiry height never expire.
+			sweepCtx = sweepCtx.WithBlockHeight(height + 3)
+			hooksKeeper.SweepExpiredCallbacks(sweepCtx)
+			suite.AssertEventEmitted(sweepCtx, types.TypeEvtPacketCallbackExpired, 3)
+			suite.Require().Equal([]string{"channel-1/2", "channel-1/3"}, pendingCallbackIDs(hooksKeeper.GetAllPacketCallbacks(ctx, "")))
+
+			// Callbacks deleted before they expire are removed from the expiry index
+			hooksKeeper.DeletePacketCallback(ctx, "channel-1", 3)
+			hooksKeeper.SweepExpiredCallbacks(ctx.WithBlockHeight(height + 10))
+			suite.Require().Len(hooksKeeper.GetAllPacketCallbacks(ctx, ""), 1)
+
+			// The contracts are only notified if enabled, whichever entry point their callback was for
+			var expSudoed []string
+			if notify {
+				expSudoed = []string{
+					fmt.Sprintf(`%s {"callback_expired": {"channel": "channel-0", "sequence": 2}}`, contract),
+					fmt.Sprintf(`%s {"callback_expired": {"channel": "channel-1", "sequence": 1}}`, contract),
+					fmt.Sprintf(`%s {"callback_expired": {"channel": "channel-0", "sequence": 1}}`, contract),
+				}
+			}
+			suite.Require().Equal(expSudoed, recorder.sudoed)
+		})
+	}
+}
+
+func pendingCallbackIDs(callbacks []types.PendingPacketCallback) []string {
+	ids := []string{}
+	for _, callback := range callbacks {
+		ids = append(ids, fmt.Sprintf("%s/%d", callback.Channel, callback.Sequence))
+	}
+	return ids
+}
+
+func (suite *HooksTestSuite) TestAckClassifier() {
+	classifiers := []types.AckClassifier{
+		types.AckClassifierDefault, types.AckClassifierStandard, types.AckClassifierJSONError, types.AckClassifierResult,
+	}
+	testCases := []struct {
+		name string
+		ack  []byte
+		// whether the ack is an error for each of the classifiers above
+		expIsError []bool
+	}{
+		{"proto success ack", channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement(), []bool{false, false, false, false}},
+		{"proto error ack", channeltypes.NewErrorAcknowledgement("failed").Acknowledgement(), []bool{true, true, true, true}},
+		{"json error with extra fields", []byte(`{"error": "failed", "code": 5}`), []bool{true, true, true, true}},
+		{"json result with extra fields", []byte(`{"result": "ok", "height": 5}`), []bool{false, true, false, false}},
+		{"custom error format", []byte(`{"status": "failed"}`), []bool{false, true, false, true}},
+		{"empty object", []byte(`{}`), []bool{true, true, false, true}},
+		{"empty object result", []byte(`{"result": {}}`), []bool{false, true, false, true}},
+		{"empty array result", []byte(`{"result": []}`), []bool{false, true, false, true}},
+		{"object result", []byte(`{"result": {"height": 5}}`), []bool{false, true, false, false}},
+		{"garbage", []byte(`not an ack`), []bool{false, true, false, true}},
+	}
+
+	for _, tc := range testCases {
+		for i, classifier := range classifiers {
+			suite.Require().Equal(tc.expIsError[i], classifier.IsAckError(tc.ack), "%s: %s classifier", tc.name, classifier)
+		}
+	}
+}
+
+func (suite *HooksTestSuite) TestAckClassifierOverride() {
+	ctx := suite.chainA.GetContext()
+	hooksKeeper := suite.chainA.GetOsmosisApp().IBCHooksKeeper
+
+	suite.Require().Equal(types.AckClassifierDefault, hooksKeeper.GetAckClassifier(ctx, "channel-0"))
+
+	// the overrides are set by governance through the ack_classifiers param
+	params := types.DefaultParams()
+	params.AckClassifiers = []types.ChannelAckClassifier{{Channel: "channel-0", Classifier: types.AckClassifierResult.String()}}
+	hooksKeeper.SetParams(ctx, params)
+	suite.Require().Equal(types.AckClassifierResult, hooksKeeper.GetAckClassifier(ctx, "channel-0"))
+	// other channels are not affected
+	suite.Require().Equal(types.AckClassifierDefault, hooksKeeper.GetAckClassifier(ctx, "channel-1"))
+
+	// invalid overrides are rejected by the param store
+	params.AckClassifiers = []types.ChannelAckClassifier{{Channel: "channel-0", Classifier: "unknown"}}
+	suite.Require().Panics(func() { hooksKeeper.SetParams(ctx, params) })
+	suite.Require().Equal(types.AckClassifierResult, hooksKeeper.GetAckClassifier(ctx, "channel-0"))
+
+	// removing the override restores the default classifier
+	params.AckClassifiers = []types.ChannelAckClassifier{}
+	hooksKeeper.SetParams(ctx, params)
+	suite.Require().Equal(types.AckClassifierDefault, hooksKeeper.GetAckClassifier(ctx, "channel-0"))
+
+	for _, classifier := range []types.AckClassifier{
+		types.AckClassifierDefault, types.AckClassifierStandard, types.AckClassifierJSONError, types.AckClassifierResult,
+	} {
+		parsed, err := types.ParseAckClassifier(classifier.String())
+		suite.Require().NoError(err)
+		suite.Require().Equal(classifier, parsed)
+	}
+	_, err := types.ParseAckClassifier("unknown")
+	suite.Require().Error(err)
+}
+
+func (suite *HooksTestSuite) TestSendWithoutMemo() {
+	// Sending a packet without memo to ensure that the ibc_callback middleware doesn't interfere with a regular send
+	transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), suite.chainA.SenderAccount.GetAddress().String(), suite.chainA.SenderAccount.GetAddress().String(), "")
+	_, _, ack, err := suite.FullSend(transferMsg, AtoB)
+	suite.Require().NoError(err)
+	suite.Require().Contains(ack, "result")
+}
+
+// setReceiveEnabled enables or disables the ICS20 receives of chain A
+func (suite *HooksTestSuite) setReceiveEnabled(enabled bool) {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	osmosisApp.TransferKeeper.SetParams(suite.chainA.GetContext(), transfertypes.NewParams(true, enabled))
+}
+
+// receiveDeferredPacket sends a packet from chain B and receives it on chain A, and checks that it was deferred
+// instead of acknowledged
+func (suite *HooksTestSuite) receiveDeferredPacket(receiver, memo string) channeltypes.Packet {
+	channelCap := suite.chainB.GetChannelCapability(suite.path.EndpointB.ChannelConfig.PortID, suite.path.EndpointB.ChannelID)
+	packet := suite.makeMockPacket(receiver, memo, 0)
+	err := suite.chainB.GetOsmosisApp().HooksICS4Wrapper.SendPacket(suite.chainB.GetContext(), channelCap, packet)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.path.EndpointB.UpdateClient())
+	suite.Require().NoError(suite.path.EndpointA.UpdateClient())
+
+	res, err := suite.path.EndpointA.RecvPacketWithResult(packet)
+	suite.Require().NoError(err)
+	_, err = ibctesting.ParseAckFromEvents(res.GetEvents())
+	suite.Require().Error(err, "the ack of a deferred packet is written asynchronously")
+
+	_, found := suite.chainA.GetOsmosisApp().IBCHooksKeeper.GetDeferredRecv(suite.chainA.GetContext(), packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	return packet
+}
+
+// retryDeferredRecv runs the retries due at the next retry height of packet, and returns the ack written for it,
+// or nil if it is still deferred
+func (suite *HooksTestSuite) retryDeferredRecv(packet channeltypes.Packet) []byte {
+	hooksKeeper := suite.chainA.GetOsmosisApp().IBCHooksKeeper
+	deferred, found := hooksKeeper.GetDeferredRecv(suite.chainA.GetContext(), packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+
+	ctx := suite.chainA.GetContext().WithBlockHeight(deferred.RetryHeight).WithEventManager(sdk.NewEventManager())
+	hooksKeeper.RetryDeferredRecvs(ctx)
+	ack, err := ibctesting.ParseAckFromEvents(ctx.EventManager().Events())
+	if err != nil {
+		return nil
+	}
+	return ack
+}
+
+func (suite *HooksTestSuite) TestDeferredRecvSucceedsOnRetry() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	memo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}},"defer_on_transfer_failure":true}}`, addr)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+
+	suite.setReceiveEnabled(false)
+	packet := suite.receiveDeferredPacket(addr.String(), memo)
+
+	// the retries fail while the receives are disabled
+	suite.Require().Nil(suite.retryDeferredRecv(packet))
+	suite.Require().Equal("", suite.hookedCount(addr))
+
+	// and succeed once they are enabled again, executing the hook and writing its ack
+	suite.setReceiveEnabled(true)
+	ack := suite.retryDeferredRecv(packet)
+	suite.Require().Contains(string(ack), "result")
+	suite.Require().Equal(`{"count":0}`, suite.hookedCount(addr))
+
+	ctx := suite.chainA.GetContext()
+	_, found := osmosisApp.IBCHooksKeeper.GetDeferredRecv(ctx, packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().False(found)
+	commitment, found := osmosisApp.IBCKeeper.ChannelKeeper.GetPacketAcknowledgement(ctx, packet.GetDestPort(), packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	suite.Require().Equal(channeltypes.CommitAcknowledgement(ack), commitment)
+
+	// the written ack is relayed back to chain B like a synchronous one, once its block is committed
+	suite.coordinator.CommitBlock(suite.chainA.TestChain)
+	suite.Require().NoError(suite.path.EndpointB.UpdateClient())
+	suite.Require().NoError(suite.path.EndpointB.AcknowledgePacket(packet, ack))
+}
+
+func (suite *HooksTestSuite) TestDeferredRecvExhausted() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	memo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}},"defer_on_transfer_failure":true}}`, addr)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+
+	suite.setReceiveEnabled(false)
+	packet := suite.receiveDeferredPacket(addr.String(), memo)
+
+	// the packet is retried until its last attempt, whose error ack is written
+	var ack []byte
+	for ack == nil {
+		deferred, found := osmosisApp.IBCHooksKeeper.GetDeferredRecv(suite.chainA.GetContext(), packet.GetDestChannel(), packet.GetSequence())
+		suite.Require().True(found)
+		ack = suite.retryDeferredRecv(packet)
+		if deferred.Attempts+1 < types.MaxDeferredRecvAttempts {
+			suite.Require().Nil(ack, "attempt %d", deferred.Attempts+1)
+		} else {
+			suite.Require().NotNil(ack, "attempt %d", deferred.Attempts+1)
+		}
+	}
+	suite.Require().Contains(string(ack), "error")
+
+	ctx := suite.chainA.GetContext()
+	_, found := osmosisApp.IBCHooksKeeper.GetDeferredRecv(ctx, packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().False(found)
+	commitment, found := osmosisApp.IBCKeeper.ChannelKeeper.GetPacketAcknowledgement(ctx, packet.GetDestPort(), packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	suite.Require().Equal(channeltypes.CommitAcknowledgement(ack), commitment)
+	suite.Require().Equal("", suite.hookedCount(addr))
+
+	// packets whose memo doesn't opt in are acknowledged right away
+	noDeferMemo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}}}}`, addr)
+	suite.Require().Contains(string(suite.receivePacketWithSequence(addr.String(), noDeferMemo, 1)), "error")
+}
+
+// A redelivered hooked packet gets the ack it was processed with, without its hook being executed again, until
+// its record is pruned
+func (suite *HooksTestSuite) TestRedeliveredPacket() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	memo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}}}}`, addr)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	relayer := suite.chainB.SenderAccount.GetAddress()
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(suite.makeMockPacket("", "", 0))
+
+	ctx := suite.chainA.GetContext()
+	packet := suite.makeMockPacket(addr.String(), memo, 0)
+	ack := osmosisApp.TransferStack.OnRecvPacket(ctx, packet, relayer)
+	suite.Require().True(ack.Success())
+	suite.Require().Equal(`{"count":0}`, suite.hookedCount(addr))
+	processed, found := osmosisApp.IBCHooksKeeper.GetProcessedPacket(ctx, packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	suite.Require().Equal(ack.Acknowledgement(), processed.Ack)
+	suite.Require().Equal(ctx.BlockHeight(), processed.Height)
+
+	// the redelivery gets the same ack, and neither executes the contract nor receives the funds again
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	redeliveredAck := osmosisApp.TransferStack.OnRecvPacket(ctx, packet, relayer)
+	suite.Require().True(redeliveredAck.Success())
+	suite.Require().Equal(ack.Acknowledgement(), redeliveredAck.Acknowledgement())
+	suite.AssertEventEmitted(ctx, types.TypeEvtPacketRedelivered, 1)
+	suite.Require().Equal(`{"count":0}`, suite.hookedCount(addr))
+	suite.Require().Equal(sdk.NewInt(1), osmosisApp.BankKeeper.GetBalance(ctx, addr, localDenom).Amount)
+
+	// a packet with the same sequence but different data is not a redelivery
+	otherPacket := suite.makeMockPacketWithAmount(addr.String(), memo, 0, "2")
+	suite.Require().True(osmosisApp.TransferStack.OnRecvPacket(ctx, otherPacket, relayer).Success())
+	suite.Require().Equal(`{"count":1}`, suite.hookedCount(addr))
+	suite.Require().Len(osmosisApp.IBCHooksKeeper.GetAllProcessedPackets(ctx), 1)
+
+	// the record is kept for the window, after which a redelivery is received as a new packet
+	osmosisApp.IBCHooksKeeper.PruneProcessedPackets(ctx.WithBlockHeight(ctx.BlockHeight()+types.ProcessedPacketWindow), types.ProcessedPacketWindow, types.MaxPrunedProcessedPacketsPerBlock)
+	_, found = osmosisApp.IBCHooksKeeper.GetProcessedPacket(ctx, packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	pruned := osmosisApp.IBCHooksKeeper.PruneProcessedPackets(ctx.WithBlockHeight(ctx.BlockHeight()+types.ProcessedPacketWindow+1), types.ProcessedPacketWindow, types.MaxPrunedProcessedPacketsPerBlock)
+	suite.Require().Equal(1, pruned)
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllProcessedPackets(ctx))
+	suite.Require().True(osmosisApp.TransferStack.OnRecvPacket(ctx, packet, relayer).Success())
+	suite.Require().Equal(`{"count":2}`, suite.hookedCount(addr))
+}
+
+// A redelivered packet whose receive is deferred gets no ack, and is not deferred again
+func (suite *HooksTestSuite) TestRedeliveredDeferredPacket() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	memo := fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}},"defer_on_transfer_failure":true}}`, addr)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+
+	suite.setReceiveEnabled(false)
+	ctx := suite.chainA.GetContext()
+	packet := suite.makeMockPacket(addr.String(), memo, 0)
+	suite.Require().Nil(osmosisApp.TransferStack.OnRecvPacket(ctx, packet, suite.chainB.SenderAccount.GetAddress()))
+	suite.Require().Nil(osmosisApp.TransferStack.OnRecvPacket(ctx, packet, suite.chainB.SenderAccount.GetAddress()))
+	deferred, found := osmosisApp.IBCHooksKeeper.GetDeferredRecv(ctx, packet.GetDestChannel(), packet.GetSequence())
+	suite.Require().True(found)
+	suite.Require().Equal(uint32(1), deferred.Attempts)
+
+	// once the retry acknowledged it, its redeliveries get the written ack
+	suite.setReceiveEnabled(true)
+	ack := suite.retryDeferredRecv(packet)
+	suite.Require().Contains(string(ack), "result")
+	redeliveredAck := osmosisApp.TransferStack.OnRecvPacket(suite.chainA.GetContext(), packet, suite.chainB.SenderAccount.GetAddress())
+	suite.Require().Equal(ack, redeliveredAck.Acknowledgement())
+	suite.Require().Equal(`{"count":0}`, suite.hookedCount(addr))
+}
+
+// The SimulateHook query validates memos, and executes hooks without committing anything
+func (suite *HooksTestSuite) TestSimulateHook() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/counter.wasm")
+	addr := suite.chainA.InstantiateContract(&suite.Suite, `{"count": 0}`, 1)
+	hooksKeeper := suite.chainA.GetOsmosisApp().IBCHooksKeeper
+	sender := suite.chainB.SenderAccount.GetAddress().String()
+	req := types.QuerySimulateHookRequest{
+		Memo:    fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"increment":{}}}}`, addr),
+		Denom:   sdk.DefaultBondDenom,
+		Amount:  "1",
+		Sender:  sender,
+		Channel: suite.path.EndpointA.ChannelID,
+	}
+
+	// without executing it, the memo is only validated
+	res, err := hooksKeeper.SimulateHook(sdk.WrapSDKContext(suite.chainA.GetContext()), &req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&types.QuerySimulateHookResponse{IsWasmRouted: true, Contract: addr.String()}, res)
+
+	// the dry run returns the ack of the execution, and leaves the contract's state alone
+	countBefore := suite.hookedCount(addr)
+	req.Execute = true
+	res, err = hooksKeeper.SimulateHook(sdk.WrapSDKContext(suite.chainA.GetContext()), &req)
+	suite.Require().NoError(err)
+	suite.Require().True(res.Success)
+	suite.Require().Contains(string(res.Ack), "result")
+	suite.Require().NotZero(res.GasUsed)
+	suite.Require().Equal(countBefore, suite.hookedCount(addr))
+
+	// the packet then really received executes the hook
+	ack := suite.receivePacket(addr.String(), req.Memo)
+	suite.Require().Contains(string(ack), "result")
+	suite.Require().NotEqual(countBefore, suite.hookedCount(addr))
+
+	// a hook failing in the dry run gets its error ack
+	req.Memo = fmt.Sprintf(`{"wasm":{"contract":"%s","msg":{"not_a_method":{}}}}`, addr)
+	res, err = hooksKeeper.SimulateHook(sdk.WrapSDKContext(suite.chainA.GetContext()), &req)
+	suite.Require().NoError(err)
+	suite.Require().False(res.Success)
+	suite.Require().Contains(string(res.Ack), "error")
+
+	// an invalid memo is rejected, whether or not it is executed
+	for _, execute := range []bool{false, true} {
+		req.Execute = execute
+		req.Memo = fmt.Sprintf(`{"wasm":{"contract":"%s","msg":"increment"}}`, addr)
+		_, err = hooksKeeper.SimulateHook(sdk.WrapSDKContext(suite.chainA.GetContext()), &req)
+		suite.Require().ErrorContains(err, `wasm["msg"] is not a map object`)
+	}
+
+	// a memo without a hook is received as a plain transfer
+	req.Memo = `{"other_middleware":{}}`
+	res, err = hooksKeeper.SimulateHook(sdk.WrapSDKContext(suite.chainA.GetContext()), &req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&types.QuerySimulateHookResponse{}, res)
+}
+
+func (suite *HooksTestSuite) TestAckSubscriptionMsgs() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	contract := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	msgServer := keeper.NewMsgServerImpl(*osmosisApp.IBCHooksKeeper)
+	authority := suite.chainA.SenderAccount.GetAddress().String()
+	other := suite.chainB.SenderAccount.GetAddress().String()
+	ctx := suite.chainA.GetContext()
+	subscribe := func(sender, contract, channel string) error {
+		_, err := msgServer.SubscribeChannelAcks(sdk.WrapSDKContext(ctx), types.NewMsgSubscribeChannelAcks(sender, contract, channel))
+		return err
+	}
+	unsubscribe := func(sender, contract, channel string) error {
+		_, err := msgServer.UnsubscribeChannelAcks(sdk.WrapSDKContext(ctx), types.NewMsgUnsubscribeChannelAcks(sender, contract, channel))
+		return err
+	}
+
+	// Contracts can't subscribe themselves until governance sets the fee, and no one else can subscribe them
+	suite.Require().ErrorIs(subscribe(contract.String(), contract.String(), "channel-0"), sdkerrors.ErrUnauthorized)
+	suite.Require().ErrorIs(subscribe(authority, contract.String(), "channel-0"), sdkerrors.ErrUnauthorized)
+	suite.Require().Empty(osmosisApp.IBCHooksKeeper.GetAllAckSubscriptions(ctx))
+
+	// The callback authority subscribes contracts for free, but only contracts
+	params := types.DefaultParams()
+	params.CallbackAuthority = authority
+	osmosisApp.IBCHooksKeeper.SetParams(ctx, params)
+	suite.Require().NoError(subscribe(authority, contract.String(), "channel-0"))
+	suite.AssertEventEmitted(ctx, types.TypeMsgSubscribeChannelAcks, 1)
+	suite.Require().ErrorIs(subscribe(authority, other, "channel-0"), sdkerrors.ErrInvalidRequest)
+	suite.Require().ErrorIs(subscribe(authority, contract.String(), "channel-0"), sdkerrors.ErrInvalidRequest)
+	suite.Require().ErrorIs(subscribe(other, contract.String(), "channel-1"), sdkerrors.ErrUnauthorized)
+
+	// Once the fee is set, contracts subscribe themselves by paying it to the community pool
+	fee := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(100)))
+	params.AckSubscriptionFee = fee
+	osmosisApp.IBCHooksKeeper.SetParams(ctx, params)
+	suite.Require().Error(subscribe(contract.String(), contract.String(), "channel-1"))
+	suite.Require().NoError(osmosisApp.BankKeeper.SendCoins(ctx, suite.chainA.SenderAccount.GetAddress(), contract, fee))
+	communityPool := osmosisApp.DistrKeeper.GetFeePoolCommunityCoins(ctx)
+	suite.Require().NoError(subscribe(contract.String(), contract.String(), "channel-1"))
+	suite.Require().True(osmosisApp.BankKeeper.GetAllBalances(ctx, contract).IsZero())
+	suite.Require().Equal(communityPool.Add(sdk.NewDecCoinsFromCoins(fee...)...), osmosisApp.DistrKeeper.GetFeePoolCommunityCoins(ctx))
+
+	res, err := osmosisApp.IBCHooksKeeper.AckSubscriptions(sdk.WrapSDKContext(ctx), &types.QueryAckSubscriptionsRequest{Channel: "channel-1"})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{contract.String()}, res.Contracts)
+	_, err = osmosisApp.IBCHooksKeeper.AckSubscriptions(sdk.WrapSDKContext(ctx), &types.QueryAckSubscriptionsRequest{Channel: "invalid"})
+	suite.Require().Error(err)
+
+	// A channel only has so many subscribers
+	for i := 1; i < types.MaxAckSubscribersPerChannel; i++ {
+		subscriber := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+		suite.Require().NoError(subscribe(authority, subscriber.String(), "channel-0"))
+	}
+	suite.Require().Len(osmosisApp.IBCHooksKeeper.GetChannelAckSubscribers(ctx, "channel-0"), types.MaxAckSubscribersPerChannel)
+	extra := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	suite.Require().ErrorIs(subscribe(authority, extra.String(), "channel-0"), sdkerrors.ErrInvalidRequest)
+
+	// Contracts unsubscribe themselves, or the callback authority unsubscribes them
+	suite.Require().ErrorIs(unsubscribe(other, contract.String(), "channel-0"), sdkerrors.ErrUnauthorized)
+	suite.Require().NoError(unsubscribe(contract.String(), contract.String(), "channel-0"))
+	suite.AssertEventEmitted(ctx, types.TypeMsgUnsubscribeChannelAcks, 1)
+	suite.Require().ErrorIs(unsubscribe(contract.String(), contract.String(), "channel-0"), sdkerrors.ErrNotFound)
+	suite.Require().NoError(unsubscribe(authority, contract.String(), "channel-1"))
+	suite.Require().Len(osmosisApp.IBCHooksKeeper.GetAllAckSubscriptions(ctx), types.MaxAckSubscribersPerChannel-1)
+}
+
+// ackSubscriberRecorder is a contract keeper that records the channel_ack messages of the subscribers, and makes
+// the failing subscriber write to the store and run out of gas
+type ackSubscriberRecorder struct {
+	types.ContractKeeper
+	storeKey sdk.StoreKey
+	failing  sdk.AccAddress
+	notified map[string][]string
+}
+
+func (r *ackSubscriberRecorder) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	if !bytes.HasPrefix(msg, []byte(`{"channel_ack":`)) {
+		return r.ContractKeeper.Sudo(ctx, contractAddress, msg)
+	}
+	if contractAddress.Equals(r.failing) {
+		ctx.KVStore(r.storeKey).Set([]byte("failing subscriber"), []byte{1})
+		ctx.GasMeter().ConsumeGas(types.AckSubscriberGasLimit+1, "failing subscriber")
+	}
+	r.notified[contractAddress.String()] = append(r.notified[contractAddress.String()], string(msg))
+	return nil, nil
+}
+
+// The subscribers of a channel are notified of the acks of its packets, and one failing doesn't affect the ack or
+// the others
+func (suite *HooksTestSuite) TestAckSubscribersNotified() {
+	suite.chainA.StoreContractCode(&suite.Suite, "./bytecode/echo.wasm")
+	subscriber := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	failing := suite.chainA.InstantiateContract(&suite.Suite, "{}", 1)
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	storeKey := osmosisApp.GetKey(types.StoreKey)
+	recorder := &ackSubscriberRecorder{ContractKeeper: osmosisApp.WasmKeeper, storeKey: storeKey, failing: failing, notified: map[string][]string{}}
+	osmosisApp.IBCHooksKeeper.SetContractKeeper(recorder)
+
+	ctx := suite.chainA.GetContext()
+	channel := suite.path.EndpointA.ChannelID
+	suite.Require().NoError(osmosisApp.IBCHooksKeeper.SubscribeChannelAcks(ctx, subscriber.String(), channel))
+	suite.Require().NoError(osmosisApp.IBCHooksKeeper.SubscribeChannelAcks(ctx, failing.String(), channel))
+	// Subscribers of other channels aren't notified
+	suite.Require().NoError(osmosisApp.IBCHooksKeeper.SubscribeChannelAcks(ctx, subscriber.String(), "channel-10"))
+
+	sender := suite.chainA.SenderAccount.GetAddress().String()
+	receiver := suite.chainB.SenderAccount.GetAddress().String()
+	data := transfertypes.NewFungibleTokenPacketData(sdk.DefaultBondDenom, "1", sender, receiver)
+	packet := channeltypes.NewPacket(data.GetBytes(), 1, transfertypes.PortID, channel, transfertypes.PortID, suite.path.EndpointB.ChannelID, clienttypes.NewHeight(0, 100), 0)
+	ack := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+	err := osmosisApp.TransferStack.OnAcknowledgementPacket(ctx, packet, ack, suite.chainA.SenderAccount.GetAddress())
+	suite.Require().NoError(err)
+
+	expected := fmt.Sprintf(`{"channel_ack":{"channel":"%s","sequence":1,"sender":"%s","receiver":"%s","denom":"%s","amount":"1","success":true}}`, channel, sender, receiver, sdk.DefaultBondDenom)
+	suite.Require().Equal([]string{expected}, recorder.notified[subscriber.String()])
+	suite.Require().Empty(recorder.notified[failing.String()])
+	suite.Require().False(ctx.KVStore(storeKey).Has([]byte("failing subscriber")))
+	suite.AssertEventEmitted(ctx, types.TypeEvtAckSubscriberFailed, 1)
+}
+
+// Packets with a callback are only sent if their timeout is within the callback timeout bounds of the params
+func (suite *HooksTestSuite) TestCallbackTimeoutBounds() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx := suite.chainA.GetContext()
+	params := types.DefaultParams()
+	params.MinCallbackTimeout = time.Minute
+	params.MaxCallbackTimeout = 24 * time.Hour
+	osmosisApp.IBCHooksKeeper.SetParams(ctx, params)
+
+	sender := suite.chainA.SenderAccount.GetAddress().String()
+	receiver := suite.chainB.SenderAccount.GetAddress().String()
+	callbackMemo := fmt.Sprintf(`{"ibc_callback":"%s"}`, sender)
+	channel := suite.path.EndpointA.ChannelID
+
+	testCases := []struct {
+		name         string
+		memo         string
+		timeout      time.Duration
+		expectSent   bool
+		expectStored bool
+	}{
+		{"too short", callbackMemo, 10 * time.Second, false, false},
+		{"too long", callbackMemo, 365 * 24 * time.Hour, false, false},
+		{"no timeout timestamp", callbackMemo, 0, false, false},
+		{"in range", callbackMemo, time.Hour, true, true},
+		{"no callback", "", 365 * 24 * time.Hour, true, false},
+		{"invalid callback", `{"ibc_callback":"not an address"}`, 365 * 24 * time.Hour, true, false},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			transferMsg := NewMsgTransfer(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)), sender, receiver, tc.memo)
+			if tc.timeout != 0 {
+				transferMsg.TimeoutTimestamp = uint64(ctx.BlockTime().Add(tc.timeout).UnixNano())
+			}
+			sequence, found := osmosisApp.IBCKeeper.ChannelKeeper.GetNextSequenceSend(ctx, transfertypes.PortID, channel)
+			suite.Require().True(found)
+
+			_, err := osmosisApp.TransferKeeper.Transfer(sdk.WrapSDKContext(ctx), transferMsg)
+			if !tc.expectSent {
+				suite.Require().ErrorIs(err, sdkerrors.ErrInvalidRequest)
+				suite.Require().Contains(err.Error(), "invalid timeout for a packet with a callback")
+				nextSequence, _ := osmosisApp.IBCKeeper.ChannelKeeper.GetNextSequenceSend(ctx, transfertypes.PortID, channel)
+				suite.Require().Equal(sequence, nextSequence)
+				return
+			}
+			suite.Require().NoError(err)
+			_, stored := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, channel, sequence)
+			suite.Require().Equal(tc.expectStored, stored)
+		})
+	}
 }
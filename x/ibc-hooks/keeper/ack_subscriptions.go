@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const ackSubscriptionPrefix = "ack-subscription::"
+
+// GetAckSubscriptionPrefix returns the prefix of the keys of the subscriptions to the acks of channel
+func GetAckSubscriptionPrefix(channel string) []byte {
+	return []byte(fmt.Sprintf("%s%s::", ackSubscriptionPrefix, channel))
+}
+
+func GetAckSubscriptionKey(channel, contract string) []byte {
+	return append(GetAckSubscriptionPrefix(channel), contract...)
+}
+
+// SubscribeChannelAcks subscribes contract to the acks of all the packets sent on channel. It errors if the contract
+// is already subscribed, or if the channel has MaxAckSubscribersPerChannel subscribers already. The caller checks
+// that the subscription is authorized and collects its fee.
+func (k Keeper) SubscribeChannelAcks(ctx sdk.Context, contract, channel string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetAckSubscriptionKey(channel, contract)
+	if store.Has(key) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is already subscribed to the acks of %s", contract, channel)
+	}
+	if len(k.GetChannelAckSubscribers(ctx, channel)) >= types.MaxAckSubscribersPerChannel {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s already has the maximum of %d ack subscribers", channel, types.MaxAckSubscribersPerChannel)
+	}
+	store.Set(key, []byte{1})
+	return nil
+}
+
+// UnsubscribeChannelAcks deletes the subscription of contract to the acks of channel. It returns false if there is
+// none.
+func (k Keeper) UnsubscribeChannelAcks(ctx sdk.Context, contract, channel string) bool {
+	store := ctx.KVStore(k.storeKey)
+	key := GetAckSubscriptionKey(channel, contract)
+	if !store.Has(key) {
+		return false
+	}
+	store.Delete(key)
+	return true
+}
+
+// GetChannelAckSubscribers returns the contracts subscribed to the acks of channel, sorted by address
+func (k Keeper) GetChannelAckSubscribers(ctx sdk.Context, channel string) []string {
+	keyPrefix := GetAckSubscriptionPrefix(channel)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), keyPrefix)
+	defer iterator.Close()
+	contracts := []string{}
+	for ; iterator.Valid(); iterator.Next() {
+		contracts = append(contracts, strings.TrimPrefix(string(iterator.Key()), string(keyPrefix)))
+	}
+	return contracts
+}
+
+// GetAllAckSubscriptions returns the subscriptions to the acks of every channel
+func (k Keeper) GetAllAckSubscriptions(ctx sdk.Context) []types.AckSubscription {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), []byte(ackSubscriptionPrefix))
+	defer iterator.Close()
+	subscriptions := []types.AckSubscription{}
+	for ; iterator.Valid(); iterator.Next() {
+		channel, contract, found := strings.Cut(strings.TrimPrefix(string(iterator.Key()), ackSubscriptionPrefix), "::")
+		if !found {
+			panic(fmt.Errorf("invalid ack subscription key %s", iterator.Key()))
+		}
+		subscriptions = append(subscriptions, types.AckSubscription{Channel: channel, Contract: contract})
+	}
+	return subscriptions
+}
+
+// NotifyAckSubscribers sudos each contract subscribed to the acks of the notification's channel with a channel_ack
+// message. Like the observer, a subscriber is only notified and can't affect the ack or the other subscribers: it
+// runs with a fixed gas limit, and its errors and state changes on error are discarded. The gas the subscribers
+// used is charged to ctx.
+func (k Keeper) NotifyAckSubscribers(ctx sdk.Context, notification types.AckNotification) {
+	if k.contractKeeper == nil {
+		return
+	}
+	msg := notification.SudoMsg()
+	for _, contract := range k.GetChannelAckSubscribers(ctx, notification.Channel) {
+		contractAddr, err := sdk.AccAddressFromBech32(contract)
+		if err != nil {
+			continue
+		}
+		subscriberCtx := ctx.WithGasMeter(sdk.NewGasMeter(types.AckSubscriberGasLimit))
+		err = osmoutils.ApplyFuncIfNoError(subscriberCtx, func(cacheCtx sdk.Context) error {
+			_, err := k.contractKeeper.Sudo(cacheCtx, contractAddr, msg)
+			return err
+		})
+		ctx.GasMeter().ConsumeGas(subscriberCtx.GasMeter().GasConsumedToLimit(), "ibc-hooks ack subscriber")
+		if err != nil {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.TypeEvtAckSubscriberFailed,
+				sdk.NewAttribute(types.AttributeChannel, notification.Channel),
+				sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(notification.Sequence, 10)),
+				sdk.NewAttribute(types.AttributeContract, contract),
+			))
+		}
+	}
+}
+
+// ChargeAckSubscriptionFee sends the ack_subscription_fee from contract to the community pool. It errors if the fee
+// is not set, as the self-subscriptions are then disabled.
+func (k Keeper) ChargeAckSubscriptionFee(ctx sdk.Context, contract sdk.AccAddress) (sdk.Coins, error) {
+	fee := k.GetParams(ctx).AckSubscriptionFee
+	if fee.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "contracts can't subscribe themselves to channel acks, the ack subscription fee is not set")
+	}
+	if err := k.distrKeeper.FundCommunityPool(ctx, fee, contract); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// DeleteExpiredCallbacks deletes up to limit callbacks whose expiry height is at most the current block height,
+// earliest expiry first, and returns them.
+func (k Keeper) DeleteExpiredCallbacks(ctx sdk.Context, limit int) []types.PendingPacketCallback {
+	store := ctx.KVStore(k.storeKey)
+	end := []byte(fmt.Sprintf("%s%020d", packetCallbackExpiryPrefix, ctx.BlockHeight()+1))
+	iterator := store.Iterator([]byte(packetCallbackExpiryPrefix), end)
+	expired := []types.PendingPacketCallback{}
+	for ; iterator.Valid() && len(expired) < limit; iterator.Next() {
+		channel, sequence, ok := parsePacketCallbackExpiryKey(iterator.Key())
+		if !ok {
+			panic(fmt.Errorf("invalid packet callback expiry index key %s", iterator.Key()))
+		}
+		expired = append(expired, types.PendingPacketCallback{Channel: channel, Sequence: sequence})
+	}
+	iterator.Close()
+
+	for i, pending := range expired {
+		callback, found := k.GetPacketCallbackInfo(ctx, pending.Channel, pending.Sequence)
+		if !found {
+			panic(fmt.Errorf("expiring packet callback %s/%d not found", pending.Channel, pending.Sequence))
+		}
+		expired[i].Callback = callback
+		k.DeletePacketCallback(ctx, pending.Channel, pending.Sequence)
+	}
+	return expired
+}
+
+// parsePacketCallbackExpiryKey returns the channel and sequence of a key created with GetPacketCallbackExpiryKey
+func parsePacketCallbackExpiryKey(key []byte) (channel string, packetSequence uint64, ok bool) {
+	heightStr, packetKey, found := strings.Cut(strings.TrimPrefix(string(key), packetCallbackExpiryPrefix), "::")
+	if !found {
+		return "", 0, false
+	}
+	if _, err := strconv.ParseInt(heightStr, 10, 64); err != nil {
+		return "", 0, false
+	}
+	return ParsePacketKey([]byte(packetKey))
+}
+
+// SweepExpiredCallbacks deletes the callbacks that expired, up to the max_expired_callbacks_per_block param.
+// The callbacks left over are deleted in the following blocks. If the notify_expired_callbacks param is set,
+// the contract of each deleted callback is sudoed with a callback_expired message. Like the observer, it runs
+// with a fixed gas limit, and its errors and state changes on error are discarded.
+func (k Keeper) SweepExpiredCallbacks(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	expired := k.DeleteExpiredCallbacks(ctx, int(params.MaxExpiredCallbacksPerBlock))
+	for _, pending := range expired {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtPacketCallbackExpired,
+			sdk.NewAttribute(types.AttributeChannel, pending.Channel),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(pending.Sequence, 10)),
+			sdk.NewAttribute(types.AttributeContract, pending.Callback.Contract),
+		))
+		if !params.NotifyExpiredCallbacks || k.contractKeeper == nil {
+			continue
+		}
+		contractAddr, err := sdk.AccAddressFromBech32(pending.Callback.Contract)
+		if err != nil {
+			continue
+		}
+		notificationCtx := ctx.WithGasMeter(sdk.NewGasMeter(types.ExpiredCallbackGasLimit))
+		_ = osmoutils.ApplyFuncIfNoError(notificationCtx, func(cacheCtx sdk.Context) error {
+			_, err := k.contractKeeper.Sudo(cacheCtx, contractAddr, types.CallbackExpiredSudoMsg(pending.Channel, pending.Sequence))
+			return err
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const callbackRegistrationGrantPrefix = "callback-registration-grant::"
+
+func GetCallbackRegistrationGrantKey(granter, grantee string) []byte {
+	return []byte(fmt.Sprintf("%s%s::%s", callbackRegistrationGrantPrefix, granter, grantee))
+}
+
+// GrantCallbackRegistration allows grantee to register callbacks to the granter contract for the packets
+// the contract sent, until expiration. It replaces any grant from granter to grantee.
+func (k Keeper) GrantCallbackRegistration(ctx sdk.Context, granter, grantee string, expiration time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetCallbackRegistrationGrantKey(granter, grantee), sdk.FormatTimeBytes(expiration))
+}
+
+// RevokeCallbackRegistration deletes the grant from granter to grantee. It returns false if there is none.
+func (k Keeper) RevokeCallbackRegistration(ctx sdk.Context, granter, grantee string) bool {
+	store := ctx.KVStore(k.storeKey)
+	key := GetCallbackRegistrationGrantKey(granter, grantee)
+	if !store.Has(key) {
+		return false
+	}
+	store.Delete(key)
+	return true
+}
+
+// GetCallbackRegistrationGrant returns the expiration of the grant from granter to grantee, whether it
+// expired or not.
+func (k Keeper) GetCallbackRegistrationGrant(ctx sdk.Context, granter, grantee string) (expiration time.Time, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetCallbackRegistrationGrantKey(granter, grantee))
+	if bz == nil {
+		return time.Time{}, false
+	}
+	expiration, err := sdk.ParseTimeBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return expiration, true
+}
+
+// GetAllCallbackRegistrationGrants returns every callback registration grant, whether it expired or not
+func (k Keeper) GetAllCallbackRegistrationGrants(ctx sdk.Context) []types.CallbackRegistrationGrant {
+	grants := []types.CallbackRegistrationGrant{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), []byte(callbackRegistrationGrantPrefix))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		granter, grantee, found := strings.Cut(strings.TrimPrefix(string(iterator.Key()), callbackRegistrationGrantPrefix), "::")
+		if !found {
+			panic(fmt.Errorf("invalid callback registration grant key %s", iterator.Key()))
+		}
+		expiration, err := sdk.ParseTimeBytes(iterator.Value())
+		if err != nil {
+			panic(err)
+		}
+		grants = append(grants, types.CallbackRegistrationGrant{Granter: granter, Grantee: grantee, Expiration: expiration})
+	}
+	return grants
+}
+
+// HasCallbackRegistrationGrant returns true if grantee can register callbacks to the granter contract,
+// i.e. if granter gave it a grant that hasn't expired at the block time.
+func (k Keeper) HasCallbackRegistrationGrant(ctx sdk.Context, granter, grantee string) bool {
+	expiration, found := k.GetCallbackRegistrationGrant(ctx, granter, grantee)
+	return found && ctx.BlockTime().Before(expiration)
+}
+
+// RegisterPacketCallback registers a callback to contract for the ICS20 packet it sent on channel with the given
+// sequence. The packet must still be waiting for its ack or timeout, and have no callback.
+// Only the commitment of a sent packet is kept in state, so the packet's data and timeout are checked against it
+// before the packet's ICS20 sender is compared to contract. Like a callback in a memo, its timeout must be within
+// the callback timeout bounds of the params.
+func (k Keeper) RegisterPacketCallback(
+	ctx sdk.Context,
+	contract, channel string,
+	sequence uint64,
+	packetData []byte,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	entry types.CallbackEntry,
+) error {
+	if _, found := k.GetPacketCallbackInfo(ctx, channel, sequence); found {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "packet %d on %s already has a callback", sequence, channel)
+	}
+	// the commitment is deleted once the packet is acked or timed out
+	commitment := k.channelKeeper.GetPacketCommitment(ctx, transfertypes.PortID, channel, sequence)
+	if len(commitment) == 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrNotFound, "packet %d on %s is not waiting for an ack or timeout", sequence, channel)
+	}
+	packet := channeltypes.NewPacket(packetData, sequence, transfertypes.PortID, channel, "", "", timeoutHeight, timeoutTimestamp)
+	// the commitment only covers the data and timeout of the packet, and the codec is unused
+	if !bytes.Equal(commitment, channeltypes.CommitPacket(nil, packet)) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "the data and timeout don't match the commitment of packet %d on %s", sequence, channel)
+	}
+
+	var data transfertypes.FungibleTokenPacketData
+	if err := json.Unmarshal(packetData, &data); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "packet %d on %s is not an ICS20 packet", sequence, channel)
+	}
+	if data.Sender != contract {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "packet %d on %s was sent by %s, not %s", sequence, channel, data.Sender, contract)
+	}
+	if err := k.GetParams(ctx).ValidateCallbackTimeout(ctx.BlockTime(), timeoutTimestamp); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, types.ErrBadCallbackTimeout, err)
+	}
+
+	k.StorePacketCallback(ctx, channel, sequence, contract, entry, 0)
+	return nil
+}
@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const (
+	deferredRecvPrefix            = "deferred-recv::"
+	deferredRecvRetryHeightPrefix = "deferred-recv-by-height::"
+)
+
+// GetDeferredRecvKey returns the key at which a deferred packet, received on channel, is stored
+func GetDeferredRecvKey(channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s::%d", deferredRecvPrefix, channel, packetSequence))
+}
+
+// GetDeferredRecvRetryHeightKey returns the key at which a deferred packet is indexed by the height of its next
+// retry. The height is zero padded so that the keys are sorted by height.
+func GetDeferredRecvRetryHeightKey(retryHeight int64, channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d::%s::%d", deferredRecvRetryHeightPrefix, retryHeight, channel, packetSequence))
+}
+
+// DeferRecv stores a received hooked packet whose ICS20 receive failed, to be received again from the next block.
+// The packet's ack is written once a retry succeeds, or once its MaxDeferredRecvAttempts attempts failed.
+func (k Keeper) DeferRecv(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) {
+	deferred := types.DeferredRecv{
+		Packet:      packet,
+		Relayer:     relayer.String(),
+		Attempts:    1,
+		RetryHeight: ctx.BlockHeight() + 1,
+	}
+	k.setDeferredRecv(ctx, deferred)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtRecvDeferred,
+		sdk.NewAttribute(types.AttributeChannel, packet.GetDestChannel()),
+		sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+	))
+}
+
+// setDeferredRecv stores the deferred packet and indexes it by the height of its next retry
+func (k Keeper) setDeferredRecv(ctx sdk.Context, deferred types.DeferredRecv) {
+	store := ctx.KVStore(k.storeKey)
+	channel, sequence := deferred.Packet.GetDestChannel(), deferred.Packet.GetSequence()
+	osmoutils.MustSet(store, GetDeferredRecvKey(channel, sequence), &deferred)
+	store.Set(GetDeferredRecvRetryHeightKey(deferred.RetryHeight, channel, sequence), []byte{1})
+}
+
+// GetDeferredRecv returns the deferred packet received on channel with the given sequence, if any
+func (k Keeper) GetDeferredRecv(ctx sdk.Context, channel string, packetSequence uint64) (types.DeferredRecv, bool) {
+	deferred := types.DeferredRecv{}
+	found, err := osmoutils.Get(ctx.KVStore(k.storeKey), GetDeferredRecvKey(channel, packetSequence), &deferred)
+	if err != nil {
+		panic(err)
+	}
+	return deferred, found
+}
+
+// deleteDeferredRecv deletes the deferred packet along with its retry height index
+func (k Keeper) deleteDeferredRecv(ctx sdk.Context, deferred types.DeferredRecv) {
+	store := ctx.KVStore(k.storeKey)
+	channel, sequence := deferred.Packet.GetDestChannel(), deferred.Packet.GetSequence()
+	store.Delete(GetDeferredRecvKey(channel, sequence))
+	store.Delete(GetDeferredRecvRetryHeightKey(deferred.RetryHeight, channel, sequence))
+}
+
+// GetAllDeferredRecvs returns all the deferred packets, sorted by channel and sequence
+func (k Keeper) GetAllDeferredRecvs(ctx sdk.Context) []types.DeferredRecv {
+	deferred, err := osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(deferredRecvPrefix), func(bz []byte) (types.DeferredRecv, error) {
+		deferred := types.DeferredRecv{}
+		err := deferred.Unmarshal(bz)
+		return deferred, err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return deferred
+}
+
+// getDueDeferredRecvs returns up to limit deferred packets whose retry height is at most the current block height,
+// earliest retry height first.
+func (k Keeper) getDueDeferredRecvs(ctx sdk.Context, limit int) []types.DeferredRecv {
+	store := ctx.KVStore(k.storeKey)
+	end := []byte(fmt.Sprintf("%s%020d", deferredRecvRetryHeightPrefix, ctx.BlockHeight()+1))
+	iterator := store.Iterator([]byte(deferredRecvRetryHeightPrefix), end)
+	defer iterator.Close()
+	due := []types.DeferredRecv{}
+	for ; iterator.Valid() && len(due) < limit; iterator.Next() {
+		channel, sequence, ok := parseDeferredRecvRetryHeightKey(iterator.Key())
+		if !ok {
+			panic(fmt.Errorf("invalid deferred recv retry height key %s", iterator.Key()))
+		}
+		deferred, found := k.GetDeferredRecv(ctx, channel, sequence)
+		if !found {
+			panic(fmt.Errorf("deferred recv %s/%d not found", channel, sequence))
+		}
+		due = append(due, deferred)
+	}
+	return due
+}
+
+// parseDeferredRecvRetryHeightKey returns the channel and sequence of a key created with
+// GetDeferredRecvRetryHeightKey
+func parseDeferredRecvRetryHeightKey(key []byte) (channel string, packetSequence uint64, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(string(key), deferredRecvRetryHeightPrefix), "::")
+	if len(parts) != 3 || !channeltypes.IsValidChannelID(parts[1]) {
+		return "", 0, false
+	}
+	if _, err := strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return "", 0, false
+	}
+	packetSequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[1], packetSequence, true
+}
+
+// RetryDeferredRecvs receives again the deferred packets due in this block, up to MaxDeferredRecvRetriesPerBlock.
+// As in IBC core, the state changes of a retry are only kept if its ack is successful. A packet whose receive
+// fails again is retried in the next block, until its last attempt, after which its error ack is written.
+func (k Keeper) RetryDeferredRecvs(ctx sdk.Context) {
+	if k.recvRetrier == nil {
+		return
+	}
+	for _, deferred := range k.getDueDeferredRecvs(ctx, types.MaxDeferredRecvRetriesPerBlock) {
+		k.deleteDeferredRecv(ctx, deferred)
+		relayer, _ := sdk.AccAddressFromBech32(deferred.Relayer)
+		final := deferred.Attempts+1 >= types.MaxDeferredRecvAttempts
+
+		cacheCtx, writeFn := ctx.CacheContext()
+		ack := k.recvRetrier.RetryRecv(cacheCtx, deferred.Packet, relayer, final)
+		deferred.Attempts++
+		if ack == nil {
+			deferred.RetryHeight = ctx.BlockHeight() + 1
+			k.setDeferredRecv(ctx, deferred)
+			continue
+		}
+		if ack.Success() {
+			writeFn()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtDeferredRecvAcked,
+			sdk.NewAttribute(types.AttributeChannel, deferred.Packet.GetDestChannel()),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(deferred.Packet.GetSequence(), 10)),
+			sdk.NewAttribute(types.AttributeAttempts, strconv.FormatUint(uint64(deferred.Attempts), 10)),
+			sdk.NewAttribute(types.AttributeSuccess, strconv.FormatBool(ack.Success())),
+		))
+		if err := k.recvRetrier.WriteRecvAck(ctx, deferred.Packet, ack); err != nil {
+			k.Logger(ctx).Error("cannot write the ack of a deferred packet",
+				"channel", deferred.Packet.GetDestChannel(), "sequence", deferred.Packet.GetSequence(), "error", err)
+		}
+	}
+}
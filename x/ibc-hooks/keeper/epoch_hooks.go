@@ -0,0 +1,32 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	epochstypes "github.com/osmosis-labs/osmosis/v13/x/epochs/types"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var _ epochstypes.EpochHooks = EpochHooks{}
+
+type EpochHooks struct {
+	k Keeper
+}
+
+func (k Keeper) EpochHooks() epochstypes.EpochHooks {
+	return EpochHooks{k}
+}
+
+// BeforeEpochStart is the epoch start hook.
+func (h EpochHooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber int64) error {
+	return nil
+}
+
+// AfterEpochEnd is the epoch end hook. The per channel hooked packet stats roll over at the end of every
+// stats epoch.
+func (h EpochHooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber int64) error {
+	if epochIdentifier == types.StatsEpochIdentifier {
+		h.k.RolloverChannelHookStats(ctx, epochNumber)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// logRateLimiter bounds the number of lines logged per block. Logs are not part of the state, so it is kept in
+// memory, and shared by all the copies of the keeper.
+type logRateLimiter struct {
+	limit uint64
+	// height is the block logged counts the lines of
+	height int64
+	logged uint64
+}
+
+// allow counts a line to be logged at height, and returns whether it is within the limit of the block.
+// limitReached is true for the first line over the limit only, so that the suppression can be logged once.
+func (l *logRateLimiter) allow(height int64) (allowed bool, limitReached bool) {
+	if l.height != height {
+		l.height = height
+		l.logged = 0
+	}
+	l.logged++
+	return l.logged <= l.limit, l.logged == l.limit+1
+}
+
+// HookFailure is a failed hooked packet, or ack callback, as logged for node operators
+type HookFailure struct {
+	// Channel is the channel of the packet on this chain: its destination for a received packet, and its source
+	// for an ack
+	Channel    string
+	Sequence   uint64
+	PacketData []byte
+	Contract   string
+	// ErrorCode is one of the Failure codes of the types package
+	ErrorCode string
+	Denom     string
+	Amount    string
+	Error     string
+}
+
+// PacketFingerprint returns a short hash of the packet data, so that the logs of packets with the same data, e.g.
+// a spam sent on several channels, can be matched
+func PacketFingerprint(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:8])
+}
+
+// LogHookFailure logs the failure with structured fields. At most types.MaxHookFailureLogsPerBlock failures are
+// logged per block, the following ones are dropped after a single line saying so.
+// Simulations and CheckTx don't log failures.
+func (k Keeper) LogHookFailure(ctx sdk.Context, failure HookFailure) {
+	if ctx.IsCheckTx() {
+		return
+	}
+	logger := ctx.Logger().With("module", "ibc-hooks")
+	allowed, limitReached := k.failureLogs.allow(ctx.BlockHeight())
+	if limitReached {
+		logger.Info("too many hook failures in this block, the next ones are not logged", "limit", k.failureLogs.limit)
+	}
+	if !allowed {
+		return
+	}
+	logger.Info("hook failed",
+		"channel", failure.Channel,
+		"sequence", failure.Sequence,
+		"fingerprint", PacketFingerprint(failure.PacketData),
+		"contract", failure.Contract,
+		"error_code", failure.ErrorCode,
+		"denom", failure.Denom,
+		"amount", failure.Amount,
+		"error", failure.Error,
+	)
+}
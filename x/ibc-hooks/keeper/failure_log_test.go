@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRateLimiter(t *testing.T) {
+	limiter := logRateLimiter{limit: 2}
+	expected := []struct {
+		allowed      bool
+		limitReached bool
+	}{
+		{true, false},
+		{true, false},
+		// the first line over the limit reports it, the next ones are just dropped
+		{false, true},
+		{false, false},
+	}
+	for i, exp := range expected {
+		allowed, limitReached := limiter.allow(10)
+		require.Equal(t, exp.allowed, allowed, "line %d", i)
+		require.Equal(t, exp.limitReached, limitReached, "line %d", i)
+	}
+
+	// the count restarts with every block
+	allowed, limitReached := limiter.allow(11)
+	require.True(t, allowed)
+	require.False(t, limitReached)
+	allowed, _ = limiter.allow(11)
+	require.True(t, allowed)
+	allowed, limitReached = limiter.allow(11)
+	require.False(t, allowed)
+	require.True(t, limitReached)
+}
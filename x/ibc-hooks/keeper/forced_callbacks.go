@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// ForceEmitCallback delivers ack to the contract of the callback registered for the packet sent on channel with
+// sequence, as if the packet had been acknowledged with it, and deletes the callback. Unlike a received ack, a failed
+// delivery is returned rather than logged, and leaves the callback in place. It returns the delivered callback.
+func (k Keeper) ForceEmitCallback(ctx sdk.Context, channel string, sequence uint64, ack []byte, success bool) (types.PacketCallback, error) {
+	callback, found := k.GetPacketCallbackInfo(ctx, channel, sequence)
+	if !found {
+		return types.PacketCallback{}, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "no callback for packet %d on %s", sequence, channel)
+	}
+	if k.callbacks == nil {
+		return types.PacketCallback{}, fmt.Errorf("no callback deliverer is set")
+	}
+	if err := k.callbacks.DeliverAckCallback(ctx, channel, sequence, callback, ack, success); err != nil {
+		return types.PacketCallback{}, sdkerrors.Wrap(err, "Ack callback error")
+	}
+	k.DeletePacketCallback(ctx, channel, sequence)
+	return callback, nil
+}
+
+// ForceDeleteCallback deletes the callback registered for the packet sent on channel with sequence without
+// delivering it, and returns it.
+func (k Keeper) ForceDeleteCallback(ctx sdk.Context, channel string, sequence uint64) (types.PacketCallback, error) {
+	callback, found := k.GetPacketCallbackInfo(ctx, channel, sequence)
+	if !found {
+		return types.PacketCallback{}, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "no callback for packet %d on %s", sequence, channel)
+	}
+	k.DeletePacketCallback(ctx, channel, sequence)
+	return callback, nil
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// InitGenesis initializes the ibc-hooks state from the genesis state. The params are left to their defaults if the
+// genesis state has none.
+func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
+	if genState.Params != nil {
+		k.SetParams(ctx, *genState.Params)
+	}
+	for _, pending := range genState.PacketCallbacks {
+		k.setPacketCallback(ctx, pending.Channel, pending.Sequence, pending.Callback)
+	}
+	for _, contract := range genState.SerializedContracts {
+		k.SetSerializePerBlock(ctx, contract, true)
+	}
+	for _, stats := range genState.ChannelHookStats {
+		k.setChannelHookStats(ctx, stats)
+	}
+	for _, grant := range genState.CallbackRegistrationGrants {
+		k.GrantCallbackRegistration(ctx, grant.Granter, grant.Grantee, grant.Expiration)
+	}
+	for _, deferred := range genState.DeferredRecvs {
+		k.setDeferredRecv(ctx, deferred)
+	}
+	for _, processed := range genState.ProcessedPackets {
+		k.setProcessedPacket(ctx, processed)
+	}
+	for _, subscription := range genState.AckSubscriptions {
+		if err := k.SubscribeChannelAcks(ctx, subscription.Contract, subscription.Channel); err != nil {
+			panic(err)
+		}
+	}
+	for _, contract := range genState.PrivilegedContracts {
+		if err := k.AddPrivilegedContract(ctx, contract); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns the ibc-hooks state as a genesis state.
+// The block journal and the transient store only hold the state of the current block, so they are not exported.
+// The callbacks that the v2 migration hasn't moved yet are exported along with the others.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	params := k.GetParams(ctx)
+	return &types.GenesisState{
+		Params:                     &params,
+		PacketCallbacks:            append(k.GetAllPacketCallbacks(ctx, ""), k.getAllLegacyPacketCallbacks(ctx)...),
+		SerializedContracts:        k.GetAllSerializedContracts(ctx),
+		ChannelHookStats:           k.GetAllChannelHookStats(ctx),
+		CallbackRegistrationGrants: k.GetAllCallbackRegistrationGrants(ctx),
+		DeferredRecvs:              k.GetAllDeferredRecvs(ctx),
+		ProcessedPackets:           k.GetAllProcessedPackets(ctx),
+		AckSubscriptions:           k.GetAllAckSubscriptions(ctx),
+		PrivilegedContracts:        k.GetAllPrivilegedContracts(ctx),
+	}
+}
@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+func (k Keeper) PacketCallbacks(ctx context.Context, req *types.QueryPacketCallbacksRequest) (*types.QueryPacketCallbacksResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	keyPrefix := GetPacketCallbackPrefix(req.Channel)
+	callbackStore := prefix.NewStore(sdkCtx.KVStore(k.storeKey), keyPrefix)
+
+	callbacks := []types.PendingPacketCallback{}
+	pageRes, err := query.Paginate(callbackStore, req.Pagination, func(key, value []byte) error {
+		pending, err := parsePendingPacketCallback(append(append([]byte{}, keyPrefix...), key...), value)
+		if err != nil {
+			return err
+		}
+		callbacks = append(callbacks, pending)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryPacketCallbacksResponse{Callbacks: callbacks, Pagination: pageRes}, nil
+}
+
+func (k Keeper) ChannelHookStats(ctx context.Context, req *types.QueryChannelHookStatsRequest) (*types.QueryChannelHookStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if !channeltypes.IsValidChannelID(req.Channel) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %s", req.Channel)
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return &types.QueryChannelHookStatsResponse{Stats: k.GetChannelHookStats(sdkCtx, req.Channel)}, nil
+}
+
+func (k Keeper) AckSubscriptions(ctx context.Context, req *types.QueryAckSubscriptionsRequest) (*types.QueryAckSubscriptionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if !channeltypes.IsValidChannelID(req.Channel) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %s", req.Channel)
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return &types.QueryAckSubscriptionsResponse{Contracts: k.GetChannelAckSubscribers(sdkCtx, req.Channel)}, nil
+}
+
+func (k Keeper) PrivilegedContracts(ctx context.Context, req *types.QueryPrivilegedContractsRequest) (*types.QueryPrivilegedContractsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return &types.QueryPrivilegedContractsResponse{Contracts: k.GetAllPrivilegedContracts(sdkCtx)}, nil
+}
+
+func (k Keeper) SimulateHook(ctx context.Context, req *types.QuerySimulateHookRequest) (*types.QuerySimulateHookResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if k.hookSimulator == nil {
+		return nil, status.Error(codes.Unavailable, "hook simulation is not configured")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	isWasmRouted, contract, err := k.hookSimulator.ValidateMemo(req.Memo, req.Receiver, sdkCtx.ChainID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !isWasmRouted {
+		return &types.QuerySimulateHookResponse{}, nil
+	}
+	res := &types.QuerySimulateHookResponse{IsWasmRouted: true, Contract: contract.String()}
+	if !req.Execute {
+		return res, nil
+	}
+
+	if !channeltypes.IsValidChannelID(req.Channel) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %s", req.Channel)
+	}
+	channel, found := k.channelKeeper.GetChannel(sdkCtx, transfertypes.PortID, req.Channel)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "channel %s of port %s not found", req.Channel, transfertypes.PortID)
+	}
+	data := transfertypes.FungibleTokenPacketData{
+		Denom:    req.Denom,
+		Amount:   req.Amount,
+		Sender:   req.Sender,
+		Receiver: contract.String(),
+		Memo:     req.Memo,
+	}
+	if err := data.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The simulated packet has sequence 0, which no packet received on the channel can have
+	packet := channeltypes.NewPacket(data.GetBytes(), 0, channel.Counterparty.PortId, channel.Counterparty.ChannelId,
+		transfertypes.PortID, req.Channel, clienttypes.ZeroHeight(), 0)
+
+	// The receive runs as in a CheckTx, so that it leaves the in-memory state of the keeper alone, and its cached
+	// state changes are never written
+	simCtx, _ := sdkCtx.CacheContext()
+	simCtx = simCtx.WithIsCheckTx(true).
+		WithGasMeter(sdk.NewGasMeter(types.SimulateHookGasLimit)).
+		WithEventManager(sdk.NewEventManager())
+	ack, err := k.simulateRecv(simCtx, packet)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	res.Ack = ack.Acknowledgement()
+	res.Success = ack.Success()
+	res.GasUsed = simCtx.GasMeter().GasConsumed()
+	return res, nil
+}
+
+// simulateRecv receives packet through the hook simulator. Running out of gas is returned as an error.
+func (k Keeper) simulateRecv(ctx sdk.Context, packet channeltypes.Packet) (ack ibcexported.Acknowledgement, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("out of gas in %s, the simulation is limited to %d gas", outOfGas.Descriptor, types.SimulateHookGasLimit)
+		}
+	}()
+	return k.hookSimulator.SimulateRecv(ctx, packet), nil
+}
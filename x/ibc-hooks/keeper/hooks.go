@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// SetHooks sets the hooks notified of the lifecycle of hooked packets. Until they are set, nobody is notified.
+func (k *Keeper) SetHooks(hooks types.IBCHooksHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set ibc-hooks hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
+// AfterHookExecution notifies the hooks that a hooked packet received on this chain got its ack.
+// Simulations and CheckTx don't execute the packet for real, so the hooks aren't notified of them.
+func (k Keeper) AfterHookExecution(ctx sdk.Context, packet types.HookedPacketInfo, result types.HookExecutionResult) {
+	if k.hooks == nil || ctx.IsCheckTx() {
+		return
+	}
+	_ = osmoutils.ApplyFuncIfNoError(ctx, func(ctx sdk.Context) error {
+		return k.hooks.AfterHookExecution(ctx, packet, result)
+	})
+}
+
+// AfterCallbackExecution notifies the hooks that the contract of a packet sent from this chain was called back
+// with its ack
+func (k Keeper) AfterCallbackExecution(ctx sdk.Context, packet types.CallbackPacketInfo, ack types.CallbackAckInfo) {
+	if k.hooks == nil || ctx.IsCheckTx() {
+		return
+	}
+	_ = osmoutils.ApplyFuncIfNoError(ctx, func(ctx sdk.Context) error {
+		return k.hooks.AfterCallbackExecution(ctx, packet, ack)
+	})
+}
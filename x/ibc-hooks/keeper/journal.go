@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// blockJournal keeps track of what happened to the hooked packets of the current block outside of the state.
+// The state changes of a packet that gets an error ack are reverted by IBC, so anything that must outlive
+// the packet's failure is recorded here instead. It is shared by all the copies of the keeper.
+type blockJournal struct {
+	pendingNotifications []pendingNotification
+	failedPackets        []failedPacket
+}
+
+type pendingNotification struct {
+	observer     sdk.AccAddress
+	notification types.HookExecutedNotification
+}
+
+// failedPacket is a hooked packet that got an error ack, waiting to be counted in the stats of its channel
+type failedPacket struct {
+	port     string
+	channel  string
+	sequence uint64
+}
+
+// NotifyObserver sudos the observer contract with the notification. The observer is only notified and can't
+// affect the caller: it runs with a fixed gas limit, and its errors and state changes on error are discarded.
+// The gas it used is charged to ctx.
+func (k Keeper) NotifyObserver(ctx sdk.Context, observer sdk.AccAddress, notification types.HookExecutedNotification) {
+	observerCtx := ctx.WithGasMeter(sdk.NewGasMeter(types.ObserverGasLimit))
+	_ = osmoutils.ApplyFuncIfNoError(observerCtx, func(cacheCtx sdk.Context) error {
+		_, err := k.contractKeeper.Sudo(cacheCtx, observer, notification.SudoMsg())
+		return err
+	})
+	ctx.GasMeter().ConsumeGas(observerCtx.GasMeter().GasConsumedToLimit(), "ibc-hooks observer")
+}
+
+// QueueObserverNotification defers the notification to the end of the block, for executions whose state changes
+// are about to be reverted, along with those of an observer notified right away. The observer's gas limit is
+// charged to ctx upfront, as the end blocker has nobody to charge it to.
+func (k Keeper) QueueObserverNotification(ctx sdk.Context, observer sdk.AccAddress, notification types.HookExecutedNotification) {
+	ctx.GasMeter().ConsumeGas(types.ObserverGasLimit, "ibc-hooks observer")
+	// Simulations and CheckTx don't execute the packet for real
+	if ctx.IsCheckTx() {
+		return
+	}
+	k.journal.pendingNotifications = append(k.journal.pendingNotifications, pendingNotification{observer, notification})
+}
+
+// DeliverQueuedObserverNotifications notifies the observer of the notifications queued during the block.
+// A notification is dropped if its packet wasn't received, i.e. if the tx that received it failed altogether.
+func (k Keeper) DeliverQueuedObserverNotifications(ctx sdk.Context) {
+	pending := k.journal.pendingNotifications
+	k.journal.pendingNotifications = nil
+	for _, p := range pending {
+		if _, received := k.channelKeeper.GetPacketReceipt(ctx, p.notification.Port, p.notification.Channel, p.notification.Sequence); !received {
+			continue
+		}
+		k.NotifyObserver(ctx, p.observer, p.notification)
+	}
+}
@@ -2,52 +2,415 @@ package keeper
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
 	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
 )
 
 type (
 	Keeper struct {
-		storeKey sdk.StoreKey
+		storeKey   sdk.StoreKey
+		tStoreKey  sdk.StoreKey
+		paramSpace paramtypes.Subspace
+
+		channelKeeper  types.ChannelKeeper
+		distrKeeper    types.DistrKeeper
+		contractKeeper types.ContractKeeper
+		recvRetrier    types.RecvRetrier
+		hookSimulator  types.HookSimulator
+		callbacks      types.CallbackDeliverer
+		hooks          types.IBCHooksHooks
+
+		journal     *blockJournal
+		failureLogs *logRateLimiter
 	}
 )
 
 // NewKeeper returns a new instance of the x/ibchooks keeper
 func NewKeeper(
 	storeKey sdk.StoreKey,
+	tStoreKey sdk.StoreKey,
+	paramSpace paramtypes.Subspace,
+	channelKeeper types.ChannelKeeper,
+	distrKeeper types.DistrKeeper,
 ) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
 	return Keeper{
-		storeKey: storeKey,
+		storeKey:      storeKey,
+		tStoreKey:     tStoreKey,
+		paramSpace:    paramSpace,
+		channelKeeper: channelKeeper,
+		distrKeeper:   distrKeeper,
+		journal:       &blockJournal{},
+		failureLogs:   &logRateLimiter{limit: types.MaxHookFailureLogsPerBlock},
 	}
 }
 
+// SetContractKeeper sets the wasm keeper. It is set after construction, as the wasm keeper is created
+// after the ibc-hooks keeper.
+func (k *Keeper) SetContractKeeper(contractKeeper types.ContractKeeper) {
+	k.contractKeeper = contractKeeper
+}
+
+// SetRecvRetrier sets what receives the deferred hooked packets again. It is set after construction, as it
+// wraps the transfer stack, which is created after the ibc-hooks keeper.
+func (k *Keeper) SetRecvRetrier(recvRetrier types.RecvRetrier) {
+	k.recvRetrier = recvRetrier
+}
+
+// SetHookSimulator sets what the SimulateHook query validates memos and receives packets with. It is set after
+// construction, as it wraps the transfer stack, which is created after the ibc-hooks keeper.
+func (k *Keeper) SetHookSimulator(hookSimulator types.HookSimulator) {
+	k.hookSimulator = hookSimulator
+}
+
+// SetCallbackDeliverer sets what delivers the forced packet callbacks. It is set after construction, as the
+// callbacks are delivered by the wasm hooks, which are created after the ibc-hooks keeper.
+func (k *Keeper) SetCallbackDeliverer(callbacks types.CallbackDeliverer) {
+	k.callbacks = callbacks
+}
+
+// IsContract returns true if addr is a wasm contract
+func (k Keeper) IsContract(ctx sdk.Context, addr sdk.AccAddress) bool {
+	return k.contractKeeper != nil && k.contractKeeper.GetContractInfo(ctx, addr) != nil
+}
+
+// GetParams returns the ibc-hooks parameters.
+// Parameters that were never set have their default value.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	k.paramSpace.GetIfExists(ctx, types.KeyObserverContract, &params.ObserverContract)
+	k.paramSpace.GetIfExists(ctx, types.KeyObservedChannels, &params.ObservedChannels)
+	k.paramSpace.GetIfExists(ctx, types.KeyAllowedHookDenoms, &params.AllowedHookDenoms)
+	k.paramSpace.GetIfExists(ctx, types.KeyMaxHookedPacketsPerBlock, &params.MaxHookedPacketsPerBlock)
+	k.paramSpace.GetIfExists(ctx, types.KeyAckClassifiers, &params.AckClassifiers)
+	k.paramSpace.GetIfExists(ctx, types.KeyMaxExpiredCallbacks, &params.MaxExpiredCallbacksPerBlock)
+	k.paramSpace.GetIfExists(ctx, types.KeyNotifyExpiredCallbacks, &params.NotifyExpiredCallbacks)
+	k.paramSpace.GetIfExists(ctx, types.KeyCallbackAuthority, &params.CallbackAuthority)
+	k.paramSpace.GetIfExists(ctx, types.KeyAckSubscriptionFee, &params.AckSubscriptionFee)
+	k.paramSpace.GetIfExists(ctx, types.KeyMinCallbackTimeout, &params.MinCallbackTimeout)
+	k.paramSpace.GetIfExists(ctx, types.KeyMaxCallbackTimeout, &params.MaxCallbackTimeout)
+	k.paramSpace.GetIfExists(ctx, types.KeyHookFeesEnabled, &params.HookFeesEnabled)
+	k.paramSpace.GetIfExists(ctx, types.KeyHookFeeDenoms, &params.HookFeeDenoms)
+	k.paramSpace.GetIfExists(ctx, types.KeyLegacyReceiverMemo, &params.LegacyReceiverMemoEnabled)
+	k.paramSpace.GetIfExists(ctx, types.KeyAckTransferEnabled, &params.AckTransferEnabled)
+	k.paramSpace.GetIfExists(ctx, types.KeyMaxHookGas, &params.MaxHookGas)
+	k.paramSpace.GetIfExists(ctx, types.KeyPrivilegedMaxHookGas, &params.PrivilegedMaxHookGas)
+	k.paramSpace.GetIfExists(ctx, types.KeyMaxHookAmounts, &params.MaxHookAmounts)
+	k.paramSpace.GetIfExists(ctx, types.KeyPrivilegedContractsAuthority, &params.PrivilegedContractsAuthority)
+	return params
+}
+
+// SetParams sets the ibc-hooks parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
 // Logger returns a logger for the x/tokenfactory module
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
 
+const (
+	packetCallbackPrefix          = "packet-callback::"
+	packetCallbackTimeIndexPrefix = "packet-callback-by-time::"
+	packetCallbackExpiryPrefix    = "packet-callback-by-expiry::"
+)
+
+// GetPacketCallbackPrefix returns the prefix of the keys of the callbacks registered for packets sent on the
+// channel, or of all the callbacks if channel is empty.
+func GetPacketCallbackPrefix(channel string) []byte {
+	if channel == "" {
+		return []byte(packetCallbackPrefix)
+	}
+	return []byte(fmt.Sprintf("%s%s::", packetCallbackPrefix, channel))
+}
+
 func GetPacketKey(channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s::%d", packetCallbackPrefix, channel, packetSequence))
+}
+
+// GetLegacyPacketKey returns the unprefixed key packet callbacks were stored at before the v2 store migration
+func GetLegacyPacketKey(channel string, packetSequence uint64) []byte {
 	return []byte(fmt.Sprintf("%s::%d", channel, packetSequence))
 }
 
-// StorePacketCallback stores which contract will be listening for the ack or timeout of a packet
-func (k Keeper) StorePacketCallback(ctx sdk.Context, channel string, packetSequence uint64, contract string) {
+// GetPacketCallbackTimeKey returns the key at which a callback is indexed by its registration time
+func GetPacketCallbackTimeKey(registrationTime time.Time, channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s::%s::%d", packetCallbackTimeIndexPrefix, osmoutils.FormatTimeString(registrationTime), channel, packetSequence))
+}
+
+// GetPacketCallbackExpiryKey returns the key at which a callback is indexed by its expiry height.
+// The height is zero padded so that the keys are sorted by height.
+func GetPacketCallbackExpiryKey(expiryHeight int64, channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d::%s::%d", packetCallbackExpiryPrefix, expiryHeight, channel, packetSequence))
+}
+
+// ParsePacketKey returns the channel and sequence of a key created with GetPacketKey or GetLegacyPacketKey.
+// ok is false if the key is not a packet callback key.
+func ParsePacketKey(key []byte) (channel string, packetSequence uint64, ok bool) {
+	channel, sequenceStr, found := strings.Cut(strings.TrimPrefix(string(key), packetCallbackPrefix), "::")
+	if !found || !channeltypes.IsValidChannelID(channel) {
+		return "", 0, false
+	}
+	packetSequence, err := strconv.ParseUint(sequenceStr, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return channel, packetSequence, true
+}
+
+// StorePacketCallback stores which contract will be listening for the ack or timeout of a packet,
+// and which of its entry points gets called, along with the block height and time at which the
+// callback was registered. The callback is deleted at expiryHeight if it hasn't been delivered by then,
+// or never expires if expiryHeight is zero.
+func (k Keeper) StorePacketCallback(ctx sdk.Context, channel string, packetSequence uint64, contract string, entry types.CallbackEntry, expiryHeight int64) {
+	k.setPacketCallback(ctx, channel, packetSequence, types.PacketCallback{
+		Contract:           contract,
+		RegistrationHeight: ctx.BlockHeight(),
+		RegistrationTime:   ctx.BlockTime(),
+		Entry:              entry,
+		ExpiryHeight:       expiryHeight,
+	})
+}
+
+// setPacketCallback stores the callback and indexes it by registration time, so that it can be pruned
+// once it is stale, and by expiry height. Callbacks without a registration time are not indexed, and are
+// never pruned.
+func (k Keeper) setPacketCallback(ctx sdk.Context, channel string, packetSequence uint64, callback types.PacketCallback) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set(GetPacketKey(channel, packetSequence), []byte(contract))
+	osmoutils.MustSet(store, GetPacketKey(channel, packetSequence), &callback)
+	if !callback.RegistrationTime.IsZero() {
+		store.Set(GetPacketCallbackTimeKey(callback.RegistrationTime, channel, packetSequence), []byte{1})
+	}
+	if callback.ExpiryHeight > 0 {
+		store.Set(GetPacketCallbackExpiryKey(callback.ExpiryHeight, channel, packetSequence), []byte{1})
+	}
 }
 
 // GetPacketCallback returns the bech32 addr of the contract that is expecting a callback from a packet
 func (k Keeper) GetPacketCallback(ctx sdk.Context, channel string, packetSequence uint64) string {
+	callback, found := k.GetPacketCallbackInfo(ctx, channel, packetSequence)
+	if !found {
+		return ""
+	}
+	return callback.Contract
+}
+
+// GetPacketCallbackInfo returns the callback registered for a packet. Callbacks stored before registration
+// heights were tracked are returned with the height and time of the migration that converted them. Callbacks
+// still at their v1 key, until the v2 migration reaches them, are returned as they were stored.
+func (k Keeper) GetPacketCallbackInfo(ctx sdk.Context, channel string, packetSequence uint64) (types.PacketCallback, bool) {
 	store := ctx.KVStore(k.storeKey)
-	return string(store.Get(GetPacketKey(channel, packetSequence)))
+	key := GetPacketKey(channel, packetSequence)
+	if !store.Has(key) {
+		return k.getLegacyPacketCallback(ctx, channel, packetSequence)
+	}
+	callback := types.PacketCallback{}
+	osmoutils.MustGet(store, key, &callback)
+	return callback, true
 }
 
 // DeletePacketCallback deletes the callback from storage once it has been processed
 func (k Keeper) DeletePacketCallback(ctx sdk.Context, channel string, packetSequence uint64) {
+	callback, found := k.GetPacketCallbackInfo(ctx, channel, packetSequence)
+	if !found {
+		return
+	}
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetPacketKey(channel, packetSequence))
+	store.Delete(GetLegacyPacketKey(channel, packetSequence))
+	if !callback.RegistrationTime.IsZero() {
+		store.Delete(GetPacketCallbackTimeKey(callback.RegistrationTime, channel, packetSequence))
+	}
+	if callback.ExpiryHeight > 0 {
+		store.Delete(GetPacketCallbackExpiryKey(callback.ExpiryHeight, channel, packetSequence))
+	}
+}
+
+// GetAllPacketCallbacks returns every callback that is still waiting for its packet's ack or timeout.
+// If channel is not empty, only the callbacks for packets sent on that channel are returned.
+// The callbacks are sorted by channel id, then by ascending sequence, whatever order they were registered and
+// acked in. Callbacks are only registered on the transfer port, so the port doesn't need to be part of the order.
+func (k Keeper) GetAllPacketCallbacks(ctx sdk.Context, channel string) []types.PendingPacketCallback {
+	callbacks := []types.PendingPacketCallback{}
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetPacketCallbackPrefix(channel))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		pending, err := parsePendingPacketCallback(iterator.Key(), iterator.Value())
+		if err != nil {
+			panic(err)
+		}
+		callbacks = append(callbacks, pending)
+	}
+	// The keys hold the sequences in decimal, so that the store iterates sequence 10 before sequence 9
+	sort.SliceStable(callbacks, func(i, j int) bool {
+		if callbacks[i].Channel != callbacks[j].Channel {
+			return callbacks[i].Channel < callbacks[j].Channel
+		}
+		return callbacks[i].Sequence < callbacks[j].Sequence
+	})
+	return callbacks
+}
+
+// parsePendingPacketCallback parses a callback stored at a key created with GetPacketKey
+func parsePendingPacketCallback(key, value []byte) (types.PendingPacketCallback, error) {
+	channel, sequence, ok := ParsePacketKey(key)
+	if !ok {
+		return types.PendingPacketCallback{}, fmt.Errorf("invalid packet callback key %s", key)
+	}
+	callback := types.PacketCallback{}
+	if err := callback.Unmarshal(value); err != nil {
+		return types.PendingPacketCallback{}, err
+	}
+	return types.PendingPacketCallback{Channel: channel, Sequence: sequence, Callback: callback}, nil
+}
+
+// PruneStaleCallbacks deletes up to limit callbacks that were registered more than maxAge before the
+// current block time, oldest first, and returns how many were deleted. The ack of a packet that is this
+// old is not expected to arrive anymore (e.g. because the relayers stopped relaying the channel), so the
+// callbacks would otherwise stay in state forever. Callbacks without a registration time are never pruned.
+func (k Keeper) PruneStaleCallbacks(ctx sdk.Context, maxAge time.Duration, limit int) int {
+	store := ctx.KVStore(k.storeKey)
+	end := append([]byte(packetCallbackTimeIndexPrefix), osmoutils.FormatTimeString(ctx.BlockTime().Add(-maxAge))...)
+	iterator := store.Iterator([]byte(packetCallbackTimeIndexPrefix), end)
+	stale := [][]byte{}
+	for ; iterator.Valid() && len(stale) < limit; iterator.Next() {
+		stale = append(stale, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, timeKey := range stale {
+		_, packetKey, found := strings.Cut(strings.TrimPrefix(string(timeKey), packetCallbackTimeIndexPrefix), "::")
+		channel, sequence, ok := ParsePacketKey([]byte(packetKey))
+		if !found || !ok {
+			panic(fmt.Errorf("invalid packet callback time index key %s", timeKey))
+		}
+		k.DeletePacketCallback(ctx, channel, sequence)
+	}
+	return len(stale)
+}
+
+func GetHookExecutionKey(contract, sender string) []byte {
+	return []byte(fmt.Sprintf("hook-execution::%s::%s", contract, sender))
+}
+
+// SetHookExecution marks the contract as being executed by an ibc hook on behalf of sender for the rest of the
+// transaction, or until ClearHookExecution is called
+func (k Keeper) SetHookExecution(ctx sdk.Context, contract, sender string) {
+	store := ctx.TransientStore(k.tStoreKey)
+	store.Set(GetHookExecutionKey(contract, sender), []byte{1})
+}
+
+// ClearHookExecution removes the hook execution flag for the contract and sender
+func (k Keeper) ClearHookExecution(ctx sdk.Context, contract, sender string) {
+	store := ctx.TransientStore(k.tStoreKey)
+	store.Delete(GetHookExecutionKey(contract, sender))
+}
+
+// IsInHookExecution returns true if the contract is currently being executed by an ibc hook on behalf of sender.
+// Contracts can use this (through the wasm bindings) to verify that a call really originated from an IBC packet,
+// by passing the sender of the call they are handling. The flag is keyed by sender so that a call made to the
+// contract by anyone else while the hook is running (e.g. a re-entrant call from another contract) isn't
+// mistaken for the hook's.
+func (k Keeper) IsInHookExecution(ctx sdk.Context, contract, sender string) bool {
+	store := ctx.TransientStore(k.tStoreKey)
+	return store.Has(GetHookExecutionKey(contract, sender))
+}
+
+const serializePerBlockPrefix = "serialize-per-block::"
+
+func GetSerializePerBlockKey(contract string) []byte {
+	return []byte(serializePerBlockPrefix + contract)
+}
+
+// SetSerializePerBlock enables or disables per block serialization of hooked packets for the contract.
+// When enabled, only the first hooked packet targeting the contract in a block is executed.
+func (k Keeper) SetSerializePerBlock(ctx sdk.Context, contract string, enabled bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !enabled {
+		store.Delete(GetSerializePerBlockKey(contract))
+		return
+	}
+	store.Set(GetSerializePerBlockKey(contract), []byte{1})
+}
+
+// IsSerializedPerBlock returns true if the contract has opted in to receiving at most one hooked packet per block
+func (k Keeper) IsSerializedPerBlock(ctx sdk.Context, contract string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetSerializePerBlockKey(contract))
+}
+
+// GetAllSerializedContracts returns the contracts that opted in to receiving at most one hooked packet per block
+func (k Keeper) GetAllSerializedContracts(ctx sdk.Context) []string {
+	contracts := []string{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), []byte(serializePerBlockPrefix))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		contracts = append(contracts, strings.TrimPrefix(string(iterator.Key()), serializePerBlockPrefix))
+	}
+	return contracts
+}
+
+func GetHookedPacketCountKey(contract string) []byte {
+	return []byte(fmt.Sprintf("hooked-packets::%s", contract))
+}
+
+// GetHookedPacketCount returns the number of hooked packets executed against the contract in the current block
+func (k Keeper) GetHookedPacketCount(ctx sdk.Context, contract string) uint64 {
+	store := ctx.TransientStore(k.tStoreKey)
+	bz := store.Get(GetHookedPacketCountKey(contract))
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// IncrementHookedPacketCount increases the number of hooked packets executed against the contract in the
+// current block. The counter lives in the transient store, so it is reset on every commit.
+func (k Keeper) IncrementHookedPacketCount(ctx sdk.Context, contract string) {
+	store := ctx.TransientStore(k.tStoreKey)
+	count := k.GetHookedPacketCount(ctx, contract)
+	store.Set(GetHookedPacketCountKey(contract), sdk.Uint64ToBigEndian(count+1))
+}
+
+var blockHookedPacketCountKey = []byte("block-hooked-packets")
+
+// GetBlockHookedPacketCount returns the number of hooked packets executed in the current block, for all contracts
+func (k Keeper) GetBlockHookedPacketCount(ctx sdk.Context) uint64 {
+	store := ctx.TransientStore(k.tStoreKey)
+	bz := store.Get(blockHookedPacketCountKey)
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// IncrementBlockHookedPacketCount increases the number of hooked packets executed in the current block. The counter
+// lives in the transient store, so it is reset on every commit, and the increments of the packets that get an error
+// ack are reverted along with the rest of their state changes.
+func (k Keeper) IncrementBlockHookedPacketCount(ctx sdk.Context) {
+	store := ctx.TransientStore(k.tStoreKey)
+	count := k.GetBlockHookedPacketCount(ctx)
+	store.Set(blockHookedPacketCountKey, sdk.Uint64ToBigEndian(count+1))
+}
+
+// GetAckClassifier returns the classifier of the acks of the packets sent on the channel.
+// It is overridden per channel by governance, through the ack_classifiers param.
+func (k Keeper) GetAckClassifier(ctx sdk.Context, channel string) types.AckClassifier {
+	return k.GetParams(ctx).GetAckClassifier(channel)
 }
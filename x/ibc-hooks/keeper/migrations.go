@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// The v1 packet callback keys are unprefixed, and start with their channel id ("channel-" and its number), so they
+// are all within this range. The module's other keys starting with "channel-", such as the channel stats, are
+// outside of it.
+var (
+	legacyPacketCallbackStart = []byte("channel-0")
+	legacyPacketCallbackEnd   = []byte("channel-:")
+)
+
+// MigrateToV2 migrates the store from consensus version 1 in one migration: it initializes the params that aren't
+// set to their defaults, and moves the packet callbacks from their unprefixed v1 keys to their prefixed keys.
+// At most MaxMigratedCallbacksPerBlock callbacks are moved, so that a large store can't stall the upgrade block,
+// and the begin blocker moves the others in the following blocks. Until then, they are read at their v1 keys.
+// Running it again changes nothing.
+func (k Keeper) MigrateToV2(ctx sdk.Context) error {
+	k.InitializeDefaultParams(ctx)
+	migrated, remaining := k.MigrateLegacyPacketCallbacks(ctx, types.MaxMigratedCallbacksPerBlock)
+	k.Logger(ctx).Info("migrated the packet callbacks to their v2 keys", "migrated", migrated, "remaining", remaining)
+	return nil
+}
+
+// InitializeDefaultParams sets the params that aren't set in the param store to their defaults, and leaves the
+// others unchanged
+func (k Keeper) InitializeDefaultParams(ctx sdk.Context) {
+	defaults := types.DefaultParams()
+	for _, pair := range defaults.ParamSetPairs() {
+		if !k.paramSpace.Has(ctx, pair.Key) {
+			k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		}
+	}
+}
+
+// MigrateLegacyPacketCallbacks moves up to limit packet callbacks from their unprefixed v1 keys to their prefixed
+// keys, and indexes them by registration time. It returns the number of callbacks moved, and whether some are left.
+// Callbacks stored as a bare contract address have no known registration height and time, so they are stamped
+// with the current block's rather than being treated as infinitely old by the pruning. Callbacks that can't be
+// parsed could never be delivered, so they are logged and deleted instead of stopping the migration.
+func (k Keeper) MigrateLegacyPacketCallbacks(ctx sdk.Context, limit int) (migrated int, remaining bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(legacyPacketCallbackStart, legacyPacketCallbackEnd)
+	keys, values := [][]byte{}, [][]byte{}
+	for ; iterator.Valid(); iterator.Next() {
+		if len(keys) == limit {
+			remaining = true
+			break
+		}
+		keys, values = append(keys, iterator.Key()), append(values, iterator.Value())
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		store.Delete(key)
+		channel, sequence, ok := ParsePacketKey(key)
+		callback, err := types.ParsePacketCallback(values[i])
+		if !ok || err != nil {
+			k.Logger(ctx).Error("dropping an invalid v1 packet callback", "key", string(key), "error", err)
+			continue
+		}
+		if types.IsLegacyPacketCallback(values[i]) {
+			callback.RegistrationHeight = ctx.BlockHeight()
+			callback.RegistrationTime = ctx.BlockTime()
+		}
+		k.setPacketCallback(ctx, channel, sequence, callback)
+		migrated++
+	}
+	return migrated, remaining
+}
+
+// getLegacyPacketCallback returns the callback of a packet stored at its v1 key, if any. A callback that can't be
+// parsed is treated as missing, as the migration drops it.
+func (k Keeper) getLegacyPacketCallback(ctx sdk.Context, channel string, packetSequence uint64) (types.PacketCallback, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetLegacyPacketKey(channel, packetSequence))
+	if bz == nil {
+		return types.PacketCallback{}, false
+	}
+	callback, err := types.ParsePacketCallback(bz)
+	if err != nil {
+		return types.PacketCallback{}, false
+	}
+	return callback, true
+}
+
+// getAllLegacyPacketCallbacks returns the callbacks still stored at their v1 keys that can be parsed, sorted by key
+func (k Keeper) getAllLegacyPacketCallbacks(ctx sdk.Context) []types.PendingPacketCallback {
+	iterator := ctx.KVStore(k.storeKey).Iterator(legacyPacketCallbackStart, legacyPacketCallbackEnd)
+	defer iterator.Close()
+	legacy := []types.PendingPacketCallback{}
+	for ; iterator.Valid(); iterator.Next() {
+		channel, sequence, ok := ParsePacketKey(iterator.Key())
+		callback, err := types.ParsePacketCallback(iterator.Value())
+		if ok && err == nil {
+			legacy = append(legacy, types.PendingPacketCallback{Channel: channel, Sequence: sequence, Callback: callback})
+		}
+	}
+	return legacy
+}
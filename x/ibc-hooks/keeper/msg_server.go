@@ -0,0 +1,305 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+// NewMsgServerImpl returns an implementation of the MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(keeper Keeper) types.MsgServer {
+	return &msgServer{Keeper: keeper}
+}
+
+var _ types.MsgServer = msgServer{}
+
+func (server msgServer) SetSerializePerBlock(goCtx context.Context, msg *types.MsgSetSerializePerBlock) (*types.MsgSetSerializePerBlockResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// The registration is per contract: only contracts receive hooked packets
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+	if !server.Keeper.IsContract(ctx, sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a contract", msg.Sender)
+	}
+
+	server.Keeper.SetSerializePerBlock(ctx, msg.Sender, msg.Enabled)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgSetSerializePerBlock,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeEnabled, strconv.FormatBool(msg.Enabled)),
+		),
+	})
+
+	return &types.MsgSetSerializePerBlockResponse{}, nil
+}
+
+func (server msgServer) CancelPacketCallback(goCtx context.Context, msg *types.MsgCancelPacketCallback) (*types.MsgCancelPacketCallbackResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	callback, found := server.Keeper.GetPacketCallbackInfo(ctx, msg.Channel, msg.Sequence)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "no callback for packet %d on %s", msg.Sequence, msg.Channel)
+	}
+	if callback.Contract != msg.Sender {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "only %s can cancel the callback for packet %d on %s", callback.Contract, msg.Sequence, msg.Channel)
+	}
+	server.Keeper.DeletePacketCallback(ctx, msg.Channel, msg.Sequence)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgCancelPacketCallback,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(msg.Sequence, 10)),
+		),
+	})
+
+	return &types.MsgCancelPacketCallbackResponse{}, nil
+}
+
+func (server msgServer) GrantCallbackRegistration(goCtx context.Context, msg *types.MsgGrantCallbackRegistration) (*types.MsgGrantCallbackRegistrationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// Only contracts receive callbacks, so only they can grant their registration
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+	if !server.Keeper.IsContract(ctx, sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a contract", msg.Sender)
+	}
+	if !msg.Expiration.After(ctx.BlockTime()) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "expiration %s is not after the block time", msg.Expiration)
+	}
+
+	server.Keeper.GrantCallbackRegistration(ctx, msg.Sender, msg.Grantee, msg.Expiration)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgGrantCallbackRegistration,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeGrantee, msg.Grantee),
+			sdk.NewAttribute(types.AttributeExpiration, msg.Expiration.String()),
+		),
+	})
+
+	return &types.MsgGrantCallbackRegistrationResponse{}, nil
+}
+
+func (server msgServer) RevokeCallbackRegistration(goCtx context.Context, msg *types.MsgRevokeCallbackRegistration) (*types.MsgRevokeCallbackRegistrationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.RevokeCallbackRegistration(ctx, msg.Sender, msg.Grantee) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "%s has no grant to %s", msg.Sender, msg.Grantee)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgRevokeCallbackRegistration,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeGrantee, msg.Grantee),
+		),
+	})
+
+	return &types.MsgRevokeCallbackRegistrationResponse{}, nil
+}
+
+func (server msgServer) RegisterPacketCallback(goCtx context.Context, msg *types.MsgRegisterPacketCallback) (*types.MsgRegisterPacketCallbackResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.HasCallbackRegistrationGrant(ctx, msg.Contract, msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s has no unexpired grant from %s", msg.Sender, msg.Contract)
+	}
+	err := server.Keeper.RegisterPacketCallback(ctx, msg.Contract, msg.Channel, msg.Sequence, msg.PacketData, msg.TimeoutHeight, msg.TimeoutTimestamp, msg.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgRegisterPacketCallback,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeContract, msg.Contract),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(msg.Sequence, 10)),
+		),
+	})
+
+	return &types.MsgRegisterPacketCallbackResponse{}, nil
+}
+
+func (server msgServer) ForceEmitCallback(goCtx context.Context, msg *types.MsgForceEmitCallback) (*types.MsgForceEmitCallbackResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.GetParams(ctx).IsCallbackAuthority(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the callback authority", msg.Sender)
+	}
+	callback, err := server.Keeper.ForceEmitCallback(ctx, msg.Channel, msg.Sequence, msg.Ack, msg.Success)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgForceEmitCallback,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(msg.Sequence, 10)),
+			sdk.NewAttribute(types.AttributeContract, callback.Contract),
+			sdk.NewAttribute(types.AttributeAck, string(msg.Ack)),
+			sdk.NewAttribute(types.AttributeSuccess, strconv.FormatBool(msg.Success)),
+		),
+	})
+
+	return &types.MsgForceEmitCallbackResponse{}, nil
+}
+
+func (server msgServer) ForceDeleteCallback(goCtx context.Context, msg *types.MsgForceDeleteCallback) (*types.MsgForceDeleteCallbackResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.GetParams(ctx).IsCallbackAuthority(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the callback authority", msg.Sender)
+	}
+	callback, err := server.Keeper.ForceDeleteCallback(ctx, msg.Channel, msg.Sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgForceDeleteCallback,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(msg.Sequence, 10)),
+			sdk.NewAttribute(types.AttributeContract, callback.Contract),
+		),
+	})
+
+	return &types.MsgForceDeleteCallbackResponse{}, nil
+}
+
+func (server msgServer) SubscribeChannelAcks(goCtx context.Context, msg *types.MsgSubscribeChannelAcks) (*types.MsgSubscribeChannelAcksResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// Only contracts can be sudoed with the acks
+	contract, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, err
+	}
+	if !server.Keeper.IsContract(ctx, contract) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is not a contract", msg.Contract)
+	}
+
+	// The callback authority subscribes contracts for free, a contract subscribing itself pays the fee
+	isAuthority := server.Keeper.GetParams(ctx).IsCallbackAuthority(msg.Sender)
+	if !isAuthority && msg.Sender != msg.Contract {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s can't subscribe %s to channel acks", msg.Sender, msg.Contract)
+	}
+	fee := sdk.Coins{}
+	if !isAuthority {
+		if fee, err = server.Keeper.ChargeAckSubscriptionFee(ctx, contract); err != nil {
+			return nil, err
+		}
+	}
+	if err := server.Keeper.SubscribeChannelAcks(ctx, msg.Contract, msg.Channel); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgSubscribeChannelAcks,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeContract, msg.Contract),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+			sdk.NewAttribute(types.AttributeFee, fee.String()),
+		),
+	})
+
+	return &types.MsgSubscribeChannelAcksResponse{}, nil
+}
+
+func (server msgServer) UnsubscribeChannelAcks(goCtx context.Context, msg *types.MsgUnsubscribeChannelAcks) (*types.MsgUnsubscribeChannelAcksResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Sender != msg.Contract && !server.Keeper.GetParams(ctx).IsCallbackAuthority(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s can't unsubscribe %s from channel acks", msg.Sender, msg.Contract)
+	}
+	if !server.Keeper.UnsubscribeChannelAcks(ctx, msg.Contract, msg.Channel) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "%s is not subscribed to the acks of %s", msg.Contract, msg.Channel)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgUnsubscribeChannelAcks,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeContract, msg.Contract),
+			sdk.NewAttribute(types.AttributeChannel, msg.Channel),
+		),
+	})
+
+	return &types.MsgUnsubscribeChannelAcksResponse{}, nil
+}
+
+func (server msgServer) AddPrivilegedContract(goCtx context.Context, msg *types.MsgAddPrivilegedContract) (*types.MsgAddPrivilegedContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.GetParams(ctx).IsPrivilegedContractsAuthority(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the privileged contracts authority", msg.Sender)
+	}
+	// Only contracts receive hooked packets
+	contract, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, err
+	}
+	if !server.Keeper.IsContract(ctx, contract) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is not a contract", msg.Contract)
+	}
+	if err := server.Keeper.AddPrivilegedContract(ctx, msg.Contract); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgAddPrivilegedContract,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeContract, msg.Contract),
+		),
+	})
+
+	return &types.MsgAddPrivilegedContractResponse{}, nil
+}
+
+func (server msgServer) RemovePrivilegedContract(goCtx context.Context, msg *types.MsgRemovePrivilegedContract) (*types.MsgRemovePrivilegedContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if !server.Keeper.GetParams(ctx).IsPrivilegedContractsAuthority(msg.Sender) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the privileged contracts authority", msg.Sender)
+	}
+	if !server.Keeper.RemovePrivilegedContract(ctx, msg.Contract) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "%s is not a privileged contract", msg.Contract)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgRemovePrivilegedContract,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeContract, msg.Contract),
+		),
+	})
+
+	return &types.MsgRemovePrivilegedContractResponse{}, nil
+}
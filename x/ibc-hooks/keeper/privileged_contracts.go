@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const privilegedContractPrefix = "privileged-contract::"
+
+func GetPrivilegedContractKey(contract string) []byte {
+	return []byte(privilegedContractPrefix + contract)
+}
+
+// AddPrivilegedContract makes contract privileged: its hooked packets are exempt from the per block throttle and
+// the max hook amounts, and their executions are bounded by the privileged max hook gas. It errors if the contract
+// is already privileged. The caller checks that the sender is the privileged contracts authority.
+func (k Keeper) AddPrivilegedContract(ctx sdk.Context, contract string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetPrivilegedContractKey(contract)
+	if store.Has(key) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "%s is already a privileged contract", contract)
+	}
+	store.Set(key, []byte{1})
+	return nil
+}
+
+// RemovePrivilegedContract makes contract bounded by the limits of the other contracts again. It returns false if
+// the contract wasn't privileged.
+func (k Keeper) RemovePrivilegedContract(ctx sdk.Context, contract string) bool {
+	store := ctx.KVStore(k.storeKey)
+	key := GetPrivilegedContractKey(contract)
+	if !store.Has(key) {
+		return false
+	}
+	store.Delete(key)
+	return true
+}
+
+// IsPrivilegedContract returns true if the hooked packets to contract are exempt from the limits of the other
+// contracts
+func (k Keeper) IsPrivilegedContract(ctx sdk.Context, contract string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetPrivilegedContractKey(contract))
+}
+
+// GetAllPrivilegedContracts returns the privileged contracts, sorted by address
+func (k Keeper) GetAllPrivilegedContracts(ctx sdk.Context) []string {
+	contracts := []string{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), []byte(privilegedContractPrefix))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		contracts = append(contracts, strings.TrimPrefix(string(iterator.Key()), privilegedContractPrefix))
+	}
+	return contracts
+}
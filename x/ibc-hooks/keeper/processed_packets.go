@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const (
+	processedPacketPrefix       = "processed-packet::"
+	processedPacketHeightPrefix = "processed-packet-by-height::"
+)
+
+// GetProcessedPacketKey returns the key at which the processed packet, received on channel, is stored
+func GetProcessedPacketKey(channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s::%d", processedPacketPrefix, channel, packetSequence))
+}
+
+// GetProcessedPacketHeightKey returns the key at which a processed packet is indexed by the height it was
+// acknowledged at. The height is zero padded so that the keys are sorted by height.
+func GetProcessedPacketHeightKey(height int64, channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d::%s::%d", processedPacketHeightPrefix, height, channel, packetSequence))
+}
+
+// processedAck is the ack of a processed packet, returned as it was written when the packet is redelivered
+type processedAck struct {
+	bz      []byte
+	success bool
+}
+
+var _ ibcexported.Acknowledgement = processedAck{}
+
+func (a processedAck) Success() bool           { return a.success }
+func (a processedAck) Acknowledgement() []byte { return a.bz }
+
+// RecordProcessedPacket records the ack of a received hooked packet, so that a redelivery of the packet within
+// the ProcessedPacketWindow gets the same ack without its hook being executed again.
+// The record is part of the state changes of the receive, so it is reverted along with them by IBC core when
+// the ack is an error.
+func (k Keeper) RecordProcessedPacket(ctx sdk.Context, packet channeltypes.Packet, ack ibcexported.Acknowledgement) {
+	channel, sequence := packet.GetDestChannel(), packet.GetSequence()
+	if previous, found := k.GetProcessedPacket(ctx, channel, sequence); found {
+		k.deleteProcessedPacket(ctx, previous)
+	}
+	dataHash := sha256.Sum256(packet.GetData())
+	k.setProcessedPacket(ctx, types.ProcessedPacket{
+		Channel:  channel,
+		Sequence: sequence,
+		DataHash: dataHash[:],
+		Ack:      ack.Acknowledgement(),
+		Success:  ack.Success(),
+		Height:   ctx.BlockHeight(),
+	})
+}
+
+// GetRedeliveredPacketAck returns the recorded ack of packet if it was already processed. A packet with the same
+// channel and sequence but different data is not a redelivery.
+func (k Keeper) GetRedeliveredPacketAck(ctx sdk.Context, packet channeltypes.Packet) (ibcexported.Acknowledgement, bool) {
+	processed, found := k.GetProcessedPacket(ctx, packet.GetDestChannel(), packet.GetSequence())
+	if !found {
+		return nil, false
+	}
+	dataHash := sha256.Sum256(packet.GetData())
+	if !bytes.Equal(processed.DataHash, dataHash[:]) {
+		return nil, false
+	}
+	return processedAck{bz: processed.Ack, success: processed.Success}, true
+}
+
+// setProcessedPacket stores the processed packet and indexes it by the height it was acknowledged at
+func (k Keeper) setProcessedPacket(ctx sdk.Context, processed types.ProcessedPacket) {
+	store := ctx.KVStore(k.storeKey)
+	osmoutils.MustSet(store, GetProcessedPacketKey(processed.Channel, processed.Sequence), &processed)
+	store.Set(GetProcessedPacketHeightKey(processed.Height, processed.Channel, processed.Sequence), []byte{1})
+}
+
+// GetProcessedPacket returns the processed packet received on channel with the given sequence, if any
+func (k Keeper) GetProcessedPacket(ctx sdk.Context, channel string, packetSequence uint64) (types.ProcessedPacket, bool) {
+	processed := types.ProcessedPacket{}
+	found, err := osmoutils.Get(ctx.KVStore(k.storeKey), GetProcessedPacketKey(channel, packetSequence), &processed)
+	if err != nil {
+		panic(err)
+	}
+	return processed, found
+}
+
+// deleteProcessedPacket deletes the processed packet along with its height index
+func (k Keeper) deleteProcessedPacket(ctx sdk.Context, processed types.ProcessedPacket) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetProcessedPacketKey(processed.Channel, processed.Sequence))
+	store.Delete(GetProcessedPacketHeightKey(processed.Height, processed.Channel, processed.Sequence))
+}
+
+// GetAllProcessedPackets returns all the processed packets, sorted by channel and sequence
+func (k Keeper) GetAllProcessedPackets(ctx sdk.Context) []types.ProcessedPacket {
+	processed, err := osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(processedPacketPrefix), func(bz []byte) (types.ProcessedPacket, error) {
+		processed := types.ProcessedPacket{}
+		err := processed.Unmarshal(bz)
+		return processed, err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return processed
+}
+
+// PruneProcessedPackets deletes up to limit processed packets acknowledged more than window blocks ago, oldest
+// first, and returns the number of packets deleted. Redeliveries of the deleted packets are received as new
+// packets.
+func (k Keeper) PruneProcessedPackets(ctx sdk.Context, window int64, limit int) int {
+	store := ctx.KVStore(k.storeKey)
+	end := []byte(fmt.Sprintf("%s%020d", processedPacketHeightPrefix, ctx.BlockHeight()-window))
+	iterator := store.Iterator([]byte(processedPacketHeightPrefix), end)
+	stale := []types.ProcessedPacket{}
+	for ; iterator.Valid() && len(stale) < limit; iterator.Next() {
+		channel, sequence, ok := parseProcessedPacketHeightKey(iterator.Key())
+		if !ok {
+			panic(fmt.Errorf("invalid processed packet height key %s", iterator.Key()))
+		}
+		processed, found := k.GetProcessedPacket(ctx, channel, sequence)
+		if !found {
+			panic(fmt.Errorf("processed packet %s/%d not found", channel, sequence))
+		}
+		stale = append(stale, processed)
+	}
+	iterator.Close()
+
+	for _, processed := range stale {
+		k.deleteProcessedPacket(ctx, processed)
+	}
+	return len(stale)
+}
+
+// parseProcessedPacketHeightKey returns the channel and sequence of a key created with
+// GetProcessedPacketHeightKey
+func parseProcessedPacketHeightKey(key []byte) (channel string, packetSequence uint64, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(string(key), processedPacketHeightPrefix), "::")
+	if len(parts) != 3 || !channeltypes.IsValidChannelID(parts[1]) {
+		return "", 0, false
+	}
+	if _, err := strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return "", 0, false
+	}
+	packetSequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[1], packetSequence, true
+}
@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const channelHookStatsPrefix = "channel-hook-stats::"
+
+func GetChannelHookStatsKey(channel string) []byte {
+	return []byte(channelHookStatsPrefix + channel)
+}
+
+// GetChannelHookStats returns the hooked packet counters of the destination channel
+func (k Keeper) GetChannelHookStats(ctx sdk.Context, channel string) types.ChannelHookStats {
+	store := ctx.KVStore(k.storeKey)
+	key := GetChannelHookStatsKey(channel)
+	if !store.Has(key) {
+		return types.ChannelHookStats{Channel: channel}
+	}
+	stats := types.ChannelHookStats{}
+	osmoutils.MustGet(store, key, &stats)
+	return stats
+}
+
+func (k Keeper) setChannelHookStats(ctx sdk.Context, stats types.ChannelHookStats) {
+	store := ctx.KVStore(k.storeKey)
+	osmoutils.MustSet(store, GetChannelHookStatsKey(stats.Channel), &stats)
+}
+
+// GetAllChannelHookStats returns the hooked packet counters of every channel that received hooked packets
+func (k Keeper) GetAllChannelHookStats(ctx sdk.Context) []types.ChannelHookStats {
+	allStats, err := osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(channelHookStatsPrefix),
+		func(bz []byte) (types.ChannelHookStats, error) {
+			stats := types.ChannelHookStats{}
+			err := stats.Unmarshal(bz)
+			return stats, err
+		})
+	if err != nil {
+		panic(err)
+	}
+	return allStats
+}
+
+// RecordHookedPacket counts a wasm routed packet in the stats of its destination channel. The routed funds are
+// only counted if the packet was executed successfully.
+// The state changes of a packet that gets an error ack are reverted, so its failure is queued in the block journal
+// and counted at the end of the block instead, by RecordQueuedHookFailures.
+func (k Keeper) RecordHookedPacket(ctx sdk.Context, packet channeltypes.Packet, routed sdk.Coins, success bool) {
+	if success {
+		k.addHookedPacket(ctx, packet.GetDestChannel(), routed, false)
+		return
+	}
+	// Simulations and CheckTx don't execute the packet for real
+	if ctx.IsCheckTx() {
+		return
+	}
+	k.journal.failedPackets = append(k.journal.failedPackets, failedPacket{
+		port:     packet.GetDestPort(),
+		channel:  packet.GetDestChannel(),
+		sequence: packet.GetSequence(),
+	})
+}
+
+// RecordQueuedHookFailures counts the hooked packets that got an error ack during the block in the stats of their
+// channel. A failure is dropped if its packet wasn't received, i.e. if the tx that received it failed altogether.
+func (k Keeper) RecordQueuedHookFailures(ctx sdk.Context) {
+	failed := k.journal.failedPackets
+	k.journal.failedPackets = nil
+	for _, p := range failed {
+		if _, received := k.channelKeeper.GetPacketReceipt(ctx, p.port, p.channel, p.sequence); !received {
+			continue
+		}
+		k.addHookedPacket(ctx, p.channel, nil, true)
+	}
+}
+
+// addHookedPacket adds a hooked packet to the current stats period of the channel. Only the routed amounts of
+// the denoms with the largest amounts are kept, so a denom that falls out of them starts over from zero.
+func (k Keeper) addHookedPacket(ctx sdk.Context, channel string, routed sdk.Coins, errorAck bool) {
+	stats := k.GetChannelHookStats(ctx, channel)
+	stats.Current.HookedPackets++
+	if errorAck {
+		stats.Current.ErrorAcks++
+	}
+	stats.Current.Routed = types.TopDenoms(stats.Current.Routed.Add(routed...), types.MaxChannelHookStatsDenoms)
+	k.setChannelHookStats(ctx, stats)
+}
+
+// RolloverChannelHookStats ends the current stats period of every channel that had hooked packets during it.
+// The period is stamped with the epoch number and becomes the most recent entry of the channel's history,
+// which keeps the last ChannelHookStatsHistoryLength periods.
+func (k Keeper) RolloverChannelHookStats(ctx sdk.Context, epochNumber int64) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(channelHookStatsPrefix))
+	all := []types.ChannelHookStats{}
+	for ; iterator.Valid(); iterator.Next() {
+		stats := types.ChannelHookStats{}
+		if err := stats.Unmarshal(iterator.Value()); err != nil {
+			panic(err)
+		}
+		all = append(all, stats)
+	}
+	iterator.Close()
+
+	for _, stats := range all {
+		if stats.Current.HookedPackets == 0 {
+			continue
+		}
+		stats.Current.EpochNumber = epochNumber
+		stats.History = append([]types.ChannelHookStatsPeriod{stats.Current}, stats.History...)
+		if len(stats.History) > types.ChannelHookStatsHistoryLength {
+			stats.History = stats.History[:types.ChannelHookStatsHistoryLength]
+		}
+		stats.Current = types.ChannelHookStatsPeriod{}
+		k.setChannelHookStats(ctx, stats)
+	}
+}
@@ -0,0 +1,146 @@
+package ibc_hooks_test
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// readV1StoreDump returns the [key, value] pairs of a v1 ibc-hooks store, holding the callbacks of packets sent on
+// several channels at their unprefixed keys, sorted by key. Most callbacks are bare contract addresses, the others
+// are in the format of the callbacks that recorded their registration height.
+func (suite *HooksTestSuite) readV1StoreDump() [][2][]byte {
+	bz, err := os.ReadFile("./testdata/v1_store.json")
+	suite.Require().NoError(err)
+	var dump [][2][]byte
+	suite.Require().NoError(json.Unmarshal(bz, &dump))
+	return dump
+}
+
+// The consolidated v2 migration initializes the params that were never set, and moves the callbacks to their v2
+// keys a page at a time, the leftovers being moved by the begin blocker. It can be run again without changing
+// anything.
+func (suite *HooksTestSuite) TestMigrateToV2() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	hooksKeeper := osmosisApp.IBCHooksKeeper
+	ctx := suite.chainA.GetContext()
+
+	// The v1 store, along with its params: only one of them was set
+	dump := suite.readV1StoreDump()
+	suite.Require().Greater(len(dump), types.MaxMigratedCallbacksPerBlock)
+	store := ctx.KVStore(osmosisApp.GetKey(types.StoreKey))
+	for _, pair := range dump {
+		store.Set(pair[0], pair[1])
+	}
+	paramStore := prefix.NewStore(ctx.KVStore(osmosisApp.GetKey(paramstypes.StoreKey)), append([]byte(types.ModuleName), '/'))
+	for _, pair := range (&types.Params{}).ParamSetPairs() {
+		paramStore.Delete(pair.Key)
+	}
+	osmosisApp.GetSubspace(types.ModuleName).Set(ctx, types.KeyMaxHookedPacketsPerBlock, uint64(5))
+
+	expected := make([]types.PendingPacketCallback, len(dump))
+	for i, pair := range dump {
+		channel, sequence, ok := keeper.ParsePacketKey(pair[0])
+		suite.Require().True(ok)
+		callback, err := types.ParsePacketCallback(pair[1])
+		suite.Require().NoError(err)
+		expected[i] = types.PendingPacketCallback{Channel: channel, Sequence: sequence, Callback: callback}
+	}
+
+	// The upgrade's migrations only move the first page of callbacks
+	migrationCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 10).WithBlockTime(ctx.BlockTime().Add(time.Hour))
+	runMigration := func(ctx sdk.Context) {
+		fromVM := osmosisApp.UpgradeKeeper.GetModuleVersionMap(ctx)
+		fromVM[types.ModuleName] = 1
+		toVM, err := osmosisApp.ModuleManager().RunMigrations(ctx, osmosisApp.Configurator(), fromVM)
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint64(2), toVM[types.ModuleName])
+	}
+	runMigration(migrationCtx)
+
+	// The params are compared as proto text, since their empty lists are read back from the subspace as nil
+	expectedParams := types.DefaultParams()
+	expectedParams.MaxHookedPacketsPerBlock = 5
+	requireParams := func() {
+		params := hooksKeeper.GetParams(ctx)
+		suite.Require().Equal(expectedParams.String(), params.String())
+	}
+	requireParams()
+	for _, pair := range (&types.Params{}).ParamSetPairs() {
+		suite.Require().True(osmosisApp.GetSubspace(types.ModuleName).Has(ctx, pair.Key), "param %s", pair.Key)
+	}
+
+	// The callbacks stored as a bare contract address are stamped with the block that moved them. Until then,
+	// every callback is still delivered from its v1 key, and exported.
+	stamp := func(i int, ctx sdk.Context) types.PendingPacketCallback {
+		pending := expected[i]
+		if types.IsLegacyPacketCallback(dump[i][1]) {
+			pending.Callback.RegistrationHeight = ctx.BlockHeight()
+			pending.Callback.RegistrationTime = ctx.BlockTime()
+		}
+		return pending
+	}
+	for i, pending := range expected {
+		moved := i < types.MaxMigratedCallbacksPerBlock
+		suite.Require().Equal(!moved, store.Has(dump[i][0]), "callback %d", i)
+		if moved {
+			pending = stamp(i, migrationCtx)
+		}
+		callback, found := hooksKeeper.GetPacketCallbackInfo(ctx, pending.Channel, pending.Sequence)
+		suite.Require().True(found)
+		suite.Require().Equal(pending.Callback, callback)
+	}
+	suite.Require().Len(hooksKeeper.GetAllPacketCallbacks(ctx, ""), types.MaxMigratedCallbacksPerBlock)
+	suite.Require().Len(hooksKeeper.ExportGenesis(ctx).PacketCallbacks, len(dump))
+
+	// The begin blocker of the next block moves the others
+	nextCtx := migrationCtx.WithBlockHeight(migrationCtx.BlockHeight() + 1).WithBlockTime(migrationCtx.BlockTime().Add(5 * time.Second))
+	ibchooks.NewAppModule(osmosisApp.AccountKeeper, *hooksKeeper).BeginBlock(nextCtx, abci.RequestBeginBlock{})
+	migrated := hooksKeeper.GetAllPacketCallbacks(ctx, "")
+	suite.Require().Len(migrated, len(dump))
+	for i, pair := range dump {
+		suite.Require().False(store.Has(pair[0]), "callback %d", i)
+		pending := stamp(i, migrationCtx)
+		if i >= types.MaxMigratedCallbacksPerBlock {
+			pending = stamp(i, nextCtx)
+		}
+		suite.Require().Contains(migrated, pending)
+	}
+
+	// Running the migration again changes nothing
+	runMigration(nextCtx.WithBlockHeight(nextCtx.BlockHeight() + 1))
+	suite.Require().Equal(migrated, hooksKeeper.GetAllPacketCallbacks(ctx, ""))
+	requireParams()
+
+	// Once moved, the callbacks are delivered and deleted as usual
+	hooksKeeper.DeletePacketCallback(ctx, expected[0].Channel, expected[0].Sequence)
+	_, found := hooksKeeper.GetPacketCallbackInfo(ctx, expected[0].Channel, expected[0].Sequence)
+	suite.Require().False(found)
+}
+
+// An invalid callback at a v1 key could never be delivered, so the migration drops it rather than failing
+func (suite *HooksTestSuite) TestMigrateInvalidLegacyCallback() {
+	osmosisApp := suite.chainA.GetOsmosisApp()
+	ctx := suite.chainA.GetContext()
+	store := ctx.KVStore(osmosisApp.GetKey(types.StoreKey))
+	store.Set(keeper.GetLegacyPacketKey("channel-0", 1), []byte("not a callback"))
+	contract := suite.chainA.SenderAccount.GetAddress().String()
+	store.Set(keeper.GetLegacyPacketKey("channel-0", 2), []byte(contract))
+
+	_, found := osmosisApp.IBCHooksKeeper.GetPacketCallbackInfo(ctx, "channel-0", 1)
+	suite.Require().False(found)
+	migrated, remaining := osmosisApp.IBCHooksKeeper.MigrateLegacyPacketCallbacks(ctx, types.MaxMigratedCallbacksPerBlock)
+	suite.Require().Equal(1, migrated)
+	suite.Require().False(remaining)
+	suite.Require().False(store.Has(keeper.GetLegacyPacketKey("channel-0", 1)))
+	suite.Require().Equal(contract, osmosisApp.IBCHooksKeeper.GetPacketCallback(ctx, "channel-0", 2))
+}
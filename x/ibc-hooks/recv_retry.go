@@ -0,0 +1,43 @@
+package ibc_hooks
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var _ types.RecvRetrier = RecvRetrier{}
+
+// RecvRetrier receives the deferred hooked packets again through the transfer stack, and writes their acks
+// with the channel capabilities of the transfer module.
+type RecvRetrier struct {
+	im           *IBCMiddleware
+	hooks        *WasmHooks
+	scopedKeeper types.ScopedKeeper
+}
+
+func NewRecvRetrier(im *IBCMiddleware, hooks *WasmHooks, scopedKeeper types.ScopedKeeper) RecvRetrier {
+	return RecvRetrier{
+		im:           im,
+		hooks:        hooks,
+		scopedKeeper: scopedKeeper,
+	}
+}
+
+// RetryRecv receives packet again, running its hook if the ICS20 receive succeeds
+func (r RecvRetrier) RetryRecv(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress, final bool) ibcexported.Acknowledgement {
+	return r.hooks.onRecvPacket(*r.im, ctx, packet, relayer, !final)
+}
+
+// WriteRecvAck writes the ack of a deferred packet through the hooks middleware
+func (r RecvRetrier) WriteRecvAck(ctx sdk.Context, packet channeltypes.Packet, ack ibcexported.Acknowledgement) error {
+	chanCap, ok := r.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(packet.GetDestPort(), packet.GetDestChannel()))
+	if !ok {
+		return sdkerrors.Wrapf(channeltypes.ErrChannelCapabilityNotFound, "channel %s of port %s", packet.GetDestChannel(), packet.GetDestPort())
+	}
+	return r.im.WriteAcknowledgement(ctx, chanCap, packet, ack)
+}
@@ -0,0 +1,42 @@
+package ibc_hooks
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// DeriveIntermediateSender returns the account that receives the funds of a hooked packet and executes
+// the contract on behalf of originalSender, the sender of the packet on the counterparty chain of channel.
+// Contracts can rely on it being the same for all packets of a remote sender,
+// without remote senders being able to impersonate each other.
+func DeriveIntermediateSender(channel, originalSender string) sdk.AccAddress {
+	return address.Module(types.SenderPrefix, []byte(fmt.Sprintf("%s/%s", channel, originalSender)))
+}
+
+// ensureIntermediateSender makes sure that there is a module account at the intermediate sender address,
+// so that the funds of hooked packets can't be controlled by a key, even one claiming the address later on.
+//
+// If the address is unused, or used by a base account that never signed a tx (e.g. created by a bank send
+// to the address), it is replaced by a module account. Vesting accounts are never replaced, as that would
+// release their locked coins. Otherwise, somebody else already controls the address and an error is returned.
+// Account creation does not consume gas, as it isn't caused by the relayer.
+func (h WasmHooks) ensureIntermediateSender(ctx sdk.Context, sender sdk.AccAddress) error {
+	acc := h.accountKeeper.GetAccount(ctx, sender)
+	if _, ok := acc.(authtypes.ModuleAccountI); ok {
+		return nil
+	}
+	if _, ok := acc.(vestexported.VestingAccount); ok {
+		return types.AckErrorf(types.ErrIntermediateSender, sender, "the address is used by a vesting account")
+	}
+	if err := osmoutils.CreateModuleAccount(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), h.accountKeeper, sender); err != nil {
+		return types.WrapAckError(err, types.ErrIntermediateSender, sender, types.StableErrorText(err))
+	}
+	return nil
+}
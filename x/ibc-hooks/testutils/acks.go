@@ -0,0 +1,87 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// jsonAck is the JSON encoding of a channel ack, as written in the events and given to OnAcknowledgementPacket.
+// It can't be unmarshalled to an Acknowledgement as its oneof response has no JSON tags.
+type jsonAck struct {
+	Result []byte  `json:"result"`
+	Error  *string `json:"error"`
+}
+
+func parseAck(ack []byte) (jsonAck, error) {
+	parsed := jsonAck{}
+	if err := json.Unmarshal(ack, &parsed); err != nil {
+		return jsonAck{}, fmt.Errorf("ack %s is not a JSON channel ack: %w", ack, err)
+	}
+	if (parsed.Result == nil) == (parsed.Error == nil) {
+		return jsonAck{}, fmt.Errorf("ack %s has neither or both of a result and an error", ack)
+	}
+	return parsed, nil
+}
+
+// ParseContractAck returns the ContractAck of the success ack of a hooked packet
+func ParseContractAck(ack []byte) (ibchooks.ContractAck, error) {
+	parsed, err := parseAck(ack)
+	if err != nil {
+		return ibchooks.ContractAck{}, err
+	}
+	if parsed.Error != nil {
+		return ibchooks.ContractAck{}, fmt.Errorf("ack is an error: %s", *parsed.Error)
+	}
+	contractAck := ibchooks.ContractAck{}
+	if err := json.Unmarshal(parsed.Result, &contractAck); err != nil {
+		return ibchooks.ContractAck{}, fmt.Errorf("ack result %s is not a contract ack: %w", parsed.Result, err)
+	}
+	return contractAck, nil
+}
+
+// ParseErrorAck returns the message of an error ack
+func ParseErrorAck(ack []byte) (string, error) {
+	parsed, err := parseAck(ack)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Error == nil {
+		return "", fmt.Errorf("ack is a success: %s", parsed.Result)
+	}
+	return *parsed.Error, nil
+}
+
+// ParseRejectionAck returns the reason a contract gave for rejecting a hooked packet, from its error ack.
+// rejected is false if the ack isn't the error ack of a rejection.
+func ParseRejectionAck(ack []byte) (reason string, rejected bool) {
+	msg, err := ParseErrorAck(ack)
+	if err != nil {
+		return "", false
+	}
+	prefix := strings.TrimSuffix(types.ErrRejectedByContract, "%s")
+	if !strings.HasPrefix(msg, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(msg, prefix), true
+}
+
+// RequireContractAck fails the test unless ack is the success ack of a hooked packet, and returns its ContractAck
+func RequireContractAck(t require.TestingT, ack []byte) ibchooks.ContractAck {
+	contractAck, err := ParseContractAck(ack)
+	require.NoError(t, err)
+	return contractAck
+}
+
+// RequireErrorAck fails the test unless ack is an error ack whose message contains contains, and returns the message
+func RequireErrorAck(t require.TestingT, ack []byte, contains string) string {
+	msg, err := ParseErrorAck(ack)
+	require.NoError(t, err)
+	require.Contains(t, msg, contains)
+	return msg
+}
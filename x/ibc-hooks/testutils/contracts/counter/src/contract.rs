@@ -3,7 +3,8 @@ use std::collections::HashMap;
 #[cfg(not(feature = "library"))]
 use cosmwasm_std::entry_point;
 use cosmwasm_std::{
-    to_binary, Binary, Coin, Deps, DepsMut, Env, MessageInfo, Response, StdResult, Uint128,
+    from_binary, to_binary, to_vec, Binary, Coin, ContractResult, Deps, DepsMut, Env, MessageInfo,
+    QueryRequest, Response, StdResult, SystemResult, Uint128,
 };
 use cw2::set_contract_version;
 
@@ -68,18 +69,46 @@ pub mod utils {
             )
             .map(|_r| true)
     }
+
+    // is_in_hook_execution asks the chain whether this call is being made by an ibc hook.
+    // The query is sent raw, as the contract's entry points don't use the osmosis custom query type.
+    pub fn is_in_hook_execution(
+        deps: Deps,
+        env: &Env,
+        info: &MessageInfo,
+    ) -> Result<bool, ContractError> {
+        let request: QueryRequest<OsmosisQuery> =
+            QueryRequest::Custom(OsmosisQuery::IsInHookExecution {
+                contract: env.contract.address.to_string(),
+                sender: info.sender.to_string(),
+            });
+        match deps.querier.raw_query(&to_vec(&request)?) {
+            SystemResult::Ok(ContractResult::Ok(bin)) => {
+                let response: IsInHookExecutionResponse = from_binary(&bin)?;
+                Ok(response.in_hook_execution)
+            }
+            _ => Ok(false),
+        }
+    }
 }
 
 #[cfg_attr(not(feature = "library"), entry_point)]
 pub fn execute(
     deps: DepsMut,
-    _env: Env,
+    env: Env,
     info: MessageInfo,
     msg: ExecuteMsg,
 ) -> Result<Response, ContractError> {
     match msg {
         ExecuteMsg::Increment {} => execute::increment(deps, info),
+        ExecuteMsg::IncrementFromHook {} => execute::increment_from_hook(deps, env, info),
         ExecuteMsg::Reset { count } => execute::reset(deps, info, count),
+        ExecuteMsg::ReceiveAck {
+            channel: _,
+            sequence: _,
+            ack: _,
+            success: _,
+        } => execute::receive_ack(deps, info),
     }
 }
 
@@ -102,10 +131,36 @@ pub mod execute {
         Ok(Response::new().add_attribute("action", "increment"))
     }
 
+    pub fn increment_from_hook(
+        deps: DepsMut,
+        env: Env,
+        info: MessageInfo,
+    ) -> Result<Response, ContractError> {
+        if !utils::is_in_hook_execution(deps.as_ref(), &env, &info)? {
+            return Err(ContractError::Unauthorized {});
+        }
+        increment(deps, info)
+    }
+
     pub fn reset(deps: DepsMut, info: MessageInfo, count: i32) -> Result<Response, ContractError> {
         utils::update_counter(deps, info.sender, &|_counter| count, &|_counter| vec![])?;
         Ok(Response::new().add_attribute("action", "reset"))
     }
+
+    // Acks received as an execute message are counted under the sender, so that tests can
+    // verify who delivered them
+    pub fn receive_ack(deps: DepsMut, info: MessageInfo) -> Result<Response, ContractError> {
+        utils::update_counter(
+            deps,
+            info.sender,
+            &|counter| match counter {
+                None => 1,
+                Some(counter) => counter.count + 1,
+            },
+            &|_counter| vec![],
+        )?;
+        Ok(Response::new().add_attribute("action", "ack"))
+    }
 }
 
 #[cfg_attr(not(feature = "library"), entry_point)]
@@ -117,6 +172,15 @@ pub fn sudo(deps: DepsMut, env: Env, msg: SudoMsg) -> Result<Response, ContractE
             ack: _,
             success,
         } => sudo::receive_ack(deps, env.contract.address, success),
+        SudoMsg::HookExecuted {
+            channel: _,
+            sequence: _,
+            contract,
+            denom: _,
+            amount: _,
+            success: _,
+            error_code: _,
+        } => sudo::hook_executed(deps, contract),
     }
 }
 
@@ -141,6 +205,21 @@ pub mod sudo {
         )?;
         Ok(Response::new().add_attribute("action", "ack"))
     }
+
+    // Hook executions are counted under the executed contract, so that tests can verify
+    // which executions the observer was notified of
+    pub fn hook_executed(deps: DepsMut, contract: String) -> Result<Response, ContractError> {
+        utils::update_counter(
+            deps,
+            Addr::unchecked(contract),
+            &|counter| match counter {
+                None => 1,
+                Some(counter) => counter.count + 1,
+            },
+            &|_counter| vec![],
+        )?;
+        Ok(Response::new().add_attribute("action", "hook_executed"))
+    }
 }
 
 pub fn naive_add_coins(lhs: &Vec<Coin>, rhs: &Vec<Coin>) -> Vec<Coin> {
@@ -375,4 +454,58 @@ mod tests {
         let value: GetCountResponse = from_binary(&res).unwrap();
         assert_eq!(2, value.count);
     }
+
+    #[test]
+    fn execute_acks() {
+        let mut deps = mock_dependencies();
+        let env = mock_env();
+        let info = mock_info("hooks", &[]);
+        let get_msg = QueryMsg::GetCount {
+            addr: Addr::unchecked("hooks"),
+        };
+
+        let msg = ExecuteMsg::ReceiveAck {
+            channel: format!("channel-0"),
+            sequence: 1,
+            ack: String::new(),
+            success: true,
+        };
+        let _res = execute(deps.as_mut(), env.clone(), info, msg).unwrap();
+
+        // should increase the counter of the sender by 1
+        let res = query(deps.as_ref(), env.clone(), get_msg).unwrap();
+        let value: GetCountResponse = from_binary(&res).unwrap();
+        assert_eq!(1, value.count);
+
+        // and not the one of the contract
+        let get_msg = QueryMsg::GetCount {
+            addr: Addr::unchecked(env.clone().contract.address),
+        };
+        query(deps.as_ref(), env, get_msg).unwrap_err();
+    }
+
+    #[test]
+    fn hook_executions() {
+        let mut deps = mock_dependencies();
+        let env = mock_env();
+        let get_msg = QueryMsg::GetCount {
+            addr: Addr::unchecked("hooked"),
+        };
+
+        let msg = SudoMsg::HookExecuted {
+            channel: format!("channel-0"),
+            sequence: 1,
+            contract: format!("hooked"),
+            denom: format!("token"),
+            amount: format!("1"),
+            success: true,
+            error_code: 0,
+        };
+        let _res = sudo(deps.as_mut(), env.clone(), msg).unwrap();
+
+        // should increase the counter of the executed contract by 1
+        let res = query(deps.as_ref(), env, get_msg).unwrap();
+        let value: GetCountResponse = from_binary(&res).unwrap();
+        assert_eq!(1, value.count);
+    }
 }
@@ -1,5 +1,5 @@
 use cosmwasm_schema::{cw_serde, QueryResponses};
-use cosmwasm_std::{Addr, Coin};
+use cosmwasm_std::{Addr, Coin, CustomQuery};
 
 #[cw_serde]
 pub struct InstantiateMsg {
@@ -9,7 +9,16 @@ pub struct InstantiateMsg {
 #[cw_serde]
 pub enum ExecuteMsg {
     Increment {},
+    // IncrementFromHook is like Increment, but only accepts calls made by an ibc hook
+    IncrementFromHook {},
     Reset { count: i32 },
+    // ReceiveAck is the ack callback, when it is delivered as an execute message
+    ReceiveAck {
+        channel: String,
+        sequence: u64,
+        ack: String,
+        success: bool,
+    },
 }
 
 #[cw_serde]
@@ -41,4 +50,27 @@ pub enum SudoMsg {
         ack: String,
         success: bool,
     },
+    // HookExecuted is the notification sent to the ibc-hooks observer contract
+    HookExecuted {
+        channel: String,
+        sequence: u64,
+        contract: String,
+        denom: String,
+        amount: String,
+        success: bool,
+        error_code: u32,
+    },
+}
+
+// OsmosisQuery is the subset of the osmosis custom queries used by this contract
+#[cw_serde]
+pub enum OsmosisQuery {
+    IsInHookExecution { contract: String, sender: String },
+}
+
+impl CustomQuery for OsmosisQuery {}
+
+#[cw_serde]
+pub struct IsInHookExecutionResponse {
+    pub in_hook_execution: bool,
 }
@@ -0,0 +1,107 @@
+package testutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+// TestChainID is the chain id of the contexts returned by NewTestKeeper
+const TestChainID = "testchain"
+
+// NewTestKeeper returns an ibc-hooks keeper over an in-memory store, with the default params, and a context at
+// height 1 to use it with
+func NewTestKeeper(t testing.TB, channelKeeper types.ChannelKeeper, distrKeeper types.DistrKeeper) (*keeper.Keeper, sdk.Context) {
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	tStoreKey := sdk.NewTransientStoreKey(types.TransientStoreKey)
+	paramsKey := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	paramsTKey := sdk.NewTransientStoreKey(paramstypes.TStoreKey)
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db, log.NewNopLogger())
+	cms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(tStoreKey, sdk.StoreTypeTransient, db)
+	cms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, db)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	paramSpace := paramstypes.NewSubspace(
+		codec.NewProtoCodec(codectypes.NewInterfaceRegistry()), codec.NewLegacyAmino(), paramsKey, paramsTKey, types.ModuleName)
+	hooksKeeper := keeper.NewKeeper(storeKey, tStoreKey, paramSpace, channelKeeper, distrKeeper)
+
+	ctx := sdk.NewContext(cms, tmproto.Header{Height: 1, ChainID: TestChainID, Time: time.Unix(1, 0).UTC()}, false, log.NewNopLogger())
+	hooksKeeper.SetParams(ctx, types.DefaultParams())
+	return &hooksKeeper, ctx
+}
+
+// TestHooksEnv is the ibc-hooks middleware over a MockTransferApp, with the wasm hooks executing the contracts of
+// a MockContractExecutor, and every keeper mocked in memory. It is enough to receive hooked packets, and to send
+// packets with callbacks and deliver their acks, without an app.
+type TestHooksEnv struct {
+	Ctx            sdk.Context
+	Keeper         *keeper.Keeper
+	Contracts      *MockContractExecutor
+	BankKeeper     *MockBankKeeper
+	AccountKeeper  *MockAccountKeeper
+	ChannelKeeper  *MockChannelKeeper
+	ICS4Wrapper    *MockICS4Wrapper
+	DistrKeeper    *MockDistrKeeper
+	TransferApp    *MockTransferApp
+	WasmHooks      ibchooks.WasmHooks
+	ICS4Middleware *ibchooks.ICS4Middleware
+	Middleware     ibchooks.IBCMiddleware
+}
+
+func NewTestHooksEnv(t testing.TB) *TestHooksEnv {
+	env := &TestHooksEnv{
+		BankKeeper:    NewMockBankKeeper(),
+		AccountKeeper: NewMockAccountKeeper(),
+		ChannelKeeper: NewMockChannelKeeper(),
+		DistrKeeper:   &MockDistrKeeper{},
+		ICS4Wrapper:   &MockICS4Wrapper{},
+	}
+	env.Keeper, env.Ctx = NewTestKeeper(t, env.ChannelKeeper, env.DistrKeeper)
+	env.Contracts = NewMockContractExecutor(env.BankKeeper)
+	env.Keeper.SetContractKeeper(env.Contracts)
+	env.TransferApp = &MockTransferApp{BankKeeper: env.BankKeeper}
+
+	env.WasmHooks = ibchooks.NewWasmHooks(env.Keeper, env.Contracts, env.AccountKeeper, env.BankKeeper)
+	env.Keeper.SetCallbackDeliverer(env.WasmHooks)
+	ics4Middleware := ibchooks.NewICS4Middleware(env.ICS4Wrapper, &env.WasmHooks)
+	env.ICS4Middleware = &ics4Middleware
+	env.Middleware = ibchooks.NewIBCMiddleware(env.TransferApp, env.ICS4Middleware)
+	return env
+}
+
+// RecvPacket receives packet through the middleware, with a fresh event manager, and returns its ack
+func (env *TestHooksEnv) RecvPacket(packet channeltypes.Packet) ibcexported.Acknowledgement {
+	env.Ctx = env.Ctx.WithEventManager(sdk.NewEventManager())
+	return env.Middleware.OnRecvPacket(env.Ctx, packet, RelayerAddr)
+}
+
+// SendPacket sends packet through the middleware, registering its callback if its memo has one
+func (env *TestHooksEnv) SendPacket(packet channeltypes.Packet) error {
+	env.Ctx = env.Ctx.WithEventManager(sdk.NewEventManager())
+	return env.ICS4Middleware.SendPacket(env.Ctx, nil, packet)
+}
+
+// AcknowledgePacket delivers ack, the ack of packet sent from this chain, through the middleware
+func (env *TestHooksEnv) AcknowledgePacket(packet channeltypes.Packet, ack []byte) error {
+	env.Ctx = env.Ctx.WithEventManager(sdk.NewEventManager())
+	return env.Middleware.OnAcknowledgementPacket(env.Ctx, packet, ack, RelayerAddr)
+}
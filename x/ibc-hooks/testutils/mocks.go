@@ -0,0 +1,262 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v3/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var (
+	_ types.ContractExecutor  = &MockContractExecutor{}
+	_ types.ContractKeeper    = &MockContractExecutor{}
+	_ types.BankKeeper        = &MockBankKeeper{}
+	_ types.ChannelKeeper     = &MockChannelKeeper{}
+	_ types.DistrKeeper       = &MockDistrKeeper{}
+	_ osmoutils.AccountKeeper = &MockAccountKeeper{}
+	_ porttypes.IBCModule     = &MockTransferApp{}
+	_ porttypes.ICS4Wrapper   = &MockICS4Wrapper{}
+)
+
+// MockContract is a contract of the MockContractExecutor. A nil entry point fails as if the contract didn't have it.
+type MockContract struct {
+	Execute func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error)
+	Sudo    func(ctx sdk.Context, msg []byte) ([]byte, error)
+}
+
+// ContractCall is a call of a contract of the MockContractExecutor. Sudo calls have no caller nor funds.
+type ContractCall struct {
+	Contract sdk.AccAddress
+	Caller   sdk.AccAddress
+	Msg      []byte
+	Funds    sdk.Coins
+}
+
+// MockContractExecutor executes the contracts registered with SetContract instead of wasm code, and records their
+// calls in the order they were made. If BankKeeper is set, the funds of an execution are sent from the caller to
+// the contract before it runs, as wasmd does.
+type MockContractExecutor struct {
+	BankKeeper types.BankKeeper
+	Executions []ContractCall
+	Sudos      []ContractCall
+
+	contracts map[string]MockContract
+}
+
+func NewMockContractExecutor(bankKeeper types.BankKeeper) *MockContractExecutor {
+	return &MockContractExecutor{BankKeeper: bankKeeper, contracts: map[string]MockContract{}}
+}
+
+// SetContract registers contract at addr, replacing the contract already there
+func (e *MockContractExecutor) SetContract(addr sdk.AccAddress, contract MockContract) {
+	e.contracts[addr.String()] = contract
+}
+
+func (e *MockContractExecutor) GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *wasmtypes.ContractInfo {
+	if _, found := e.contracts[contractAddress.String()]; !found {
+		return nil
+	}
+	return &wasmtypes.ContractInfo{Label: contractAddress.String()}
+}
+
+func (e *MockContractExecutor) Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+	e.Executions = append(e.Executions, ContractCall{Contract: contractAddress, Caller: caller, Msg: msg, Funds: coins})
+	contract, found := e.contracts[contractAddress.String()]
+	if !found {
+		return nil, fmt.Errorf("no contract at %s", contractAddress)
+	}
+	if contract.Execute == nil {
+		return nil, fmt.Errorf("contract %s has no execute entry point", contractAddress)
+	}
+	if e.BankKeeper != nil && !coins.IsZero() {
+		if err := e.BankKeeper.SendCoins(ctx, caller, contractAddress, coins); err != nil {
+			return nil, err
+		}
+	}
+	return contract.Execute(ctx, caller, msg, coins)
+}
+
+func (e *MockContractExecutor) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	e.Sudos = append(e.Sudos, ContractCall{Contract: contractAddress, Msg: msg})
+	contract, found := e.contracts[contractAddress.String()]
+	if !found {
+		return nil, fmt.Errorf("no contract at %s", contractAddress)
+	}
+	if contract.Sudo == nil {
+		return nil, fmt.Errorf("contract %s has no sudo entry point", contractAddress)
+	}
+	return contract.Sudo(ctx, msg)
+}
+
+// MockBankKeeper keeps the balances in memory. The addresses in Blocked can't receive funds from the hooks.
+// The balances are outside of the store, so they aren't reverted along with the state changes of a failed packet.
+type MockBankKeeper struct {
+	Blocked map[string]bool
+
+	balances map[string]sdk.Coins
+}
+
+func NewMockBankKeeper() *MockBankKeeper {
+	return &MockBankKeeper{Blocked: map[string]bool{}, balances: map[string]sdk.Coins{}}
+}
+
+// Mint adds coins to the balance of addr
+func (b *MockBankKeeper) Mint(addr sdk.AccAddress, coins sdk.Coins) {
+	b.balances[addr.String()] = b.balances[addr.String()].Add(coins...)
+}
+
+func (b *MockBankKeeper) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return b.balances[addr.String()]
+}
+
+func (b *MockBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	balance := b.balances[fromAddr.String()]
+	if !balance.IsAllGTE(amt) {
+		return fmt.Errorf("%s is smaller than %s: insufficient funds", balance, amt)
+	}
+	b.balances[fromAddr.String()] = balance.Sub(amt)
+	b.Mint(toAddr, amt)
+	return nil
+}
+
+func (b *MockBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return b.Blocked[addr.String()]
+}
+
+// MockAccountKeeper keeps the accounts in memory, numbering them in the order they were created
+type MockAccountKeeper struct {
+	accounts      map[string]authtypes.AccountI
+	nextAccountNo uint64
+}
+
+func NewMockAccountKeeper() *MockAccountKeeper {
+	return &MockAccountKeeper{accounts: map[string]authtypes.AccountI{}}
+}
+
+func (a *MockAccountKeeper) NewAccount(ctx sdk.Context, acc authtypes.AccountI) authtypes.AccountI {
+	if err := acc.SetAccountNumber(a.nextAccountNo); err != nil {
+		panic(err)
+	}
+	a.nextAccountNo++
+	return acc
+}
+
+func (a *MockAccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI {
+	return a.accounts[addr.String()]
+}
+
+func (a *MockAccountKeeper) SetAccount(ctx sdk.Context, acc authtypes.AccountI) {
+	a.accounts[acc.GetAddress().String()] = acc
+}
+
+// MockChannelKeeper holds the channels, packet receipts and packet commitments set in its maps. The channels are
+// keyed by "<port>/<channel>", and the packets by PacketID.
+type MockChannelKeeper struct {
+	Channels    map[string]channeltypes.Channel
+	Receipts    map[string]bool
+	Commitments map[string][]byte
+}
+
+func NewMockChannelKeeper() *MockChannelKeeper {
+	return &MockChannelKeeper{
+		Channels:    map[string]channeltypes.Channel{},
+		Receipts:    map[string]bool{},
+		Commitments: map[string][]byte{},
+	}
+}
+
+// PacketID returns the key of a packet in the maps of the MockChannelKeeper
+func PacketID(portID, channelID string, sequence uint64) string {
+	return fmt.Sprintf("%s/%s/%d", portID, channelID, sequence)
+}
+
+func (c *MockChannelKeeper) GetPacketReceipt(ctx sdk.Context, portID, channelID string, sequence uint64) (string, bool) {
+	if !c.Receipts[PacketID(portID, channelID, sequence)] {
+		return "", false
+	}
+	return string([]byte{byte(1)}), true
+}
+
+func (c *MockChannelKeeper) GetPacketCommitment(ctx sdk.Context, portID, channelID string, sequence uint64) []byte {
+	return c.Commitments[PacketID(portID, channelID, sequence)]
+}
+
+func (c *MockChannelKeeper) GetChannel(ctx sdk.Context, portID, channelID string) (channeltypes.Channel, bool) {
+	channel, found := c.Channels[portID+"/"+channelID]
+	return channel, found
+}
+
+// MockDistrKeeper records the funds sent to the community pool
+type MockDistrKeeper struct {
+	CommunityPool sdk.Coins
+}
+
+func (d *MockDistrKeeper) FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error {
+	d.CommunityPool = d.CommunityPool.Add(amount...)
+	return nil
+}
+
+// MockTransferApp stands in for the transfer module under the middleware. It credits the receiver of the ICS20
+// packets it receives with the local denom of their funds, unless FailRecv is set, in which case it returns an
+// error ack. Only the packet callbacks used by the hooks are implemented: the others panic.
+type MockTransferApp struct {
+	porttypes.IBCModule
+
+	BankKeeper *MockBankKeeper
+	FailRecv   bool
+}
+
+func (m *MockTransferApp) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	if m.FailRecv {
+		return channeltypes.NewErrorAcknowledgement("transfer failed")
+	}
+	var data transfertypes.FungibleTokenPacketData
+	if err := json.Unmarshal(packet.GetData(), &data); err != nil {
+		return channeltypes.NewErrorAcknowledgement("cannot unmarshal ICS-20 transfer packet data")
+	}
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	amount, ok := sdk.NewIntFromString(data.Amount)
+	if !ok {
+		return channeltypes.NewErrorAcknowledgement("invalid amount")
+	}
+	m.BankKeeper.Mint(receiver, sdk.NewCoins(sdk.NewCoin(osmoutils.MustExtractDenomFromPacketOnRecv(packet), amount)))
+	return channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+}
+
+func (m *MockTransferApp) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	return nil
+}
+
+func (m *MockTransferApp) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	return nil
+}
+
+// MockICS4Wrapper stands in for the channel keeper under the ICS4 middleware, recording the packets sent and the
+// acks written through it
+type MockICS4Wrapper struct {
+	SentPackets []ibcexported.PacketI
+	WrittenAcks []ibcexported.Acknowledgement
+}
+
+func (w *MockICS4Wrapper) SendPacket(ctx sdk.Context, chanCap *capabilitytypes.Capability, packet ibcexported.PacketI) error {
+	w.SentPackets = append(w.SentPackets, packet)
+	return nil
+}
+
+func (w *MockICS4Wrapper) WriteAcknowledgement(ctx sdk.Context, chanCap *capabilitytypes.Capability, packet ibcexported.PacketI, ack ibcexported.Acknowledgement) error {
+	w.WrittenAcks = append(w.WrittenAcks, ack)
+	return nil
+}
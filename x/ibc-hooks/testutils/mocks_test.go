@@ -0,0 +1,100 @@
+package testutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/testutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var (
+	contractAddr = sdk.AccAddress([]byte("contract____________"))
+	callerAddr   = sdk.AccAddress([]byte("caller______________"))
+)
+
+// The executor sends the funds of an execution from the caller to the contract before running it, and records
+// the calls, including those that fail
+func TestMockContractExecutor(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	env.BankKeeper.Mint(callerAddr, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 10)))
+	env.Contracts.SetContract(contractAddr, testutils.MockContract{
+		Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+			return msg, nil
+		},
+	})
+	require.NotNil(t, env.Contracts.GetContractInfo(env.Ctx, contractAddr))
+	require.Nil(t, env.Contracts.GetContractInfo(env.Ctx, callerAddr))
+
+	funds := sdk.NewCoins(sdk.NewInt64Coin("uosmo", 4))
+	res, err := env.Contracts.Execute(env.Ctx, contractAddr, callerAddr, []byte(`{"echo": {}}`), funds)
+	require.NoError(t, err)
+	require.Equal(t, `{"echo": {}}`, string(res))
+	require.Equal(t, funds, env.BankKeeper.GetAllBalances(env.Ctx, contractAddr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 6)), env.BankKeeper.GetAllBalances(env.Ctx, callerAddr))
+
+	_, err = env.Contracts.Execute(env.Ctx, contractAddr, callerAddr, []byte(`{}`), sdk.NewCoins(sdk.NewInt64Coin("uosmo", 7)))
+	require.ErrorContains(t, err, "insufficient funds")
+	_, err = env.Contracts.Sudo(env.Ctx, contractAddr, []byte(`{}`))
+	require.ErrorContains(t, err, "no sudo entry point")
+	_, err = env.Contracts.Execute(env.Ctx, callerAddr, contractAddr, []byte(`{}`), nil)
+	require.ErrorContains(t, err, "no contract at")
+
+	require.Len(t, env.Contracts.Executions, 3)
+	require.Len(t, env.Contracts.Sudos, 1)
+}
+
+// A packet received through the env credits its receiver and executes the contract of its memo, and the ack
+// helpers tell the success acks from the error acks
+func TestTestHooksEnvRecvPacket(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	env.Contracts.SetContract(contractAddr, testutils.MockContract{
+		Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+			return []byte("done"), nil
+		},
+	})
+
+	ack := env.RecvPacket(testutils.NewRecvPacket(1, "remote-sender", contractAddr.String(), "10", testutils.WasmMemo(contractAddr.String(), `{"echo": {}}`)))
+	require.True(t, ack.Success())
+	contractAck := testutils.RequireContractAck(t, ack.Acknowledgement())
+	require.Equal(t, "done", string(contractAck.ContractResult))
+	require.Len(t, env.Contracts.Executions, 1)
+	_, err := testutils.ParseErrorAck(ack.Acknowledgement())
+	require.Error(t, err)
+
+	env.TransferApp.FailRecv = true
+	ack = env.RecvPacket(testutils.NewRecvPacket(2, "remote-sender", contractAddr.String(), "10", testutils.WasmMemo(contractAddr.String(), `{"echo": {}}`)))
+	require.False(t, ack.Success())
+	testutils.RequireErrorAck(t, ack.Acknowledgement(), "")
+	_, err = testutils.ParseContractAck(ack.Acknowledgement())
+	require.Error(t, err)
+	require.Len(t, env.Contracts.Executions, 1)
+}
+
+// A packet sent through the env with a callback memo is passed on to the ICS4 wrapper and its callback registered
+func TestTestHooksEnvSendPacket(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	env.Contracts.SetContract(contractAddr, testutils.MockContract{})
+
+	packet := testutils.NewSendPacket(1, contractAddr.String(), "remote-receiver", "uosmo", "10", testutils.CallbackMemo(contractAddr.String(), types.CallbackEntrySudo))
+	require.NoError(t, env.SendPacket(packet))
+	require.Len(t, env.ICS4Wrapper.SentPackets, 1)
+	require.Equal(t, packet.GetSequence(), env.ICS4Wrapper.SentPackets[0].GetSequence())
+	_, found := env.Keeper.GetPacketCallbackInfo(env.Ctx, testutils.LocalChannel, 1)
+	require.True(t, found)
+}
+
+func TestParseRejectionAck(t *testing.T) {
+	reason, rejected := testutils.ParseRejectionAck(channeltypes.NewErrorAcknowledgement(fmt.Sprintf(types.ErrRejectedByContract, "not today")).Acknowledgement())
+	require.True(t, rejected)
+	require.Equal(t, "not today", reason)
+
+	_, rejected = testutils.ParseRejectionAck(channeltypes.NewErrorAcknowledgement("transfer failed").Acknowledgement())
+	require.False(t, rejected)
+	_, rejected = testutils.ParseRejectionAck([]byte("not an ack"))
+	require.False(t, rejected)
+}
@@ -0,0 +1,91 @@
+package testutils
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+const (
+	// LocalChannel is the channel of the packets received and sent by the chain under test
+	LocalChannel = "channel-0"
+	// CounterpartyChannel is the channel of LocalChannel on the counterparty chain
+	CounterpartyChannel = "channel-1"
+	// RemoteDenom is the denom of the packets received from the counterparty chain
+	RemoteDenom = sdk.DefaultBondDenom
+)
+
+// RelayerAddr is the relayer of the packets received and acknowledged in a TestHooksEnv
+var RelayerAddr = sdk.AccAddress([]byte("relayer_____________"))
+
+// NewTransferPacket returns the ICS20 packet of data, sent from sourceChannel to destChannel on the transfer ports,
+// timing out at height 100
+func NewTransferPacket(sourceChannel, destChannel string, sequence uint64, data transfertypes.FungibleTokenPacketData) channeltypes.Packet {
+	return channeltypes.NewPacket(
+		data.GetBytes(),
+		sequence,
+		transfertypes.PortID,
+		sourceChannel,
+		transfertypes.PortID,
+		destChannel,
+		clienttypes.NewHeight(0, 100),
+		0,
+	)
+}
+
+// NewRecvPacket returns a packet of amount RemoteDenom sent to receiver on this chain by sender, an address of the
+// counterparty chain
+func NewRecvPacket(sequence uint64, sender, receiver, amount, memo string) channeltypes.Packet {
+	return NewTransferPacket(CounterpartyChannel, LocalChannel, sequence, transfertypes.FungibleTokenPacketData{
+		Denom:    RemoteDenom,
+		Amount:   amount,
+		Sender:   sender,
+		Receiver: receiver,
+		Memo:     memo,
+	})
+}
+
+// NewSendPacket returns a packet of amount denom sent by sender on this chain to receiver on the counterparty chain
+func NewSendPacket(sequence uint64, sender, receiver, denom, amount, memo string) channeltypes.Packet {
+	return NewTransferPacket(LocalChannel, CounterpartyChannel, sequence, transfertypes.FungibleTokenPacketData{
+		Denom:    denom,
+		Amount:   amount,
+		Sender:   sender,
+		Receiver: receiver,
+		Memo:     memo,
+	})
+}
+
+// WasmMemo returns the memo executing contract, a bech32 address, with msg, a JSON object
+func WasmMemo(contract, msg string) string {
+	return fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": %s}}`, contract, msg)
+}
+
+// WasmMemoWithFields returns the memo executing contract with msg, a JSON object, along with the other fields of
+// the wasm object, e.g. `"min_amount": "10"`
+func WasmMemoWithFields(contract, msg, fields string) string {
+	if fields == "" {
+		return WasmMemo(contract, msg)
+	}
+	return fmt.Sprintf(`{"wasm": {"contract": "%s", "msg": %s, %s}}`, contract, msg, fields)
+}
+
+// LegacyReceiverMemo returns the receiver of a packet sent to receiver by a counterparty without the memo field,
+// holding the memo executing contract with msg, a JSON object, in the legacy format
+func LegacyReceiverMemo(receiver, contract, msg string) string {
+	return fmt.Sprintf(`{"receiver": "%s", "wasm": {"contract": "%s", "msg": %s}}`, receiver, contract, msg)
+}
+
+// CallbackMemo returns the memo calling back contract through entry with the ack of the packet it is sent with
+func CallbackMemo(contract string, entry types.CallbackEntry) string {
+	if entry == types.CallbackEntryExecute {
+		return fmt.Sprintf(`{"%s": {"%s": "%s", "%s": "%s"}}`, types.IBCCallbackKey,
+			types.IBCCallbackContractKey, contract, types.IBCCallbackEntryKey, types.IBCCallbackEntryExecute)
+	}
+	return fmt.Sprintf(`{"%s": "%s"}`, types.IBCCallbackKey, contract)
+}
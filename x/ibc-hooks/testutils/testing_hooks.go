@@ -9,11 +9,13 @@ import (
 	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
 
 	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
 )
 
 var (
-	_ ibchooks.Hooks = TestRecvOverrideHooks{}
-	_ ibchooks.Hooks = TestRecvBeforeAfterHooks{}
+	_ ibchooks.Hooks      = TestRecvOverrideHooks{}
+	_ ibchooks.Hooks      = TestRecvBeforeAfterHooks{}
+	_ types.IBCHooksHooks = &TestIBCHooksRecorder{}
 )
 
 type Status struct {
@@ -40,3 +42,29 @@ func (t TestRecvBeforeAfterHooks) OnRecvPacketBeforeHook(ctx sdk.Context, packet
 func (t TestRecvBeforeAfterHooks) OnRecvPacketAfterHook(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress, ack ibcexported.Acknowledgement) {
 	t.Status.AfterRan = true
 }
+
+// TestIBCHooksRecorder records the notifications of the ibc-hooks keeper hooks, in the order they were received
+type TestIBCHooksRecorder struct {
+	HookExecutions     []HookExecution
+	CallbackExecutions []CallbackExecution
+}
+
+type HookExecution struct {
+	Packet types.HookedPacketInfo
+	Result types.HookExecutionResult
+}
+
+type CallbackExecution struct {
+	Packet types.CallbackPacketInfo
+	Ack    types.CallbackAckInfo
+}
+
+func (r *TestIBCHooksRecorder) AfterHookExecution(ctx sdk.Context, packet types.HookedPacketInfo, result types.HookExecutionResult) error {
+	r.HookExecutions = append(r.HookExecutions, HookExecution{packet, result})
+	return nil
+}
+
+func (r *TestIBCHooksRecorder) AfterCallbackExecution(ctx sdk.Context, packet types.CallbackPacketInfo, ack types.CallbackAckInfo) error {
+	r.CallbackExecutions = append(r.CallbackExecutions, CallbackExecution{packet, ack})
+	return nil
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+)
+
+// AckClassifier is how the acks of the packets sent on a channel are classified as successes or errors
+// for the ack callbacks. Counterparty chains with non-standard acks (e.g. through custom middlewares)
+// can need another classifier than the default one.
+type AckClassifier uint8
+
+const (
+	// AckClassifierDefault decodes the ack as a standard channeltypes.Acknowledgement, and falls back to
+	// AckClassifierJSONError if it isn't one.
+	AckClassifierDefault AckClassifier = iota
+	// AckClassifierStandard decodes the ack as a standard channeltypes.Acknowledgement.
+	// Acks that can't be decoded, or that are invalid, e.g. with an empty result, are errors.
+	AckClassifierStandard
+	// AckClassifierJSONError classifies the acks that are JSON objects with a non-empty "error" key as errors,
+	// and anything else as a success.
+	AckClassifierJSONError
+	// AckClassifierResult classifies the acks that are JSON objects with a non-empty "result" key as successes,
+	// and anything else as an error. A result is empty if it is null, "", {} or [].
+	AckClassifierResult
+)
+
+var ackClassifierNames = map[AckClassifier]string{
+	AckClassifierDefault:   "default",
+	AckClassifierStandard:  "standard",
+	AckClassifierJSONError: "json_error",
+	AckClassifierResult:    "result",
+}
+
+func (c AckClassifier) String() string {
+	if name, ok := ackClassifierNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(c))
+}
+
+// ParseAckClassifier returns the classifier with the given name
+func ParseAckClassifier(name string) (AckClassifier, error) {
+	for classifier, classifierName := range ackClassifierNames {
+		if classifierName == name {
+			return classifier, nil
+		}
+	}
+	return AckClassifierDefault, fmt.Errorf("unknown ack classifier %s", name)
+}
+
+// Validate returns an error if the classifier is not one of the known classifiers
+func (c AckClassifier) Validate() error {
+	if _, ok := ackClassifierNames[c]; !ok {
+		return fmt.Errorf("unknown ack classifier %d", uint8(c))
+	}
+	return nil
+}
+
+// IsAckError returns true if the classifier considers the acknowledgement to be an error
+func (c AckClassifier) IsAckError(acknowledgement []byte) bool {
+	switch c {
+	case AckClassifierStandard:
+		ack, ok := decodeStandardAck(acknowledgement)
+		return !ok || ack.ValidateBasic() != nil || !ack.Success()
+	case AckClassifierJSONError:
+		return osmoutils.IsAckError(acknowledgement)
+	case AckClassifierResult:
+		var ack map[string]interface{}
+		if err := json.Unmarshal(acknowledgement, &ack); err != nil {
+			return true
+		}
+		return isEmptyJSONValue(ack["result"])
+	default:
+		if ack, ok := decodeStandardAck(acknowledgement); ok {
+			return !ack.Success()
+		}
+		return osmoutils.IsAckError(acknowledgement)
+	}
+}
+
+// decodeStandardAck strictly decodes the acknowledgement as a channeltypes.Acknowledgement,
+// which is the JSON encoding of its proto definition. Unknown fields are rejected.
+func decodeStandardAck(acknowledgement []byte) (channeltypes.Acknowledgement, bool) {
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return channeltypes.Acknowledgement{}, false
+	}
+	return ack, true
+}
+
+// isEmptyJSONValue returns true if the decoded JSON value is missing, null, an empty string, an empty object or
+// an empty array.
+func isEmptyJSONValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The error acks of the hooked packets are stored with the processed packets and relayed to the counterparty, so
+// every validator must build them byte for byte. Their messages are only built from fixed text, the packet and the
+// params. The text of the errors returned by other modules (wasmd, the bank, encoding/json...) is only logged: it
+// can include anything those modules format into it, and can change between their versions.
+
+// AckError is the error of a hook failure whose message can be put in an error ack as is. Its cause, whose text may
+// not be stable, is only part of Error(), for the logs.
+type AckError struct {
+	Msg   string
+	Cause error
+}
+
+// AckErrorf returns an AckError with the message built from format, which must only be given values of the packet,
+// of the params or fixed text
+func AckErrorf(format string, args ...interface{}) AckError {
+	return AckError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// WrapAckError is AckErrorf for an error caused by cause. The stable text of cause, see StableErrorText, can be
+// given as one of args.
+func WrapAckError(cause error, format string, args ...interface{}) AckError {
+	return AckError{Msg: fmt.Sprintf(format, args...), Cause: cause}
+}
+
+func (e AckError) Error() string {
+	if e.Cause == nil {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Msg, e.Cause.Error())
+}
+
+func (e AckError) Unwrap() error {
+	return e.Cause
+}
+
+// failureMessages are the fixed messages of the failures caused by other modules, put in the error acks in place of
+// the text of their errors
+var failureMessages = map[string]string{
+	FailureInvalidMemo:        "the memo is not valid",
+	FailureIntermediateSender: "the intermediate sender cannot receive the funds",
+	FailureBadPacket:          "the packet data cannot be encoded",
+	FailureTransfer:           "the transfer of the funds failed",
+	FailureHookFee:            "the hook fee cannot be paid",
+	FailureExecution:          "the contract execution failed",
+	FailureBadResponse:        "the contract response cannot be encoded",
+	FailureHookOutOfGas:       "the contract execution ran out of gas",
+	FailureAckTransfer:        "the hook result cannot be sent back",
+}
+
+// StableErrorText returns the text of err that can be put in an error ack: the message of the AckError in err, or
+// the description, codespace and code of the registered error err wraps, which unlike its message don't depend on
+// what it was wrapped with. Any other error only gets a fixed text.
+func StableErrorText(err error) string {
+	var ackErr AckError
+	if errors.As(err, &ackErr) {
+		return ackErr.Msg
+	}
+	var registered *sdkerrors.Error
+	if errors.As(err, &registered) {
+		return fmt.Sprintf("%s (codespace: %s, code: %d)", registered.Error(), registered.Codespace(), registered.ABCICode())
+	}
+	return "internal error"
+}
+
+// ErrorAckMessage returns the message of the error ack of a hook failure with code caused by err: the message of the
+// AckError in err, or otherwise code and its fixed message, followed by the stable text of err if it wraps a
+// registered error. err can be nil for the failures reported by an error ack of another module.
+func ErrorAckMessage(code string, err error) string {
+	var ackErr AckError
+	if errors.As(err, &ackErr) {
+		return ackErr.Msg
+	}
+	msg, ok := failureMessages[code]
+	if !ok {
+		msg = "the hook failed"
+	}
+	var registered *sdkerrors.Error
+	if errors.As(err, &registered) {
+		return fmt.Sprintf("%s: %s: %s", code, msg, StableErrorText(registered))
+	}
+	return fmt.Sprintf("%s: %s", code, msg)
+}
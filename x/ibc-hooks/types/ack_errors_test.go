@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorAckMessage(t *testing.T) {
+	var jsonErr error = &json.UnsupportedValueError{Str: "NaN"}
+	ackErr := WrapAckError(jsonErr, ErrPostTransfer, "osmo1abc", StableErrorText(jsonErr))
+
+	testCases := map[string]struct {
+		code   string
+		err    error
+		expMsg string
+	}{
+		"ack error": {
+			code:   FailureInvalidMemo,
+			err:    AckErrorf(ErrMemoTooDeep, 3),
+			expMsg: fmt.Sprintf(ErrMemoTooDeep, 3),
+		},
+		"ack error with a cause": {
+			code:   FailureExecution,
+			err:    ackErr,
+			expMsg: "cannot forward the remaining funds to osmo1abc: internal error",
+		},
+		"wrapped ack error": {
+			code:   FailureExecution,
+			err:    fmt.Errorf("forwarding: %w", ackErr),
+			expMsg: "cannot forward the remaining funds to osmo1abc: internal error",
+		},
+		"registered error": {
+			code:   FailureHookFee,
+			err:    sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s is smaller than %s", "1uosmo", "2uosmo"),
+			expMsg: "hook_fee_failed: the hook fee cannot be paid: insufficient funds (codespace: sdk, code: 5)",
+		},
+		"other error": {
+			code:   FailureBadResponse,
+			err:    errors.New("json: error calling MarshalJSON for type *main.T: 0xc000010000"),
+			expMsg: "bad_response: the contract response cannot be encoded",
+		},
+		"error ack of another module": {
+			code:   FailureTransfer,
+			expMsg: "transfer_failed: the transfer of the funds failed",
+		},
+		"unknown code": {
+			code:   "unknown",
+			err:    errors.New("failed"),
+			expMsg: "unknown: the hook failed",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expMsg, ErrorAckMessage(tc.code, tc.err))
+		})
+	}
+}
+
+// The cause of an AckError is only part of its error text, for the logs, not of its ack message
+func TestAckErrorCause(t *testing.T) {
+	cause := sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "0x1234")
+	err := WrapAckError(cause, ErrHookFee, "osmo1abc", StableErrorText(cause))
+
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	require.Contains(t, err.Error(), "0x1234")
+	require.NotContains(t, ErrorAckMessage(FailureHookFee, err), "0x1234")
+}
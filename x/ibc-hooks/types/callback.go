@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// IsLegacyPacketCallback returns true if bz is a callback stored before registration heights were
+// tracked, which was just the bech32 address of the contract.
+func IsLegacyPacketCallback(bz []byte) bool {
+	_, _, err := bech32.DecodeAndConvert(string(bz))
+	return err == nil
+}
+
+// ParsePacketCallback parses a stored packet callback, in either the legacy or the current format.
+// Legacy callbacks have a zero registration height and time.
+func ParsePacketCallback(bz []byte) (PacketCallback, error) {
+	if IsLegacyPacketCallback(bz) {
+		return PacketCallback{Contract: string(bz)}, nil
+	}
+	callback := PacketCallback{}
+	err := callback.Unmarshal(bz)
+	return callback, err
+}
+
+// CallbackExpiredSudoMsg returns the callback_expired sudo message sent to the contract of a callback that expired
+// before its packet's ack or timeout arrived
+func CallbackExpiredSudoMsg(channel string, packetSequence uint64) []byte {
+	return []byte(fmt.Sprintf(`{"callback_expired": {"channel": "%s", "sequence": %d}}`, channel, packetSequence))
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	authzcodec "github.com/cosmos/cosmos-sdk/x/authz/codec"
+)
+
+func RegisterCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&MsgSetSerializePerBlock{}, "osmosis/ibc-hooks/set-serialize-per-block", nil)
+	cdc.RegisterConcrete(&MsgCancelPacketCallback{}, "osmosis/ibc-hooks/cancel-packet-callback", nil)
+	cdc.RegisterConcrete(&MsgGrantCallbackRegistration{}, "osmosis/ibc-hooks/grant-callback-registration", nil)
+	cdc.RegisterConcrete(&MsgRevokeCallbackRegistration{}, "osmosis/ibc-hooks/revoke-callback-registration", nil)
+	cdc.RegisterConcrete(&MsgRegisterPacketCallback{}, "osmosis/ibc-hooks/register-packet-callback", nil)
+	cdc.RegisterConcrete(&MsgForceEmitCallback{}, "osmosis/ibc-hooks/force-emit-callback", nil)
+	cdc.RegisterConcrete(&MsgForceDeleteCallback{}, "osmosis/ibc-hooks/force-delete-callback", nil)
+	cdc.RegisterConcrete(&MsgSubscribeChannelAcks{}, "osmosis/ibc-hooks/subscribe-channel-acks", nil)
+	cdc.RegisterConcrete(&MsgUnsubscribeChannelAcks{}, "osmosis/ibc-hooks/unsubscribe-channel-acks", nil)
+	cdc.RegisterConcrete(&MsgAddPrivilegedContract{}, "osmosis/ibc-hooks/add-privileged-contract", nil)
+	cdc.RegisterConcrete(&MsgRemovePrivilegedContract{}, "osmosis/ibc-hooks/remove-privileged-contract", nil)
+}
+
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations(
+		(*sdk.Msg)(nil),
+		&MsgSetSerializePerBlock{},
+		&MsgCancelPacketCallback{},
+		&MsgGrantCallbackRegistration{},
+		&MsgRevokeCallbackRegistration{},
+		&MsgRegisterPacketCallback{},
+		&MsgForceEmitCallback{},
+		&MsgForceDeleteCallback{},
+		&MsgSubscribeChannelAcks{},
+		&MsgUnsubscribeChannelAcks{},
+		&MsgAddPrivilegedContract{},
+		&MsgRemovePrivilegedContract{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+var (
+	amino     = codec.NewLegacyAmino()
+	ModuleCdc = codec.NewProtoCodec(cdctypes.NewInterfaceRegistry())
+)
+
+func init() {
+	RegisterCodec(amino)
+	// Register all Amino interfaces and concrete types on the authz Amino codec so that this can later be
+	// used to properly serialize MsgGrant and MsgExec instances
+	sdk.RegisterLegacyAminoCodec(amino)
+	RegisterCodec(authzcodec.Amino)
+
+	amino.Seal()
+}
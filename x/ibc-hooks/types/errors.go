@@ -5,4 +5,62 @@ var (
 	ErrBadMetadataFormatMsg = "wasm metadata not properly formatted for: '%v'. %s"
 	ErrBadExecutionMsg      = "cannot execute contract: %v"
 	ErrBadResponse          = "cannot create response: %v"
+	ErrSerializedPerBlock   = "contract %s only accepts one hooked packet per block"
+	ErrMinAmountNotMet      = "received amount %s is below the minimum amount %s"
+	ErrFundsAmountExceeded  = "funds amount %s exceeds the received amount %s"
+	ErrFundsAmountAfterFee  = "funds amount %s exceeds the received amount %s minus the hook fee %s"
+	ErrHookFeeNotAllowed    = "hook fees cannot be paid in denom %s"
+	ErrHookFeeExceeded      = "hook fee %s exceeds the received amount %s"
+	ErrHookFee              = "cannot pay the hook fee to the relayer %s: %s"
+	ErrIntermediateSender   = "cannot create intermediate sender %s: %v"
+	ErrPostTransfer         = "cannot forward the remaining funds to %s: %s"
+	ErrDenomNotAllowed      = "denom %s is not allowed in hooked packets"
+	ErrBadCallbackTimeout   = "invalid timeout for a packet with a callback: %s"
+	// ErrThrottled starts with a fixed code so that senders can tell throttled packets apart and retry them
+	ErrThrottled = "throttled: the limit of %d hooked packets per block was reached, retry in a later block"
+	// ErrRejectedByContract starts with a fixed code so that senders can tell a contract's rejection apart from a
+	// failed execution. It is followed by the reason given by the contract.
+	ErrRejectedByContract = "REJECTED_BY_CONTRACT: %s"
+	// ErrBadLegacyReceiverMemo is the error of a receiver holding a malformed memo in the legacy format
+	ErrBadLegacyReceiverMemo = "legacy receiver memo not properly formatted: %s"
+	// ErrAckTransferNotAllowed, ErrAckTransferFunds and ErrAckTransfer are the errors of the return transfers of the
+	// hook results
+	ErrAckTransferNotAllowed = "the hook results cannot be sent back with a return transfer"
+	ErrAckTransferFunds      = "received amount %s minus the hook fee cannot pay the return transfer amount %s"
+	ErrAckTransfer           = "cannot send the hook result to %s on %s: %s"
+	// ErrHookAmountExceeded and ErrHookOutOfGas are the errors of the hooked packets over the limits of the
+	// unprivileged contracts, or of the privileged ones for the gas
+	ErrHookAmountExceeded = "amount %s exceeds the max amount %s of denom %s in hooked packets"
+	ErrHookOutOfGas       = "out of gas, the execution is limited to %d gas"
+	// ErrMemoTooLarge and ErrMemoTooDeep are the errors of the memos rejected before being parsed, see QuickRejectMemo
+	ErrMemoTooLarge = "memo of %d bytes exceeds the limit of %d bytes"
+	ErrMemoTooDeep  = "memo nests objects and arrays deeper than the limit of %d levels"
+)
+
+// Codes of the hook failures in the logs, for operators to filter on
+const (
+	FailureDenomNotAllowed    = "denom_not_allowed"
+	FailureInvalidMemo        = "invalid_memo"
+	FailureThrottled          = "throttled"
+	FailureSerializedPerBlock = "serialized_per_block"
+	FailureIntermediateSender = "intermediate_sender"
+	FailureBadPacket          = "bad_packet"
+	FailureTransfer           = "transfer_failed"
+	FailureMinAmountNotMet    = "min_amount_not_met"
+	FailureFundsAmountTooHigh = "funds_amount_too_high"
+	FailureHookFeeNotAllowed  = "hook_fee_not_allowed"
+	FailureHookFeeTooHigh     = "hook_fee_too_high"
+	FailureHookFee            = "hook_fee_failed"
+	FailureRejectedByContract = "rejected_by_contract"
+	FailureExecution          = "execution_failed"
+	FailureBadResponse        = "bad_response"
+	FailureInvalidCallback    = "invalid_callback"
+	FailureCallback           = "callback_failed"
+	// the return transfers of the hook results
+	FailureAckTransferNotAllowed = "ack_transfer_not_allowed"
+	FailureAckTransferFunds      = "ack_transfer_funds_too_low"
+	FailureAckTransfer           = "ack_transfer_failed"
+	// the limits of the hooked packets
+	FailureHookAmountExceeded = "hook_amount_exceeded"
+	FailureHookOutOfGas       = "hook_out_of_gas"
 )
@@ -0,0 +1,35 @@
+package types
+
+// event types
+const (
+	TypeEvtHookedPacketThrottled = "hooked_packet_throttled"
+	TypeEvtHookedPacketRejected  = "hooked_packet_rejected"
+	TypeEvtPostTransfer          = "hook_post_transfer"
+	TypeEvtPacketCallbackExpired = "packet_callback_expired"
+	TypeEvtRecvDeferred          = "hooked_packet_recv_deferred"
+	TypeEvtDeferredRecvAcked     = "deferred_recv_acknowledged"
+	TypeEvtPacketRedelivered     = "hooked_packet_redelivered"
+	TypeEvtAckSubscriberFailed   = "ack_subscriber_failed"
+	TypeEvtHookFeePaid           = "hook_fee_paid"
+	TypeEvtAckTransfer           = "hook_ack_transfer"
+	// TypeEvtPacketCallbackRegistered and TypeEvtPacketCallbackDelivered identify the packet of an ack callback, as a
+	// contract can have callbacks pending for several packets at once
+	TypeEvtPacketCallbackRegistered = "packet_callback_registered"
+	TypeEvtPacketCallbackDelivered  = "packet_callback_delivered"
+	// TypeEvtLegacyReceiverMemo is emitted for the deprecated hooked packets whose memo is in their receiver
+	TypeEvtLegacyReceiverMemo = "legacy_receiver_memo"
+
+	AttributeSender     = "sender"
+	AttributeEnabled    = "enabled"
+	AttributeChannel    = "channel"
+	AttributeSequence   = "sequence"
+	AttributeContract   = "contract"
+	AttributeRecipient  = "recipient"
+	AttributeReason     = "reason"
+	AttributeGrantee    = "grantee"
+	AttributeExpiration = "expiration"
+	AttributeAttempts   = "attempts"
+	AttributeSuccess    = "success"
+	AttributeAck        = "ack"
+	AttributeFee        = "fee"
+)
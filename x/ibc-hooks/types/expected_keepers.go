@@ -0,0 +1,83 @@
+package types
+
+import (
+	"context"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
+)
+
+// BankKeeper defines the expected interface needed to forward the funds left after a hook.
+type BankKeeper interface {
+	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	BlockedAddr(addr sdk.AccAddress) bool
+}
+
+// TransferKeeper defines the expected interface of the transfer keeper needed by the wasm hooks, to send the results
+// of the hooked executions back to their senders.
+type TransferKeeper interface {
+	Transfer(goCtx context.Context, msg *transfertypes.MsgTransfer) (*transfertypes.MsgTransferResponse, error)
+}
+
+// DistrKeeper defines the expected interface of the distribution keeper needed to collect the ack subscription fees.
+type DistrKeeper interface {
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error
+}
+
+// ContractKeeper defines the expected interface of the wasm keeper needed by the ibc-hooks keeper.
+type ContractKeeper interface {
+	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *wasmtypes.ContractInfo
+	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
+
+// ContractExecutor defines the expected interface of the wasm contract keeper needed by the wasm hooks, to execute
+// the contracts of hooked packets and to call back the contracts of sent packets.
+type ContractExecutor interface {
+	Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
+	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
+
+// ChannelKeeper defines the expected interface of the IBC channel keeper needed by the ibc-hooks keeper.
+type ChannelKeeper interface {
+	GetPacketReceipt(ctx sdk.Context, portID, channelID string, sequence uint64) (string, bool)
+	GetPacketCommitment(ctx sdk.Context, portID, channelID string, sequence uint64) []byte
+	GetChannel(ctx sdk.Context, portID, channelID string) (channeltypes.Channel, bool)
+}
+
+// RecvRetrier receives the deferred hooked packets again, and writes their acks once they are resolved.
+type RecvRetrier interface {
+	// RetryRecv receives packet again. If final is false and the ICS20 receive fails again, it returns a nil ack
+	// so that the packet stays deferred.
+	RetryRecv(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress, final bool) ibcexported.Acknowledgement
+	// WriteRecvAck writes the ack of a deferred packet
+	WriteRecvAck(ctx sdk.Context, packet channeltypes.Packet, ack ibcexported.Acknowledgement) error
+}
+
+// ScopedKeeper defines the expected interface of the transfer module's scoped keeper, whose channel capabilities
+// are needed to write the acks of the deferred hooked packets.
+type ScopedKeeper interface {
+	GetCapability(ctx sdk.Context, name string) (*capabilitytypes.Capability, bool)
+}
+
+// HookSimulator validates the memos of hooked packets and receives packets through the transfer stack, for the
+// SimulateHook query.
+type HookSimulator interface {
+	// ValidateMemo returns whether memo routes a packet sent to receiver on chain chainID into a contract, and
+	// that contract. An empty receiver is taken to be the contract of the memo.
+	ValidateMemo(memo, receiver, chainID string) (isWasmRouted bool, contract sdk.AccAddress, err error)
+	// SimulateRecv receives packet, executing its hook if it has one, and returns its ack
+	SimulateRecv(ctx sdk.Context, packet channeltypes.Packet) ibcexported.Acknowledgement
+}
+
+// CallbackDeliverer delivers the ack callbacks of the packets sent from this chain to their contracts, for the
+// forced callback messages.
+type CallbackDeliverer interface {
+	// DeliverAckCallback delivers ack to the contract of callback, the callback of the packet sent on channel with
+	// sequence, telling it whether the packet succeeded
+	DeliverAckCallback(ctx sdk.Context, channel string, sequence uint64, callback PacketCallback, ack []byte, success bool) error
+}
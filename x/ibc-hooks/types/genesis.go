@@ -0,0 +1,134 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+)
+
+// DefaultGenesis returns the default ibc-hooks genesis state.
+func DefaultGenesis() *GenesisState {
+	params := DefaultParams()
+	return &GenesisState{
+		Params:                     &params,
+		PacketCallbacks:            []PendingPacketCallback{},
+		SerializedContracts:        []string{},
+		ChannelHookStats:           []ChannelHookStats{},
+		CallbackRegistrationGrants: []CallbackRegistrationGrant{},
+		DeferredRecvs:              []DeferredRecv{},
+		ProcessedPackets:           []ProcessedPacket{},
+		AckSubscriptions:           []AckSubscription{},
+		PrivilegedContracts:        []string{},
+	}
+}
+
+// Validate validates the genesis state. Returns nil on success, error otherwise.
+func (g *GenesisState) Validate() error {
+	if g.Params != nil {
+		if err := g.Params.Validate(); err != nil {
+			return err
+		}
+	}
+
+	callbacks := make(map[string]bool, len(g.PacketCallbacks))
+	for _, pending := range g.PacketCallbacks {
+		if !channeltypes.IsValidChannelID(pending.Channel) {
+			return fmt.Errorf("invalid packet callback channel: %s", pending.Channel)
+		}
+		if _, err := sdk.AccAddressFromBech32(pending.Callback.Contract); err != nil {
+			return fmt.Errorf("invalid packet callback contract %s: %w", pending.Callback.Contract, err)
+		}
+		key := fmt.Sprintf("%s/%d", pending.Channel, pending.Sequence)
+		if callbacks[key] {
+			return fmt.Errorf("duplicate callback for packet %d of channel %s", pending.Sequence, pending.Channel)
+		}
+		callbacks[key] = true
+	}
+
+	for _, contract := range g.SerializedContracts {
+		if _, err := sdk.AccAddressFromBech32(contract); err != nil {
+			return fmt.Errorf("invalid serialized contract %s: %w", contract, err)
+		}
+	}
+
+	channels := make(map[string]bool, len(g.ChannelHookStats))
+	for _, stats := range g.ChannelHookStats {
+		if channels[stats.Channel] {
+			return fmt.Errorf("duplicate hook stats for channel %s", stats.Channel)
+		}
+		channels[stats.Channel] = true
+	}
+
+	for _, grant := range g.CallbackRegistrationGrants {
+		if _, err := sdk.AccAddressFromBech32(grant.Granter); err != nil {
+			return fmt.Errorf("invalid callback registration granter %s: %w", grant.Granter, err)
+		}
+		if _, err := sdk.AccAddressFromBech32(grant.Grantee); err != nil {
+			return fmt.Errorf("invalid callback registration grantee %s: %w", grant.Grantee, err)
+		}
+	}
+
+	deferredRecvs := make(map[string]bool, len(g.DeferredRecvs))
+	for _, deferred := range g.DeferredRecvs {
+		if err := deferred.Packet.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid deferred packet: %w", err)
+		}
+		if deferred.Attempts >= MaxDeferredRecvAttempts {
+			return fmt.Errorf("deferred packet %d of channel %s has no attempts left", deferred.Packet.Sequence, deferred.Packet.DestinationChannel)
+		}
+		key := fmt.Sprintf("%s/%d", deferred.Packet.DestinationChannel, deferred.Packet.Sequence)
+		if deferredRecvs[key] {
+			return fmt.Errorf("duplicate deferred packet %d of channel %s", deferred.Packet.Sequence, deferred.Packet.DestinationChannel)
+		}
+		deferredRecvs[key] = true
+	}
+
+	processedPackets := make(map[string]bool, len(g.ProcessedPackets))
+	for _, processed := range g.ProcessedPackets {
+		if !channeltypes.IsValidChannelID(processed.Channel) {
+			return fmt.Errorf("invalid processed packet channel: %s", processed.Channel)
+		}
+		if len(processed.DataHash) != sha256.Size {
+			return fmt.Errorf("invalid data hash of processed packet %d of channel %s", processed.Sequence, processed.Channel)
+		}
+		key := fmt.Sprintf("%s/%d", processed.Channel, processed.Sequence)
+		if processedPackets[key] {
+			return fmt.Errorf("duplicate processed packet %d of channel %s", processed.Sequence, processed.Channel)
+		}
+		processedPackets[key] = true
+	}
+
+	subscribers := make(map[string]int, len(g.AckSubscriptions))
+	ackSubscriptions := make(map[string]bool, len(g.AckSubscriptions))
+	for _, subscription := range g.AckSubscriptions {
+		if !channeltypes.IsValidChannelID(subscription.Channel) {
+			return fmt.Errorf("invalid ack subscription channel: %s", subscription.Channel)
+		}
+		if _, err := sdk.AccAddressFromBech32(subscription.Contract); err != nil {
+			return fmt.Errorf("invalid ack subscription contract %s: %w", subscription.Contract, err)
+		}
+		key := fmt.Sprintf("%s/%s", subscription.Channel, subscription.Contract)
+		if ackSubscriptions[key] {
+			return fmt.Errorf("duplicate subscription of %s to the acks of channel %s", subscription.Contract, subscription.Channel)
+		}
+		ackSubscriptions[key] = true
+		subscribers[subscription.Channel]++
+		if subscribers[subscription.Channel] > MaxAckSubscribersPerChannel {
+			return fmt.Errorf("channel %s has more than %d ack subscribers", subscription.Channel, MaxAckSubscribersPerChannel)
+		}
+	}
+
+	privilegedContracts := make(map[string]bool, len(g.PrivilegedContracts))
+	for _, contract := range g.PrivilegedContracts {
+		if _, err := sdk.AccAddressFromBech32(contract); err != nil {
+			return fmt.Errorf("invalid privileged contract %s: %w", contract, err)
+		}
+		if privilegedContracts[contract] {
+			return fmt.Errorf("duplicate privileged contract %s", contract)
+		}
+		privilegedContracts[contract] = true
+	}
+	return nil
+}
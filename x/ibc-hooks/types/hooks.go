@@ -0,0 +1,86 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+)
+
+// IBCHooksHooks lets other modules observe the lifecycle of hooked packets, e.g. to index them, without forking
+// the middleware. The hooks only observe: an error they return, or a panic, is logged and their state changes
+// are discarded, without affecting the packet.
+type IBCHooksHooks interface {
+	// AfterHookExecution is called once a hooked packet received on this chain got its ack, whether its hook
+	// succeeded or was rejected before being executed. The state changes of a failed packet, including those of
+	// the hook, are reverted along with the packet's.
+	AfterHookExecution(ctx sdk.Context, packet HookedPacketInfo, result HookExecutionResult) error
+	// AfterCallbackExecution is called once the contract of a packet sent from this chain was called back with the
+	// packet's ack, whether the callback succeeded or not.
+	AfterCallbackExecution(ctx sdk.Context, packet CallbackPacketInfo, ack CallbackAckInfo) error
+}
+
+// HookedPacketInfo is a hooked packet received on this chain
+type HookedPacketInfo struct {
+	Channel  string
+	Sequence uint64
+	// Sender is the sender of the packet on the counterparty chain
+	Sender string
+	// Contract is empty if the memo couldn't be parsed
+	Contract string
+	// Denom is the local denom of the received funds
+	Denom  string
+	Amount string
+}
+
+// HookExecutionResult is the outcome of the hook of a received packet
+type HookExecutionResult struct {
+	Success bool
+	// Funds are the funds the contract was executed with. They are empty if the hook failed.
+	Funds sdk.Coins
+	Ack   []byte
+}
+
+// CallbackPacketInfo is a packet sent from this chain whose ack is delivered to a contract
+type CallbackPacketInfo struct {
+	Channel  string
+	Sequence uint64
+	Contract string
+	Entry    CallbackEntry
+}
+
+// CallbackAckInfo is the ack a contract was called back with, and the outcome of the callback
+type CallbackAckInfo struct {
+	Ack []byte
+	// AckSuccess is whether the ack was classified as a success
+	AckSuccess bool
+	// Error is the error of the callback, empty if it succeeded
+	Error string
+}
+
+var _ IBCHooksHooks = MultiIBCHooksHooks{}
+
+// MultiIBCHooksHooks combines multiple ibc-hooks hooks, all hook functions are run in array sequence.
+// Each hook runs on its own, so that one failing doesn't keep the others from observing the packet.
+type MultiIBCHooksHooks []IBCHooksHooks
+
+func NewMultiIBCHooksHooks(hooks ...IBCHooksHooks) MultiIBCHooksHooks {
+	return hooks
+}
+
+func (h MultiIBCHooksHooks) AfterHookExecution(ctx sdk.Context, packet HookedPacketInfo, result HookExecutionResult) error {
+	for i := range h {
+		_ = osmoutils.ApplyFuncIfNoError(ctx, func(ctx sdk.Context) error {
+			return h[i].AfterHookExecution(ctx, packet, result)
+		})
+	}
+	return nil
+}
+
+func (h MultiIBCHooksHooks) AfterCallbackExecution(ctx sdk.Context, packet CallbackPacketInfo, ack CallbackAckInfo) error {
+	for i := range h {
+		_ = osmoutils.ApplyFuncIfNoError(ctx, func(ctx sdk.Context) error {
+			return h[i].AfterCallbackExecution(ctx, packet, ack)
+		})
+	}
+	return nil
+}
@@ -1,7 +1,75 @@
 package types
 
+import "time"
+
 const (
-	ModuleName     = "ibchooks"
-	StoreKey       = "hooks-for-ibc" // not using the module name because of collisions with key "ibc"
-	IBCCallbackKey = "ibc_callback"
+	ModuleName        = "ibchooks"
+	StoreKey          = "hooks-for-ibc" // not using the module name because of collisions with key "ibc"
+	TransientStoreKey = "transient_" + ModuleName
+	RouterKey         = ModuleName
+	IBCCallbackKey    = "ibc_callback"
+
+	// ObserverGasLimit is the gas available to the observer contract for each notification
+	ObserverGasLimit uint64 = 200_000
+
+	// PacketCallbackMaxAge is how long after its registration a packet callback is considered stale and pruned.
+	// It is far beyond the timeout of any packet a contract would reasonably send.
+	PacketCallbackMaxAge = 30 * 24 * time.Hour
+	// MaxPrunedCallbacksPerBlock bounds the number of stale packet callbacks deleted in a single end blocker
+	MaxPrunedCallbacksPerBlock = 100
+	// MaxMigratedCallbacksPerBlock bounds the number of packet callbacks moved to their v2 keys by the store
+	// migration, and then by each begin blocker until none are left
+	MaxMigratedCallbacksPerBlock = 1_000
+	// ExpiredCallbackGasLimit is the gas available to a contract for each callback_expired notification
+	ExpiredCallbackGasLimit uint64 = 200_000
+
+	// MaxAckSubscribersPerChannel bounds the number of contracts subscribed to the acks of a channel, as each of
+	// them is sudoed with every ack of the channel
+	MaxAckSubscribersPerChannel = 5
+	// AckSubscriberGasLimit is the gas available to a subscribed contract for each channel_ack notification
+	AckSubscriberGasLimit uint64 = 200_000
+
+	// StatsEpochIdentifier is the epoch at the end of which the per channel hooked packet stats roll over
+	StatsEpochIdentifier = "day"
+	// ChannelHookStatsHistoryLength is the number of finished stats periods kept for each channel
+	ChannelHookStatsHistoryLength = 7
+	// MaxChannelHookStatsDenoms is the number of denoms, with the largest amounts, whose routed amount is tracked
+	// in a stats period
+	MaxChannelHookStatsDenoms = 10
+
+	// MaxHookFailureLogsPerBlock bounds the number of hook failures logged in a block, so that a spam of failing
+	// packets can't blow up the logs of a node
+	MaxHookFailureLogsPerBlock = 50
+
+	// MaxDeferredRecvAttempts is the number of times the ICS20 receive of a deferred hooked packet is attempted,
+	// counting the first receive, before its error ack is written
+	MaxDeferredRecvAttempts = 5
+	// MaxDeferredRecvRetriesPerBlock bounds the number of deferred hooked packets retried in a single begin blocker.
+	// The packets left over are retried in the following blocks.
+	MaxDeferredRecvRetriesPerBlock = 20
+
+	// ProcessedPacketWindow is the number of blocks, about a day, during which the ack of a hooked packet is kept
+	// so that a redelivery of the packet gets the same ack without its hook being executed again
+	ProcessedPacketWindow int64 = 14_400
+	// MaxPrunedProcessedPacketsPerBlock bounds the number of processed packets deleted in a single end blocker
+	MaxPrunedProcessedPacketsPerBlock = 100
+
+	// SimulateHookGasLimit is the gas available to the receive and the hook execution simulated by a SimulateHook
+	// query
+	SimulateHookGasLimit uint64 = 3_000_000
+
+	// MaxRejectionReasonLength is the maximum length, in bytes, of the reason of a contract rejecting a hooked packet
+	MaxRejectionReasonLength = 256
+
+	// SenderPrefix is the address.Module key from which the intermediate senders of hooked packets are derived
+	SenderPrefix = "ibc-wasm-hook-intermediary"
+
+	// keys of the object form of the ibc_callback memo entry
+	IBCCallbackContractKey = "contract"
+	IBCCallbackEntryKey    = "entry"
+	IBCCallbackExpiryKey   = "expiry_height"
+
+	// values of the ibc_callback entry
+	IBCCallbackEntrySudo    = "sudo"
+	IBCCallbackEntryExecute = "execute"
 )
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+)
+
+// WasmHookPayloadTypeURL is the type URL of the Any wrapping a WasmHookPayload in a memo. It is spelled out, as
+// proto.MessageName only knows the type once memo.pb.go's init has registered it, after the package's vars are set.
+const WasmHookPayloadTypeURL = "/osmosis.ibchooks.WasmHookPayload"
+
+// UnmarshalMemoJSON is json.Unmarshal, decoding the numbers of v's interface{} values as json.Number rather than
+// float64. A memo is decoded into such values and parts of it are marshalled again, e.g. the msg of the contract, so
+// its numbers must keep their literal: a float64 would round any integer above 2^53, such as a token amount.
+func UnmarshalMemoJSON(bz []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	// like json.Unmarshal, anything but whitespace after the value is an error
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// MemoLimits bound the memos QuickRejectMemo lets through to be parsed
+type MemoLimits struct {
+	// MaxBytes is the maximum length of a memo, or 0 for no limit
+	MaxBytes int
+	// MaxDepth is the maximum nesting depth of the JSON objects and arrays of a memo, or 0 for no limit
+	MaxDepth int
+}
+
+// DefaultMemoLimits are the limits of the memos parsed outside of consensus. They are well above the memos of the
+// hooks, which are a contract call and its options.
+var DefaultMemoLimits = MemoLimits{MaxBytes: 256 * 1024, MaxDepth: 64}
+
+// QuickRejectMemo returns an error if memo is longer than limits.MaxBytes, or if its JSON objects and arrays nest
+// deeper than limits.MaxDepth, so that a hostile memo can be rejected before being unmarshalled. It scans the memo
+// once without allocating, counting the brackets outside of JSON strings, so it accepts memos that are not JSON and
+// doesn't validate the ones that are. It only allocates the error of a rejected memo.
+func QuickRejectMemo(memo string, limits MemoLimits) error {
+	if limits.MaxBytes > 0 && len(memo) > limits.MaxBytes {
+		return AckErrorf(ErrMemoTooLarge, len(memo), limits.MaxBytes)
+	}
+	if limits.MaxDepth <= 0 {
+		return nil
+	}
+	depth := 0
+	inString, escaped := false, false
+	for i := 0; i < len(memo); i++ {
+		c := memo[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > limits.MaxDepth {
+				return AckErrorf(ErrMemoTooDeep, limits.MaxDepth)
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// ParseWasmHookPayload parses a memo holding the base64 encoding of an Any wrapping a WasmHookPayload.
+// ok is false for any other memo.
+func ParseWasmHookPayload(memo string) (payload WasmHookPayload, ok bool) {
+	bz, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return WasmHookPayload{}, false
+	}
+	var any codectypes.Any
+	if err := any.Unmarshal(bz); err != nil || any.TypeUrl != WasmHookPayloadTypeURL {
+		return WasmHookPayload{}, false
+	}
+	if err := payload.Unmarshal(any.Value); err != nil {
+		return WasmHookPayload{}, false
+	}
+	return payload, true
+}
+
+// EncodeMemo returns the memo holding the payload, which is the base64 encoding of an Any wrapping it.
+func (p WasmHookPayload) EncodeMemo() (string, error) {
+	any, err := codectypes.NewAnyWithValue(&p)
+	if err != nil {
+		return "", err
+	}
+	bz, err := any.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bz), nil
+}
+
+// IsEmpty returns true if the payload has neither a contract to execute nor a callback.
+func (p WasmHookPayload) IsEmpty() bool {
+	return p.Contract == "" && len(p.Msg) == 0 && p.Callback == ""
+}
+
+// Metadata returns the JSON memo object the payload is handled as. The contract and msg are set in the "wasm" key,
+// and the callback is the "ibc_callback" key. A msg that isn't JSON is kept as a string, so that the packet is
+// rejected as if its JSON memo had a msg that is not an object.
+func (p WasmHookPayload) Metadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+	if p.Contract != "" || len(p.Msg) > 0 {
+		wasm := map[string]interface{}{"contract": p.Contract}
+		if len(p.Msg) > 0 {
+			var msg interface{}
+			if err := UnmarshalMemoJSON(p.Msg, &msg); err != nil {
+				msg = string(p.Msg)
+			}
+			wasm["msg"] = msg
+		}
+		metadata["wasm"] = wasm
+	}
+	if p.Callback != "" {
+		metadata[IBCCallbackKey] = p.Callback
+	}
+	return metadata
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickRejectMemo(t *testing.T) {
+	limits := MemoLimits{MaxBytes: 64, MaxDepth: 3}
+	testCases := map[string]struct {
+		memo   string
+		limits MemoLimits
+		expErr string
+	}{
+		"empty memo":                   {memo: "", limits: limits},
+		"hook memo":                    {memo: `{"wasm": {"contract": "c", "msg": {"echo": {}}}}`, limits: MemoLimits{MaxBytes: 64, MaxDepth: 4}},
+		"memo at the size limit":       {memo: strings.Repeat("a", 64), limits: limits},
+		"memo over the size limit":     {memo: strings.Repeat("a", 65), limits: limits, expErr: fmt.Sprintf(ErrMemoTooLarge, 65, 64)},
+		"nesting over the depth limit": {memo: `{"a": [{"b": []}]}`, limits: limits, expErr: fmt.Sprintf(ErrMemoTooDeep, 3)},
+		"brackets within strings":      {memo: `{"a": "[[[{{{"}`, limits: limits},
+		"escaped quotes within strings": {
+			memo:   `{"a": "\"[[[", "b": "\\"}`,
+			limits: limits,
+		},
+		"escaped quote doesn't end a string": {
+			memo:   `{"a": "\"[[[{"}`,
+			limits: MemoLimits{MaxDepth: 1},
+		},
+		"closed brackets don't count": {memo: `[[[]]] [[[]]] [[[]]]`, limits: limits},
+		"not JSON":                    {memo: "a plain memo", limits: limits},
+		"no limits":                   {memo: strings.Repeat("[", 100), limits: MemoLimits{}},
+		"no depth limit":              {memo: strings.Repeat("[", 10), limits: MemoLimits{MaxBytes: 10}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := QuickRejectMemo(tc.memo, tc.limits)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+// hostileMemos are memos of about 1MB built to make their unmarshalling expensive
+var hostileMemos = map[string]string{
+	"deep nesting":                     strings.Repeat("[", 1<<20),
+	"long string then deep nesting":    `{"a": "` + strings.Repeat("x", 1<<19) + `", "b": ` + strings.Repeat("[", 1<<19),
+	"many small objects":               "[" + strings.Repeat(`{"a":[1]},`, 1<<17) + "{}]",
+	"long string of escaped brackets":  `"` + strings.Repeat(`\"[`, 1<<19) + `"`,
+	"hook memo with a huge msg string": `{"wasm": {"contract": "c", "msg": "` + strings.Repeat("x", 1<<20) + `"}}`,
+}
+
+// QuickRejectMemo allocates nothing but the error of a rejected memo, however large and nested the memo is
+func TestQuickRejectMemoAllocations(t *testing.T) {
+	limitsCases := map[string]MemoLimits{
+		"default limits": DefaultMemoLimits,
+		"depth limit":    {MaxDepth: DefaultMemoLimits.MaxDepth},
+		"no limits":      {},
+	}
+	for limitsName, limits := range limitsCases {
+		for memoName, memo := range hostileMemos {
+			allocs := testing.AllocsPerRun(10, func() {
+				_ = QuickRejectMemo(memo, limits)
+			})
+			require.LessOrEqual(t, allocs, float64(5), "%s, %s", limitsName, memoName)
+		}
+	}
+}
+
+func BenchmarkQuickRejectMemo(b *testing.B) {
+	for name, memo := range hostileMemos {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(memo)))
+			for i := 0; i < b.N; i++ {
+				_ = QuickRejectMemo(memo, MemoLimits{MaxDepth: DefaultMemoLimits.MaxDepth})
+			}
+		})
+	}
+}
+
+// BenchmarkUnmarshalMemoJSON is the cost of parsing the memos QuickRejectMemo rejects, for comparison
+func BenchmarkUnmarshalMemoJSON(b *testing.B) {
+	for name, memo := range hostileMemos {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(memo)))
+			for i := 0; i < b.N; i++ {
+				var metadata map[string]interface{}
+				_ = UnmarshalMemoJSON([]byte(memo), &metadata)
+			}
+		})
+	}
+}
+
+func TestWasmHookPayloadTypeURL(t *testing.T) {
+	require.Equal(t, "/"+proto.MessageName(&WasmHookPayload{}), WasmHookPayloadTypeURL)
+
+	memo, err := WasmHookPayload{Contract: "contract", Msg: []byte(`{"echo": {}}`)}.EncodeMemo()
+	require.NoError(t, err)
+	payload, ok := ParseWasmHookPayload(memo)
+	require.True(t, ok)
+	require.Equal(t, "contract", payload.Contract)
+}
@@ -0,0 +1,412 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+)
+
+// constants
+const (
+	TypeMsgSetSerializePerBlock = "set_serialize_per_block"
+	TypeMsgCancelPacketCallback = "cancel_packet_callback"
+
+	TypeMsgGrantCallbackRegistration  = "grant_callback_registration"
+	TypeMsgRevokeCallbackRegistration = "revoke_callback_registration"
+	TypeMsgRegisterPacketCallback     = "register_packet_callback"
+
+	TypeMsgForceEmitCallback   = "force_emit_callback"
+	TypeMsgForceDeleteCallback = "force_delete_callback"
+
+	TypeMsgSubscribeChannelAcks   = "subscribe_channel_acks"
+	TypeMsgUnsubscribeChannelAcks = "unsubscribe_channel_acks"
+
+	TypeMsgAddPrivilegedContract    = "add_privileged_contract"
+	TypeMsgRemovePrivilegedContract = "remove_privileged_contract"
+)
+
+var (
+	_ sdk.Msg = &MsgSetSerializePerBlock{}
+	_ sdk.Msg = &MsgCancelPacketCallback{}
+	_ sdk.Msg = &MsgGrantCallbackRegistration{}
+	_ sdk.Msg = &MsgRevokeCallbackRegistration{}
+	_ sdk.Msg = &MsgRegisterPacketCallback{}
+	_ sdk.Msg = &MsgForceEmitCallback{}
+	_ sdk.Msg = &MsgForceDeleteCallback{}
+	_ sdk.Msg = &MsgSubscribeChannelAcks{}
+	_ sdk.Msg = &MsgUnsubscribeChannelAcks{}
+	_ sdk.Msg = &MsgAddPrivilegedContract{}
+	_ sdk.Msg = &MsgRemovePrivilegedContract{}
+)
+
+// NewMsgSetSerializePerBlock creates a msg to enable or disable per block serialization of hooks for a contract
+func NewMsgSetSerializePerBlock(sender string, enabled bool) *MsgSetSerializePerBlock {
+	return &MsgSetSerializePerBlock{
+		Sender:  sender,
+		Enabled: enabled,
+	}
+}
+
+func (m MsgSetSerializePerBlock) Route() string { return RouterKey }
+func (m MsgSetSerializePerBlock) Type() string  { return TypeMsgSetSerializePerBlock }
+func (m MsgSetSerializePerBlock) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+
+	return nil
+}
+
+func (m MsgSetSerializePerBlock) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgSetSerializePerBlock) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgCancelPacketCallback creates a msg to cancel the callback a contract registered for a packet
+func NewMsgCancelPacketCallback(sender, channel string, sequence uint64) *MsgCancelPacketCallback {
+	return &MsgCancelPacketCallback{
+		Sender:   sender,
+		Channel:  channel,
+		Sequence: sequence,
+	}
+}
+
+func (m MsgCancelPacketCallback) Route() string { return RouterKey }
+func (m MsgCancelPacketCallback) Type() string  { return TypeMsgCancelPacketCallback }
+func (m MsgCancelPacketCallback) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	if !channeltypes.IsValidChannelID(m.Channel) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid channel id %s", m.Channel)
+	}
+
+	return nil
+}
+
+func (m MsgCancelPacketCallback) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgCancelPacketCallback) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgGrantCallbackRegistration creates a msg for a contract to allow grantee to register callbacks to it
+// until expiration
+func NewMsgGrantCallbackRegistration(sender, grantee string, expiration time.Time) *MsgGrantCallbackRegistration {
+	return &MsgGrantCallbackRegistration{
+		Sender:     sender,
+		Grantee:    grantee,
+		Expiration: expiration,
+	}
+}
+
+func (m MsgGrantCallbackRegistration) Route() string { return RouterKey }
+func (m MsgGrantCallbackRegistration) Type() string  { return TypeMsgGrantCallbackRegistration }
+func (m MsgGrantCallbackRegistration) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	_, err = sdk.AccAddressFromBech32(m.Grantee)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid grantee address (%s)", err)
+	}
+	if m.Sender == m.Grantee {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "a contract can't grant to itself")
+	}
+
+	return nil
+}
+
+func (m MsgGrantCallbackRegistration) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgGrantCallbackRegistration) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgRevokeCallbackRegistration creates a msg for a contract to revoke the grant it gave to grantee
+func NewMsgRevokeCallbackRegistration(sender, grantee string) *MsgRevokeCallbackRegistration {
+	return &MsgRevokeCallbackRegistration{
+		Sender:  sender,
+		Grantee: grantee,
+	}
+}
+
+func (m MsgRevokeCallbackRegistration) Route() string { return RouterKey }
+func (m MsgRevokeCallbackRegistration) Type() string  { return TypeMsgRevokeCallbackRegistration }
+func (m MsgRevokeCallbackRegistration) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	_, err = sdk.AccAddressFromBech32(m.Grantee)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid grantee address (%s)", err)
+	}
+
+	return nil
+}
+
+func (m MsgRevokeCallbackRegistration) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgRevokeCallbackRegistration) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgRegisterPacketCallback creates a msg for a grantee of contract to register a callback to it for a packet
+// it sent
+func NewMsgRegisterPacketCallback(sender, contract string, packet channeltypes.Packet, entry CallbackEntry) *MsgRegisterPacketCallback {
+	return &MsgRegisterPacketCallback{
+		Sender:           sender,
+		Contract:         contract,
+		Channel:          packet.GetSourceChannel(),
+		Sequence:         packet.GetSequence(),
+		PacketData:       packet.GetData(),
+		TimeoutHeight:    packet.TimeoutHeight,
+		TimeoutTimestamp: packet.GetTimeoutTimestamp(),
+		Entry:            entry,
+	}
+}
+
+func (m MsgRegisterPacketCallback) Route() string { return RouterKey }
+func (m MsgRegisterPacketCallback) Type() string  { return TypeMsgRegisterPacketCallback }
+func (m MsgRegisterPacketCallback) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	_, err = sdk.AccAddressFromBech32(m.Contract)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid contract address (%s)", err)
+	}
+	if !channeltypes.IsValidChannelID(m.Channel) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid channel id %s", m.Channel)
+	}
+	if len(m.PacketData) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "packet data cannot be empty")
+	}
+	if _, ok := CallbackEntry_name[int32(m.Entry)]; !ok {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown callback entry %d", m.Entry)
+	}
+
+	return nil
+}
+
+func (m MsgRegisterPacketCallback) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgRegisterPacketCallback) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgForceEmitCallback creates a msg for the callback authority to deliver the callback of a packet with ack
+func NewMsgForceEmitCallback(sender, channel string, sequence uint64, ack []byte, success bool) *MsgForceEmitCallback {
+	return &MsgForceEmitCallback{
+		Sender:   sender,
+		Channel:  channel,
+		Sequence: sequence,
+		Ack:      ack,
+		Success:  success,
+	}
+}
+
+func (m MsgForceEmitCallback) Route() string { return RouterKey }
+func (m MsgForceEmitCallback) Type() string  { return TypeMsgForceEmitCallback }
+func (m MsgForceEmitCallback) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	if !channeltypes.IsValidChannelID(m.Channel) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid channel id %s", m.Channel)
+	}
+	if len(m.Ack) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "ack cannot be empty")
+	}
+
+	return nil
+}
+
+func (m MsgForceEmitCallback) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgForceEmitCallback) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgForceDeleteCallback creates a msg for the callback authority to delete the callback of a packet
+func NewMsgForceDeleteCallback(sender, channel string, sequence uint64) *MsgForceDeleteCallback {
+	return &MsgForceDeleteCallback{
+		Sender:   sender,
+		Channel:  channel,
+		Sequence: sequence,
+	}
+}
+
+func (m MsgForceDeleteCallback) Route() string { return RouterKey }
+func (m MsgForceDeleteCallback) Type() string  { return TypeMsgForceDeleteCallback }
+func (m MsgForceDeleteCallback) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(m.Sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	if !channeltypes.IsValidChannelID(m.Channel) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid channel id %s", m.Channel)
+	}
+
+	return nil
+}
+
+func (m MsgForceDeleteCallback) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgForceDeleteCallback) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgSubscribeChannelAcks creates a msg to subscribe a contract to the acks of the packets sent on a channel
+func NewMsgSubscribeChannelAcks(sender, contract, channel string) *MsgSubscribeChannelAcks {
+	return &MsgSubscribeChannelAcks{
+		Sender:   sender,
+		Contract: contract,
+		Channel:  channel,
+	}
+}
+
+func (m MsgSubscribeChannelAcks) Route() string { return RouterKey }
+func (m MsgSubscribeChannelAcks) Type() string  { return TypeMsgSubscribeChannelAcks }
+func (m MsgSubscribeChannelAcks) ValidateBasic() error {
+	return validateAckSubscriptionMsg(m.Sender, m.Contract, m.Channel)
+}
+
+func (m MsgSubscribeChannelAcks) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgSubscribeChannelAcks) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgUnsubscribeChannelAcks creates a msg to delete the subscription of a contract to the acks of a channel
+func NewMsgUnsubscribeChannelAcks(sender, contract, channel string) *MsgUnsubscribeChannelAcks {
+	return &MsgUnsubscribeChannelAcks{
+		Sender:   sender,
+		Contract: contract,
+		Channel:  channel,
+	}
+}
+
+func (m MsgUnsubscribeChannelAcks) Route() string { return RouterKey }
+func (m MsgUnsubscribeChannelAcks) Type() string  { return TypeMsgUnsubscribeChannelAcks }
+func (m MsgUnsubscribeChannelAcks) ValidateBasic() error {
+	return validateAckSubscriptionMsg(m.Sender, m.Contract, m.Channel)
+}
+
+func (m MsgUnsubscribeChannelAcks) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgUnsubscribeChannelAcks) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgAddPrivilegedContract creates a msg to make a contract privileged
+func NewMsgAddPrivilegedContract(sender, contract string) *MsgAddPrivilegedContract {
+	return &MsgAddPrivilegedContract{
+		Sender:   sender,
+		Contract: contract,
+	}
+}
+
+func (m MsgAddPrivilegedContract) Route() string { return RouterKey }
+func (m MsgAddPrivilegedContract) Type() string  { return TypeMsgAddPrivilegedContract }
+func (m MsgAddPrivilegedContract) ValidateBasic() error {
+	return validatePrivilegedContractMsg(m.Sender, m.Contract)
+}
+
+func (m MsgAddPrivilegedContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgAddPrivilegedContract) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgRemovePrivilegedContract creates a msg to make a privileged contract bounded by the limits of the other
+// contracts again
+func NewMsgRemovePrivilegedContract(sender, contract string) *MsgRemovePrivilegedContract {
+	return &MsgRemovePrivilegedContract{
+		Sender:   sender,
+		Contract: contract,
+	}
+}
+
+func (m MsgRemovePrivilegedContract) Route() string { return RouterKey }
+func (m MsgRemovePrivilegedContract) Type() string  { return TypeMsgRemovePrivilegedContract }
+func (m MsgRemovePrivilegedContract) ValidateBasic() error {
+	return validatePrivilegedContractMsg(m.Sender, m.Contract)
+}
+
+func (m MsgRemovePrivilegedContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgRemovePrivilegedContract) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+func validatePrivilegedContractMsg(sender, contract string) error {
+	_, err := sdk.AccAddressFromBech32(sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	_, err = sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid contract address (%s)", err)
+	}
+
+	return nil
+}
+
+func validateAckSubscriptionMsg(sender, contract, channel string) error {
+	_, err := sdk.AccAddressFromBech32(sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+	_, err = sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid contract address (%s)", err)
+	}
+	if !channeltypes.IsValidChannelID(channel) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid channel id %s", channel)
+	}
+
+	return nil
+}
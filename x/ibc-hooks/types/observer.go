@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HookExecutedNotification is the summary of the execution of a hooked packet that the observer contract is notified of
+type HookExecutedNotification struct {
+	Port      string
+	Channel   string
+	Sequence  uint64
+	Contract  string
+	Funds     sdk.Coin
+	Success   bool
+	ErrorCode uint32
+}
+
+// SudoMsg returns the hook_executed sudo message sent to the observer contract
+func (n HookExecutedNotification) SudoMsg() []byte {
+	return []byte(fmt.Sprintf(
+		`{"hook_executed": {"channel": "%s", "sequence": %d, "contract": "%s", "denom": "%s", "amount": "%s", "success": %t, "error_code": %d}}`,
+		n.Channel, n.Sequence, n.Contract, n.Funds.Denom, n.Funds.Amount, n.Success, n.ErrorCode))
+}
+
+// AckNotification is the summary of the ack of a packet sent on a channel that the contracts subscribed to the acks
+// of the channel are notified of
+type AckNotification struct {
+	Channel  string `json:"channel"`
+	Sequence uint64 `json:"sequence"`
+	// Sender, Receiver, Denom and Amount are those of the ICS20 packet, empty for other packets. They come from the
+	// packet as it was sent.
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	// Success is whether the ack was classified as a success
+	Success bool `json:"success"`
+}
+
+// SudoMsg returns the channel_ack sudo message sent to the subscribed contracts. It doesn't hold the ack, which
+// can be large.
+func (n AckNotification) SudoMsg() []byte {
+	bz, _ := json.Marshal(map[string]AckNotification{"channel_ack": n})
+	return bz
+}
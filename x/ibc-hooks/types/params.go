@@ -0,0 +1,574 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+)
+
+// MaxHookedPacketsPerBlockUpperBound is the highest non-zero value of the max_hooked_packets_per_block param.
+// A limit above it is more than a block can fit, so it would never throttle anything.
+const MaxHookedPacketsPerBlockUpperBound uint64 = 10_000
+
+const (
+	// DefaultMaxExpiredCallbacksPerBlock is the default value of the max_expired_callbacks_per_block param
+	DefaultMaxExpiredCallbacksPerBlock uint64 = 100
+	// MaxExpiredCallbacksPerBlockUpperBound is the highest value of the max_expired_callbacks_per_block param.
+	// Each expired callback can cost a contract call when the notifications are enabled.
+	MaxExpiredCallbacksPerBlockUpperBound uint64 = 1_000
+)
+
+// Parameter store keys.
+var (
+	KeyObserverContract         = []byte("ObserverContract")
+	KeyObservedChannels         = []byte("ObservedChannels")
+	KeyAllowedHookDenoms        = []byte("AllowedHookDenoms")
+	KeyMaxHookedPacketsPerBlock = []byte("MaxHookedPacketsPerBlock")
+	KeyAckClassifiers           = []byte("AckClassifiers")
+	KeyMaxExpiredCallbacks      = []byte("MaxExpiredCallbacksPerBlock")
+	KeyNotifyExpiredCallbacks   = []byte("NotifyExpiredCallbacks")
+	KeyCallbackAuthority        = []byte("CallbackAuthority")
+	KeyAckSubscriptionFee       = []byte("AckSubscriptionFee")
+	KeyMinCallbackTimeout       = []byte("MinCallbackTimeout")
+	KeyMaxCallbackTimeout       = []byte("MaxCallbackTimeout")
+	KeyHookFeesEnabled          = []byte("HookFeesEnabled")
+	KeyHookFeeDenoms            = []byte("HookFeeDenoms")
+	KeyLegacyReceiverMemo       = []byte("LegacyReceiverMemoEnabled")
+	KeyAckTransferEnabled       = []byte("AckTransferEnabled")
+	// the limits of the hooked packets to unprivileged and privileged contracts
+	KeyMaxHookGas                   = []byte("MaxHookGas")
+	KeyPrivilegedMaxHookGas         = []byte("PrivilegedMaxHookGas")
+	KeyMaxHookAmounts               = []byte("MaxHookAmounts")
+	KeyPrivilegedContractsAuthority = []byte("PrivilegedContractsAuthority")
+
+	_ paramtypes.ParamSet = &Params{}
+)
+
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func NewParams(observerContract string, observedChannels []string, allowedHookDenoms []string, maxHookedPacketsPerBlock uint64,
+	ackClassifiers []ChannelAckClassifier, maxExpiredCallbacksPerBlock uint64, notifyExpiredCallbacks bool, callbackAuthority string,
+	ackSubscriptionFee sdk.Coins, minCallbackTimeout, maxCallbackTimeout time.Duration, hookFeesEnabled bool, hookFeeDenoms []string,
+	legacyReceiverMemoEnabled bool, ackTransferEnabled bool, maxHookGas, privilegedMaxHookGas uint64, maxHookAmounts sdk.Coins,
+	privilegedContractsAuthority string,
+) Params {
+	return Params{
+		ObserverContract:            observerContract,
+		ObservedChannels:            observedChannels,
+		AllowedHookDenoms:           allowedHookDenoms,
+		MaxHookedPacketsPerBlock:    maxHookedPacketsPerBlock,
+		AckClassifiers:              ackClassifiers,
+		MaxExpiredCallbacksPerBlock: maxExpiredCallbacksPerBlock,
+		NotifyExpiredCallbacks:      notifyExpiredCallbacks,
+		CallbackAuthority:           callbackAuthority,
+		AckSubscriptionFee:          ackSubscriptionFee,
+		MinCallbackTimeout:          minCallbackTimeout,
+		MaxCallbackTimeout:          maxCallbackTimeout,
+		HookFeesEnabled:             hookFeesEnabled,
+		HookFeeDenoms:               hookFeeDenoms,
+		LegacyReceiverMemoEnabled:   legacyReceiverMemoEnabled,
+		AckTransferEnabled:          ackTransferEnabled,
+		// the limits of the hooked packets to unprivileged and privileged contracts
+		MaxHookGas:                   maxHookGas,
+		PrivilegedMaxHookGas:         privilegedMaxHookGas,
+		MaxHookAmounts:               maxHookAmounts,
+		PrivilegedContractsAuthority: privilegedContractsAuthority,
+	}
+}
+
+// default ibc-hooks module parameters.
+func DefaultParams() Params {
+	return Params{
+		ObserverContract:  "",
+		ObservedChannels:  []string{},
+		AllowedHookDenoms: []string{},
+		// no limit
+		MaxHookedPacketsPerBlock:    0,
+		AckClassifiers:              []ChannelAckClassifier{},
+		MaxExpiredCallbacksPerBlock: DefaultMaxExpiredCallbacksPerBlock,
+		NotifyExpiredCallbacks:      false,
+		// the forced callback messages are disabled until governance sets an authority
+		CallbackAuthority: "",
+		// contracts can't subscribe themselves to the acks of a channel until governance sets a fee
+		AckSubscriptionFee: sdk.Coins{},
+		// callbacks are only rejected on packets that already timed out
+		MinCallbackTimeout: 0,
+		// no bound
+		MaxCallbackTimeout: 0,
+		// the memos can't pay the relayers out of the received funds until governance enables it
+		HookFeesEnabled: false,
+		HookFeeDenoms:   []string{},
+		// the memos are only read from the memo field
+		LegacyReceiverMemoEnabled: false,
+		// the memos can't ask for the hook results to be sent back until governance enables it
+		AckTransferEnabled: false,
+		// the executions are only bounded by the gas of the relayers' transactions
+		MaxHookGas:           0,
+		PrivilegedMaxHookGas: 0,
+		// no denom is capped
+		MaxHookAmounts: sdk.Coins{},
+		// no contract can be made privileged until governance sets an authority
+		PrivilegedContractsAuthority: "",
+	}
+}
+
+// validate params.
+func (p Params) Validate() error {
+	if err := validateObserverContract(p.ObserverContract); err != nil {
+		return err
+	}
+	if err := validateObservedChannels(p.ObservedChannels); err != nil {
+		return err
+	}
+	if err := validateAllowedHookDenoms(p.AllowedHookDenoms); err != nil {
+		return err
+	}
+	if err := validateMaxHookedPacketsPerBlock(p.MaxHookedPacketsPerBlock); err != nil {
+		return err
+	}
+	if err := validateAckClassifiers(p.AckClassifiers); err != nil {
+		return err
+	}
+	if err := validateMaxExpiredCallbacksPerBlock(p.MaxExpiredCallbacksPerBlock); err != nil {
+		return err
+	}
+	if err := validateNotifyExpiredCallbacks(p.NotifyExpiredCallbacks); err != nil {
+		return err
+	}
+	if err := validateCallbackAuthority(p.CallbackAuthority); err != nil {
+		return err
+	}
+	if err := validateAckSubscriptionFee(p.AckSubscriptionFee); err != nil {
+		return err
+	}
+	if err := validateCallbackTimeout(p.MinCallbackTimeout); err != nil {
+		return err
+	}
+	if err := validateCallbackTimeout(p.MaxCallbackTimeout); err != nil {
+		return err
+	}
+	if p.MaxCallbackTimeout != 0 && p.MaxCallbackTimeout < p.MinCallbackTimeout {
+		return fmt.Errorf("max callback timeout %s is below the min callback timeout %s", p.MaxCallbackTimeout, p.MinCallbackTimeout)
+	}
+	if err := validateHookFeesEnabled(p.HookFeesEnabled); err != nil {
+		return err
+	}
+	if err := validateHookFeeDenoms(p.HookFeeDenoms); err != nil {
+		return err
+	}
+	if err := validateLegacyReceiverMemoEnabled(p.LegacyReceiverMemoEnabled); err != nil {
+		return err
+	}
+	if err := validateAckTransferEnabled(p.AckTransferEnabled); err != nil {
+		return err
+	}
+	if err := validateHookGas(p.MaxHookGas); err != nil {
+		return err
+	}
+	if err := validateHookGas(p.PrivilegedMaxHookGas); err != nil {
+		return err
+	}
+	// the privileged contracts can't be more constrained than the others
+	if p.PrivilegedMaxHookGas != 0 && (p.MaxHookGas == 0 || p.PrivilegedMaxHookGas < p.MaxHookGas) {
+		return fmt.Errorf("privileged max hook gas %d must be zero or at least the max hook gas %d, which must then be set",
+			p.PrivilegedMaxHookGas, p.MaxHookGas)
+	}
+	if err := validateMaxHookAmounts(p.MaxHookAmounts); err != nil {
+		return err
+	}
+	if err := validatePrivilegedContractsAuthority(p.PrivilegedContractsAuthority); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Implements params.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyObserverContract, &p.ObserverContract, validateObserverContract),
+		paramtypes.NewParamSetPair(KeyObservedChannels, &p.ObservedChannels, validateObservedChannels),
+		paramtypes.NewParamSetPair(KeyAllowedHookDenoms, &p.AllowedHookDenoms, validateAllowedHookDenoms),
+		paramtypes.NewParamSetPair(KeyMaxHookedPacketsPerBlock, &p.MaxHookedPacketsPerBlock, validateMaxHookedPacketsPerBlock),
+		paramtypes.NewParamSetPair(KeyAckClassifiers, &p.AckClassifiers, validateAckClassifiers),
+		paramtypes.NewParamSetPair(KeyMaxExpiredCallbacks, &p.MaxExpiredCallbacksPerBlock, validateMaxExpiredCallbacksPerBlock),
+		paramtypes.NewParamSetPair(KeyNotifyExpiredCallbacks, &p.NotifyExpiredCallbacks, validateNotifyExpiredCallbacks),
+		paramtypes.NewParamSetPair(KeyCallbackAuthority, &p.CallbackAuthority, validateCallbackAuthority),
+		paramtypes.NewParamSetPair(KeyAckSubscriptionFee, &p.AckSubscriptionFee, validateAckSubscriptionFee),
+		paramtypes.NewParamSetPair(KeyMinCallbackTimeout, &p.MinCallbackTimeout, validateCallbackTimeout),
+		paramtypes.NewParamSetPair(KeyMaxCallbackTimeout, &p.MaxCallbackTimeout, validateCallbackTimeout),
+		paramtypes.NewParamSetPair(KeyHookFeesEnabled, &p.HookFeesEnabled, validateHookFeesEnabled),
+		paramtypes.NewParamSetPair(KeyHookFeeDenoms, &p.HookFeeDenoms, validateHookFeeDenoms),
+		paramtypes.NewParamSetPair(KeyLegacyReceiverMemo, &p.LegacyReceiverMemoEnabled, validateLegacyReceiverMemoEnabled),
+		paramtypes.NewParamSetPair(KeyAckTransferEnabled, &p.AckTransferEnabled, validateAckTransferEnabled),
+		paramtypes.NewParamSetPair(KeyMaxHookGas, &p.MaxHookGas, validateHookGas),
+		paramtypes.NewParamSetPair(KeyPrivilegedMaxHookGas, &p.PrivilegedMaxHookGas, validateHookGas),
+		paramtypes.NewParamSetPair(KeyMaxHookAmounts, &p.MaxHookAmounts, validateMaxHookAmounts),
+		paramtypes.NewParamSetPair(KeyPrivilegedContractsAuthority, &p.PrivilegedContractsAuthority, validatePrivilegedContractsAuthority),
+	}
+}
+
+// IsObservedChannel returns true if the observer contract is notified of the hooked packets received on channel.
+func (p Params) IsObservedChannel(channel string) bool {
+	if len(p.ObservedChannels) == 0 {
+		return true
+	}
+	for _, observedChannel := range p.ObservedChannels {
+		if observedChannel == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowedHookDenom returns true if packets of the local denom can be routed into contracts.
+func (p Params) IsAllowedHookDenom(denom string) bool {
+	if len(p.AllowedHookDenoms) == 0 {
+		return true
+	}
+	for _, allowedDenom := range p.AllowedHookDenoms {
+		if allowedDenom == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHookFeeDenom returns true if the memos of hooked packets can pay the relayers a fee in the local denom.
+// No denom can if the hook fees are disabled.
+func (p Params) IsHookFeeDenom(denom string) bool {
+	if !p.HookFeesEnabled {
+		return false
+	}
+	for _, feeDenom := range p.HookFeeDenoms {
+		if feeDenom == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCallbackAuthority returns true if sender can force the delivery or the deletion of packet callbacks, and manage
+// the ack subscriptions of any contract. No one can if the callback authority is not set.
+func (p Params) IsCallbackAuthority(sender string) bool {
+	return p.CallbackAuthority != "" && p.CallbackAuthority == sender
+}
+
+// IsPrivilegedContractsAuthority returns true if sender can add and remove the privileged contracts. No one can if
+// the privileged contracts authority is not set.
+func (p Params) IsPrivilegedContractsAuthority(sender string) bool {
+	return p.PrivilegedContractsAuthority != "" && p.PrivilegedContractsAuthority == sender
+}
+
+// GetHookGasLimit returns the gas available to the contract execution of a hooked packet, to a privileged contract
+// or not. Zero means that the execution is only bounded by the gas of the relayer's transaction.
+func (p Params) GetHookGasLimit(privileged bool) uint64 {
+	if privileged {
+		return p.PrivilegedMaxHookGas
+	}
+	return p.MaxHookGas
+}
+
+// GetMaxHookAmount returns the highest amount of the local denom that a hooked packet can route into an
+// unprivileged contract, and false if the denom is not capped.
+func (p Params) GetMaxHookAmount(denom string) (sdk.Int, bool) {
+	maxAmount := p.MaxHookAmounts.AmountOf(denom)
+	return maxAmount, maxAmount.IsPositive()
+}
+
+// ValidateCallbackTimeout returns an error if a packet sent at blockTime with timeoutTimestamp, in nanoseconds since
+// the epoch, times out too soon or too late for a callback to be registered on it. A zero timeoutTimestamp means
+// that the packet has no timeout timestamp, and is only accepted if there is no max callback timeout.
+func (p Params) ValidateCallbackTimeout(blockTime time.Time, timeoutTimestamp uint64) error {
+	if timeoutTimestamp == 0 {
+		if p.MaxCallbackTimeout != 0 {
+			return fmt.Errorf("packets with a callback must have a timeout timestamp at most %s after the block time", p.MaxCallbackTimeout)
+		}
+		return nil
+	}
+
+	// timestamps beyond the range of time.Time are far past any max callback timeout
+	if timeoutTimestamp > math.MaxInt64 {
+		timeoutTimestamp = math.MaxInt64
+	}
+	timeout := time.Unix(0, int64(timeoutTimestamp)).Sub(blockTime)
+	if timeout <= 0 {
+		return fmt.Errorf("the packet timed out %s before the block time", -timeout)
+	}
+	if timeout < p.MinCallbackTimeout {
+		return fmt.Errorf("the packet times out %s after the block time, the min callback timeout is %s", timeout, p.MinCallbackTimeout)
+	}
+	if p.MaxCallbackTimeout != 0 && timeout > p.MaxCallbackTimeout {
+		return fmt.Errorf("the packet times out %s after the block time, the max callback timeout is %s", timeout, p.MaxCallbackTimeout)
+	}
+	return nil
+}
+
+// GetAckClassifier returns the classifier of the acks of the packets sent on channel.
+// Channels without an override use AckClassifierDefault.
+func (p Params) GetAckClassifier(channel string) AckClassifier {
+	for _, override := range p.AckClassifiers {
+		if override.Channel == channel {
+			// validated when the params are set
+			classifier, err := ParseAckClassifier(override.Classifier)
+			if err != nil {
+				return AckClassifierDefault
+			}
+			return classifier
+		}
+	}
+	return AckClassifierDefault
+}
+
+func validateObserverContract(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// Empty strings are valid for unsetting the param
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateObservedChannels(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, channel := range v {
+		if !channeltypes.IsValidChannelID(channel) {
+			return fmt.Errorf("invalid observed channel: %s", channel)
+		}
+	}
+
+	return nil
+}
+
+func validateAllowedHookDenoms(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, denom := range v {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMaxHookedPacketsPerBlock accepts zero, which means no limit, or a limit up to MaxHookedPacketsPerBlockUpperBound.
+func validateMaxHookedPacketsPerBlock(i interface{}) error {
+	maxHookedPackets, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if maxHookedPackets > MaxHookedPacketsPerBlockUpperBound {
+		return fmt.Errorf("max hooked packets per block must be at most %d, or zero for no limit, got %d",
+			MaxHookedPacketsPerBlockUpperBound, maxHookedPackets)
+	}
+
+	return nil
+}
+
+// validateAckClassifiers accepts at most one override per channel, with a known classifier other than the default one.
+func validateAckClassifiers(i interface{}) error {
+	v, ok := i.([]ChannelAckClassifier)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	channels := make(map[string]bool, len(v))
+	for _, override := range v {
+		if !channeltypes.IsValidChannelID(override.Channel) {
+			return fmt.Errorf("invalid ack classifier channel: %s", override.Channel)
+		}
+		if channels[override.Channel] {
+			return fmt.Errorf("duplicate ack classifier for channel %s", override.Channel)
+		}
+		channels[override.Channel] = true
+
+		classifier, err := ParseAckClassifier(override.Classifier)
+		if err != nil {
+			return err
+		}
+		if classifier == AckClassifierDefault {
+			return fmt.Errorf("channel %s overrides its ack classifier with the default one, remove the override instead", override.Channel)
+		}
+	}
+
+	return nil
+}
+
+// validateMaxExpiredCallbacksPerBlock accepts a limit between 1 and MaxExpiredCallbacksPerBlockUpperBound.
+// Zero isn't accepted, as the expired callbacks would then never be deleted.
+func validateMaxExpiredCallbacksPerBlock(i interface{}) error {
+	maxExpiredCallbacks, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if maxExpiredCallbacks == 0 || maxExpiredCallbacks > MaxExpiredCallbacksPerBlockUpperBound {
+		return fmt.Errorf("max expired callbacks per block must be between 1 and %d, got %d",
+			MaxExpiredCallbacksPerBlockUpperBound, maxExpiredCallbacks)
+	}
+
+	return nil
+}
+
+func validateNotifyExpiredCallbacks(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateCallbackAuthority(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty callback authority disables the forced callback messages
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return fmt.Errorf("invalid callback authority address (%s): %w", v, err)
+	}
+
+	return nil
+}
+
+// validateAckSubscriptionFee accepts valid coins. Empty coins disable the self-subscriptions to channel acks.
+func validateAckSubscriptionFee(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("invalid ack subscription fee: %w", err)
+	}
+
+	return nil
+}
+
+// validateCallbackTimeout accepts non-negative durations. Zero disables the max callback timeout.
+func validateCallbackTimeout(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("callback timeout must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateHookFeesEnabled(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+// validateHookFeeDenoms accepts valid denoms, each at most once.
+func validateHookFeeDenoms(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	denoms := make(map[string]bool, len(v))
+	for _, denom := range v {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return fmt.Errorf("invalid hook fee denom: %w", err)
+		}
+		if denoms[denom] {
+			return fmt.Errorf("duplicate hook fee denom %s", denom)
+		}
+		denoms[denom] = true
+	}
+
+	return nil
+}
+
+func validateLegacyReceiverMemoEnabled(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateAckTransferEnabled(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+// validateHookGas accepts any gas limit. Zero means that the executions are only bounded by the gas of the
+// relayers' transactions.
+func validateHookGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+// validateMaxHookAmounts accepts valid coins. Empty coins cap no denom.
+func validateMaxHookAmounts(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("invalid max hook amounts: %w", err)
+	}
+
+	return nil
+}
+
+func validatePrivilegedContractsAuthority(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty privileged contracts authority disables the privileged contracts messages
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return fmt.Errorf("invalid privileged contracts authority address (%s): %w", v, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMaxHookedPacketsPerBlock(t *testing.T) {
+	testCases := map[string]struct {
+		maxHookedPackets interface{}
+		expected         bool
+	}{
+		"zero means no limit": {
+			maxHookedPackets: uint64(0),
+			expected:         true,
+		},
+		"one": {
+			maxHookedPackets: uint64(1),
+			expected:         true,
+		},
+		"upper bound": {
+			maxHookedPackets: MaxHookedPacketsPerBlockUpperBound,
+			expected:         true,
+		},
+		"above the upper bound": {
+			maxHookedPackets: MaxHookedPacketsPerBlockUpperBound + 1,
+			expected:         false,
+		},
+		"max uint64": {
+			maxHookedPackets: ^uint64(0),
+			expected:         false,
+		},
+		"invalid parameter type": {
+			maxHookedPackets: 10,
+			expected:         false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateMaxHookedPacketsPerBlock(tc.maxHookedPackets)
+
+			if !tc.expected {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateAckClassifiers(t *testing.T) {
+	testCases := map[string]struct {
+		ackClassifiers interface{}
+		expected       bool
+	}{
+		"no override": {
+			ackClassifiers: []ChannelAckClassifier{},
+			expected:       true,
+		},
+		"overrides": {
+			ackClassifiers: []ChannelAckClassifier{{"channel-0", "result"}, {"channel-1", "json_error"}, {"channel-2", "standard"}},
+			expected:       true,
+		},
+		"duplicate channel": {
+			ackClassifiers: []ChannelAckClassifier{{"channel-0", "result"}, {"channel-0", "standard"}},
+			expected:       false,
+		},
+		"invalid channel": {
+			ackClassifiers: []ChannelAckClassifier{{"not a channel", "result"}},
+			expected:       false,
+		},
+		"unknown classifier": {
+			ackClassifiers: []ChannelAckClassifier{{"channel-0", "unknown"}},
+			expected:       false,
+		},
+		"default classifier": {
+			ackClassifiers: []ChannelAckClassifier{{"channel-0", "default"}},
+			expected:       false,
+		},
+		"invalid parameter type": {
+			ackClassifiers: map[string]string{"channel-0": "result"},
+			expected:       false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateAckClassifiers(tc.ackClassifiers)
+
+			if !tc.expected {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateMaxExpiredCallbacksPerBlock(t *testing.T) {
+	testCases := map[string]struct {
+		maxExpiredCallbacks interface{}
+		expected            bool
+	}{
+		"zero": {
+			maxExpiredCallbacks: uint64(0),
+			expected:            false,
+		},
+		"one": {
+			maxExpiredCallbacks: uint64(1),
+			expected:            true,
+		},
+		"upper bound": {
+			maxExpiredCallbacks: MaxExpiredCallbacksPerBlockUpperBound,
+			expected:            true,
+		},
+		"above the upper bound": {
+			maxExpiredCallbacks: MaxExpiredCallbacksPerBlockUpperBound + 1,
+			expected:            false,
+		},
+		"invalid parameter type": {
+			maxExpiredCallbacks: 10,
+			expected:            false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateMaxExpiredCallbacksPerBlock(tc.maxExpiredCallbacks)
+
+			if !tc.expected {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGetAckClassifier(t *testing.T) {
+	params := NewParams("", nil, nil, 0, []ChannelAckClassifier{{"channel-0", "result"}, {"channel-1", "json_error"}}, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "")
+	require.Equal(t, AckClassifierResult, params.GetAckClassifier("channel-0"))
+	require.Equal(t, AckClassifierJSONError, params.GetAckClassifier("channel-1"))
+	require.Equal(t, AckClassifierDefault, params.GetAckClassifier("channel-2"))
+	require.Equal(t, AckClassifierDefault, DefaultParams().GetAckClassifier("channel-0"))
+}
+
+func TestValidateParams(t *testing.T) {
+	require.NoError(t, DefaultParams().Validate())
+	require.NoError(t, NewParams("", nil, nil, MaxHookedPacketsPerBlockUpperBound, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, MaxHookedPacketsPerBlockUpperBound+1, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, []ChannelAckClassifier{{"channel-0", "unknown"}}, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, MaxExpiredCallbacksPerBlockUpperBound, true, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, 0, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, sdk.AccAddress("authority").String(), nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "not an address", nil, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", sdk.NewCoins(sdk.NewInt64Coin("uosmo", 100)), 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", sdk.Coins{sdk.NewInt64Coin("uosmo", 0)}, 0, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, time.Minute, time.Hour, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, time.Minute, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, -time.Minute, 0, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, time.Hour, time.Minute, false, nil, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, true, []string{"uosmo"}, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, true, []string{"1nvalid"}, false, false, 0, 0, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, true, []string{"uosmo", "uosmo"}, false, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, true, false, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, true, 0, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 100_000, 0, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 100_000, 100_000, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 100_000, 99_999, nil, "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 100_000, nil, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 100)), "").Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, sdk.Coins{sdk.NewInt64Coin("uosmo", 0)}, "").Validate())
+	require.NoError(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, sdk.AccAddress("authority").String()).Validate())
+	require.Error(t, NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false, 0, 0, nil, "not an address").Validate())
+}
+
+func TestValidateCallbackTimeout(t *testing.T) {
+	blockTime := time.Unix(1_700_000_000, 0)
+	timeoutIn := func(d time.Duration) uint64 {
+		return uint64(blockTime.Add(d).UnixNano())
+	}
+	bounded := NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, time.Minute, 24*time.Hour, false, nil, false, false, 0, 0, nil, "")
+	testCases := map[string]struct {
+		params           Params
+		timeoutTimestamp uint64
+		expectErr        bool
+	}{
+		"in range":                           {bounded, timeoutIn(time.Hour), false},
+		"at the min":                         {bounded, timeoutIn(time.Minute), false},
+		"at the max":                         {bounded, timeoutIn(24 * time.Hour), false},
+		"too short":                          {bounded, timeoutIn(time.Second), true},
+		"too long":                           {bounded, timeoutIn(365 * 24 * time.Hour), true},
+		"beyond the range of times":          {bounded, ^uint64(0), true},
+		"no timeout timestamp with a max":    {bounded, 0, true},
+		"already timed out":                  {bounded, timeoutIn(-time.Second), true},
+		"no bounds":                          {DefaultParams(), timeoutIn(365 * 24 * time.Hour), false},
+		"no timeout timestamp without a max": {DefaultParams(), 0, false},
+		"already timed out without bounds":   {DefaultParams(), timeoutIn(-time.Second), true},
+		"timing out at the block time":       {DefaultParams(), timeoutIn(0), true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.params.ValidateCallbackTimeout(blockTime, tc.timeoutTimestamp)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsCallbackAuthority(t *testing.T) {
+	authority := sdk.AccAddress("authority").String()
+	params := NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, authority, nil, 0, 0, false, nil, false, false, 0, 0, nil, "")
+	require.True(t, params.IsCallbackAuthority(authority))
+	require.False(t, params.IsCallbackAuthority(sdk.AccAddress("other").String()))
+	// no one is the authority when it is not set, not even an empty sender
+	require.False(t, DefaultParams().IsCallbackAuthority(""))
+}
+
+func TestIsHookFeeDenom(t *testing.T) {
+	params := NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, true, []string{"uosmo"}, false, false, 0, 0, nil, "")
+	require.True(t, params.IsHookFeeDenom("uosmo"))
+	require.False(t, params.IsHookFeeDenom("uatom"))
+	// the denoms can't pay fees while the fees are disabled
+	params.HookFeesEnabled = false
+	require.False(t, params.IsHookFeeDenom("uosmo"))
+	// no denom can pay fees by default
+	require.False(t, DefaultParams().IsHookFeeDenom("uosmo"))
+}
+
+func TestPrivilegedLimits(t *testing.T) {
+	authority := sdk.AccAddress("authority").String()
+	params := NewParams("", nil, nil, 0, nil, DefaultMaxExpiredCallbacksPerBlock, false, "", nil, 0, 0, false, nil, false, false,
+		100_000, 1_000_000, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 100)), authority)
+	require.True(t, params.IsPrivilegedContractsAuthority(authority))
+	require.False(t, params.IsPrivilegedContractsAuthority(sdk.AccAddress("other").String()))
+	require.Equal(t, uint64(100_000), params.GetHookGasLimit(false))
+	require.Equal(t, uint64(1_000_000), params.GetHookGasLimit(true))
+
+	maxAmount, capped := params.GetMaxHookAmount("uosmo")
+	require.True(t, capped)
+	require.Equal(t, sdk.NewInt(100), maxAmount)
+	_, capped = params.GetMaxHookAmount("uatom")
+	require.False(t, capped)
+
+	// nothing is limited by default, and no one is the authority, not even an empty sender
+	require.False(t, DefaultParams().IsPrivilegedContractsAuthority(""))
+	require.Zero(t, DefaultParams().GetHookGasLimit(false))
+	_, capped = DefaultParams().GetMaxHookAmount("uosmo")
+	require.False(t, capped)
+}
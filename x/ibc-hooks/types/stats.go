@@ -0,0 +1,23 @@
+package types
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TopDenoms returns the n coins with the largest amounts. Coins with the same amount are ranked by denom.
+func TopDenoms(coins sdk.Coins, n int) sdk.Coins {
+	if len(coins) <= n {
+		return coins
+	}
+	ranked := make(sdk.Coins, len(coins))
+	copy(ranked, coins)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if !ranked[i].Amount.Equal(ranked[j].Amount) {
+			return ranked[i].Amount.GT(ranked[j].Amount)
+		}
+		return ranked[i].Denom < ranked[j].Denom
+	})
+	return sdk.NewCoins(ranked[:n]...)
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopDenoms(t *testing.T) {
+	testCases := map[string]struct {
+		coins    sdk.Coins
+		n        int
+		expected sdk.Coins
+	}{
+		"fewer denoms than n": {
+			coins:    sdk.NewCoins(sdk.NewInt64Coin("uaa", 1), sdk.NewInt64Coin("ubb", 2)),
+			n:        3,
+			expected: sdk.NewCoins(sdk.NewInt64Coin("uaa", 1), sdk.NewInt64Coin("ubb", 2)),
+		},
+		"keeps the largest amounts": {
+			coins:    sdk.NewCoins(sdk.NewInt64Coin("uaa", 5), sdk.NewInt64Coin("ubb", 1), sdk.NewInt64Coin("ucc", 3)),
+			n:        2,
+			expected: sdk.NewCoins(sdk.NewInt64Coin("uaa", 5), sdk.NewInt64Coin("ucc", 3)),
+		},
+		"ties are ranked by denom": {
+			coins:    sdk.NewCoins(sdk.NewInt64Coin("uaa", 2), sdk.NewInt64Coin("ubb", 2), sdk.NewInt64Coin("ucc", 2)),
+			n:        2,
+			expected: sdk.NewCoins(sdk.NewInt64Coin("uaa", 2), sdk.NewInt64Coin("ubb", 2)),
+		},
+		"no coins": {
+			coins:    sdk.NewCoins(),
+			n:        2,
+			expected: sdk.NewCoins(),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, TopDenoms(tc.coins, tc.n))
+		})
+	}
+}
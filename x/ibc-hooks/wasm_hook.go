@@ -1,10 +1,20 @@
 package ibc_hooks
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
-	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/keeper"
@@ -14,6 +24,7 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/osmoutils"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
 	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/exported"
@@ -26,23 +37,118 @@ type ContractAck struct {
 	IbcAck         []byte `json:"ibc_ack"`
 }
 
+var _ types.CallbackDeliverer = WasmHooks{}
+
 type WasmHooks struct {
-	ContractKeeper *wasmkeeper.PermissionedKeeper
+	ContractKeeper types.ContractExecutor
+	// TransferKeeper sends the return transfers of the hook results. It is optional: without it, the hooked packets
+	// asking for one fail.
+	TransferKeeper types.TransferKeeper
 	ibcHooksKeeper *keeper.Keeper
+	accountKeeper  osmoutils.AccountKeeper
+	bankKeeper     types.BankKeeper
 }
 
-func NewWasmHooks(ibcHooksKeeper *keeper.Keeper, contractKeeper *wasmkeeper.PermissionedKeeper) WasmHooks {
+func NewWasmHooks(ibcHooksKeeper *keeper.Keeper, contractKeeper types.ContractExecutor, accountKeeper osmoutils.AccountKeeper, bankKeeper types.BankKeeper) WasmHooks {
 	return WasmHooks{
 		ContractKeeper: contractKeeper,
 		ibcHooksKeeper: ibcHooksKeeper,
+		accountKeeper:  accountKeeper,
+		bankKeeper:     bankKeeper,
 	}
 }
 
 func (h WasmHooks) ProperlyConfigured() bool {
-	return h.ContractKeeper != nil && h.ibcHooksKeeper != nil
+	return h.ContractKeeper != nil && h.ibcHooksKeeper != nil && h.accountKeeper != nil && h.bankKeeper != nil
+}
+
+// PostTransfer is where the hook forwards the funds left on the intermediate sender after a successful
+// contract execution. To is nil if the memo didn't ask for it.
+type PostTransfer struct {
+	To sdk.AccAddress
+	// Denom is the only denom forwarded. If it is empty, the packet's denom and every denom the intermediate
+	// sender received during the execution are forwarded.
+	Denom string
+}
+
+// AckTransferAmount is the amount of the received denom sent back by the return transfer of a hook result. It is
+// paid out of the received funds.
+var AckTransferAmount = sdk.OneInt()
+
+// AckTransferTimeout is how long after the execution the return transfer of a hook result times out.
+const AckTransferTimeout = 10 * time.Minute
+
+// AckTransfer is where the hook sends the result of a successful contract execution back to, in the memo of a
+// transfer of AckTransferAmount of the received denom. Channel is empty if the memo didn't ask for it.
+type AckTransfer struct {
+	// Channel is the local channel the return transfer is sent on
+	Channel string
+	// To is the address of the receiver on the counterparty chain, which isn't validated
+	To string
+}
+
+// ParsedMemo is the wasm routed memo of a received packet, as parsed by ValidateAndParseMemo
+type ParsedMemo struct {
+	// Contract is the contract executed with Msg, which is also the packet's receiver
+	Contract sdk.AccAddress
+	Msg      []byte
+	// MinAmount, FundsAmount and FeeFromFunds are nil unless the memo sets them
+	MinAmount    sdk.Int
+	FundsAmount  sdk.Int
+	FeeFromFunds sdk.Int
+	PostTransfer PostTransfer
+	// DeferOnTransferFailure asks for the packet to be retried later instead of failing when the transfer fails
+	DeferOnTransferFailure bool
+	AckTransfer            AckTransfer
+}
+
+// AckTransferMemo is the memo of the return transfer of a hook result. Its only key isn't "wasm", so that the
+// counterparty's hooks don't route the return transfer into a contract.
+type AckTransferMemo struct {
+	HookResult HookResult `json:"ibc_hook_result"`
+}
+
+// HookResult summarizes the execution of the hooked packet received on Channel with Sequence
+type HookResult struct {
+	Channel  string `json:"channel"`
+	Sequence string `json:"sequence"`
+	Contract string `json:"contract"`
+	Success  bool   `json:"success"`
+	// ResultHash is the hex encoded SHA-256 hash of the data the contract returned
+	ResultHash string `json:"result_hash"`
 }
 
 func (h WasmHooks) OnRecvPacketOverride(im IBCMiddleware, ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	// A redelivered hooked packet gets the ack it was processed with, without its hook being executed again.
+	// A redelivered packet whose receive is still deferred gets no ack yet, as it is written by the retries.
+	if h.ProperlyConfigured() {
+		if ack, found := h.ibcHooksKeeper.GetRedeliveredPacketAck(ctx, packet); found {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.TypeEvtPacketRedelivered,
+				sdk.NewAttribute(types.AttributeChannel, packet.GetDestChannel()),
+				sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+			))
+			return ack
+		}
+		if deferred, found := h.ibcHooksKeeper.GetDeferredRecv(ctx, packet.GetDestChannel(), packet.GetSequence()); found &&
+			bytes.Equal(deferred.Packet.GetData(), packet.GetData()) {
+			return nil
+		}
+	}
+
+	ack := h.onRecvPacket(im, ctx, packet, relayer, true)
+	if ack == nil {
+		// The ICS20 receive failed and the memo asked for it to be retried. The ack is written asynchronously,
+		// once a retry succeeds or the attempts are exhausted.
+		h.ibcHooksKeeper.DeferRecv(ctx, packet, relayer)
+	}
+	return ack
+}
+
+// onRecvPacket receives packet, executing its hook if it has one. If deferrable is true and the memo of the
+// hooked packet set defer_on_transfer_failure, a failed ICS20 receive returns a nil ack instead of its error ack,
+// and its state changes are discarded, so that the receive can be retried.
+func (h WasmHooks) onRecvPacket(im IBCMiddleware, ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress, deferrable bool) (ack ibcexported.Acknowledgement) {
 	if !h.ProperlyConfigured() {
 		// Not configured
 		return im.App.OnRecvPacket(ctx, packet, relayer)
@@ -53,74 +159,529 @@ func (h WasmHooks) OnRecvPacketOverride(im IBCMiddleware, ctx sdk.Context, packe
 		return im.App.OnRecvPacket(ctx, packet, relayer)
 	}
 
+	params := h.ibcHooksKeeper.GetParams(ctx)
+
+	// The counterparties without the memo field put it in the receiver instead. If the legacy receiver memo is
+	// enabled, a packet without a memo whose receiver holds one is handled as if it had been sent with it.
+	memo, receiver := data.GetMemo(), data.Receiver
+	isLegacy, legacyErr := false, error(nil)
+	if params.LegacyReceiverMemoEnabled && normalizeMemo(memo) == "" {
+		if legacy, legacyMemo, legacyReceiver, err := ParseLegacyReceiverMemo(data.Receiver); legacy {
+			isLegacy, legacyErr, memo, receiver = true, err, legacyMemo, legacyReceiver
+		}
+	}
+
+	// Outside of consensus, in the simulations of relayed packets, the memos too large or too deeply nested to be
+	// worth unmarshalling are rejected before being parsed
+	if ctx.IsCheckTx() {
+		if err := types.QuickRejectMemo(memo, types.DefaultMemoLimits); err != nil {
+			return channeltypes.NewErrorAcknowledgement(types.ErrorAckMessage(types.FailureInvalidMemo, err))
+		}
+	}
+
 	// Validate the memo
-	isWasmRouted, contractAddr, msgBytes, err := ValidateAndParseMemo(data.GetMemo(), data.Receiver)
+	isWasmRouted, parsed, err := ValidateAndParseMemo(memo, receiver, ctx.ChainID())
+	if legacyErr != nil {
+		isWasmRouted, err = true, legacyErr
+	}
 	if !isWasmRouted {
 		return im.App.OnRecvPacket(ctx, packet, relayer)
 	}
+	// The malformed legacy receivers are only rejected, the deprecation event is for the legacy memos that parse
+	if isLegacy && legacyErr == nil {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtLegacyReceiverMemo,
+			sdk.NewAttribute(types.AttributeChannel, packet.GetDestChannel()),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+			sdk.NewAttribute(types.AttributeSender, data.Sender),
+		))
+	}
+
+	// The packet's denom is the denom in the sender chain. This needs to be converted to the local denom.
+	denom := osmoutils.MustExtractDenomFromPacketOnRecv(packet)
+
+	// Every wasm routed packet is counted in the stats of its destination channel, whether it was executed or
+	// rejected. Only the funds of the executed packets are counted as routed. Deferred packets are counted once
+	// they are acknowledged. The packet, as it was received, is recorded along with its ack to deduplicate its
+	// redeliveries. The hooks of other modules are then notified of the packet's outcome.
+	var routed sdk.Coins
+	received := packet
+	defer func() {
+		if ack != nil {
+			h.ibcHooksKeeper.RecordHookedPacket(ctx, packet, routed, ack.Success())
+			h.ibcHooksKeeper.RecordProcessedPacket(ctx, received, ack)
+			h.ibcHooksKeeper.AfterHookExecution(ctx, types.HookedPacketInfo{
+				Channel:  packet.GetDestChannel(),
+				Sequence: packet.GetSequence(),
+				Sender:   data.Sender,
+				Contract: parsed.Contract.String(),
+				Denom:    denom,
+				Amount:   data.Amount,
+			}, types.HookExecutionResult{Success: ack.Success(), Funds: routed, Ack: ack.Acknowledgement()})
+		}
+	}()
+
+	// Only the allowed denoms are routed into contracts. Other packets are rejected so that they get refunded
+	// on the sender chain, as receiving them as plain transfers would leave the funds on the receiver (usually
+	// the contract itself) without it being executed. This is checked first, as it doesn't depend on the memo.
+	if !params.IsAllowedHookDenom(denom) {
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureDenomNotAllowed, fmt.Sprintf(types.ErrDenomNotAllowed, denom))
+	}
+
 	if err != nil {
-		return channeltypes.NewErrorAcknowledgement(err.Error())
+		return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureInvalidMemo, err)
+	}
+	if parsed.Msg == nil || parsed.Contract == nil { // This should never happen
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureInvalidMemo, "error in wasmhook message validation")
+	}
+
+	// A memo can only pay the relayer out of the received funds if the hook fees are enabled for the denom.
+	// Otherwise, the packet is rejected before the funds are received, so that they get refunded on the sender chain.
+	if !parsed.FeeFromFunds.IsNil() && !params.IsHookFeeDenom(denom) {
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureHookFeeNotAllowed, fmt.Sprintf(types.ErrHookFeeNotAllowed, denom))
+	}
+
+	// Likewise, a memo can only ask for the result to be sent back if the return transfers are enabled
+	if parsed.AckTransfer.Channel != "" && !params.AckTransferEnabled {
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureAckTransferNotAllowed, types.ErrAckTransferNotAllowed)
+	}
+
+	// The privileged contracts, such as the protocol's own contracts, are exempt from the per block limit and the
+	// max hook amounts, and their executions are bounded by a higher gas limit
+	privileged := h.ibcHooksKeeper.IsPrivilegedContract(ctx, parsed.Contract.String())
+
+	// Hooked packets of amounts over the max hook amount of their denom are rejected before the funds are received,
+	// so that they get refunded on the sender chain. An invalid amount is left to the receive to reject.
+	if maxAmount, capped := params.GetMaxHookAmount(denom); capped && !privileged {
+		if amount, ok := sdk.NewIntFromString(data.GetAmount()); ok && amount.GT(maxAmount) {
+			return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureHookAmountExceeded, fmt.Sprintf(types.ErrHookAmountExceeded, amount, maxAmount, denom))
+		}
 	}
-	if msgBytes == nil || contractAddr == nil { // This should never happen
-		return channeltypes.NewErrorAcknowledgement("error in wasmhook message validation")
+
+	// Hooked packets over the per block limit are rejected before the funds are received, so that they get
+	// refunded on the sender chain and can be sent again later.
+	// The packets to the privileged contracts are counted too, although they are never throttled. The packets that
+	// get an error ack aren't, as their state changes are reverted.
+	if !privileged && params.MaxHookedPacketsPerBlock > 0 &&
+		h.ibcHooksKeeper.GetBlockHookedPacketCount(ctx) >= params.MaxHookedPacketsPerBlock {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtHookedPacketThrottled,
+			sdk.NewAttribute(types.AttributeChannel, packet.GetDestChannel()),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+			sdk.NewAttribute(types.AttributeContract, parsed.Contract.String()),
+		))
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureThrottled, fmt.Sprintf(types.ErrThrottled, params.MaxHookedPacketsPerBlock))
+	}
+	h.ibcHooksKeeper.IncrementBlockHookedPacketCount(ctx)
+
+	// Contracts that opted in to per block serialization only get the first hooked packet of each block.
+	// Later packets are rejected before the funds are received so that they get refunded on the sender chain.
+	if h.ibcHooksKeeper.IsSerializedPerBlock(ctx, parsed.Contract.String()) &&
+		h.ibcHooksKeeper.GetHookedPacketCount(ctx, parsed.Contract.String()) > 0 {
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureSerializedPerBlock, fmt.Sprintf(types.ErrSerializedPerBlock, parsed.Contract.String()))
 	}
+	h.ibcHooksKeeper.IncrementHookedPacketCount(ctx, parsed.Contract.String())
 
-	// The funds sent on this packet need to be transferred to the wasm hooks module address/
-	// For this, we override the ICS20 packet's Receiver (essentially hijacking the funds for the module)
-	// and execute the underlying OnRecvPacket() call (which should eventually land on the transfer app's
-	// relay.go and send the sunds to the module.
+	// The funds sent on this packet need to be transferred to the intermediate sender derived from the
+	// packet's sender. For this, we override the ICS20 packet's Receiver (essentially hijacking the funds
+	// for the intermediate sender) and execute the underlying OnRecvPacket() call (which should eventually
+	// land on the transfer app's relay.go and send the funds to the intermediate sender.
 	//
 	// If that succeeds, we make the contract call
-	data.Receiver = WasmHookModuleAccountAddr.String()
+	intermediateSender := DeriveIntermediateSender(packet.GetDestChannel(), data.GetSender())
+	if err := h.ensureIntermediateSender(ctx, intermediateSender); err != nil {
+		return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureIntermediateSender, err)
+	}
+	data.Receiver = intermediateSender.String()
 	bz, err := json.Marshal(data)
 	if err != nil {
-		return channeltypes.NewErrorAcknowledgement(fmt.Sprintf("cannot marshal the ICS20 packet: %s", err.Error()))
+		return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureBadPacket, err)
 	}
-	packet.Data = bz
+	// The transfer app receives the packet with the rewritten receiver, while the failures are logged with the
+	// fingerprint of the packet as it was relayed
+	transferPacket := packet
+	transferPacket.Data = bz
 
-	// Execute the receive
-	ack := im.App.OnRecvPacket(ctx, packet, relayer)
+	// Execute the receive. Its state changes are only kept if it succeeds, so that a deferred receive is retried
+	// from a clean state. The error ack of the transfer app holds the text of its error, so it is only logged and
+	// replaced with a fixed one.
+	transferCtx, writeTransfer := ctx.CacheContext()
+	ack = im.App.OnRecvPacket(transferCtx, transferPacket, relayer)
 	if !ack.Success() {
-		return ack
+		h.logHookFailure(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureTransfer, string(ack.Acknowledgement()))
+		if deferrable && parsed.DeferOnTransferFailure {
+			return nil
+		}
+		return channeltypes.NewErrorAcknowledgement(types.ErrorAckMessage(types.FailureTransfer, nil))
 	}
+	writeTransfer()
+	ctx.EventManager().EmitEvents(transferCtx.EventManager().Events())
 
 	amount, ok := sdk.NewIntFromString(data.GetAmount())
 	if !ok {
 		// This should never happen, as it should've been caught in the underlaying call to OnRecvPacket,
 		// but returning here for completeness
-		return channeltypes.NewErrorAcknowledgement("Invalid packet data: Amount is not an int")
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureBadPacket, "Invalid packet data: Amount is not an int")
 	}
 
-	// The packet's denom is the denom in the sender chain. This needs to be converted to the local denom.
-	denom := osmoutils.MustExtractDenomFromPacketOnRecv(packet)
-	funds := sdk.NewCoins(sdk.NewCoin(denom, amount))
+	// If the sender set a minimum amount, the contract is only executed if at least that much was received.
+	// Otherwise, the error ack makes the receive be reverted and the funds refunded on the sender chain.
+	if !parsed.MinAmount.IsNil() && amount.LT(parsed.MinAmount) {
+		return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureMinAmountNotMet, fmt.Sprintf(types.ErrMinAmountNotMet, amount, parsed.MinAmount))
+	}
+
+	// If the sender set a fee, it is paid to the relayer out of the received funds before the execution, and the
+	// rest of the funds is what the execution gets. The error ack of a failed hook reverts the payment along with
+	// the receive, so the sender is refunded in full.
+	available := amount
+	if !parsed.FeeFromFunds.IsNil() {
+		if parsed.FeeFromFunds.GT(amount) {
+			return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureHookFeeTooHigh, fmt.Sprintf(types.ErrHookFeeExceeded, parsed.FeeFromFunds, amount))
+		}
+		if err := h.payHookFee(ctx, parsed.Contract, intermediateSender, relayer, sdk.NewCoin(denom, parsed.FeeFromFunds)); err != nil {
+			return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureHookFee, err)
+		}
+		available = amount.Sub(parsed.FeeFromFunds)
+	}
+
+	// If the sender asked for the result to be sent back, the amount of the return transfer is kept on the
+	// intermediate sender out of the rest of the funds
+	if parsed.AckTransfer.Channel != "" {
+		if available.LT(AckTransferAmount) {
+			return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureAckTransferFunds, fmt.Sprintf(types.ErrAckTransferFunds, amount, AckTransferAmount))
+		}
+		available = available.Sub(AckTransferAmount)
+	}
+
+	// If the sender set a funds amount, only that much is attached to the execution. The remainder stays on the
+	// intermediate sender, from which it is forwarded along with the contract's output if post_transfer_to is set.
+	// A funds amount above the received amount, less the fee and the return transfer amount, makes the receive be
+	// reverted and the funds refunded.
+	fundsCoin := sdk.NewCoin(denom, available)
+	if !parsed.FundsAmount.IsNil() {
+		if parsed.FundsAmount.GT(available) {
+			if !parsed.FeeFromFunds.IsNil() {
+				return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureFundsAmountTooHigh, fmt.Sprintf(types.ErrFundsAmountAfterFee, parsed.FundsAmount, amount, parsed.FeeFromFunds))
+			}
+			return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureFundsAmountTooHigh, fmt.Sprintf(types.ErrFundsAmountExceeded, parsed.FundsAmount, amount))
+		}
+		fundsCoin = sdk.NewCoin(denom, parsed.FundsAmount)
+	}
+	funds := sdk.NewCoins(fundsCoin)
 
 	execMsg := wasmtypes.MsgExecuteContract{
-		Sender:   WasmHookModuleAccountAddr.String(),
-		Contract: contractAddr.String(),
-		Msg:      msgBytes,
+		Sender:   intermediateSender.String(),
+		Contract: parsed.Contract.String(),
+		Msg:      parsed.Msg,
 		Funds:    funds,
 	}
-	response, err := h.execWasmMsg(ctx, &execMsg)
+	balancesBeforeExec := h.bankKeeper.GetAllBalances(ctx, intermediateSender)
+	response, err := h.execHookedWasmMsg(ctx, &execMsg, params.GetHookGasLimit(privileged))
+	if err == nil && parsed.AckTransfer.Channel != "" {
+		// The return transfer is sent before the post transfer, which could forward its amount. If it fails, the
+		// execution is reverted along with the packet.
+		err = h.sendAckTransfer(ctx, packet, parsed.Contract, intermediateSender, parsed.AckTransfer, denom, response.Data)
+	}
+	if err == nil && parsed.PostTransfer.To != nil {
+		// The forwarding is part of the hook: if it fails, the execution is reverted along with the packet.
+		err = h.forwardPostTransfer(ctx, parsed.Contract, intermediateSender, parsed.PostTransfer, denom, balancesBeforeExec)
+	}
+	h.notifyObserver(ctx, packet, parsed.Contract, fundsCoin, err)
 	if err != nil {
-		return channeltypes.NewErrorAcknowledgement(err.Error())
+		// A contract rejecting the packet gets its reason in the error ack instead of the wasmd error text. The
+		// reason is the contract's own output, so every validator parses the same one.
+		if reason, rejected := ParseHookRejection(err); rejected {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.TypeEvtHookedPacketRejected,
+				sdk.NewAttribute(types.AttributeChannel, packet.GetDestChannel()),
+				sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+				sdk.NewAttribute(types.AttributeContract, parsed.Contract.String()),
+				sdk.NewAttribute(types.AttributeReason, reason),
+			))
+			return h.failHookedPacket(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureRejectedByContract, fmt.Sprintf(types.ErrRejectedByContract, reason))
+		}
+		var outOfGas hookOutOfGasError
+		if errors.As(err, &outOfGas) {
+			return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureHookOutOfGas, types.WrapAckError(err, types.ErrHookOutOfGas, outOfGas.gasLimit))
+		}
+		return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureExecution, err)
 	}
 
 	fullAck := ContractAck{ContractResult: response.Data, IbcAck: ack.Acknowledgement()}
 	bz, err = json.Marshal(fullAck)
 	if err != nil {
-		return channeltypes.NewErrorAcknowledgement(fmt.Sprintf(types.ErrBadResponse, err.Error()))
+		return h.failHookedPacketWithError(ctx, packet, parsed.Contract, denom, data.Amount, types.FailureBadResponse, err)
 	}
 
+	routed = funds
 	return channeltypes.NewResultAcknowledgement(bz)
 }
 
+// failHookedPacket logs the failure of a received hooked packet, and returns its error ack with msg. msg must only
+// be built from fixed text, the packet and the params, as the ack is part of the state.
+func (h WasmHooks) failHookedPacket(ctx sdk.Context, packet channeltypes.Packet, contract sdk.AccAddress, denom, amount, code, msg string) ibcexported.Acknowledgement {
+	h.logHookFailure(ctx, packet, contract, denom, amount, code, msg)
+	return channeltypes.NewErrorAcknowledgement(msg)
+}
+
+// failHookedPacketWithError logs the failure of a received hooked packet caused by err, and returns its error ack
+// with the stable message of err, see types.ErrorAckMessage. Only the log gets the text of err.
+func (h WasmHooks) failHookedPacketWithError(ctx sdk.Context, packet channeltypes.Packet, contract sdk.AccAddress, denom, amount, code string, err error) ibcexported.Acknowledgement {
+	h.logHookFailure(ctx, packet, contract, denom, amount, code, err.Error())
+	return channeltypes.NewErrorAcknowledgement(types.ErrorAckMessage(code, err))
+}
+
+// logHookFailure logs the failure of a received hooked packet. denom is the local denom of the received funds.
+func (h WasmHooks) logHookFailure(ctx sdk.Context, packet channeltypes.Packet, contract sdk.AccAddress, denom, amount, code, msg string) {
+	h.ibcHooksKeeper.LogHookFailure(ctx, keeper.HookFailure{
+		Channel:    packet.GetDestChannel(),
+		Sequence:   packet.GetSequence(),
+		PacketData: packet.GetData(),
+		Contract:   contract.String(),
+		ErrorCode:  code,
+		Denom:      denom,
+		Amount:     amount,
+		Error:      msg,
+	})
+}
+
+// payHookFee sends fee, which the memo set to be paid out of the received funds, from the intermediate sender to
+// the relayer of the packet
+func (h WasmHooks) payHookFee(ctx sdk.Context, contractAddr, intermediateSender, relayer sdk.AccAddress, fee sdk.Coin) error {
+	if err := h.bankKeeper.SendCoins(ctx, intermediateSender, relayer, sdk.NewCoins(fee)); err != nil {
+		return types.WrapAckError(err, types.ErrHookFee, relayer, types.StableErrorText(err))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtHookFeePaid,
+		sdk.NewAttribute(types.AttributeContract, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeSender, intermediateSender.String()),
+		sdk.NewAttribute(types.AttributeRecipient, relayer.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, fee.String()),
+	))
+	return nil
+}
+
+// forwardPostTransfer sends the funds left on the intermediate sender after the contract execution to
+// postTransfer.To. Without a postTransfer.Denom, the whole balance of the packet's denom and of every denom whose
+// balance increased during the execution (e.g. the output of a swap) is sent.
+func (h WasmHooks) forwardPostTransfer(ctx sdk.Context, contractAddr, intermediateSender sdk.AccAddress, postTransfer PostTransfer, packetDenom string, balancesBeforeExec sdk.Coins) error {
+	if h.bankKeeper.BlockedAddr(postTransfer.To) {
+		return types.AckErrorf(types.ErrPostTransfer, postTransfer.To, "the address is not allowed to receive funds")
+	}
+
+	balances := h.bankKeeper.GetAllBalances(ctx, intermediateSender)
+	forwarded := sdk.NewCoins()
+	for _, balance := range balances {
+		switch {
+		case postTransfer.Denom != "":
+			if balance.Denom != postTransfer.Denom {
+				continue
+			}
+		case balance.Denom != packetDenom && !balance.Amount.GT(balancesBeforeExec.AmountOf(balance.Denom)):
+			continue
+		}
+		forwarded = forwarded.Add(balance)
+	}
+	if forwarded.Empty() {
+		return nil
+	}
+
+	if err := h.bankKeeper.SendCoins(ctx, intermediateSender, postTransfer.To, forwarded); err != nil {
+		return types.WrapAckError(err, types.ErrPostTransfer, postTransfer.To, types.StableErrorText(err))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtPostTransfer,
+		sdk.NewAttribute(types.AttributeContract, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeSender, intermediateSender.String()),
+		sdk.NewAttribute(types.AttributeRecipient, postTransfer.To.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, forwarded.String()),
+	))
+	return nil
+}
+
+// sendAckTransfer sends AckTransferAmount of denom from the intermediate sender to ackTransfer.To, on
+// ackTransfer.Channel, with the result of the successful execution of the hooked packet in its memo.
+func (h WasmHooks) sendAckTransfer(ctx sdk.Context, packet channeltypes.Packet, contractAddr, intermediateSender sdk.AccAddress, ackTransfer AckTransfer, denom string, result []byte) error {
+	if h.TransferKeeper == nil {
+		return types.AckErrorf(types.ErrAckTransfer, ackTransfer.To, ackTransfer.Channel, "the return transfers are not supported")
+	}
+
+	resultHash := sha256.Sum256(result)
+	memo, err := json.Marshal(AckTransferMemo{HookResult: HookResult{
+		Channel:    packet.GetDestChannel(),
+		Sequence:   strconv.FormatUint(packet.GetSequence(), 10),
+		Contract:   contractAddr.String(),
+		Success:    true,
+		ResultHash: hex.EncodeToString(resultHash[:]),
+	}})
+	if err != nil {
+		return types.WrapAckError(err, types.ErrAckTransfer, ackTransfer.To, ackTransfer.Channel, types.StableErrorText(err))
+	}
+
+	msg := &transfertypes.MsgTransfer{
+		SourcePort:       transfertypes.PortID,
+		SourceChannel:    ackTransfer.Channel,
+		Token:            sdk.NewCoin(denom, AckTransferAmount),
+		Sender:           intermediateSender.String(),
+		Receiver:         ackTransfer.To,
+		TimeoutTimestamp: uint64(ctx.BlockTime().Add(AckTransferTimeout).UnixNano()),
+		Memo:             string(memo),
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return types.WrapAckError(err, types.ErrAckTransfer, ackTransfer.To, ackTransfer.Channel, types.StableErrorText(err))
+	}
+	if _, err := h.TransferKeeper.Transfer(sdk.WrapSDKContext(ctx), msg); err != nil {
+		return types.WrapAckError(err, types.ErrAckTransfer, ackTransfer.To, ackTransfer.Channel, types.StableErrorText(err))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtAckTransfer,
+		sdk.NewAttribute(types.AttributeContract, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeChannel, ackTransfer.Channel),
+		sdk.NewAttribute(types.AttributeRecipient, ackTransfer.To),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Token.String()),
+	))
+	return nil
+}
+
+// execWasmMsg executes execMsg as the wasm msg server would, emitting the same message event
 func (h WasmHooks) execWasmMsg(ctx sdk.Context, execMsg *wasmtypes.MsgExecuteContract) (*wasmtypes.MsgExecuteContractResponse, error) {
 	if err := execMsg.ValidateBasic(); err != nil {
-		return nil, fmt.Errorf(types.ErrBadExecutionMsg, err.Error())
+		return nil, types.WrapAckError(err, types.ErrBadExecutionMsg, types.StableErrorText(err))
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(execMsg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(execMsg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+	// Flag the contract as being executed by the hook so that it can verify the provenance of the call.
+	// The flag is cleared as soon as the execution finishes, whether it succeeded or not.
+	h.ibcHooksKeeper.SetHookExecution(ctx, execMsg.Contract, execMsg.Sender)
+	defer h.ibcHooksKeeper.ClearHookExecution(ctx, execMsg.Contract, execMsg.Sender)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, wasmtypes.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, execMsg.Sender),
+	))
+	data, err := h.ContractKeeper.Execute(ctx, contractAddr, senderAddr, execMsg.Msg, execMsg.Funds)
+	if err != nil {
+		return nil, err
+	}
+	return &wasmtypes.MsgExecuteContractResponse{Data: data}, nil
+}
+
+// execHookedWasmMsg executes the contract of a hooked packet with at most gasLimit gas, or with the gas left in ctx
+// if gasLimit is zero. Running out of gasLimit is returned as an error, for the packet to get an error ack. The gas
+// used by the execution is charged to ctx.
+func (h WasmHooks) execHookedWasmMsg(ctx sdk.Context, execMsg *wasmtypes.MsgExecuteContract, gasLimit uint64) (response *wasmtypes.MsgExecuteContractResponse, err error) {
+	if gasLimit == 0 {
+		return h.execWasmMsg(ctx, execMsg)
+	}
+
+	execCtx := ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			response, err = nil, hookOutOfGasError{descriptor: outOfGas.Descriptor, gasLimit: gasLimit}
+		}
+		ctx.GasMeter().ConsumeGas(execCtx.GasMeter().GasConsumedToLimit(), "ibc-hooks execution")
+	}()
+	return h.execWasmMsg(execCtx, execMsg)
+}
+
+// hookOutOfGasError is the error of a hooked execution that ran out of its gas limit
+type hookOutOfGasError struct {
+	descriptor string
+	gasLimit   uint64
+}
+
+func (e hookOutOfGasError) Error() string {
+	return fmt.Sprintf("%s in %s", fmt.Sprintf(types.ErrHookOutOfGas, e.gasLimit), e.descriptor)
+}
+
+// hookRejectionRegex matches the start of a hook rejection in the error of a contract execution
+var hookRejectionRegex = regexp.MustCompile(`\{\s*"hook_rejection"\s*:`)
+
+// ParseHookRejection returns the reason of a contract rejecting a hooked packet. A contract rejects a packet by
+// failing with an error whose message is {"hook_rejection": {"reason": "..."}}. wasmd wraps the contract's error,
+// so the rejection is looked for anywhere in the error text. The reason is sanitized before it is returned.
+func ParseHookRejection(execErr error) (reason string, rejected bool) {
+	msg := execErr.Error()
+	loc := hookRejectionRegex.FindStringIndex(msg)
+	if loc == nil {
+		return "", false
+	}
+
+	var rejection struct {
+		HookRejection *struct {
+			Reason string `json:"reason"`
+		} `json:"hook_rejection"`
+	}
+	// the decoder stops at the end of the rejection object, ignoring whatever wasmd appended to the error
+	if err := json.NewDecoder(strings.NewReader(msg[loc[0]:])).Decode(&rejection); err != nil || rejection.HookRejection == nil {
+		return "", false
 	}
-	wasmMsgServer := wasmkeeper.NewMsgServerImpl(h.ContractKeeper)
-	return wasmMsgServer.ExecuteContract(sdk.WrapSDKContext(ctx), execMsg)
+	return sanitizeRejectionReason(rejection.HookRejection.Reason), true
+}
+
+// sanitizeRejectionReason removes the non printable characters of a rejection reason and truncates it to
+// MaxRejectionReasonLength bytes, so that a contract can't put arbitrary data in the ack.
+func sanitizeRejectionReason(reason string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, reason)
+	if len(sanitized) <= types.MaxRejectionReasonLength {
+		return sanitized
+	}
+	sanitized = sanitized[:types.MaxRejectionReasonLength]
+	// don't cut a multi byte character in half
+	for !utf8.ValidString(sanitized) {
+		sanitized = sanitized[:len(sanitized)-1]
+	}
+	return sanitized
+}
+
+// notifyObserver notifies the observer contract, if one is configured for the channel of packet, with a summary of
+// the execution of a hooked packet. The observer is only notified and can't affect the packet.
+// If the execution failed, the packet's state changes are reverted by the error ack, along with those of an observer
+// notified right away, so the observer is notified at the end of the block instead.
+func (h WasmHooks) notifyObserver(ctx sdk.Context, packet channeltypes.Packet, contractAddr sdk.AccAddress, funds sdk.Coin, execErr error) {
+	params := h.ibcHooksKeeper.GetParams(ctx)
+	if params.ObserverContract == "" || !params.IsObservedChannel(packet.GetDestChannel()) {
+		return
+	}
+	observerAddr, err := sdk.AccAddressFromBech32(params.ObserverContract)
+	if err != nil {
+		return
+	}
+
+	var errorCode uint32
+	if execErr != nil {
+		_, errorCode, _ = sdkerrors.ABCIInfo(execErr, false)
+	}
+	notification := types.HookExecutedNotification{
+		Port:      packet.GetDestPort(),
+		Channel:   packet.GetDestChannel(),
+		Sequence:  packet.GetSequence(),
+		Contract:  contractAddr.String(),
+		Funds:     funds,
+		Success:   execErr == nil,
+		ErrorCode: errorCode,
+	}
+	if execErr != nil {
+		h.ibcHooksKeeper.QueueObserverNotification(ctx, observerAddr, notification)
+		return
+	}
+	h.ibcHooksKeeper.NotifyObserver(ctx, observerAddr, notification)
 }
 
 func isIcs20Packet(packet channeltypes.Packet) (isIcs20 bool, ics20data transfertypes.FungibleTokenPacketData) {
@@ -131,9 +692,88 @@ func isIcs20Packet(packet channeltypes.Packet) (isIcs20 bool, ics20data transfer
 	return true, data
 }
 
+// normalizeMemo trims the whitespace and the byte order marks some wallets add around the memo, which would
+// otherwise make it fail to parse and be ignored
+func normalizeMemo(memo string) string {
+	return strings.TrimFunc(memo, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\uFEFF'
+	})
+}
+
+// ParseLegacyReceiverMemo parses receiver as the {"receiver": "osmo1...", "wasm": {...}} JSON object that the
+// counterparties without the ICS20 memo field put in the receiver of hooked packets instead.
+// isLegacy is false if receiver isn't a JSON object with a wasm key, which no bech32 address can be, and the packet
+// is then handled as usual. Otherwise, memo is the memo the object is equivalent to and actualReceiver the receiver
+// it embeds, unless err says how it is malformed. The object is validated strictly, so that there is no doubt
+// about what the sender meant: it must have exactly these two keys, once each, with nothing after it, actualReceiver
+// must be a local bech32 address and wasm an object.
+func ParseLegacyReceiverMemo(receiver string) (isLegacy bool, memo string, actualReceiver string, err error) {
+	var fields map[string]json.RawMessage
+	if !strings.HasPrefix(receiver, "{") || json.Unmarshal([]byte(receiver), &fields) != nil {
+		return false, "", "", nil
+	}
+	if _, ok := fields["wasm"]; !ok {
+		return false, "", "", nil
+	}
+
+	// json.Unmarshal keeps the last value of a duplicate key, so the keys are counted separately
+	keys, err := jsonObjectKeys(receiver)
+	if err != nil {
+		return true, "", "", types.WrapAckError(err, types.ErrBadLegacyReceiverMemo, "not a valid JSON object")
+	}
+	for _, key := range keys {
+		if key != "receiver" && key != "wasm" {
+			return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, fmt.Sprintf("unexpected key %q", key))
+		}
+	}
+	if len(keys) != len(fields) {
+		return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, "duplicate key")
+	}
+
+	receiverRaw, ok := fields["receiver"]
+	if !ok {
+		return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, `missing key "receiver"`)
+	}
+	if err := json.Unmarshal(receiverRaw, &actualReceiver); err != nil {
+		return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, `"receiver" is not a string`)
+	}
+	if _, err := sdk.AccAddressFromBech32(actualReceiver); err != nil {
+		return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, `"receiver" is not a valid bech32 address`)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(fields["wasm"]), []byte("{")) {
+		return true, "", "", types.AckErrorf(types.ErrBadLegacyReceiverMemo, `"wasm" is not an object`)
+	}
+
+	return true, fmt.Sprintf(`{"wasm":%s}`, fields["wasm"]), actualReceiver, nil
+}
+
+// jsonObjectKeys returns the keys of the JSON object obj in order, including the duplicates
+func jsonObjectKeys(obj string) ([]string, error) {
+	dec := json.NewDecoder(strings.NewReader(obj))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key.(string))
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
 // jsonStringHasKey parses the memo as a json object and checks if it contains the key.
+// Memos that are not JSON can hold a base64 encoded WasmHookPayload instead, in which case the payload is
+// handled as the JSON object it is equivalent to.
 func jsonStringHasKey(memo, key string) (found bool, jsonObject map[string]interface{}) {
 	jsonObject = make(map[string]interface{})
+	memo = normalizeMemo(memo)
 
 	// If there is no memo, the packet was either sent with an earlier version of IBC, or the memo was
 	// intentionally left blank. Nothing to do here. Ignore the packet and pass it down the stack.
@@ -141,10 +781,15 @@ func jsonStringHasKey(memo, key string) (found bool, jsonObject map[string]inter
 		return false, jsonObject
 	}
 
-	// the jsonObject must be a valid JSON object
-	err := json.Unmarshal([]byte(memo), &jsonObject)
+	// the jsonObject must be a valid JSON object, or the memo a proto payload. Its numbers are kept as json.Number,
+	// so that those of the contract's msg and of the memo forwarded without the callback aren't rounded.
+	err := types.UnmarshalMemoJSON([]byte(memo), &jsonObject)
 	if err != nil {
-		return false, jsonObject
+		payload, isPayload := types.ParseWasmHookPayload(memo)
+		if !isPayload {
+			return false, make(map[string]interface{})
+		}
+		jsonObject = payload.Metadata()
 	}
 
 	// If the key doesn't exist, there's nothing to do on this hook. Continue by passing the packet
@@ -157,63 +802,171 @@ func jsonStringHasKey(memo, key string) (found bool, jsonObject map[string]inter
 	return true, jsonObject
 }
 
-func ValidateAndParseMemo(memo string, receiver string) (isWasmRouted bool, contractAddr sdk.AccAddress, msgBytes []byte, err error) {
+// ValidateAndParseMemo parses the wasm routed memo of a packet sent to receiver on chain chainID. The parsed memo
+// is empty unless the packet is wasm routed and its memo is valid.
+func ValidateAndParseMemo(memo string, receiver string, chainID string) (isWasmRouted bool, parsed ParsedMemo, err error) {
 	isWasmRouted, metadata := jsonStringHasKey(memo, "wasm")
 	if !isWasmRouted {
-		return isWasmRouted, sdk.AccAddress{}, nil, nil
+		return isWasmRouted, ParsedMemo{}, nil
 	}
 
 	wasmRaw := metadata["wasm"]
 
-	// Make sure the wasm key is a map. If it isn't, ignore this packet
+	// A null wasm key most likely means that the sender didn't want a hook (e.g. a serialized optional field),
+	// so we treat it as absent and pass the packet down the stack.
+	if wasmRaw == nil {
+		return false, ParsedMemo{}, nil
+	}
+
+	// Any other value must be a map. If it isn't, the sender meant to call a contract but the memo is malformed
 	wasm, ok := wasmRaw.(map[string]interface{})
 	if !ok {
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, "wasm metadata is not a valid JSON map object")
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, "wasm metadata is not a valid JSON map object")
+	}
+
+	// A hook can be scoped to the chain it is meant for, so that the chains the packet is forwarded through don't
+	// execute it. wasm["chain"] takes precedence: if set, the hook only runs on that chain. Otherwise, a memo that
+	// also forwards the packet to a next hop is meant for its final hop. A hook meant for another chain is treated
+	// as absent, so the packet passes down the stack untouched.
+	if wasm["chain"] != nil {
+		chain, ok := wasm["chain"].(string)
+		if !ok || chain == "" {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["chain"] is not a chain id`)
+		}
+		if chain != chainID {
+			return false, ParsedMemo{}, nil
+		}
+	} else if _, forwarded := metadata["forward"]; forwarded {
+		return false, ParsedMemo{}, nil
 	}
 
 	// Get the contract
 	contract, ok := wasm["contract"].(string)
 	if !ok {
 		// The tokens will be returned
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, `Could not find key wasm["contract"]`)
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `Could not find key wasm["contract"]`)
 	}
 
-	contractAddr, err = sdk.AccAddressFromBech32(contract)
+	// Check the prefix explicitly, as an address of another chain can never be a local contract
+	hrp, _, err := bech32.DecodeAndConvert(contract)
 	if err != nil {
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, `wasm["contract"] is not a valid bech32 address`)
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["contract"] is not a valid bech32 address`)
+	}
+	if expectedHrp := sdk.GetConfig().GetBech32AccountAddrPrefix(); hrp != expectedHrp {
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, fmt.Sprintf(`wasm["contract"] has bech32 prefix %s, expected %s`, hrp, expectedHrp))
+	}
+	parsed.Contract, err = sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["contract"] is not a valid bech32 address`)
 	}
 
 	// The contract and the receiver should be the same for the packet to be valid
 	if contract != receiver {
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, `wasm["contract"] should be the same as the receiver of the packet`)
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["contract"] should be the same as the receiver of the packet`)
 	}
 
 	// Ensure the message key is provided
 	if wasm["msg"] == nil {
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, `Could not find key wasm["msg"]`)
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `Could not find key wasm["msg"]`)
 	}
 
 	// Make sure the msg key is a map. If it isn't, return an error
 	_, ok = wasm["msg"].(map[string]interface{})
 	if !ok {
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, `wasm["msg"] is not a map object`)
+		return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["msg"] is not a map object`)
 	}
 
 	// Get the message string by serializing the map
-	msgBytes, err = json.Marshal(wasm["msg"])
+	parsed.Msg, err = json.Marshal(wasm["msg"])
 	if err != nil {
 		// The tokens will be returned
-		return isWasmRouted, sdk.AccAddress{}, nil,
-			fmt.Errorf(types.ErrBadMetadataFormatMsg, memo, err.Error())
+		return isWasmRouted, ParsedMemo{}, types.WrapAckError(err, types.ErrBadMetadataFormatMsg, memo, `wasm["msg"] cannot be encoded`)
+	}
+
+	// The minimum amount is optional. If provided, it must be a positive integer string
+	if wasm["min_amount"] != nil {
+		minAmountStr, ok := wasm["min_amount"].(string)
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["min_amount"] is not a string`)
+		}
+		parsed.MinAmount, ok = sdk.NewIntFromString(minAmountStr)
+		if !ok || !parsed.MinAmount.IsPositive() {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["min_amount"] is not a positive integer`)
+		}
+	}
+
+	// The funds amount is optional. If provided, it must be a non-negative integer string
+	if wasm["funds_amount"] != nil {
+		fundsAmountStr, ok := wasm["funds_amount"].(string)
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["funds_amount"] is not a string`)
+		}
+		parsed.FundsAmount, ok = sdk.NewIntFromString(fundsAmountStr)
+		if !ok || parsed.FundsAmount.IsNegative() {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["funds_amount"] is not a non-negative integer`)
+		}
+	}
+
+	// The fee paid to the relayer out of the received funds is optional. If provided, it must be a positive integer
+	// string. Whether it can be paid depends on the params, which are checked when the packet is received.
+	if wasm["fee_from_funds"] != nil {
+		feeFromFundsStr, ok := wasm["fee_from_funds"].(string)
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["fee_from_funds"] is not a string`)
+		}
+		parsed.FeeFromFunds, ok = sdk.NewIntFromString(feeFromFundsStr)
+		if !ok || !parsed.FeeFromFunds.IsPositive() {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["fee_from_funds"] is not a positive integer`)
+		}
+	}
+
+	// The post transfer address is optional. If provided, it must be a local bech32 address
+	if wasm["post_transfer_to"] != nil {
+		postTransferTo, ok := wasm["post_transfer_to"].(string)
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["post_transfer_to"] is not a string`)
+		}
+		parsed.PostTransfer.To, err = sdk.AccAddressFromBech32(postTransferTo)
+		if err != nil {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["post_transfer_to"] is not a valid bech32 address`)
+		}
+	}
+	if wasm["post_transfer_denom"] != nil {
+		parsed.PostTransfer.Denom, ok = wasm["post_transfer_denom"].(string)
+		if !ok || sdk.ValidateDenom(parsed.PostTransfer.Denom) != nil {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["post_transfer_denom"] is not a valid denom`)
+		}
+		if parsed.PostTransfer.To == nil {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["post_transfer_denom"] requires wasm["post_transfer_to"]`)
+		}
+	}
+
+	// Deferring the receive when the ICS20 transfer fails is optional. If provided, it must be a boolean
+	if wasm["defer_on_transfer_failure"] != nil {
+		parsed.DeferOnTransferFailure, ok = wasm["defer_on_transfer_failure"].(bool)
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["defer_on_transfer_failure"] is not a boolean`)
+		}
 	}
 
-	return isWasmRouted, contractAddr, msgBytes, nil
+	// The return transfer is optional. If provided, it must be a map with a local channel and a remote address.
+	// Whether it can be sent depends on the params, which are checked when the packet is received.
+	if wasm["ack_transfer"] != nil {
+		ackTransferMap, ok := wasm["ack_transfer"].(map[string]interface{})
+		if !ok {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["ack_transfer"] is not a map object`)
+		}
+		parsed.AckTransfer.Channel, ok = ackTransferMap["channel"].(string)
+		if !ok || !channeltypes.IsValidChannelID(parsed.AckTransfer.Channel) {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["ack_transfer"]["channel"] is not a valid channel id`)
+		}
+		parsed.AckTransfer.To, ok = ackTransferMap["to"].(string)
+		if !ok || strings.TrimSpace(parsed.AckTransfer.To) == "" {
+			return isWasmRouted, ParsedMemo{}, types.AckErrorf(types.ErrBadMetadataFormatMsg, memo, `wasm["ack_transfer"]["to"] is not a non-empty string`)
+		}
+	}
+
+	return isWasmRouted, parsed, nil
 }
 
 func (h WasmHooks) SendPacketOverride(i ICS4Middleware, ctx sdk.Context, chanCap *capabilitytypes.Capability, packet ibcexported.PacketI) error {
@@ -239,16 +992,29 @@ func (h WasmHooks) SendPacketOverride(i ICS4Middleware, ctx sdk.Context, chanCap
 	// This way receiver chains that are on old versions of IBC will be able to process the packet
 
 	callbackRaw := metadata[types.IBCCallbackKey] // This will be used later.
-	delete(metadata, types.IBCCallbackKey)
-	bzMetadata, err := json.Marshal(metadata)
-	if err != nil {
-		return sdkerrors.Wrap(err, "Send packet with callback error")
-	}
-	stringMetadata := string(bzMetadata)
-	if stringMetadata == "{}" {
+	if payload, isPayload := types.ParseWasmHookPayload(normalizeMemo(data.GetMemo())); isPayload {
+		// A proto payload memo is sent as a proto payload without the callback
+		payload.Callback = ""
 		data.Memo = ""
+		if !payload.IsEmpty() {
+			memo, err := payload.EncodeMemo()
+			if err != nil {
+				return sdkerrors.Wrap(err, "Send packet with callback error")
+			}
+			data.Memo = memo
+		}
 	} else {
-		data.Memo = stringMetadata
+		delete(metadata, types.IBCCallbackKey)
+		bzMetadata, err := json.Marshal(metadata)
+		if err != nil {
+			return sdkerrors.Wrap(err, "Send packet with callback error")
+		}
+		stringMetadata := string(bzMetadata)
+		if stringMetadata == "{}" {
+			data.Memo = ""
+		} else {
+			data.Memo = stringMetadata
+		}
 	}
 	dataBytes, err := json.Marshal(data)
 	if err != nil {
@@ -266,25 +1032,76 @@ func (h WasmHooks) SendPacketOverride(i ICS4Middleware, ctx sdk.Context, chanCap
 		TimeoutHeight:      concretePacket.TimeoutHeight,
 	}
 
+	// Make sure the callback is well formed and the contract a valid bech32 addr. If it isn't, the packet is sent
+	// without registering it. A callback that would already be expired is ignored too.
+	contract, entry, expiryHeight, ok := parseCallbackMetadata(callbackRaw)
+	if ok {
+		_, err = sdk.AccAddressFromBech32(contract)
+		ok = err == nil && (expiryHeight == 0 || expiryHeight > ctx.BlockHeight())
+	}
+	// A callback is only registered on a packet whose timeout is within the bounds of the params, so that it isn't
+	// kept for years, nor wasted on a packet that already timed out
+	if ok {
+		if err := h.ibcHooksKeeper.GetParams(ctx).ValidateCallbackTimeout(ctx.BlockTime(), concretePacket.TimeoutTimestamp); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, types.ErrBadCallbackTimeout, err)
+		}
+	}
+
 	err = i.channel.SendPacket(ctx, chanCap, packetWithoutCallbackMemo)
 	if err != nil {
 		return err
 	}
 
-	// Make sure the callback contract is a string and a valid bech32 addr. If it isn't, ignore this packet
-	contract, ok := callbackRaw.(string)
-	if !ok {
-		return nil
+	if ok {
+		h.ibcHooksKeeper.StorePacketCallback(ctx, packet.GetSourceChannel(), packet.GetSequence(), contract, entry, expiryHeight)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtPacketCallbackRegistered,
+			sdk.NewAttribute(types.AttributeChannel, packet.GetSourceChannel()),
+			sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+			sdk.NewAttribute(types.AttributeSender, data.Sender),
+			sdk.NewAttribute(types.AttributeContract, contract),
+		))
 	}
-	_, err = sdk.AccAddressFromBech32(contract)
-	if err != nil {
-		return nil
-	}
-
-	h.ibcHooksKeeper.StorePacketCallback(ctx, packet.GetSourceChannel(), packet.GetSequence(), contract)
 	return nil
 }
 
+// parseCallbackMetadata parses the value of the ibc_callback memo key. It is either the contract address,
+// in which case the callback is delivered as a sudo message, or an object of the form
+// {"contract": "osmo1contractAddr", "entry": "execute"|"sudo", "expiry_height": 12345}, where entry is optional
+// and defaults to sudo, and expiry_height is optional and defaults to zero, for a callback that never expires.
+func parseCallbackMetadata(callbackRaw interface{}) (contract string, entry types.CallbackEntry, expiryHeight int64, ok bool) {
+	switch callback := callbackRaw.(type) {
+	case string:
+		return callback, types.CallbackEntrySudo, 0, true
+	case map[string]interface{}:
+		contract, ok = callback[types.IBCCallbackContractKey].(string)
+		if !ok {
+			return "", types.CallbackEntrySudo, 0, false
+		}
+		if expiryRaw, found := callback[types.IBCCallbackExpiryKey]; found {
+			// the memo's numbers are decoded as json.Number. Its float64 represents every height a chain can reach
+			// exactly, and accepts the integers written with a fraction or an exponent as before.
+			expiryNumber, isNumber := expiryRaw.(json.Number)
+			expiry, err := expiryNumber.Float64()
+			if !isNumber || err != nil || expiry <= 0 || expiry != math.Trunc(expiry) || expiry > 1<<53 {
+				return "", types.CallbackEntrySudo, 0, false
+			}
+			expiryHeight = int64(expiry)
+		}
+		entryRaw, found := callback[types.IBCCallbackEntryKey]
+		if !found {
+			return contract, types.CallbackEntrySudo, expiryHeight, true
+		}
+		switch entryRaw {
+		case types.IBCCallbackEntrySudo:
+			return contract, types.CallbackEntrySudo, expiryHeight, true
+		case types.IBCCallbackEntryExecute:
+			return contract, types.CallbackEntryExecute, expiryHeight, true
+		}
+	}
+	return "", types.CallbackEntrySudo, 0, false
+}
+
 func (h WasmHooks) OnAcknowledgementPacketOverride(im IBCMiddleware, ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
 	err := im.App.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer)
 	if err != nil {
@@ -296,37 +1113,140 @@ func (h WasmHooks) OnAcknowledgementPacketOverride(im IBCMiddleware, ctx sdk.Con
 		return nil
 	}
 
-	contract := h.ibcHooksKeeper.GetPacketCallback(ctx, packet.GetSourceChannel(), packet.GetSequence())
-	if contract == "" {
+	classifier := h.ibcHooksKeeper.GetAckClassifier(ctx, packet.GetSourceChannel())
+	success := !classifier.IsAckError(acknowledgement)
+
+	if err := h.deliverPacketAckCallback(ctx, packet, acknowledgement, success); err != nil {
+		return err
+	}
+
+	// The contracts subscribed to the acks of the channel are notified once the packet's own callback succeeded
+	_, data := isIcs20Packet(packet)
+	h.ibcHooksKeeper.NotifyAckSubscribers(ctx, types.AckNotification{
+		Channel:  packet.GetSourceChannel(),
+		Sequence: packet.GetSequence(),
+		Sender:   data.Sender,
+		Receiver: data.Receiver,
+		Denom:    data.Denom,
+		Amount:   data.Amount,
+		Success:  success,
+	})
+	return nil
+}
+
+// deliverPacketAckCallback calls back the contract of the packet's callback, if it has one, with the ack, and
+// deletes the callback once delivered
+func (h WasmHooks) deliverPacketAckCallback(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, success bool) error {
+	callback, found := h.ibcHooksKeeper.GetPacketCallbackInfo(ctx, packet.GetSourceChannel(), packet.GetSequence())
+	if !found {
 		// No callback configured
 		return nil
 	}
 
-	contractAddr, err := sdk.AccAddressFromBech32(contract)
+	contractAddr, err := sdk.AccAddressFromBech32(callback.Contract)
 	if err != nil {
+		h.logCallbackFailure(ctx, packet, callback.Contract, types.FailureInvalidCallback, err.Error())
 		return sdkerrors.Wrap(err, "Ack callback error") // The callback configured is not a beck32. Error out
 	}
 
-	success := "false"
-	if !osmoutils.IsAckError(acknowledgement) {
-		success = "true"
-	}
-
 	// Notify the sender that the ack has been received
-	ackAsJson, err := json.Marshal(acknowledgement)
+	callbackMsg, err := ackCallbackMsg(packet.GetSourceChannel(), packet.GetSequence(), acknowledgement, success)
 	if err != nil {
 		// If the ack is not a json object, error
+		h.logCallbackFailure(ctx, packet, callback.Contract, types.FailureBadPacket, err.Error())
 		return err
 	}
-
-	sudoMsg := []byte(fmt.Sprintf(
-		`{"receive_ack": {"channel": "%s", "sequence": %d, "ack": %s, "success": %s}}`,
-		packet.SourceChannel, packet.Sequence, ackAsJson, success))
-	_, err = h.ContractKeeper.Sudo(ctx, contractAddr, sudoMsg)
+	err = h.sendAckCallback(ctx, contractAddr, callback.Entry, callbackMsg)
+	h.afterCallbackExecution(ctx, packet.GetSourceChannel(), packet.GetSequence(), callback, acknowledgement, success, err)
 	if err != nil {
 		// error processing the callback
+		h.logCallbackFailure(ctx, packet, callback.Contract, types.FailureCallback, err.Error())
 		return sdkerrors.Wrap(err, "Ack callback error")
 	}
 	h.ibcHooksKeeper.DeletePacketCallback(ctx, packet.GetSourceChannel(), packet.GetSequence())
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtPacketCallbackDelivered,
+		sdk.NewAttribute(types.AttributeChannel, packet.GetSourceChannel()),
+		sdk.NewAttribute(types.AttributeSequence, strconv.FormatUint(packet.GetSequence(), 10)),
+		sdk.NewAttribute(types.AttributeContract, callback.Contract),
+		sdk.NewAttribute(types.AttributeSuccess, strconv.FormatBool(success)),
+	))
 	return nil
 }
+
+// DeliverAckCallback delivers ack to the contract of callback, as the callback of the packet sent on channel with
+// sequence, through the same entry point as a received ack. Governance uses it to deliver stuck callbacks.
+func (h WasmHooks) DeliverAckCallback(ctx sdk.Context, channel string, sequence uint64, callback types.PacketCallback, ack []byte, success bool) error {
+	if !h.ProperlyConfigured() {
+		return fmt.Errorf("wasm hooks are not configured")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(callback.Contract)
+	if err != nil {
+		return err
+	}
+	callbackMsg, err := ackCallbackMsg(channel, sequence, ack, success)
+	if err != nil {
+		return err
+	}
+	err = h.sendAckCallback(ctx, contractAddr, callback.Entry, callbackMsg)
+	h.afterCallbackExecution(ctx, channel, sequence, callback, ack, success, err)
+	return err
+}
+
+// afterCallbackExecution notifies the hooks of other modules that the contract of callback was called back with
+// the ack of the packet sent on channel with sequence, and of the callback's error, if any
+func (h WasmHooks) afterCallbackExecution(ctx sdk.Context, channel string, sequence uint64, callback types.PacketCallback, ack []byte, success bool, callbackErr error) {
+	ackInfo := types.CallbackAckInfo{Ack: ack, AckSuccess: success}
+	if callbackErr != nil {
+		ackInfo.Error = callbackErr.Error()
+	}
+	h.ibcHooksKeeper.AfterCallbackExecution(ctx, types.CallbackPacketInfo{
+		Channel:  channel,
+		Sequence: sequence,
+		Contract: callback.Contract,
+		Entry:    callback.Entry,
+	}, ackInfo)
+}
+
+// ackCallbackMsg returns the message notifying a contract of the ack of the packet it sent on channel with sequence
+func ackCallbackMsg(channel string, sequence uint64, ack []byte, success bool) ([]byte, error) {
+	ackAsJson, err := json.Marshal(ack)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf(
+		`{"receive_ack": {"channel": "%s", "sequence": %d, "ack": %s, "success": %t}}`,
+		channel, sequence, ackAsJson, success)), nil
+}
+
+// sendAckCallback delivers callbackMsg to the contract through entry
+func (h WasmHooks) sendAckCallback(ctx sdk.Context, contractAddr sdk.AccAddress, entry types.CallbackEntry, callbackMsg []byte) error {
+	if entry == types.CallbackEntryExecute {
+		// Contracts without a sudo entry point get the same message as a regular execute from the
+		// hooks module account, so that their usual checks on info.sender apply.
+		_, err := h.execWasmMsg(ctx, &wasmtypes.MsgExecuteContract{
+			Sender:   WasmHookModuleAccountAddr.String(),
+			Contract: contractAddr.String(),
+			Msg:      callbackMsg,
+		})
+		return err
+	}
+	_, err := h.ContractKeeper.Sudo(ctx, contractAddr, callbackMsg)
+	return err
+}
+
+// logCallbackFailure logs the failure of the ack callback of a packet sent from this chain. The denom is the one
+// the packet was sent with.
+func (h WasmHooks) logCallbackFailure(ctx sdk.Context, packet channeltypes.Packet, contract, code, msg string) {
+	_, data := isIcs20Packet(packet)
+	h.ibcHooksKeeper.LogHookFailure(ctx, keeper.HookFailure{
+		Channel:    packet.GetSourceChannel(),
+		Sequence:   packet.GetSequence(),
+		PacketData: packet.GetData(),
+		Contract:   contract,
+		ErrorCode:  code,
+		Denom:      data.Denom,
+		Amount:     data.Amount,
+		Error:      msg,
+	})
+}
@@ -0,0 +1,595 @@
+package ibc_hooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	ibchooks "github.com/osmosis-labs/osmosis/v13/x/ibc-hooks"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/testutils"
+	"github.com/osmosis-labs/osmosis/v13/x/ibc-hooks/types"
+)
+
+var (
+	hookContract = sdk.AccAddress([]byte("contract____________"))
+	remoteSender = "remote-sender"
+)
+
+// A hooked packet executes its contract from the intermediate sender of the packet's sender, with the funds it
+// received, and its ack holds the contract's result along with the transfer's ack
+func TestWasmHookExecutesContract(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	env.Contracts.SetContract(hookContract, testutils.MockContract{
+		Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+			return []byte("executed"), nil
+		},
+	})
+
+	packet := testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", testutils.WasmMemo(hookContract.String(), `{"echo": {"msg": "test"}}`))
+	ack := env.RecvPacket(packet)
+	require.True(t, ack.Success())
+	contractAck := testutils.RequireContractAck(t, ack.Acknowledgement())
+	require.Equal(t, "executed", string(contractAck.ContractResult))
+	require.JSONEq(t, `{"result":"AQ=="}`, string(contractAck.IbcAck))
+
+	intermediateSender := ibchooks.DeriveIntermediateSender(testutils.LocalChannel, remoteSender)
+	funds := sdk.NewCoins(sdk.NewCoin(osmoutils.MustExtractDenomFromPacketOnRecv(packet), sdk.NewInt(10)))
+	require.Equal(t, []testutils.ContractCall{{
+		Contract: hookContract,
+		Caller:   intermediateSender,
+		Msg:      []byte(`{"echo":{"msg":"test"}}`),
+		Funds:    funds,
+	}}, env.Contracts.Executions)
+	require.Equal(t, funds, env.BankKeeper.GetAllBalances(env.Ctx, hookContract))
+	require.True(t, env.BankKeeper.GetAllBalances(env.Ctx, intermediateSender).IsZero())
+	_, isModuleAccount := env.AccountKeeper.GetAccount(env.Ctx, intermediateSender).(authtypes.ModuleAccountI)
+	require.True(t, isModuleAccount)
+
+	// The redelivered packet gets the same ack without the contract being executed again
+	redelivered := env.RecvPacket(packet)
+	require.Equal(t, ack.Acknowledgement(), redelivered.Acknowledgement())
+	require.Len(t, env.Contracts.Executions, 1)
+}
+
+// A failing contract gets the packet an error ack, with the reason of the contract if it rejected the packet, and
+// otherwise with the registered wasmd error only
+func TestWasmHookContractFailure(t *testing.T) {
+	testCases := []struct {
+		name         string
+		contractErr  error
+		expRejection string
+		expAck       string
+	}{
+		{
+			name:         "rejection",
+			contractErr:  sdkerrors.Wrap(wasmtypes.ErrExecuteFailed, `{"hook_rejection": {"reason": "deposits closed"}}`),
+			expRejection: "deposits closed",
+			expAck:       "deposits closed",
+		},
+		{
+			name:        "execution failure",
+			contractErr: sdkerrors.Wrap(wasmtypes.ErrExecuteFailed, "out of stock"),
+			expAck:      wasmtypes.ErrExecuteFailed.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testutils.NewTestHooksEnv(t)
+			recorder := &testutils.TestIBCHooksRecorder{}
+			env.Keeper.SetHooks(recorder)
+			env.Contracts.SetContract(hookContract, testutils.MockContract{
+				Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+					return nil, tc.contractErr
+				},
+			})
+
+			ack := env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", testutils.WasmMemo(hookContract.String(), `{"echo": {}}`)))
+			require.False(t, ack.Success())
+			testutils.RequireErrorAck(t, ack.Acknowledgement(), tc.expAck)
+			// the text the contract's error was wrapped with is only logged
+			require.NotContains(t, string(ack.Acknowledgement()), "out of stock")
+			reason, rejected := testutils.ParseRejectionAck(ack.Acknowledgement())
+			require.Equal(t, tc.expRejection != "", rejected)
+			require.Equal(t, tc.expRejection, reason)
+
+			require.Len(t, recorder.HookExecutions, 1)
+			require.False(t, recorder.HookExecutions[0].Result.Success)
+			require.Equal(t, ack.Acknowledgement(), recorder.HookExecutions[0].Result.Ack)
+		})
+	}
+}
+
+// An invalid memo is rejected without any contract being executed
+func TestWasmHookInvalidMemo(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	otherContract := sdk.AccAddress([]byte("other_contract______"))
+	ack := env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, otherContract.String(), "10", testutils.WasmMemo(hookContract.String(), `{"echo": {}}`)))
+	testutils.RequireErrorAck(t, ack.Acknowledgement(), "should be the same as the receiver")
+	require.Empty(t, env.Contracts.Executions)
+
+	_, err := testutils.ParseContractAck(ack.Acknowledgement())
+	require.Error(t, err)
+}
+
+// Outside of consensus, a memo exceeding the memo limits gets an error ack before being parsed, while in consensus
+// it is parsed as any other memo
+func TestWasmHookQuickRejectMemo(t *testing.T) {
+	deepMsg := strings.Repeat(`{"a": `, types.DefaultMemoLimits.MaxDepth) + "{}" + strings.Repeat("}", types.DefaultMemoLimits.MaxDepth)
+	memo := testutils.WasmMemo(hookContract.String(), deepMsg)
+
+	env := testutils.NewTestHooksEnv(t)
+	env.Ctx = env.Ctx.WithIsCheckTx(true)
+	ack := env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", memo))
+	testutils.RequireErrorAck(t, ack.Acknowledgement(), "deeper than the limit")
+	require.Empty(t, env.Contracts.Executions)
+
+	env = testutils.NewTestHooksEnv(t)
+	env.Contracts.SetContract(hookContract, testutils.MockContract{
+		Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+			return []byte("executed"), nil
+		},
+	})
+	ack = env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", memo))
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	require.Len(t, env.Contracts.Executions, 1)
+}
+
+// receiveAckMsg returns the message calling back the contract of the packet sent on testutils.LocalChannel with
+// sequence, with its ack
+func receiveAckMsg(t *testing.T, sequence uint64, ack []byte, success bool) string {
+	ackJSON, err := json.Marshal(ack)
+	require.NoError(t, err)
+	return fmt.Sprintf(`{"receive_ack": {"channel": "%s", "sequence": %d, "ack": %s, "success": %t}}`, testutils.LocalChannel, sequence, ackJSON, success)
+}
+
+// The ack of a packet sent with a callback is delivered to its contract through the entry of the callback, and the
+// callback is deleted once delivered
+func TestWasmHookAckCallback(t *testing.T) {
+	transferAck := channeltypes.NewResultAcknowledgement([]byte{byte(1)}).Acknowledgement()
+	errorAck := channeltypes.NewErrorAcknowledgement("failed").Acknowledgement()
+	testCases := []struct {
+		name    string
+		entry   types.CallbackEntry
+		ack     []byte
+		success bool
+	}{
+		{"sudo with a success ack", types.CallbackEntrySudo, transferAck, true},
+		{"sudo with an error ack", types.CallbackEntrySudo, errorAck, false},
+		{"execute with a success ack", types.CallbackEntryExecute, transferAck, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testutils.NewTestHooksEnv(t)
+			var received []byte
+			env.Contracts.SetContract(hookContract, testutils.MockContract{
+				Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+					received = msg
+					return nil, nil
+				},
+				Sudo: func(ctx sdk.Context, msg []byte) ([]byte, error) {
+					received = msg
+					return nil, nil
+				},
+			})
+
+			// The callback is registered, and removed from the memo of the packet sent
+			packet := testutils.NewSendPacket(1, hookContract.String(), "remote-receiver", "uosmo", "10", testutils.CallbackMemo(hookContract.String(), tc.entry))
+			require.NoError(t, env.SendPacket(packet))
+			require.Len(t, env.ICS4Wrapper.SentPackets, 1)
+			sent := env.ICS4Wrapper.SentPackets[0].(channeltypes.Packet)
+			require.NotContains(t, string(sent.GetData()), types.IBCCallbackKey)
+			callback, found := env.Keeper.GetPacketCallbackInfo(env.Ctx, testutils.LocalChannel, 1)
+			require.True(t, found)
+			require.Equal(t, tc.entry, callback.Entry)
+
+			require.NoError(t, env.AcknowledgePacket(sent, tc.ack))
+			require.JSONEq(t, receiveAckMsg(t, 1, tc.ack, tc.success), string(received))
+			if tc.entry == types.CallbackEntryExecute {
+				require.Len(t, env.Contracts.Executions, 1)
+				require.Equal(t, ibchooks.WasmHookModuleAccountAddr, env.Contracts.Executions[0].Caller)
+				require.Empty(t, env.Contracts.Sudos)
+			} else {
+				require.Len(t, env.Contracts.Sudos, 1)
+				require.Empty(t, env.Contracts.Executions)
+			}
+			_, found = env.Keeper.GetPacketCallbackInfo(env.Ctx, testutils.LocalChannel, 1)
+			require.False(t, found)
+		})
+	}
+}
+
+// A failed callback fails the ack, and keeps the callback so that it can be delivered again
+func TestWasmHookFailedAckCallback(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	env.Contracts.SetContract(hookContract, testutils.MockContract{
+		Sudo: func(ctx sdk.Context, msg []byte) ([]byte, error) {
+			return nil, errors.New("callback failed")
+		},
+	})
+
+	packet := testutils.NewSendPacket(1, hookContract.String(), "remote-receiver", "uosmo", "10", testutils.CallbackMemo(hookContract.String(), types.CallbackEntrySudo))
+	require.NoError(t, env.SendPacket(packet))
+	err := env.AcknowledgePacket(packet, channeltypes.NewResultAcknowledgement([]byte{byte(1)}).Acknowledgement())
+	require.ErrorContains(t, err, "callback failed")
+	_, found := env.Keeper.GetPacketCallbackInfo(env.Ctx, testutils.LocalChannel, 1)
+	require.True(t, found)
+}
+
+// A contract sending several transfers with callbacks in one execution gets a callback registered for each packet,
+// listed by ascending sequence, and each is delivered exactly once with the ack of its own packet, whatever order
+// the acks are relayed in
+func TestWasmHookMultipleCallbacksInOneTx(t *testing.T) {
+	env := testutils.NewTestHooksEnv(t)
+	received := []string{}
+	env.Contracts.SetContract(hookContract, testutils.MockContract{
+		Sudo: func(ctx sdk.Context, msg []byte) ([]byte, error) {
+			received = append(received, string(msg))
+			return nil, nil
+		},
+	})
+
+	// The sequences cross a power of ten, as the store sorts them as decimal strings
+	sequences := []uint64{9, 10, 11}
+	for _, sequence := range sequences {
+		packet := testutils.NewSendPacket(sequence, hookContract.String(), "remote-receiver", "uosmo", "10", testutils.CallbackMemo(hookContract.String(), types.CallbackEntrySudo))
+		require.NoError(t, env.SendPacket(packet))
+	}
+	require.Len(t, env.ICS4Wrapper.SentPackets, len(sequences))
+	pendingSequences := func() []uint64 {
+		pending := []uint64{}
+		for _, callback := range env.Keeper.GetAllPacketCallbacks(env.Ctx, testutils.LocalChannel) {
+			require.Equal(t, hookContract.String(), callback.Callback.Contract)
+			pending = append(pending, callback.Sequence)
+		}
+		return pending
+	}
+	require.Equal(t, sequences, pendingSequences())
+
+	// The callbacks of other channels are listed by channel id, then by sequence
+	env.Keeper.StorePacketCallback(env.Ctx, "channel-2", 1, hookContract.String(), types.CallbackEntrySudo, 0)
+	env.Keeper.StorePacketCallback(env.Ctx, "channel-10", 2, hookContract.String(), types.CallbackEntrySudo, 0)
+	env.Keeper.StorePacketCallback(env.Ctx, "channel-10", 1, hookContract.String(), types.CallbackEntrySudo, 0)
+	ids := []string{}
+	for _, callback := range env.Keeper.GetAllPacketCallbacks(env.Ctx, "") {
+		ids = append(ids, fmt.Sprintf("%s/%d", callback.Channel, callback.Sequence))
+	}
+	require.Equal(t, []string{"channel-0/9", "channel-0/10", "channel-0/11", "channel-10/1", "channel-10/2", "channel-2/1"}, ids)
+
+	// The acks are relayed out of order, each calling back with its own sequence and ack
+	for i, sequence := range []uint64{11, 9, 10} {
+		sent := env.ICS4Wrapper.SentPackets[sequence-sequences[0]].(channeltypes.Packet)
+		require.Equal(t, sequence, sent.GetSequence())
+		ack := channeltypes.NewResultAcknowledgement([]byte{byte(sequence)}).Acknowledgement()
+		require.NoError(t, env.AcknowledgePacket(sent, ack))
+		require.Len(t, received, i+1)
+		require.JSONEq(t, receiveAckMsg(t, sequence, ack, true), received[i])
+		require.NotContains(t, pendingSequences(), sequence)
+	}
+	require.Empty(t, pendingSequences())
+	require.Len(t, env.Contracts.Sudos, len(sequences))
+
+	// A relayed again ack doesn't call back again
+	sent := env.ICS4Wrapper.SentPackets[0].(channeltypes.Packet)
+	require.NoError(t, env.AcknowledgePacket(sent, channeltypes.NewResultAcknowledgement([]byte{byte(9)}).Acknowledgement()))
+	require.Len(t, received, len(sequences))
+}
+
+// The memos that aren't JSON objects pass through as plain transfers, while the whitespace and byte order marks
+// around a hook's memo are ignored, so that the hook is still executed, or rejected if it is malformed
+func TestWasmHookMemoNormalization(t *testing.T) {
+	hookMemo := testutils.WasmMemo(hookContract.String(), `{"echo": {}}`)
+	payloadMemo, err := types.WasmHookPayload{Contract: hookContract.String(), Msg: []byte(`{"echo": {}}`)}.EncodeMemo()
+	require.NoError(t, err)
+
+	const byteOrderMark = "\uFEFF"
+	const (
+		passThrough = iota
+		executed
+		rejected
+	)
+	testCases := []struct {
+		name     string
+		memo     string
+		expected int
+	}{
+		{"JSON string", `"hello"`, passThrough},
+		{"JSON number", `42`, passThrough},
+		{"JSON null", `null`, passThrough},
+		{"JSON array", "[" + hookMemo + "]", passThrough},
+		{"whitespace only", " \n\t", passThrough},
+		{"trailing garbage", hookMemo + " garbage", passThrough},
+		{"two objects", hookMemo + hookMemo, passThrough},
+		{"trailing newline", hookMemo + "\n", executed},
+		{"surrounding whitespace", " \r\n\t" + hookMemo + " \r\n", executed},
+		{"byte order mark", byteOrderMark + hookMemo, executed},
+		{"byte order mark and trailing newline", byteOrderMark + hookMemo + "\r\n", executed},
+		{"payload with trailing newline", payloadMemo + "\n", executed},
+		{"payload with byte order mark", byteOrderMark + payloadMemo, executed},
+		{"byte order mark and malformed hook", byteOrderMark + testutils.WasmMemo(hookContract.String(), `"not an object"`), rejected},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testutils.NewTestHooksEnv(t)
+			env.Contracts.SetContract(hookContract, testutils.MockContract{
+				Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+					return []byte("executed"), nil
+				},
+			})
+
+			ack := env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", tc.memo))
+			switch tc.expected {
+			case passThrough:
+				require.True(t, ack.Success())
+				require.Equal(t, channeltypes.NewResultAcknowledgement([]byte{byte(1)}).Acknowledgement(), ack.Acknowledgement())
+				require.Empty(t, env.Contracts.Executions)
+			case executed:
+				contractAck := testutils.RequireContractAck(t, ack.Acknowledgement())
+				require.Equal(t, "executed", string(contractAck.ContractResult))
+				require.Len(t, env.Contracts.Executions, 1)
+				require.Equal(t, []byte(`{"echo":{}}`), env.Contracts.Executions[0].Msg)
+			case rejected:
+				testutils.RequireErrorAck(t, ack.Acknowledgement(), `wasm["msg"] is not a map object`)
+				require.Empty(t, env.Contracts.Executions)
+			}
+		})
+	}
+}
+
+// A memo setting fee_from_funds pays the relayer that much of the received funds, exactly once, before the contract
+// is executed with the rest. It is rejected before the funds are received unless the fees are enabled for the denom.
+func TestWasmHookFeeFromFunds(t *testing.T) {
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", ""))
+	feesEnabled := types.DefaultParams()
+	feesEnabled.HookFeesEnabled = true
+	feesEnabled.HookFeeDenoms = []string{localDenom}
+	otherDenomFees := feesEnabled
+	otherDenomFees.HookFeeDenoms = []string{"uosmo"}
+
+	testCases := []struct {
+		name   string
+		params types.Params
+		fields string
+		// the error ack, or the amounts the relayer, the contract and the intermediate sender get out of 10
+		expErr          string
+		expRelayer      int64
+		expContract     int64
+		expIntermediate int64
+	}{
+		{"fee paid", feesEnabled, `"fee_from_funds": "3"`, "", 3, 7, 0},
+		{"whole amount paid", feesEnabled, `"fee_from_funds": "10"`, "", 10, 0, 0},
+		{"fee with a funds amount", feesEnabled, `"fee_from_funds": "3", "funds_amount": "5"`, "", 3, 5, 2},
+		{"no fee", feesEnabled, "", "", 0, 10, 0},
+		{"no fee with the fees disabled", types.DefaultParams(), "", "", 0, 10, 0},
+		{"fees disabled", types.DefaultParams(), `"fee_from_funds": "3"`, "hook fees cannot be paid", 0, 0, 0},
+		{"denom not accepted", otherDenomFees, `"fee_from_funds": "3"`, "hook fees cannot be paid", 0, 0, 0},
+		{"fee above the received amount", feesEnabled, `"fee_from_funds": "11"`, "exceeds the received amount", 0, 0, 10},
+		{"funds amount above the rest", feesEnabled, `"fee_from_funds": "3", "funds_amount": "8"`, "minus the hook fee 3", 3, 0, 7},
+		{"zero fee", feesEnabled, `"fee_from_funds": "0"`, "is not a positive integer", 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testutils.NewTestHooksEnv(t)
+			env.Keeper.SetParams(env.Ctx, tc.params)
+			env.Contracts.SetContract(hookContract, testutils.MockContract{
+				Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+					return nil, nil
+				},
+			})
+			balance := func(addr sdk.AccAddress) sdk.Int {
+				return env.BankKeeper.GetAllBalances(env.Ctx, addr).AmountOf(localDenom)
+			}
+			intermediateSender := ibchooks.DeriveIntermediateSender(testutils.LocalChannel, remoteSender)
+
+			packet := testutils.NewRecvPacket(1, remoteSender, hookContract.String(), "10", testutils.WasmMemoWithFields(hookContract.String(), `{"echo": {}}`, tc.fields))
+			ack := env.RecvPacket(packet)
+			// The balances of the mock bank keeper aren't reverted by the error acks, so they are the ones at the
+			// time of the failure
+			require.Equal(t, sdk.NewInt(tc.expRelayer), balance(testutils.RelayerAddr))
+			require.Equal(t, sdk.NewInt(tc.expContract), balance(hookContract))
+			require.Equal(t, sdk.NewInt(tc.expIntermediate), balance(intermediateSender))
+			if tc.expErr != "" {
+				testutils.RequireErrorAck(t, ack.Acknowledgement(), tc.expErr)
+				require.Empty(t, env.Contracts.Executions)
+				return
+			}
+			require.True(t, ack.Success(), string(ack.Acknowledgement()))
+			require.Len(t, env.Contracts.Executions, 1)
+			require.Equal(t, sdk.NewCoins(sdk.NewCoin(localDenom, sdk.NewInt(tc.expContract))), env.Contracts.Executions[0].Funds)
+
+			feeEvents, expFeeEvents := 0, 0
+			for _, event := range env.Ctx.EventManager().Events() {
+				if event.Type == types.TypeEvtHookFeePaid {
+					feeEvents++
+				}
+			}
+			if tc.expRelayer > 0 {
+				expFeeEvents = 1
+			}
+			require.Equal(t, expFeeEvents, feeEvents)
+
+			// The redelivered packet doesn't pay the fee again
+			env.RecvPacket(packet)
+			require.Equal(t, sdk.NewInt(tc.expRelayer), balance(testutils.RelayerAddr))
+			require.Len(t, env.Contracts.Executions, 1)
+		})
+	}
+}
+
+// Amounts above 2^53, which a float64 would round, keep every digit from the received packet to the msg and funds
+// of the contract, and from the memo of a sent packet to the memo it is sent with and the notifications of its ack
+func TestWasmHookLargeAmounts(t *testing.T) {
+	const amount = "123456789012345678901234567890"
+	const fundsAmount = "100000000000000000000000000001"
+	env := testutils.NewTestHooksEnv(t)
+	var callbacks, notifications []string
+	env.Contracts.SetContract(hookContract, testutils.MockContract{
+		Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+			return nil, nil
+		},
+		Sudo: func(ctx sdk.Context, msg []byte) ([]byte, error) {
+			callbacks = append(callbacks, string(msg))
+			return nil, nil
+		},
+	})
+	subscriber := sdk.AccAddress([]byte("subscriber__________"))
+	env.Contracts.SetContract(subscriber, testutils.MockContract{
+		Sudo: func(ctx sdk.Context, msg []byte) ([]byte, error) {
+			notifications = append(notifications, string(msg))
+			return nil, nil
+		},
+	})
+	received, ok := sdk.NewIntFromString(amount)
+	require.True(t, ok)
+	funds, ok := sdk.NewIntFromString(fundsAmount)
+	require.True(t, ok)
+
+	// The numbers of the contract's msg are passed on as they were written, and the amounts of the memo are exact
+	msg := fmt.Sprintf(`{"deposit": {"amount": %s, "min_out": "%s"}}`, amount, amount)
+	fields := fmt.Sprintf(`"min_amount": "%s", "funds_amount": "%s"`, amount, fundsAmount)
+	packet := testutils.NewRecvPacket(1, remoteSender, hookContract.String(), amount, testutils.WasmMemoWithFields(hookContract.String(), msg, fields))
+	ack := env.RecvPacket(packet)
+	require.True(t, ack.Success(), string(ack.Acknowledgement()))
+	localDenom := osmoutils.MustExtractDenomFromPacketOnRecv(packet)
+	require.Len(t, env.Contracts.Executions, 1)
+	require.Equal(t, fmt.Sprintf(`{"deposit":{"amount":%s,"min_out":"%s"}}`, amount, amount), string(env.Contracts.Executions[0].Msg))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(localDenom, funds)), env.Contracts.Executions[0].Funds)
+	intermediateSender := ibchooks.DeriveIntermediateSender(testutils.LocalChannel, remoteSender)
+	require.Equal(t, received.Sub(funds), env.BankKeeper.GetAllBalances(env.Ctx, intermediateSender).AmountOf(localDenom))
+
+	// The rest of the memo of a packet sent with a callback is sent as it was written
+	require.NoError(t, env.Keeper.SubscribeChannelAcks(env.Ctx, subscriber.String(), testutils.LocalChannel))
+	memo := fmt.Sprintf(`{"ibc_callback": "%s", "next": {"amount": %s}}`, hookContract, amount)
+	require.NoError(t, env.SendPacket(testutils.NewSendPacket(1, hookContract.String(), "remote-receiver", "uosmo", amount, memo)))
+	require.Len(t, env.ICS4Wrapper.SentPackets, 1)
+	sent := env.ICS4Wrapper.SentPackets[0].(channeltypes.Packet)
+	var sentData transfertypes.FungibleTokenPacketData
+	require.NoError(t, json.Unmarshal(sent.GetData(), &sentData))
+	require.Equal(t, amount, sentData.Amount)
+	require.Equal(t, fmt.Sprintf(`{"next":{"amount":%s}}`, amount), sentData.Memo)
+
+	transferAck := channeltypes.NewResultAcknowledgement([]byte{byte(1)}).Acknowledgement()
+	require.NoError(t, env.AcknowledgePacket(sent, transferAck))
+	require.Len(t, callbacks, 1)
+	require.JSONEq(t, receiveAckMsg(t, 1, transferAck, true), callbacks[0])
+	require.Len(t, notifications, 1)
+	require.JSONEq(t, fmt.Sprintf(
+		`{"channel_ack": {"channel": "%s", "sequence": 1, "sender": "%s", "receiver": "remote-receiver", "denom": "uosmo", "amount": "%s", "success": true}}`,
+		testutils.LocalChannel, hookContract, amount), notifications[0])
+}
+
+// With the legacy receiver memo enabled, a packet without a memo whose receiver holds the memo in the legacy format
+// is handled as the hooked packet it is equivalent to, with a deprecation event. A malformed legacy receiver is
+// rejected, while the plain and memo based packets are handled as usual.
+func TestWasmHookLegacyReceiverMemo(t *testing.T) {
+	legacyEnabled := types.DefaultParams()
+	legacyEnabled.LegacyReceiverMemoEnabled = true
+	contract := hookContract.String()
+	otherContract := sdk.AccAddress([]byte("other_contract______")).String()
+	legacyReceiver := testutils.LegacyReceiverMemo(contract, contract, `{"echo": {}}`)
+	legacyWasm := fmt.Sprintf(`"wasm": {"contract": "%s", "msg": {"echo": {}}}`, contract)
+
+	testCases := []struct {
+		name     string
+		params   types.Params
+		receiver string
+		memo     string
+		// the error ack, or whether the contract is executed
+		expErr      string
+		expExecuted bool
+		expLegacy   bool
+	}{
+		{"legacy packet", legacyEnabled, legacyReceiver, "", "", true, true},
+		{"legacy packet with a whitespace memo", legacyEnabled, legacyReceiver, " \n", "", true, true},
+		{"keys in another order", legacyEnabled, fmt.Sprintf(`{%s, "receiver": "%s"}`, legacyWasm, contract), "", "", true, true},
+		{"legacy packet while disabled", types.DefaultParams(), legacyReceiver, "", "decoding bech32 failed", false, false},
+		{"memo based packet", legacyEnabled, contract, testutils.WasmMemo(contract, `{"echo": {}}`), "", true, false},
+		{"plain transfer", legacyEnabled, contract, "", "", false, false},
+		{"legacy receiver along with a memo", legacyEnabled, legacyReceiver, testutils.WasmMemo(contract, `{"echo": {}}`), "should be the same as the receiver", false, false},
+		{"JSON receiver without wasm", legacyEnabled, fmt.Sprintf(`{"receiver": "%s"}`, contract), "", "decoding bech32 failed", false, false},
+		{"trailing garbage", legacyEnabled, legacyReceiver + "x", "", "decoding bech32 failed", false, false},
+		{"leading whitespace", legacyEnabled, " " + legacyReceiver, "", "decoding bech32 failed", false, false},
+		{"unexpected key", legacyEnabled, fmt.Sprintf(`{"receiver": "%s", %s, "memo": ""}`, contract, legacyWasm), "", `unexpected key "memo"`, false, false},
+		{"duplicate receiver", legacyEnabled, fmt.Sprintf(`{"receiver": "%s", "receiver": "%s", %s}`, otherContract, contract, legacyWasm), "", "duplicate key", false, false},
+		{"missing receiver", legacyEnabled, fmt.Sprintf(`{%s}`, legacyWasm), "", `missing key "receiver"`, false, false},
+		{"receiver is not a string", legacyEnabled, fmt.Sprintf(`{"receiver": 1, %s}`, legacyWasm), "", `"receiver" is not a string`, false, false},
+		{"receiver is not an address", legacyEnabled, fmt.Sprintf(`{"receiver": "contract", %s}`, legacyWasm), "", "is not a valid bech32 address", false, false},
+		{"receiver of another chain", legacyEnabled, fmt.Sprintf(`{"receiver": "%s", %s}`, sdk.MustBech32ifyAddressBytes("cosmos", hookContract), legacyWasm), "", "is not a valid bech32 address", false, false},
+		{"null wasm", legacyEnabled, fmt.Sprintf(`{"receiver": "%s", "wasm": null}`, contract), "", `"wasm" is not an object`, false, false},
+		{"wasm is not an object", legacyEnabled, fmt.Sprintf(`{"receiver": "%s", "wasm": "%s"}`, contract, contract), "", `"wasm" is not an object`, false, false},
+		{"receiver is not the contract", legacyEnabled, testutils.LegacyReceiverMemo(otherContract, contract, `{"echo": {}}`), "", "should be the same as the receiver", false, true},
+		{"malformed msg", legacyEnabled, testutils.LegacyReceiverMemo(contract, contract, `"echo"`), "", `wasm["msg"] is not a map object`, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testutils.NewTestHooksEnv(t)
+			env.Keeper.SetParams(env.Ctx, tc.params)
+			env.Contracts.SetContract(hookContract, testutils.MockContract{
+				Execute: func(ctx sdk.Context, caller sdk.AccAddress, msg []byte, funds sdk.Coins) ([]byte, error) {
+					return []byte("executed"), nil
+				},
+			})
+
+			ack := env.RecvPacket(testutils.NewRecvPacket(1, remoteSender, tc.receiver, "10", tc.memo))
+			legacyEvents := 0
+			for _, event := range env.Ctx.EventManager().Events() {
+				if event.Type == types.TypeEvtLegacyReceiverMemo {
+					legacyEvents++
+				}
+			}
+			if tc.expLegacy {
+				require.Equal(t, 1, legacyEvents)
+			} else {
+				require.Zero(t, legacyEvents)
+			}
+
+			switch {
+			case tc.expErr != "":
+				testutils.RequireErrorAck(t, ack.Acknowledgement(), tc.expErr)
+				require.Empty(t, env.Contracts.Executions)
+			case tc.expExecuted:
+				contractAck := testutils.RequireContractAck(t, ack.Acknowledgement())
+				require.Equal(t, "executed", string(contractAck.ContractResult))
+				require.Len(t, env.Contracts.Executions, 1)
+				require.Equal(t, []byte(`{"echo":{}}`), env.Contracts.Executions[0].Msg)
+				// the contract gets the funds from the intermediate sender, as for a memo based packet
+				require.Equal(t, ibchooks.DeriveIntermediateSender(testutils.LocalChannel, remoteSender), env.Contracts.Executions[0].Caller)
+			default:
+				require.True(t, ack.Success(), string(ack.Acknowledgement()))
+				require.Empty(t, env.Contracts.Executions)
+			}
+		})
+	}
+}
+
+func TestParseLegacyReceiverMemo(t *testing.T) {
+	contract := hookContract.String()
+
+	isLegacy, memo, receiver, err := ibchooks.ParseLegacyReceiverMemo(testutils.LegacyReceiverMemo(contract, contract, `{"echo": {}}`))
+	require.NoError(t, err)
+	require.True(t, isLegacy)
+	require.Equal(t, contract, receiver)
+	require.JSONEq(t, testutils.WasmMemo(contract, `{"echo": {}}`), memo)
+
+	// no bech32 address is mistaken for a legacy receiver
+	for _, plain := range []string{contract, "", "{", "{}", `{"receiver": "osmo1"}`, `["wasm"]`} {
+		isLegacy, _, _, err := ibchooks.ParseLegacyReceiverMemo(plain)
+		require.NoError(t, err)
+		require.False(t, isLegacy, plain)
+	}
+}
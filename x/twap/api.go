@@ -8,14 +8,18 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
-type twapType bool
+// MaxTwapCandles is the maximum number of intervals GetTwapCandles can split a time range into.
+const MaxTwapCandles = 500
+
+type twapType int
 
 const (
 	// arithmeticTwapType is the type of twap that is calculated by taking the arithmetic weighted average of the spot prices.
-	arithmeticTwapType twapType = true
+	arithmeticTwapType twapType = iota
 	// geometricTwapType is the type of twap that is calculated by taking the geometric weighted average of the spot prices.
-	// nolint: unused
-	geometricTwapType twapType = false
+	geometricTwapType
+	// harmonicTwapType is the type of twap that is calculated by taking the harmonic weighted average of the spot prices.
+	harmonicTwapType
 )
 
 // GetArithmeticTwap returns an arithmetic time weighted average price.
@@ -35,6 +39,9 @@ const (
 // * it is not provided externally
 // * it is set to current time
 //
+// An endTime before ctx.BlockTime() gives the TWAP as it was at endTime: only the records at or before endTime
+// are used, never the most recent record if it was written after endTime.
+//
 // This function will error if:
 // * startTime > endTime
 // * endTime in the future
@@ -42,6 +49,7 @@ const (
 // * pool with id poolId does not exist, or does not contain quoteAssetDenom, baseAssetDenom
 // * there were some computational errors during computing arithmetic twap within the time range of
 //   startRecord, endRecord - including the exact record times, which indicates that the result returned could be faulty
+// * the window overlaps a tracking gap of the pool, see GetArithmeticTwapAllowingGaps
 
 // N.B. If there is a notable use case, the state machine could maintain more historical records, e.g. at one per hour.
 func (k Keeper) GetArithmeticTwap(
@@ -52,8 +60,47 @@ func (k Keeper) GetArithmeticTwap(
 	startTime time.Time,
 	endTime time.Time,
 ) (sdk.Dec, error) {
+	result, err := k.GetArithmeticTwapResult(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime)
+	return result.Price, err
+}
+
+// GetArithmeticTwapResult returns the arithmetic TWAP of GetArithmeticTwap along with the records it was computed from,
+// so that callers can tell whether its start was interpolated, and how recently the spot price errored.
+// It errors in the same cases as GetArithmeticTwap.
+func (k Keeper) GetArithmeticTwapResult(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (types.TwapResult, error) {
 	arithmeticStrategy := &arithmetic{k}
-	return k.getTwap(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime, arithmeticStrategy)
+	return k.getTwap(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime, arithmeticStrategy, false)
+}
+
+// GetArithmeticTwapAllowingGaps returns the arithmetic TWAP of GetArithmeticTwapResult, even if its window overlaps
+// tracking gaps of the pool, along with the fraction of the window outside of them.
+// The accumulators don't distinguish a gap from a period without swaps, so the TWAP weighs the last spot prices
+// recorded before a gap over all of it.
+func (k Keeper) GetArithmeticTwapAllowingGaps(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (twap types.TwapResult, coveredFraction sdk.Dec, err error) {
+	arithmeticStrategy := &arithmetic{k}
+	twap, err = k.getTwap(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime, arithmeticStrategy, true)
+	if err != nil {
+		return types.TwapResult{}, sdk.Dec{}, err
+	}
+	coveredFraction, err = k.trackedFraction(ctx, poolId, startTime, endTime)
+	if err != nil {
+		return types.TwapResult{}, sdk.Dec{}, err
+	}
+	return twap, coveredFraction, nil
 }
 
 // GetArithmeticTwapToNow returns arithmetic twap from start time until the current block time for quote and base
@@ -65,12 +112,147 @@ func (k Keeper) GetArithmeticTwapToNow(
 	quoteAssetDenom string,
 	startTime time.Time,
 ) (sdk.Dec, error) {
+	result, err := k.GetArithmeticTwapToNowResult(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime)
+	return result.Price, err
+}
+
+// GetArithmeticTwapToNowResult returns the arithmetic TWAP of GetArithmeticTwapToNow along with the records it was
+// computed from, see GetArithmeticTwapResult.
+func (k Keeper) GetArithmeticTwapToNowResult(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+) (types.TwapResult, error) {
 	arithmeticStrategy := &arithmetic{k}
-	return k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, arithmeticStrategy)
+	return k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, arithmeticStrategy, false)
 }
 
-// getTwap computes and returns twap from the start time until the end time. The type
-// of twap returned depends on the strategy given and can be either arithmetic or geometric.
+// GetGeometricTwap returns the geometric TWAP of baseAssetDenom in terms of quoteAssetDenom over
+// [startTime, endTime] in pool poolId, that is the time weighted geometric mean of its spot prices.
+// It is computed from the geometric accumulators of the records as GetArithmeticTwap is from the arithmetic ones,
+// and errors in the same cases.
+func (k Keeper) GetGeometricTwap(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (sdk.Dec, error) {
+	geometricStrategy := &geometric{k}
+	result, err := k.getTwap(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime, geometricStrategy, false)
+	return result.Price, err
+}
+
+// GetGeometricTwapToNow returns the geometric TWAP from startTime until the current block time for quote and base
+// assets in a given pool.
+func (k Keeper) GetGeometricTwapToNow(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+) (sdk.Dec, error) {
+	geometricStrategy := &geometric{k}
+	result, err := k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, geometricStrategy, false)
+	return result.Price, err
+}
+
+// GetHarmonicTwap returns the harmonic TWAP of baseAssetDenom in terms of quoteAssetDenom over
+// [startTime, endTime] in pool poolId, that is the reciprocal of the time weighted arithmetic mean of the reciprocals
+// of its spot prices, e.g. for quoting liabilities. It is computed as the reciprocal of the arithmetic TWAP of
+// quoteAssetDenom in terms of baseAssetDenom, and errors in the same cases as GetArithmeticTwap.
+func (k Keeper) GetHarmonicTwap(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (sdk.Dec, error) {
+	result, err := k.GetHarmonicTwapResult(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime)
+	return result.Price, err
+}
+
+// GetHarmonicTwapResult returns the harmonic TWAP of GetHarmonicTwap along with the records it was computed from,
+// see GetArithmeticTwapResult.
+func (k Keeper) GetHarmonicTwapResult(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (types.TwapResult, error) {
+	harmonicStrategy := &harmonic{k}
+	return k.getTwap(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, endTime, harmonicStrategy, false)
+}
+
+// GetHarmonicTwapToNow returns the harmonic TWAP from startTime until the current block time for quote and base
+// assets in a given pool.
+func (k Keeper) GetHarmonicTwapToNow(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+) (sdk.Dec, error) {
+	result, err := k.GetHarmonicTwapToNowResult(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime)
+	return result.Price, err
+}
+
+// GetHarmonicTwapToNowResult returns the harmonic TWAP of GetHarmonicTwapToNow along with the records it was
+// computed from, see GetArithmeticTwapResult.
+func (k Keeper) GetHarmonicTwapToNowResult(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+) (types.TwapResult, error) {
+	harmonicStrategy := &harmonic{k}
+	return k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, harmonicStrategy, false)
+}
+
+// SpotDeviationFromTwap returns |spot / twap - 1|, the relative deviation of the spot price of baseAssetDenom in units
+// of quoteAssetDenom from its arithmetic TWAP over the window ending at the current block time, in pool `poolId`.
+// The spot price is the one stored by the most recent record of the pool, whose accumulators only weigh in the spot
+// prices recorded before it, so a spot price recorded in this block doesn't move the TWAP it is compared to.
+//
+// This function will error if:
+// * window is not positive
+// * the TWAP over the window errors, see GetArithmeticTwapToNow
+// * the TWAP is zero
+func (k Keeper) SpotDeviationFromTwap(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	window time.Duration,
+) (sdk.Dec, error) {
+	if window <= 0 {
+		return sdk.Dec{}, types.NonPositiveDurationError{Name: "window", Duration: window}
+	}
+	twap, err := k.GetArithmeticTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, ctx.BlockTime().Add(-window))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if twap.IsZero() {
+		return sdk.Dec{}, types.ZeroTwapError{PoolId: poolId, BaseAsset: baseAssetDenom, QuoteAsset: quoteAssetDenom, Window: window}
+	}
+	record, err := k.GetBeginBlockAccumulatorRecord(ctx, poolId, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	spotPrice := lastSpotPriceForQuoteAsset(record, record.Asset0Denom, quoteAssetDenom)
+	return spotPrice.Quo(twap).Sub(sdk.OneDec()).Abs(), nil
+}
+
+// getTwap computes and returns twap from the start time until the end time, along with the records it was computed
+// from. The type of twap returned depends on the strategy given and can be either arithmetic or geometric.
+// Unless allowGaps is set, it errors if the window overlaps a tracking gap of the pool.
 func (k Keeper) getTwap(
 	ctx sdk.Context,
 	poolId uint64,
@@ -79,29 +261,38 @@ func (k Keeper) getTwap(
 	startTime time.Time,
 	endTime time.Time,
 	strategy twapStrategy,
-) (sdk.Dec, error) {
+	allowGaps bool,
+) (types.TwapResult, error) {
 	if startTime.After(endTime) {
-		return sdk.Dec{}, types.StartTimeAfterEndTimeError{StartTime: startTime, EndTime: endTime}
+		return types.TwapResult{}, types.InvalidTimeRangeError{Start: startTime, End: endTime}
 	}
 	if endTime.Equal(ctx.BlockTime()) {
-		return k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, strategy)
+		return k.getTwapToNow(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime, strategy, allowGaps)
 	} else if endTime.After(ctx.BlockTime()) {
-		return sdk.Dec{}, types.EndTimeInFutureError{EndTime: endTime, BlockTime: ctx.BlockTime()}
+		return types.TwapResult{}, types.EndTimeInFutureError{EndTime: endTime, BlockTime: ctx.BlockTime()}
 	}
-	startRecord, err := k.getInterpolatedRecord(ctx, poolId, startTime, baseAssetDenom, quoteAssetDenom)
+	startRecord, startSourceTime, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, startTime, baseAssetDenom, quoteAssetDenom)
 	if err != nil {
-		return sdk.Dec{}, err
+		return types.TwapResult{}, err
 	}
-	endRecord, err := k.getInterpolatedRecord(ctx, poolId, endTime, baseAssetDenom, quoteAssetDenom)
+	endRecord, endSourceTime, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, endTime, baseAssetDenom, quoteAssetDenom)
 	if err != nil {
-		return sdk.Dec{}, err
+		return types.TwapResult{}, err
+	}
+	if !allowGaps {
+		if err := k.checkTrackingGaps(ctx, poolId, startTime, endTime); err != nil {
+			return types.TwapResult{}, err
+		}
 	}
 
-	return strategy.computeTwap(startRecord, endRecord, quoteAssetDenom)
+	// computeTwap returns the TWAP along with spot price errors, so the result is returned with the error
+	twap, err := strategy.computeTwap(startRecord, endRecord, quoteAssetDenom)
+	return types.NewTwapResult(twap, startRecord, endRecord, startSourceTime, endSourceTime), err
 }
 
 // getTwapToNow computes and returns twap from the start time until the current block time. The type
 // of twap returned depends on the strategy given and can be either arithmetic or geometric.
+// Unless allowGaps is set, it errors if the window overlaps a tracking gap of the pool.
 func (k Keeper) getTwapToNow(
 	ctx sdk.Context,
 	poolId uint64,
@@ -109,21 +300,76 @@ func (k Keeper) getTwapToNow(
 	quoteAssetDenom string,
 	startTime time.Time,
 	strategy twapStrategy,
-) (sdk.Dec, error) {
+	allowGaps bool,
+) (types.TwapResult, error) {
 	if startTime.After(ctx.BlockTime()) {
-		return sdk.Dec{}, types.StartTimeAfterEndTimeError{StartTime: startTime, EndTime: ctx.BlockTime()}
+		return types.TwapResult{}, types.InvalidTimeRangeError{Start: startTime, End: ctx.BlockTime()}
 	}
 
-	startRecord, err := k.getInterpolatedRecord(ctx, poolId, startTime, baseAssetDenom, quoteAssetDenom)
+	startRecord, startSourceTime, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, startTime, baseAssetDenom, quoteAssetDenom)
 	if err != nil {
-		return sdk.Dec{}, err
+		return types.TwapResult{}, err
 	}
-	endRecord, err := k.GetBeginBlockAccumulatorRecord(ctx, poolId, baseAssetDenom, quoteAssetDenom)
+	// the record at the beginning of the block, see GetBeginBlockAccumulatorRecord
+	endRecord, endSourceTime, err := k.getMostRecentRecordWithProvenance(ctx, poolId, baseAssetDenom, quoteAssetDenom)
 	if err != nil {
-		return sdk.Dec{}, err
+		return types.TwapResult{}, err
+	}
+	if !allowGaps {
+		if err := k.checkTrackingGaps(ctx, poolId, startTime, ctx.BlockTime()); err != nil {
+			return types.TwapResult{}, err
+		}
+	}
+
+	twap, err := strategy.computeTwap(startRecord, endRecord, quoteAssetDenom)
+	return types.NewTwapResult(twap, startRecord, endRecord, startSourceTime, endSourceTime), err
+}
+
+// GetPoolIdsForDenomPair returns the ids of the pools with twap records for the pair of denomA and denomB,
+// given in either order, in increasing order. Pools are added when their records are created, and removed
+// when the records of the pair stop being updated, e.g. after a denom rename or a quarantine repair.
+func (k Keeper) GetPoolIdsForDenomPair(ctx sdk.Context, denomA string, denomB string) ([]uint64, error) {
+	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(denomA, denomB)
+	if err != nil {
+		return nil, err
+	}
+	return k.getPoolIdsForPair(ctx, asset0Denom, asset1Denom)
+}
+
+// GetAllMostRecentRecordsForPool returns the most recent record of every denom pair of pool `poolId`, sorted by
+// denom pair, e.g. the three records of the AB, AC and BC pairs of a three-asset pool. They are the records as
+// stored, written at the end of the last block the pool changed in, with its spot prices, accumulators and
+// LastErrorTime at that block. The slice is empty if the pool has no records.
+func (k Keeper) GetAllMostRecentRecordsForPool(ctx sdk.Context, poolId uint64) ([]types.TwapRecord, error) {
+	return k.getAllMostRecentRecordsForPool(ctx, poolId)
+}
+
+// GetPoolTwapPairs returns the denom pairs of pool `poolId` that have records, i.e. the pairs a TWAP of the pool can be
+// queried for, sorted by denom pair. They are the pairs of its most recent records, e.g. the AB, AC and BC pairs of a
+// three-asset pool. The slice is empty if the pool has no records.
+func (k Keeper) GetPoolTwapPairs(ctx sdk.Context, poolId uint64) ([]types.DenomPair, error) {
+	records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]types.DenomPair, 0, len(records))
+	for _, record := range records {
+		pairs = append(pairs, types.DenomPair{Denom0: record.Asset0Denom, Denom1: record.Asset1Denom})
 	}
+	return pairs, nil
+}
 
-	return strategy.computeTwap(startRecord, endRecord, quoteAssetDenom)
+// GetLastUpdateInfo returns when the most recent record of the denom pair of `baseAsset` and `quoteAsset` of pool
+// `poolId`, given in either order, was written, and whether it is stale: written more than maxBlockAge blocks before
+// the current block. A pool's records are written at the end of every block it changes in, so a pair that stays stale
+// is of a pool that no longer trades, or whose records aren't updated because it is quarantined, which the info also
+// tells. Only the most recent record is read, not the record history.
+func (k Keeper) GetLastUpdateInfo(ctx sdk.Context, poolId uint64, baseAsset, quoteAsset string, maxBlockAge uint64) (types.LastUpdateInfo, error) {
+	record, err := k.getMostRecentRecordStoreRepresentation(ctx, poolId, baseAsset, quoteAsset)
+	if err != nil {
+		return types.LastUpdateInfo{}, err
+	}
+	return types.NewLastUpdateInfo(record, ctx.BlockHeight(), maxBlockAge, k.isPoolQuarantined(ctx, poolId)), nil
 }
 
 // GetBeginBlockAccumulatorRecord returns a TwapRecord struct corresponding to the state of pool `poolId`
@@ -131,3 +377,136 @@ func (k Keeper) getTwapToNow(
 func (k Keeper) GetBeginBlockAccumulatorRecord(ctx sdk.Context, poolId uint64, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
 	return k.getMostRecentRecord(ctx, poolId, asset0Denom, asset1Denom)
 }
+
+// GetHistoricalSpotPrice returns the spot price of baseAssetDenom in units of quoteAssetDenom, as stored
+// by the last record of pool `poolId` at or before t, rather than an average.
+// It also returns that record's time, and whether the pool's spot price had errored when the record was written,
+// in which case the returned spot price may be faulty.
+//
+// This function will error if:
+// * t is in the future
+// * t is before the record history keep period, or before the first record of the pool
+// * pool with id poolId does not exist, or does not contain quoteAssetDenom, baseAssetDenom
+func (k Keeper) GetHistoricalSpotPrice(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	t time.Time,
+) (spotPrice sdk.Dec, recordTime time.Time, errorActive bool, err error) {
+	if t.After(ctx.BlockTime()) {
+		return sdk.Dec{}, time.Time{}, false, types.TimeInFutureError{Time: t, BlockTime: ctx.BlockTime()}
+	}
+	if tooOld := k.newTimeTooOldError(ctx, t); t.Before(tooOld.OldestQueryableTime) {
+		return sdk.Dec{}, time.Time{}, false, tooOld
+	}
+	if baseAssetDenom == quoteAssetDenom {
+		return sdk.Dec{}, time.Time{}, false, types.SameDenomError{Denom: baseAssetDenom}
+	}
+
+	record, err := k.getRecordAtOrBeforeTime(ctx, poolId, t, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, time.Time{}, false, err
+	}
+	return lastSpotPriceForQuoteAsset(record, record.Asset0Denom, quoteAssetDenom), record.Time, isSpotPriceErrorActive(record), nil
+}
+
+// GetTwapCandles splits [startTime, endTime] into intervals of the given duration, the last one being truncated
+// to end at endTime, and returns a candle for each of them, in time order.
+// A candle has the arithmetic TWAP of baseAssetDenom in units of quoteAssetDenom over its interval, computed from
+// the records interpolated at its bounds as in GetArithmeticTwap, and the spot prices of the first and last records
+// written in [interval start, interval end). If there is no such record, the spot price of the last record before
+// the interval is carried forward as both.
+// A candle also tells whether the records at its bounds were interpolated, because no record was written
+// at that exact time.
+//
+// This function will error if:
+// * interval is not positive, or startTime is not before endTime
+// * endTime is in the future
+// * [startTime, endTime] spans more than MaxTwapCandles intervals
+// * startTime is older than the record history, or pool creation
+// * pool with id poolId does not exist, or does not contain quoteAssetDenom, baseAssetDenom
+// A spot price error within an interval doesn't make the function fail, but sets the candle's ErrorActive instead.
+func (k Keeper) GetTwapCandles(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+	interval time.Duration,
+) ([]types.TwapCandle, error) {
+	if interval <= 0 {
+		return nil, types.NonPositiveDurationError{Name: "interval", Duration: interval}
+	}
+	if !startTime.Before(endTime) {
+		return nil, types.InvalidTimeRangeError{Start: startTime, End: endTime}
+	}
+	if endTime.After(ctx.BlockTime()) {
+		return nil, types.EndTimeInFutureError{EndTime: endTime, BlockTime: ctx.BlockTime()}
+	}
+	numCandles := endTime.Sub(startTime) / interval
+	if endTime.Sub(startTime)%interval != 0 {
+		numCandles++
+	}
+	if numCandles > MaxTwapCandles {
+		return nil, types.TooManyCandlesError{
+			StartTime: startTime, EndTime: endTime, Interval: interval, NumCandles: int64(numCandles), MaxCandles: MaxTwapCandles,
+		}
+	}
+	if baseAssetDenom == quoteAssetDenom {
+		return nil, types.SameDenomError{Denom: baseAssetDenom}
+	}
+
+	startRecord, startSourceTime, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, startTime, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return nil, err
+	}
+	startInterpolated := !startSourceTime.Equal(startTime)
+	candles := make([]types.TwapCandle, 0, numCandles)
+	for startRecord.Time.Before(endTime) {
+		candleEnd := startRecord.Time.Add(interval)
+		if candleEnd.After(endTime) {
+			candleEnd = endTime
+		}
+		endRecord, endSourceTime, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, candleEnd, baseAssetDenom, quoteAssetDenom)
+		if err != nil {
+			return nil, err
+		}
+		endInterpolated := !endSourceTime.Equal(candleEnd)
+		candle, err := k.twapCandle(ctx, startRecord, endRecord, quoteAssetDenom)
+		if err != nil {
+			return nil, err
+		}
+		candle.StartInterpolated = startInterpolated
+		candle.EndInterpolated = endInterpolated
+		candles = append(candles, candle)
+		startRecord, startInterpolated = endRecord, endInterpolated
+	}
+	return candles, nil
+}
+
+// twapCandle returns the candle of the interval between startRecord and endRecord, both interpolated at its bounds.
+// Its spot prices are those of the first and last records written in the interval, or of startRecord if there is none.
+func (k Keeper) twapCandle(ctx sdk.Context, startRecord, endRecord types.TwapRecord, quoteAssetDenom string) (types.TwapCandle, error) {
+	// computeTwap only errors on spot price errors, in which case the TWAP is still set
+	twap, twapErr := computeTwap(startRecord, endRecord, quoteAssetDenom, arithmeticTwapType)
+	candle := types.TwapCandle{
+		StartTime:      startRecord.Time,
+		EndTime:        endRecord.Time,
+		ArithmeticTwap: twap,
+		ErrorActive:    twapErr != nil,
+	}
+
+	first, last, found, err := k.getFirstAndLastRecordsInTimeRange(ctx, startRecord.PoolId, startRecord.Asset0Denom, startRecord.Asset1Denom, startRecord.Time, endRecord.Time)
+	if err != nil {
+		return types.TwapCandle{}, err
+	}
+	if !found {
+		first, last = startRecord, startRecord
+	}
+	candle.HasRecords = found
+	candle.FirstSpotPrice = lastSpotPriceForQuoteAsset(first, first.Asset0Denom, quoteAssetDenom)
+	candle.LastSpotPrice = lastSpotPriceForQuoteAsset(last, last.Asset0Denom, quoteAssetDenom)
+	return candle, nil
+}
@@ -1,12 +1,14 @@
 package twap_test
 
 import (
-	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/osmosis-labs/osmosis/v13/osmomath"
 	"github.com/osmosis-labs/osmosis/v13/x/twap"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
@@ -77,7 +79,7 @@ var (
 		sdk.ZeroDec(),                // TODO: choose correct
 	)
 
-	spotPriceError = errors.New("twap: error in pool spot price occurred between start and end time, twap result may be faulty")
+	spotPriceError = types.ErrSpotPriceErrorInWindow
 )
 
 func (s *TestSuite) TestGetBeginBlockAccumulatorRecord() {
@@ -99,9 +101,9 @@ func (s *TestSuite) TestGetBeginBlockAccumulatorRecord() {
 		baseDenom  string
 		expError   error
 	}{
-		"no record (wrong pool ID)":                         {initStartRecord, initStartRecord, baseTime, 4, denomA, denomB, fmt.Errorf("twap not found")},
+		"no record (wrong pool ID)":                         {initStartRecord, initStartRecord, baseTime, 4, denomA, denomB, types.ErrRecordNotFound},
 		"default record":                                    {initStartRecord, initStartRecord, baseTime, 1, denomA, denomB, nil},
-		"default record but same denom":                     {initStartRecord, initStartRecord, baseTime, 1, denomA, denomA, fmt.Errorf("both assets cannot be of the same denom: assetA: %s, assetB: %s", denomA, denomA)},
+		"default record but same denom":                     {initStartRecord, initStartRecord, baseTime, 1, denomA, denomA, types.SameDenomError{Denom: denomA}},
 		"default record wrong order (should get reordered)": {initStartRecord, initStartRecord, baseTime, 1, denomB, denomA, nil},
 		"one second later record":                           {initStartRecord, recordWithUpdatedAccum(initStartRecord, OneSec, OneSec, sdk.ZeroDec()), tPlusOne, 1, denomA, denomB, nil},
 		"idempotent overwrite":                              {initStartRecord, initStartRecord, baseTime, 1, denomA, denomB, nil},
@@ -123,7 +125,7 @@ func (s *TestSuite) TestGetBeginBlockAccumulatorRecord() {
 			actualRecord, err := s.twapkeeper.GetBeginBlockAccumulatorRecord(s.Ctx, tc.poolId, tc.baseDenom, tc.quoteDenom)
 
 			if tc.expError != nil {
-				s.Require().ErrorContains(err, fmt.Sprintf("%s", tc.expError))
+				s.Require().ErrorIs(err, tc.expError)
 				return
 			}
 
@@ -136,6 +138,31 @@ func (s *TestSuite) TestGetBeginBlockAccumulatorRecord() {
 	}
 }
 
+// In the block following an upgrade truncating the block time, the most recent record can be after the block time.
+// It can't be interpolated back to the block time, so the begin block record and the TWAPs to now error.
+func (s *TestSuite) TestGetBeginBlockAccumulatorRecord_RecordAfterBlockTime() {
+	// the pool's first record is written at the current block time
+	poolId, denomA, denomB := s.setupDefaultPool()
+	startTime := s.Ctx.BlockTime()
+	blockTime := startTime.Add(time.Second)
+	s.Ctx = s.Ctx.WithBlockTime(blockTime)
+	record := newRecord(poolId, blockTime.Add(time.Millisecond), sdk.NewDec(10), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	record.Asset0Denom, record.Asset1Denom = denomB, denomA
+	s.twapkeeper.StoreNewRecord(s.Ctx, record)
+
+	expErr := types.RecordAfterTargetTimeError{PoolId: poolId, RecordTime: record.Time, TargetTime: blockTime}
+	_, err := s.twapkeeper.GetBeginBlockAccumulatorRecord(s.Ctx, poolId, denomA, denomB)
+	s.Require().Equal(expErr, err)
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolId, denomA, denomB, startTime)
+	s.Require().Equal(expErr, err)
+
+	// once the block time reaches the record, it is returned as is
+	s.Ctx = s.Ctx.WithBlockTime(record.Time)
+	actualRecord, err := s.twapkeeper.GetBeginBlockAccumulatorRecord(s.Ctx, poolId, denomA, denomB)
+	s.Require().NoError(err)
+	s.Require().Equal(record, actualRecord)
+}
+
 type getTwapInput struct {
 	poolId          uint64
 	quoteAssetDenom string
@@ -288,19 +315,31 @@ func (s *TestSuite) TestGetArithmeticTwap() {
 			recordsToSet: []types.TwapRecord{baseRecord},
 			ctxTime:      baseTime,
 			input:        makeSimpleTwapInput(tPlusOne, baseTime, baseQuoteBA),
-			expectError:  types.StartTimeAfterEndTimeError{StartTime: tPlusOne, EndTime: baseTime},
+			expectError:  types.InvalidTimeRangeError{Start: tPlusOne, End: baseTime},
+		},
+		"start time after end time, both in the future": {
+			recordsToSet: []types.TwapRecord{baseRecord},
+			ctxTime:      baseTime,
+			input:        makeSimpleTwapInput(tPlusOneMin, tPlusOne, baseQuoteBA),
+			expectError:  types.InvalidTimeRangeError{Start: tPlusOneMin, End: tPlusOne},
+		},
+		"start time after end time, pool without records": {
+			recordsToSet: []types.TwapRecord{baseRecord},
+			ctxTime:      tPlusOne,
+			input:        getTwapInput{2, denom0, denom1, tPlusOne, baseTime},
+			expectError:  types.InvalidTimeRangeError{Start: tPlusOne, End: baseTime},
 		},
 		"start time too old (end time = now)": {
 			recordsToSet: []types.TwapRecord{baseRecord},
 			ctxTime:      baseTime,
 			input:        makeSimpleTwapInput(baseTime.Add(-time.Hour), baseTime, baseQuoteBA),
-			expectError:  twap.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
+			expectError:  types.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
 		},
 		"start time too old": {
 			recordsToSet: []types.TwapRecord{baseRecord},
 			ctxTime:      baseTime.Add(time.Second),
 			input:        makeSimpleTwapInput(baseTime.Add(-time.Hour), baseTime, baseQuoteBA),
-			expectError:  twap.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
+			expectError:  types.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
 		},
 		"spot price error in record at record time (start time = record time)": {
 			recordsToSet: []types.TwapRecord{withLastErrTime(baseRecord, baseTime)},
@@ -352,6 +391,24 @@ func (s *TestSuite) TestGetArithmeticTwap() {
 			expectError:  spotPriceError,
 			expectSpErr:  baseTime,
 		},
+		// last error time after record time is malformed, so the whole window after the record errors,
+		// even when it starts after the stored error time.
+		"malformed record: error time after record time, window after error time": {
+			recordsToSet: []types.TwapRecord{withLastErrTime(baseRecord, tPlusOne)},
+			ctxTime:      tPlusOneMin,
+			input:        makeSimpleTwapInput(baseTime.Add(10*time.Second), baseTime.Add(20*time.Second), baseQuoteBA),
+			expTwap:      sdk.NewDec(10),
+			expectError:  spotPriceError,
+			expectSpErr:  baseTime,
+		},
+		"malformed record: error time after record time, window after error time (end time = now)": {
+			recordsToSet: []types.TwapRecord{withLastErrTime(baseRecord, tPlusOne)},
+			ctxTime:      tPlusOneMin,
+			input:        makeSimpleTwapInput(baseTime.Add(10*time.Second), tPlusOneMin, baseQuoteBA),
+			expTwap:      sdk.NewDec(10),
+			expectError:  spotPriceError,
+			expectSpErr:  baseTime,
+		},
 	}
 	for name, test := range tests {
 		s.Run(name, func() {
@@ -365,7 +422,7 @@ func (s *TestSuite) TestGetArithmeticTwap() {
 
 			if test.expectError != nil || !test.expectSpErr.IsZero() {
 				s.Require().Error(err)
-				s.Require().Equal(test.expectError, err)
+				s.Require().Equal(s.withKeepPeriodDetails(test.expectError), err)
 				s.Require().Equal(test.expTwap, twap)
 				return
 			}
@@ -535,13 +592,13 @@ func (s *TestSuite) TestGetArithmeticTwap_PruningRecordKeepPeriod() {
 			recordsToSet: []types.TwapRecord{baseRecord},
 			ctxTime:      baseTimePlusKeepPeriod,
 			input:        makeSimpleTwapInput(baseTime.Add(-time.Millisecond), baseTimePlusKeepPeriod, baseQuoteBA),
-			expectError:  twap.TimeTooOldError{Time: baseTime.Add(-time.Millisecond)},
+			expectError:  types.TimeTooOldError{Time: baseTime.Add(-time.Millisecond)},
 		},
 		"(1 record at keep threshold); with end time; ctxTime = base keep threshold, start time = base time - 1ms (source of error); end time = base keep threshold - ms; error": {
 			recordsToSet: []types.TwapRecord{baseRecord},
 			ctxTime:      baseTimePlusKeepPeriod,
 			input:        makeSimpleTwapInput(baseTime.Add(-time.Millisecond), baseTimePlusKeepPeriod.Add(-time.Millisecond), baseQuoteBA),
-			expectError:  twap.TimeTooOldError{Time: baseTime.Add(-time.Millisecond)},
+			expectError:  types.TimeTooOldError{Time: baseTime.Add(-time.Millisecond)},
 		},
 		"(2 records); to now; with one directly at threshold, interpolated": {
 			recordsToSet: []types.TwapRecord{baseRecord, recordBeforeKeepThreshold},
@@ -588,7 +645,7 @@ func (s *TestSuite) TestGetArithmeticTwap_PruningRecordKeepPeriod() {
 
 			if test.expectError != nil {
 				s.Require().Error(err)
-				s.Require().ErrorIs(err, test.expectError)
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectError))
 				return
 			}
 			s.Require().NoError(err)
@@ -737,13 +794,13 @@ func (s *TestSuite) TestGetArithmeticTwapToNow() {
 			recordsToSet:  []types.TwapRecord{baseRecord},
 			ctxTime:       tPlusOne,
 			input:         makeSimpleTwapToNowInput(baseTime.Add(-time.Hour), baseQuoteBA),
-			expectedError: twap.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
+			expectedError: types.TimeTooOldError{Time: baseTime.Add(-time.Hour)},
 		},
 		"start time too new": {
 			recordsToSet:  []types.TwapRecord{baseRecord},
 			ctxTime:       tPlusOne,
 			input:         makeSimpleTwapToNowInput(baseTime.Add(time.Hour), baseQuoteBA),
-			expectedError: types.StartTimeAfterEndTimeError{StartTime: baseTime.Add(time.Hour), EndTime: tPlusOne},
+			expectedError: types.InvalidTimeRangeError{Start: baseTime.Add(time.Hour), End: tPlusOne},
 		},
 		"spot price error in record at record time (start time > record time)": {
 			recordsToSet:  []types.TwapRecord{withLastErrTime(baseRecord, baseTime)},
@@ -769,7 +826,7 @@ func (s *TestSuite) TestGetArithmeticTwapToNow() {
 
 			if test.expectedError != nil {
 				s.Require().Error(err)
-				s.Require().Equal(test.expectedError, err)
+				s.Require().Equal(s.withKeepPeriodDetails(test.expectedError), err)
 				return
 			}
 			s.Require().NoError(err)
@@ -837,3 +894,991 @@ func (s *TestSuite) TestGetArithmeticTwapToNow_ThreeAsset() {
 		})
 	}
 }
+
+// TestGetTwap_StaleMostRecentRecord tests TWAPs over windows that are entirely after the most recent record,
+// as happens when a pool has had no swaps for days. The most recent record's spot prices get extended
+// to both ends of the window, so the TWAP must equal them exactly.
+func (s *TestSuite) TestGetTwap_StaleMostRecentRecord() {
+	now := baseTime.Add(72 * time.Hour)
+	windows := []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+	tests := map[string]struct {
+		record        types.TwapRecord
+		expectedError error
+	}{
+		"no error": {
+			record: baseRecord,
+		},
+		"error before the record time is not propagated": {
+			record: withLastErrTime(baseRecord, baseTime.Add(-time.Second)),
+		},
+		"error at the record time is propagated": {
+			record:        withLastErrTime(baseRecord, baseTime),
+			expectedError: spotPriceError,
+		},
+	}
+	for name, test := range tests {
+		for _, window := range windows {
+			s.Run(fmt.Sprintf("%s, %s window", name, window), func() {
+				s.SetupTest()
+				s.preSetRecords([]types.TwapRecord{test.record})
+				s.Ctx = s.Ctx.WithBlockTime(now)
+				startTime := now.Add(-window)
+
+				checkTwap := func(result sdk.Dec, err error, expTwap sdk.Dec) {
+					if test.expectedError != nil {
+						s.Require().Equal(test.expectedError, err)
+					} else {
+						s.Require().NoError(err)
+					}
+					s.Require().Equal(expTwap, result)
+				}
+
+				// arithmetic, to now
+				result, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, test.record.PoolId, denom0, denom1, startTime)
+				checkTwap(result, err, test.record.P1LastSpotPrice)
+				result, err = s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, test.record.PoolId, denom1, denom0, startTime)
+				checkTwap(result, err, test.record.P0LastSpotPrice)
+
+				// arithmetic, window ending before now
+				result, err = s.twapkeeper.GetArithmeticTwap(s.Ctx, test.record.PoolId, denom0, denom1, startTime.Add(-time.Minute), now.Add(-time.Minute))
+				checkTwap(result, err, test.record.P1LastSpotPrice)
+
+				// geometric: the accumulator is extended by log(sp0) for the whole window
+				startRecord, err := s.twapkeeper.GetInterpolatedRecord(s.Ctx, test.record.PoolId, denom0, denom1, startTime)
+				s.Require().NoError(err)
+				endRecord, err := s.twapkeeper.GetBeginBlockAccumulatorRecord(s.Ctx, test.record.PoolId, denom0, denom1)
+				s.Require().NoError(err)
+				expGeomAccumDiff := types.SpotPriceMulDuration(twap.TwapLog(test.record.P0LastSpotPrice), window)
+				s.Require().Equal(expGeomAccumDiff, endRecord.GeometricTwapAccumulator.Sub(startRecord.GeometricTwapAccumulator))
+
+				geomTwap, err := twap.ComputeTwap(startRecord, endRecord, denom0, twap.GeometricTwapType)
+				if test.expectedError != nil {
+					s.Require().Equal(test.expectedError, err)
+				} else {
+					s.Require().NoError(err)
+				}
+				bounds := types.TwapErrorBounds(types.GeometricTwapType, window, test.record.P0LastSpotPrice)
+				s.Require().Equal(0, bounds.CompareBigDec(osmomath.BigDecFromSDKDec(test.record.P0LastSpotPrice), osmomath.BigDecFromSDKDec(geomTwap)))
+			})
+		}
+	}
+}
+
+// TestGetArithmeticTwap_HistoricalEndTime tests that a TWAP ending before the block time only uses the records
+// at or before its end time, so that a large price move after it doesn't change the result.
+func (s *TestSuite) TestGetArithmeticTwap_HistoricalEndTime() {
+	// the spot price of denom1 in denom0 moves from 2 to 1000 at baseTime + 30s
+	tPlus30sp1000Record := newTwoAssetPoolTwapRecordWithDefaults(
+		baseTime.Add(30*time.Second),
+		sdk.NewDec(1000),
+		OneSec.MulInt64(10*10+5*10+2*10), // accum A
+		OneSec.MulInt64(3+5),             // accum B
+		sdk.ZeroDec(),
+	)
+	recordsBeforeMove := []types.TwapRecord{baseRecord, tPlus10sp5Record, tPlus20sp2Record}
+	recordsWithMove := append(append([]types.TwapRecord{}, recordsBeforeMove...), tPlus30sp1000Record)
+
+	tests := map[string]struct {
+		endTime time.Time
+		expTwap sdk.Dec
+	}{
+		"end time between records": {
+			endTime: baseTime.Add(15 * time.Second),
+			// (10 * 10s + 5 * 5s) / 15s
+			expTwap: sdk.NewDec(125).QuoInt64(15),
+		},
+		"end time at a record": {
+			endTime: baseTime.Add(20 * time.Second),
+			// (10 * 10s + 5 * 10s) / 20s
+			expTwap: sdk.NewDecWithPrec(75, 1),
+		},
+		"end time after the last record before the move": {
+			endTime: baseTime.Add(25 * time.Second),
+			// (10 * 10s + 5 * 10s + 2 * 5s) / 25s
+			expTwap: sdk.NewDecWithPrec(64, 1),
+		},
+		"end time at the record of the move": {
+			endTime: baseTime.Add(30 * time.Second),
+			// (10 * 10s + 5 * 10s + 2 * 10s) / 30s, the new spot price only applies after the record
+			expTwap: sdk.NewDec(170).QuoInt64(30),
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			// the TWAP computed at the end time, as the block time, before the move happened
+			s.SetupTest()
+			for _, record := range recordsBeforeMove {
+				if !record.Time.After(test.endTime) {
+					s.twapkeeper.StoreNewRecord(s.Ctx, record)
+				}
+			}
+			s.Ctx = s.Ctx.WithBlockTime(test.endTime)
+			twapAtEndTime, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, baseRecord.PoolId, denom1, denom0, baseTime)
+			s.Require().NoError(err)
+			s.Require().Equal(test.expTwap, twapAtEndTime)
+
+			// the same TWAP computed later, once the move was recorded
+			s.SetupTest()
+			s.preSetRecords(recordsWithMove)
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+			twap, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, baseRecord.PoolId, denom1, denom0, baseTime, test.endTime)
+			s.Require().NoError(err)
+			s.Require().Equal(twapAtEndTime, twap)
+		})
+	}
+}
+
+// TestGetArithmeticTwap_ErrorTimeAtWindowBoundaries tests that both window bounds are inclusive
+// for spot price errors, whether the bound is exactly at a record time or interpolated onto the error time.
+func (s *TestSuite) TestGetArithmeticTwap_ErrorTimeAtWindowBoundaries() {
+	tPlus10 := baseTime.Add(10 * time.Second)
+	tPlus20 := baseTime.Add(20 * time.Second)
+	// the spot price errored in the block of the second record,
+	// and the third record carries that error time over.
+	erroringRecords := []types.TwapRecord{
+		baseRecord,
+		withLastErrTime(tPlus10sp5Record, tPlus10),
+		withLastErrTime(tPlus20sp2Record, tPlus10),
+	}
+	// malformed record, with an error time after its record time.
+	malformedRecords := []types.TwapRecord{withLastErrTime(baseRecord, baseTime.Add(5*time.Second))}
+
+	tests := map[string]struct {
+		recordsToSet []types.TwapRecord
+		input        getTwapInput
+		expTwap      sdk.Dec
+		expectError  error
+	}{
+		"errTime == startTime": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(tPlus10, tPlus20, baseQuoteBA),
+			expTwap:      sdk.NewDec(5),
+			expectError:  spotPriceError,
+		},
+		"errTime == endTime": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(baseTime, tPlus10, baseQuoteBA),
+			expTwap:      sdk.NewDec(10),
+			expectError:  spotPriceError,
+		},
+		"errTime < startTime, faulty spot price still in effect at startTime": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(tPlus10.Add(time.Second), tPlus20, baseQuoteBA),
+			expTwap:      sdk.NewDec(5),
+			expectError:  spotPriceError,
+		},
+		"errTime < startTime, faulty spot price replaced before startTime": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(tPlus20, baseTime.Add(30*time.Second), baseQuoteBA),
+			expTwap:      sdk.NewDec(2),
+		},
+		"errTime == interpolation target, as startTime": {
+			recordsToSet: malformedRecords,
+			input:        makeSimpleTwapInput(baseTime.Add(5*time.Second), tPlus10, baseQuoteBA),
+			expTwap:      sdk.NewDec(10),
+			expectError:  spotPriceError,
+		},
+		"errTime == interpolation target, as endTime": {
+			recordsToSet: malformedRecords,
+			input:        makeSimpleTwapInput(baseTime, baseTime.Add(5*time.Second), baseQuoteBA),
+			expTwap:      sdk.NewDec(10),
+			expectError:  spotPriceError,
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords(test.recordsToSet)
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			twap, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, test.input.poolId,
+				test.input.baseAssetDenom, test.input.quoteAssetDenom,
+				test.input.startTime, test.input.endTime)
+
+			if test.expectError != nil {
+				s.Require().Equal(test.expectError, err)
+			} else {
+				s.Require().NoError(err)
+			}
+			s.Require().Equal(test.expTwap, twap)
+		})
+	}
+}
+
+// TestGetArithmeticTwapResult tests the records GetArithmeticTwapResult reports having computed the TWAP from, and the
+// last error time it observed.
+func (s *TestSuite) TestGetArithmeticTwapResult() {
+	tPlus10 := baseTime.Add(10 * time.Second)
+	tPlus20 := baseTime.Add(20 * time.Second)
+	// the spot price errored in the block of the second record,
+	// and the third record carries that error time over.
+	erroringRecords := []types.TwapRecord{
+		baseRecord,
+		withLastErrTime(tPlus10sp5Record, tPlus10),
+		withLastErrTime(tPlus20sp2Record, tPlus10),
+	}
+
+	tests := map[string]struct {
+		recordsToSet []types.TwapRecord
+		input        getTwapInput
+		expResult    types.TwapResult
+		expectError  error
+	}{
+		"start and end at records, without errors": {
+			recordsToSet: []types.TwapRecord{baseRecord, tPlus10sp5Record},
+			input:        makeSimpleTwapInput(baseTime, tPlus10, baseQuoteBA),
+			expResult:    types.TwapResult{Price: sdk.NewDec(10), StartRecordTime: baseTime, EndRecordTime: tPlus10},
+		},
+		"interpolated start and end, without errors": {
+			recordsToSet: []types.TwapRecord{baseRecord, tPlus10sp5Record},
+			input:        makeSimpleTwapInput(baseTime.Add(5*time.Second), tPlus10.Add(5*time.Second), baseQuoteBA),
+			expResult: types.TwapResult{
+				Price: sdk.MustNewDecFromStr("7.5"), StartRecordTime: baseTime, EndRecordTime: tPlus10, StartInterpolated: true,
+			},
+		},
+		"error at the end of the window": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(baseTime, tPlus10, baseQuoteBA),
+			expResult:    types.TwapResult{Price: sdk.NewDec(10), StartRecordTime: baseTime, EndRecordTime: tPlus10, LastErrorTime: tPlus10},
+			expectError:  spotPriceError,
+		},
+		"faulty spot price still in effect at the interpolated start": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(tPlus10.Add(time.Second), tPlus20, baseQuoteBA),
+			expResult: types.TwapResult{
+				Price: sdk.NewDec(5), StartRecordTime: tPlus10, EndRecordTime: tPlus20, StartInterpolated: true, LastErrorTime: tPlus10.Add(time.Second),
+			},
+			expectError: spotPriceError,
+		},
+		"error before the window, ending at the block time": {
+			recordsToSet: erroringRecords,
+			input:        makeSimpleTwapInput(tPlus20, tPlusOneMin, baseQuoteBA),
+			expResult:    types.TwapResult{Price: sdk.NewDec(2), StartRecordTime: tPlus20, EndRecordTime: tPlus20, LastErrorTime: tPlus10},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords(test.recordsToSet)
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			result, err := s.twapkeeper.GetArithmeticTwapResult(s.Ctx, test.input.poolId,
+				test.input.baseAssetDenom, test.input.quoteAssetDenom,
+				test.input.startTime, test.input.endTime)
+
+			if test.expectError != nil {
+				s.Require().Equal(test.expectError, err)
+			} else {
+				s.Require().NoError(err)
+			}
+			s.Require().Equal(test.expResult, result)
+
+			// the TWAP alone is the price of the result
+			twap, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, test.input.poolId,
+				test.input.baseAssetDenom, test.input.quoteAssetDenom,
+				test.input.startTime, test.input.endTime)
+			s.Require().Equal(test.expectError, err)
+			s.Require().Equal(result.Price, twap)
+
+			if test.input.endTime.Equal(s.Ctx.BlockTime()) {
+				toNow, err := s.twapkeeper.GetArithmeticTwapToNowResult(s.Ctx, test.input.poolId,
+					test.input.baseAssetDenom, test.input.quoteAssetDenom, test.input.startTime)
+				s.Require().Equal(test.expectError, err)
+				s.Require().Equal(result, toNow)
+			}
+		})
+	}
+}
+
+// TestGetGeometricTwap tests GetGeometricTwap over records whose spot prices are powers of 2, so that the mean of
+// their base 2 logarithms is an integer, and the power approximation is exact.
+func (s *TestSuite) TestGetGeometricTwap() {
+	tPlusTwo := baseTime.Add(2 * time.Second)
+	// sp0 = 2 over [baseTime, tPlusOne], then 8
+	sp2Record := newTwoAssetPoolTwapRecordWithDefaults(baseTime, sdk.NewDec(2), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	sp8Record := newTwoAssetPoolTwapRecordWithDefaults(tPlusOne, sdk.NewDec(8), sdk.NewDec(2000), sdk.NewDec(500), sdk.NewDec(1000))
+
+	tests := map[string]struct {
+		input       getTwapInput
+		expTwap     sdk.Dec
+		expectedErr error
+	}{
+		"one record, quote asset 0": {
+			input:   makeSimpleTwapInput(baseTime, tPlusOne, baseQuoteBA),
+			expTwap: sdk.NewDec(2),
+		},
+		"one record, quote asset 1": {
+			input:   makeSimpleTwapInput(baseTime, tPlusOne, baseQuoteAB),
+			expTwap: sdk.NewDecWithPrec(5, 1),
+		},
+		// 2^((1 * 1s + 3 * 1s) / 2s)
+		"two records, quote asset 0": {
+			input:   makeSimpleTwapInput(baseTime, tPlusTwo, baseQuoteBA),
+			expTwap: sdk.NewDec(4),
+		},
+		"two records, quote asset 1": {
+			input:   makeSimpleTwapInput(baseTime, tPlusTwo, baseQuoteAB),
+			expTwap: sdk.NewDecWithPrec(25, 2),
+		},
+		"end time = now": {
+			input:   makeSimpleTwapInput(tPlusOne, tPlusOneMin, baseQuoteBA),
+			expTwap: sdk.NewDec(8),
+		},
+		// an empty window is the last spot price
+		"start time = end time": {
+			input:   makeSimpleTwapInput(tPlusOne, tPlusOne, baseQuoteBA),
+			expTwap: sdk.NewDec(8),
+		},
+
+		// error catching
+		"start time before the first record": {
+			input:       makeSimpleTwapInput(tMinOne, tPlusOne, baseQuoteBA),
+			expectedErr: types.TimeTooOldError{Time: tMinOne},
+		},
+		"end time in the future": {
+			input:       makeSimpleTwapInput(baseTime, tPlusOneMin.Add(time.Second), baseQuoteBA),
+			expectedErr: types.EndTimeInFutureError{EndTime: tPlusOneMin.Add(time.Second), BlockTime: tPlusOneMin},
+		},
+		"start time after end time": {
+			input:       makeSimpleTwapInput(tPlusTwo, tPlusOne, baseQuoteBA),
+			expectedErr: types.InvalidTimeRangeError{Start: tPlusTwo, End: tPlusOne},
+		},
+		"start time after end time in the future": {
+			input:       makeSimpleTwapInput(tPlusOneMin.Add(2*time.Second), tPlusOneMin.Add(time.Second), baseQuoteBA),
+			expectedErr: types.InvalidTimeRangeError{Start: tPlusOneMin.Add(2 * time.Second), End: tPlusOneMin.Add(time.Second)},
+		},
+		"same base and quote": {
+			input:       getTwapInput{basePoolId, denom0, denom0, baseTime, tPlusOne},
+			expectedErr: types.SameDenomError{Denom: denom0},
+		},
+		"quote asset not in pool": {
+			input:       getTwapInput{basePoolId, denom2, denom0, baseTime, tPlusOne},
+			expectedErr: types.PairNotInPoolError{PoolId: basePoolId, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords([]types.TwapRecord{sp2Record, sp8Record})
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			twap, err := s.twapkeeper.GetGeometricTwap(s.Ctx, test.input.poolId,
+				test.input.baseAssetDenom, test.input.quoteAssetDenom,
+				test.input.startTime, test.input.endTime)
+
+			if test.expectedErr != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(test.expTwap, twap)
+		})
+	}
+}
+
+func (s *TestSuite) TestGetHarmonicTwap() {
+	tests := map[string]struct {
+		input       getTwapInput
+		toNow       bool
+		expTwap     sdk.Dec
+		expectedErr error
+	}{
+		// 1 / ((0.1 * 10s + 0.2 * 10s) / 20s)
+		"two records, quote asset 0": {
+			input:   makeSimpleTwapInput(baseTime, baseTime.Add(20*time.Second), baseQuoteBA),
+			expTwap: sdk.MustNewDecFromStr("6.666666666666666667"),
+		},
+		// 1 / ((10 * 10s + 5 * 10s) / 20s)
+		"two records, quote asset 1": {
+			input:   makeSimpleTwapInput(baseTime, baseTime.Add(20*time.Second), baseQuoteAB),
+			expTwap: sdk.MustNewDecFromStr("0.133333333333333333"),
+		},
+		"one record, quote asset 0": {
+			input:   makeSimpleTwapInput(baseTime, baseTime.Add(5*time.Second), baseQuoteBA),
+			expTwap: sdk.NewDec(10),
+		},
+		// an empty window is the last spot price, as for the other TWAP types
+		"start time = end time": {
+			input:   makeSimpleTwapInput(baseTime.Add(5*time.Second), baseTime.Add(5*time.Second), baseQuoteBA),
+			expTwap: sdk.NewDec(10),
+		},
+		// 1 / ((0.2 * 10s + 0.5 * 40s) / 50s)
+		"to now, quote asset 0": {
+			input:   makeSimpleTwapInput(baseTime.Add(10*time.Second), tPlusOneMin, baseQuoteBA),
+			toNow:   true,
+			expTwap: sdk.MustNewDecFromStr("2.272727272727272727"),
+		},
+
+		// error catching
+		"start time after end time": {
+			input:       makeSimpleTwapInput(baseTime.Add(20*time.Second), baseTime.Add(10*time.Second), baseQuoteBA),
+			expectedErr: types.InvalidTimeRangeError{Start: baseTime.Add(20 * time.Second), End: baseTime.Add(10 * time.Second)},
+		},
+		"start time before the first record": {
+			input:       makeSimpleTwapInput(tMinOne, tPlusOne, baseQuoteBA),
+			expectedErr: types.TimeTooOldError{Time: tMinOne},
+		},
+		"quote asset not in pool": {
+			input:       getTwapInput{basePoolId, denom2, denom0, baseTime, tPlusOne},
+			expectedErr: types.PairNotInPoolError{PoolId: basePoolId, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords([]types.TwapRecord{baseRecord, tPlus10sp5Record, tPlus20sp2Record})
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			var twap sdk.Dec
+			var err error
+			if test.toNow {
+				twap, err = s.twapkeeper.GetHarmonicTwapToNow(s.Ctx, test.input.poolId,
+					test.input.baseAssetDenom, test.input.quoteAssetDenom, test.input.startTime)
+			} else {
+				twap, err = s.twapkeeper.GetHarmonicTwap(s.Ctx, test.input.poolId,
+					test.input.baseAssetDenom, test.input.quoteAssetDenom,
+					test.input.startTime, test.input.endTime)
+			}
+
+			if test.expectedErr != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(test.expTwap, twap)
+		})
+	}
+}
+
+func (s *TestSuite) TestGetHistoricalSpotPrice() {
+	errRecord := withLastErrTime(tPlus20sp2Record, tPlus20sp2Record.Time)
+	keepPeriod := types.DefaultParams().RecordHistoryKeepPeriod
+
+	tests := map[string]struct {
+		ctxTime    time.Time
+		t          time.Time
+		baseDenom  string
+		quoteDenom string
+
+		expSpotPrice   sdk.Dec
+		expRecordTime  time.Time
+		expErrorActive bool
+		expectedError  error
+	}{
+		"t exactly on a record": {
+			t:             baseTime,
+			baseDenom:     denom1,
+			quoteDenom:    denom0,
+			expSpotPrice:  sdk.NewDec(10),
+			expRecordTime: baseTime,
+		},
+		"t exactly on a record, use sp1": {
+			t:             baseTime,
+			baseDenom:     denom0,
+			quoteDenom:    denom1,
+			expSpotPrice:  sdk.NewDecWithPrec(1, 1),
+			expRecordTime: baseTime,
+		},
+		"t between records": {
+			t:             baseTime.Add(15 * time.Second),
+			baseDenom:     denom1,
+			quoteDenom:    denom0,
+			expSpotPrice:  sdk.NewDec(5),
+			expRecordTime: tPlus10sp5Record.Time,
+		},
+		"t exactly on a record with a spot price error": {
+			t:              errRecord.Time,
+			baseDenom:      denom1,
+			quoteDenom:     denom0,
+			expSpotPrice:   sdk.NewDec(2),
+			expRecordTime:  errRecord.Time,
+			expErrorActive: true,
+		},
+		"t after the last record": {
+			t:              tPlusOneMin,
+			baseDenom:      denom1,
+			quoteDenom:     denom0,
+			expSpotPrice:   sdk.NewDec(2),
+			expRecordTime:  errRecord.Time,
+			expErrorActive: true,
+		},
+
+		// error catching
+		"t before history": {
+			t:             baseTime.Add(-time.Second),
+			baseDenom:     denom1,
+			quoteDenom:    denom0,
+			expectedError: types.TimeTooOldError{Time: baseTime.Add(-time.Second)},
+		},
+		"t before the keep period, with a record still stored": {
+			ctxTime:       baseTime.Add(keepPeriod).Add(time.Second),
+			t:             baseTime,
+			baseDenom:     denom1,
+			quoteDenom:    denom0,
+			expectedError: types.TimeTooOldError{Time: baseTime},
+		},
+		"t in the future": {
+			t:             tPlusOneMin.Add(time.Second),
+			baseDenom:     denom1,
+			quoteDenom:    denom0,
+			expectedError: types.TimeInFutureError{Time: tPlusOneMin.Add(time.Second), BlockTime: tPlusOneMin},
+		},
+		"same base and quote": {
+			t:             baseTime,
+			baseDenom:     denom0,
+			quoteDenom:    denom0,
+			expectedError: types.SameDenomError{Denom: denom0},
+		},
+		"denom not in pool": {
+			t:             baseTime,
+			baseDenom:     denom2,
+			quoteDenom:    denom0,
+			expectedError: types.PairNotInPoolError{PoolId: baseRecord.PoolId, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords([]types.TwapRecord{baseRecord, tPlus10sp5Record, errRecord})
+			ctxTime := test.ctxTime
+			if (ctxTime == time.Time{}) {
+				ctxTime = tPlusOneMin
+			}
+			s.Ctx = s.Ctx.WithBlockTime(ctxTime)
+
+			spotPrice, recordTime, errorActive, err := s.twapkeeper.GetHistoricalSpotPrice(s.Ctx, baseRecord.PoolId,
+				test.baseDenom, test.quoteDenom, test.t)
+
+			if test.expectedError != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedError))
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(test.expSpotPrice, spotPrice)
+			s.Require().Equal(test.expRecordTime, recordTime)
+			s.Require().Equal(test.expErrorActive, errorActive)
+		})
+	}
+}
+
+func (s *TestSuite) TestSpotDeviationFromTwap() {
+	tests := map[string]struct {
+		window     time.Duration
+		baseDenom  string
+		quoteDenom string
+
+		expDeviation sdk.Dec
+		expectedErr  error
+	}{
+		// the twap of sp0 is (10 * 10s + 5 * 10s) / 20s = 7.5, the last spot price 2
+		"window since the first record": {
+			window:       20 * time.Second,
+			baseDenom:    denom1,
+			quoteDenom:   denom0,
+			expDeviation: sdk.MustNewDecFromStr("0.733333333333333333"),
+		},
+		// the twap of sp1 is (0.1 * 10s + 0.2 * 10s) / 20s = 0.15, the last spot price 0.5
+		"window since the first record, use sp1": {
+			window:       20 * time.Second,
+			baseDenom:    denom0,
+			quoteDenom:   denom1,
+			expDeviation: sdk.MustNewDecFromStr("2.333333333333333333"),
+		},
+		"window since the second record": {
+			window:       10 * time.Second,
+			baseDenom:    denom1,
+			quoteDenom:   denom0,
+			expDeviation: sdk.NewDecWithPrec(6, 1),
+		},
+
+		// error catching
+		"zero window": {
+			window:      0,
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			expectedErr: types.NonPositiveDurationError{Name: "window", Duration: 0},
+		},
+		"window before the first record": {
+			window:      21 * time.Second,
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			expectedErr: types.TimeTooOldError{Time: baseTime.Add(-time.Second)},
+		},
+		"denom not in pool": {
+			window:      20 * time.Second,
+			baseDenom:   denom2,
+			quoteDenom:  denom0,
+			expectedErr: types.PairNotInPoolError{PoolId: baseRecord.PoolId, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords([]types.TwapRecord{baseRecord, tPlus10sp5Record, tPlus20sp2Record})
+			s.Ctx = s.Ctx.WithBlockTime(tPlus20sp2Record.Time)
+
+			deviation, err := s.twapkeeper.SpotDeviationFromTwap(s.Ctx, baseRecord.PoolId, test.baseDenom, test.quoteDenom, test.window)
+
+			if test.expectedErr != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(test.expDeviation, deviation)
+		})
+	}
+}
+
+func (s *TestSuite) TestGetTwapCandles() {
+	errRecord := withLastErrTime(tPlus20sp2Record, tPlus20sp2Record.Time)
+	// the records are written at baseTime, baseTime + 10s and baseTime + 20s, the bounds in between are interpolated
+	interpolated := func(bound time.Duration) bool {
+		return bound != 0 && bound != 10*time.Second && bound != 20*time.Second
+	}
+	// newCandle returns the candle over [baseTime + start, baseTime + end]
+	newCandle := func(start, end time.Duration, twap, firstSpotPrice, lastSpotPrice sdk.Dec, hasRecords bool) types.TwapCandle {
+		return types.TwapCandle{
+			StartTime:         baseTime.Add(start),
+			EndTime:           baseTime.Add(end),
+			ArithmeticTwap:    twap,
+			FirstSpotPrice:    firstSpotPrice,
+			LastSpotPrice:     lastSpotPrice,
+			HasRecords:        hasRecords,
+			StartInterpolated: interpolated(start),
+			EndInterpolated:   interpolated(end),
+		}
+	}
+	withErrorActive := func(candle types.TwapCandle) types.TwapCandle {
+		candle.ErrorActive = true
+		return candle
+	}
+
+	// The history is: spot price 10 from baseTime, 5 from baseTime + 10s and 2 from baseTime + 20s,
+	// for denom1 in denom0. The spot prices of denom0 in denom1 are 0.1, 0.2 and 0.5.
+	tests := map[string]struct {
+		records    []types.TwapRecord
+		baseDenom  string
+		quoteDenom string
+		startTime  time.Time
+		endTime    time.Time
+		interval   time.Duration
+
+		expCandles  []types.TwapCandle
+		expectedErr error
+	}{
+		"one record per interval, then none": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(40 * time.Second),
+			interval:   10 * time.Second,
+			expCandles: []types.TwapCandle{
+				newCandle(0, 10*time.Second, sdk.NewDec(10), sdk.NewDec(10), sdk.NewDec(10), true),
+				newCandle(10*time.Second, 20*time.Second, sdk.NewDec(5), sdk.NewDec(5), sdk.NewDec(5), true),
+				newCandle(20*time.Second, 30*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), true),
+				newCandle(30*time.Second, 40*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), false),
+			},
+		},
+		"several records per interval": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(40 * time.Second),
+			interval:   20 * time.Second,
+			expCandles: []types.TwapCandle{
+				// (10 * 10s + 5 * 10s) / 20s
+				newCandle(0, 20*time.Second, sdk.NewDecWithPrec(75, 1), sdk.NewDec(10), sdk.NewDec(5), true),
+				newCandle(20*time.Second, 40*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), true),
+			},
+		},
+		"several records per interval, use sp1": {
+			baseDenom:  denom0,
+			quoteDenom: denom1,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(40 * time.Second),
+			interval:   20 * time.Second,
+			expCandles: []types.TwapCandle{
+				// (0.1 * 10s + 0.2 * 10s) / 20s
+				newCandle(0, 20*time.Second, sdk.NewDecWithPrec(15, 2), sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), true),
+				newCandle(20*time.Second, 40*time.Second, sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1), true),
+			},
+		},
+		"intervals not aligned with records, last one truncated": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(40 * time.Second),
+			interval:   15 * time.Second,
+			expCandles: []types.TwapCandle{
+				// (10 * 10s + 5 * 5s) / 15s
+				newCandle(0, 15*time.Second, sdk.NewDec(25).QuoInt64(3), sdk.NewDec(10), sdk.NewDec(5), true),
+				// (5 * 5s + 2 * 10s) / 15s
+				newCandle(15*time.Second, 30*time.Second, sdk.NewDec(3), sdk.NewDec(2), sdk.NewDec(2), true),
+				newCandle(30*time.Second, 40*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), false),
+			},
+		},
+		"start between records carries the previous spot price forward": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime.Add(5 * time.Second),
+			endTime:    baseTime.Add(9 * time.Second),
+			interval:   2 * time.Second,
+			expCandles: []types.TwapCandle{
+				newCandle(5*time.Second, 7*time.Second, sdk.NewDec(10), sdk.NewDec(10), sdk.NewDec(10), false),
+				newCandle(7*time.Second, 9*time.Second, sdk.NewDec(10), sdk.NewDec(10), sdk.NewDec(10), false),
+			},
+		},
+		"spot price error": {
+			records:    []types.TwapRecord{baseRecord, tPlus10sp5Record, errRecord},
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(40 * time.Second),
+			interval:   10 * time.Second,
+			expCandles: []types.TwapCandle{
+				newCandle(0, 10*time.Second, sdk.NewDec(10), sdk.NewDec(10), sdk.NewDec(10), true),
+				// the error at the end of the interval is reported, as for GetArithmeticTwap
+				withErrorActive(newCandle(10*time.Second, 20*time.Second, sdk.NewDec(5), sdk.NewDec(5), sdk.NewDec(5), true)),
+				withErrorActive(newCandle(20*time.Second, 30*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), true)),
+				withErrorActive(newCandle(30*time.Second, 40*time.Second, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), false)),
+			},
+		},
+		"up to the block time": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime.Add(40 * time.Second),
+			endTime:    tPlusOneMin,
+			interval:   time.Hour,
+			expCandles: []types.TwapCandle{
+				newCandle(40*time.Second, time.Minute, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2), false),
+			},
+		},
+		"as many intervals as allowed": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(twap.MaxTwapCandles * 100 * time.Millisecond),
+			interval:   100 * time.Millisecond,
+		},
+
+		// error catching
+		"too many intervals": {
+			baseDenom:  denom1,
+			quoteDenom: denom0,
+			startTime:  baseTime,
+			endTime:    baseTime.Add(twap.MaxTwapCandles*100*time.Millisecond + time.Millisecond),
+			interval:   100 * time.Millisecond,
+			expectedErr: types.TooManyCandlesError{
+				StartTime:  baseTime,
+				EndTime:    baseTime.Add(twap.MaxTwapCandles*100*time.Millisecond + time.Millisecond),
+				Interval:   100 * time.Millisecond,
+				NumCandles: twap.MaxTwapCandles + 1,
+				MaxCandles: twap.MaxTwapCandles,
+			},
+		},
+		"zero interval": {
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			startTime:   baseTime,
+			endTime:     baseTime.Add(40 * time.Second),
+			expectedErr: types.NonPositiveDurationError{Name: "interval", Duration: 0},
+		},
+		"start time equal to end time": {
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			startTime:   baseTime.Add(10 * time.Second),
+			endTime:     baseTime.Add(10 * time.Second),
+			interval:    time.Second,
+			expectedErr: types.InvalidTimeRangeError{Start: baseTime.Add(10 * time.Second), End: baseTime.Add(10 * time.Second)},
+		},
+		"end time in the future": {
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			startTime:   baseTime,
+			endTime:     tPlusOneMin.Add(time.Second),
+			interval:    10 * time.Second,
+			expectedErr: types.EndTimeInFutureError{EndTime: tPlusOneMin.Add(time.Second), BlockTime: tPlusOneMin},
+		},
+		"start time before history": {
+			baseDenom:   denom1,
+			quoteDenom:  denom0,
+			startTime:   baseTime.Add(-time.Second),
+			endTime:     baseTime.Add(40 * time.Second),
+			interval:    10 * time.Second,
+			expectedErr: types.TimeTooOldError{Time: baseTime.Add(-time.Second)},
+		},
+		"same base and quote": {
+			baseDenom:   denom0,
+			quoteDenom:  denom0,
+			startTime:   baseTime,
+			endTime:     baseTime.Add(40 * time.Second),
+			interval:    10 * time.Second,
+			expectedErr: types.SameDenomError{Denom: denom0},
+		},
+		"denom not in pool": {
+			baseDenom:   denom2,
+			quoteDenom:  denom0,
+			startTime:   baseTime,
+			endTime:     baseTime.Add(40 * time.Second),
+			interval:    10 * time.Second,
+			expectedErr: types.PairNotInPoolError{PoolId: baseRecord.PoolId, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			records := test.records
+			if records == nil {
+				records = []types.TwapRecord{baseRecord, tPlus10sp5Record, tPlus20sp2Record}
+			}
+			s.preSetRecords(records)
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			candles, err := s.twapkeeper.GetTwapCandles(s.Ctx, baseRecord.PoolId,
+				test.baseDenom, test.quoteDenom, test.startTime, test.endTime, test.interval)
+
+			if test.expectedErr != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
+				return
+			}
+			s.Require().NoError(err)
+			if test.expCandles == nil {
+				s.Require().Len(candles, twap.MaxTwapCandles)
+				return
+			}
+			s.Require().Equal(test.expCandles, candles)
+		})
+	}
+}
+
+func (s *TestSuite) TestGetPoolIdsForDenomPair() {
+	getPoolIds := func(denomA, denomB string) []uint64 {
+		poolIds, err := s.twapkeeper.GetPoolIdsForDenomPair(s.Ctx, denomA, denomB)
+		s.Require().NoError(err)
+		return poolIds
+	}
+
+	// pools are indexed by every pair of their denoms when they are created
+	poolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	otherPoolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	s.Require().Equal([]uint64{poolIdAB, poolIdABC, otherPoolIdAB}, getPoolIds(denom0, denom1))
+	s.Require().Equal([]uint64{poolIdAB, poolIdABC, otherPoolIdAB}, getPoolIds(denom1, denom0))
+	s.Require().Equal([]uint64{poolIdABC}, getPoolIds(denom0, denom2))
+	s.Require().Equal([]uint64{poolIdABC}, getPoolIds(denom2, denom1))
+	s.Require().Empty(getPoolIds(denom0, "token/D"))
+
+	// updating the records doesn't change the index
+	s.Ctx = s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Second))
+	s.Require().NoError(s.twapkeeper.UpdateRecords(s.Ctx, poolIdAB))
+	s.Require().Equal([]uint64{poolIdAB, poolIdABC, otherPoolIdAB}, getPoolIds(denom0, denom1))
+
+	// a renamed denom moves the pool to the renamed pair
+	s.Require().NoError(s.twapkeeper.MigratePairDenom(s.Ctx, poolIdAB, denom1, "token/D"))
+	s.Require().Equal([]uint64{poolIdABC, otherPoolIdAB}, getPoolIds(denom0, denom1))
+	s.Require().Equal([]uint64{poolIdAB}, getPoolIds("token/D", denom0))
+
+	_, err := s.twapkeeper.GetPoolIdsForDenomPair(s.Ctx, denom0, denom0)
+	s.Require().Error(err)
+}
+
+func (s *TestSuite) TestGetPoolTwapPairs() {
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+
+	pairs, err := s.twapkeeper.GetPoolTwapPairs(s.Ctx, poolIdABC)
+	s.Require().NoError(err)
+	s.Require().Equal([]types.DenomPair{{Denom0: denom0, Denom1: denom1}, {Denom0: denom0, Denom1: denom2}, {Denom0: denom1, Denom1: denom2}}, pairs)
+
+	// a pool without records has no pairs
+	pairs, err = s.twapkeeper.GetPoolTwapPairs(s.Ctx, poolIdABC+1)
+	s.Require().NoError(err)
+	s.Require().Empty(pairs)
+}
+
+// TestPairNotInPoolErrorHasPoolDenoms tests that a TWAP of a pool queried with denoms it doesn't have errors with the
+// denoms it has, e.g. for denoms of another pool.
+func (s *TestSuite) TestPairNotInPoolErrorHasPoolDenoms() {
+	poolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdAD := s.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin(denom0, 1_000_000_000), sdk.NewInt64Coin(denom3, 1_000_000_000))
+	startTime := s.Ctx.BlockTime()
+	s.Ctx = s.Ctx.WithBlockTime(startTime.Add(time.Minute))
+	expErr := types.PairNotInPoolError{PoolId: poolIdAB, Asset0Denom: denom0, Asset1Denom: denom3, PoolDenoms: []string{denom0, denom1}}
+
+	_, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, poolIdAB, denom3, denom0, startTime, s.Ctx.BlockTime())
+	s.Require().Equal(expErr, err)
+	s.Require().ErrorIs(err, types.ErrRecordNotFound)
+	s.Require().ErrorContains(err, "the pool has denoms token/A, token/B")
+
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolIdAB, denom3, denom0, startTime)
+	s.Require().Equal(expErr, err)
+
+	// the pair is in the other pool
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolIdAD, denom3, denom0, startTime)
+	s.Require().NoError(err)
+
+	// a pool that doesn't exist has no denoms
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolIdAD+1, denom3, denom0, startTime)
+	s.Require().Equal(types.PairNotInPoolError{PoolId: poolIdAD + 1, Asset0Denom: denom0, Asset1Denom: denom3}, err)
+}
+
+// TestCombineTwapsMatchesKeeper checks that combining the TWAPs over [a, b] and [b, c] with types.CombineArithmeticTwaps
+// and types.CombineGeometricTwaps gives the TWAP the keeper computes over [a, c], for random splits of random windows of
+// a synthetic history, quoted in either asset. Geometric TWAPs are only bounded for asset 0 prices of at least 1, so
+// the prices are kept between 1 and 10^6.
+func (s *TestSuite) TestCombineTwapsMatchesKeeper() {
+	minPrice, maxPrice := sdk.OneDec(), sdk.NewDec(1_000_000)
+	arithmeticTolerance := osmomath.ErrTolerance{AdditiveTolerance: sdk.SmallestDec()}
+	geometricTolerance := osmomath.ErrTolerance{MultiplicativeTolerance: types.GeometricTwapPowPrecision.MulInt64(6)}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		s.SetupTest()
+
+		// a price with 7 significant digits, between 1 and 10^6, changing at every record
+		price := sdk.NewDecWithPrec(1_000_000+r.Int63n(9_000_000), 6-int64(r.Intn(6)))
+		record := newTwoAssetPoolTwapRecordWithDefaults(baseTime.Add(time.Duration(r.Int63n(int64(time.Second)))), price, sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+		records := []types.TwapRecord{record}
+		for j := 0; j < 1+r.Intn(10); j++ {
+			record = twap.RecordWithUpdatedAccumulators(record, record.Time.Add(time.Duration(1+r.Int63n(int64(time.Hour)))))
+			price = sdk.MinDec(sdk.MaxDec(price.Mul(sdk.NewDecWithPrec(500_000+r.Int63n(1_000_001), 6)), minPrice), maxPrice)
+			record.P0LastSpotPrice, record.P1LastSpotPrice = price, sdk.OneDec().Quo(price)
+			records = append(records, record)
+		}
+		s.preSetRecords(records)
+		s.Ctx = s.Ctx.WithBlockTime(record.Time.Add(time.Hour))
+
+		// a <= b <= c, anywhere from the first record to before the block time, as a TWAP to now also reads the pool's
+		// current spot price
+		span := int64(s.Ctx.BlockTime().Sub(records[0].Time))
+		splits := []int64{r.Int63n(span), r.Int63n(span), r.Int63n(span)}
+		sort.Slice(splits, func(i, j int) bool { return splits[i] < splits[j] })
+		a, b, c := records[0].Time.Add(time.Duration(splits[0])), records[0].Time.Add(time.Duration(splits[1])), records[0].Time.Add(time.Duration(splits[2]))
+		durationAB, durationBC := types.AccumulatorTimeDelta(a, b), types.AccumulatorTimeDelta(b, c)
+
+		for _, denoms := range [][2]string{{denom0, denom1}, {denom1, denom0}} {
+			baseDenom, quoteDenom := denoms[0], denoms[1]
+
+			arithmeticAB, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, 1, baseDenom, quoteDenom, a, b)
+			s.Require().NoError(err)
+			arithmeticBC, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, 1, baseDenom, quoteDenom, b, c)
+			s.Require().NoError(err)
+			arithmeticAC, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, 1, baseDenom, quoteDenom, a, c)
+			s.Require().NoError(err)
+			combined, err := types.CombineArithmeticTwaps(arithmeticAB, durationAB, arithmeticBC, durationBC)
+			s.Require().NoError(err)
+			s.Require().Equal(0, arithmeticTolerance.CompareBigDec(osmomath.BigDecFromSDKDec(arithmeticAC), osmomath.BigDecFromSDKDec(combined)),
+				"iteration %d, quote %s: combined arithmetic twap %s, keeper %s", i, quoteDenom, combined, arithmeticAC)
+
+			geometricAB, err := s.twapkeeper.GetGeometricTwap(s.Ctx, 1, baseDenom, quoteDenom, a, b)
+			s.Require().NoError(err)
+			geometricBC, err := s.twapkeeper.GetGeometricTwap(s.Ctx, 1, baseDenom, quoteDenom, b, c)
+			s.Require().NoError(err)
+			geometricAC, err := s.twapkeeper.GetGeometricTwap(s.Ctx, 1, baseDenom, quoteDenom, a, c)
+			s.Require().NoError(err)
+			combined, err = types.CombineGeometricTwaps(geometricAB, durationAB, geometricBC, durationBC)
+			s.Require().NoError(err)
+			s.Require().Equal(0, geometricTolerance.CompareBigDec(osmomath.BigDecFromSDKDec(geometricAC), osmomath.BigDecFromSDKDec(combined)),
+				"iteration %d, quote %s: combined geometric twap %s, keeper %s", i, quoteDenom, combined, geometricAC)
+		}
+	}
+}
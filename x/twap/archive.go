@@ -0,0 +1,198 @@
+package twap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// MaxArchivedRecordsLimit is the maximum number of records GetArchivedRecords returns at once.
+const MaxArchivedRecordsLimit = 1000
+
+// recordArchive is the archive of the records written by the node since it enabled it, for the TWAPs older than
+// the record history keep period. It is not part of the state machine: it lives in a database of the node, outside
+// of the multistore, that pruning never touches, and it is configured per node in app.toml.
+//
+// The records written in a block are first held in a transient store, so that those of discarded cache contexts
+// and of CheckTx are dropped, and copied into the database at the end of the block, at the same keys as the
+// historical pool index. The archive isn't versioned: a query reads the records archived up to the latest block,
+// whatever the height of its state.
+type recordArchive struct {
+	db         dbm.DB
+	pendingKey *sdk.TransientStoreKey
+}
+
+// SetArchive enables the archive of the node in db, with the records written in a block pending in the transient
+// store of pendingKey, or disables it if db is nil.
+// The keeper copies made before share the archive.
+func (k *Keeper) SetArchive(db dbm.DB, pendingKey *sdk.TransientStoreKey) {
+	if k.archive == nil {
+		k.archive = &recordArchive{}
+	}
+	k.archive.db = db
+	k.archive.pendingKey = pendingKey
+}
+
+// IsArchiveEnabled returns whether the node archives the records it writes
+func (k Keeper) IsArchiveEnabled() bool {
+	return k.archive != nil && k.archive.db != nil
+}
+
+// pendingArchiveStore returns the transient store of the records written in the block.
+// It doesn't consume gas, so that enabling the archive doesn't change the gas used by the node.
+func (k Keeper) pendingArchiveStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).TransientStore(k.archive.pendingKey)
+}
+
+// markForArchive holds twap for flushArchive to archive it at the end of the block, if the archive is enabled
+func (k Keeper) markForArchive(ctx sdk.Context, twap types.TwapRecord) {
+	if !k.IsArchiveEnabled() {
+		return
+	}
+	key := types.FormatHistoricalPoolIndexTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom, twap.Time)
+	osmoutils.MustSet(k.pendingArchiveStore(ctx), key, &twap)
+}
+
+// flushArchive copies the records written in the block into the archive. Writing the same record twice is a no-op,
+// so a block replayed after a crash can flush again.
+// A failure to write the archive is logged, as it must not halt the node.
+func (k Keeper) flushArchive(ctx sdk.Context) {
+	if !k.IsArchiveEnabled() {
+		return
+	}
+	iter := k.pendingArchiveStore(ctx).Iterator(nil, nil)
+	defer iter.Close()
+
+	batch := k.archive.db.NewBatch()
+	defer batch.Close()
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		if err := batch.Set(iter.Key(), iter.Value()); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("error archiving twap records: %s", err))
+			return
+		}
+		count++
+	}
+	if count == 0 {
+		return
+	}
+	if err := batch.WriteSync(); err != nil {
+		ctx.Logger().Error(fmt.Sprintf("error archiving twap records: %s", err))
+		return
+	}
+	telemetry.IncrCounter(float32(count), types.ModuleName, types.MetricKeyArchive, types.MetricKeyRecords)
+}
+
+// archiveStore returns the archive as a KVStore, to read it with the store helpers
+func (k Keeper) archiveStore() sdk.KVStore {
+	return dbadapter.Store{DB: k.archive.db}
+}
+
+// GetArchivedRecords returns the archived records of the denom pair of assetA and assetB of pool poolId written
+// from startTime to endTime, both inclusive, in ascending time order.
+// At most limit records are returned, or MaxArchivedRecordsLimit if limit is 0 or above it. When there are more,
+// next is the time of the first record left, to query the following ones from.
+// Returns ArchiveDisabledError if the node doesn't archive its records.
+func (k Keeper) GetArchivedRecords(
+	ctx sdk.Context,
+	poolId uint64,
+	assetA, assetB string,
+	startTime, endTime time.Time,
+	limit uint64,
+) (records []types.TwapRecord, next *time.Time, err error) {
+	if !k.IsArchiveEnabled() {
+		return nil, nil, types.ArchiveDisabledError{}
+	}
+	if startTime.After(endTime) {
+		return nil, nil, types.InvalidTimeRangeError{Start: startTime, End: endTime}
+	}
+	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(assetA, assetB)
+	if err != nil {
+		return nil, nil, err
+	}
+	if limit == 0 || limit > MaxArchivedRecordsLimit {
+		limit = MaxArchivedRecordsLimit
+	}
+
+	start := types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, startTime)
+	// the end of the range is exclusive, so we append a zero byte to get the smallest key after the end time's
+	end := append(types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, endTime), 0)
+	iter := k.archiveStore().Iterator(start, end)
+	defer iter.Close()
+
+	records = []types.TwapRecord{}
+	for ; iter.Valid(); iter.Next() {
+		record, err := types.ParseTwapFromBz(iter.Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		if uint64(len(records)) == limit {
+			return records, &record.Time, nil
+		}
+		records = append(records, record)
+	}
+	return records, nil, nil
+}
+
+// GetArchivedArithmeticTwap returns the arithmetic TWAP of GetArithmeticTwap computed from the archived records,
+// so that startTime may be older than the record history keep period. It only goes as far back as the first record
+// archived by the node, and errors with ArchivedRecordNotFoundError before it.
+// Like GetArithmeticTwap, it errors if the pool is quarantined or if the window overlaps a tracking gap of the pool.
+// Returns ArchiveDisabledError if the node doesn't archive its records.
+func (k Keeper) GetArchivedArithmeticTwap(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (sdk.Dec, error) {
+	if !k.IsArchiveEnabled() {
+		return sdk.Dec{}, types.ArchiveDisabledError{}
+	}
+	if startTime.After(endTime) {
+		return sdk.Dec{}, types.InvalidTimeRangeError{Start: startTime, End: endTime}
+	}
+	if endTime.After(ctx.BlockTime()) {
+		return sdk.Dec{}, types.EndTimeInFutureError{EndTime: endTime, BlockTime: ctx.BlockTime()}
+	}
+	if k.isPoolQuarantined(ctx, poolId) {
+		return sdk.Dec{}, types.PoolQuarantinedError{PoolId: poolId}
+	}
+	startRecord, err := k.getArchivedInterpolatedRecord(poolId, startTime, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	endRecord, err := k.getArchivedInterpolatedRecord(poolId, endTime, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if err := k.checkTrackingGaps(ctx, poolId, startTime, endTime); err != nil {
+		return sdk.Dec{}, err
+	}
+	arithmeticStrategy := &arithmetic{k}
+	return arithmeticStrategy.computeTwap(startRecord, endRecord, quoteAssetDenom)
+}
+
+// getArchivedInterpolatedRecord is getInterpolatedRecord, reading the archive rather than the state
+func (k Keeper) getArchivedInterpolatedRecord(poolId uint64, t time.Time, assetA, assetB string) (types.TwapRecord, error) {
+	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(assetA, assetB)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+	prefix := types.FormatHistoricalPoolIndexTimePrefix(poolId, asset0Denom, asset1Denom)
+	key := types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, t)
+	record, err := osmoutils.GetLastValueBeforeOrAtKey(k.archiveStore(), prefix, key, types.ParseTwapFromBz)
+	if err != nil {
+		return types.TwapRecord{}, types.ArchivedRecordNotFoundError{PoolId: poolId, Asset0Denom: asset0Denom, Asset1Denom: asset1Denom, Time: t}
+	}
+	record.LastErrorTime = interpolatedLastErrorTime(record, t)
+	return recordWithUpdatedAccumulators(record, t), nil
+}
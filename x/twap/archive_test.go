@@ -0,0 +1,234 @@
+package twap_test
+
+import (
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/osmosis-labs/osmosis/v13/app/apptesting"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// enableArchive enables the archive of the keeper in a new in-memory database
+func (s *TestSuite) enableArchive() {
+	s.twapkeeper.SetArchive(dbm.NewMemDB(), s.App.GetTKey(types.ArchiveTransientStoreKey))
+}
+
+func (s *TestSuite) TestArchiveDisabled() {
+	s.Require().False(s.twapkeeper.IsArchiveEnabled())
+
+	_, _, err := s.twapkeeper.GetArchivedRecords(s.Ctx, 1, denom0, denom1, baseTime, baseTime, 0)
+	s.Require().ErrorIs(err, types.ArchiveDisabledError{})
+	_, err = s.twapkeeper.GetArchivedArithmeticTwap(s.Ctx, 1, denom0, denom1, baseTime, baseTime)
+	s.Require().ErrorIs(err, types.ArchiveDisabledError{})
+}
+
+func (s *TestSuite) TestGetArchivedRecords() {
+	records := []types.TwapRecord{}
+	for i := 0; i < 5; i++ {
+		records = append(records, newEmptyPriceRecord(1, baseTime.Add(time.Duration(i)*time.Minute), denom0, denom1))
+	}
+	otherPoolRecord := newEmptyPriceRecord(2, baseTime, denom0, denom1)
+	nextTime := records[2].Time
+
+	tests := map[string]struct {
+		assetA, assetB     string
+		startTime, endTime time.Time
+		limit              uint64
+		expRecords         []types.TwapRecord
+		expNext            *time.Time
+		expErr             error
+	}{
+		"all records": {
+			assetA: denom0, assetB: denom1, startTime: baseTime, endTime: records[4].Time,
+			expRecords: records,
+		},
+		"denoms in reverse order": {
+			assetA: denom1, assetB: denom0, startTime: baseTime, endTime: records[4].Time,
+			expRecords: records,
+		},
+		"both bounds are inclusive": {
+			assetA: denom0, assetB: denom1, startTime: records[1].Time, endTime: records[3].Time,
+			expRecords: records[1:4],
+		},
+		"window between records": {
+			assetA: denom0, assetB: denom1, startTime: records[1].Time.Add(time.Second), endTime: records[2].Time.Add(-time.Second),
+			expRecords: []types.TwapRecord{},
+		},
+		"limit with records left": {
+			assetA: denom0, assetB: denom1, startTime: baseTime, endTime: records[4].Time, limit: 2,
+			expRecords: records[:2], expNext: &nextTime,
+		},
+		"limit without records left": {
+			assetA: denom0, assetB: denom1, startTime: baseTime, endTime: records[4].Time, limit: 5,
+			expRecords: records,
+		},
+		"denom pair not in pool": {
+			assetA: denom0, assetB: denom2, startTime: baseTime, endTime: records[4].Time,
+			expRecords: []types.TwapRecord{},
+		},
+		"start time after end time": {
+			assetA: denom0, assetB: denom1, startTime: tPlusOne, endTime: baseTime,
+			expErr: types.InvalidTimeRangeError{Start: tPlusOne, End: baseTime},
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.enableArchive()
+			s.preSetRecords(append([]types.TwapRecord{otherPoolRecord}, records...))
+			s.twapkeeper.FlushArchive(s.Ctx)
+
+			archived, next, err := s.twapkeeper.GetArchivedRecords(s.Ctx, 1, tc.assetA, tc.assetB, tc.startTime, tc.endTime, tc.limit)
+			if tc.expErr != nil {
+				s.Require().Equal(tc.expErr, err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expRecords, archived)
+			s.Require().Equal(tc.expNext, next)
+		})
+	}
+}
+
+// TestArchiveOnlyKeepsWrittenRecords tests that the records of discarded cache contexts are not archived, and that
+// marking records for the archive consumes no gas.
+func (s *TestSuite) TestArchiveOnlyKeepsWrittenRecords() {
+	s.enableArchive()
+	record := newEmptyPriceRecord(1, baseTime, denom0, denom1)
+
+	discardedCtx, _ := s.Ctx.CacheContext()
+	s.twapkeeper.StoreNewRecord(discardedCtx, record)
+	s.twapkeeper.FlushArchive(s.Ctx)
+	archived, _, err := s.twapkeeper.GetArchivedRecords(s.Ctx, 1, denom0, denom1, baseTime, baseTime, 0)
+	s.Require().NoError(err)
+	s.Require().Empty(archived)
+
+	gasMeter := sdk.NewInfiniteGasMeter()
+	s.twapkeeper.StoreNewRecord(s.Ctx.WithGasMeter(gasMeter), record)
+	s.twapkeeper.FlushArchive(s.Ctx)
+	archived, _, err = s.twapkeeper.GetArchivedRecords(s.Ctx, 1, denom0, denom1, baseTime, baseTime, 0)
+	s.Require().NoError(err)
+	s.Require().Equal([]types.TwapRecord{record}, archived)
+
+	s.SetupTest()
+	disabledGasMeter := sdk.NewInfiniteGasMeter()
+	s.twapkeeper.StoreNewRecord(s.Ctx.WithGasMeter(disabledGasMeter), record)
+	s.Require().Equal(disabledGasMeter.GasConsumed(), gasMeter.GasConsumed())
+}
+
+func (s *TestSuite) TestGetArchivedArithmeticTwap() {
+	s.enableArchive()
+	// the price of token/B is 10 token/A for an hour, then 20 token/A
+	s.preSetRecords([]types.TwapRecord{
+		newRecord(1, baseTime, sdk.NewDec(10), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+		newRecord(1, baseTime.Add(time.Hour), sdk.NewDec(20), sdk.NewDec(36_000_000), sdk.NewDec(360_000), sdk.ZeroDec()),
+	})
+	s.twapkeeper.FlushArchive(s.Ctx)
+	endTime := baseTime.Add(2 * time.Hour)
+	s.Ctx = s.Ctx.WithBlockTime(endTime)
+
+	// the archive gives the TWAP of the state while the records are kept
+	twap, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, 1, denom1, denom0, baseTime, endTime)
+	s.Require().NoError(err)
+	s.Require().Equal(sdk.NewDec(15).String(), twap.String())
+	archivedTwap, err := s.twapkeeper.GetArchivedArithmeticTwap(s.Ctx, 1, denom1, denom0, baseTime, endTime)
+	s.Require().NoError(err)
+	s.Require().Equal(twap.String(), archivedTwap.String())
+
+	// and keeps giving it once the records are pruned
+	s.Ctx = s.Ctx.WithBlockTime(baseTime.Add(100 * time.Hour))
+	s.Require().NoError(s.twapkeeper.PruneRecords(s.Ctx))
+	_, err = s.twapkeeper.GetArithmeticTwap(s.Ctx, 1, denom1, denom0, baseTime, endTime)
+	s.Require().ErrorAs(err, &types.TimeTooOldError{})
+	archivedTwap, err = s.twapkeeper.GetArchivedArithmeticTwap(s.Ctx, 1, denom1, denom0, baseTime, endTime)
+	s.Require().NoError(err)
+	s.Require().Equal(sdk.NewDec(15).String(), archivedTwap.String())
+
+	// before the first archived record
+	_, err = s.twapkeeper.GetArchivedArithmeticTwap(s.Ctx, 1, denom1, denom0, tMinOne, endTime)
+	s.Require().True(errors.Is(err, types.ErrRecordNotFound))
+	s.Require().Equal(types.ArchivedRecordNotFoundError{PoolId: 1, Asset0Denom: denom0, Asset1Denom: denom1, Time: tMinOne}, err)
+
+	// the window must be in the past
+	_, err = s.twapkeeper.GetArchivedArithmeticTwap(s.Ctx, 1, denom1, denom0, baseTime, s.Ctx.BlockTime().Add(time.Second))
+	s.Require().ErrorAs(err, &types.EndTimeInFutureError{})
+}
+
+// TestArchiveKeepsConsensusStateIdentical runs the same blocks on two apps, one of which archives its records, and
+// tests that their app hashes and gas consumption are identical, while only the archiving one keeps pruned records.
+func (s *TestSuite) TestArchiveKeepsConsensusStateIdentical() {
+	disabled := s.runArchiveScenario(nil)
+	archiveDB := dbm.NewMemDB()
+	enabled := s.runArchiveScenario(archiveDB)
+
+	s.Require().Equal(disabled.appHashes, enabled.appHashes)
+	s.Require().Equal(disabled.gasUsed, enabled.gasUsed)
+	s.Require().Equal(disabled.twapStore, enabled.twapStore)
+
+	// the state only has the records of the bar/foo pair within the keep period, and the newest one before it, but
+	// the archive has all of them
+	s.Require().Less(len(enabled.stateRecords), len(enabled.archivedRecords))
+	for _, record := range enabled.stateRecords {
+		s.Require().Contains(enabled.archivedRecords, record)
+	}
+	s.Require().Equal(enabled.createTime, enabled.archivedRecords[0].Time)
+}
+
+type archiveScenarioResult struct {
+	appHashes       [][]byte
+	gasUsed         []uint64
+	twapStore       [][2][]byte
+	createTime      time.Time
+	stateRecords    []types.TwapRecord // of the bar/foo pair
+	archivedRecords []types.TwapRecord // of the bar/foo pair
+}
+
+// runArchiveScenario creates a pool on a new app, with the archive of the node in archiveDB or disabled if it is nil,
+// then swaps against the pool and prunes the records in each of several blocks spanning more than the keep period.
+func (s *TestSuite) runArchiveScenario(archiveDB dbm.DB) archiveScenarioResult {
+	h := apptesting.KeeperTestHelper{}
+	h.SetT(s.T())
+	h.Setup()
+	if archiveDB != nil {
+		h.App.TwapKeeper.SetArchive(archiveDB, h.App.GetTKey(types.ArchiveTransientStoreKey))
+	}
+	// the accounts and the block time of the setup are random, so the scenario sets its own
+	h.TestAccs = []sdk.AccAddress{sdk.AccAddress("archive_scenario_acc")}
+	h.Ctx = h.Ctx.WithBlockTime(baseTime)
+	h.SetEpochStartTime()
+
+	res := archiveScenarioResult{createTime: baseTime}
+	poolId := h.PrepareBalancerPool()
+	for i := 0; i < 6; i++ {
+		h.Ctx = h.Ctx.WithBlockTime(h.Ctx.BlockTime().Add(12 * time.Hour)).WithGasMeter(sdk.NewInfiniteGasMeter())
+		h.RunBasicSwap(poolId)
+		h.Require().NoError(h.App.TwapKeeper.PruneRecords(h.Ctx))
+		h.EndBlock()
+		res.gasUsed = append(res.gasUsed, h.Ctx.GasMeter().GasConsumed())
+		h.Commit()
+		res.appHashes = append(res.appHashes, h.App.LastCommitID().Hash)
+	}
+
+	iter := h.Ctx.KVStore(h.App.GetKey(types.StoreKey)).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		res.twapStore = append(res.twapStore, [2][]byte{iter.Key(), iter.Value()})
+	}
+
+	stateRecords, err := h.App.TwapKeeper.GetAllHistoricalPoolIndexedTWAPs(h.Ctx)
+	h.Require().NoError(err)
+	for _, record := range stateRecords {
+		if record.Asset0Denom == "bar" && record.Asset1Denom == "foo" {
+			res.stateRecords = append(res.stateRecords, record)
+		}
+	}
+	if archiveDB != nil {
+		res.archivedRecords, _, err = h.App.TwapKeeper.GetArchivedRecords(h.Ctx, poolId, "foo", "bar", baseTime, h.Ctx.BlockTime(), 0)
+		h.Require().NoError(err)
+	}
+	return res
+}
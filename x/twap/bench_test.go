@@ -0,0 +1,125 @@
+package twap_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/osmosis-labs/osmosis/v13/app"
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/testutil"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+var (
+	benchTimeSpacingDist = testutil.TimeSpacingDist{
+		FirstRecordTime: baseTime,
+		MinSpacing:      time.Second,
+		MaxSpacing:      30 * time.Second,
+	}
+	benchPriceWalkParams = testutil.PriceWalkParams{
+		InitialPrice: sdk.NewDec(5),
+		Drift:        0,
+		Volatility:   0.001,
+	}
+)
+
+// setupRecordHistory returns a context at the time of the last record of a generated record history of
+// the given shape, stored in a new app, along with the app's twap keeper and the records.
+func setupRecordHistory(b *testing.B, pools uint64, pairsPerPool, recordsPerPair int) (sdk.Context, *twap.Keeper, []types.TwapRecord) {
+	b.Helper()
+	osmosisApp := app.Setup(false)
+	ctx := osmosisApp.BaseApp.NewContext(false, tmproto.Header{Height: 1, ChainID: "osmosis-1", Time: baseTime})
+	k := osmosisApp.TwapKeeper
+
+	records := testutil.GenerateRecordHistory(1, pools, pairsPerPool, recordsPerPair, benchTimeSpacingDist, benchPriceWalkParams)
+	lastTime := baseTime
+	// the records of every pair are in time order, so the last stored one is its most recent record
+	for _, record := range records {
+		k.StoreNewRecord(ctx, record)
+		if record.Time.After(lastTime) {
+			lastTime = record.Time
+		}
+	}
+	return ctx.WithBlockTime(lastTime), k, records
+}
+
+func benchmarkPruneRecords(b *testing.B, pools uint64, pairsPerPool, recordsPerPair int) {
+	ctx, k, _ := setupRecordHistory(b, pools, pairsPerPool, recordsPerPair)
+	// keep the second half of the history, so that pruning deletes about half of the records
+	params := k.GetParams(ctx)
+	params.RecordHistoryKeepPeriod = ctx.BlockTime().Sub(baseTime) / 2
+	k.SetParams(ctx, params)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cacheCtx, _ := ctx.CacheContext()
+		if err := k.PruneRecords(cacheCtx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPruneRecordsSmall(b *testing.B) {
+	benchmarkPruneRecords(b, 10, 3, 100)
+}
+
+func BenchmarkPruneRecordsLarge(b *testing.B) {
+	benchmarkPruneRecords(b, 100, 3, 1000)
+}
+
+func benchmarkGetRecordAtOrBeforeTime(b *testing.B, pools uint64, pairsPerPool, recordsPerPair int) {
+	ctx, k, records := setupRecordHistory(b, pools, pairsPerPool, recordsPerPair)
+	r := rand.New(rand.NewSource(1))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a time right after a random record of a random pair
+		record := records[r.Intn(len(records))]
+		t := record.Time.Add(time.Millisecond)
+		if _, err := k.GetRecordAtOrBeforeTime(ctx, record.PoolId, t, record.Asset0Denom, record.Asset1Denom); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetRecordAtOrBeforeTimeSmall(b *testing.B) {
+	benchmarkGetRecordAtOrBeforeTime(b, 10, 3, 100)
+}
+
+func BenchmarkGetRecordAtOrBeforeTimeLarge(b *testing.B) {
+	benchmarkGetRecordAtOrBeforeTime(b, 100, 3, 1000)
+}
+
+// benchmarkGetArithmeticTwap benchmarks the range queries, which interpolate the records at or before both
+// ends of their window.
+func benchmarkGetArithmeticTwap(b *testing.B, pools uint64, pairsPerPool, recordsPerPair int) {
+	ctx, k, records := setupRecordHistory(b, pools, pairsPerPool, recordsPerPair)
+	r := rand.New(rand.NewSource(1))
+	// the windows end before the block time, as the TWAPs to now don't look records up by time
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Second))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a window ending at a random record of a random pair, starting halfway from the first record
+		record := records[r.Intn(len(records))]
+		startTime := baseTime.Add(record.Time.Sub(baseTime) / 2)
+		if _, err := k.GetArithmeticTwap(ctx, record.PoolId, record.Asset0Denom, record.Asset1Denom, startTime, record.Time); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetArithmeticTwapSmall(b *testing.B) {
+	benchmarkGetArithmeticTwap(b, 10, 3, 100)
+}
+
+func BenchmarkGetArithmeticTwapLarge(b *testing.B) {
+	benchmarkGetArithmeticTwap(b, 100, 3, 1000)
+}
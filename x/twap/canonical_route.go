@@ -0,0 +1,141 @@
+package twap
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// SetCanonicalTwapRoute sets route as the canonical route of route.Denom, replacing its current route if it has one.
+// The canonical routes are the routes GetCanonicalTwap computes the TWAPs of their denom in units of the
+// CanonicalQuoteDenom param over, so that the price of a denom can be queried without knowing the pools to query it
+// from.
+//
+// This function will error if:
+// * sender is not the canonical route authority, or setting the canonical routes is disabled
+// * the route is invalid, see types.CanonicalTwapRoute.Validate
+// * the route doesn't end in the CanonicalQuoteDenom param, e.g. because it is unset
+// * the pool of a hop doesn't exist, or doesn't hold both the base and the quote asset of the hop
+func (k Keeper) SetCanonicalTwapRoute(ctx sdk.Context, sender string, route types.CanonicalTwapRoute) error {
+	params := k.GetParams(ctx)
+	if err := checkCanonicalRouteAuthority(params, sender); err != nil {
+		return err
+	}
+	if err := route.Validate(); err != nil {
+		return err
+	}
+	if params.CanonicalQuoteDenom == "" || route.QuoteDenom() != params.CanonicalQuoteDenom {
+		return types.InvalidRouteError{Reason: fmt.Sprintf("the route ends in %s, not in the canonical quote denom %q",
+			route.QuoteDenom(), params.CanonicalQuoteDenom)}
+	}
+	if err := k.validateRouteInPools(ctx, route.TwapRoute()); err != nil {
+		return err
+	}
+
+	k.storeCanonicalTwapRoute(ctx, route)
+	return nil
+}
+
+// RemoveCanonicalTwapRoute removes the canonical route of denom.
+//
+// This function will error if:
+// * sender is not the canonical route authority, or setting the canonical routes is disabled
+// * denom has no canonical route
+func (k Keeper) RemoveCanonicalTwapRoute(ctx sdk.Context, sender string, denom string) error {
+	if err := checkCanonicalRouteAuthority(k.GetParams(ctx), sender); err != nil {
+		return err
+	}
+	if _, err := k.GetCanonicalTwapRoute(ctx, denom); err != nil {
+		return err
+	}
+
+	ctx.KVStore(k.storeKey).Delete(types.FormatCanonicalTwapRouteKey(denom))
+	return nil
+}
+
+// GetCanonicalTwapRoute returns the canonical route of denom, or a types.CanonicalRouteNotFoundError if it has none.
+func (k Keeper) GetCanonicalTwapRoute(ctx sdk.Context, denom string) (types.CanonicalTwapRoute, error) {
+	var route types.CanonicalTwapRoute
+	found, err := osmoutils.Get(ctx.KVStore(k.storeKey), types.FormatCanonicalTwapRouteKey(denom), &route)
+	if err != nil {
+		return types.CanonicalTwapRoute{}, err
+	}
+	if !found {
+		return types.CanonicalTwapRoute{}, types.CanonicalRouteNotFoundError{Denom: denom}
+	}
+	return route, nil
+}
+
+// GetAllCanonicalTwapRoutes returns the canonical routes of all the denoms, sorted by denom.
+func (k Keeper) GetAllCanonicalTwapRoutes(ctx sdk.Context) ([]types.CanonicalTwapRoute, error) {
+	return osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(types.CanonicalTwapRoutePrefix), types.ParseCanonicalTwapRouteFromBz)
+}
+
+// GetCanonicalTwap returns the arithmetic TWAP of denom in units of the CanonicalQuoteDenom param over the window
+// ending at the block time, computed over the canonical route of denom by GetArithmeticTwapForRoute. As for it, if
+// the spot price of the pool of a hop errored within the window, the TWAP is returned along with
+// types.ErrSpotPriceErrorInWindow.
+//
+// This function will error if:
+// * window is not positive
+// * denom has no canonical route
+// * the route no longer ends in the CanonicalQuoteDenom param, as the param changed after the route was set
+// * the TWAP of a hop errors, see GetArithmeticTwapForRoute
+func (k Keeper) GetCanonicalTwap(ctx sdk.Context, denom string, window time.Duration) (types.RouteTwapResult, error) {
+	if window <= 0 {
+		return types.RouteTwapResult{}, types.NonPositiveDurationError{Name: "window", Duration: window}
+	}
+	route, err := k.GetCanonicalTwapRoute(ctx, denom)
+	if err != nil {
+		return types.RouteTwapResult{}, err
+	}
+	if quoteDenom := k.GetParams(ctx).CanonicalQuoteDenom; route.QuoteDenom() != quoteDenom {
+		return types.RouteTwapResult{}, types.InvalidRouteError{Reason: fmt.Sprintf(
+			"the canonical route of %s ends in %s, not in the canonical quote denom %q", denom, route.QuoteDenom(), quoteDenom)}
+	}
+	endTime := ctx.BlockTime()
+	return k.GetArithmeticTwapForRoute(ctx, route.TwapRoute(), endTime.Add(-window), endTime)
+}
+
+// validateRouteInPools returns an error if the pool of a hop of route doesn't exist, or doesn't hold both the base
+// and the quote asset of the hop.
+func (k Keeper) validateRouteInPools(ctx sdk.Context, route []types.TwapRoutePoolPair) error {
+	for _, hop := range route {
+		denoms, err := k.ammkeeper.GetPoolDenoms(ctx, hop.PoolId)
+		if err != nil {
+			return err
+		}
+		if !containsDenom(denoms, hop.BaseAsset) || !containsDenom(denoms, hop.QuoteAsset) {
+			asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(hop.BaseAsset, hop.QuoteAsset)
+			if err != nil {
+				return err
+			}
+			return types.PairNotInPoolError{PoolId: hop.PoolId, Asset0Denom: asset0Denom, Asset1Denom: asset1Denom, PoolDenoms: denoms}
+		}
+	}
+	return nil
+}
+
+func (k Keeper) storeCanonicalTwapRoute(ctx sdk.Context, route types.CanonicalTwapRoute) {
+	osmoutils.MustSet(ctx.KVStore(k.storeKey), types.FormatCanonicalTwapRouteKey(route.Denom), &route)
+}
+
+func checkCanonicalRouteAuthority(params types.Params, sender string) error {
+	if params.CanonicalRouteAuthority == "" || params.CanonicalRouteAuthority != sender {
+		return types.CanonicalRouteUnauthorizedError{Sender: sender, Authority: params.CanonicalRouteAuthority}
+	}
+	return nil
+}
+
+func containsDenom(denoms []string, denom string) bool {
+	for _, d := range denoms {
+		if d == denom {
+			return true
+		}
+	}
+	return false
+}
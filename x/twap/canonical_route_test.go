@@ -0,0 +1,216 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// setCanonicalRouteParams enables the canonical routes with the first test account as the canonical route authority,
+// and denom2 as the canonical quote denom.
+func (s *TestSuite) setCanonicalRouteParams() string {
+	authority := s.TestAccs[0].String()
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.CanonicalRouteAuthority = authority
+	params.CanonicalQuoteDenom = denom2
+	s.twapkeeper.SetParams(s.Ctx, params)
+	return authority
+}
+
+// prepareCanonicalRoutePools creates a pool of A and B, where A is worth 2 B, and a pool of B and C, where B is worth
+// 3 C, and returns their ids.
+func (s *TestSuite) prepareCanonicalRoutePools() (poolIdAB uint64, poolIdBC uint64) {
+	poolIdAB = s.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin(denom0, 1_000_000_000), sdk.NewInt64Coin(denom1, 2_000_000_000))
+	poolIdBC = s.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin(denom1, 1_000_000_000), sdk.NewInt64Coin(denom2, 3_000_000_000))
+	return poolIdAB, poolIdBC
+}
+
+func (s *TestSuite) TestSetCanonicalTwapRoute() {
+	// SetupTest creates new accounts, so each case is run with those the expected errors were built with
+	accs := s.TestAccs
+	tests := map[string]struct {
+		disableRoutes bool
+		quoteDenom    string
+		sender        string
+		route         func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute
+
+		expectedErr error
+	}{
+		"two hops": {
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom0, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdAB, QuoteAsset: denom1}, {PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+		},
+		"one hop": {
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom1, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+		},
+		"error: not the canonical route authority": {
+			sender: s.TestAccs[1].String(),
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom1, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+			expectedErr: types.CanonicalRouteUnauthorizedError{Sender: s.TestAccs[1].String(), Authority: s.TestAccs[0].String()},
+		},
+		"error: canonical routes disabled": {
+			disableRoutes: true,
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom1, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+			expectedErr: types.CanonicalRouteUnauthorizedError{Sender: s.TestAccs[0].String()},
+		},
+		"error: route doesn't end in the canonical quote denom": {
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom0, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdAB, QuoteAsset: denom1}}}
+			},
+			expectedErr: types.InvalidRouteError{Reason: `the route ends in token/B, not in the canonical quote denom "token/C"`},
+		},
+		"error: no canonical quote denom": {
+			quoteDenom: "unset",
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom1, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+			expectedErr: types.InvalidRouteError{Reason: `the route ends in token/C, not in the canonical quote denom ""`},
+		},
+		"error: no hops": {
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom0}
+			},
+			expectedErr: types.InvalidRouteError{Reason: "the route has no hops"},
+		},
+		"error: base asset of a hop not in its pool": {
+			route: func(poolIdAB, poolIdBC uint64) types.CanonicalTwapRoute {
+				return types.CanonicalTwapRoute{Denom: denom0, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+			},
+			expectedErr: types.PairNotInPoolError{PoolId: 2, Asset0Denom: denom0, Asset1Denom: denom2, PoolDenoms: []string{denom1, denom2}},
+		},
+	}
+
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.TestAccs = accs
+			authority := s.setCanonicalRouteParams()
+			params := s.twapkeeper.GetParams(s.Ctx)
+			if test.disableRoutes {
+				params.CanonicalRouteAuthority = ""
+			}
+			if test.quoteDenom == "unset" {
+				params.CanonicalQuoteDenom = ""
+			}
+			s.twapkeeper.SetParams(s.Ctx, params)
+			sender := authority
+			if test.sender != "" {
+				sender = test.sender
+			}
+			route := test.route(s.prepareCanonicalRoutePools())
+
+			err := s.twapkeeper.SetCanonicalTwapRoute(s.Ctx, sender, route)
+			if test.expectedErr != nil {
+				s.Require().Equal(test.expectedErr, err)
+				_, err = s.twapkeeper.GetCanonicalTwapRoute(s.Ctx, route.Denom)
+				s.Require().Equal(types.CanonicalRouteNotFoundError{Denom: route.Denom}, err)
+				return
+			}
+			s.Require().NoError(err)
+			storedRoute, err := s.twapkeeper.GetCanonicalTwapRoute(s.Ctx, route.Denom)
+			s.Require().NoError(err)
+			s.Require().Equal(route, storedRoute)
+		})
+	}
+}
+
+// TestGetCanonicalTwap tests that the canonical TWAP of a denom is the TWAP over its canonical route, the product of
+// the TWAPs of its hops, and that it follows the updates of the route.
+func (s *TestSuite) TestGetCanonicalTwap() {
+	s.SetupTest()
+	authority := s.setCanonicalRouteParams()
+	poolIdAB, poolIdBC := s.prepareCanonicalRoutePools()
+	route := types.CanonicalTwapRoute{Denom: denom0, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdAB, QuoteAsset: denom1}, {PoolId: poolIdBC, QuoteAsset: denom2}}}
+	s.Require().NoError(s.twapkeeper.SetCanonicalTwapRoute(s.Ctx, authority, route))
+
+	s.Ctx = s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Minute))
+	window := 30 * time.Second
+	startTime := s.Ctx.BlockTime().Add(-window)
+
+	result, err := s.twapkeeper.GetCanonicalTwap(s.Ctx, denom0, window)
+	s.Require().NoError(err)
+	twapAB, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, poolIdAB, denom0, denom1, startTime, s.Ctx.BlockTime())
+	s.Require().NoError(err)
+	twapBC, err := s.twapkeeper.GetArithmeticTwap(s.Ctx, poolIdBC, denom1, denom2, startTime, s.Ctx.BlockTime())
+	s.Require().NoError(err)
+	s.Require().Equal(twapAB.Mul(twapBC), result.Price)
+	s.Require().Equal(sdk.NewDec(6), result.Price)
+	routeResult, err := s.twapkeeper.GetArithmeticTwapForRoute(s.Ctx, route.TwapRoute(), startTime, s.Ctx.BlockTime())
+	s.Require().NoError(err)
+	s.Require().Equal(routeResult, result)
+
+	// a denom without a canonical route errors
+	_, err = s.twapkeeper.GetCanonicalTwap(s.Ctx, denom1, window)
+	s.Require().Equal(types.CanonicalRouteNotFoundError{Denom: denom1}, err)
+
+	// a non-positive window errors
+	_, err = s.twapkeeper.GetCanonicalTwap(s.Ctx, denom0, 0)
+	s.Require().Equal(types.NonPositiveDurationError{Name: "window", Duration: 0}, err)
+
+	// a route set for another canonical quote denom errors once the param changed
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.CanonicalQuoteDenom = denom1
+	s.twapkeeper.SetParams(s.Ctx, params)
+	_, err = s.twapkeeper.GetCanonicalTwap(s.Ctx, denom0, window)
+	s.Require().Equal(types.InvalidRouteError{Reason: `the canonical route of token/A ends in token/C, not in the canonical quote denom "token/B"`}, err)
+
+	// a removed route errors
+	s.Require().NoError(s.twapkeeper.RemoveCanonicalTwapRoute(s.Ctx, authority, denom0))
+	_, err = s.twapkeeper.GetCanonicalTwap(s.Ctx, denom0, window)
+	s.Require().Equal(types.CanonicalRouteNotFoundError{Denom: denom0}, err)
+	s.Require().Equal(types.CanonicalRouteNotFoundError{Denom: denom0}, s.twapkeeper.RemoveCanonicalTwapRoute(s.Ctx, authority, denom0))
+}
+
+func (s *TestSuite) TestCanonicalTwapRouteMsgServerAndQuery() {
+	s.SetupTest()
+	authority := s.setCanonicalRouteParams()
+	poolIdAB, poolIdBC := s.prepareCanonicalRoutePools()
+	routeA := types.CanonicalTwapRoute{Denom: denom0, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdAB, QuoteAsset: denom1}, {PoolId: poolIdBC, QuoteAsset: denom2}}}
+	routeB := types.CanonicalTwapRoute{Denom: denom1, Hops: []types.CanonicalTwapRouteHop{{PoolId: poolIdBC, QuoteAsset: denom2}}}
+
+	msgServer := twap.NewMsgServerImpl(s.twapkeeper)
+	querier := client.Querier{K: *s.twapkeeper}
+	ctx := sdk.WrapSDKContext(s.Ctx)
+
+	// the routes can only be set by the canonical route authority
+	_, err := msgServer.SetCanonicalTwapRoute(ctx, types.NewMsgSetCanonicalTwapRoute(s.TestAccs[1].String(), routeA))
+	s.Require().ErrorIs(err, types.CanonicalRouteUnauthorizedError{Sender: s.TestAccs[1].String(), Authority: authority})
+
+	_, err = msgServer.SetCanonicalTwapRoute(ctx, types.NewMsgSetCanonicalTwapRoute(authority, routeA))
+	s.Require().NoError(err)
+	_, err = msgServer.SetCanonicalTwapRoute(ctx, types.NewMsgSetCanonicalTwapRoute(authority, routeB))
+	s.Require().NoError(err)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtSetCanonicalTwapRoute, 2)
+
+	routesRes, err := querier.CanonicalTwapRoutes(s.Ctx, queryproto.CanonicalTwapRoutesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(&queryproto.CanonicalTwapRoutesResponse{QuoteDenom: denom2, Routes: []types.CanonicalTwapRoute{routeA, routeB}}, routesRes)
+
+	// the routes are exported in genesis
+	s.Require().Equal([]types.CanonicalTwapRoute{routeA, routeB}, s.twapkeeper.ExportGenesis(s.Ctx).CanonicalRoutes)
+
+	s.Ctx = s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Minute))
+	twapRes, err := querier.CanonicalTwap(s.Ctx, queryproto.CanonicalTwapRequest{Denom: denom0, WindowDuration: 30 * time.Second})
+	s.Require().NoError(err)
+	s.Require().Equal(&queryproto.CanonicalTwapResponse{QuoteDenom: denom2, ArithmeticTwap: sdk.NewDec(6)}, twapRes)
+
+	_, err = msgServer.RemoveCanonicalTwapRoute(ctx, types.NewMsgRemoveCanonicalTwapRoute(authority, denom0))
+	s.Require().NoError(err)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtRemoveCanonicalTwapRoute, 1)
+
+	routesRes, err = querier.CanonicalTwapRoutes(s.Ctx, queryproto.CanonicalTwapRoutesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal([]types.CanonicalTwapRoute{routeB}, routesRes.Routes)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/osmosis-labs/osmosis/v13/osmoutils/osmocli"
 	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
@@ -15,10 +16,51 @@ import (
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
+// FlagAllowGaps is the flag of the twap command allowing windows with tracking gaps.
+const FlagAllowGaps = "allow-gaps"
+
+// FlagHarmonic is the flag of the twap command querying the harmonic TWAP instead of the arithmetic one.
+const FlagHarmonic = "harmonic"
+
+// FlagWindow is the flag of the geometric-twap command giving the duration of a window ending at the latest block.
+const FlagWindow = "window"
+
+// Flags of the optional filters of the historical-records command.
+const (
+	FlagBaseAsset  = "base-asset"
+	FlagQuoteAsset = "quote-asset"
+	FlagStartTime  = "start-time"
+	FlagEndTime    = "end-time"
+)
+
+// FlagStaleOnly is the flag of the all-last-update-info command only returning the info of the stale denom pairs.
+const FlagStaleOnly = "stale-only"
+
 // GetQueryCmd returns the cli query commands for this module.
 func GetQueryCmd() *cobra.Command {
 	cmd := osmocli.QueryIndexCmd(types.ModuleName)
 	cmd.AddCommand(GetQueryTwapCommand())
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryModuleVersionCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryPinnedRecordsCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryTwapChangeCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryHistoricalSpotPriceCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryTwapCandlesCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryPoolHealthCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryTwapsForPairCommand)
+	cmd.AddCommand(GetQueryGeometricTwapCommand())
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryGeometricTwapToNowCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryArchivedTwapRecordsCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryArchivedArithmeticTwapCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryHistoricalRecordsCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryMostRecentRecordsCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQuerySpotPricesAtTimeCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryMedianSpotPriceCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryArithmeticTwapForRouteCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryPoolTwapPairsCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryCanonicalTwapCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryCanonicalTwapRoutesCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryLastUpdateInfoCommand)
+	osmocli.AddQueryCmd(cmd, queryproto.NewQueryClient, GetQueryAllLastUpdateInfoCommand)
 
 	return cmd
 }
@@ -33,6 +75,8 @@ func GetQueryTwapCommand() *cobra.Command {
 Example:
 {{.CommandPrefix}} twap 1 uosmo 1667088000 24h
 {{.CommandPrefix}} twap 1 uosmo 1667088000 1667174400
+{{.CommandPrefix}} twap 1 uosmo 1667088000 24h --allow-gaps
+{{.CommandPrefix}} twap 1 uosmo 1667088000 24h --harmonic
 `, types.ModuleName),
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,12 +109,27 @@ Example:
 					poolId, baseDenom, liquidity.Liquidity[0], liquidity.Liquidity[1])
 			}
 
+			allowGaps, err := cmd.Flags().GetBool(FlagAllowGaps)
+			if err != nil {
+				return err
+			}
+			twapType := queryproto.ArithmeticTwapType
+			harmonic, err := cmd.Flags().GetBool(FlagHarmonic)
+			if err != nil {
+				return err
+			}
+			if harmonic {
+				twapType = queryproto.HarmonicTwapType
+			}
+
 			res, err := queryClient.ArithmeticTwap(cmd.Context(), &queryproto.ArithmeticTwapRequest{
 				PoolId:     poolId,
 				BaseAsset:  baseDenom,
 				QuoteAsset: quoteDenom,
 				StartTime:  startTime,
 				EndTime:    &endTime,
+				AllowGaps:  allowGaps,
+				TwapType:   twapType,
 			})
 			if err != nil {
 				return err
@@ -80,11 +139,482 @@ Example:
 		},
 	}
 
+	cmd.Flags().Bool(FlagAllowGaps, false, "Return the twap of a window overlapping periods where the pool's records were not updated, along with the fraction of the window they were updated")
+	cmd.Flags().Bool(FlagHarmonic, false, "Return the harmonic twap, the reciprocal of the arithmetic twap of the quote asset in the base asset")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetQueryModuleVersionCommand returns the module version and the enabled record extensions.
+func GetQueryModuleVersionCommand() (*osmocli.QueryDescriptor, *queryproto.ModuleVersionRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "module-version",
+		Short: "Query the twap module version and the record extensions it populates.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} module-version`,
+	}, &queryproto.ModuleVersionRequest{}
+}
+
+// GetQueryPinnedRecordsCommand returns the records that are exempt from pruning.
+func GetQueryPinnedRecordsCommand() (*osmocli.QueryDescriptor, *queryproto.PinnedRecordsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "pinned-records",
+		Short: "Query the twap records that are pinned, and thus never pruned.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} pinned-records`,
+	}, &queryproto.PinnedRecordsRequest{}
+}
+
+// GetQueryTwapChangeCommand returns the change between the TWAPs of two windows of the same duration.
+func GetQueryTwapChangeCommand() (*osmocli.QueryDescriptor, *queryproto.TwapChangeRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "twap-change [pool-id] [base-asset] [quote-asset] [window] [offset]",
+		Short: "Query the change between the twap over the last window, and the twap over the window ending offset ago.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} twap-change 1 uatom uosmo 24h 24h`,
+	}, &queryproto.TwapChangeRequest{}
+}
+
+// GetQueryHistoricalSpotPriceCommand returns the spot price recorded at a past time.
+func GetQueryHistoricalSpotPriceCommand() (*osmocli.QueryDescriptor, *queryproto.HistoricalSpotPriceRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "historical-spot-price [pool-id] [base-asset] [quote-asset] [unix-time]",
+		Short: "Query the spot price of the last twap record at or before a time.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} historical-spot-price 1 uatom uosmo 1667088000`,
+	}, &queryproto.HistoricalSpotPriceRequest{}
+}
+
+// GetQueryTwapCandlesCommand returns the twap and first and last spot prices of the intervals of a time range.
+func GetQueryTwapCandlesCommand() (*osmocli.QueryDescriptor, *queryproto.TwapCandlesRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "twap-candles [pool-id] [base-asset] [quote-asset] [start-unix-time] [end-unix-time] [interval]",
+		Short: "Query the twap, and the spot prices of the first and last records, of every interval of a time range.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} twap-candles 1 uatom uosmo 1667088000 1667174400 1h`,
+	}, &queryproto.TwapCandlesRequest{}
+}
+
+// GetQueryPoolHealthCommand returns whether the records of a pool are quarantined.
+func GetQueryPoolHealthCommand() (*osmocli.QueryDescriptor, *queryproto.PoolHealthRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "pool-health [pool-id]",
+		Short: "Query whether the twap records of a pool are quarantined because its denoms don't match its records.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} pool-health 1`,
+	}, &queryproto.PoolHealthRequest{}
+}
+
+// GetQueryTwapsForPairCommand returns the twaps of every pool with records for a denom pair.
+func GetQueryTwapsForPairCommand() (*osmocli.QueryDescriptor, *queryproto.TwapsForPairRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "twaps-for-pair [base-asset] [quote-asset] [window]",
+		Short: "Query the twap over the last window of every pool with twap records for a denom pair.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} twaps-for-pair uatom uosmo 10m`,
+	}, &queryproto.TwapsForPairRequest{}
+}
+
+// GetQueryGeometricTwapCommand returns the geometric twap of a pool and its inverse, over a time range or over a
+// window ending at the latest block time.
+func GetQueryGeometricTwapCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "geometric-twap [pool-id] [base-asset] [quote-asset] [start-time] [end-time]",
+		Short: "Query the geometric twap of a pool and its inverse, between two times or over a window ending at the latest block time",
+		Long: osmocli.FormatLongDescDirect(`Query the geometric twap of a pool and its inverse, the twap of the quote asset in units of the base asset.
+The times are unix times or RFC3339 timestamps. Instead of them, --window gives the duration of a window ending at the latest block time.
+
+Example:
+{{.CommandPrefix}} geometric-twap 1 uatom uosmo 1667088000 1667174400
+{{.CommandPrefix}} geometric-twap 1 uatom uosmo 2022-10-30T00:00:00Z 2022-10-31T00:00:00Z
+{{.CommandPrefix}} geometric-twap 1 uatom uosmo --window 30m
+`, types.ModuleName),
+		Args: cobra.RangeArgs(3, 5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			window, err := cmd.Flags().GetString(FlagWindow)
+			if err != nil {
+				return err
+			}
+			twapArgs, err := ParseGeometricTwapArgs(args, window)
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if twapArgs.Window != 0 {
+				// the window ends at the time of the latest block, which is queried at that block so that the
+				// window ends at its block time
+				node, err := clientCtx.GetNode()
+				if err != nil {
+					return err
+				}
+				var height *int64
+				if clientCtx.Height != 0 {
+					height = &clientCtx.Height
+				}
+				block, err := node.Block(cmd.Context(), height)
+				if err != nil {
+					return err
+				}
+				twapArgs.EndTime = block.Block.Time
+				twapArgs.StartTime = block.Block.Time.Add(-twapArgs.Window)
+				clientCtx = clientCtx.WithHeight(block.Block.Height)
+			}
+			queryClient := queryproto.NewQueryClient(clientCtx)
+
+			twap, err := queryClient.GeometricTwap(cmd.Context(), &queryproto.GeometricTwapRequest{
+				PoolId:     twapArgs.PoolId,
+				BaseAsset:  twapArgs.BaseAsset,
+				QuoteAsset: twapArgs.QuoteAsset,
+				StartTime:  twapArgs.StartTime,
+				EndTime:    twapArgs.EndTime,
+			})
+			if err != nil {
+				return err
+			}
+			inverseTwap, err := queryClient.GeometricTwap(cmd.Context(), &queryproto.GeometricTwapRequest{
+				PoolId:     twapArgs.PoolId,
+				BaseAsset:  twapArgs.QuoteAsset,
+				QuoteAsset: twapArgs.BaseAsset,
+				StartTime:  twapArgs.StartTime,
+				EndTime:    twapArgs.EndTime,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintString(fmt.Sprintf("geometric_twap: %s\ninverse_geometric_twap: %s\nstart_time: %s\nend_time: %s\n",
+				twap.GeometricTwap, inverseTwap.GeometricTwap,
+				twapArgs.StartTime.UTC().Format(time.RFC3339Nano), twapArgs.EndTime.UTC().Format(time.RFC3339Nano)))
+		},
+	}
+
+	cmd.Flags().String(FlagWindow, "", "The duration of a window ending at the latest block time, e.g. 30m, instead of the start and end times")
 	flags.AddQueryFlagsToCmd(cmd)
 
 	return cmd
 }
 
+// GeometricTwapArgs are the parsed args of the geometric-twap command.
+type GeometricTwapArgs struct {
+	PoolId     uint64
+	BaseAsset  string
+	QuoteAsset string
+	// StartTime and EndTime are the times of the args, unset if Window is set.
+	StartTime time.Time
+	EndTime   time.Time
+	// Window is the duration of the --window flag, zero if the times are set.
+	Window time.Duration
+}
+
+// ParseGeometricTwapArgs parses the args of the geometric-twap command, and window, the value of its --window flag.
+// Either the start and end times or the window must be given, but not both.
+func ParseGeometricTwapArgs(args []string, window string) (GeometricTwapArgs, error) {
+	if len(args) < 3 {
+		return GeometricTwapArgs{}, fmt.Errorf("the pool id, base asset and quote asset must be given")
+	}
+	poolId, err := osmocli.ParseUint(args[0], "poolId")
+	if err != nil {
+		return GeometricTwapArgs{}, err
+	}
+	twapArgs := GeometricTwapArgs{PoolId: poolId, BaseAsset: strings.TrimSpace(args[1]), QuoteAsset: strings.TrimSpace(args[2])}
+
+	switch {
+	case len(args) > 3 && window != "":
+		return GeometricTwapArgs{}, fmt.Errorf("either the start and end times or --%s can be given, not both", FlagWindow)
+	case len(args) == 5:
+		if twapArgs.StartTime, err = parseUnixOrRFC3339Time(args[3], "start time"); err != nil {
+			return GeometricTwapArgs{}, err
+		}
+		if twapArgs.EndTime, err = parseUnixOrRFC3339Time(args[4], "end time"); err != nil {
+			return GeometricTwapArgs{}, err
+		}
+	case len(args) == 4:
+		return GeometricTwapArgs{}, fmt.Errorf("the end time must be given with the start time")
+	case window != "":
+		twapArgs.Window, err = time.ParseDuration(window)
+		if err != nil {
+			return GeometricTwapArgs{}, fmt.Errorf("could not parse --%s %s as a duration: %w", FlagWindow, window, err)
+		}
+		if twapArgs.Window <= 0 {
+			return GeometricTwapArgs{}, fmt.Errorf("--%s must be positive, got %s", FlagWindow, window)
+		}
+	default:
+		return GeometricTwapArgs{}, fmt.Errorf("either the start and end times or --%s must be given", FlagWindow)
+	}
+	return twapArgs, nil
+}
+
+// parseUnixOrRFC3339Time parses arg as a unix time, or else as an RFC3339 timestamp.
+func parseUnixOrRFC3339Time(arg string, fieldName string) (time.Time, error) {
+	if t, err := osmocli.ParseUnixTime(arg, fieldName); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, arg)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse %s %s as a unix time or an RFC3339 timestamp", fieldName, arg)
+	}
+	return t, nil
+}
+
+// GetQueryGeometricTwapToNowCommand returns the geometric twap of a pool from a time until the block time.
+func GetQueryGeometricTwapToNowCommand() (*osmocli.QueryDescriptor, *queryproto.GeometricTwapToNowRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "geometric-twap-to-now [pool-id] [base-asset] [quote-asset] [start-unix-time]",
+		Short: "Query the geometric twap of a pool from a time until the block time.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} geometric-twap-to-now 1 uatom uosmo 1667088000`,
+	}, &queryproto.GeometricTwapToNowRequest{}
+}
+
+// GetQueryArchivedTwapRecordsCommand returns the records of a pool over a time range from the archive of the node.
+func GetQueryArchivedTwapRecordsCommand() (*osmocli.QueryDescriptor, *queryproto.ArchivedTwapRecordsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "archived-twap-records [pool-id] [base-asset] [quote-asset] [start-unix-time] [end-unix-time] [limit]",
+		Short: "Query the twap records of a pool between two times from the archive of the node.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} archived-twap-records 1 uatom uosmo 1667088000 1667174400 100`,
+	}, &queryproto.ArchivedTwapRecordsRequest{}
+}
+
+// GetQueryArchivedArithmeticTwapCommand returns the arithmetic twap of a pool over a time range from the archive
+// of the node.
+func GetQueryArchivedArithmeticTwapCommand() (*osmocli.QueryDescriptor, *queryproto.ArchivedArithmeticTwapRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "archived-arithmetic-twap [pool-id] [base-asset] [quote-asset] [start-unix-time] [end-unix-time]",
+		Short: "Query the arithmetic twap of a pool between two times from the archive of the node.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} archived-arithmetic-twap 1 uatom uosmo 1667088000 1667174400`,
+	}, &queryproto.ArchivedArithmeticTwapRequest{}
+}
+
+// GetQueryMostRecentRecordsCommand returns the most recent record of every denom pair of a pool.
+func GetQueryMostRecentRecordsCommand() (*osmocli.QueryDescriptor, *queryproto.MostRecentRecordsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "most-recent-records [pool-id]",
+		Short: "Query the most recent twap record of every denom pair of a pool.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} most-recent-records 1`,
+	}, &queryproto.MostRecentRecordsRequest{}
+}
+
+// GetQueryPoolTwapPairsCommand returns the denom pairs of a pool that have twap records.
+func GetQueryPoolTwapPairsCommand() (*osmocli.QueryDescriptor, *queryproto.PoolTwapPairsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "pool-twap-pairs [pool-id]",
+		Short: "Query the denom pairs of a pool that have twap records.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} pool-twap-pairs 1`,
+	}, &queryproto.PoolTwapPairsRequest{}
+}
+
+// GetQueryCanonicalTwapCommand returns the arithmetic TWAP of a denom in units of the canonical quote denom over a
+// window ending at the block time.
+func GetQueryCanonicalTwapCommand() (*osmocli.QueryDescriptor, *queryproto.CanonicalTwapRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "canonical-twap [denom] [window]",
+		Short: "Query the arithmetic twap of a denom in units of the canonical quote denom over a window ending at the block time, computed over the canonical route of the denom.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} canonical-twap uatom 1h`,
+	}, &queryproto.CanonicalTwapRequest{}
+}
+
+// GetQueryCanonicalTwapRoutesCommand returns the canonical routes of all the denoms.
+func GetQueryCanonicalTwapRoutesCommand() (*osmocli.QueryDescriptor, *queryproto.CanonicalTwapRoutesRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "canonical-twap-routes",
+		Short: "Query the canonical routes of all the denoms, and the canonical quote denom they end in.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} canonical-twap-routes`,
+	}, &queryproto.CanonicalTwapRoutesRequest{}
+}
+
+// GetQueryLastUpdateInfoCommand returns when the most recent record of a denom pair of a pool was written.
+func GetQueryLastUpdateInfoCommand() (*osmocli.QueryDescriptor, *queryproto.LastUpdateInfoRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "last-update-info [pool-id] [base-asset] [quote-asset] [max-block-age]",
+		Short: "Query the height and time of the most recent twap record of a denom pair of a pool, and whether it is older than max-block-age blocks.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} last-update-info 1 uatom uosmo 100`,
+	}, &queryproto.LastUpdateInfoRequest{}
+}
+
+// GetQueryAllLastUpdateInfoCommand returns a page of the last update info of every denom pair of every pool.
+func GetQueryAllLastUpdateInfoCommand() (*osmocli.QueryDescriptor, *queryproto.AllLastUpdateInfoRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "all-last-update-info [max-block-age]",
+		Short: "Query a page of the height and time of the most recent twap record of every denom pair of every pool, and whether it is older than max-block-age blocks.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} all-last-update-info 100
+{{.CommandPrefix}} all-last-update-info 100 --stale-only --limit=50`,
+		CustomFieldParsers: map[string]osmocli.CustomFieldParserFn{
+			"StaleOnly": osmocli.FlagOnlyParser(func(fs *pflag.FlagSet) (bool, error) { return fs.GetBool(FlagStaleOnly) }),
+		},
+		Flags: osmocli.FlagDesc{OptionalFlags: []*pflag.FlagSet{FlagSetAllLastUpdateInfo()}},
+	}, &queryproto.AllLastUpdateInfoRequest{}
+}
+
+// FlagSetAllLastUpdateInfo returns the flags of the all-last-update-info command.
+func FlagSetAllLastUpdateInfo() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	fs.Bool(FlagStaleOnly, false, "Only return the info of the denom pairs whose most recent record is older than max-block-age blocks")
+	return fs
+}
+
+// GetQuerySpotPricesAtTimeCommand returns the spot prices of many pairs at a time.
+func GetQuerySpotPricesAtTimeCommand() (*osmocli.QueryDescriptor, *queryproto.SpotPricesAtTimeRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "spot-prices-at-time [unix-time] [pairs]",
+		Short: "Query the spot prices of the last twap records at or before a time of many pairs, given as pool-id:base-asset:quote-asset separated by commas.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} spot-prices-at-time 1667088000 1:uatom:uusdc,2:uosmo:uusdc`,
+		CustomFieldParsers: map[string]osmocli.CustomFieldParserFn{
+			"Pairs": parseSpotPricePairs,
+		},
+	}, &queryproto.SpotPricesAtTimeRequest{}
+}
+
+// parseSpotPricePairs parses the pairs of the spot-prices-at-time command, of the form pool-id:base-asset:quote-asset
+// and separated by commas.
+func parseSpotPricePairs(arg string, _ *pflag.FlagSet) (any, osmocli.FieldReadLocation, error) {
+	pairs := []queryproto.SpotPricePair{}
+	for _, pairStr := range strings.Split(arg, ",") {
+		fields := strings.Split(strings.TrimSpace(pairStr), ":")
+		if len(fields) != 3 {
+			return nil, osmocli.UsedArg, fmt.Errorf("pair %q is not of the form pool-id:base-asset:quote-asset", pairStr)
+		}
+		poolId, err := osmocli.ParseUint(fields[0], "pool-id")
+		if err != nil {
+			return nil, osmocli.UsedArg, err
+		}
+		pairs = append(pairs, queryproto.SpotPricePair{PoolId: poolId, BaseAsset: fields[1], QuoteAsset: fields[2]})
+	}
+	return pairs, osmocli.UsedArg, nil
+}
+
+// GetQueryMedianSpotPriceCommand returns the median of the spot prices of a pair of a pool sampled from a time until
+// an optional end time.
+func GetQueryMedianSpotPriceCommand() (*osmocli.QueryDescriptor, *queryproto.MedianSpotPriceRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "median-spot-price [pool-id] [base-asset] [quote-asset] [start-unix-time]",
+		Short: "Query the median of the spot prices of a pool sampled at the end of every block from a time until the block time, or the end time.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} median-spot-price 1 uatom uosmo 1667088000
+{{.CommandPrefix}} median-spot-price 1 uatom uosmo 1667088000 --end-time=1667091600`,
+		CustomFlagOverrides: map[string]string{
+			"EndTime": FlagEndTime,
+		},
+		CustomFieldParsers: map[string]osmocli.CustomFieldParserFn{
+			"EndTime": osmocli.FlagOnlyParser(optionalUnixTimeParser(FlagEndTime)),
+		},
+		Flags: osmocli.FlagDesc{OptionalFlags: []*pflag.FlagSet{FlagSetMedianSpotPrice()}},
+	}, &queryproto.MedianSpotPriceRequest{}
+}
+
+// FlagSetMedianSpotPrice returns the flags of the median-spot-price command.
+func FlagSetMedianSpotPrice() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	fs.String(FlagEndTime, "", "The unix time the window ends at, included, instead of the block time")
+	return fs
+}
+
+// GetQueryArithmeticTwapForRouteCommand returns the arithmetic TWAP of an asset over a route of pools, from a time
+// until an optional end time.
+func GetQueryArithmeticTwapForRouteCommand() (*osmocli.QueryDescriptor, *queryproto.ArithmeticTwapForRouteRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "twap-for-route [route] [start-unix-time]",
+		Short: "Query the arithmetic twap of an asset over a route of pools, given as pool-id:base-asset:quote-asset hops separated by commas, from a time until the block time, or the end time.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} twap-for-route 1:uatom:uosmo,2:uosmo:uusdc 1667088000
+{{.CommandPrefix}} twap-for-route 1:uatom:uosmo,2:uosmo:uusdc 1667088000 --end-time=1667091600`,
+		CustomFlagOverrides: map[string]string{
+			"EndTime": FlagEndTime,
+		},
+		CustomFieldParsers: map[string]osmocli.CustomFieldParserFn{
+			"Route":   parseTwapRoute,
+			"EndTime": osmocli.FlagOnlyParser(optionalUnixTimeParser(FlagEndTime)),
+		},
+		Flags: osmocli.FlagDesc{OptionalFlags: []*pflag.FlagSet{FlagSetArithmeticTwapForRoute()}},
+	}, &queryproto.ArithmeticTwapForRouteRequest{}
+}
+
+// parseTwapRoute parses the route of the twap-for-route command, of hops of the form pool-id:base-asset:quote-asset
+// separated by commas.
+func parseTwapRoute(arg string, _ *pflag.FlagSet) (any, osmocli.FieldReadLocation, error) {
+	route := []queryproto.TwapRoutePoolPair{}
+	for _, hopStr := range strings.Split(arg, ",") {
+		fields := strings.Split(strings.TrimSpace(hopStr), ":")
+		if len(fields) != 3 {
+			return nil, osmocli.UsedArg, fmt.Errorf("hop %q is not of the form pool-id:base-asset:quote-asset", hopStr)
+		}
+		poolId, err := osmocli.ParseUint(fields[0], "pool-id")
+		if err != nil {
+			return nil, osmocli.UsedArg, err
+		}
+		route = append(route, queryproto.TwapRoutePoolPair{PoolId: poolId, BaseAsset: fields[1], QuoteAsset: fields[2]})
+	}
+	return route, osmocli.UsedArg, nil
+}
+
+// FlagSetArithmeticTwapForRoute returns the flags of the twap-for-route command.
+func FlagSetArithmeticTwapForRoute() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	fs.String(FlagEndTime, "", "The unix time the TWAP ends at, instead of the block time")
+	return fs
+}
+
+// GetQueryHistoricalRecordsCommand returns a page of the historical records of a pool, optionally of a denom pair
+// and within a time range.
+func GetQueryHistoricalRecordsCommand() (*osmocli.QueryDescriptor, *queryproto.HistoricalRecordsRequest) {
+	return &osmocli.QueryDescriptor{
+		Use:   "historical-records [pool-id]",
+		Short: "Query a page of the historical twap records of a pool, optionally of a denom pair and within a time range.",
+		Long: `{{.Short}}{{.ExampleHeader}}
+{{.CommandPrefix}} historical-records 1
+{{.CommandPrefix}} historical-records 1 --base-asset=uatom --quote-asset=uosmo --start-time=1667088000 --end-time=1667174400 --limit=50`,
+		CustomFlagOverrides: map[string]string{
+			"BaseAsset":  FlagBaseAsset,
+			"QuoteAsset": FlagQuoteAsset,
+			"StartTime":  FlagStartTime,
+			"EndTime":    FlagEndTime,
+		},
+		CustomFieldParsers: map[string]osmocli.CustomFieldParserFn{
+			"StartTime": osmocli.FlagOnlyParser(optionalUnixTimeParser(FlagStartTime)),
+			"EndTime":   osmocli.FlagOnlyParser(optionalUnixTimeParser(FlagEndTime)),
+		},
+		Flags: osmocli.FlagDesc{OptionalFlags: []*pflag.FlagSet{FlagSetHistoricalRecords()}},
+	}, &queryproto.HistoricalRecordsRequest{}
+}
+
+// FlagSetHistoricalRecords returns the flags of the optional filters of the historical-records command.
+func FlagSetHistoricalRecords() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	fs.String(FlagBaseAsset, "", "Only return the records of the denom pair of the base asset and the quote asset")
+	fs.String(FlagQuoteAsset, "", "Only return the records of the denom pair of the base asset and the quote asset")
+	fs.String(FlagStartTime, "", "Only return the records written at or after this unix time")
+	fs.String(FlagEndTime, "", "Only return the records written before this unix time")
+	return fs
+}
+
+// optionalUnixTimeParser returns a parser of the unix time of flagName, that is nil when the flag is not set.
+func optionalUnixTimeParser(flagName string) func(fs *pflag.FlagSet) (*time.Time, error) {
+	return func(fs *pflag.FlagSet) (*time.Time, error) {
+		arg, err := fs.GetString(flagName)
+		if err != nil || arg == "" {
+			return nil, err
+		}
+		t, err := osmocli.ParseUnixTime(arg, flagName)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+}
+
 func twapQueryParseArgs(args []string) (poolId uint64, baseDenom string, startTime time.Time, endTime time.Time, err error) {
 	// boilerplate parse fields
 	// <UINT PARSE>
@@ -0,0 +1,360 @@
+package twapcli_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils/osmocli"
+	twapcli "github.com/osmosis-labs/osmosis/v13/x/twap/client/cli"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+)
+
+func TestParseGeometricTwapArgs(t *testing.T) {
+	tcs := map[string]struct {
+		args     string
+		window   string
+		expected twapcli.GeometricTwapArgs
+		expErr   bool
+	}{
+		"unix times": {
+			args: "1 uatom uosmo 1667088000 1667174400",
+			expected: twapcli.GeometricTwapArgs{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Unix(1667088000, 0),
+				EndTime:    time.Unix(1667174400, 0),
+			},
+		},
+		"RFC3339 timestamps": {
+			args: "1 uatom uosmo 2022-10-30T00:00:00Z 2022-10-31T00:00:00Z",
+			expected: twapcli.GeometricTwapArgs{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Date(2022, 10, 30, 0, 0, 0, 0, time.UTC),
+				EndTime:    time.Date(2022, 10, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"window": {
+			args:   "1 uatom uosmo",
+			window: "30m",
+			expected: twapcli.GeometricTwapArgs{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				Window:     30 * time.Minute,
+			},
+		},
+		"window of hours and minutes": {
+			args:   "1 uatom uosmo",
+			window: "1h30m",
+			expected: twapcli.GeometricTwapArgs{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				Window:     90 * time.Minute,
+			},
+		},
+		"both times and window": {
+			args:   "1 uatom uosmo 1667088000 1667174400",
+			window: "30m",
+			expErr: true,
+		},
+		"start time and window": {
+			args:   "1 uatom uosmo 1667088000",
+			window: "30m",
+			expErr: true,
+		},
+		"neither times nor window": {
+			args:   "1 uatom uosmo",
+			expErr: true,
+		},
+		"start time without end time": {
+			args:   "1 uatom uosmo 1667088000",
+			expErr: true,
+		},
+		"window is not a duration": {
+			args:   "1 uatom uosmo",
+			window: "30",
+			expErr: true,
+		},
+		"negative window": {
+			args:   "1 uatom uosmo",
+			window: "-30m",
+			expErr: true,
+		},
+		"end time is a duration": {
+			args:   "1 uatom uosmo 1667088000 24h",
+			expErr: true,
+		},
+		"invalid pool id": {
+			args:   "pool uatom uosmo 1667088000 1667174400",
+			expErr: true,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			twapArgs, err := twapcli.ParseGeometricTwapArgs(strings.Fields(tc.args), tc.window)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, twapArgs)
+		})
+	}
+}
+
+func TestGetQueryGeometricTwapToNowCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryGeometricTwapToNowCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.GeometricTwapToNowRequest]{
+		"basic test": {
+			Cmd: "1 uatom uosmo 1667088000",
+			ExpectedQuery: &queryproto.GeometricTwapToNowRequest{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Unix(1667088000, 0),
+			},
+		},
+		"start time is not a unix time": {
+			Cmd:         "1 uatom uosmo 2022-10-30T00:00:00Z",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryArchivedTwapRecordsCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryArchivedTwapRecordsCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.ArchivedTwapRecordsRequest]{
+		"basic test": {
+			Cmd: "1 uatom uosmo 1667088000 1667174400 100",
+			ExpectedQuery: &queryproto.ArchivedTwapRecordsRequest{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Unix(1667088000, 0),
+				EndTime:    time.Unix(1667174400, 0),
+				Limit:      100,
+			},
+		},
+		"invalid limit": {
+			Cmd:         "1 uatom uosmo 1667088000 1667174400 all",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryHistoricalRecordsCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryHistoricalRecordsCommand()
+	startTime, endTime := time.Unix(1667088000, 0), time.Unix(1667174400, 0)
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.HistoricalRecordsRequest]{
+		"pool only": {
+			Cmd: "1",
+			ExpectedQuery: &queryproto.HistoricalRecordsRequest{
+				PoolId:     1,
+				Pagination: &query.PageRequest{Key: []uint8{}, Limit: 100},
+			},
+		},
+		"all filters": {
+			Cmd: "1 --base-asset=uatom --quote-asset=uosmo --start-time=1667088000 --end-time=1667174400 --offset=2",
+			ExpectedQuery: &queryproto.HistoricalRecordsRequest{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  &startTime,
+				EndTime:    &endTime,
+				Pagination: &query.PageRequest{Key: []uint8{}, Offset: 2, Limit: 100},
+			},
+		},
+		"start time is not a unix time": {
+			Cmd:         "1 --start-time=2022-10-30T00:00:00Z",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryMedianSpotPriceCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryMedianSpotPriceCommand()
+	endTime := time.Unix(1667091600, 0)
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.MedianSpotPriceRequest]{
+		"until the block time": {
+			Cmd: "1 uatom uosmo 1667088000",
+			ExpectedQuery: &queryproto.MedianSpotPriceRequest{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Unix(1667088000, 0),
+			},
+		},
+		"until the end time": {
+			Cmd: "1 uatom uosmo 1667088000 --end-time=1667091600",
+			ExpectedQuery: &queryproto.MedianSpotPriceRequest{
+				PoolId:     1,
+				BaseAsset:  "uatom",
+				QuoteAsset: "uosmo",
+				StartTime:  time.Unix(1667088000, 0),
+				EndTime:    &endTime,
+			},
+		},
+		"end time is not a unix time": {
+			Cmd:         "1 uatom uosmo 1667088000 --end-time=1h",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryMostRecentRecordsCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryMostRecentRecordsCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.MostRecentRecordsRequest]{
+		"basic test": {
+			Cmd:           "1",
+			ExpectedQuery: &queryproto.MostRecentRecordsRequest{PoolId: 1},
+		},
+		"pool id is not a number": {
+			Cmd:         "pool",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryPoolTwapPairsCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryPoolTwapPairsCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.PoolTwapPairsRequest]{
+		"basic test": {
+			Cmd:           "1",
+			ExpectedQuery: &queryproto.PoolTwapPairsRequest{PoolId: 1},
+		},
+		"pool id is not a number": {
+			Cmd:         "pool",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryCanonicalTwapCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryCanonicalTwapCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.CanonicalTwapRequest]{
+		"basic test": {
+			Cmd:           "uatom 1h",
+			ExpectedQuery: &queryproto.CanonicalTwapRequest{Denom: "uatom", WindowDuration: time.Hour},
+		},
+		"window is not a duration": {
+			Cmd:         "uatom 1667088000",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryArithmeticTwapForRouteCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryArithmeticTwapForRouteCommand()
+	endTime := time.Unix(1667091600, 0)
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.ArithmeticTwapForRouteRequest]{
+		"two hops": {
+			Cmd: "1:uatom:uosmo,2:uosmo:uusdc 1667088000",
+			ExpectedQuery: &queryproto.ArithmeticTwapForRouteRequest{
+				Route: []queryproto.TwapRoutePoolPair{
+					{PoolId: 1, BaseAsset: "uatom", QuoteAsset: "uosmo"},
+					{PoolId: 2, BaseAsset: "uosmo", QuoteAsset: "uusdc"},
+				},
+				StartTime: time.Unix(1667088000, 0),
+			},
+		},
+		"with an end time": {
+			Cmd: "1:uatom:uosmo 1667088000 --end-time=1667091600",
+			ExpectedQuery: &queryproto.ArithmeticTwapForRouteRequest{
+				Route:     []queryproto.TwapRoutePoolPair{{PoolId: 1, BaseAsset: "uatom", QuoteAsset: "uosmo"}},
+				StartTime: time.Unix(1667088000, 0),
+				EndTime:   &endTime,
+			},
+		},
+		"hop without a quote asset": {
+			Cmd:         "1:uatom 1667088000",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQuerySpotPricesAtTimeCommand(t *testing.T) {
+	desc, _ := twapcli.GetQuerySpotPricesAtTimeCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.SpotPricesAtTimeRequest]{
+		"one pair": {
+			Cmd: "1667088000 1:uatom:uusdc",
+			ExpectedQuery: &queryproto.SpotPricesAtTimeRequest{
+				Time:  time.Unix(1667088000, 0),
+				Pairs: []queryproto.SpotPricePair{{PoolId: 1, BaseAsset: "uatom", QuoteAsset: "uusdc"}},
+			},
+		},
+		"several pairs": {
+			Cmd: "1667088000 1:uatom:uusdc,2:uosmo:uusdc",
+			ExpectedQuery: &queryproto.SpotPricesAtTimeRequest{
+				Time: time.Unix(1667088000, 0),
+				Pairs: []queryproto.SpotPricePair{
+					{PoolId: 1, BaseAsset: "uatom", QuoteAsset: "uusdc"},
+					{PoolId: 2, BaseAsset: "uosmo", QuoteAsset: "uusdc"},
+				},
+			},
+		},
+		"pair without a quote asset": {
+			Cmd:         "1667088000 1:uatom",
+			ExpectedErr: true,
+		},
+		"pool id is not a number": {
+			Cmd:         "1667088000 pool:uatom:uusdc",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryLastUpdateInfoCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryLastUpdateInfoCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.LastUpdateInfoRequest]{
+		"basic test": {
+			Cmd: "1 uatom uosmo 100",
+			ExpectedQuery: &queryproto.LastUpdateInfoRequest{
+				PoolId: 1, BaseAsset: "uatom", QuoteAsset: "uosmo", MaxBlockAge: 100,
+			},
+		},
+		"max block age is not a number": {
+			Cmd:         "1 uatom uosmo 1h",
+			ExpectedErr: true,
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
+
+func TestGetQueryAllLastUpdateInfoCommand(t *testing.T) {
+	desc, _ := twapcli.GetQueryAllLastUpdateInfoCommand()
+	tcs := map[string]osmocli.QueryCliTestCase[*queryproto.AllLastUpdateInfoRequest]{
+		"all pairs": {
+			Cmd: "100",
+			ExpectedQuery: &queryproto.AllLastUpdateInfoRequest{
+				MaxBlockAge: 100,
+				Pagination:  &query.PageRequest{Key: []uint8{}, Limit: 100},
+			},
+		},
+		"stale pairs only": {
+			Cmd: "100 --stale-only --limit=50",
+			ExpectedQuery: &queryproto.AllLastUpdateInfoRequest{
+				MaxBlockAge: 100,
+				StaleOnly:   true,
+				Pagination:  &query.PageRequest{Key: []uint8{}, Limit: 50},
+			},
+		},
+	}
+	osmocli.RunQueryTestCases(t, desc, tcs)
+}
@@ -0,0 +1,102 @@
+package twapcli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils/osmocli"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// GetTxCmd returns the transaction commands for this module.
+func GetTxCmd() *cobra.Command {
+	cmd := osmocli.TxIndexCmd(types.ModuleName)
+	osmocli.AddTxCmd(cmd, NewPinTwapRecordCmd)
+	osmocli.AddTxCmd(cmd, NewUnpinTwapRecordCmd)
+	osmocli.AddTxCmd(cmd, NewSetCanonicalTwapRouteCmd)
+	osmocli.AddTxCmd(cmd, NewRemoveCanonicalTwapRouteCmd)
+	osmocli.AddTxCmd(cmd, NewUpdateParamsCmd)
+
+	return cmd
+}
+
+func NewPinTwapRecordCmd() (*osmocli.TxCliDesc, *types.MsgPinTwapRecord) {
+	return &osmocli.TxCliDesc{
+		Use:     "pin-twap-record [pool-id] [denom0] [denom1] [unix-time]",
+		Short:   "Pin the twap record at or before the given time, so that it is never pruned. Must be the pin authority to do so.",
+		Example: "osmosisd tx twap pin-twap-record 1 uatom uosmo 1667088000 --from pin-authority",
+	}, &types.MsgPinTwapRecord{}
+}
+
+func NewUnpinTwapRecordCmd() (*osmocli.TxCliDesc, *types.MsgUnpinTwapRecord) {
+	return &osmocli.TxCliDesc{
+		Use:     "unpin-twap-record [pool-id] [denom0] [denom1] [unix-time]",
+		Short:   "Unpin the pinned twap record at or before the given time. Must be the pin authority to do so.",
+		Example: "osmosisd tx twap unpin-twap-record 1 uatom uosmo 1667088000 --from pin-authority",
+	}, &types.MsgUnpinTwapRecord{}
+}
+
+func NewSetCanonicalTwapRouteCmd() (*osmocli.TxCliDesc, *types.MsgSetCanonicalTwapRoute) {
+	return &osmocli.TxCliDesc{
+		Use:              "set-canonical-twap-route [denom] [hops]",
+		Short:            "Set the canonical route of a denom to the canonical quote denom, given as pool-id:quote-asset hops separated by commas. Must be the canonical route authority to do so.",
+		Example:          "osmosisd tx twap set-canonical-twap-route ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2 1:uosmo,678:ibc/D189335C6E4A68B513C10AB227BF1C1D38C746766278BA3EEB4FB14124F1D858 --from canonical-route-authority",
+		NumArgs:          2,
+		ParseAndBuildMsg: buildSetCanonicalTwapRouteMsg,
+	}, &types.MsgSetCanonicalTwapRoute{}
+}
+
+// buildSetCanonicalTwapRouteMsg builds the msg of the set-canonical-twap-route command, whose hops are of the form
+// pool-id:quote-asset and separated by commas.
+func buildSetCanonicalTwapRouteMsg(clientCtx client.Context, args []string, _ *pflag.FlagSet) (sdk.Msg, error) {
+	route := types.CanonicalTwapRoute{Denom: args[0]}
+	for _, hopStr := range strings.Split(args[1], ",") {
+		fields := strings.Split(strings.TrimSpace(hopStr), ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("hop %q is not of the form pool-id:quote-asset", hopStr)
+		}
+		poolId, err := osmocli.ParseUint(fields[0], "pool-id")
+		if err != nil {
+			return nil, err
+		}
+		route.Hops = append(route.Hops, types.CanonicalTwapRouteHop{PoolId: poolId, QuoteAsset: fields[1]})
+	}
+	return types.NewMsgSetCanonicalTwapRoute(clientCtx.GetFromAddress().String(), route), nil
+}
+
+func NewRemoveCanonicalTwapRouteCmd() (*osmocli.TxCliDesc, *types.MsgRemoveCanonicalTwapRoute) {
+	return &osmocli.TxCliDesc{
+		Use:     "remove-canonical-twap-route [denom]",
+		Short:   "Remove the canonical route of a denom. Must be the canonical route authority to do so.",
+		Example: "osmosisd tx twap remove-canonical-twap-route ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2 --from canonical-route-authority",
+	}, &types.MsgRemoveCanonicalTwapRoute{}
+}
+
+func NewUpdateParamsCmd() (*osmocli.TxCliDesc, *types.MsgUpdateParams) {
+	return &osmocli.TxCliDesc{
+		Use:              "update-params [params-file]",
+		Short:            "Replace the twap params with the params of a JSON file, such as the params object of the params query. Must be the params authority to do so.",
+		Example:          "osmosisd tx twap update-params params.json --from params-authority",
+		NumArgs:          1,
+		ParseAndBuildMsg: buildUpdateParamsMsg,
+	}, &types.MsgUpdateParams{}
+}
+
+// buildUpdateParamsMsg builds the msg of the update-params command from the JSON file of the params.
+func buildUpdateParamsMsg(clientCtx client.Context, args []string, _ *pflag.FlagSet) (sdk.Msg, error) {
+	bz, err := os.ReadFile(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var params types.Params
+	if err := clientCtx.Codec.UnmarshalJSON(bz, &params); err != nil {
+		return nil, fmt.Errorf("invalid params file %s: %w", args[0], err)
+	}
+	return types.NewMsgUpdateParams(clientCtx.GetFromAddress().String(), params), nil
+}
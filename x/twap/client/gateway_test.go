@@ -0,0 +1,188 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"google.golang.org/grpc/codes"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server/api"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+)
+
+const (
+	arithmeticTwapPath      = "/osmosis/twap/v1beta1/ArithmeticTwap"
+	arithmeticTwapToNowPath = "/osmosis/twap/v1beta1/ArithmeticTwapToNow"
+	geometricTwapToNowPath  = "/osmosis/twap/v1beta1/GeometricTwapToNow"
+)
+
+// gatewayServer serves the twap queries on ctx through the gRPC gateway mux of the node's REST server.
+func (suite *QueryTestSuite) gatewayServer(ctx sdk.Context) *httptest.Server {
+	suite.QueryHelper.Ctx = ctx
+	apiSrv := api.New(sdkclient.Context{}.WithInterfaceRegistry(suite.App.InterfaceRegistry()), log.NewNopLogger())
+	err := queryproto.RegisterQueryHandlerClient(context.Background(), apiSrv.GRPCGatewayRouter, queryproto.NewQueryClient(suite.QueryHelper))
+	suite.Require().NoError(err)
+
+	server := httptest.NewServer(apiSrv.GRPCGatewayRouter)
+	suite.T().Cleanup(server.Close)
+	return server
+}
+
+// getGatewayJSON sends a GET request to the gateway and decodes the JSON response into res.
+func (suite *QueryTestSuite) getGatewayJSON(server *httptest.Server, path string, params url.Values, res interface{}) int {
+	resp, err := http.Get(server.URL + path + "?" + params.Encode())
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	suite.Require().Equal("application/json", resp.Header.Get("Content-Type"))
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(res))
+	return resp.StatusCode
+}
+
+func (suite *QueryTestSuite) TestGatewayArithmeticTwap() {
+	suite.SetupTest()
+
+	var (
+		coins = sdk.NewCoins(
+			sdk.NewInt64Coin("tokenA", 1000),
+			sdk.NewInt64Coin("tokenB", 2000),
+		)
+		poolID    = suite.PrepareBalancerPoolWithCoins(coins...)
+		startTime = suite.Ctx.BlockTime()
+		server    = suite.gatewayServer(suite.Ctx.WithBlockTime(startTime.Add(time.Hour)))
+	)
+
+	for _, path := range []string{arithmeticTwapPath, arithmeticTwapToNowPath} {
+		path := path
+
+		suite.Run(path, func() {
+			var res struct {
+				ArithmeticTwap string `json:"arithmetic_twap"`
+				StartTime      string `json:"start_time"`
+			}
+			statusCode := suite.getGatewayJSON(server, path, url.Values{
+				"pool_id":     {strconv.FormatUint(poolID, 10)},
+				"base_asset":  {"tokenA"},
+				"quote_asset": {"tokenB"},
+				"start_time":  {startTime.Format(time.RFC3339Nano)},
+			}, &res)
+
+			suite.Require().Equal(http.StatusOK, statusCode)
+			suite.Require().Equal(sdk.NewDec(2).String(), res.ArithmeticTwap)
+
+			// The start time must come back as an RFC3339 string rather than as a struct or in nanoseconds.
+			resStartTime, err := time.Parse(time.RFC3339Nano, res.StartTime)
+			suite.Require().NoError(err, "start_time %q is not RFC3339", res.StartTime)
+			suite.Require().True(startTime.Equal(resStartTime), "expected %s, got %s", startTime, resStartTime)
+		})
+	}
+}
+
+func (suite *QueryTestSuite) TestGatewayGeometricTwapToNow() {
+	suite.SetupTest()
+
+	// the spot price of tokenA, the pool's asset 0, is 2, as twapPow only converges for non-negative exponents
+	var (
+		coins = sdk.NewCoins(
+			sdk.NewInt64Coin("tokenA", 2000),
+			sdk.NewInt64Coin("tokenB", 1000),
+		)
+		poolID    = suite.PrepareBalancerPoolWithCoins(coins...)
+		startTime = suite.Ctx.BlockTime()
+		blockTime = startTime.Add(time.Hour)
+		server    = suite.gatewayServer(suite.Ctx.WithBlockTime(blockTime))
+	)
+
+	var res struct {
+		GeometricTwap string `json:"geometric_twap"`
+		EndTime       string `json:"end_time"`
+	}
+	statusCode := suite.getGatewayJSON(server, geometricTwapToNowPath, url.Values{
+		"pool_id":     {strconv.FormatUint(poolID, 10)},
+		"base_asset":  {"tokenA"},
+		"quote_asset": {"tokenB"},
+		"start_time":  {startTime.Format(time.RFC3339Nano)},
+	}, &res)
+
+	suite.Require().Equal(http.StatusOK, statusCode)
+	suite.Require().Equal(sdk.NewDecWithPrec(5, 1).String(), res.GeometricTwap)
+
+	// The end time is the block time the TWAP was computed until, as an RFC3339 string.
+	resEndTime, err := time.Parse(time.RFC3339Nano, res.EndTime)
+	suite.Require().NoError(err, "end_time %q is not RFC3339", res.EndTime)
+	suite.Require().True(blockTime.Equal(resEndTime), "expected %s, got %s", blockTime, resEndTime)
+}
+
+func (suite *QueryTestSuite) TestGatewayErrorResponse() {
+	suite.SetupTest()
+
+	var (
+		coins = sdk.NewCoins(
+			sdk.NewInt64Coin("tokenA", 1000),
+			sdk.NewInt64Coin("tokenB", 2000),
+		)
+		poolID    = suite.PrepareBalancerPoolWithCoins(coins...)
+		startTime = suite.Ctx.BlockTime()
+		server    = suite.gatewayServer(suite.Ctx.WithBlockTime(startTime.Add(time.Hour)))
+	)
+
+	testCases := []struct {
+		name      string
+		poolId    uint64
+		startTime string
+
+		// expectCode is the expected gRPC code of the error, checked if set.
+		expectCode       codes.Code
+		expectStatusCode int
+	}{
+		{
+			name:             "start time not in RFC3339",
+			poolId:           poolID,
+			startTime:        strconv.FormatInt(startTime.UnixNano(), 10),
+			expectCode:       codes.InvalidArgument,
+			expectStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "non-existent pool",
+			poolId:    poolID + 1,
+			startTime: startTime.Format(time.RFC3339Nano),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		for _, path := range []string{arithmeticTwapPath, arithmeticTwapToNowPath, geometricTwapToNowPath} {
+			suite.Run(tc.name+" "+path, func() {
+				var res struct {
+					Code    *codes.Code `json:"code"`
+					Message string      `json:"message"`
+				}
+				statusCode := suite.getGatewayJSON(server, path, url.Values{
+					"pool_id":     {strconv.FormatUint(tc.poolId, 10)},
+					"base_asset":  {"tokenA"},
+					"quote_asset": {"tokenB"},
+					"start_time":  {tc.startTime},
+				}, &res)
+
+				suite.Require().NotEqual(http.StatusOK, statusCode)
+				suite.Require().NotNil(res.Code, "error response has no code")
+				suite.Require().NotEqual(codes.OK, *res.Code)
+				suite.Require().NotEmpty(res.Message)
+				if tc.expectCode != codes.OK {
+					suite.Require().Equal(tc.expectCode, *res.Code)
+					suite.Require().Equal(tc.expectStatusCode, statusCode)
+				}
+			})
+		}
+	}
+}
@@ -30,6 +30,216 @@ func (q Querier) Params(grpcCtx context.Context,
 	return q.Q.Params(ctx, *req)
 }
 
+func (q Querier) HistoricalSpotPrice(grpcCtx context.Context,
+	req *queryproto.HistoricalSpotPriceRequest,
+) (*queryproto.HistoricalSpotPriceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.HistoricalSpotPrice(ctx, *req)
+}
+
+func (q Querier) TwapCandles(grpcCtx context.Context,
+	req *queryproto.TwapCandlesRequest,
+) (*queryproto.TwapCandlesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.TwapCandles(ctx, *req)
+}
+
+func (q Querier) PoolHealth(grpcCtx context.Context,
+	req *queryproto.PoolHealthRequest,
+) (*queryproto.PoolHealthResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.PoolHealth(ctx, *req)
+}
+
+func (q Querier) TwapsForPair(grpcCtx context.Context,
+	req *queryproto.TwapsForPairRequest,
+) (*queryproto.TwapsForPairResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.TwapsForPair(ctx, *req)
+}
+
+func (q Querier) GeometricTwap(grpcCtx context.Context,
+	req *queryproto.GeometricTwapRequest,
+) (*queryproto.GeometricTwapResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.GeometricTwap(ctx, *req)
+}
+
+func (q Querier) GeometricTwapToNow(grpcCtx context.Context,
+	req *queryproto.GeometricTwapToNowRequest,
+) (*queryproto.GeometricTwapToNowResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.GeometricTwapToNow(ctx, *req)
+}
+
+func (q Querier) ArchivedTwapRecords(grpcCtx context.Context,
+	req *queryproto.ArchivedTwapRecordsRequest,
+) (*queryproto.ArchivedTwapRecordsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.ArchivedTwapRecords(ctx, *req)
+}
+
+func (q Querier) ArchivedArithmeticTwap(grpcCtx context.Context,
+	req *queryproto.ArchivedArithmeticTwapRequest,
+) (*queryproto.ArchivedArithmeticTwapResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.ArchivedArithmeticTwap(ctx, *req)
+}
+
+func (q Querier) HistoricalRecords(grpcCtx context.Context,
+	req *queryproto.HistoricalRecordsRequest,
+) (*queryproto.HistoricalRecordsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.HistoricalRecords(ctx, *req)
+}
+
+func (q Querier) ArithmeticTwapForRoute(grpcCtx context.Context,
+	req *queryproto.ArithmeticTwapForRouteRequest,
+) (*queryproto.ArithmeticTwapForRouteResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.ArithmeticTwapForRoute(ctx, *req)
+}
+
+func (q Querier) MedianSpotPrice(grpcCtx context.Context,
+	req *queryproto.MedianSpotPriceRequest,
+) (*queryproto.MedianSpotPriceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.MedianSpotPrice(ctx, *req)
+}
+
+func (q Querier) MostRecentRecords(grpcCtx context.Context,
+	req *queryproto.MostRecentRecordsRequest,
+) (*queryproto.MostRecentRecordsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.MostRecentRecords(ctx, *req)
+}
+
+func (q Querier) PoolTwapPairs(grpcCtx context.Context,
+	req *queryproto.PoolTwapPairsRequest,
+) (*queryproto.PoolTwapPairsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.PoolTwapPairs(ctx, *req)
+}
+
+func (q Querier) CanonicalTwap(grpcCtx context.Context,
+	req *queryproto.CanonicalTwapRequest,
+) (*queryproto.CanonicalTwapResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.CanonicalTwap(ctx, *req)
+}
+
+func (q Querier) CanonicalTwapRoutes(grpcCtx context.Context,
+	req *queryproto.CanonicalTwapRoutesRequest,
+) (*queryproto.CanonicalTwapRoutesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.CanonicalTwapRoutes(ctx, *req)
+}
+
+func (q Querier) LastUpdateInfo(grpcCtx context.Context,
+	req *queryproto.LastUpdateInfoRequest,
+) (*queryproto.LastUpdateInfoResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.LastUpdateInfo(ctx, *req)
+}
+
+func (q Querier) AllLastUpdateInfo(grpcCtx context.Context,
+	req *queryproto.AllLastUpdateInfoRequest,
+) (*queryproto.AllLastUpdateInfoResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.AllLastUpdateInfo(ctx, *req)
+}
+
+func (q Querier) SpotPricesAtTime(grpcCtx context.Context,
+	req *queryproto.SpotPricesAtTimeRequest,
+) (*queryproto.SpotPricesAtTimeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.SpotPricesAtTime(ctx, *req)
+}
+
+func (q Querier) TwapChange(grpcCtx context.Context,
+	req *queryproto.TwapChangeRequest,
+) (*queryproto.TwapChangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.TwapChange(ctx, *req)
+}
+
+func (q Querier) PinnedRecords(grpcCtx context.Context,
+	req *queryproto.PinnedRecordsRequest,
+) (*queryproto.PinnedRecordsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.PinnedRecords(ctx, *req)
+}
+
+func (q Querier) ModuleVersion(grpcCtx context.Context,
+	req *queryproto.ModuleVersionRequest,
+) (*queryproto.ModuleVersionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(grpcCtx)
+	return q.Q.ModuleVersion(ctx, *req)
+}
+
 func (q Querier) ArithmeticTwapToNow(grpcCtx context.Context,
 	req *queryproto.ArithmeticTwapToNowRequest,
 ) (*queryproto.ArithmeticTwapToNowResponse, error) {
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+)
+
+// Streaming queries are not supported by the query.yml code generation, so they are written by hand here.
+
+func (q Querier) StreamTwapRecords(req *queryproto.StreamTwapRecordsRequest,
+	srv queryproto.Query_StreamTwapRecordsServer,
+) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx, err := q.streamContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	return q.Q.StreamTwapRecords(ctx, *req, srv.Send)
+}
+
+// streamContext returns the sdk.Context of a streaming query, at the height of the request's
+// height header if it has one. Cancelling the stream cancels the returned context's Context().
+func (q Querier) streamContext(grpcCtx context.Context) (sdk.Context, error) {
+	// queries routed in process already carry an sdk.Context
+	if ctx, ok := grpcCtx.Value(sdk.SdkContextKey).(sdk.Context); ok {
+		return ctx.WithContext(grpcCtx), nil
+	}
+	if q.Q.NewQueryContext == nil {
+		return sdk.Context{}, status.Error(codes.Unimplemented, "streaming queries are not enabled on this node")
+	}
+
+	var height int64
+	if md, ok := metadata.FromIncomingContext(grpcCtx); ok {
+		if heightHeaders := md.Get(grpctypes.GRPCBlockHeightHeader); len(heightHeaders) == 1 {
+			var err error
+			height, err = strconv.ParseInt(heightHeaders[0], 10, 64)
+			if err != nil || height < 0 {
+				return sdk.Context{}, status.Errorf(codes.InvalidArgument, "invalid height header %q", heightHeaders[0])
+			}
+		}
+	}
+	ctx, err := q.Q.NewQueryContext(height)
+	if err != nil {
+		return sdk.Context{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return ctx.WithContext(grpcCtx), nil
+}
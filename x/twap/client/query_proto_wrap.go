@@ -3,21 +3,34 @@ package client
 import (
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/osmosis-labs/osmosis/v13/x/twap"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 // This file should evolve to being code gen'd, off of `proto/twap/v1beta/query.yml`
 
 type Querier struct {
 	K twap.Keeper
+	// NewQueryContext returns a context on the state committed at the given height, or at the latest height if it is 0.
+	// The SDK's gRPC server only sets up an sdk.Context for unary queries, so streaming queries need it to get one.
+	NewQueryContext func(height int64) (sdk.Context, error)
+}
+
+// measureQuery records the latency of the query name since start, as the twap.query.<name> metric
+func measureQuery(start time.Time, name string) {
+	telemetry.MeasureSince(start, types.ModuleName, types.MetricKeyQuery, name)
 }
 
 func (q Querier) ArithmeticTwap(ctx sdk.Context,
 	req queryproto.ArithmeticTwapRequest, // nolint: staticcheck
 ) (*queryproto.ArithmeticTwapResponse, error) {
+	defer measureQuery(time.Now(), "ArithmeticTwap")
 	if req.EndTime == nil {
 		req.EndTime = &time.Time{}
 	}
@@ -25,24 +38,572 @@ func (q Querier) ArithmeticTwap(ctx sdk.Context,
 		*req.EndTime = ctx.BlockTime()
 	}
 
-	twap, err := q.K.GetArithmeticTwap(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, *req.EndTime)
+	if err := validateTwapType(req.TwapType, req.AllowGaps); err != nil {
+		return nil, err
+	}
+	startTime, err := q.resolveStartTime(ctx, req.StartTime, req.WindowDuration, *req.EndTime, req.ClampToKeepPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	result, coveredFraction := types.TwapResult{}, sdk.OneDec()
+	switch {
+	case req.AllowGaps:
+		result, coveredFraction, err = q.K.GetArithmeticTwapAllowingGaps(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, startTime, *req.EndTime)
+	case req.TwapType == queryproto.HarmonicTwapType:
+		result, err = q.K.GetHarmonicTwapResult(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, startTime, *req.EndTime)
+	default:
+		result, err = q.K.GetArithmeticTwapResult(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, startTime, *req.EndTime)
+	}
 
 	// nolint: staticcheck
-	return &queryproto.ArithmeticTwapResponse{ArithmeticTwap: twap}, err
+	return &queryproto.ArithmeticTwapResponse{
+		ArithmeticTwap:    result.Price,
+		StartTime:         startTime,
+		CoveredFraction:   coveredFraction,
+		StartInterpolated: result.StartInterpolated,
+		StartRecordTime:   result.StartRecordTime,
+		EndRecordTime:     result.EndRecordTime,
+		LastErrorTime:     optionalTime(result.LastErrorTime),
+	}, err
+}
+
+// validateTwapType returns an InvalidArgument error if twapType isn't a TWAP type, or isn't the arithmetic type
+// while allowGaps is set.
+func validateTwapType(twapType queryproto.TwapType, allowGaps bool) error {
+	if _, ok := queryproto.TwapType_name[int32(twapType)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown twap_type %d", twapType)
+	}
+	if allowGaps && twapType != queryproto.ArithmeticTwapType {
+		return status.Errorf(codes.InvalidArgument, "allow_gaps is only supported for arithmetic TWAPs, not %s", twapType)
+	}
+	return nil
+}
+
+// optionalTime returns a pointer to t, or nil if t is the zero time
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// GeometricTwap returns the geometric TWAP over [start_time, end_time], end_time defaulting to the block time.
+func (q Querier) GeometricTwap(ctx sdk.Context,
+	req queryproto.GeometricTwapRequest,
+) (*queryproto.GeometricTwapResponse, error) {
+	defer measureQuery(time.Now(), "GeometricTwap")
+	if (req.EndTime == time.Time{}) {
+		req.EndTime = ctx.BlockTime()
+	}
+	twap, err := q.K.GetGeometricTwap(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, req.EndTime)
+	return &queryproto.GeometricTwapResponse{GeometricTwap: twap}, err
+}
+
+// GeometricTwapToNow returns the geometric TWAP over [start_time, block time], and the block time it ends at.
+func (q Querier) GeometricTwapToNow(ctx sdk.Context,
+	req queryproto.GeometricTwapToNowRequest,
+) (*queryproto.GeometricTwapToNowResponse, error) {
+	defer measureQuery(time.Now(), "GeometricTwapToNow")
+	cachedCtx := twap.WithRecordCache(ctx)
+	twap, err := q.K.GetGeometricTwapToNow(cachedCtx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime)
+	return &queryproto.GeometricTwapToNowResponse{GeometricTwap: twap, EndTime: ctx.BlockTime()}, err
 }
 
 func (q Querier) ArithmeticTwapToNow(ctx sdk.Context,
 	req queryproto.ArithmeticTwapToNowRequest, // nolint: staticcheck
 ) (*queryproto.ArithmeticTwapToNowResponse, error) {
-	twap, err := q.K.GetArithmeticTwapToNow(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime)
+	defer measureQuery(time.Now(), "ArithmeticTwapToNow")
+	if err := validateTwapType(req.TwapType, false); err != nil {
+		return nil, err
+	}
+	startTime, err := q.resolveStartTime(ctx, req.StartTime, req.WindowDuration, ctx.BlockTime(), req.ClampToKeepPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	// TWAPs to now end at the most recent record, which the most requested pairs usually have in the record cache
+	cachedCtx := twap.WithRecordCache(ctx)
+	var result types.TwapResult
+	if req.TwapType == queryproto.HarmonicTwapType {
+		result, err = q.K.GetHarmonicTwapToNowResult(cachedCtx, req.PoolId, req.BaseAsset, req.QuoteAsset, startTime)
+	} else {
+		result, err = q.K.GetArithmeticTwapToNowResult(cachedCtx, req.PoolId, req.BaseAsset, req.QuoteAsset, startTime)
+	}
 
 	// nolint: staticcheck
-	return &queryproto.ArithmeticTwapToNowResponse{ArithmeticTwap: twap}, err
+	return &queryproto.ArithmeticTwapToNowResponse{
+		ArithmeticTwap:    result.Price,
+		StartTime:         startTime,
+		StartInterpolated: result.StartInterpolated,
+		StartRecordTime:   result.StartRecordTime,
+		EndRecordTime:     result.EndRecordTime,
+		LastErrorTime:     optionalTime(result.LastErrorTime),
+	}, err
+}
+
+// resolveStartTime returns the start time of a TWAP query, which is either given directly,
+// or as a window ending at endTime, the end time of the TWAP.
+// If clampToKeepPeriod is set, start times before the record history keep period are moved to its start.
+// Setting both a start time and a window duration, or a window duration that isn't positive, is an
+// InvalidArgument error.
+func (q Querier) resolveStartTime(ctx sdk.Context, startTime time.Time, windowDuration *time.Duration, endTime time.Time, clampToKeepPeriod bool) (time.Time, error) {
+	if windowDuration != nil {
+		if (startTime != time.Time{}) {
+			return time.Time{}, status.Error(codes.InvalidArgument, "only one of start_time and window_duration can be set")
+		}
+		if *windowDuration <= 0 {
+			return time.Time{}, status.Errorf(codes.InvalidArgument, "window_duration must be positive, was %s", *windowDuration)
+		}
+		startTime = endTime.Add(-*windowDuration)
+	}
+
+	if clampToKeepPeriod {
+		keepPeriodStart := ctx.BlockTime().Add(-q.K.GetParams(ctx).RecordHistoryKeepPeriod)
+		if startTime.Before(keepPeriodStart) {
+			startTime = keepPeriodStart
+		}
+	}
+	return startTime, nil
 }
 
 func (q Querier) Params(ctx sdk.Context,
 	req queryproto.ParamsRequest,
 ) (*queryproto.ParamsResponse, error) {
+	defer measureQuery(time.Now(), "Params")
 	params := q.K.GetParams(ctx)
 	return &queryproto.ParamsResponse{Params: params}, nil
 }
+
+func (q Querier) ModuleVersion(ctx sdk.Context,
+	req queryproto.ModuleVersionRequest,
+) (*queryproto.ModuleVersionResponse, error) {
+	defer measureQuery(time.Now(), "ModuleVersion")
+	storeVersion := q.K.GetStoreVersion(ctx)
+	return &queryproto.ModuleVersionResponse{
+		ConsensusVersion:    types.ConsensusVersion,
+		StoreVersion:        storeVersion,
+		RecordSchemaVersion: types.CurrentRecordSchemaVersion,
+		RecordExtensions:    types.RecordExtensionsForStoreVersion(storeVersion),
+	}, nil
+}
+
+func (q Querier) PinnedRecords(ctx sdk.Context,
+	req queryproto.PinnedRecordsRequest,
+) (*queryproto.PinnedRecordsResponse, error) {
+	defer measureQuery(time.Now(), "PinnedRecords")
+	records, err := q.K.GetPinnedRecords(ctx)
+	return &queryproto.PinnedRecordsResponse{Records: records}, err
+}
+
+// TwapChange returns the arithmetic TWAPs over [now - window, now] and [now - offset - window, now - offset],
+// and the relative change between them.
+// An error computing either TWAP is returned in its window rather than failing the query,
+// in which case the change is left unset.
+func (q Querier) TwapChange(ctx sdk.Context,
+	req queryproto.TwapChangeRequest,
+) (*queryproto.TwapChangeResponse, error) {
+	defer measureQuery(time.Now(), "TwapChange")
+	if req.Window <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "window must be positive, was %s", req.Window)
+	}
+	if req.Offset <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "offset must be positive, was %s", req.Offset)
+	}
+
+	now := ctx.BlockTime()
+	previousEnd := now.Add(-req.Offset)
+	current := q.twapWindow(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, now.Add(-req.Window), now)
+	previous := q.twapWindow(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, previousEnd.Add(-req.Window), previousEnd)
+
+	res := &queryproto.TwapChangeResponse{Current: current, Previous: previous}
+	if current.Error == "" && previous.Error == "" && previous.ArithmeticTwap.IsPositive() {
+		change := current.ArithmeticTwap.Quo(previous.ArithmeticTwap).Sub(sdk.OneDec())
+		res.Change = &change
+	}
+	return res, nil
+}
+
+func (q Querier) twapWindow(ctx sdk.Context, poolId uint64, baseAsset, quoteAsset string, startTime, endTime time.Time) queryproto.TwapWindow {
+	window := queryproto.TwapWindow{StartTime: startTime, EndTime: endTime}
+	twap, err := q.K.GetArithmeticTwap(ctx, poolId, baseAsset, quoteAsset, startTime, endTime)
+	if err != nil {
+		window.Error = err.Error()
+	}
+	window.ArithmeticTwap = sdk.ZeroDec()
+	if !twap.IsNil() {
+		window.ArithmeticTwap = twap
+	}
+	return window
+}
+
+// ArchivedTwapRecords returns the archived records of [start_time, end_time], end_time defaulting to the block time.
+func (q Querier) ArchivedTwapRecords(ctx sdk.Context,
+	req queryproto.ArchivedTwapRecordsRequest,
+) (*queryproto.ArchivedTwapRecordsResponse, error) {
+	defer measureQuery(time.Now(), "ArchivedTwapRecords")
+	if (req.EndTime == time.Time{}) {
+		req.EndTime = ctx.BlockTime()
+	}
+	records, nextTime, err := q.K.GetArchivedRecords(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, req.EndTime, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.ArchivedTwapRecordsResponse{Records: records, NextTime: nextTime}, nil
+}
+
+// ArchivedArithmeticTwap returns the arithmetic TWAP over [start_time, end_time] computed from the archived records,
+// end_time defaulting to the block time.
+func (q Querier) ArchivedArithmeticTwap(ctx sdk.Context,
+	req queryproto.ArchivedArithmeticTwapRequest,
+) (*queryproto.ArchivedArithmeticTwapResponse, error) {
+	defer measureQuery(time.Now(), "ArchivedArithmeticTwap")
+	if (req.EndTime == time.Time{}) {
+		req.EndTime = ctx.BlockTime()
+	}
+	twap, err := q.K.GetArchivedArithmeticTwap(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, req.EndTime)
+	return &queryproto.ArchivedArithmeticTwapResponse{ArithmeticTwap: twap}, err
+}
+
+// HistoricalRecords returns a page of the historical records of a pool, of a denom pair or of all of them.
+func (q Querier) HistoricalRecords(ctx sdk.Context,
+	req queryproto.HistoricalRecordsRequest,
+) (*queryproto.HistoricalRecordsResponse, error) {
+	defer measureQuery(time.Now(), "HistoricalRecords")
+	if req.PoolId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "pool id must be set")
+	}
+	if (req.BaseAsset == "") != (req.QuoteAsset == "") {
+		return nil, status.Error(codes.InvalidArgument, "both or neither of base asset and quote asset must be set")
+	}
+	records, pageRes, err := q.K.GetHistoricalRecordsPage(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, req.EndTime, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.HistoricalRecordsResponse{Records: records, Pagination: pageRes}, nil
+}
+
+// MostRecentRecords returns the most recent record of every denom pair of a pool.
+func (q Querier) MostRecentRecords(ctx sdk.Context,
+	req queryproto.MostRecentRecordsRequest,
+) (*queryproto.MostRecentRecordsResponse, error) {
+	defer measureQuery(time.Now(), "MostRecentRecords")
+	if req.PoolId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "pool id must be set")
+	}
+	records, err := q.K.GetAllMostRecentRecordsForPool(ctx, req.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.MostRecentRecordsResponse{Records: records}, nil
+}
+
+// PoolTwapPairs returns the denom pairs of a pool that have twap records.
+func (q Querier) PoolTwapPairs(ctx sdk.Context,
+	req queryproto.PoolTwapPairsRequest,
+) (*queryproto.PoolTwapPairsResponse, error) {
+	defer measureQuery(time.Now(), "PoolTwapPairs")
+	if req.PoolId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "pool id must be set")
+	}
+	denomPairs, err := q.K.GetPoolTwapPairs(ctx, req.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]queryproto.TwapPair, 0, len(denomPairs))
+	for _, denomPair := range denomPairs {
+		pairs = append(pairs, queryproto.TwapPair{Asset0Denom: denomPair.Denom0, Asset1Denom: denomPair.Denom1})
+	}
+	return &queryproto.PoolTwapPairsResponse{Pairs: pairs}, nil
+}
+
+// CanonicalTwap returns the arithmetic TWAP of the denom in units of the canonical quote denom over the window ending
+// at the block time, computed over the canonical route of the denom. The TWAP is returned along with the spot price
+// error of a hop, as for ArithmeticTwapForRoute.
+func (q Querier) CanonicalTwap(ctx sdk.Context,
+	req queryproto.CanonicalTwapRequest,
+) (*queryproto.CanonicalTwapResponse, error) {
+	defer measureQuery(time.Now(), "CanonicalTwap")
+	result, err := q.K.GetCanonicalTwap(ctx, req.Denom, req.WindowDuration)
+	return &queryproto.CanonicalTwapResponse{
+		QuoteDenom:     q.K.GetParams(ctx).CanonicalQuoteDenom,
+		ArithmeticTwap: result.Price,
+		LastErrorTime:  optionalTime(result.LastErrorTime),
+	}, err
+}
+
+// CanonicalTwapRoutes returns the canonical routes of all the denoms, and the canonical quote denom they end in.
+func (q Querier) CanonicalTwapRoutes(ctx sdk.Context,
+	req queryproto.CanonicalTwapRoutesRequest,
+) (*queryproto.CanonicalTwapRoutesResponse, error) {
+	defer measureQuery(time.Now(), "CanonicalTwapRoutes")
+	routes, err := q.K.GetAllCanonicalTwapRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.CanonicalTwapRoutesResponse{QuoteDenom: q.K.GetParams(ctx).CanonicalQuoteDenom, Routes: routes}, nil
+}
+
+// LastUpdateInfo returns when the most recent record of a denom pair of a pool was written, and whether it is stale.
+func (q Querier) LastUpdateInfo(ctx sdk.Context,
+	req queryproto.LastUpdateInfoRequest,
+) (*queryproto.LastUpdateInfoResponse, error) {
+	defer measureQuery(time.Now(), "LastUpdateInfo")
+	if req.PoolId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "pool id must be set")
+	}
+	info, err := q.K.GetLastUpdateInfo(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.MaxBlockAge)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.LastUpdateInfoResponse{Info: pairLastUpdateInfo(info)}, nil
+}
+
+// AllLastUpdateInfo returns a page of the last update info of every denom pair of every pool, or of the stale ones.
+func (q Querier) AllLastUpdateInfo(ctx sdk.Context,
+	req queryproto.AllLastUpdateInfoRequest,
+) (*queryproto.AllLastUpdateInfoResponse, error) {
+	defer measureQuery(time.Now(), "AllLastUpdateInfo")
+	infos, pageRes, err := q.K.GetLastUpdateInfoPage(ctx, req.MaxBlockAge, req.StaleOnly, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	pairInfos := make([]queryproto.PairLastUpdateInfo, 0, len(infos))
+	for _, info := range infos {
+		pairInfos = append(pairInfos, pairLastUpdateInfo(info))
+	}
+	return &queryproto.AllLastUpdateInfoResponse{Infos: pairInfos, Pagination: pageRes}, nil
+}
+
+func pairLastUpdateInfo(info types.LastUpdateInfo) queryproto.PairLastUpdateInfo {
+	return queryproto.PairLastUpdateInfo{
+		PoolId:            info.PoolId,
+		Asset0Denom:       info.Asset0Denom,
+		Asset1Denom:       info.Asset1Denom,
+		Height:            info.Height,
+		Time:              info.Time,
+		BlocksSinceUpdate: info.BlocksSinceUpdate,
+		Stale:             info.Stale,
+		Quarantined:       info.Quarantined,
+	}
+}
+
+// SpotPricesAtTime returns the spot prices of the pairs at the requested time, in the order of the request.
+// An error resolving a pair is returned in its entry rather than failing the query.
+func (q Querier) SpotPricesAtTime(ctx sdk.Context,
+	req queryproto.SpotPricesAtTimeRequest,
+) (*queryproto.SpotPricesAtTimeResponse, error) {
+	defer measureQuery(time.Now(), "SpotPricesAtTime")
+	pairs := make([]types.SpotPricePair, 0, len(req.Pairs))
+	for _, pair := range req.Pairs {
+		pairs = append(pairs, types.SpotPricePair{PoolId: pair.PoolId, BaseAsset: pair.BaseAsset, QuoteAsset: pair.QuoteAsset})
+	}
+	prices, err := q.K.GetSpotPricesAtTime(ctx, req.Time, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	spotPrices := make([]queryproto.PairSpotPrice, 0, len(prices))
+	for i, price := range prices {
+		spotPrice := queryproto.PairSpotPrice{Pair: req.Pairs[i], SpotPrice: sdk.ZeroDec()}
+		if price.Err != nil {
+			spotPrice.Error = price.Err.Error()
+		} else {
+			spotPrice.SpotPrice = price.SpotPrice
+			spotPrice.RecordTime = price.RecordTime
+			spotPrice.ErrorActive = price.ErrorActive
+		}
+		spotPrices = append(spotPrices, spotPrice)
+	}
+	return &queryproto.SpotPricesAtTimeResponse{SpotPrices: spotPrices}, nil
+}
+
+// ArithmeticTwapForRoute returns the arithmetic TWAP over the route from start_time until end_time, end_time
+// defaulting to the block time. The TWAP is returned along with the spot price error of a hop, as for ArithmeticTwap.
+func (q Querier) ArithmeticTwapForRoute(ctx sdk.Context,
+	req queryproto.ArithmeticTwapForRouteRequest,
+) (*queryproto.ArithmeticTwapForRouteResponse, error) {
+	defer measureQuery(time.Now(), "ArithmeticTwapForRoute")
+	endTime := ctx.BlockTime()
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+	route := make([]types.TwapRoutePoolPair, 0, len(req.Route))
+	for _, hop := range req.Route {
+		route = append(route, types.TwapRoutePoolPair{PoolId: hop.PoolId, BaseAsset: hop.BaseAsset, QuoteAsset: hop.QuoteAsset})
+	}
+	result, err := q.K.GetArithmeticTwapForRoute(ctx, route, req.StartTime, endTime)
+	return &queryproto.ArithmeticTwapForRouteResponse{
+		ArithmeticTwap: result.Price,
+		LastErrorTime:  optionalTime(result.LastErrorTime),
+	}, err
+}
+
+// MedianSpotPrice returns the median of the spot prices sampled over [start_time, end_time], end_time defaulting to
+// the block time.
+func (q Querier) MedianSpotPrice(ctx sdk.Context,
+	req queryproto.MedianSpotPriceRequest,
+) (*queryproto.MedianSpotPriceResponse, error) {
+	defer measureQuery(time.Now(), "MedianSpotPrice")
+	endTime := ctx.BlockTime()
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+	median, numSamples, err := q.K.GetMedianSpotPrice(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.MedianSpotPriceResponse{MedianSpotPrice: median, NumSamples: uint64(numSamples)}, nil
+}
+
+func (q Querier) HistoricalSpotPrice(ctx sdk.Context,
+	req queryproto.HistoricalSpotPriceRequest,
+) (*queryproto.HistoricalSpotPriceResponse, error) {
+	defer measureQuery(time.Now(), "HistoricalSpotPrice")
+	spotPrice, recordTime, errorActive, err := q.K.GetHistoricalSpotPrice(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.HistoricalSpotPriceResponse{SpotPrice: spotPrice, RecordTime: recordTime, ErrorActive: errorActive}, nil
+}
+
+// TwapCandles returns the candles of the intervals of [start_time, end_time], end_time defaulting to the block time.
+func (q Querier) TwapCandles(ctx sdk.Context,
+	req queryproto.TwapCandlesRequest,
+) (*queryproto.TwapCandlesResponse, error) {
+	defer measureQuery(time.Now(), "TwapCandles")
+	if (req.EndTime == time.Time{}) {
+		req.EndTime = ctx.BlockTime()
+	}
+	candles, err := q.K.GetTwapCandles(ctx, req.PoolId, req.BaseAsset, req.QuoteAsset, req.StartTime, req.EndTime, req.Interval)
+	if err != nil {
+		return nil, err
+	}
+	return &queryproto.TwapCandlesResponse{Candles: candles}, nil
+}
+
+// PoolHealth returns whether the records of the pool are quarantined, and why.
+func (q Querier) PoolHealth(ctx sdk.Context,
+	req queryproto.PoolHealthRequest,
+) (*queryproto.PoolHealthResponse, error) {
+	defer measureQuery(time.Now(), "PoolHealth")
+	quarantined, found := q.K.GetQuarantinedPool(ctx, req.PoolId)
+	if !found {
+		return &queryproto.PoolHealthResponse{}, nil
+	}
+	return &queryproto.PoolHealthResponse{Quarantined: true, Quarantine: &quarantined}, nil
+}
+
+// TwapsForPair returns the arithmetic TWAPs over [now - window, now] of every pool with records for the pair
+// of base_asset and quote_asset, sorted by pool id.
+// An error computing the TWAP of a pool is returned in its entry rather than failing the query.
+func (q Querier) TwapsForPair(ctx sdk.Context,
+	req queryproto.TwapsForPairRequest,
+) (*queryproto.TwapsForPairResponse, error) {
+	defer measureQuery(time.Now(), "TwapsForPair")
+	if req.Window < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "window must not be negative, was %s", req.Window)
+	}
+	if _, _, err := types.LexicographicalOrderDenoms(req.BaseAsset, req.QuoteAsset); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	poolIds, err := q.K.GetPoolIdsForDenomPair(ctx, req.BaseAsset, req.QuoteAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := ctx.BlockTime().Add(-req.Window)
+	twaps := make([]queryproto.PoolTwap, 0, len(poolIds))
+	for _, poolId := range poolIds {
+		poolTwap := queryproto.PoolTwap{PoolId: poolId, ArithmeticTwap: sdk.ZeroDec()}
+		twap, err := q.K.GetArithmeticTwapToNow(ctx, poolId, req.BaseAsset, req.QuoteAsset, startTime)
+		if err != nil {
+			poolTwap.Error = err.Error()
+		}
+		if !twap.IsNil() {
+			poolTwap.ArithmeticTwap = twap
+		}
+		twaps = append(twaps, poolTwap)
+	}
+	return &queryproto.TwapsForPairResponse{Twaps: twaps}, nil
+}
+
+const (
+	// DefaultStreamBatchSize is the number of records per message of StreamTwapRecords when the request does not set one.
+	DefaultStreamBatchSize = 100
+	// MaxStreamBatchSize is the largest number of records per message of StreamTwapRecords.
+	MaxStreamBatchSize = 10_000
+	// DefaultStreamMaxBatchBytes bounds the encoded size of the records of a StreamTwapRecords message when the request
+	// does not set a bound. It is well below the default 4 MiB message size limit of gRPC clients.
+	DefaultStreamMaxBatchBytes = 1 << 20
+)
+
+// StreamTwapRecords passes the historical records of the requested time range to send, in time order, in batches of
+// at most req.BatchSize records and req.MaxBatchBytes encoded bytes.
+// Records are read from the store as the batches are sent, and the stream stops with a codes.Canceled error
+// once ctx.Context() is done, or with a codes.ResourceExhausted error once ctx's gas limit is reached.
+func (q Querier) StreamTwapRecords(ctx sdk.Context,
+	req queryproto.StreamTwapRecordsRequest,
+	send func(*queryproto.StreamTwapRecordsResponse) error,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			// the batches sent so far are complete, the client can resume after the last record it received.
+			err = status.Errorf(codes.ResourceExhausted,
+				"out of gas in location: %s, the stream is limited to %d gas, resume it from the last received record",
+				outOfGas.Descriptor, ctx.GasMeter().Limit())
+		}
+	}()
+
+	if req.EndTime != nil && !req.StartTime.Before(*req.EndTime) {
+		return status.Errorf(codes.InvalidArgument, "start_time (%s) must be before end_time (%s)", req.StartTime, *req.EndTime)
+	}
+	batchSize := int(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = DefaultStreamBatchSize
+	}
+	if batchSize > MaxStreamBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch_size must be at most %d, was %d", MaxStreamBatchSize, batchSize)
+	}
+	maxBatchBytes := req.MaxBatchBytes
+	if maxBatchBytes == 0 {
+		maxBatchBytes = DefaultStreamMaxBatchBytes
+	}
+
+	batch := make([]types.TwapRecord, 0, batchSize)
+	batchBytes := uint64(0)
+	var streamErr error
+	flush := func() {
+		streamErr = send(&queryproto.StreamTwapRecordsResponse{Records: batch})
+		batch = make([]types.TwapRecord, 0, batchSize)
+		batchBytes = 0
+	}
+
+	err = q.K.IterateHistoricalRecords(ctx, req.PoolId, req.StartTime, req.EndTime, func(record types.TwapRecord) bool {
+		if err := ctx.Context().Err(); err != nil {
+			streamErr = status.Error(codes.Canceled, err.Error())
+			return true
+		}
+		recordBytes := uint64(record.Size())
+		if len(batch) == batchSize || (len(batch) > 0 && batchBytes+recordBytes > maxBatchBytes) {
+			flush()
+			if streamErr != nil {
+				return true
+			}
+		}
+		batch = append(batch, record)
+		batchBytes += recordBytes
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	if streamErr == nil && len(batch) > 0 {
+		flush()
+	}
+	return streamErr
+}
@@ -1,17 +1,31 @@
 package client_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/osmosis-labs/osmosis/v13/app/apptesting"
 	"github.com/osmosis-labs/osmosis/v13/x/gamm/types"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	twapgrpc "github.com/osmosis-labs/osmosis/v13/x/twap/client/grpc"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	twaptypes "github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 type QueryTestSuite struct {
@@ -190,3 +204,1288 @@ func (suite *QueryTestSuite) TestQueryTwap() {
 		})
 	}
 }
+
+func (suite *QueryTestSuite) TestQueryGeometricTwap() {
+	suite.SetupTest()
+	// the spot price of tokenA, the pool's asset 0, is 2, as twapPow only converges for non-negative exponents
+	poolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 2000), sdk.NewInt64Coin("tokenB", 1000))
+	validStartTime := suite.Ctx.BlockTime()
+	newBlockTime := validStartTime.Add(time.Hour)
+	startTimeTooOld := validStartTime.Add(-time.Hour)
+	suite.Ctx = suite.Ctx.WithBlockTime(newBlockTime)
+	queryClient := suite.grpcQueryClient()
+
+	testCases := map[string]struct {
+		poolId          uint64
+		baseAssetDenom  string
+		quoteAssetDenom string
+		startTime       time.Time
+		endTime         time.Time
+		expectErr       bool
+		result          sdk.Dec
+	}{
+		"tokenA in terms of tokenB": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: validStartTime, endTime: newBlockTime,
+			result: sdk.NewDecWithPrec(5, 1),
+		},
+		"tokenB in terms of tokenA": {
+			poolId: poolID, baseAssetDenom: "tokenB", quoteAssetDenom: "tokenA",
+			startTime: validStartTime, endTime: newBlockTime,
+			result: sdk.NewDec(2),
+		},
+		"past end time": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: validStartTime, endTime: validStartTime.Add(time.Minute),
+			result: sdk.NewDecWithPrec(5, 1),
+		},
+		"no end time": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: validStartTime,
+			result:    sdk.NewDecWithPrec(5, 1),
+		},
+		"start time too old": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: startTimeTooOld, endTime: newBlockTime,
+			expectErr: true,
+		},
+		"end time in the future": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: validStartTime, endTime: newBlockTime.Add(time.Minute),
+			expectErr: true,
+		},
+		"quote asset not in pool": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenC",
+			startTime: validStartTime, endTime: newBlockTime,
+			expectErr: true,
+		},
+		"same base and quote asset": {
+			poolId: poolID, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenA",
+			startTime: validStartTime, endTime: newBlockTime,
+			expectErr: true,
+		},
+		"non-existent pool": {
+			poolId: poolID + 1, baseAssetDenom: "tokenA", quoteAssetDenom: "tokenB",
+			startTime: validStartTime, endTime: newBlockTime,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		suite.Run(name, func() {
+			res, err := queryClient.GeometricTwap(context.Background(), &queryproto.GeometricTwapRequest{
+				PoolId:     tc.poolId,
+				BaseAsset:  tc.baseAssetDenom,
+				QuoteAsset: tc.quoteAssetDenom,
+				StartTime:  tc.startTime,
+				EndTime:    tc.endTime,
+			})
+
+			// a window ending at the block time is also a TWAP to now, which gives the same result or error
+			if tc.endTime.IsZero() || tc.endTime.Equal(newBlockTime) {
+				resToNow, errToNow := queryClient.GeometricTwapToNow(context.Background(), &queryproto.GeometricTwapToNowRequest{
+					PoolId:     tc.poolId,
+					BaseAsset:  tc.baseAssetDenom,
+					QuoteAsset: tc.quoteAssetDenom,
+					StartTime:  tc.startTime,
+				})
+				if tc.expectErr {
+					suite.Require().Error(errToNow)
+					suite.Require().Equal(status.Convert(err).Message(), status.Convert(errToNow).Message())
+				} else {
+					suite.Require().NoError(errToNow)
+					suite.Require().Equal(tc.result.String(), resToNow.GeometricTwap.String())
+					suite.Require().True(newBlockTime.Equal(resToNow.EndTime), "expected end time %s, got %s", newBlockTime, resToNow.EndTime)
+				}
+			}
+
+			if !tc.expectErr {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.result.String(), res.GeometricTwap.String())
+				return
+			}
+			suite.Require().Error(err)
+
+			// the window is validated as the arithmetic query validates it, with the same errors
+			endTime := tc.endTime
+			_, arithmeticErr := queryClient.ArithmeticTwap(context.Background(), &queryproto.ArithmeticTwapRequest{
+				PoolId:     tc.poolId,
+				BaseAsset:  tc.baseAssetDenom,
+				QuoteAsset: tc.quoteAssetDenom,
+				StartTime:  tc.startTime,
+				EndTime:    &endTime,
+			})
+			suite.Require().Equal(status.Convert(arithmeticErr).Message(), status.Convert(err).Message())
+			suite.Require().Equal(status.Code(arithmeticErr), status.Code(err))
+		})
+	}
+}
+
+func (suite *QueryTestSuite) TestQueryTwapWindowDuration() {
+	suite.SetupTest()
+
+	var (
+		coins = sdk.NewCoins(
+			sdk.NewInt64Coin("tokenA", 1000),
+			sdk.NewInt64Coin("tokenB", 2000),
+		)
+		poolID     = suite.PrepareBalancerPoolWithCoins(coins...)
+		poolTime   = suite.Ctx.BlockTime()
+		keepPeriod = suite.App.TwapKeeper.GetParams(suite.Ctx).RecordHistoryKeepPeriod
+
+		// Set current block time to the end of the keep period of the pool creation.
+		ctx = suite.Ctx.WithBlockTime(poolTime.Add(keepPeriod))
+
+		durationOf = func(d time.Duration) *time.Duration { return &d }
+	)
+
+	testCases := []struct {
+		name              string
+		startTime         time.Time
+		windowDuration    *time.Duration
+		clampToKeepPeriod bool
+		// endTime is only set on the ArithmeticTwap request
+		endTime time.Time
+
+		expectErr bool
+		// expectInvalidArgument is set for the requests rejected before the TWAP is computed
+		expectInvalidArgument bool
+		expectedStartTime     time.Time
+	}{
+		{
+			name:              "start time",
+			startTime:         poolTime.Add(time.Hour),
+			expectedStartTime: poolTime.Add(time.Hour),
+		},
+		{
+			name:              "window duration",
+			windowDuration:    durationOf(time.Hour),
+			expectedStartTime: ctx.BlockTime().Add(-time.Hour),
+		},
+		{
+			name:              "window duration exactly equal to the keep period",
+			windowDuration:    durationOf(keepPeriod),
+			expectedStartTime: poolTime,
+		},
+		{
+			name:           "window duration slightly over the keep period",
+			windowDuration: durationOf(keepPeriod + time.Second),
+			expectErr:      true,
+		},
+		{
+			name:              "window duration slightly over the keep period, clamped",
+			windowDuration:    durationOf(keepPeriod + time.Second),
+			clampToKeepPeriod: true,
+			expectedStartTime: poolTime,
+		},
+		{
+			name:              "start time before the keep period, clamped",
+			startTime:         poolTime.Add(-time.Hour),
+			clampToKeepPeriod: true,
+			expectedStartTime: poolTime,
+		},
+		{
+			name:              "window duration within the keep period, clamp has no effect",
+			windowDuration:    durationOf(time.Hour),
+			clampToKeepPeriod: true,
+			expectedStartTime: ctx.BlockTime().Add(-time.Hour),
+		},
+		{
+			name:              "window duration ending at a past end time",
+			windowDuration:    durationOf(time.Hour),
+			endTime:           ctx.BlockTime().Add(-time.Hour),
+			expectedStartTime: ctx.BlockTime().Add(-2 * time.Hour),
+		},
+		{
+			name:                  "both start time and window duration",
+			startTime:             poolTime.Add(time.Hour),
+			windowDuration:        durationOf(time.Hour),
+			expectErr:             true,
+			expectInvalidArgument: true,
+		},
+		{
+			name:                  "zero window duration",
+			windowDuration:        durationOf(0),
+			expectErr:             true,
+			expectInvalidArgument: true,
+		},
+		{
+			name:                  "negative window duration",
+			windowDuration:        durationOf(-time.Hour),
+			expectErr:             true,
+			expectInvalidArgument: true,
+		},
+		{
+			name:                  "start time after the block time",
+			startTime:             ctx.BlockTime().Add(time.Hour),
+			expectErr:             true,
+			expectInvalidArgument: true,
+		},
+		{
+			name:                  "start time after a past end time",
+			startTime:             ctx.BlockTime().Add(-time.Hour),
+			endTime:               ctx.BlockTime().Add(-2 * time.Hour),
+			expectErr:             true,
+			expectInvalidArgument: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		suite.Run(tc.name, func() {
+			client := client.Querier{K: *suite.App.TwapKeeper}
+
+			result, err := client.ArithmeticTwap(ctx, queryproto.ArithmeticTwapRequest{
+				PoolId:            poolID,
+				BaseAsset:         "tokenA",
+				QuoteAsset:        "tokenB",
+				StartTime:         tc.startTime,
+				EndTime:           &tc.endTime,
+				WindowDuration:    tc.windowDuration,
+				ClampToKeepPeriod: tc.clampToKeepPeriod,
+			})
+
+			if tc.expectErr {
+				suite.Require().Error(err, "expected error - ArithmeticTwap")
+				if tc.expectInvalidArgument {
+					suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+				}
+			} else {
+				suite.Require().NoError(err, "unexpected error - ArithmeticTwap")
+				suite.Require().Equal(sdk.NewDec(2).String(), result.ArithmeticTwap.String())
+				suite.Require().Equal(tc.expectedStartTime, result.StartTime)
+			}
+			if (tc.endTime != time.Time{}) {
+				return
+			}
+
+			resultToNow, err := client.ArithmeticTwapToNow(ctx, queryproto.ArithmeticTwapToNowRequest{
+				PoolId:            poolID,
+				BaseAsset:         "tokenA",
+				QuoteAsset:        "tokenB",
+				StartTime:         tc.startTime,
+				WindowDuration:    tc.windowDuration,
+				ClampToKeepPeriod: tc.clampToKeepPeriod,
+			})
+
+			if tc.expectErr {
+				suite.Require().Error(err, "expected error - ArithmeticTwapToNow")
+				if tc.expectInvalidArgument {
+					suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+				}
+			} else {
+				suite.Require().NoError(err, "unexpected error - ArithmeticTwapToNow")
+				suite.Require().Equal(sdk.NewDec(2).String(), resultToNow.ArithmeticTwap.String())
+				suite.Require().Equal(tc.expectedStartTime, resultToNow.StartTime)
+			}
+		})
+	}
+}
+
+// The twap_type of the ArithmeticTwap and ArithmeticTwapToNow requests defaults to the arithmetic TWAP, and selects the
+// harmonic TWAP, which is the same for a pool whose spot price didn't change.
+func (suite *QueryTestSuite) TestQueryTwapType() {
+	suite.SetupTest()
+	poolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	startTime := suite.Ctx.BlockTime()
+	ctx := suite.Ctx.WithBlockTime(startTime.Add(time.Hour))
+	querier := client.Querier{K: *suite.App.TwapKeeper}
+
+	testCases := []struct {
+		name      string
+		twapType  queryproto.TwapType
+		allowGaps bool
+
+		expectInvalidArgument bool
+	}{
+		{name: "arithmetic", twapType: queryproto.ArithmeticTwapType},
+		{name: "harmonic", twapType: queryproto.HarmonicTwapType},
+		{name: "arithmetic, allowing gaps", twapType: queryproto.ArithmeticTwapType, allowGaps: true},
+		{name: "harmonic, allowing gaps", twapType: queryproto.HarmonicTwapType, allowGaps: true, expectInvalidArgument: true},
+		{name: "unknown type", twapType: queryproto.TwapType(2), expectInvalidArgument: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		suite.Run(tc.name, func() {
+			endTime := time.Time{}
+			res, err := querier.ArithmeticTwap(ctx, queryproto.ArithmeticTwapRequest{
+				PoolId:     poolID,
+				BaseAsset:  "tokenA",
+				QuoteAsset: "tokenB",
+				StartTime:  startTime,
+				EndTime:    &endTime,
+				AllowGaps:  tc.allowGaps,
+				TwapType:   tc.twapType,
+			})
+			if tc.expectInvalidArgument {
+				suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+			} else {
+				suite.Require().NoError(err)
+				suite.Require().Equal(sdk.NewDec(2).String(), res.ArithmeticTwap.String())
+			}
+			if tc.allowGaps {
+				return
+			}
+
+			resToNow, err := querier.ArithmeticTwapToNow(ctx, queryproto.ArithmeticTwapToNowRequest{
+				PoolId:     poolID,
+				BaseAsset:  "tokenA",
+				QuoteAsset: "tokenB",
+				StartTime:  startTime,
+				TwapType:   tc.twapType,
+			})
+			if tc.expectInvalidArgument {
+				suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+			} else {
+				suite.Require().NoError(err)
+				suite.Require().Equal(sdk.NewDec(2).String(), resToNow.ArithmeticTwap.String())
+			}
+		})
+	}
+}
+
+func (suite *QueryTestSuite) TestQueryTwapChange() {
+	suite.SetupTest()
+
+	var (
+		poolId   = uint64(1000)
+		baseTime = suite.Ctx.BlockTime()
+		day      = 24 * time.Hour
+		// The price of tokenA in tokenB is 2 for a day, then 3 for a day.
+		// P1 is the price of asset0 (tokenA) quoted in asset1 (tokenB).
+		record = func(t time.Time, p1SpotPrice sdk.Dec, p1Accum sdk.Dec) twaptypes.TwapRecord {
+			return twaptypes.TwapRecord{
+				PoolId:                      poolId,
+				Asset0Denom:                 "tokenA",
+				Asset1Denom:                 "tokenB",
+				Height:                      1,
+				Time:                        t,
+				P0LastSpotPrice:             sdk.OneDec().Quo(p1SpotPrice),
+				P1LastSpotPrice:             p1SpotPrice,
+				P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				P1ArithmeticTwapAccumulator: p1Accum,
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+			}
+		}
+		records = []twaptypes.TwapRecord{
+			record(baseTime, sdk.NewDec(2), sdk.ZeroDec()),
+			record(baseTime.Add(day), sdk.NewDec(3), twaptypes.SpotPriceMulDuration(sdk.NewDec(2), day)),
+		}
+
+		ctx = suite.Ctx.WithBlockTime(baseTime.Add(2 * day))
+	)
+
+	genesis := twaptypes.DefaultGenesis()
+	genesis.Twaps = records
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+
+	testCases := []struct {
+		name   string
+		window time.Duration
+		offset time.Duration
+
+		expectErr      bool
+		expectCurrent  sdk.Dec
+		expectPrevious sdk.Dec
+		// empty if the window is expected to error
+		expectChange         string
+		expectPreviousErrMsg string
+	}{
+		{
+			name:           "+50% over a day",
+			window:         day,
+			offset:         day,
+			expectCurrent:  sdk.NewDec(3),
+			expectPrevious: sdk.NewDec(2),
+			expectChange:   sdk.NewDecWithPrec(5, 1).String(),
+		},
+		{
+			name:           "overlapping windows",
+			window:         day,
+			offset:         12 * time.Hour,
+			expectCurrent:  sdk.NewDec(3),
+			expectPrevious: sdk.NewDecWithPrec(25, 1),
+			expectChange:   sdk.NewDecWithPrec(2, 1).String(),
+		},
+		{
+			name:                 "previous window starts before the first record",
+			window:               day,
+			offset:               day + time.Second,
+			expectCurrent:        sdk.NewDec(3),
+			expectPrevious:       sdk.ZeroDec(),
+			expectPreviousErrMsg: "too old",
+		},
+		{
+			name:      "zero window",
+			window:    0,
+			offset:    day,
+			expectErr: true,
+		},
+		{
+			name:      "zero offset",
+			window:    day,
+			offset:    0,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		suite.Run(tc.name, func() {
+			client := client.Querier{K: *suite.App.TwapKeeper}
+
+			result, err := client.TwapChange(ctx, queryproto.TwapChangeRequest{
+				PoolId:     poolId,
+				BaseAsset:  "tokenA",
+				QuoteAsset: "tokenB",
+				Window:     tc.window,
+				Offset:     tc.offset,
+			})
+			if tc.expectErr {
+				suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+				return
+			}
+			suite.Require().NoError(err)
+
+			// both windows have the same duration, the previous one ending offset before the block time
+			suite.Require().Equal(ctx.BlockTime().Add(-tc.window), result.Current.StartTime)
+			suite.Require().Equal(ctx.BlockTime(), result.Current.EndTime)
+			suite.Require().Equal(ctx.BlockTime().Add(-tc.offset-tc.window), result.Previous.StartTime)
+			suite.Require().Equal(ctx.BlockTime().Add(-tc.offset), result.Previous.EndTime)
+
+			suite.Require().Empty(result.Current.Error)
+			suite.Require().Equal(tc.expectCurrent.String(), result.Current.ArithmeticTwap.String())
+			suite.Require().Equal(tc.expectPrevious.String(), result.Previous.ArithmeticTwap.String())
+
+			if tc.expectPreviousErrMsg != "" {
+				suite.Require().Contains(result.Previous.Error, tc.expectPreviousErrMsg)
+				suite.Require().Nil(result.Change)
+				return
+			}
+			suite.Require().Empty(result.Previous.Error)
+			suite.Require().NotNil(result.Change)
+			suite.Require().Equal(tc.expectChange, result.Change.String())
+		})
+	}
+}
+
+func (suite *QueryTestSuite) TestQueryTwapsForPair() {
+	suite.SetupTest()
+
+	var (
+		baseTime = suite.Ctx.BlockTime()
+		day      = 24 * time.Hour
+		// P1 is the price of asset0 (tokenA) quoted in asset1 (tokenB).
+		record = func(poolId uint64, t time.Time, asset1 string, p1SpotPrice sdk.Dec) twaptypes.TwapRecord {
+			return twaptypes.TwapRecord{
+				PoolId:                      poolId,
+				Asset0Denom:                 "tokenA",
+				Asset1Denom:                 asset1,
+				Height:                      1,
+				Time:                        t,
+				P0LastSpotPrice:             sdk.OneDec().Quo(p1SpotPrice),
+				P1LastSpotPrice:             p1SpotPrice,
+				P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+			}
+		}
+		// Three pools trade tokenA for tokenB, the last one only since a day ago,
+		// and another pool trades tokenA for tokenC.
+		records = []twaptypes.TwapRecord{
+			record(1000, baseTime, "tokenB", sdk.NewDec(2)),
+			record(1001, baseTime, "tokenB", sdk.NewDec(4)),
+			record(1002, baseTime.Add(day), "tokenB", sdk.NewDec(8)),
+			record(1003, baseTime, "tokenC", sdk.NewDec(3)),
+		}
+
+		ctx = suite.Ctx.WithBlockTime(baseTime.Add(2 * day))
+	)
+
+	genesis := twaptypes.DefaultGenesis()
+	genesis.Twaps = records
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+
+	poolTwap := func(poolId uint64, twap sdk.Dec) queryproto.PoolTwap {
+		return queryproto.PoolTwap{PoolId: poolId, ArithmeticTwap: twap}
+	}
+
+	testCases := []struct {
+		name       string
+		baseAsset  string
+		quoteAsset string
+		window     time.Duration
+
+		expectErr bool
+		// the error of a pool is only checked to contain expectErrMsgs[pool id]
+		expectTwaps   []queryproto.PoolTwap
+		expectErrMsgs map[uint64]string
+	}{
+		{
+			name:        "every pool of the pair",
+			baseAsset:   "tokenA",
+			quoteAsset:  "tokenB",
+			window:      day,
+			expectTwaps: []queryproto.PoolTwap{poolTwap(1000, sdk.NewDec(2)), poolTwap(1001, sdk.NewDec(4)), poolTwap(1002, sdk.NewDec(8))},
+		},
+		{
+			name:          "a pool erroring doesn't fail the others",
+			baseAsset:     "tokenA",
+			quoteAsset:    "tokenB",
+			window:        2 * day,
+			expectTwaps:   []queryproto.PoolTwap{poolTwap(1000, sdk.NewDec(2)), poolTwap(1001, sdk.NewDec(4)), poolTwap(1002, sdk.ZeroDec())},
+			expectErrMsgs: map[uint64]string{1002: "too old"},
+		},
+		{
+			name:        "pair quoted the other way",
+			baseAsset:   "tokenB",
+			quoteAsset:  "tokenA",
+			window:      day,
+			expectTwaps: []queryproto.PoolTwap{poolTwap(1000, sdk.NewDecWithPrec(5, 1)), poolTwap(1001, sdk.NewDecWithPrec(25, 2)), poolTwap(1002, sdk.NewDecWithPrec(125, 3))},
+		},
+		{
+			name:        "zero window",
+			baseAsset:   "tokenA",
+			quoteAsset:  "tokenC",
+			window:      0,
+			expectTwaps: []queryproto.PoolTwap{poolTwap(1003, sdk.NewDec(3))},
+		},
+		{
+			name:        "no pool has the pair",
+			baseAsset:   "tokenB",
+			quoteAsset:  "tokenC",
+			window:      day,
+			expectTwaps: []queryproto.PoolTwap{},
+		},
+		{
+			name:       "same denoms",
+			baseAsset:  "tokenA",
+			quoteAsset: "tokenA",
+			window:     day,
+			expectErr:  true,
+		},
+		{
+			name:       "negative window",
+			baseAsset:  "tokenA",
+			quoteAsset: "tokenB",
+			window:     -day,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		suite.Run(tc.name, func() {
+			client := client.Querier{K: *suite.App.TwapKeeper}
+
+			result, err := client.TwapsForPair(ctx, queryproto.TwapsForPairRequest{
+				BaseAsset:  tc.baseAsset,
+				QuoteAsset: tc.quoteAsset,
+				Window:     tc.window,
+			})
+			if tc.expectErr {
+				suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+				return
+			}
+			suite.Require().NoError(err)
+
+			suite.Require().Len(result.Twaps, len(tc.expectTwaps))
+			for i, twap := range result.Twaps {
+				expectErrMsg := tc.expectErrMsgs[twap.PoolId]
+				if expectErrMsg != "" {
+					suite.Require().Contains(twap.Error, expectErrMsg)
+					twap.Error = ""
+				}
+				suite.Require().Equal(tc.expectTwaps[i].PoolId, twap.PoolId)
+				suite.Require().Equal(tc.expectTwaps[i].ArithmeticTwap.String(), twap.ArithmeticTwap.String())
+				suite.Require().Empty(twap.Error)
+			}
+		})
+	}
+}
+
+// streamRecords stores records for pools 1000 and 1001 every minute over the half hour after the block time,
+// and returns the records of each pool in time order.
+func (suite *QueryTestSuite) TestQueryArchived() {
+	suite.SetupTest()
+
+	var (
+		baseTime = suite.Ctx.BlockTime()
+		record   = func(t time.Time, p0SpotPrice sdk.Dec, p0Accumulator sdk.Dec) twaptypes.TwapRecord {
+			return twaptypes.TwapRecord{
+				PoolId:                      1000,
+				Asset0Denom:                 "tokenA",
+				Asset1Denom:                 "tokenB",
+				Height:                      1,
+				Time:                        t,
+				P0LastSpotPrice:             p0SpotPrice,
+				P1LastSpotPrice:             sdk.OneDec().Quo(p0SpotPrice),
+				P0ArithmeticTwapAccumulator: p0Accumulator,
+				P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+			}
+		}
+		// tokenB is worth 2 tokenA for an hour, then 4 tokenA
+		records = []twaptypes.TwapRecord{
+			record(baseTime, sdk.NewDec(2), sdk.ZeroDec()),
+			record(baseTime.Add(time.Hour), sdk.NewDec(4), sdk.NewDec(7_200_000)),
+		}
+		recordsReq = &queryproto.ArchivedTwapRecordsRequest{PoolId: 1000, BaseAsset: "tokenB", QuoteAsset: "tokenA", StartTime: baseTime}
+		twapReq    = &queryproto.ArchivedArithmeticTwapRequest{PoolId: 1000, BaseAsset: "tokenB", QuoteAsset: "tokenA", StartTime: baseTime}
+	)
+
+	// the archive is disabled by default
+	queryClient := suite.grpcQueryClient()
+	_, err := queryClient.ArchivedTwapRecords(context.Background(), recordsReq)
+	suite.Require().Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = queryClient.ArchivedArithmeticTwap(context.Background(), twapReq)
+	suite.Require().Equal(codes.FailedPrecondition, status.Code(err))
+
+	suite.App.TwapKeeper.SetArchive(dbm.NewMemDB(), suite.App.GetTKey(twaptypes.ArchiveTransientStoreKey))
+	genesis := twaptypes.DefaultGenesis()
+	genesis.Twaps = records
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+	suite.Ctx = suite.Ctx.WithBlockTime(baseTime.Add(2 * time.Hour))
+	queryClient = suite.grpcQueryClient()
+
+	// the end time defaults to the block time
+	recordsRes, err := queryClient.ArchivedTwapRecords(context.Background(), recordsReq)
+	suite.Require().NoError(err)
+	suite.Require().Len(recordsRes.Records, 2)
+	suite.Require().Nil(recordsRes.NextTime)
+
+	limitedReq := *recordsReq
+	limitedReq.Limit = 1
+	recordsRes, err = queryClient.ArchivedTwapRecords(context.Background(), &limitedReq)
+	suite.Require().NoError(err)
+	suite.Require().Len(recordsRes.Records, 1)
+	suite.Require().NotNil(recordsRes.NextTime)
+	suite.Require().True(records[1].Time.Equal(*recordsRes.NextTime))
+
+	twapRes, err := queryClient.ArchivedArithmeticTwap(context.Background(), twapReq)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(3).String(), twapRes.ArithmeticTwap.String())
+}
+
+// The TWAP queries return the records they computed the TWAP from, and the last time the spot price errored.
+func (suite *QueryTestSuite) TestQueryTwapRecordMetadata() {
+	suite.SetupTest()
+
+	var (
+		baseTime  = suite.Ctx.BlockTime()
+		errorTime = baseTime.Add(-time.Minute)
+		record    = func(t time.Time, p0SpotPrice sdk.Dec, p0Accumulator sdk.Dec, lastErrorTime time.Time) twaptypes.TwapRecord {
+			return twaptypes.TwapRecord{
+				PoolId:                      1000,
+				Asset0Denom:                 "tokenA",
+				Asset1Denom:                 "tokenB",
+				Height:                      1,
+				Time:                        t,
+				P0LastSpotPrice:             p0SpotPrice,
+				P1LastSpotPrice:             sdk.OneDec().Quo(p0SpotPrice),
+				P0ArithmeticTwapAccumulator: p0Accumulator,
+				P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+				LastErrorTime:               lastErrorTime,
+			}
+		}
+		// tokenB is worth 2 tokenA for an hour, then 4 tokenA, and the spot price errored a minute before the first
+		// record, whose spot prices would be zero had it errored in its own block
+		records = []twaptypes.TwapRecord{
+			record(baseTime, sdk.NewDec(2), sdk.ZeroDec(), errorTime),
+			record(baseTime.Add(time.Hour), sdk.NewDec(4), sdk.NewDec(7_200_000), errorTime),
+		}
+		startTime = baseTime.Add(70 * time.Minute)
+		endTime   = baseTime.Add(100 * time.Minute)
+	)
+
+	genesis := twaptypes.DefaultGenesis()
+	genesis.Twaps = records
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+	suite.Ctx = suite.Ctx.WithBlockTime(baseTime.Add(2 * time.Hour))
+	queryClient := suite.grpcQueryClient()
+
+	res, err := queryClient.ArithmeticTwap(context.Background(), &queryproto.ArithmeticTwapRequest{
+		PoolId: 1000, BaseAsset: "tokenB", QuoteAsset: "tokenA", StartTime: startTime, EndTime: &endTime,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(4).String(), res.ArithmeticTwap.String())
+	suite.Require().True(res.StartInterpolated)
+	suite.Require().True(res.StartRecordTime.Equal(records[1].Time))
+	suite.Require().True(res.EndRecordTime.Equal(records[1].Time))
+	// the faulty spot price was replaced before the window
+	suite.Require().NotNil(res.LastErrorTime)
+	suite.Require().True(res.LastErrorTime.Equal(errorTime))
+
+	toNowRes, err := queryClient.ArithmeticTwapToNow(context.Background(), &queryproto.ArithmeticTwapToNowRequest{
+		PoolId: 1000, BaseAsset: "tokenB", QuoteAsset: "tokenA", StartTime: records[1].Time,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(4).String(), toNowRes.ArithmeticTwap.String())
+	suite.Require().False(toNowRes.StartInterpolated)
+	suite.Require().True(toNowRes.StartRecordTime.Equal(records[1].Time))
+	suite.Require().True(toNowRes.EndRecordTime.Equal(records[1].Time))
+	suite.Require().True(toNowRes.LastErrorTime.Equal(errorTime))
+
+	// a pool whose spot price never errored has no last error time
+	suite.SetupTest()
+	poolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	createTime := suite.Ctx.BlockTime()
+	suite.Ctx = suite.Ctx.WithBlockTime(createTime.Add(time.Hour))
+	queryClient = suite.grpcQueryClient()
+	toNowRes, err = queryClient.ArithmeticTwapToNow(context.Background(), &queryproto.ArithmeticTwapToNowRequest{
+		PoolId: poolID, BaseAsset: "tokenA", QuoteAsset: "tokenB", StartTime: createTime,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Nil(toNowRes.LastErrorTime)
+}
+
+func (suite *QueryTestSuite) TestQueryMostRecentRecords() {
+	suite.SetupTest()
+	createTime := suite.Ctx.BlockTime()
+	// the spot prices of asset 0 of every pair are above 1, so that the geometric accumulators of the records are
+	// positive, as genesis requires
+	poolID := suite.PrepareBalancerPoolWithCoins(
+		sdk.NewInt64Coin("tokenA", 3000),
+		sdk.NewInt64Coin("tokenB", 2000),
+		sdk.NewInt64Coin("tokenC", 1000),
+	)
+	queryClient := suite.grpcQueryClient()
+	req := &queryproto.MostRecentRecordsRequest{PoolId: poolID}
+
+	// requireRecords checks that the records are those of the three pairs of the pool, written at recordTime, with
+	// the last error times of lastErrorTimes, and positive spot prices unless they errored at recordTime
+	requireRecords := func(records []twaptypes.TwapRecord, recordTime time.Time, lastErrorTimes [3]time.Time) {
+		suite.Require().Len(records, 3)
+		pairs := [3][2]string{{"tokenA", "tokenB"}, {"tokenA", "tokenC"}, {"tokenB", "tokenC"}}
+		for i, record := range records {
+			suite.Require().Equal(poolID, record.PoolId)
+			suite.Require().Equal(pairs[i], [2]string{record.Asset0Denom, record.Asset1Denom})
+			suite.Require().True(recordTime.Equal(record.Time), "record %d time %s", i, record.Time)
+			suite.Require().True(lastErrorTimes[i].Equal(record.LastErrorTime), "record %d last error time %s", i, record.LastErrorTime)
+			if lastErrorTimes[i].Equal(recordTime) {
+				continue
+			}
+			suite.Require().True(record.P0LastSpotPrice.IsPositive())
+			suite.Require().True(record.P1LastSpotPrice.IsPositive())
+		}
+	}
+
+	// the records of the pool's creation
+	res, err := queryClient.MostRecentRecords(context.Background(), req)
+	suite.Require().NoError(err)
+	requireRecords(res.Records, createTime, [3]time.Time{})
+	for _, record := range res.Records {
+		suite.Require().True(record.P0ArithmeticTwapAccumulator.IsZero())
+	}
+
+	// a swap in a later block updates the records of every pair at the end of the block
+	swapTime := createTime.Add(time.Hour)
+	suite.Ctx = suite.Ctx.WithBlockTime(swapTime)
+	suite.RunBasicSwap(poolID)
+	suite.EndBlock()
+	res, err = queryClient.MostRecentRecords(context.Background(), req)
+	suite.Require().NoError(err)
+	requireRecords(res.Records, swapTime, [3]time.Time{})
+	for _, record := range res.Records {
+		suite.Require().True(record.P0ArithmeticTwapAccumulator.IsPositive())
+	}
+
+	// the last error time of a record tells that its pair's spot price errored, in which case its spot prices are zero
+	erroredRecord := res.Records[2]
+	erroredRecord.LastErrorTime = swapTime
+	erroredRecord.P0LastSpotPrice, erroredRecord.P1LastSpotPrice = sdk.ZeroDec(), sdk.ZeroDec()
+	genesis := twaptypes.DefaultGenesis()
+	genesis.Twaps = []twaptypes.TwapRecord{erroredRecord}
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+	res, err = queryClient.MostRecentRecords(context.Background(), req)
+	suite.Require().NoError(err)
+	requireRecords(res.Records, swapTime, [3]time.Time{{}, {}, swapTime})
+
+	// a pool without records has none
+	res, err = queryClient.MostRecentRecords(context.Background(), &queryproto.MostRecentRecordsRequest{PoolId: poolID + 1})
+	suite.Require().NoError(err)
+	suite.Require().Empty(res.Records)
+
+	_, err = queryClient.MostRecentRecords(context.Background(), &queryproto.MostRecentRecordsRequest{})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *QueryTestSuite) TestQueryPoolTwapPairs() {
+	suite.SetupTest()
+	poolID := suite.PrepareBalancerPoolWithCoins(
+		sdk.NewInt64Coin("tokenA", 1000),
+		sdk.NewInt64Coin("tokenB", 2000),
+		sdk.NewInt64Coin("tokenC", 3000),
+	)
+	otherPoolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenD", 2000))
+	queryClient := suite.grpcQueryClient()
+
+	res, err := queryClient.PoolTwapPairs(context.Background(), &queryproto.PoolTwapPairsRequest{PoolId: poolID})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]queryproto.TwapPair{
+		{Asset0Denom: "tokenA", Asset1Denom: "tokenB"},
+		{Asset0Denom: "tokenA", Asset1Denom: "tokenC"},
+		{Asset0Denom: "tokenB", Asset1Denom: "tokenC"},
+	}, res.Pairs)
+
+	// a TWAP of the pool for the denoms of the other pool errors with the denoms of the pool
+	_, err = queryClient.ArithmeticTwapToNow(context.Background(), &queryproto.ArithmeticTwapToNowRequest{
+		PoolId: poolID, BaseAsset: "tokenA", QuoteAsset: "tokenD", StartTime: suite.Ctx.BlockTime(),
+	})
+	suite.Require().ErrorContains(err, fmt.Sprintf("not in pool id %d, the pool has denoms tokenA, tokenB, tokenC", poolID))
+
+	// a pool without records has no pairs
+	res, err = queryClient.PoolTwapPairs(context.Background(), &queryproto.PoolTwapPairsRequest{PoolId: otherPoolID + 1})
+	suite.Require().NoError(err)
+	suite.Require().Empty(res.Pairs)
+
+	_, err = queryClient.PoolTwapPairs(context.Background(), &queryproto.PoolTwapPairsRequest{})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+// The last update info tells a pool swapped in every block from a pool whose records haven't been updated since its
+// creation.
+func (suite *QueryTestSuite) TestQueryLastUpdateInfo() {
+	suite.SetupTest()
+	createHeight, createTime := suite.Ctx.BlockHeight(), suite.Ctx.BlockTime()
+	activePoolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	stalePoolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenC", 1000), sdk.NewInt64Coin("tokenD", 2000))
+	queryClient := suite.grpcQueryClient()
+
+	// only the active pool is swapped in, for 5 blocks. The pools changed in a block are only cleared on commit.
+	for i := 0; i < 5; i++ {
+		suite.EndBlock()
+		suite.Commit()
+		suite.RunBasicSwap(activePoolID)
+	}
+	suite.EndBlock()
+	activeInfo := queryproto.PairLastUpdateInfo{
+		PoolId: activePoolID, Asset0Denom: "tokenA", Asset1Denom: "tokenB",
+		Height: suite.Ctx.BlockHeight(), Time: suite.Ctx.BlockTime().UTC(),
+	}
+	staleInfo := queryproto.PairLastUpdateInfo{
+		PoolId: stalePoolID, Asset0Denom: "tokenC", Asset1Denom: "tokenD",
+		Height: createHeight, Time: createTime.UTC(), BlocksSinceUpdate: 5, Stale: true,
+	}
+
+	res, err := queryClient.LastUpdateInfo(context.Background(), &queryproto.LastUpdateInfoRequest{
+		PoolId: activePoolID, BaseAsset: "tokenB", QuoteAsset: "tokenA", MaxBlockAge: 3,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(activeInfo, res.Info)
+	res, err = queryClient.LastUpdateInfo(context.Background(), &queryproto.LastUpdateInfoRequest{
+		PoolId: stalePoolID, BaseAsset: "tokenC", QuoteAsset: "tokenD", MaxBlockAge: 3,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(staleInfo, res.Info)
+
+	// a record is only stale once it is older than the max block age
+	res, err = queryClient.LastUpdateInfo(context.Background(), &queryproto.LastUpdateInfoRequest{
+		PoolId: stalePoolID, BaseAsset: "tokenC", QuoteAsset: "tokenD", MaxBlockAge: 5,
+	})
+	suite.Require().NoError(err)
+	suite.Require().False(res.Info.Stale)
+
+	// every pair of every pool, a page at a time
+	allRes, err := queryClient.AllLastUpdateInfo(context.Background(), &queryproto.AllLastUpdateInfoRequest{MaxBlockAge: 3})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]queryproto.PairLastUpdateInfo{activeInfo, staleInfo}, allRes.Infos)
+
+	allRes, err = queryClient.AllLastUpdateInfo(context.Background(), &queryproto.AllLastUpdateInfoRequest{
+		MaxBlockAge: 3, Pagination: &query.PageRequest{Limit: 1},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]queryproto.PairLastUpdateInfo{activeInfo}, allRes.Infos)
+	suite.Require().NotNil(allRes.Pagination.NextKey)
+	allRes, err = queryClient.AllLastUpdateInfo(context.Background(), &queryproto.AllLastUpdateInfoRequest{
+		MaxBlockAge: 3, Pagination: &query.PageRequest{Key: allRes.Pagination.NextKey, Limit: 1},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]queryproto.PairLastUpdateInfo{staleInfo}, allRes.Infos)
+	suite.Require().Nil(allRes.Pagination.NextKey)
+
+	// only the stale pairs
+	allRes, err = queryClient.AllLastUpdateInfo(context.Background(), &queryproto.AllLastUpdateInfoRequest{
+		MaxBlockAge: 3, StaleOnly: true, Pagination: &query.PageRequest{CountTotal: true},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]queryproto.PairLastUpdateInfo{staleInfo}, allRes.Infos)
+	suite.Require().Equal(uint64(1), allRes.Pagination.Total)
+
+	// a pair the pool doesn't have
+	_, err = queryClient.LastUpdateInfo(context.Background(), &queryproto.LastUpdateInfoRequest{
+		PoolId: activePoolID, BaseAsset: "tokenA", QuoteAsset: "tokenC",
+	})
+	suite.Require().Error(err)
+	_, err = queryClient.LastUpdateInfo(context.Background(), &queryproto.LastUpdateInfoRequest{BaseAsset: "tokenA", QuoteAsset: "tokenB"})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *QueryTestSuite) TestQueryMedianSpotPrice() {
+	suite.SetupTest()
+	createTime := suite.Ctx.BlockTime()
+	poolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	params := suite.App.TwapKeeper.GetParams(suite.Ctx)
+	params.MedianTrackedPools = []uint64{poolID}
+	suite.App.TwapKeeper.SetParams(suite.Ctx, params)
+	spotPrice, err := suite.App.GAMMKeeper.CalculateSpotPrice(suite.Ctx, poolID, "tokenB", "tokenA")
+	suite.Require().NoError(err)
+	for i := 0; i < 2; i++ {
+		suite.EndBlock()
+		suite.Commit()
+	}
+	queryClient := suite.grpcQueryClient()
+
+	// the window ends at the block time by default
+	req := &queryproto.MedianSpotPriceRequest{PoolId: poolID, BaseAsset: "tokenA", QuoteAsset: "tokenB", StartTime: createTime}
+	res, err := queryClient.MedianSpotPrice(context.Background(), req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(spotPrice, res.MedianSpotPrice)
+	suite.Require().Equal(uint64(2), res.NumSamples)
+
+	req.EndTime = &createTime
+	res, err = queryClient.MedianSpotPrice(context.Background(), req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), res.NumSamples)
+
+	// a pool that isn't tracked has no samples
+	req.PoolId = poolID + 1
+	_, err = queryClient.MedianSpotPrice(context.Background(), req)
+	suite.Require().ErrorContains(err, "no spot price samples")
+}
+
+func (suite *QueryTestSuite) TestQueryArithmeticTwapForRoute() {
+	suite.SetupTest()
+	createTime := suite.Ctx.BlockTime()
+	poolAB := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	poolBC := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenB", 1000), sdk.NewInt64Coin("tokenC", 3000))
+	for i := 0; i < 2; i++ {
+		suite.EndBlock()
+		suite.Commit()
+	}
+	queryClient := suite.grpcQueryClient()
+
+	// the TWAP over the route is the product of the TWAPs of its hops
+	twapAB, err := queryClient.ArithmeticTwapToNow(context.Background(), &queryproto.ArithmeticTwapToNowRequest{
+		PoolId: poolAB, BaseAsset: "tokenA", QuoteAsset: "tokenB", StartTime: createTime,
+	})
+	suite.Require().NoError(err)
+	twapBC, err := queryClient.ArithmeticTwapToNow(context.Background(), &queryproto.ArithmeticTwapToNowRequest{
+		PoolId: poolBC, BaseAsset: "tokenB", QuoteAsset: "tokenC", StartTime: createTime,
+	})
+	suite.Require().NoError(err)
+
+	req := &queryproto.ArithmeticTwapForRouteRequest{
+		Route: []queryproto.TwapRoutePoolPair{
+			{PoolId: poolAB, BaseAsset: "tokenA", QuoteAsset: "tokenB"},
+			{PoolId: poolBC, BaseAsset: "tokenB", QuoteAsset: "tokenC"},
+		},
+		StartTime: createTime,
+	}
+	res, err := queryClient.ArithmeticTwapForRoute(context.Background(), req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(twapAB.ArithmeticTwap.Mul(twapBC.ArithmeticTwap), res.ArithmeticTwap)
+	suite.Require().Nil(res.LastErrorTime)
+
+	// hops that don't chain are rejected
+	req.Route[1].BaseAsset = "tokenC"
+	req.Route[1].QuoteAsset = "tokenB"
+	_, err = queryClient.ArithmeticTwapForRoute(context.Background(), req)
+	suite.Require().ErrorContains(err, "invalid twap route")
+}
+
+func (suite *QueryTestSuite) streamRecords() map[uint64][]twaptypes.TwapRecord {
+	baseTime := suite.Ctx.BlockTime().UTC()
+	records := map[uint64][]twaptypes.TwapRecord{}
+	genesis := twaptypes.DefaultGenesis()
+	for i := 0; i < 30; i++ {
+		for _, poolId := range []uint64{1000, 1001} {
+			record := twaptypes.TwapRecord{
+				PoolId:          poolId,
+				Asset0Denom:     "tokenA",
+				Asset1Denom:     "tokenB",
+				Height:          int64(i + 1),
+				Time:            baseTime.Add(time.Duration(i) * time.Minute),
+				P0LastSpotPrice: sdk.OneDec(),
+				P1LastSpotPrice: sdk.OneDec(),
+				// all records have the same encoded size
+				P0ArithmeticTwapAccumulator: sdk.NewDec(int64(100 + i)),
+				P1ArithmeticTwapAccumulator: sdk.NewDec(int64(100 + i)),
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+			}
+			records[poolId] = append(records[poolId], record)
+			genesis.Twaps = append(genesis.Twaps, record)
+		}
+	}
+	suite.App.TwapKeeper.InitGenesis(suite.Ctx, genesis)
+	return records
+}
+
+type recordKey struct {
+	poolId uint64
+	time   time.Time
+}
+
+func recordKeys(records []twaptypes.TwapRecord) []recordKey {
+	keys := []recordKey{}
+	for _, record := range records {
+		keys = append(keys, recordKey{poolId: record.PoolId, time: record.Time.UTC()})
+	}
+	return keys
+}
+
+func (suite *QueryTestSuite) TestStreamTwapRecords() {
+	suite.SetupTest()
+	records := suite.streamRecords()
+	baseTime := suite.Ctx.BlockTime().UTC()
+	endTime := baseTime.Add(10 * time.Minute)
+	recordBytes := uint64(records[1000][0].Size())
+
+	testCases := []struct {
+		name string
+		req  queryproto.StreamTwapRecordsRequest
+
+		expectErr        bool
+		expectBatchSizes []int
+		expectRecords    []twaptypes.TwapRecord
+	}{
+		{
+			name:             "single pool in batches",
+			req:              queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: baseTime, BatchSize: 7},
+			expectBatchSizes: []int{7, 7, 7, 7, 2},
+			expectRecords:    records[1000],
+		},
+		{
+			name:             "default batch size",
+			req:              queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: baseTime},
+			expectBatchSizes: []int{30},
+			expectRecords:    records[1000],
+		},
+		{
+			name: "batches bounded by bytes",
+			req: queryproto.StreamTwapRecordsRequest{
+				PoolId: 1000, StartTime: baseTime, EndTime: &endTime, BatchSize: 7, MaxBatchBytes: 3*recordBytes + 1,
+			},
+			expectBatchSizes: []int{3, 3, 3, 1},
+			expectRecords:    records[1000][:10],
+		},
+		{
+			name:             "records larger than the byte bound are sent alone",
+			req:              queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: baseTime, EndTime: &endTime, MaxBatchBytes: 1},
+			expectBatchSizes: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expectRecords:    records[1000][:10],
+		},
+		{
+			name:             "all pools, end time is exclusive",
+			req:              queryproto.StreamTwapRecordsRequest{StartTime: baseTime.Add(time.Minute), EndTime: &endTime, BatchSize: 5},
+			expectBatchSizes: []int{5, 5, 5, 3},
+			expectRecords: func() []twaptypes.TwapRecord {
+				expected := []twaptypes.TwapRecord{}
+				for i := 1; i < 10; i++ {
+					expected = append(expected, records[1000][i], records[1001][i])
+				}
+				return expected
+			}(),
+		},
+		{
+			name:             "no records in range",
+			req:              queryproto.StreamTwapRecordsRequest{StartTime: baseTime.Add(time.Hour)},
+			expectBatchSizes: nil,
+			expectRecords:    []twaptypes.TwapRecord{},
+		},
+		{
+			name:      "start time not before end time",
+			req:       queryproto.StreamTwapRecordsRequest{StartTime: endTime, EndTime: &endTime},
+			expectErr: true,
+		},
+		{
+			name:      "batch size too large",
+			req:       queryproto.StreamTwapRecordsRequest{StartTime: baseTime, BatchSize: client.MaxStreamBatchSize + 1},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		suite.Run(tc.name, func() {
+			queryClient := suite.grpcQueryClient()
+
+			stream, err := queryClient.StreamTwapRecords(context.Background(), &tc.req)
+			suite.Require().NoError(err)
+
+			var batchSizes []int
+			received := []twaptypes.TwapRecord{}
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if tc.expectErr {
+					suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+					return
+				}
+				suite.Require().NoError(err)
+				batchSizes = append(batchSizes, len(res.Records))
+				received = append(received, res.Records...)
+			}
+			suite.Require().False(tc.expectErr)
+			suite.Require().Equal(tc.expectBatchSizes, batchSizes)
+			suite.Require().Equal(recordKeys(tc.expectRecords), recordKeys(received))
+		})
+	}
+}
+
+// grpcQueryClient serves the twap queries over an in memory gRPC connection, as streaming queries
+// can't go through the query router, and the router doesn't keep the details of gRPC statuses.
+// Unary queries get suite.Ctx, as they do from the baseapp gRPC server.
+func (suite *QueryTestSuite) grpcQueryClient() queryproto.QueryClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(_ context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(sdk.WrapSDKContext(suite.Ctx), req)
+		}))
+	queryproto.RegisterQueryServer(server, twapgrpc.Querier{Q: client.Querier{
+		K: *suite.App.TwapKeeper,
+		NewQueryContext: func(int64) (sdk.Context, error) {
+			return suite.Ctx, nil
+		},
+	}})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	suite.T().Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { _ = conn.Close() })
+	return queryproto.NewQueryClient(conn)
+}
+
+// A query for a time before the record history keep period fails with an OutOfRange status, whose ErrorInfo details
+// give the keep period and the oldest queryable time to the client.
+func (suite *QueryTestSuite) TestQueryTimeTooOldStatusDetails() {
+	suite.SetupTest()
+	poolID := suite.PrepareBalancerPoolWithCoins(sdk.NewInt64Coin("tokenA", 1000), sdk.NewInt64Coin("tokenB", 2000))
+	poolCreationTime := suite.Ctx.BlockTime()
+	keepPeriod := suite.App.TwapKeeper.GetParams(suite.Ctx).RecordHistoryKeepPeriod
+	suite.Ctx = suite.Ctx.WithBlockTime(poolCreationTime.Add(keepPeriod + time.Hour))
+	oldestQueryableTime := poolCreationTime.Add(time.Hour)
+	queryClient := suite.grpcQueryClient()
+
+	requireTimeTooOldDetails := func(err error, requestedTime time.Time) {
+		st, ok := status.FromError(err)
+		suite.Require().True(ok)
+		suite.Require().Equal(codes.OutOfRange, st.Code())
+		suite.Require().Len(st.Details(), 1)
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		suite.Require().True(ok)
+		suite.Require().Equal(twaptypes.ErrorInfoReasonTimeTooOld, info.Reason)
+		suite.Require().Equal(twaptypes.ModuleName, info.Domain)
+
+		parsedRequestedTime, err := time.Parse(time.RFC3339Nano, info.Metadata[twaptypes.ErrorInfoKeyRequestedTime])
+		suite.Require().NoError(err)
+		suite.Require().True(requestedTime.Equal(parsedRequestedTime))
+		parsedKeepPeriod, err := time.ParseDuration(info.Metadata[twaptypes.ErrorInfoKeyKeepPeriod])
+		suite.Require().NoError(err)
+		suite.Require().Equal(keepPeriod, parsedKeepPeriod)
+		parsedOldestQueryableTime, err := time.Parse(time.RFC3339Nano, info.Metadata[twaptypes.ErrorInfoKeyOldestQueryableTime])
+		suite.Require().NoError(err)
+		suite.Require().True(oldestQueryableTime.Equal(parsedOldestQueryableTime))
+	}
+
+	spotPriceTime := oldestQueryableTime.Add(-time.Minute)
+	_, err := queryClient.HistoricalSpotPrice(context.Background(), &queryproto.HistoricalSpotPriceRequest{
+		PoolId:     poolID,
+		BaseAsset:  "tokenA",
+		QuoteAsset: "tokenB",
+		Time:       spotPriceTime,
+	})
+	requireTimeTooOldDetails(err, spotPriceTime)
+
+	twapStartTime := poolCreationTime.Add(-time.Minute)
+	_, err = queryClient.ArithmeticTwap(context.Background(), &queryproto.ArithmeticTwapRequest{
+		PoolId:     poolID,
+		BaseAsset:  "tokenA",
+		QuoteAsset: "tokenB",
+		StartTime:  twapStartTime,
+	})
+	requireTimeTooOldDetails(err, twapStartTime)
+
+	_, err = queryClient.GeometricTwap(context.Background(), &queryproto.GeometricTwapRequest{
+		PoolId:     poolID,
+		BaseAsset:  "tokenA",
+		QuoteAsset: "tokenB",
+		StartTime:  twapStartTime,
+	})
+	requireTimeTooOldDetails(err, twapStartTime)
+}
+
+func (suite *QueryTestSuite) TestStreamTwapRecordsCancellation() {
+	suite.SetupTest()
+	records := suite.streamRecords()
+
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := suite.Ctx.WithContext(goCtx)
+
+	batches := 0
+	err := client.Querier{K: *suite.App.TwapKeeper}.StreamTwapRecords(ctx,
+		queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: suite.Ctx.BlockTime(), BatchSize: 5},
+		func(res *queryproto.StreamTwapRecordsResponse) error {
+			batches++
+			// the client goes away after the second batch
+			if batches == 2 {
+				cancel()
+			}
+			return nil
+		})
+	suite.Require().Equal(codes.Canceled, status.Code(err))
+	suite.Require().Equal(2, batches)
+	suite.Require().Less(2*5, len(records[1000]))
+
+	// an error sending a batch also stops the stream
+	batches = 0
+	sendErr := errors.New("connection reset")
+	err = client.Querier{K: *suite.App.TwapKeeper}.StreamTwapRecords(suite.Ctx,
+		queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: suite.Ctx.BlockTime(), BatchSize: 5},
+		func(res *queryproto.StreamTwapRecordsResponse) error {
+			batches++
+			return sendErr
+		})
+	suite.Require().ErrorIs(err, sendErr)
+	suite.Require().Equal(1, batches)
+}
+
+func (suite *QueryTestSuite) TestStreamTwapRecordsGasLimit() {
+	suite.SetupTest()
+	records := suite.streamRecords()
+	req := queryproto.StreamTwapRecordsRequest{PoolId: 1000, StartTime: suite.Ctx.BlockTime(), BatchSize: 5}
+
+	ctx := suite.Ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	err := client.Querier{K: *suite.App.TwapKeeper}.StreamTwapRecords(ctx, req,
+		func(*queryproto.StreamTwapRecordsResponse) error { return nil })
+	suite.Require().NoError(err)
+	streamGas := ctx.GasMeter().GasConsumed()
+
+	// with half the gas, the stream stops with the batches sent so far
+	received := []twaptypes.TwapRecord{}
+	ctx = suite.Ctx.WithGasMeter(sdk.NewGasMeter(streamGas / 2))
+	err = client.Querier{K: *suite.App.TwapKeeper}.StreamTwapRecords(ctx, req,
+		func(res *queryproto.StreamTwapRecordsResponse) error {
+			received = append(received, res.Records...)
+			return nil
+		})
+	suite.Require().Equal(codes.ResourceExhausted, status.Code(err))
+	suite.Require().NotEmpty(received)
+	suite.Require().Less(len(received), len(records[1000]))
+	suite.Require().Equal(recordKeys(records[1000][:len(received)]), recordKeys(received))
+}
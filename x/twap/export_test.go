@@ -5,17 +5,18 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 type (
-	TimeTooOldError = timeTooOldError
-	TwapType        = twapType
+	TwapType = twapType
 )
 
 const (
 	ArithmeticTwapType = arithmeticTwapType
 	GeometricTwapType  = geometricTwapType
+	HarmonicTwapType   = harmonicTwapType
 )
 
 var GeometricTwapMathBase = geometricTwapMathBase
@@ -24,12 +25,12 @@ func (k Keeper) StoreNewRecord(ctx sdk.Context, record types.TwapRecord) {
 	k.storeNewRecord(ctx, record)
 }
 
-func (k Keeper) GetMostRecentRecordStoreRepresentation(ctx sdk.Context, poolId uint64, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
-	return k.getMostRecentRecordStoreRepresentation(ctx, poolId, asset0Denom, asset1Denom)
+func (k Keeper) StorePinnedRecord(ctx sdk.Context, record types.TwapRecord) {
+	k.storePinnedRecord(ctx, record)
 }
 
-func (k Keeper) GetAllMostRecentRecordsForPool(ctx sdk.Context, poolId uint64) ([]types.TwapRecord, error) {
-	return k.getAllMostRecentRecordsForPool(ctx, poolId)
+func (k Keeper) GetMostRecentRecordStoreRepresentation(ctx sdk.Context, poolId uint64, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
+	return k.getMostRecentRecordStoreRepresentation(ctx, poolId, asset0Denom, asset1Denom)
 }
 
 func (k Keeper) GetRecordAtOrBeforeTime(ctx sdk.Context, poolId uint64, time time.Time, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
@@ -52,6 +53,10 @@ func (k Keeper) GetChangedPools(ctx sdk.Context) []uint64 {
 	return k.getChangedPools(ctx)
 }
 
+func (k Keeper) GetDeferredPools(ctx sdk.Context) []uint64 {
+	return k.getDeferredPools(ctx)
+}
+
 func (k Keeper) UpdateRecord(ctx sdk.Context, record types.TwapRecord) types.TwapRecord {
 	return k.updateRecord(ctx, record)
 }
@@ -61,11 +66,37 @@ func (k Keeper) UpdateRecords(ctx sdk.Context, poolId uint64) error {
 }
 
 func (k Keeper) PruneRecordsBeforeTimeButNewest(ctx sdk.Context, lastKeptTime time.Time) error {
-	return k.pruneRecordsBeforeTimeButNewest(ctx, lastKeptTime)
+	_, err := k.pruneRecordsBeforeTimeButNewest(ctx, types.PruningState{LastKeptTime: lastKeptTime}, 0)
+	return err
 }
 
+// PruneRecords starts the pruning of the records, and prunes them all at once, as the EndBlocks of the following
+// blocks would with no limit of records per block.
 func (k Keeper) PruneRecords(ctx sdk.Context) error {
-	return k.pruneRecords(ctx)
+	if err := k.pruneRecords(ctx); err != nil {
+		return err
+	}
+	return k.pruneRecordsInBlock(ctx, 0)
+}
+
+func (k Keeper) GetPruningState(ctx sdk.Context) (types.PruningState, bool, error) {
+	return k.getPruningState(ctx)
+}
+
+func (k Keeper) StoreSpotPriceSample(ctx sdk.Context, sample types.SpotPriceSample) {
+	k.storeSpotPriceSample(ctx, sample)
+}
+
+func (k Keeper) GetSpotPriceSamples(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string, startTime, endTime time.Time) ([]types.SpotPriceSample, error) {
+	return k.getSpotPriceSamples(ctx, poolId, asset0Denom, asset1Denom, startTime, endTime)
+}
+
+func (k Keeper) GetAllTimeIndexedSpotPriceSamples(ctx sdk.Context) ([]types.SpotPriceSample, error) {
+	return osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(types.SpotPriceSampleTimeIndexPrefix), types.ParseSpotPriceSampleFromBz)
+}
+
+func (k Keeper) FlushArchive(ctx sdk.Context) {
+	k.flushArchive(ctx)
 }
 
 func (k Keeper) GetInterpolatedRecord(ctx sdk.Context, poolId uint64, asset0Denom string, asset1Denom string, t time.Time) (types.TwapRecord, error) {
@@ -84,8 +115,8 @@ func ComputeGeometricTwap(startRecord types.TwapRecord, endRecord types.TwapReco
 	return computeGeometricTwap(startRecord, endRecord, quoteAsset)
 }
 
-func RecordWithUpdatedAccumulators(record types.TwapRecord, t time.Time) types.TwapRecord {
-	return recordWithUpdatedAccumulators(record, t)
+func ComputeHarmonicTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) (sdk.Dec, error) {
+	return computeHarmonicTwap(startRecord, endRecord, quoteAsset)
 }
 
 func NewTwapRecord(k types.AmmInterface, ctx sdk.Context, poolId uint64, denom0, denom1 string) (types.TwapRecord, error) {
@@ -100,16 +131,19 @@ func TwapPow(x sdk.Dec) sdk.Dec {
 	return twapPow(x)
 }
 
-func GetSpotPrices(
+func FetchAndSanitizeSpotPrices(
 	ctx sdk.Context,
 	k types.AmmInterface,
 	poolId uint64,
 	denom0, denom1 string,
 	previousErrorTime time.Time,
 ) (sp0 sdk.Dec, sp1 sdk.Dec, latestErrTime time.Time) {
-	return getSpotPrices(ctx, k, poolId, denom0, denom1, previousErrorTime)
+	return fetchAndSanitizeSpotPrices(ctx, k, poolId, denom0, denom1, previousErrorTime)
 }
 
+// GetAmmInterface and SetAmmInterface let the tests swap the AMM interface of the keeper for a mock. They only exist
+// in the test builds: a running chain always computes the TWAPs from the AMM interface given to NewKeeper, which
+// TestAmmInterfaceIsOnlySetByNewKeeper checks.
 func (k *Keeper) GetAmmInterface() types.AmmInterface {
 	return k.ammkeeper
 }
@@ -0,0 +1,30 @@
+package twap
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// SetHooks sets the hooks notified of the updates of the records. Until they are set, nobody is notified.
+// The keeper is copied by value into the twap module, so the hooks must be set before the module is created.
+func (k *Keeper) SetHooks(hooks types.TwapHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set twap hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
+// afterTwapRecordUpdate notifies the hooks that the record of a denom pair of pool poolId was updated. A hook that
+// errors or panics has its state changes reverted, without failing the update of the records.
+func (k Keeper) afterTwapRecordUpdate(ctx sdk.Context, record types.TwapRecord) {
+	if k.hooks == nil {
+		return
+	}
+	_ = osmoutils.ApplyFuncIfNoError(ctx, func(ctx sdk.Context) error {
+		k.hooks.AfterTwapRecordUpdate(ctx, record.PoolId, record.Asset0Denom, record.Asset1Denom, record)
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package twap_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// recordUpdate is a call of AfterTwapRecordUpdate
+type recordUpdate struct {
+	poolId uint64
+	denom0 string
+	denom1 string
+	record types.TwapRecord
+}
+
+// mockTwapHooks records the calls of its hooks
+type mockTwapHooks struct {
+	updates []recordUpdate
+}
+
+var _ types.TwapHooks = &mockTwapHooks{}
+
+func (h *mockTwapHooks) AfterTwapRecordUpdate(ctx sdk.Context, poolId uint64, denom0, denom1 string, record types.TwapRecord) {
+	h.updates = append(h.updates, recordUpdate{poolId: poolId, denom0: denom0, denom1: denom1, record: record})
+}
+
+// TestTwapHooks tests that the hooks are notified once per denom pair of the pools changed in a block, with their
+// new records, and not for the pools that didn't change.
+func (s *TestSuite) TestTwapHooks() {
+	poolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.Commit()
+
+	hooks, otherHooks := &mockTwapHooks{}, &mockTwapHooks{}
+	s.twapkeeper.SetHooks(types.NewMultiTwapHooks(hooks, otherHooks))
+	s.Require().Panics(func() { s.twapkeeper.SetHooks(hooks) })
+
+	// only the pool swapped against changed
+	s.RunBasicSwap(poolIdABC)
+	s.twapkeeper.EndBlock(s.Ctx)
+	records, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolIdABC)
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+	expUpdates := make([]recordUpdate, 0, len(records))
+	for _, record := range records {
+		s.Require().Equal(s.Ctx.BlockTime(), record.Time)
+		expUpdates = append(expUpdates, recordUpdate{poolId: poolIdABC, denom0: record.Asset0Denom, denom1: record.Asset1Denom, record: record})
+	}
+	s.Require().Equal(expUpdates, hooks.updates)
+	s.Require().Equal(expUpdates, otherHooks.updates)
+
+	// no pool changed in the next block
+	s.Commit()
+	hooks.updates, otherHooks.updates = nil, nil
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.Require().Empty(hooks.updates)
+	s.Require().Empty(otherHooks.updates)
+
+	// both pools changed
+	s.Commit()
+	s.RunBasicSwap(poolIdAB)
+	s.RunBasicSwap(poolIdABC)
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.Require().Len(hooks.updates, 4)
+	s.Require().Equal(poolIdAB, hooks.updates[0].poolId)
+	for _, update := range hooks.updates[1:] {
+		s.Require().Equal(poolIdABC, update.poolId)
+	}
+}
@@ -0,0 +1,47 @@
+package twap
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+const mostRecentIndexInvariantName = "most-recent-index-invariant"
+
+// RegisterInvariants registers the twap module's invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, mostRecentIndexInvariantName, MostRecentIndexInvariant(k))
+}
+
+// MostRecentIndexInvariant ensures that the most recent record of every denom pair is the newest of its historical
+// records, which the TWAPs over past windows read. Otherwise the TWAPs to now read different prices than them.
+// The divergences can be repaired with RepairMostRecentIndex.
+func MostRecentIndexInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		records, err := types.GetAllMostRecentTwaps(ctx.KVStore(k.storeKey))
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, mostRecentIndexInvariantName,
+				fmt.Sprintf("\terror reading the most recent records: %s\n", err)), true
+		}
+		var divergences []string
+		for _, record := range records {
+			divergence, err := k.getMostRecentIndexDivergence(ctx, record)
+			if err != nil {
+				return sdk.FormatInvariant(types.ModuleName, mostRecentIndexInvariantName,
+					fmt.Sprintf("\terror reading the historical records: %s\n", err)), true
+			}
+			if divergence != nil {
+				divergences = append(divergences, fmt.Sprintf("\t%s\n", divergence))
+			}
+		}
+		if len(divergences) > 0 {
+			return sdk.FormatInvariant(types.ModuleName, mostRecentIndexInvariantName,
+				fmt.Sprintf("\t%d most recent records diverge from the historical records\n%s", len(divergences), strings.Join(divergences, ""))), true
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, mostRecentIndexInvariantName, "All most recent records are the newest historical records"), false
+	}
+}
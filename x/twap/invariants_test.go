@@ -0,0 +1,168 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// indexTestRecords returns the records of the token/A token/B pair of pool poolId, whose token/B is worth 10 token/A
+// for an hour, then 20 token/A
+func indexTestRecords(poolId uint64) []types.TwapRecord {
+	return []types.TwapRecord{
+		newRecord(poolId, baseTime, sdk.NewDec(10), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+		newRecord(poolId, baseTime.Add(time.Hour), sdk.NewDec(20), sdk.NewDec(36_000_000), sdk.NewDec(360_000), sdk.ZeroDec()),
+	}
+}
+
+// setMostRecentRecord overwrites the most recent record of the pair of record, without storing it as a historical
+// record, as a faulty migration would
+func (s *TestSuite) setMostRecentRecord(record types.TwapRecord) {
+	store := s.Ctx.KVStore(s.App.GetKey(types.StoreKey))
+	osmoutils.MustSet(store, types.FormatMostRecentTWAPKey(record.PoolId, record.Asset0Denom, record.Asset1Denom), &record)
+}
+
+// deleteHistoricalRecord deletes record from the historical records, leaving it as the most recent record
+func (s *TestSuite) deleteHistoricalRecord(record types.TwapRecord) {
+	store := s.Ctx.KVStore(s.App.GetKey(types.StoreKey))
+	store.Delete(types.FormatHistoricalTimeIndexTWAPKey(record.Time, record.PoolId, record.Asset0Denom, record.Asset1Denom))
+	store.Delete(types.FormatHistoricalPoolIndexTWAPKey(record.PoolId, record.Asset0Denom, record.Asset1Denom, record.Time))
+}
+
+// twapsOverLastHour returns the arithmetic TWAP of token/B over the hour before the block time of pool poolId, computed
+// to now from the most recent record, and over the same window from the historical records
+func (s *TestSuite) twapsOverLastHour(poolId uint64) (toNow sdk.Dec, overWindow sdk.Dec) {
+	startTime := s.Ctx.BlockTime().Add(-time.Hour)
+	toNow, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolId, denom1, denom0, startTime)
+	s.Require().NoError(err)
+	// a window ending before the block time only reads historical records
+	laterCtx := s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Second))
+	overWindow, err = s.twapkeeper.GetArithmeticTwap(laterCtx, poolId, denom1, denom0, startTime, s.Ctx.BlockTime())
+	s.Require().NoError(err)
+	return toNow, overWindow
+}
+
+func (s *TestSuite) TestMostRecentIndexInvariant() {
+	records := indexTestRecords(1)
+	tests := map[string]struct {
+		corrupt func()
+		// expRecord is the record of the pair, both most recent and newest historical, after the repair
+		expRecord types.TwapRecord
+		expBroken bool
+	}{
+		"consistent index": {
+			corrupt:   func() {},
+			expRecord: records[1],
+		},
+		"most recent record older than the historical records": {
+			corrupt:   func() { s.setMostRecentRecord(records[0]) },
+			expRecord: records[1],
+			expBroken: true,
+		},
+		"most recent record differing from the newest historical record at the same time": {
+			corrupt: func() {
+				record := records[1]
+				record.P0LastSpotPrice = sdk.NewDec(30)
+				s.setMostRecentRecord(record)
+			},
+			expRecord: records[1],
+			expBroken: true,
+		},
+		"most recent record missing from the historical records": {
+			corrupt:   func() { s.deleteHistoricalRecord(records[1]) },
+			expRecord: records[1],
+			expBroken: true,
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords(records)
+			s.Ctx = s.Ctx.WithBlockTime(baseTime.Add(2 * time.Hour))
+			tc.corrupt()
+
+			_, broken := twap.MostRecentIndexInvariant(*s.twapkeeper)(s.Ctx)
+			s.Require().Equal(tc.expBroken, broken)
+			if tc.expBroken {
+				toNow, overWindow := s.twapsOverLastHour(1)
+				s.Require().NotEqual(overWindow.String(), toNow.String())
+			}
+
+			repaired, err := s.twapkeeper.RepairMostRecentIndex(s.Ctx, 1)
+			s.Require().NoError(err)
+			if tc.expBroken {
+				s.Require().Equal(1, repaired)
+			} else {
+				s.Require().Equal(0, repaired)
+			}
+
+			_, broken = twap.MostRecentIndexInvariant(*s.twapkeeper)(s.Ctx)
+			s.Require().False(broken)
+			mostRecent, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, 1, denom0, denom1)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expRecord, mostRecent)
+			historical, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, 1, s.Ctx.BlockTime(), denom0, denom1)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expRecord, historical)
+
+			toNow, overWindow := s.twapsOverLastHour(1)
+			s.Require().Equal(sdk.NewDec(20).String(), overWindow.String())
+			s.Require().Equal(overWindow.String(), toNow.String())
+		})
+	}
+}
+
+func (s *TestSuite) TestRepairMostRecentIndexBatch() {
+	setup := func() {
+		s.SetupTest()
+		for poolId := uint64(1); poolId <= 3; poolId++ {
+			s.preSetRecords(indexTestRecords(poolId))
+		}
+		s.Ctx = s.Ctx.WithBlockTime(baseTime.Add(2 * time.Hour))
+		// pools 1 and 3 diverge
+		s.setMostRecentRecord(indexTestRecords(1)[0])
+		s.setMostRecentRecord(indexTestRecords(3)[0])
+	}
+
+	setup()
+	_, _, err := s.twapkeeper.RepairMostRecentIndexBatch(s.Ctx, 0, 0)
+	s.Require().Error(err)
+
+	// pools 1 and 2, then pool 3
+	next, repaired, err := s.twapkeeper.RepairMostRecentIndexBatch(s.Ctx, 0, 2)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(3), next)
+	s.Require().Equal(1, repaired)
+	_, broken := twap.MostRecentIndexInvariant(*s.twapkeeper)(s.Ctx)
+	s.Require().True(broken)
+
+	next, repaired, err = s.twapkeeper.RepairMostRecentIndexBatch(s.Ctx, next, 2)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(0), next)
+	s.Require().Equal(1, repaired)
+	_, broken = twap.MostRecentIndexInvariant(*s.twapkeeper)(s.Ctx)
+	s.Require().False(broken)
+
+	// every pool, one at a time
+	setup()
+	repaired, err = s.twapkeeper.RepairAllMostRecentIndexes(s.Ctx, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(2, repaired)
+	_, broken = twap.MostRecentIndexInvariant(*s.twapkeeper)(s.Ctx)
+	s.Require().False(broken)
+	for poolId := uint64(1); poolId <= 3; poolId++ {
+		toNow, overWindow := s.twapsOverLastHour(poolId)
+		s.Require().Equal(overWindow.String(), toNow.String())
+	}
+
+	// a store without records has nothing to repair
+	s.SetupTest()
+	repaired, err = s.twapkeeper.RepairAllMostRecentIndexes(s.Ctx, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(0, repaired)
+}
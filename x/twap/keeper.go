@@ -2,6 +2,7 @@ package twap
 
 import (
 	"sort"
+	"strconv"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -17,16 +18,25 @@ type Keeper struct {
 
 	paramSpace paramtypes.Subspace
 
-	ammkeeper types.AmmInterface
+	ammkeeper     types.AmmInterface
+	upgradeKeeper types.UpgradeKeeper
+
+	// recordCache is shared by the copies of the keeper, and disabled unless the node enabled it, see SetRecordCacheSize
+	recordCache *recordCache
+	// archive is shared by the copies of the keeper, and disabled unless the node enabled it, see SetArchive
+	archive *recordArchive
+
+	// hooks are notified of the updates of the records, see SetHooks
+	hooks types.TwapHooks
 }
 
-func NewKeeper(storeKey sdk.StoreKey, transientKey *sdk.TransientStoreKey, paramSpace paramtypes.Subspace, ammKeeper types.AmmInterface) *Keeper {
+func NewKeeper(storeKey sdk.StoreKey, transientKey *sdk.TransientStoreKey, paramSpace paramtypes.Subspace, ammKeeper types.AmmInterface, upgradeKeeper types.UpgradeKeeper) *Keeper {
 	// set KeyTable if it has not already been set
 	if !paramSpace.HasKeyTable() {
 		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
 	}
 
-	return &Keeper{storeKey: storeKey, transientKey: transientKey, paramSpace: paramSpace, ammkeeper: ammKeeper}
+	return &Keeper{storeKey: storeKey, transientKey: transientKey, paramSpace: paramSpace, ammkeeper: ammKeeper, upgradeKeeper: upgradeKeeper, recordCache: &recordCache{}, archive: &recordArchive{}}
 }
 
 // GetParams returns the total set of twap parameters.
@@ -40,6 +50,31 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+// UpdateParams replaces the twap parameters with params, for a MsgUpdateParams sent by sender. Only the params
+// authority of the current params can update them. If the record history keep period changes while a pruning is in
+// progress, the pruning is restarted with the threshold of the new period: a longer period must not let it prune the
+// records it now keeps. A shorter period otherwise takes effect at the next prune epoch.
+func (k Keeper) UpdateParams(ctx sdk.Context, sender string, params types.Params) error {
+	oldParams := k.GetParams(ctx)
+	if oldParams.ParamsAuthority == "" || oldParams.ParamsAuthority != sender {
+		return types.ParamsUnauthorizedError{Sender: sender, Authority: oldParams.ParamsAuthority}
+	}
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	k.SetParams(ctx, params)
+	if params.RecordHistoryKeepPeriod == oldParams.RecordHistoryKeepPeriod {
+		return nil
+	}
+	_, found, err := k.getPruningState(ctx)
+	if err != nil || !found {
+		return err
+	}
+	k.setPruningState(ctx, types.PruningState{LastKeptTime: ctx.BlockTime().Add(-params.RecordHistoryKeepPeriod)})
+	return nil
+}
+
 func (k *Keeper) PruneEpochIdentifier(ctx sdk.Context) string {
 	return k.GetParams(ctx).PruneEpochIdentifier
 }
@@ -67,6 +102,80 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState *types.GenesisState) {
 	for _, twap := range genState.Twaps {
 		k.storeNewRecord(ctx, twap)
 	}
+
+	for _, twap := range genState.PinnedRecords {
+		k.storePinnedRecord(ctx, twap)
+	}
+
+	for _, route := range genState.CanonicalRoutes {
+		k.storeCanonicalTwapRoute(ctx, route)
+	}
+
+	if err := k.createRecordsForPoolsWithoutRecords(ctx); err != nil {
+		panic(err)
+	}
+	k.flushArchive(ctx)
+}
+
+// createRecordsForPoolsWithoutRecords creates the initial records of the pools that have no records.
+// This is the case of pools that are imported via genesis without twap records (e.g. testnet snapshots),
+// as AfterCreatePool is never called for them. Without this, TWAPs of these pools can't be queried
+// until they are swapped against.
+// The records are created at the genesis time. Pools whose spot prices can't be computed
+// are skipped, with an event.
+func (k Keeper) createRecordsForPoolsWithoutRecords(ctx sdk.Context) error {
+	pools, err := k.ammkeeper.GetPoolsAndPoke(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		poolId := pool.GetId()
+		records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+		if err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			continue
+		}
+
+		records, err = k.newRecordsForPool(ctx, poolId)
+		if err != nil {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.TypeEvtSkipGenesisPool,
+				sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(poolId, 10)),
+				sdk.NewAttribute(types.AttributeReason, err.Error()),
+			))
+			continue
+		}
+		for _, record := range records {
+			k.storeNewRecord(ctx, record)
+		}
+	}
+	return nil
+}
+
+// newRecordsForPool returns new records for all the unique pairs of denoms of the pool,
+// or an error if any of their spot prices can't be computed.
+func (k Keeper) newRecordsForPool(ctx sdk.Context, poolId uint64) ([]types.TwapRecord, error) {
+	denoms, err := k.ammkeeper.GetPoolDenoms(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+
+	denomPairs := types.GetAllUniqueDenomPairs(denoms)
+	records := make([]types.TwapRecord, 0, len(denomPairs))
+	for _, denomPair := range denomPairs {
+		record, err := newTwapRecord(k.ammkeeper, ctx, poolId, denomPair.Denom0, denomPair.Denom1)
+		if err != nil {
+			return nil, err
+		}
+		if record.LastErrorTime.Equal(ctx.BlockTime()) {
+			return nil, types.SpotPriceUncomputableError{PoolId: poolId, Asset0Denom: record.Asset0Denom, Asset1Denom: record.Asset1Denom}
+		}
+		records = append(records, record)
+	}
+	return records, nil
 }
 
 // ExportGenesis returns the twap module's exported genesis.
@@ -78,8 +187,20 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 		panic(err)
 	}
 
+	pinnedRecords, err := k.getAllPinnedRecords(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	canonicalRoutes, err := k.GetAllCanonicalTwapRoutes(ctx)
+	if err != nil {
+		panic(err)
+	}
+
 	return &types.GenesisState{
-		Params: k.GetParams(ctx),
-		Twaps:  twapRecords,
+		Params:          k.GetParams(ctx),
+		Twaps:           twapRecords,
+		PinnedRecords:   pinnedRecords,
+		CanonicalRoutes: canonicalRoutes,
 	}
 }
@@ -1,17 +1,27 @@
 package twap_test
 
 import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/osmosis-labs/osmosis/v13/app/apptesting"
 	"github.com/osmosis-labs/osmosis/v13/app/apptesting/osmoassert"
+	"github.com/osmosis-labs/osmosis/v13/x/gamm/pool-models/balancer"
+	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
 	"github.com/osmosis-labs/osmosis/v13/x/twap"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types/twapmock"
 )
 
 // TODO: Consider switching this everywhere
@@ -226,6 +236,15 @@ func (suite *TestSuite) TestTwapInitGenesis() {
 
 			expectPanic: true,
 		},
+		"custom invalid genesis, last error time after record time - error": {
+			twapGenesis: types.NewGenesisState(
+				types.NewParams("week", 48*time.Hour),
+				[]types.TwapRecord{
+					withLastErrTime(mostRecentRecordPoolOne, mostRecentRecordPoolOne.Time.Add(time.Second)),
+				}),
+
+			expectPanic: true,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -255,6 +274,69 @@ func (suite *TestSuite) TestTwapInitGenesis() {
 	}
 }
 
+// TestTwapInitGenesis_PoolsWithoutRecords tests that pools imported via genesis without twap records
+// get records at the genesis time, unless their spot prices can't be computed.
+func (s *TestSuite) TestTwapInitGenesis_PoolsWithoutRecords() {
+	s.SetupTest()
+	genesisTime := s.Ctx.BlockTime()
+
+	newPool := func(poolId uint64, coins ...sdk.Coin) *codectypes.Any {
+		poolAssets := []balancer.PoolAsset{}
+		for _, coin := range coins {
+			poolAssets = append(poolAssets, balancer.PoolAsset{Weight: sdk.NewInt(1), Token: coin})
+		}
+		pool, err := balancer.NewBalancerPool(poolId, balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()}, poolAssets, "", genesisTime)
+		s.Require().NoError(err)
+		any, err := codectypes.NewAnyWithValue(&pool)
+		s.Require().NoError(err)
+		return any
+	}
+
+	s.App.GAMMKeeper.InitGenesis(s.Ctx, gammtypes.GenesisState{
+		Pools: []*codectypes.Any{
+			newPool(1, sdk.NewInt64Coin(denom0, 1000), sdk.NewInt64Coin(denom1, 2000)),
+			newPool(2, sdk.NewInt64Coin(denom0, 1000), sdk.NewInt64Coin(denom1, 1000), sdk.NewInt64Coin(denom2, 1000)),
+			newPool(3, sdk.NewInt64Coin(denom0, 1000), sdk.NewInt64Coin(denom1, 1000)),
+		},
+		NextPoolNumber: 4,
+		Params:         gammtypes.DefaultParams(),
+	}, s.App.AppCodec())
+
+	// the spot prices of pool 3 can't be computed
+	mockAMMI := twapmock.NewProgrammedAmmInterface(s.twapkeeper.GetAmmInterface())
+	mockAMMI.ProgramPoolSpotPriceOverride(3, denom0, denom1, sdk.Dec{}, errors.New("spot price error"))
+	mockAMMI.ProgramPoolSpotPriceOverride(3, denom1, denom0, sdk.Dec{}, errors.New("spot price error"))
+	s.twapkeeper.SetAmmInterface(mockAMMI)
+
+	s.twapkeeper.InitGenesis(s.Ctx, types.DefaultGenesis())
+
+	for poolId, expectedPairs := range map[uint64]int{1: 1, 2: 3} {
+		records, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolId)
+		s.Require().NoError(err)
+		s.Require().Len(records, expectedPairs)
+		for _, record := range records {
+			s.Require().Equal(genesisTime, record.Time)
+			s.Require().True(record.LastErrorTime.IsZero())
+		}
+	}
+	records, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, 3)
+	s.Require().NoError(err)
+	s.Require().Empty(records)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtSkipGenesisPool, 1)
+
+	// The TWAPs of the backfilled pools can be queried right away, and later on.
+	record, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, 1, denom0, denom1)
+	s.Require().NoError(err)
+	twap, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, 1, denom0, denom1, genesisTime)
+	s.Require().NoError(err)
+	s.Require().Equal(record.P1LastSpotPrice, twap)
+
+	ctx := s.Ctx.WithBlockTime(genesisTime.Add(time.Hour))
+	twap, err = s.twapkeeper.GetArithmeticTwapToNow(ctx, 1, denom0, denom1, genesisTime)
+	s.Require().NoError(err)
+	s.Require().Equal(record.P1LastSpotPrice, twap)
+}
+
 // TestTWAPExportGenesis tests that genesis is exported correctly.
 // It first initializes genesis to the expected value. Then, attempts
 // to export it. Lastly, compares exported to the expected.
@@ -274,6 +356,13 @@ func (suite *TestSuite) TestTWAPExportGenesis() {
 		"custom multi-record; decreasing": {
 			expectedGenesis: decreasingOrderByTimeRecordsPoolTwo,
 		},
+		"custom genesis with pinned records": {
+			expectedGenesis: &types.GenesisState{
+				Params:        basicParams,
+				Twaps:         basicCustomGenesis.Twaps,
+				PinnedRecords: basicCustomGenesis.Twaps,
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -299,6 +388,7 @@ func (suite *TestSuite) TestTWAPExportGenesis() {
 			})
 
 			suite.Require().Equal(tc.expectedGenesis.Twaps, actualGenesis.Twaps)
+			suite.Require().ElementsMatch(tc.expectedGenesis.PinnedRecords, actualGenesis.PinnedRecords)
 		})
 	}
 }
@@ -318,6 +408,18 @@ func (s *TestSuite) preSetRecords(records []types.TwapRecord) {
 	}
 }
 
+// withKeepPeriodDetails returns err, with the keep period and oldest queryable time at the current block time if it
+// is a TimeTooOldError, so that the test cases only need to give its time.
+func (s *TestSuite) withKeepPeriodDetails(err error) error {
+	tooOld, ok := err.(types.TimeTooOldError)
+	if !ok {
+		return err
+	}
+	tooOld.KeepPeriod = s.twapkeeper.GetParams(s.Ctx).RecordHistoryKeepPeriod
+	tooOld.OldestQueryableTime = s.Ctx.BlockTime().Add(-tooOld.KeepPeriod)
+	return tooOld
+}
+
 // getAllHistoricalRecordsForPool returns all historical records for a given pool.
 func (s *TestSuite) getAllHistoricalRecordsForPool(poolId uint64) []types.TwapRecord {
 	allRecords, err := s.twapkeeper.GetAllHistoricalPoolIndexedTWAPs(s.Ctx)
@@ -605,3 +707,112 @@ func recordWithUpdatedSpotPrice(record types.TwapRecord, sp0 sdk.Dec, sp1 sdk.De
 	record.P1LastSpotPrice = sp1
 	return record
 }
+
+// TestAmmInterfaceIsOnlySetByNewKeeper tests that the AMM interface the TWAPs are computed from can only be swapped
+// by the tests: outside of the _test.go files, which aren't part of the production binary, the keeper's AMM interface
+// is only ever set by NewKeeper.
+func TestAmmInterfaceIsOnlySetByNewKeeper(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				switch node := node.(type) {
+				case *ast.FuncDecl:
+					if node.Name.Name == "SetAmmInterface" {
+						t.Errorf("%s: SetAmmInterface must only be declared in export_test.go", fset.Position(node.Pos()))
+					}
+				case *ast.AssignStmt:
+					for _, lhs := range node.Lhs {
+						if selector, ok := lhs.(*ast.SelectorExpr); ok && selector.Sel.Name == "ammkeeper" {
+							t.Errorf("%s: the AMM interface must only be set by NewKeeper", fset.Position(node.Pos()))
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// Only the params authority can update the params with MsgUpdateParams, and only to valid params
+func (s *TestSuite) TestUpdateParams() {
+	authority, other := s.TestAccs[0].String(), s.TestAccs[1].String()
+	msgServer := twap.NewMsgServerImpl(s.twapkeeper)
+	ctx := sdk.WrapSDKContext(s.Ctx)
+	params := s.twapkeeper.GetParams(s.Ctx)
+	keepPeriod := params.RecordHistoryKeepPeriod
+
+	newParams := params
+	newParams.ParamsAuthority = authority
+	newParams.RecordHistoryKeepPeriod = 72 * time.Hour
+
+	// without a params authority, the params can't be updated that way
+	_, err := msgServer.UpdateParams(ctx, types.NewMsgUpdateParams(authority, newParams))
+	s.Require().ErrorIs(err, types.ParamsUnauthorizedError{Sender: authority})
+
+	params.ParamsAuthority = authority
+	s.twapkeeper.SetParams(s.Ctx, params)
+
+	tests := map[string]struct {
+		sender     string
+		keepPeriod time.Duration
+		expErr     error
+		// whether the error is a validation error, rather than expErr
+		expInvalid bool
+	}{
+		"not the params authority": {
+			sender:     other,
+			keepPeriod: 72 * time.Hour,
+			expErr:     types.ParamsUnauthorizedError{Sender: other, Authority: authority},
+		},
+		"zero keep period": {
+			sender:     authority,
+			keepPeriod: 0,
+			expInvalid: true,
+		},
+		"negative keep period": {
+			sender:     authority,
+			keepPeriod: -time.Hour,
+			expInvalid: true,
+		},
+		"keep period over the max": {
+			sender:     authority,
+			keepPeriod: types.MaxRecordHistoryKeepPeriod + time.Nanosecond,
+			expInvalid: true,
+		},
+	}
+	for name, tc := range tests {
+		s.Run(name, func() {
+			invalidParams := params
+			invalidParams.RecordHistoryKeepPeriod = tc.keepPeriod
+			_, err := msgServer.UpdateParams(ctx, types.NewMsgUpdateParams(tc.sender, invalidParams))
+			s.Require().Error(err)
+			if !tc.expInvalid {
+				s.Require().ErrorIs(err, tc.expErr)
+			}
+			s.Require().Equal(keepPeriod, s.twapkeeper.GetParams(s.Ctx).RecordHistoryKeepPeriod)
+		})
+	}
+
+	// the params authority updates the keep period, up to the max
+	for _, period := range []time.Duration{72 * time.Hour, types.MaxRecordHistoryKeepPeriod} {
+		newParams.RecordHistoryKeepPeriod = period
+		_, err = msgServer.UpdateParams(ctx, types.NewMsgUpdateParams(authority, newParams))
+		s.Require().NoError(err)
+		s.Require().Equal(period, s.twapkeeper.RecordHistoryKeepPeriod(s.Ctx))
+	}
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtUpdateParams, 2)
+
+	// the params authority can hand the params over to another authority
+	newParams.ParamsAuthority = other
+	_, err = msgServer.UpdateParams(ctx, types.NewMsgUpdateParams(authority, newParams))
+	s.Require().NoError(err)
+	_, err = msgServer.UpdateParams(ctx, types.NewMsgUpdateParams(authority, newParams))
+	s.Require().ErrorIs(err, types.ParamsUnauthorizedError{Sender: authority, Authority: other})
+}
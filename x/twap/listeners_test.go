@@ -1,10 +1,14 @@
 package twap_test
 
 import (
+	"strconv"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/osmosis-labs/osmosis/v13/app"
 	"github.com/osmosis-labs/osmosis/v13/osmoutils"
 	"github.com/osmosis-labs/osmosis/v13/x/twap"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
@@ -78,6 +82,59 @@ func (s *TestSuite) TestAfterPoolCreatedHook() {
 	}
 }
 
+const poolMutatorModuleName = "poolmutator"
+
+// poolMutatorModule is a test module whose EndBlock changes pool prices. The rest of its AppModule is left
+// unimplemented, as a module manager only needs its name to run its EndBlock.
+type poolMutatorModule struct {
+	module.AppModule
+	endBlock func(ctx sdk.Context)
+}
+
+func (poolMutatorModule) Name() string { return poolMutatorModuleName }
+
+func (m poolMutatorModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	m.endBlock(ctx)
+	return []abci.ValidatorUpdate{}
+}
+
+// TestEndBlockAfterPoolMutatingEndBlock tests that, with the app's EndBlock order, a pool changed in the EndBlock
+// of another module has its change reflected in the records of the same block.
+func (s *TestSuite) TestEndBlockAfterPoolMutatingEndBlock() {
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	s.EndBlock()
+	s.Commit()
+	recordBefore, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, poolId, s.Ctx.BlockTime(), denom0, denom1)
+	s.Require().NoError(err)
+
+	// the app's EndBlock order, restricted to twap and the test module. twap is registered first, so that only
+	// the order can make it run after the test module.
+	appModules := s.App.ModuleManager()
+	order := []string{}
+	for _, name := range app.OrderEndBlockers(append(appModules.ModuleNames(), poolMutatorModuleName)) {
+		if name == types.ModuleName || name == poolMutatorModuleName {
+			order = append(order, name)
+		}
+	}
+	mutator := poolMutatorModule{endBlock: func(ctx sdk.Context) {
+		s.RunBasicSwap(poolId)
+	}}
+	mm := module.NewManager(appModules.Modules[types.ModuleName], mutator)
+	mm.SetOrderEndBlockers(order...)
+	mm.EndBlock(s.Ctx, abci.RequestEndBlock{Height: s.Ctx.BlockHeight()})
+
+	record, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, poolId, s.Ctx.BlockTime(), denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Equal(s.Ctx.BlockTime(), record.Time)
+	s.Require().Equal(s.Ctx.BlockHeight(), record.Height)
+
+	spotPrice, err := s.App.GAMMKeeper.CalculateSpotPrice(s.Ctx, poolId, record.Asset0Denom, record.Asset1Denom)
+	s.Require().NoError(err)
+	s.Require().NotEqual(recordBefore.P0LastSpotPrice, spotPrice)
+	s.Require().Equal(spotPrice, record.P0LastSpotPrice)
+}
+
 // TestEndBlock tests if records are correctly updated upon endblock.
 func (s *TestSuite) TestEndBlock() {
 	tests := []struct {
@@ -199,7 +256,193 @@ func (s *TestSuite) TestEndBlock() {
 	}
 }
 
-// TestAfterEpochEnd tests if records get succesfully deleted via `AfterEpochEnd` hook.
+// TestEndBlockRecordUpdateEvents tests that the EndBlock emits an event per updated record of the pools changed in the
+// block, with the new record's pool id, denoms, spot prices, height and spot price error, and none for the others.
+func (s *TestSuite) TestEndBlockRecordUpdateEvents() {
+	s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.Commit()
+
+	// only the pool swapped against changed
+	s.RunBasicSwap(poolIdABC)
+	s.Ctx = s.Ctx.WithEventManager(sdk.NewEventManager())
+	s.twapkeeper.EndBlock(s.Ctx)
+
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtTwapRecordUpdate, 3)
+	records, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolIdABC)
+	s.Require().NoError(err)
+	i := 0
+	for _, event := range s.Ctx.EventManager().Events() {
+		if event.Type != types.TypeEvtTwapRecordUpdate {
+			continue
+		}
+		record := records[i]
+		s.Require().Equal(map[string]string{
+			types.AttributePoolId:          strconv.FormatUint(poolIdABC, 10),
+			types.AttributeDenom0:          record.Asset0Denom,
+			types.AttributeDenom1:          record.Asset1Denom,
+			types.AttributeP0LastSpotPrice: record.P0LastSpotPrice.String(),
+			types.AttributeP1LastSpotPrice: record.P1LastSpotPrice.String(),
+			types.AttributeHeight:          strconv.FormatInt(s.Ctx.BlockHeight(), 10),
+			types.AttributeSpotPriceError:  "false",
+		}, s.ExtractAttributes(event))
+		i++
+	}
+
+	// no pool changed in the next block
+	s.Commit()
+	s.Ctx = s.Ctx.WithEventManager(sdk.NewEventManager())
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtTwapRecordUpdate, 0)
+
+	// the event of a record whose spot price errored when it was written tells so
+	ctx := s.Ctx.WithEventManager(sdk.NewEventManager())
+	types.EmitTwapRecordUpdateEvent(ctx, withLastErrTime(records[0], records[0].Time))
+	event := s.FindEvent(ctx.EventManager().Events(), types.TypeEvtTwapRecordUpdate)
+	s.Require().Equal("true", s.ExtractAttributes(event)[types.AttributeSpotPriceError])
+}
+
+// TestEndBlockGasBudget tests that, past its gas budget, EndBlock defers the remaining changed pools to the next
+// blocks, and that the records eventually written for them have the accumulators of the whole interval.
+func (s *TestSuite) TestEndBlockGasBudget() {
+	const numPools = 50
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	poolIds := make([]uint64, numPools)
+	for i := range poolIds {
+		poolIds[i] = s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	}
+	s.EndBlock()
+	s.Commit()
+
+	creationRecords := make(map[uint64]types.TwapRecord, numPools)
+	for _, poolId := range poolIds {
+		record, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+		s.Require().NoError(err)
+		creationRecords[poolId] = record
+	}
+
+	// every pool changes in this block, but the budget only lets one pool be updated per block
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.EndBlockGasBudget = 1
+	s.twapkeeper.SetParams(s.Ctx, params)
+	for _, poolId := range poolIds {
+		s.RunBasicSwap(poolId)
+	}
+	s.EndBlock()
+	s.Require().Equal(poolIds[1:], s.twapkeeper.GetDeferredPools(s.Ctx))
+	s.Commit()
+
+	// a deferred pool changing again is updated once, in the order of the deferred pools
+	lastPoolId := poolIds[numPools-1]
+	s.RunBasicSwap(lastPoolId)
+
+	updateTimes := map[uint64]time.Time{poolIds[0]: baseTime.Add(time.Second)}
+	for _, poolId := range poolIds[1:] {
+		s.EndBlock()
+		updateTimes[poolId] = s.Ctx.BlockTime()
+		s.Require().NotContains(s.twapkeeper.GetDeferredPools(s.Ctx), poolId)
+		s.Commit()
+	}
+	s.Require().Empty(s.twapkeeper.GetDeferredPools(s.Ctx))
+
+	for _, poolId := range poolIds {
+		record, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+		s.Require().NoError(err)
+		s.Require().Equal(updateTimes[poolId], record.Time, "pool %d", poolId)
+
+		// the pool had no record while it was deferred, so its accumulators grew with its creation spot prices
+		expected := twap.RecordWithUpdatedAccumulators(creationRecords[poolId], updateTimes[poolId])
+		s.Require().Equal(expected.P0ArithmeticTwapAccumulator, record.P0ArithmeticTwapAccumulator, "pool %d", poolId)
+		s.Require().Equal(expected.P1ArithmeticTwapAccumulator, record.P1ArithmeticTwapAccumulator, "pool %d", poolId)
+		s.Require().Equal(expected.GeometricTwapAccumulator, record.GeometricTwapAccumulator, "pool %d", poolId)
+
+		spotPrice, err := s.App.GAMMKeeper.CalculateSpotPrice(s.Ctx, poolId, denom0, denom1)
+		s.Require().NoError(err)
+		s.Require().Equal(spotPrice, record.P0LastSpotPrice, "pool %d", poolId)
+		s.Require().Len(s.getAllHistoricalRecordsForPool(poolId), 2, "pool %d", poolId)
+	}
+}
+
+// TestSpotDeviationAlert tests that the EndBlock emits a spot deviation alert for a pool whose spot price is tripled
+// by a swap in the final block of the alert window, with the deviation returned by SpotDeviationFromTwap,
+// and that it doesn't for small swaps, nor once the alerts are disabled.
+func (s *TestSuite) TestSpotDeviationAlert() {
+	const window = 10 * time.Second
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.SpotDeviationAlertThreshold = sdk.OneDec()
+	params.SpotDeviationAlertWindow = window
+	s.twapkeeper.SetParams(s.Ctx, params)
+	s.EndBlock()
+	s.Commit()
+
+	// the alerts are read from the events of s.Ctx, so the twap EndBlock is run directly rather than through the module
+	// manager, which gives each module an event manager of its own
+	alerts := func() []sdk.Event {
+		alerts := []sdk.Event{}
+		for _, event := range s.Ctx.EventManager().Events() {
+			if event.Type == types.TypeEvtSpotDeviationAlert {
+				alerts = append(alerts, event)
+			}
+		}
+		return alerts
+	}
+
+	// small swaps don't trigger alerts, nor do the blocks in which the pool is younger than the window
+	for s.Ctx.BlockTime().Before(baseTime.Add(window)) {
+		s.RunBasicSwap(poolId)
+		s.twapkeeper.EndBlock(s.Ctx)
+		s.Require().Empty(alerts())
+		s.Commit()
+	}
+
+	// the price of denom0 is tripled in the final block of the window. Until the EndBlock records it, the
+	// deviation is computed from the spot price recorded in the previous block.
+	twapBefore, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolId, denom0, denom1, baseTime)
+	s.Require().NoError(err)
+	s.ModifySpotPrice(poolId, sdk.NewDec(3), denom0)
+	deviation, err := s.twapkeeper.SpotDeviationFromTwap(s.Ctx, poolId, denom0, denom1, window)
+	s.Require().NoError(err)
+	s.Require().True(deviation.LT(sdk.NewDecWithPrec(1, 4)), "deviation %s", deviation)
+
+	s.twapkeeper.EndBlock(s.Ctx)
+	spotPrice, err := s.App.GAMMKeeper.CalculateSpotPrice(s.Ctx, poolId, denom1, denom0)
+	s.Require().NoError(err)
+	s.Require().True(spotPrice.Quo(twapBefore).Sub(sdk.NewDec(3)).Abs().LT(sdk.NewDecWithPrec(1, 2)), "spot price %s", spotPrice)
+
+	// the spike doesn't weigh in the TWAP yet, so denom0 deviates by about 2 and denom1 by about 2/3
+	twapAfter, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolId, denom0, denom1, baseTime)
+	s.Require().NoError(err)
+	s.Require().Equal(twapBefore, twapAfter)
+	deviation0, err := s.twapkeeper.SpotDeviationFromTwap(s.Ctx, poolId, denom0, denom1, window)
+	s.Require().NoError(err)
+	s.Require().Equal(spotPrice.Quo(twapAfter).Sub(sdk.OneDec()).Abs(), deviation0)
+	s.Require().True(deviation0.Sub(sdk.NewDec(2)).Abs().LT(sdk.NewDecWithPrec(1, 2)), "deviation %s", deviation0)
+	deviation1, err := s.twapkeeper.SpotDeviationFromTwap(s.Ctx, poolId, denom1, denom0, window)
+	s.Require().NoError(err)
+	s.Require().True(deviation1.LT(deviation0))
+
+	s.Require().Equal([]sdk.Event{sdk.NewEvent(
+		types.TypeEvtSpotDeviationAlert,
+		sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(types.AttributeDenom0, denom0),
+		sdk.NewAttribute(types.AttributeDenom1, denom1),
+		sdk.NewAttribute(types.AttributeDeviation, deviation0.String()),
+		sdk.NewAttribute(types.AttributeThreshold, sdk.OneDec().String()),
+	)}, alerts())
+	s.Commit()
+
+	// once the alerts are disabled, the price dropping back isn't flagged
+	params.SpotDeviationAlertThreshold = sdk.ZeroDec()
+	s.twapkeeper.SetParams(s.Ctx, params)
+	s.ModifySpotPrice(poolId, sdk.OneDec(), denom0)
+	s.twapkeeper.EndBlock(s.Ctx)
+	s.Require().Empty(alerts())
+}
+
+// TestAfterEpochEnd tests if records get succesfully deleted via `AfterEpochEnd` hook, and the EndBlock.
 // We test details of correct implementation of pruning method in store test.
 // Specifically, the newest record that is younger than the (current block time - record keep period)
 // is kept, and the rest are deleted.
@@ -207,8 +450,10 @@ func (s *TestSuite) TestAfterEpochEnd() {
 	s.SetupTest()
 	s.Ctx = s.Ctx.WithBlockTime(baseTime)
 
-	// Create TWAP record from pool creation.
+	// Create TWAP record from pool creation. The block is committed so that the EndBlocks below don't update the
+	// records of the pool.
 	s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	s.Commit()
 
 	// Assume some time has passed and new record created.
 	s.Ctx = s.Ctx.WithBlockTime(tPlus10sp5Record.Time)
@@ -232,6 +477,8 @@ func (s *TestSuite) TestAfterEpochEnd() {
 	// we reverse iterate here to test epochs that are not prune epoch
 	for i := len(allEpochs) - 1; i >= 0; i-- {
 		s.App.TwapKeeper.EpochHooks().AfterEpochEnd(s.Ctx, allEpochs[i].Identifier, int64(1))
+		// the records are pruned by the EndBlock of the block the epoch ends in
+		s.twapkeeper.EndBlock(s.Ctx)
 
 		recordsAfterEpoch, err := s.twapkeeper.GetAllHistoricalTimeIndexedTWAPs(s.Ctx)
 
@@ -1,10 +1,12 @@
 package twap
 
 import (
-	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/osmosis-labs/osmosis/v13/osmomath"
@@ -14,11 +16,7 @@ import (
 // geometricTwapMathBase is the base used for geometric twap calculation
 // in logarithm and power math functions.
 // See twapLog and computeGeometricTwap functions for more details.
-var (
-	geometricTwapMathBase = sdk.NewDec(2)
-	// TODO: analyze choice.
-	geometricTwapPowPrecision = sdk.MustNewDecFromStr("0.00000001")
-)
+var geometricTwapMathBase = types.GeometricTwapMathBase
 
 func newTwapRecord(k types.AmmInterface, ctx sdk.Context, poolId uint64, denom0, denom1 string) (types.TwapRecord, error) {
 	denom0, denom1, err := types.LexicographicalOrderDenoms(denom0, denom1)
@@ -26,7 +24,7 @@ func newTwapRecord(k types.AmmInterface, ctx sdk.Context, poolId uint64, denom0,
 		return types.TwapRecord{}, err
 	}
 	previousErrorTime := time.Time{} // no previous error
-	sp0, sp1, lastErrorTime := getSpotPrices(ctx, k, poolId, denom0, denom1, previousErrorTime)
+	sp0, sp1, lastErrorTime := fetchAndSanitizeSpotPrices(ctx, k, poolId, denom0, denom1, previousErrorTime)
 	return types.TwapRecord{
 		PoolId:                      poolId,
 		Asset0Denom:                 denom0,
@@ -39,15 +37,21 @@ func newTwapRecord(k types.AmmInterface, ctx sdk.Context, poolId uint64, denom0,
 		P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
 		GeometricTwapAccumulator:    sdk.ZeroDec(),
 		LastErrorTime:               lastErrorTime,
+		SchemaVersion:               types.CurrentRecordSchemaVersion,
 	}, nil
 }
 
-// getSpotPrices gets the spot prices for the pool,
+// fetchAndSanitizeSpotPrices gets the spot prices for the pool, and sanitizes them for storing in a record.
+// It is the single place where records get their spot prices from, so that new records and updated records
+// are guaranteed to have identical clamping, zeroing and error time behavior.
 // input: ctx, amm interface, pool id, asset denoms, previous error time
 // returns spot prices for both pairs of assets, and the 'latest error time'.
 // The latest error time is the previous time if there is no error in getting spot prices.
-// if there is an error in getting spot prices, then the latest error time is ctx.Blocktime()
-func getSpotPrices(
+// if there is an error in getting spot prices, then the latest error time is ctx.Blocktime(),
+// and the spot prices that failed to be fetched are set to zero.
+// if a spot price is greater than types.MaxSpotPrice, it is clamped to types.MaxSpotPrice,
+// and the latest error time is ctx.BlockTime().
+func fetchAndSanitizeSpotPrices(
 	ctx sdk.Context,
 	k types.AmmInterface,
 	poolId uint64,
@@ -100,23 +104,116 @@ func (k Keeper) afterCreatePool(ctx sdk.Context, poolId uint64) error {
 	return err
 }
 
+// EndBlock updates the records of the pools changed in this block, in ascending pool id order.
+// If the params set an EndBlockGasBudget, it stops updating pools once the gas it used reaches the budget,
+// and defers the remaining pools to the next block, whose EndBlock updates them before the pools changed in
+// that block. At least one pool is updated per block, so that the deferred pools are eventually updated.
+// A deferred pool has no record for the blocks it was deferred in, so its TWAPs over them interpolate from
+// its previous record, as for a pool that didn't change.
+// If the params set a SpotDeviationAlertThreshold, an alert event is emitted for the updated pools whose new spot
+// prices deviate from their TWAPs over the SpotDeviationAlertWindow by more than it, see emitSpotDeviationAlerts.
+// Last, the spot prices of the pools of the MedianTrackedPools param are sampled, see sampleSpotPrices. The samples
+// are not subject to the gas budget.
 func (k Keeper) EndBlock(ctx sdk.Context) {
-	// get changed pools grabs all altered pool ids from the transient store.
-	// 'altered pool ids' gets automatically cleared on commit by being a transient store
-	changedPoolIds := k.getChangedPools(ctx)
-	for _, id := range changedPoolIds {
+	startGas := ctx.GasMeter().GasConsumed()
+	params := k.GetParams(ctx)
+	budget := params.EndBlockGasBudget
+	poolIds := k.getPoolsToUpdate(ctx)
+	for i, id := range poolIds {
+		if budget != 0 && i > 0 && ctx.GasMeter().GasConsumed()-startGas >= budget {
+			for _, deferredId := range poolIds[i:] {
+				k.deferPool(ctx, deferredId)
+			}
+			telemetry.IncrCounter(float32(len(poolIds)-i), types.ModuleName, types.MetricKeyEndBlock, types.MetricKeyDeferredPools)
+			ctx.Logger().Info(fmt.Sprintf("TWAP end block reached its gas budget of %d, deferring the records"+
+				" of %d pools to the next block", budget, len(poolIds)-i))
+			break
+		}
+		k.deleteDeferredPool(ctx, id)
 		err := k.updateRecords(ctx, id)
 		if err != nil {
 			ctx.Logger().Error(fmt.Errorf(
 				"error in TWAP end block, for updating records for pool id %d."+
 					" Skipping record update. Underlying err: %w", id, err).Error())
+			continue
+		}
+		if params.SpotDeviationAlertThreshold.IsPositive() {
+			k.emitSpotDeviationAlerts(ctx, id, params.SpotDeviationAlertThreshold, params.SpotDeviationAlertWindow)
+		}
+	}
+	for _, id := range params.MedianTrackedPools {
+		k.sampleSpotPrices(ctx, id)
+	}
+	if err := k.pruneRecordsInBlock(ctx, params.MaxPrunedRecordsPerBlock); err != nil {
+		ctx.Logger().Error(fmt.Errorf("error in TWAP end block, for pruning records: %w", err).Error())
+	}
+	telemetry.SetGauge(float32(ctx.GasMeter().GasConsumed()-startGas), types.ModuleName, types.MetricKeyEndBlock, types.MetricKeyGasUsed)
+	k.flushArchive(ctx)
+}
+
+// emitSpotDeviationAlerts emits an alert event for each denom pair of pool poolId whose spot price deviates from
+// its arithmetic TWAP over window by more than threshold, as computed by SpotDeviationFromTwap. The deviation of a
+// pair is the largest of its deviations quoted in either asset, so that a fall of the price of asset 0 is flagged
+// as readily as a rise. The pairs whose deviation can't be computed, e.g. when the pool is younger than the window,
+// when the window overlaps a tracking gap, or when a spot price errored over it, are skipped.
+func (k Keeper) emitSpotDeviationAlerts(ctx sdk.Context, poolId uint64, threshold sdk.Dec, window time.Duration) {
+	records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return
+	}
+	for _, record := range records {
+		deviation0, err := k.SpotDeviationFromTwap(ctx, poolId, record.Asset1Denom, record.Asset0Denom, window)
+		if err != nil {
+			continue
+		}
+		deviation1, err := k.SpotDeviationFromTwap(ctx, poolId, record.Asset0Denom, record.Asset1Denom, window)
+		if err != nil {
+			continue
+		}
+		deviation := sdk.MaxDec(deviation0, deviation1)
+		if deviation.LTE(threshold) {
+			continue
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.TypeEvtSpotDeviationAlert,
+			sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(poolId, 10)),
+			sdk.NewAttribute(types.AttributeDenom0, record.Asset0Denom),
+			sdk.NewAttribute(types.AttributeDenom1, record.Asset1Denom),
+			sdk.NewAttribute(types.AttributeDeviation, deviation.String()),
+			sdk.NewAttribute(types.AttributeThreshold, threshold.String()),
+		))
+	}
+}
+
+// getPoolsToUpdate returns the pools deferred by previous blocks, followed by the pools changed in this block
+// that weren't deferred, each in ascending order.
+func (k Keeper) getPoolsToUpdate(ctx sdk.Context) []uint64 {
+	// get changed pools grabs all altered pool ids from the transient store.
+	// 'altered pool ids' gets automatically cleared on commit by being a transient store
+	changedPoolIds := k.getChangedPools(ctx)
+	sort.Slice(changedPoolIds, func(i, j int) bool { return changedPoolIds[i] < changedPoolIds[j] })
+
+	poolIds := k.getDeferredPools(ctx)
+	deferred := make(map[uint64]bool, len(poolIds))
+	for _, id := range poolIds {
+		deferred[id] = true
+	}
+	for _, id := range changedPoolIds {
+		if !deferred[id] {
+			poolIds = append(poolIds, id)
 		}
 	}
+	return poolIds
 }
 
 // updateRecords updates all records for a given pool id.
 // it does so by creating new records for all asset pairs
 // with updated spot prices and spot price errors, if any.
+// If the denoms of the pool no longer match the denoms of its records, the pool is
+// quarantined instead: its records are left as they are, and are neither updated nor
+// queryable until RepairQuarantinedPool is called. Quarantined pools are skipped.
+// Once all the new records are stored, an event is emitted for each of them, and the hooks are notified of them, see
+// SetHooks.
 // Returns nil on success.
 // Returns error if:
 //   - fails to get previous records.
@@ -124,6 +221,10 @@ func (k Keeper) EndBlock(ctx sdk.Context) {
 //   - the number of records does not match expected relative to the
 //     number of denoms in the pool.
 func (k Keeper) updateRecords(ctx sdk.Context, poolId uint64) error {
+	if k.isPoolQuarantined(ctx, poolId) {
+		return nil
+	}
+
 	// Will only err if pool doesn't have most recent entry set
 	records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
 	if err != nil {
@@ -135,6 +236,12 @@ func (k Keeper) updateRecords(ctx sdk.Context, poolId uint64) error {
 		return err
 	}
 
+	storedDenoms, poolDenoms := recordDenoms(records), sortedDenoms(denoms)
+	if !denomsEqual(storedDenoms, poolDenoms) {
+		k.quarantinePool(ctx, poolId, storedDenoms, poolDenoms)
+		return nil
+	}
+
 	// given # of denoms in the pool namely, that for `k` denoms in pool,
 	// there should be k * (k - 1) / 2 records
 	denomNum := len(denoms)
@@ -144,132 +251,301 @@ func (k Keeper) updateRecords(ctx sdk.Context, poolId uint64) error {
 		return types.InvalidRecordCountError{Expected: expectedRecordsLength, Actual: len(records)}
 	}
 
+	// all the records are updated before any is stored, so that a failing update leaves the pool's records as they were
+	newRecords := make([]types.TwapRecord, 0, len(records))
 	for _, record := range records {
-		newRecord := k.updateRecord(ctx, record)
+		newRecords = append(newRecords, k.updateRecord(ctx, record))
+	}
+	spotPriceErrors := 0
+	for _, newRecord := range newRecords {
 		k.storeNewRecord(ctx, newRecord)
+		if !newRecord.LastErrorTime.Before(newRecord.Time) {
+			spotPriceErrors++
+		}
+	}
+	telemetry.IncrCounter(float32(len(newRecords)), types.ModuleName, types.MetricKeyEndBlock, types.MetricKeyRecordsWritten)
+	if spotPriceErrors > 0 {
+		telemetry.IncrCounter(float32(spotPriceErrors), types.ModuleName, types.MetricKeyEndBlock, types.MetricKeySpotPriceErrors)
+	}
+	for _, newRecord := range newRecords {
+		types.EmitTwapRecordUpdateEvent(ctx, newRecord)
+		k.afterTwapRecordUpdate(ctx, newRecord)
 	}
 	return nil
 }
 
 // updateRecord returns a new record with updated accumulators and block time
 // for the current block time.
+//
+// If the block time is at or before the record's time, the record is refreshed in place at its own time
+// instead: its accumulators are kept, and its spot prices and height are those of the current block.
+// This happens at an upgrade that lowers the block time resolution (e.g. to milliseconds), where the first
+// block after the upgrade can be truncated to a time before the last record written before it. A record
+// going back in time would be interleaved with the pool's existing records, and the upgrade block must not
+// fail because of it.
 func (k Keeper) updateRecord(ctx sdk.Context, record types.TwapRecord) types.TwapRecord {
-	newRecord := recordWithUpdatedAccumulators(record, ctx.BlockTime())
-	newRecord.Height = ctx.BlockHeight()
+	if ctx.BlockTime().Before(record.Time) {
+		ctx = ctx.WithBlockTime(record.Time)
+	}
+	builder := newRecordBuilder(record, ctx.BlockTime())
 
-	newSp0, newSp1, lastErrorTime := getSpotPrices(
+	newSp0, newSp1, lastErrorTime := fetchAndSanitizeSpotPrices(
 		ctx, k.ammkeeper, record.PoolId, record.Asset0Denom, record.Asset1Denom, record.LastErrorTime)
 
 	// set last spot price to be last price of this block. This is what will get used in interpolation.
-	newRecord.P0LastSpotPrice = newSp0
-	newRecord.P1LastSpotPrice = newSp1
-	newRecord.LastErrorTime = lastErrorTime
-
-	return newRecord
+	return builder.recordWithSpotPrices(ctx.BlockHeight(), newSp0, newSp1, lastErrorTime)
 }
 
-// pruneRecords prunes twap records that happened earlier than recordHistoryKeepPeriod
+// pruneRecords starts pruning twap records that happened earlier than recordHistoryKeepPeriod
 // before current block time while preserving the most recent record before the threshold.
 // Such record is preserved for each pool.
 // See TWAP keeper's `pruneRecordsBeforeTimeButNewest(...)` for more details about the reasons for
 // keeping this record.
+// The records are pruned by the EndBlocks from this block on, at most MaxPrunedRecordsPerBlock per block, see
+// pruneRecordsInBlock. A pruning still in progress is restarted with the new threshold.
+// The spot price samples taken earlier than recordHistoryKeepPeriod are pruned right away.
 func (k Keeper) pruneRecords(ctx sdk.Context) error {
 	recordHistoryKeepPeriod := k.RecordHistoryKeepPeriod(ctx)
 
 	lastKeptTime := ctx.BlockTime().Add(-recordHistoryKeepPeriod)
-	return k.pruneRecordsBeforeTimeButNewest(ctx, lastKeptTime)
+	k.setPruningState(ctx, types.PruningState{LastKeptTime: lastKeptTime})
+	return k.pruneSpotPriceSamplesBeforeTime(ctx, lastKeptTime)
+}
+
+// pruneRecordsInBlock prunes at most limit records of the pruning in progress, zero meaning no limit, and stores
+// where to resume it from in the next block, until it has gone over all the records before its threshold.
+func (k Keeper) pruneRecordsInBlock(ctx sdk.Context, limit uint64) error {
+	state, found, err := k.getPruningState(ctx)
+	if err != nil || !found {
+		return err
+	}
+	nextState, err := k.pruneRecordsBeforeTimeButNewest(ctx, state, limit)
+	if err != nil {
+		return err
+	}
+	if nextState == nil {
+		k.deletePruningState(ctx)
+	} else {
+		k.setPruningState(ctx, *nextState)
+	}
+	return nil
 }
 
 // recordWithUpdatedAccumulators returns a record, with updated accumulator values and time for provided newTime,
 // otherwise referred to as "interpolating the record" to the target time.
 // This does not mutate the passed in record.
 //
-// pre-condition: newTime >= record.Time
+// If newTime is at or before record.Time, the record is returned unchanged: a negative time delta would decrease
+// the accumulators, corrupting every TWAP computed from the record afterwards. Callers that can't use the record
+// at its own time must check for it themselves, see getMostRecentRecord.
 func recordWithUpdatedAccumulators(record types.TwapRecord, newTime time.Time) types.TwapRecord {
-	// return the given record: no need to calculate and update the accumulator if record time matches.
-	if record.Time.Equal(newTime) {
-		return record
+	return newRecordBuilder(record, newTime).record()
+}
+
+// RecordWithUpdatedAccumulators returns the record interpolated to newTime, see recordWithUpdatedAccumulators.
+// It is exported for generating record histories with the module's accumulator math, e.g. in x/twap/testutil.
+func RecordWithUpdatedAccumulators(record types.TwapRecord, newTime time.Time) types.TwapRecord {
+	return recordWithUpdatedAccumulators(record, newTime)
+}
+
+// recordBuilder updates a record to a new time. It computes the growth of every accumulator from an immutable
+// snapshot of the record first, and only then produces the updated record, so that a step failing midway
+// (e.g. the logarithm of a zero spot price) can never produce a record with some accumulators advanced and
+// others not.
+type recordBuilder struct {
+	snapshot types.TwapRecord
+	newTime  time.Time
+	growth   accumulatorGrowth
+}
+
+// accumulatorGrowth is how much each accumulator of a record grows by between two times
+type accumulatorGrowth struct {
+	p0Arithmetic sdk.Dec
+	p1Arithmetic sdk.Dec
+	geometric    sdk.Dec
+}
+
+// newRecordBuilder computes the growth of the accumulators of snapshot until newTime.
+// If newTime is at or before snapshot's time, the accumulators don't grow.
+func newRecordBuilder(snapshot types.TwapRecord, newTime time.Time) recordBuilder {
+	builder := recordBuilder{snapshot: snapshot, newTime: newTime}
+	if !newTime.After(snapshot.Time) {
+		return builder
 	}
-	newRecord := record
-	timeDelta := newTime.Sub(record.Time)
-	newRecord.Time = newTime
+	timeDelta := types.AccumulatorTimeDelta(snapshot.Time, newTime)
 
-	// record.LastSpotPrice is the last spot price from the block the record was created in,
+	// snapshot.LastSpotPrice is the last spot price from the block the record was created in,
 	// thus it is treated as the effective spot price until the new time.
 	// (As there was no change until at or after this time)
-	p0NewAccum := types.SpotPriceMulDuration(record.P0LastSpotPrice, timeDelta)
-	newRecord.P0ArithmeticTwapAccumulator = newRecord.P0ArithmeticTwapAccumulator.Add(p0NewAccum)
+	p0Arithmetic := types.SpotPriceMulDuration(snapshot.P0LastSpotPrice, timeDelta)
+	p1Arithmetic := types.SpotPriceMulDuration(snapshot.P1LastSpotPrice, timeDelta)
+	// geometric = log_{2}{P_0} * timeDelta
+	geometric := types.SpotPriceMulDuration(twapLog(snapshot.P0LastSpotPrice), timeDelta)
 
-	p1NewAccum := types.SpotPriceMulDuration(record.P1LastSpotPrice, timeDelta)
-	newRecord.P1ArithmeticTwapAccumulator = newRecord.P1ArithmeticTwapAccumulator.Add(p1NewAccum)
+	builder.growth = accumulatorGrowth{p0Arithmetic: p0Arithmetic, p1Arithmetic: p1Arithmetic, geometric: geometric}
+	return builder
+}
 
-	// logP0SpotPrice = log_{2}{P_0}
-	logP0SpotPrice := twapLog(record.P0LastSpotPrice)
-	// p0NewGeomAccum = log_{2}{P_0} * timeDelta
-	p0NewGeomAccum := types.SpotPriceMulDuration(logP0SpotPrice, timeDelta)
-	newRecord.GeometricTwapAccumulator = newRecord.GeometricTwapAccumulator.Add(p0NewGeomAccum)
+// record returns the snapshot at the new time, with all its accumulators grown.
+// If the new time is at or before the snapshot's time, the snapshot is returned as is.
+// This does not mutate the snapshot.
+func (b recordBuilder) record() types.TwapRecord {
+	newRecord := b.snapshot
+	// return the snapshot: no need to update the accumulators if the time matches,
+	// and they must never go back in time.
+	if !b.newTime.After(b.snapshot.Time) {
+		return newRecord
+	}
+	newRecord.Time = b.newTime
+	newRecord.P0ArithmeticTwapAccumulator = b.snapshot.P0ArithmeticTwapAccumulator.Add(b.growth.p0Arithmetic)
+	newRecord.P1ArithmeticTwapAccumulator = b.snapshot.P1ArithmeticTwapAccumulator.Add(b.growth.p1Arithmetic)
+	newRecord.GeometricTwapAccumulator = b.snapshot.GeometricTwapAccumulator.Add(b.growth.geometric)
+	return newRecord
+}
 
+// recordWithSpotPrices returns the record at the new time, as written at the given height with the given spot
+// prices and last error time, in the current schema.
+func (b recordBuilder) recordWithSpotPrices(height int64, sp0, sp1 sdk.Dec, lastErrorTime time.Time) types.TwapRecord {
+	newRecord := b.record()
+	newRecord.Height = height
+	newRecord.P0LastSpotPrice = sp0
+	newRecord.P1LastSpotPrice = sp1
+	newRecord.LastErrorTime = lastErrorTime
+	newRecord.SchemaVersion = types.CurrentRecordSchemaVersion
 	return newRecord
 }
 
 // getInterpolatedRecord returns a record for this pool, representing its accumulator state at time `t`.
 // This is achieved by getting the record `r` that is at, or immediately preceding in state time `t`.
 // To be clear: the record r s.t. `t - r.Time` is minimized AND `t >= r.Time`
-// If for the record obtained, r.Time == r.LastErrorTime, this will also hold for the interpolated record.
+// r can be arbitrarily older than t, e.g. if the pool had no swaps for days. Its last spot prices
+// are then extended all the way to t, for both the arithmetic and geometric accumulators,
+// so a TWAP over a window after r.Time equals r's last spot price.
+// If for the record obtained, r.Time <= r.LastErrorTime, the interpolated record has LastErrorTime == t.
+// See interpolatedLastErrorTime for the exact semantics.
 func (k Keeper) getInterpolatedRecord(ctx sdk.Context, poolId uint64, t time.Time, assetA, assetB string) (types.TwapRecord, error) {
-	record, err := k.getRecordAtOrBeforeTime(ctx, poolId, t, assetA, assetB)
+	record, _, err := k.getInterpolatedRecordWithProvenance(ctx, poolId, t, assetA, assetB)
+	return record, err
+}
+
+// getInterpolatedRecordWithProvenance is getInterpolatedRecord, also returning the time of the record it was
+// interpolated from. The record was synthesized, rather than written at time t, if that time isn't t.
+// Query handlers use it to tell clients which records are not persisted observations.
+func (k Keeper) getInterpolatedRecordWithProvenance(ctx sdk.Context, poolId uint64, t time.Time, assetA, assetB string) (record types.TwapRecord, sourceTime time.Time, err error) {
+	record, err = k.getRecordAtOrBeforeTime(ctx, poolId, t, assetA, assetB)
 	if err != nil {
-		return types.TwapRecord{}, err
-	}
-	// if it had errored on the last record, make this record inherit the error
-	if record.Time.Equal(record.LastErrorTime) {
-		record.LastErrorTime = t
+		return types.TwapRecord{}, time.Time{}, err
 	}
+	sourceTime = record.Time
+	record.LastErrorTime = interpolatedLastErrorTime(record, t)
 	record = recordWithUpdatedAccumulators(record, t)
-	return record, nil
+	return record, sourceTime, nil
 }
 
 func (k Keeper) getMostRecentRecord(ctx sdk.Context, poolId uint64, assetA, assetB string) (types.TwapRecord, error) {
-	record, err := k.getMostRecentRecordStoreRepresentation(ctx, poolId, assetA, assetB)
+	record, _, err := k.getMostRecentRecordWithProvenance(ctx, poolId, assetA, assetB)
+	return record, err
+}
+
+// getMostRecentRecordWithProvenance is getMostRecentRecord, also returning the time of the most recent record it was
+// interpolated from.
+func (k Keeper) getMostRecentRecordWithProvenance(ctx sdk.Context, poolId uint64, assetA, assetB string) (record types.TwapRecord, sourceTime time.Time, err error) {
+	if k.isPoolQuarantined(ctx, poolId) {
+		return types.TwapRecord{}, time.Time{}, types.PoolQuarantinedError{PoolId: poolId}
+	}
+	record, err = k.getMostRecentRecordStoreRepresentation(ctx, poolId, assetA, assetB)
 	if err != nil {
-		return types.TwapRecord{}, err
+		return types.TwapRecord{}, time.Time{}, err
 	}
+	// The most recent record can be after the block time in the block following an upgrade that truncates the
+	// block time, see updateRecord. Returning it at its own time would be returning a record from the future.
+	if record.Time.After(ctx.BlockTime()) {
+		return types.TwapRecord{}, time.Time{}, types.RecordAfterTargetTimeError{PoolId: poolId, RecordTime: record.Time, TargetTime: ctx.BlockTime()}
+	}
+	sourceTime = record.Time
+	record.LastErrorTime = interpolatedLastErrorTime(record, ctx.BlockTime())
 	record = recordWithUpdatedAccumulators(record, ctx.BlockTime())
-	return record, nil
+	return record, sourceTime, nil
+}
+
+// isSpotPriceErrorActive returns true if the pool's spot price errored when the record was written, in which case
+// the record's spot prices may be faulty.
+func isSpotPriceErrorActive(record types.TwapRecord) bool {
+	return record.LastErrorTime.Equal(record.Time) || hasErrorTimeAfterRecordTime(record)
+}
+
+// hasErrorTimeAfterRecordTime returns true if the record's LastErrorTime is after its Time.
+// The state machine never writes such a record, but one can end up in state through genesis import.
+// Since we cannot tell when the error really happened, any window touching the record is treated as erroring.
+func hasErrorTimeAfterRecordTime(record types.TwapRecord) bool {
+	return record.LastErrorTime.After(record.Time)
+}
+
+// interpolatedLastErrorTime returns the LastErrorTime of record once interpolated to time t >= record.Time.
+// If the record's spot price errored at its own time (record.LastErrorTime == record.Time),
+// that faulty spot price is extended all the way to t, so the interpolated record errors at t.
+// The same applies if hasErrorTimeAfterRecordTime, including record.LastErrorTime == t.
+// Otherwise the error predates the record, and the LastErrorTime is kept as is.
+//
+// Together with computeTwap, this means an error at time e is reported for a window [start, end]
+// iff start <= e <= end, or e precedes start but the faulty spot price was still in effect at start.
+func interpolatedLastErrorTime(record types.TwapRecord, t time.Time) time.Time {
+	if record.LastErrorTime.Before(record.Time) {
+		return record.LastErrorTime
+	}
+	return t
 }
 
 // computeTwap computes and returns a TWAP of a given
-// type - arithmetic or geometric.
+// type - arithmetic, geometric or harmonic.
 // Between two records given the quote asset.
 // precondition: endRecord.Time >= startRecord.Time
 // if (endRecord.LastErrorTime >= startRecord.Time) returns an error at end + result
-// if (startRecord.LastErrorTime == startRecord.Time) returns an error at end + result
-// if (endRecord.Time == startRecord.Time) returns endRecord.LastSpotPrice
+// if (startRecord.LastErrorTime >= startRecord.Time) returns an error at end + result
+// Both bounds of the window are inclusive: an error exactly at the start or end time is reported.
+// if (endRecord.Time == startRecord.Time), up to the millisecond, returns endRecord.LastSpotPrice, for all TWAP types
 // else returns
 // (endRecord.Accumulator - startRecord.Accumulator) / (endRecord.Time - startRecord.Time)
-func computeTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string, isArithmeticTwap twapType) (sdk.Dec, error) {
+func computeTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string, typ twapType) (sdk.Dec, error) {
 	// see if we need to return an error, due to spot price issues
 	var err error = nil
-	if endRecord.LastErrorTime.After(startRecord.Time) ||
-		endRecord.LastErrorTime.Equal(startRecord.Time) ||
-		startRecord.LastErrorTime.Equal(startRecord.Time) {
-		err = errors.New("twap: error in pool spot price occurred between start and end time, twap result may be faulty")
+	if !endRecord.LastErrorTime.Before(startRecord.Time) ||
+		!startRecord.LastErrorTime.Before(startRecord.Time) {
+		err = types.ErrSpotPriceErrorInWindow
 	}
-	timeDelta := endRecord.Time.Sub(startRecord.Time)
-	// if time difference is 0, then return the last spot price based off of start.
+	timeDelta := types.AccumulatorTimeDelta(startRecord.Time, endRecord.Time)
+	// if time difference is 0 (the records are in the same millisecond),
+	// then return the last spot price based off of start.
 	if timeDelta == time.Duration(0) {
-		if quoteAsset == startRecord.Asset0Denom {
-			return endRecord.P0LastSpotPrice, err
-		}
-		return endRecord.P1LastSpotPrice, err
+		return lastSpotPriceForQuoteAsset(endRecord, startRecord.Asset0Denom, quoteAsset), err
 	}
 
-	if isArithmeticTwap {
+	switch typ {
+	case arithmeticTwapType:
 		return computeArithmeticTwap(startRecord, endRecord, quoteAsset), err
+	case harmonicTwapType:
+		twap, harmonicErr := computeHarmonicTwap(startRecord, endRecord, quoteAsset)
+		if harmonicErr != nil {
+			return sdk.Dec{}, harmonicErr
+		}
+		return twap, err
 	}
 	return computeGeometricTwap(startRecord, endRecord, quoteAsset), err
 }
 
+// lastSpotPriceForQuoteAsset returns the last spot price stored in record for quoteAsset,
+// where asset0Denom decides which of the two stored prices that is.
+// P0LastSpotPrice and P1LastSpotPrice are stored separately and needn't be exact reciprocals
+// (e.g. due to rounding), so the price for asset 1 is always P1LastSpotPrice and never
+// derived by inverting P0LastSpotPrice. This holds for arithmetic and geometric TWAPs alike,
+// so both return the same price for a zero-length window.
+func lastSpotPriceForQuoteAsset(record types.TwapRecord, asset0Denom string, quoteAsset string) sdk.Dec {
+	if quoteAsset == asset0Denom {
+		return record.P0LastSpotPrice
+	}
+	return record.P1LastSpotPrice
+}
+
 // computeArithmeticTwap computes and returns an arithmetic TWAP between
 // two records given the quote asset.
 func computeArithmeticTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) sdk.Dec {
@@ -279,7 +555,7 @@ func computeArithmeticTwap(startRecord types.TwapRecord, endRecord types.TwapRec
 	} else {
 		accumDiff = endRecord.P1ArithmeticTwapAccumulator.Sub(startRecord.P1ArithmeticTwapAccumulator)
 	}
-	timeDelta := endRecord.Time.Sub(startRecord.Time)
+	timeDelta := types.AccumulatorTimeDelta(startRecord.Time, endRecord.Time)
 	return types.AccumDiffDivDuration(accumDiff, timeDelta)
 }
 
@@ -292,7 +568,7 @@ func computeArithmeticTwap(startRecord types.TwapRecord, endRecord types.TwapRec
 func computeGeometricTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) sdk.Dec {
 	accumDiff := endRecord.GeometricTwapAccumulator.Sub(startRecord.GeometricTwapAccumulator)
 
-	timeDelta := endRecord.Time.Sub(startRecord.Time)
+	timeDelta := types.AccumulatorTimeDelta(startRecord.Time, endRecord.Time)
 	arithmeticMeanOfLogPrices := types.AccumDiffDivDuration(accumDiff, timeDelta)
 
 	geometricMeanDenom0 := twapPow(arithmeticMeanOfLogPrices)
@@ -304,6 +580,30 @@ func computeGeometricTwap(startRecord types.TwapRecord, endRecord types.TwapReco
 	return geometricMeanDenom0
 }
 
+// computeHarmonicTwap computes and returns a harmonic TWAP between
+// two records given the quote asset.
+// The harmonic mean of the spot prices is the reciprocal of the arithmetic mean of their reciprocals,
+// which are the spot prices of the other asset of the pair, so it is the reciprocal of the
+// arithmetic TWAP quoted in the other asset.
+// It returns a ZeroArithmeticTwapError if that arithmetic TWAP is zero, i.e. the accumulator of the other asset
+// didn't change over the window, as the harmonic TWAP has no value then.
+func computeHarmonicTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) (sdk.Dec, error) {
+	otherAsset := startRecord.Asset0Denom
+	if quoteAsset == startRecord.Asset0Denom {
+		otherAsset = startRecord.Asset1Denom
+	}
+	arithmeticTwap := computeArithmeticTwap(startRecord, endRecord, otherAsset)
+	if arithmeticTwap.IsZero() {
+		return sdk.Dec{}, types.ZeroArithmeticTwapError{
+			PoolId:     startRecord.PoolId,
+			QuoteAsset: quoteAsset,
+			StartTime:  startRecord.Time,
+			EndTime:    endRecord.Time,
+		}
+	}
+	return sdk.OneDec().Quo(arithmeticTwap), nil
+}
+
 // twapLog returns the logarithm of the given spot price, base 2.
 // TODO: basic test
 func twapLog(price sdk.Dec) sdk.Dec {
@@ -313,5 +613,5 @@ func twapLog(price sdk.Dec) sdk.Dec {
 // twapPow exponentiates the geometricTwapMathBase to the given exponent.
 // TODO: basic test and benchmark.
 func twapPow(exponent sdk.Dec) sdk.Dec {
-	return osmomath.PowApprox(geometricTwapMathBase, exponent, geometricTwapPowPrecision)
+	return osmomath.PowApprox(geometricTwapMathBase, exponent, types.GeometricTwapPowPrecision)
 }
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -31,7 +33,7 @@ var (
 	geometricTenSecAccum = OneSec.Mul(logTen)
 )
 
-func (s *TestSuite) TestGetSpotPrices() {
+func (s *TestSuite) TestFetchAndSanitizeSpotPrices() {
 	currTime := time.Now()
 	poolID := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
 	mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
@@ -85,7 +87,7 @@ func (s *TestSuite) TestGetSpotPrices() {
 			mockAMMI.ProgramPoolSpotPriceOverride(tc.poolID, denom0, denom1, tc.mockSp0, tc.mockSp0Err)
 			mockAMMI.ProgramPoolSpotPriceOverride(tc.poolID, denom1, denom0, tc.mockSp1, tc.mockSp1Err)
 
-			sp0, sp1, latestErrTime := twap.GetSpotPrices(ctx, mockAMMI, tc.poolID, denom0, denom1, tc.prevErrTime)
+			sp0, sp1, latestErrTime := twap.FetchAndSanitizeSpotPrices(ctx, mockAMMI, tc.poolID, denom0, denom1, tc.prevErrTime)
 			s.Require().Equal(tc.expectedSp0, sp0)
 			s.Require().Equal(tc.expectedSp1, sp1)
 			s.Require().Equal(tc.expectedLatestErrTime, latestErrTime)
@@ -122,7 +124,7 @@ func (s *TestSuite) TestNewTwapRecord() {
 			poolId,
 			denom0,
 			denom0,
-			fmt.Errorf("both assets cannot be of the same denom: assetA: %s, assetB: %s", denom0, denom0),
+			types.SameDenomError{Denom: denom0},
 			false,
 		},
 		"error in getting spot price": {
@@ -140,8 +142,7 @@ func (s *TestSuite) TestNewTwapRecord() {
 			if test.expectedPanic {
 				s.Require().Equal(twapRecord.LastErrorTime, s.Ctx.BlockTime())
 			} else if test.expectedErr != nil {
-				s.Require().Error(err)
-				s.Require().Equal(test.expectedErr.Error(), err.Error())
+				s.Require().ErrorIs(err, test.expectedErr)
 			} else {
 				s.Require().NoError(err)
 
@@ -151,7 +152,78 @@ func (s *TestSuite) TestNewTwapRecord() {
 				s.Require().Equal(sdk.ZeroDec(), twapRecord.P0ArithmeticTwapAccumulator)
 				s.Require().Equal(sdk.ZeroDec(), twapRecord.P1ArithmeticTwapAccumulator)
 				s.Require().Equal(sdk.ZeroDec(), twapRecord.GeometricTwapAccumulator)
+				s.Require().Equal(types.CurrentRecordSchemaVersion, twapRecord.SchemaVersion)
+			}
+		})
+	}
+}
+
+// TestNewTwapRecord_SpotPriceSanitization tests that initial records get the same spot price
+// sanitization as updated records, since both go through fetchAndSanitizeSpotPrices.
+func (s *TestSuite) TestNewTwapRecord_SpotPriceSanitization() {
+	tests := map[string]struct {
+		spotPriceResult0 twapmock.SpotPriceResult
+		spotPriceResult1 twapmock.SpotPriceResult
+		expectedSp0      sdk.Dec
+		expectedSp1      sdk.Dec
+		expectErrTime    bool
+	}{
+		"sp0 exceeds max spot price": {
+			spotPriceResult0: twapmock.SpotPriceResult{Sp: types.MaxSpotPrice.Add(sdk.OneDec())},
+			spotPriceResult1: twapmock.SpotPriceResult{Sp: sdk.OneDec()},
+			expectedSp0:      types.MaxSpotPrice,
+			expectedSp1:      sdk.OneDec(),
+			expectErrTime:    true,
+		},
+		"sp1 exceeds max spot price": {
+			spotPriceResult0: twapmock.SpotPriceResult{Sp: sdk.OneDec()},
+			spotPriceResult1: twapmock.SpotPriceResult{Sp: types.MaxSpotPrice.Add(sdk.OneDec())},
+			expectedSp0:      sdk.OneDec(),
+			expectedSp1:      types.MaxSpotPrice,
+			expectErrTime:    true,
+		},
+		"sp0 err with nil dec": {
+			spotPriceResult0: twapmock.SpotPriceResult{Sp: sdk.Dec{}, Err: errors.New("dummy err")},
+			spotPriceResult1: twapmock.SpotPriceResult{Sp: sdk.OneDec()},
+			expectedSp0:      sdk.ZeroDec(),
+			expectedSp1:      sdk.OneDec(),
+			expectErrTime:    true,
+		},
+		"valid spot prices": {
+			spotPriceResult0: twapmock.SpotPriceResult{Sp: sdk.NewDecWithPrec(55, 2)},
+			spotPriceResult1: twapmock.SpotPriceResult{Sp: sdk.NewDecWithPrec(6, 1)},
+			expectedSp0:      sdk.NewDecWithPrec(55, 2),
+			expectedSp1:      sdk.NewDecWithPrec(6, 1),
+		},
+	}
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+			mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
+			s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom0, denom1, test.spotPriceResult0.Sp, test.spotPriceResult0.Err)
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom1, denom0, test.spotPriceResult1.Sp, test.spotPriceResult1.Err)
+
+			expectedErrTime := time.Time{}
+			if test.expectErrTime {
+				expectedErrTime = s.Ctx.BlockTime()
 			}
+
+			// the initial record, as created on pool creation
+			err := s.twapkeeper.AfterCreatePool(s.Ctx, poolId)
+			s.Require().NoError(err)
+			initialRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+			s.Require().NoError(err)
+			s.Require().Equal(test.expectedSp0, initialRecord.P0LastSpotPrice)
+			s.Require().Equal(test.expectedSp1, initialRecord.P1LastSpotPrice)
+			s.Require().Equal(expectedErrTime, initialRecord.LastErrorTime)
+
+			// updating a record with the same spot prices must sanitize them identically
+			updatedRecord := s.twapkeeper.UpdateRecord(s.Ctx, initialRecord)
+			s.Require().Equal(initialRecord.P0LastSpotPrice, updatedRecord.P0LastSpotPrice)
+			s.Require().Equal(initialRecord.P1LastSpotPrice, updatedRecord.P1LastSpotPrice)
+			s.Require().Equal(initialRecord.LastErrorTime, updatedRecord.LastErrorTime)
 		})
 	}
 }
@@ -228,6 +300,7 @@ func (s *TestSuite) TestUpdateRecord() {
 			}
 			test.expRecord.Height = s.Ctx.BlockHeight()
 			test.expRecord.Time = s.Ctx.BlockTime()
+			test.expRecord.SchemaVersion = types.CurrentRecordSchemaVersion
 
 			programmableAmmInterface.ProgramPoolSpotPriceOverride(poolId,
 				defaultTwoAssetCoins[0].Denom, defaultTwoAssetCoins[1].Denom,
@@ -242,6 +315,83 @@ func (s *TestSuite) TestUpdateRecord() {
 	}
 }
 
+// requireAccumulatorsGrown asserts that every accumulator of updated is the accumulator of snapshot grown by the
+// snapshot's spot prices until updated's time
+func (s *TestSuite) requireAccumulatorsGrown(snapshot, updated types.TwapRecord) {
+	timeDelta := types.AccumulatorTimeDelta(snapshot.Time, updated.Time)
+	s.Require().Equal(snapshot.P0ArithmeticTwapAccumulator.Add(types.SpotPriceMulDuration(snapshot.P0LastSpotPrice, timeDelta)), updated.P0ArithmeticTwapAccumulator)
+	s.Require().Equal(snapshot.P1ArithmeticTwapAccumulator.Add(types.SpotPriceMulDuration(snapshot.P1LastSpotPrice, timeDelta)), updated.P1ArithmeticTwapAccumulator)
+	s.Require().Equal(snapshot.GeometricTwapAccumulator.Add(types.SpotPriceMulDuration(twap.TwapLog(snapshot.P0LastSpotPrice), timeDelta)), updated.GeometricTwapAccumulator)
+}
+
+// TestUpdateRecordsAccumulatorsAllOrNone tests that, for every combination of failing spot price fetches, the
+// update of a pool's records grows all the accumulators of every record, and that an update that can't compute
+// an accumulator leaves all the pool's records as they were.
+func (s *TestSuite) TestUpdateRecordsAccumulatorsAllOrNone() {
+	pairs := []types.DenomPair{{Denom0: denom0, Denom1: denom1}, {Denom0: denom0, Denom1: denom2}, {Denom0: denom1, Denom1: denom2}}
+	// the spot price fetch of each pair, in each direction, is a step that can fail
+	numSteps := 2 * len(pairs)
+
+	for failures := 0; failures < 1<<numSteps; failures++ {
+		s.Run(fmt.Sprintf("failing steps %06b", failures), func() {
+			s.SetupTest()
+			poolId := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+			mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
+			s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+			for step := 0; step < numSteps; step++ {
+				quote, base := pairs[step/2].Denom0, pairs[step/2].Denom1
+				if step%2 == 1 {
+					quote, base = base, quote
+				}
+				if failures&(1<<step) != 0 {
+					mockAMMI.ProgramPoolSpotPriceOverride(poolId, quote, base, sdk.Dec{}, errors.New("dummy err"))
+				} else {
+					mockAMMI.ProgramPoolSpotPriceOverride(poolId, quote, base, sdk.NewDec(int64(step+2)), nil)
+				}
+			}
+
+			// start from spot prices whose logarithms are not zero, so that every accumulator grows
+			snapshots, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolId)
+			s.Require().NoError(err)
+			for i := range snapshots {
+				snapshots[i].P0LastSpotPrice = twoDec
+				snapshots[i].P1LastSpotPrice = pointFiveDec
+				s.twapkeeper.StoreNewRecord(s.Ctx, snapshots[i])
+			}
+
+			updateCtx := s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Second)).WithBlockHeight(s.Ctx.BlockHeight() + 1)
+			s.Require().NoError(s.twapkeeper.UpdateRecords(updateCtx, poolId))
+			updated, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolId)
+			s.Require().NoError(err)
+			s.Require().Len(updated, len(snapshots))
+			zeroP0 := false
+			for i := range snapshots {
+				s.Require().NotEqual(snapshots[i].P0ArithmeticTwapAccumulator, updated[i].P0ArithmeticTwapAccumulator)
+				s.Require().NotEqual(snapshots[i].P1ArithmeticTwapAccumulator, updated[i].P1ArithmeticTwapAccumulator)
+				s.Require().NotEqual(snapshots[i].GeometricTwapAccumulator, updated[i].GeometricTwapAccumulator)
+				s.requireAccumulatorsGrown(snapshots[i], updated[i])
+				zeroP0 = zeroP0 || updated[i].P0LastSpotPrice.IsZero()
+			}
+
+			// the failed spot prices were stored as zero. The geometric accumulator can't grow with a zero p0 spot
+			// price, in which case the next update fails without storing any record of the pool.
+			nextCtx := updateCtx.WithBlockTime(updateCtx.BlockTime().Add(time.Second)).WithBlockHeight(updateCtx.BlockHeight() + 1)
+			osmoassert.ConditionalPanic(s.T(), zeroP0, func() {
+				s.Require().NoError(s.twapkeeper.UpdateRecords(nextCtx, poolId))
+			})
+			next, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolId)
+			s.Require().NoError(err)
+			if zeroP0 {
+				s.Require().Equal(updated, next)
+				return
+			}
+			for i := range updated {
+				s.requireAccumulatorsGrown(updated[i], next[i])
+			}
+		})
+	}
+}
+
 func TestRecordWithUpdatedAccumulators(t *testing.T) {
 	poolId := uint64(1)
 	defaultRecord := newRecord(poolId, time.Unix(1, 0), sdk.NewDec(10), oneDec, twoDec, pointFiveDec)
@@ -299,6 +449,48 @@ func TestRecordWithUpdatedAccumulators(t *testing.T) {
 	}
 }
 
+// The accumulators only grow forward in time: a record interpolated to its own time or before is returned unchanged
+func TestRecordWithUpdatedAccumulators_NewTimeAtOrBeforeRecordTime(t *testing.T) {
+	record := newRecord(1, time.Unix(10, 0), sdk.NewDec(10), oneDec, twoDec, pointFiveDec)
+	tests := map[string]struct {
+		newTime   time.Time
+		expRecord types.TwapRecord
+	}{
+		"new time before the record time": {
+			newTime:   time.Unix(9, 0),
+			expRecord: record,
+		},
+		"new time a millisecond before the record time": {
+			newTime:   record.Time.Add(-time.Millisecond),
+			expRecord: record,
+		},
+		"new time equal to the record time": {
+			newTime:   record.Time,
+			expRecord: record,
+		},
+		"new time after the record time": {
+			newTime: time.Unix(11, 0),
+			expRecord: func() types.TwapRecord {
+				expRecord := record
+				expRecord.Time = time.Unix(11, 0)
+				expRecord.P0ArithmeticTwapAccumulator = oneDec.Add(OneSec.MulInt64(10))
+				expRecord.P1ArithmeticTwapAccumulator = twoDec.Add(OneSec.QuoInt64(10))
+				expRecord.GeometricTwapAccumulator = pointFiveDec.Add(geometricTenSecAccum)
+				return expRecord
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotRecord := twap.RecordWithUpdatedAccumulators(record, test.newTime)
+			require.Equal(t, test.expRecord, gotRecord)
+			// the log of the spot price is positive, so interpolating back in time would decrease the geometric accumulator
+			require.True(t, gotRecord.GeometricTwapAccumulator.GTE(record.GeometricTwapAccumulator))
+		})
+	}
+}
+
 func TestRecordWithUpdatedAccumulators_ThreeAsset(t *testing.T) {
 	poolId := uint64(2)
 	tests := map[string]struct {
@@ -378,14 +570,41 @@ func (s *TestSuite) TestGetInterpolatedRecord() {
 			expectedAccumulator: baseRecord.P0ArithmeticTwapAccumulator.Add(sdk.NewDec(1000)),
 			expectedLastErrTime: baseTime.Add(time.Second),
 		},
+		"call at record time, record with error time after record time": {
+			recordsToPreSet:     withLastErrTime(baseRecord, baseTime.Add(time.Minute)),
+			testPoolId:          baseRecord.PoolId,
+			testDenom0:          baseRecord.Asset0Denom,
+			testDenom1:          baseRecord.Asset1Denom,
+			testTime:            baseTime,
+			expectedLastErrTime: baseTime,
+		},
+		"call 1 second after existing record, record with error time after record time": {
+			recordsToPreSet: withLastErrTime(baseRecord, baseTime.Add(time.Minute)),
+			testPoolId:      baseRecord.PoolId,
+			testDenom0:      baseRecord.Asset0Denom,
+			testDenom1:      baseRecord.Asset1Denom,
+			testTime:        baseTime.Add(time.Second),
+			// 1(spot price) * 1000(one sec in milli-seconds)
+			expectedAccumulator: baseRecord.P0ArithmeticTwapAccumulator.Add(sdk.NewDec(1000)),
+			expectedLastErrTime: baseTime.Add(time.Second),
+		},
+		"call at error time, record with error time after record time": {
+			recordsToPreSet: withLastErrTime(baseRecord, baseTime.Add(time.Second)),
+			testPoolId:      baseRecord.PoolId,
+			testDenom0:      baseRecord.Asset0Denom,
+			testDenom1:      baseRecord.Asset1Denom,
+			testTime:        baseTime.Add(time.Second),
+			// 1(spot price) * 1000(one sec in milli-seconds)
+			expectedAccumulator: baseRecord.P0ArithmeticTwapAccumulator.Add(sdk.NewDec(1000)),
+			expectedLastErrTime: baseTime.Add(time.Second),
+		},
 		"call 1 second before existing record": {
 			recordsToPreSet: baseRecord,
 			testPoolId:      baseRecord.PoolId,
 			testDenom0:      baseRecord.Asset0Denom,
 			testDenom1:      baseRecord.Asset1Denom,
 			testTime:        baseTime.Add(-time.Second),
-			expectedErr: fmt.Errorf("looking for a time thats too old, not in the historical index. "+
-				" Try storing the accumulator value. (requested time %s)", baseTime.Add(-time.Second)),
+			expectedErr:     types.TimeTooOldError{Time: baseTime.Add(-time.Second)},
 		},
 		"on lexicographical order denom parameters": {
 			recordsToPreSet: baseRecord,
@@ -410,21 +629,24 @@ func (s *TestSuite) TestGetInterpolatedRecord() {
 
 			interpolatedRecord, err := s.twapkeeper.GetInterpolatedRecord(s.Ctx, test.testPoolId, test.testDenom0, test.testDenom1, test.testTime)
 			if test.expectedErr != nil {
-				s.Require().Error(err)
-				s.Require().Equal(test.expectedErr.Error(), err.Error())
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
 				return
 			}
 			s.Require().NoError(err)
 
 			if test.testTime.Equal(baseTime) {
-				s.Require().Equal(test.recordsToPreSet, interpolatedRecord)
+				expectedRecord := test.recordsToPreSet
+				if !test.expectedLastErrTime.IsZero() {
+					expectedRecord.LastErrorTime = test.expectedLastErrTime
+				}
+				s.Require().Equal(expectedRecord, interpolatedRecord)
 			} else {
 				s.Require().Equal(test.testTime, interpolatedRecord.Time)
 				s.Require().Equal(test.recordsToPreSet.P0LastSpotPrice, interpolatedRecord.P0LastSpotPrice)
 				s.Require().Equal(test.recordsToPreSet.P1LastSpotPrice, interpolatedRecord.P1LastSpotPrice)
 				s.Require().Equal(test.expectedAccumulator, interpolatedRecord.P0ArithmeticTwapAccumulator)
 				s.Require().Equal(test.expectedAccumulator, interpolatedRecord.P1ArithmeticTwapAccumulator)
-				if test.recordsToPreSet.Time.Equal(test.recordsToPreSet.LastErrorTime) {
+				if !test.recordsToPreSet.LastErrorTime.Before(test.recordsToPreSet.Time) {
 					// last error time updated
 					s.Require().Equal(test.testTime, interpolatedRecord.LastErrorTime)
 				} else {
@@ -495,8 +717,7 @@ func (s *TestSuite) TestGetInterpolatedRecord_ThreeAsset() {
 		"call 1 second before existing record": {
 			recordsToPreSet: baseRecord,
 			testTime:        baseTime.Add(-time.Second),
-			expectedErr: fmt.Errorf("looking for a time thats too old, not in the historical index. "+
-				" Try storing the accumulator value. (requested time %s)", baseTime.Add(-time.Second)),
+			expectedErr:     types.TimeTooOldError{Time: baseTime.Add(-time.Second)},
 		},
 		"test non lexicographical order parameter": {
 			recordsToPreSet: baseRecord,
@@ -512,8 +733,7 @@ func (s *TestSuite) TestGetInterpolatedRecord_ThreeAsset() {
 
 				interpolatedRecord, err := s.twapkeeper.GetInterpolatedRecord(s.Ctx, baseRecord[i].PoolId, baseRecord[i].Asset0Denom, baseRecord[i].Asset1Denom, test.testTime)
 				if test.expectedErr != nil {
-					s.Require().Error(err)
-					s.Require().Equal(test.expectedErr.Error(), err.Error())
+					s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expectedErr))
 					return
 				}
 				s.Require().NoError(err)
@@ -557,6 +777,312 @@ type computeThreeAssetArithmeticTwapTestCase struct {
 	expErr      bool
 }
 
+// TestInterpolatedRecordMatchesUpdatedRecords is a differential test of the two ways an accumulator gets to a time:
+// interpolating a record to it directly, or through records written by updateRecord in between, with the same prices.
+// Both must give identical accumulators, otherwise a TWAP would depend on whether its endpoints were interpolated.
+// Record times are random down to the nanosecond, as block times are.
+func (s *TestSuite) TestInterpolatedRecordMatchesUpdatedRecords() {
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
+	s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+
+	r := rand.New(rand.NewSource(1))
+	randDec := func() sdk.Dec {
+		return sdk.NewDecWithPrec(r.Int63n(1_000_000_000_000)+1, int64(r.Intn(12)))
+	}
+	randDuration := func(max time.Duration) time.Duration {
+		return time.Duration(r.Int63n(int64(max)))
+	}
+
+	for i := 0; i < 100; i++ {
+		sp0, sp1 := randDec(), randDec()
+		mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom0, denom1, sp0, nil)
+		mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom1, denom0, sp1, nil)
+
+		startRecord := newRecord(poolId, baseTime.Add(randDuration(time.Second)), sp0, randDec(), randDec(), randDec())
+		startRecord.P1LastSpotPrice = sp1
+
+		updatedRecord := startRecord
+		recordTime := startRecord.Time
+		for j := 0; j < 1+r.Intn(5); j++ {
+			recordTime = recordTime.Add(randDuration(10 * time.Second))
+			updatedRecord = s.twapkeeper.UpdateRecord(s.Ctx.WithBlockTime(recordTime), updatedRecord)
+		}
+		endTime := recordTime.Add(randDuration(10 * time.Second))
+
+		interpolatedFromStart := twap.RecordWithUpdatedAccumulators(startRecord, endTime)
+		interpolatedFromUpdated := twap.RecordWithUpdatedAccumulators(updatedRecord, endTime)
+		updatedAtEnd := s.twapkeeper.UpdateRecord(s.Ctx.WithBlockTime(endTime), updatedRecord)
+		for _, record := range []types.TwapRecord{interpolatedFromUpdated, updatedAtEnd} {
+			s.Require().Equal(interpolatedFromStart.P0ArithmeticTwapAccumulator.String(), record.P0ArithmeticTwapAccumulator.String(), "iteration %d", i)
+			s.Require().Equal(interpolatedFromStart.P1ArithmeticTwapAccumulator.String(), record.P1ArithmeticTwapAccumulator.String(), "iteration %d", i)
+			s.Require().Equal(interpolatedFromStart.GeometricTwapAccumulator.String(), record.GeometricTwapAccumulator.String(), "iteration %d", i)
+		}
+
+		// the price never changed, so the arithmetic TWAP is exactly the price
+		for quoteAsset, expectedTwap := range map[string]sdk.Dec{denom0: sp0, denom1: sp1} {
+			twapValue, err := twap.ComputeTwap(startRecord, interpolatedFromUpdated, quoteAsset, twap.ArithmeticTwapType)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedTwap.String(), twapValue.String(), "iteration %d", i)
+		}
+	}
+}
+
+// TestUpdateRecordsAcrossBlockTimeTruncation simulates an upgrade that truncates block times to milliseconds, where
+// the first block after the upgrade is at or before the time of the last records written before it.
+// The upgrade block must refresh these records in place rather than fail or write records going back in time.
+func (s *TestSuite) TestUpdateRecordsAcrossBlockTimeTruncation() {
+	// not a whole millisecond, so that truncating it goes back in time
+	preUpgradeTime := baseTime.Add(time.Second + 999_999*time.Nanosecond)
+
+	tests := map[string]struct {
+		upgradeBlockTime time.Time
+		upgradeSpErr     error
+	}{
+		"block time truncated before the last record": {
+			upgradeBlockTime: preUpgradeTime.Truncate(time.Millisecond),
+		},
+		"block time equal to the last record": {
+			upgradeBlockTime: preUpgradeTime,
+		},
+		"block time truncated before the last record, with a spot price error": {
+			upgradeBlockTime: preUpgradeTime.Truncate(time.Millisecond),
+			upgradeSpErr:     errors.New("spot price error"),
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+			mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
+			s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+			creationRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+			s.Require().NoError(err)
+
+			// last block before the upgrade
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom0, denom1, sdk.NewDec(2), nil)
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom1, denom0, sdk.NewDecWithPrec(5, 1), nil)
+			preUpgradeCtx := s.Ctx.WithBlockTime(preUpgradeTime).WithBlockHeight(s.Ctx.BlockHeight() + 1)
+			s.Require().NoError(s.twapkeeper.UpdateRecords(preUpgradeCtx, poolId))
+			preUpgradeRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+			s.Require().NoError(err)
+
+			// upgrade block
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom0, denom1, sdk.NewDec(4), tc.upgradeSpErr)
+			mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom1, denom0, sdk.NewDecWithPrec(25, 2), nil)
+			upgradeCtx := s.Ctx.WithBlockTime(tc.upgradeBlockTime).WithBlockHeight(preUpgradeCtx.BlockHeight() + 1)
+			s.Require().NoError(s.twapkeeper.UpdateRecords(upgradeCtx, poolId))
+
+			// The last record keeps its time and accumulators, with the spot prices and height of the upgrade block.
+			// A spot price error is recorded at the record's time, so that TWAPs ending after it see it.
+			expectedRecord := preUpgradeRecord
+			expectedRecord.P0LastSpotPrice = sdk.NewDec(4)
+			expectedRecord.P1LastSpotPrice = sdk.NewDecWithPrec(25, 2)
+			expectedRecord.Height = upgradeCtx.BlockHeight()
+			if tc.upgradeSpErr != nil {
+				expectedRecord.LastErrorTime = preUpgradeTime
+			}
+			refreshedRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedRecord, refreshedRecord)
+			s.Require().Equal([]types.TwapRecord{creationRecord, refreshedRecord}, s.getAllHistoricalRecordsForPool(poolId))
+
+			// The following blocks write records as usual, and TWAPs across the upgrade use the refreshed spot prices
+			postUpgradeCtx := s.Ctx.WithBlockTime(preUpgradeTime.Add(time.Second).Truncate(time.Millisecond)).WithBlockHeight(upgradeCtx.BlockHeight() + 1)
+			s.Require().NoError(s.twapkeeper.UpdateRecords(postUpgradeCtx, poolId))
+			s.Require().Len(s.getAllHistoricalRecordsForPool(poolId), 3)
+
+			twapValue, err := s.twapkeeper.GetArithmeticTwap(postUpgradeCtx, poolId, denom1, denom0, preUpgradeTime, postUpgradeCtx.BlockTime())
+			s.Require().Equal(tc.upgradeSpErr != nil, err != nil, "unexpected error: %v", err)
+			s.Require().Equal(sdk.NewDec(4), twapValue)
+		})
+	}
+}
+
+// TestComputeTwapAcrossRecordsBeforeTimeDeltaChange computes a TWAP over records whose accumulators were
+// written before AccumulatorTimeDelta, when the time delta of an update was the truncated difference of the two times.
+// Such an update accounted for at most 1ms less (or more) than the current code would, so the TWAP over a window
+// drifts by at most (number of such updates * 1ms) / window of the spot price. This drift is accepted rather than
+// migrating the accumulators of existing records, see the v14 upgrade notes.
+func TestComputeTwapAcrossRecordsBeforeTimeDeltaChange(t *testing.T) {
+	const numOldUpdates = 10
+	sp := sdk.NewDec(10)
+	// not a whole millisecond, and every interval adds another half millisecond,
+	// so that every old update accounted for 1ms less than the current code does.
+	startTime := baseTime.Add(600 * time.Microsecond)
+	interval := time.Second + 500*time.Microsecond
+
+	startRecord := newRecord(1, startTime, sp, sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	oldRecord := startRecord
+	for i := 0; i < numOldUpdates; i++ {
+		newTime := oldRecord.Time.Add(interval)
+		oldTimeDelta := newTime.Sub(oldRecord.Time).Truncate(time.Millisecond)
+		oldRecord.P0ArithmeticTwapAccumulator = oldRecord.P0ArithmeticTwapAccumulator.Add(types.SpotPriceMulDuration(oldRecord.P0LastSpotPrice, oldTimeDelta))
+		oldRecord.P1ArithmeticTwapAccumulator = oldRecord.P1ArithmeticTwapAccumulator.Add(types.SpotPriceMulDuration(oldRecord.P1LastSpotPrice, oldTimeDelta))
+		oldRecord.Time = newTime
+	}
+	endTime := oldRecord.Time.Add(time.Minute + 300*time.Microsecond)
+	endRecord := twap.RecordWithUpdatedAccumulators(oldRecord, endTime)
+
+	window := types.AccumulatorTimeDelta(startTime, endTime)
+	for quoteAsset, expectedTwap := range map[string]sdk.Dec{denom0: startRecord.P0LastSpotPrice, denom1: startRecord.P1LastSpotPrice} {
+		actualTwap, err := twap.ComputeTwap(startRecord, endRecord, quoteAsset, twap.ArithmeticTwapType)
+		require.NoError(t, err)
+
+		maxDrift := types.AccumDiffDivDuration(expectedTwap.MulInt64(numOldUpdates), window)
+		drift := actualTwap.Sub(expectedTwap).Abs()
+		require.True(t, drift.IsPositive(), "records written before the change should make the TWAP drift, got %s", actualTwap)
+		require.True(t, drift.LTE(maxDrift), "drift %s exceeds %s", drift, maxDrift)
+	}
+}
+
+// TestInversionTolerance quantifies how far the product of the TWAPs of a pair quoted both ways is from 1, over a
+// randomized corpus of price paths in several regimes, with spot prices between 10^-6 and 10^6.
+// Rounding must stay within types.InversionTolerance of the bounds the TWAP type implies: 1 for geometric TWAPs, and
+// between 1 and (m + M)^2 / 4mM for arithmetic TWAPs of spot prices between m and M.
+func TestInversionTolerance(t *testing.T) {
+	minPrice, maxPrice := sdk.NewDecWithPrec(1, 6), sdk.NewDec(1_000_000)
+	// the largest relative move of the spot price between two records, in millionths
+	regimes := map[string]int64{
+		"constant price": 0,
+		"stable price":   1_000,
+		"volatile price": 500_000,
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for name, maxMove := range regimes {
+		t.Run(name, func(t *testing.T) {
+			maxObserved := sdk.ZeroDec()
+			for i := 0; i < 200; i++ {
+				// a price with 7 significant digits, of a random magnitude
+				magnitude := int64(r.Intn(12))
+				price := sdk.NewDecWithPrec(1_000_000+r.Int63n(9_000_000), 12-magnitude)
+				lowest, highest := price, price
+
+				record := types.TwapRecord{
+					Time:        baseTime,
+					Asset0Denom: denom0,
+					Asset1Denom: denom1,
+					// the pool computes the spot price of each quote asset, rounding it
+					P0LastSpotPrice:             price,
+					P1LastSpotPrice:             sdk.OneDec().Quo(price),
+					P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+					P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+					GeometricTwapAccumulator:    sdk.ZeroDec(),
+				}
+				startRecord := record
+				for j := 0; j < 1+r.Intn(10); j++ {
+					record = twap.RecordWithUpdatedAccumulators(record, record.Time.Add(time.Duration(1+r.Int63n(3_600_000))*time.Millisecond))
+					move := sdk.NewDecWithPrec(r.Int63n(2*maxMove+1)-maxMove, 6)
+					price = sdk.MinDec(sdk.MaxDec(price.Mul(sdk.OneDec().Add(move)), minPrice), maxPrice)
+					lowest, highest = sdk.MinDec(lowest, price), sdk.MaxDec(highest, price)
+					record.P0LastSpotPrice, record.P1LastSpotPrice = price, sdk.OneDec().Quo(price)
+				}
+				endRecord := twap.RecordWithUpdatedAccumulators(record, record.Time.Add(time.Duration(1+r.Int63n(3_600_000))*time.Millisecond))
+
+				// (m + M)^2 / 4mM is computed from M / m, which is exactly 1 for a constant price, so that the bound doesn't
+				// lose precision for small prices
+				product := twap.ComputeArithmeticTwap(startRecord, endRecord, denom0).Mul(twap.ComputeArithmeticTwap(startRecord, endRecord, denom1))
+				ratio := highest.Quo(lowest)
+				upperBound := ratio.Add(sdk.OneDec()).Power(2).Quo(ratio.MulInt64(4))
+				require.True(t, product.GTE(sdk.OneDec().Sub(types.InversionTolerance)), "iteration %d: product %s below 1", i, product)
+				require.True(t, product.LTE(upperBound.Add(types.InversionTolerance)), "iteration %d: product %s above %s", i, product, upperBound)
+				maxObserved = sdk.MaxDec(maxObserved, product.Sub(sdk.OneDec()).Abs())
+
+				// twapPow only converges for non-negative exponents, so geometric TWAPs are checked for prices of at least 1
+				if lowest.GTE(sdk.OneDec()) {
+					geometricProduct := twap.ComputeGeometricTwap(startRecord, endRecord, denom0).Mul(twap.ComputeGeometricTwap(startRecord, endRecord, denom1))
+					require.True(t, geometricProduct.Sub(sdk.OneDec()).Abs().LTE(types.InversionTolerance), "iteration %d: geometric product %s", i, geometricProduct)
+				}
+			}
+			t.Logf("largest divergence of the arithmetic TWAPs' product from 1: %s", maxObserved)
+		})
+	}
+}
+
+// TestTwapErrorBounds checks that the arithmetic and geometric TWAPs, quoted in either asset, are within
+// types.TwapErrorBounds of the exact means of the spot prices, computed from the same records.
+// Geometric TWAPs are only bounded for asset 0 prices of at least 1, so the prices are kept between 1 and 10^6.
+func TestTwapErrorBounds(t *testing.T) {
+	minPrice, maxPrice := sdk.OneDec(), sdk.NewDec(1_000_000)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		// a price with 7 significant digits, between 1 and 10^6
+		price := sdk.NewDecWithPrec(1_000_000+r.Int63n(9_000_000), 6-int64(r.Intn(6)))
+		lowest, highest := price, price
+		record := types.TwapRecord{
+			Time:                        baseTime,
+			Asset0Denom:                 denom0,
+			Asset1Denom:                 denom1,
+			P0LastSpotPrice:             price,
+			P1LastSpotPrice:             sdk.OneDec().Quo(price),
+			P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+			P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+			GeometricTwapAccumulator:    sdk.ZeroDec(),
+		}
+		startRecord := record
+
+		// the exact weighted sums of the spot prices, and of their logarithms in float64, whose error is far below
+		// the geometric bounds
+		sum0, sum1, logSum := osmomath.ZeroDec(), osmomath.ZeroDec(), 0.
+		for j := 0; j < 1+r.Intn(10); j++ {
+			endTime := record.Time.Add(time.Duration(1+r.Int63n(3_600_000)) * time.Millisecond)
+			ms := types.AccumulatorTimeDelta(record.Time, endTime).Milliseconds()
+			sum0 = sum0.Add(osmomath.BigDecFromSDKDec(record.P0LastSpotPrice).MulInt64(ms))
+			sum1 = sum1.Add(osmomath.BigDecFromSDKDec(record.P1LastSpotPrice).MulInt64(ms))
+			logSum += math.Log2(record.P0LastSpotPrice.MustFloat64()) * float64(ms)
+			record = twap.RecordWithUpdatedAccumulators(record, endTime)
+
+			price = sdk.MinDec(sdk.MaxDec(price.Mul(sdk.NewDecWithPrec(500_000+r.Int63n(1_000_001), 6)), minPrice), maxPrice)
+			lowest, highest = sdk.MinDec(lowest, price), sdk.MaxDec(highest, price)
+			record.P0LastSpotPrice, record.P1LastSpotPrice = price, sdk.OneDec().Quo(price)
+		}
+		window := types.AccumulatorTimeDelta(startRecord.Time, record.Time)
+		endRecord := record
+
+		exact0 := sum0.QuoInt64(window.Milliseconds())
+		exact1 := sum1.QuoInt64(window.Milliseconds())
+		geometricExact0 := osmomath.MustNewDecFromStr(strconv.FormatFloat(math.Exp2(logSum/float64(window.Milliseconds())), 'f', -1, 64))
+		geometricExact1 := osmomath.OneDec().Quo(geometricExact0)
+
+		tests := []struct {
+			twapType  types.TwapType
+			quote     string
+			exact     osmomath.BigDec
+			magnitude sdk.Dec
+		}{
+			{types.ArithmeticTwapType, denom0, exact0, lowest},
+			{types.ArithmeticTwapType, denom1, exact1, sdk.OneDec().Quo(highest)},
+			{types.GeometricTwapType, denom0, geometricExact0, lowest},
+			{types.GeometricTwapType, denom1, geometricExact1, sdk.OneDec().Quo(highest)},
+		}
+		for _, test := range tests {
+			twapType := twap.ArithmeticTwapType
+			if test.twapType == types.GeometricTwapType {
+				twapType = twap.GeometricTwapType
+			}
+			actual, err := twap.ComputeTwap(startRecord, endRecord, test.quote, twapType)
+			require.NoError(t, err)
+			bounds := types.TwapErrorBounds(test.twapType, window, test.magnitude)
+			require.Equal(t, 0, bounds.CompareBigDec(test.exact, osmomath.BigDecFromSDKDec(actual)),
+				"iteration %d, type %d, quote %s: %s not within the bounds of %s", i, test.twapType, test.quote, actual, test.exact)
+		}
+
+		// a TWAP over an empty window is the last spot price, with no error
+		actual, err := twap.ComputeTwap(endRecord, endRecord, denom0, twap.GeometricTwapType)
+		require.NoError(t, err)
+		require.Equal(t, 0, types.TwapErrorBounds(types.GeometricTwapType, 0, price).CompareBigDec(osmomath.BigDecFromSDKDec(price), osmomath.BigDecFromSDKDec(actual)))
+	}
+
+	// the arithmetic bounds are tight: the TWAP is truncated by less than an ulp
+	bounds := types.TwapErrorBounds(types.ArithmeticTwapType, time.Hour, sdk.OneDec())
+	ulp := osmomath.BigDecFromSDKDec(sdk.SmallestDec())
+	require.Equal(t, 0, bounds.CompareBigDec(osmomath.OneDec(), osmomath.OneDec().Sub(ulp)))
+	require.NotEqual(t, 0, bounds.CompareBigDec(osmomath.OneDec(), osmomath.OneDec().Sub(ulp.MulInt64(2))))
+	require.NotEqual(t, 0, bounds.CompareBigDec(osmomath.OneDec(), osmomath.OneDec().Add(ulp)))
+}
+
 // TestComputeArithmeticTwap tests computeTwap on various inputs.
 // TODO: test both arithmetic and geometric twap.
 // The test vectors are structured by setting up different start and records,
@@ -584,22 +1110,50 @@ func TestComputeTwap(t *testing.T) {
 			startRecord: newOneSidedRecord(baseTime, sdk.ZeroDec(), true),
 			endRecord:   newOneSidedRecord(baseTime, sdk.ZeroDec(), true),
 			quoteAsset:  denom0,
-			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType},
+			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType, twap.HarmonicTwapType},
 			expTwap:     sdk.ZeroDec(),
 		},
 		"same record: denom1, end spot price = 1": {
 			startRecord: newOneSidedRecord(baseTime, sdk.ZeroDec(), true),
 			endRecord:   newOneSidedRecord(baseTime, sdk.ZeroDec(), true),
 			quoteAsset:  denom1,
-			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType},
+			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType, twap.HarmonicTwapType},
 			expTwap:     sdk.OneDec(),
 		},
+		// P0 and P1 spot prices are deliberately not reciprocals of each other,
+		// to check that all TWAP types read the stored price of the quote asset instead of inverting the other one.
+		"same record: non-reciprocal spot prices, denom0": {
+			startRecord: withSp1(withSp0(newOneSidedRecord(baseTime, sdk.ZeroDec(), true), sdk.NewDec(10)), sdk.NewDecWithPrec(2, 1)),
+			endRecord:   withSp1(withSp0(newOneSidedRecord(baseTime, sdk.ZeroDec(), true), sdk.NewDec(10)), sdk.NewDecWithPrec(2, 1)),
+			quoteAsset:  denom0,
+			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType, twap.HarmonicTwapType},
+			expTwap:     sdk.NewDec(10),
+		},
+		"same record: non-reciprocal spot prices, denom1": {
+			startRecord: withSp1(withSp0(newOneSidedRecord(baseTime, sdk.ZeroDec(), true), sdk.NewDec(10)), sdk.NewDecWithPrec(2, 1)),
+			endRecord:   withSp1(withSp0(newOneSidedRecord(baseTime, sdk.ZeroDec(), true), sdk.NewDec(10)), sdk.NewDecWithPrec(2, 1)),
+			quoteAsset:  denom1,
+			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType, twap.HarmonicTwapType},
+			expTwap:     sdk.NewDecWithPrec(2, 1),
+		},
+		// the accumulators are ignored when there is no time difference, only the end record's spot price is used.
+		"same time, different accumulators: non-reciprocal spot prices, denom1": {
+			startRecord: withSp1(withSp0(newOneSidedRecord(baseTime, sdk.ZeroDec(), true), sdk.NewDec(3)), sdk.NewDec(3)),
+			endRecord:   withSp1(withSp0(newOneSidedRecord(baseTime, tenSecAccum, true), sdk.NewDec(10)), sdk.NewDecWithPrec(2, 1)),
+			quoteAsset:  denom1,
+			twapTypes:   []twap.TwapType{twap.ArithmeticTwapType, twap.GeometricTwapType, twap.HarmonicTwapType},
+			expTwap:     sdk.NewDecWithPrec(2, 1),
+		},
 		"arithmetic only: accumulator = 10*OneSec, t=5s. 0 base accum": testCaseFromDeltas(
 			sdk.ZeroDec(), tenSecAccum, 5*time.Second, sdk.NewDec(2)),
 		"arithmetic only: accumulator = 10*OneSec, t=100s. 0 base accum (asset 1)": testCaseFromDeltasAsset1(sdk.ZeroDec(), OneSec.MulInt64(10), 100*time.Second, sdk.NewDecWithPrec(1, 1)),
 		"geometric only: accumulator = log(10)*OneSec, t=5s. 0 base accum": geometricTestCaseFromDeltas0(
 			sdk.ZeroDec(), geometricTenSecAccum, 5*time.Second, twap.TwapPow(geometricTenSecAccum.QuoInt64(5*1000))),
 		"geometric only: accumulator = log(10)*OneSec, t=100s. 0 base accum (asset 1)": geometricTestCaseFromDeltas1(sdk.ZeroDec(), geometricTenSecAccum, 100*time.Second, sdk.OneDec().Quo(twap.TwapPow(geometricTenSecAccum.QuoInt64(100*1000)))),
+		"harmonic only: asset 1 accumulator = 10*OneSec, t=5s. 0 base accum": harmonicTestCaseFromDeltas0(
+			sdk.ZeroDec(), tenSecAccum, 5*time.Second, sdk.NewDecWithPrec(5, 1)),
+		"harmonic only: asset 0 accumulator = 10*OneSec, t=100s. 0 base accum (asset 1)": harmonicTestCaseFromDeltas1(
+			sdk.ZeroDec(), tenSecAccum, 100*time.Second, sdk.NewDec(10)),
 	}
 	for name, test := range tests {
 		for _, twapType := range test.twapTypes {
@@ -607,6 +1161,8 @@ func TestComputeTwap(t *testing.T) {
 			twapTypeStr := "arithmetic"
 			if twapType == twap.GeometricTwapType {
 				twapTypeStr = "geometric"
+			} else if twapType == twap.HarmonicTwapType {
+				twapTypeStr = "harmonic"
 			}
 
 			t.Run(fmt.Sprintf("%s - %s", twapTypeStr, name), func(t *testing.T) {
@@ -744,6 +1300,126 @@ func TestComputeGeometricTwap(t *testing.T) {
 	}
 }
 
+// TestComputeHarmonicTwap tests computeHarmonicTwap on hand computed vectors: the harmonic TWAP quoted in an asset is
+// the reciprocal of the arithmetic TWAP quoted in the other asset, so it reads the accumulator of the other asset.
+func TestComputeHarmonicTwap(t *testing.T) {
+	pointOneAccum := OneSec.QuoInt64(10)
+	tests := map[string]computeTwapTestCase{
+		"basic denom0: asset 1 spot price = 1 for one second, 0 init accumulator": {
+			startRecord: newOneSidedRecord(baseTime, sdk.ZeroDec(), false),
+			endRecord:   newOneSidedRecord(tPlusOne, OneSec, false),
+			quoteAsset:  denom0,
+			expTwap:     sdk.OneDec(),
+		},
+		"same record (zero time delta), division by 0 - panic": {
+			startRecord: newOneSidedRecord(baseTime, sdk.ZeroDec(), false),
+			endRecord:   newOneSidedRecord(baseTime, sdk.ZeroDec(), false),
+			quoteAsset:  denom0,
+			expPanic:    true,
+		},
+		"zero accumulator difference, zero arithmetic TWAP - error": {
+			startRecord: newOneSidedRecord(baseTime, tenSecAccum, false),
+			endRecord:   newOneSidedRecord(tPlusOne, tenSecAccum, false),
+			quoteAsset:  denom0,
+			expErr:      true,
+		},
+		// 1 / (10 / 5) = 0.5
+		"asset 1 accumulator = 10*OneSec, t=5s. 0 base accum": harmonicTestCaseFromDeltas0(
+			sdk.ZeroDec(), tenSecAccum, 5*time.Second, sdk.NewDecWithPrec(5, 1)),
+		// 1 / 3.333333333333333333 = 0.300000000000000000(03)
+		"asset 1 accumulator = 10*OneSec, t=3s. 0 base accum": harmonicTestCaseFromDeltas0(
+			sdk.ZeroDec(), tenSecAccum, 3*time.Second, sdk.NewDecWithPrec(3, 1)),
+		// 1 / (10 / 100) = 10
+		"asset 1 accumulator = 10*OneSec, t=100s. 0 base accum": harmonicTestCaseFromDeltas0(
+			sdk.ZeroDec(), tenSecAccum, 100*time.Second, sdk.NewDec(10)),
+
+		// test that base accum has no impact
+		"asset 1 accumulator = 10*OneSec, t=5s. 10 base accum": harmonicTestCaseFromDeltas0(
+			sdk.NewDec(10), tenSecAccum, 5*time.Second, sdk.NewDecWithPrec(5, 1)),
+		"asset 1 accumulator = 10*OneSec, t=3s. 10*second base accum": harmonicTestCaseFromDeltas0(
+			tenSecAccum, tenSecAccum, 3*time.Second, sdk.NewDecWithPrec(3, 1)),
+		"asset 1 accumulator = 10*OneSec, t=100s. .1*second base accum": harmonicTestCaseFromDeltas0(
+			pointOneAccum, tenSecAccum, 100*time.Second, sdk.NewDec(10)),
+
+		// 1 / (10 / 4) = 0.4
+		"asset 0 accumulator = 10*OneSec, t=4s. 0 base accum (asset 1)": harmonicTestCaseFromDeltas1(
+			sdk.ZeroDec(), tenSecAccum, 4*time.Second, sdk.NewDecWithPrec(4, 1)),
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			osmoassert.ConditionalPanic(t, test.expPanic, func() {
+				actualTwap, err := twap.ComputeHarmonicTwap(test.startRecord, test.endRecord, test.quoteAsset)
+				if test.expErr {
+					require.ErrorAs(t, err, &types.ZeroArithmeticTwapError{})
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, test.expTwap, actualTwap)
+			})
+		})
+	}
+}
+
+// TestComputeTwapMeansOrdering tests that the harmonic, geometric and arithmetic TWAPs over spot prices that vary
+// within the window are ordered as their means are, harmonic < geometric < arithmetic, quoted in either asset.
+// For a constant spot price, they are all equal to it.
+func TestComputeTwapMeansOrdering(t *testing.T) {
+	// asset 0 spot prices, held for the given durations, at least 1 for the geometric TWAP to be within its bounds
+	tests := map[string]struct {
+		spotPrices []sdk.Dec
+		durations  []time.Duration
+		isConstant bool
+	}{
+		"two prices, equal durations": {
+			spotPrices: []sdk.Dec{sdk.NewDec(10), sdk.NewDec(5)},
+			durations:  []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+		"increasing then decreasing prices": {
+			spotPrices: []sdk.Dec{sdk.NewDec(2), sdk.NewDec(5), sdk.NewDec(10), sdk.NewDec(3)},
+			durations:  []time.Duration{time.Minute, 30 * time.Second, 5 * time.Second, time.Hour},
+		},
+		"small variation": {
+			spotPrices: []sdk.Dec{sdk.NewDec(100), sdk.NewDec(101), sdk.NewDec(99)},
+			durations:  []time.Duration{time.Hour, time.Hour, 2 * time.Hour},
+		},
+		"constant price": {
+			spotPrices: []sdk.Dec{sdk.NewDec(4), sdk.NewDec(4)},
+			durations:  []time.Duration{time.Minute, time.Minute},
+			isConstant: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			startRecord := newTwoAssetPoolTwapRecordWithDefaults(baseTime, test.spotPrices[0], sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+			endRecord := startRecord
+			for i, duration := range test.durations {
+				endRecord = twap.RecordWithUpdatedAccumulators(endRecord, endRecord.Time.Add(duration))
+				if i+1 < len(test.spotPrices) {
+					endRecord.P0LastSpotPrice = test.spotPrices[i+1]
+					endRecord.P1LastSpotPrice = sdk.OneDec().Quo(test.spotPrices[i+1])
+				}
+			}
+
+			for _, quoteAsset := range []string{denom0, denom1} {
+				arithmeticTwap, err := twap.ComputeTwap(startRecord, endRecord, quoteAsset, twap.ArithmeticTwapType)
+				require.NoError(t, err)
+				geometricTwap, err := twap.ComputeTwap(startRecord, endRecord, quoteAsset, twap.GeometricTwapType)
+				require.NoError(t, err)
+				harmonicTwap, err := twap.ComputeTwap(startRecord, endRecord, quoteAsset, twap.HarmonicTwapType)
+				require.NoError(t, err)
+
+				if test.isConstant {
+					osmoassert.DecApproxEq(t, arithmeticTwap, geometricTwap, osmomath.GetPowPrecision())
+					osmoassert.DecApproxEq(t, arithmeticTwap, harmonicTwap, osmomath.GetPowPrecision())
+					continue
+				}
+				require.True(t, harmonicTwap.LT(geometricTwap), "quote %s: harmonic %s, geometric %s", quoteAsset, harmonicTwap, geometricTwap)
+				require.True(t, geometricTwap.LT(arithmeticTwap), "quote %s: geometric %s, arithmetic %s", quoteAsset, geometricTwap, arithmeticTwap)
+			}
+		})
+	}
+}
+
 func TestComputeArithmeticTwap_ThreeAsset_Arithmetic(t *testing.T) {
 	tenSecAccum := OneSec.MulInt64(10)
 	pointOneAccum := OneSec.QuoInt64(10)
@@ -858,6 +1534,14 @@ func TestComputeArithmeticTwapWithSpotPriceError(t *testing.T) {
 			expTwap:     sdk.OneDec(),
 			expErr:      true,
 		},
+		// should error, since start record's spot price errored at or before its interpolation
+		"err after StartTime from start record": {
+			startRecord: newOneSidedRecordWErrorTime(baseTime, sdk.ZeroDec(), true, tPlusOne),
+			endRecord:   newOneSidedRecord(tPlusOne, OneSec, true),
+			quoteAsset:  denom0,
+			expTwap:     sdk.OneDec(),
+			expErr:      true,
+		},
 		"err before StartTime": {
 			startRecord: newOneSidedRecord(baseTime, sdk.ZeroDec(), true),
 			endRecord:   newOneSidedRecordWErrorTime(tPlusOne, OneSec, true, tMinOne),
@@ -939,6 +1623,194 @@ func (s *TestSuite) TestPruneRecords() {
 	s.validateExpectedRecords(expectedKeptRecords)
 }
 
+// TestPruneRecordsOverManyBlocks tests that the records are pruned by the EndBlocks following the prune epoch, at
+// most MaxPrunedRecordsPerBlock per block, and that the newest record of every pair before the threshold is kept
+// throughout, whether it is gone over in the first block of the pruning or in a later one.
+func (s *TestSuite) TestPruneRecordsOverManyBlocks() {
+	const (
+		numPools          = 10
+		numOldRecords     = 300
+		maxPrunedPerBlock = 500
+	)
+	s.SetupTest()
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.MaxPrunedRecordsPerBlock = maxPrunedPerBlock
+	s.twapkeeper.SetParams(s.Ctx, params)
+	lastKeptTime := baseTime.Add(-params.RecordHistoryKeepPeriod)
+
+	type poolTime struct {
+		poolId uint64
+		time   time.Time
+	}
+	expectedKept := map[poolTime]bool{}
+	records := []types.TwapRecord{}
+	// pools 1 to 10 have a record every second until a second before the threshold, and a record after it.
+	for poolId := uint64(1); poolId <= numPools; poolId++ {
+		for i := numOldRecords; i > 0; i-- {
+			records = append(records, newEmptyPriceRecord(poolId, lastKeptTime.Add(-time.Duration(i)*time.Second), denom0, denom1))
+		}
+		expectedKept[poolTime{poolId, lastKeptTime.Add(-time.Second)}] = true
+		records = append(records, newEmptyPriceRecord(poolId, baseTime, denom0, denom1))
+		expectedKept[poolTime{poolId, baseTime}] = true
+	}
+	// pool 11 only has records older than the others, which the pruning only goes over in its last block.
+	oldPoolId := uint64(numPools + 1)
+	for i := 1000; i > 990; i-- {
+		records = append(records, newEmptyPriceRecord(oldPoolId, lastKeptTime.Add(-time.Duration(i)*time.Second), denom0, denom1))
+	}
+	expectedKept[poolTime{oldPoolId, lastKeptTime.Add(-991 * time.Second)}] = true
+	s.preSetRecords(records)
+	// a pinned record is kept.
+	pinnedRecord := newEmptyPriceRecord(1, lastKeptTime.Add(-200*time.Second), denom0, denom1)
+	s.twapkeeper.StorePinnedRecord(s.Ctx, pinnedRecord)
+	expectedKept[poolTime{1, pinnedRecord.Time}] = true
+
+	countRecords := func() int {
+		allRecords, err := s.twapkeeper.GetAllHistoricalTimeIndexedTWAPs(s.Ctx)
+		s.Require().NoError(err)
+		return len(allRecords)
+	}
+	s.Require().Equal(numPools*(numOldRecords+1)+10, countRecords())
+
+	// the prune epoch starts the pruning, without pruning any record.
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	s.Require().NoError(s.twapkeeper.EpochHooks().AfterEpochEnd(s.Ctx, params.PruneEpochIdentifier, 1))
+	s.Require().Equal(numPools*(numOldRecords+1)+10, countRecords())
+
+	blocks := 0
+	for {
+		_, isPruning, err := s.twapkeeper.GetPruningState(s.Ctx)
+		s.Require().NoError(err)
+		if !isPruning {
+			break
+		}
+		s.Require().Less(blocks, 10, "pruning doesn't converge")
+
+		before := countRecords()
+		s.twapkeeper.EndBlock(s.Ctx)
+		blocks++
+		s.Ctx = s.Ctx.WithBlockHeight(s.Ctx.BlockHeight() + 1).WithBlockTime(s.Ctx.BlockTime().Add(5 * time.Second))
+		s.Require().LessOrEqual(before-countRecords(), maxPrunedPerBlock)
+
+		// the newest record before the threshold of every pair is there to interpolate from after every block.
+		for poolId := uint64(1); poolId <= numPools; poolId++ {
+			record, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, poolId, lastKeptTime, denom0, denom1)
+			s.Require().NoError(err)
+			s.Require().Equal(lastKeptTime.Add(-time.Second), record.Time)
+		}
+		record, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, oldPoolId, lastKeptTime, denom0, denom1)
+		s.Require().NoError(err)
+		s.Require().Equal(lastKeptTime.Add(-991*time.Second), record.Time)
+	}
+	// 2998 records are pruned, 500 per block.
+	s.Require().Equal(6, blocks)
+
+	allRecords, err := s.twapkeeper.GetAllHistoricalTimeIndexedTWAPs(s.Ctx)
+	s.Require().NoError(err)
+	kept := map[poolTime]bool{}
+	for _, record := range allRecords {
+		kept[poolTime{record.PoolId, record.Time}] = true
+	}
+	s.Require().Equal(expectedKept, kept)
+	poolIndexedRecords, err := s.twapkeeper.GetAllHistoricalPoolIndexedTWAPs(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Len(poolIndexedRecords, len(expectedKept))
+}
+
+// Updating the keep period while a pruning is in progress restarts the pruning with the threshold of the new period,
+// so that a longer period keeps the records it now covers. A shorter period takes effect at the next prune epoch.
+func (s *TestSuite) TestPruneRecordsAcrossKeepPeriodChange() {
+	s.SetupTest()
+	authority := s.TestAccs[0].String()
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.MaxPrunedRecordsPerBlock = 10
+	params.ParamsAuthority = authority
+	s.twapkeeper.SetParams(s.Ctx, params)
+	keepPeriod := params.RecordHistoryKeepPeriod
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+
+	// a record every minute over the 2 hours before the threshold of the keep period, and a record at the block time.
+	minutesBeforeThreshold := func(i int) time.Time {
+		return baseTime.Add(-keepPeriod - time.Duration(i)*time.Minute)
+	}
+	records := []types.TwapRecord{}
+	for i := 120; i > 0; i-- {
+		records = append(records, newEmptyPriceRecord(1, minutesBeforeThreshold(i), denom0, denom1))
+	}
+	records = append(records, newEmptyPriceRecord(1, baseTime, denom0, denom1))
+	s.preSetRecords(records)
+
+	pruneUntilDone := func() {
+		for blocks := 0; ; blocks++ {
+			_, isPruning, err := s.twapkeeper.GetPruningState(s.Ctx)
+			s.Require().NoError(err)
+			if !isPruning {
+				return
+			}
+			s.Require().Less(blocks, 100, "pruning doesn't converge")
+			s.twapkeeper.EndBlock(s.Ctx)
+		}
+	}
+	// requireRecords checks that the records are those at the given minutes before the threshold, and at the block time
+	requireRecords := func(minutes ...int) {
+		expected := []time.Time{}
+		for _, i := range minutes {
+			expected = append(expected, minutesBeforeThreshold(i))
+		}
+		expected = append(expected, baseTime)
+		allRecords, err := s.twapkeeper.GetAllHistoricalTimeIndexedTWAPs(s.Ctx)
+		s.Require().NoError(err)
+		actual := []time.Time{}
+		for _, record := range allRecords {
+			actual = append(actual, record.Time)
+		}
+		s.Require().Equal(expected, actual)
+	}
+	minuteRange := func(from, to int) []int {
+		minutes := []int{}
+		for i := from; i >= to; i-- {
+			minutes = append(minutes, i)
+		}
+		return minutes
+	}
+
+	// The pruning starts at the epoch, and prunes the 10 newest records before the newest one before the threshold.
+	s.Require().NoError(s.twapkeeper.EpochHooks().AfterEpochEnd(s.Ctx, params.PruneEpochIdentifier, 1))
+	s.twapkeeper.EndBlock(s.Ctx)
+	requireRecords(append(minuteRange(120, 12), 1)...)
+
+	// The keep period grows by an hour: the pruning restarts before the new threshold, and the records between both
+	// thresholds are no longer pruned.
+	params.RecordHistoryKeepPeriod = keepPeriod + time.Hour
+	msgServer := twap.NewMsgServerImpl(s.twapkeeper)
+	_, err := msgServer.UpdateParams(sdk.WrapSDKContext(s.Ctx), types.NewMsgUpdateParams(authority, params))
+	s.Require().NoError(err)
+	state, isPruning, err := s.twapkeeper.GetPruningState(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().True(isPruning)
+	s.Require().Equal(types.PruningState{LastKeptTime: minutesBeforeThreshold(60)}, state)
+
+	pruneUntilDone()
+	// the newest record before the new threshold is kept to interpolate from.
+	requireRecords(append(minuteRange(61, 12), 1)...)
+	record, err := s.twapkeeper.GetRecordAtOrBeforeTime(s.Ctx, 1, minutesBeforeThreshold(60).Add(-time.Nanosecond), denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Equal(minutesBeforeThreshold(61), record.Time)
+
+	// The keep period shrinks back: nothing is pruned until the next epoch, which prunes down to its threshold.
+	params.RecordHistoryKeepPeriod = keepPeriod
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(s.Ctx), types.NewMsgUpdateParams(authority, params))
+	s.Require().NoError(err)
+	_, isPruning, err = s.twapkeeper.GetPruningState(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().False(isPruning)
+	requireRecords(append(minuteRange(61, 12), 1)...)
+
+	s.Require().NoError(s.twapkeeper.EpochHooks().AfterEpochEnd(s.Ctx, params.PruneEpochIdentifier, 2))
+	pruneUntilDone()
+	requireRecords(1)
+}
+
 // TestUpdateRecords tests that the records are updated correctly.
 // It tests the following:
 // - two-asset pools
@@ -981,7 +1853,9 @@ func (s *TestSuite) TestUpdateRecords() {
 		poolId        uint64
 		ammMock       twapmock.ProgrammedAmmInterface
 		spOverrides   []spOverride
-		blockTime     time.Time
+		// poolDenoms overrides the pool's denoms, which default to the denoms of the last spOverride.
+		poolDenoms []string
+		blockTime  time.Time
 
 		expectedHistoricalRecords []expectedResults
 		expectError               error
@@ -1000,7 +1874,7 @@ func (s *TestSuite) TestUpdateRecords() {
 
 			expectError: gammtypes.PoolDoesNotExistError{PoolId: baseRecord.PoolId + 1},
 		},
-		"the returned number of records does not match expected": {
+		"the pool's denoms do not match its records; pool quarantined, records not updated": {
 			preSetRecords: []types.TwapRecord{baseRecord},
 			poolId:        baseRecord.PoolId,
 			blockTime:     baseRecord.Time.Add(time.Second),
@@ -1023,7 +1897,21 @@ func (s *TestSuite) TestUpdateRecords() {
 				},
 			},
 
-			expectError: types.InvalidRecordCountError{Expected: 3, Actual: 1},
+			expectedHistoricalRecords: []expectedResults{
+				{
+					spotPriceA:   baseRecord.P0LastSpotPrice,
+					spotPriceB:   baseRecord.P1LastSpotPrice,
+					isMostRecent: true,
+				},
+			},
+		},
+		"the returned number of records does not match expected": {
+			preSetRecords: []types.TwapRecord{threeAssetRecordAB, threeAssetRecordAC},
+			poolId:        threeAssetRecordAB.PoolId,
+			blockTime:     threeAssetRecordAB.Time.Add(time.Second),
+			poolDenoms:    []string{denom0, denom1, denom2},
+
+			expectError: types.InvalidRecordCountError{Expected: 3, Actual: 2},
 		},
 		"two-asset; pre-set record at t; updated valid spot price": {
 			preSetRecords: []types.TwapRecord{baseRecord},
@@ -1233,7 +2121,7 @@ func (s *TestSuite) TestUpdateRecords() {
 		},
 		// This case should never happen in-practice since ctx.BlockTime
 		// should always be greater than the last record's time.
-		"two-asset; pre-set at t and t + 1, new record inserted between existing": {
+		"two-asset; pre-set at t and t + 1, block time between them refreshes the record at t + 1": {
 			preSetRecords: []types.TwapRecord{baseRecord, tPlus10sp5Record},
 			poolId:        baseRecord.PoolId,
 
@@ -1258,19 +2146,12 @@ func (s *TestSuite) TestUpdateRecords() {
 					spotPriceA: baseRecord.P0LastSpotPrice,
 					spotPriceB: baseRecord.P1LastSpotPrice,
 				},
-				// The new record added.
-				// TODO: it should not be possible to add a record between existing records.
-				// https://github.com/osmosis-labs/osmosis/issues/2686
+				// The record at t + 1, refreshed in place with the new spot prices rather than preceded by a new record.
 				{
 					spotPriceA:   sdk.OneDec(),
 					spotPriceB:   sdk.OneDec().Add(sdk.OneDec()),
 					isMostRecent: true,
 				},
-				// The original record at t + 1.
-				{
-					spotPriceA: tPlus10sp5Record.P0LastSpotPrice,
-					spotPriceB: tPlus10sp5Record.P1LastSpotPrice,
-				},
 			},
 		},
 		"multi-asset pool; pre-set at t and t + 1; creates new records": {
@@ -1471,6 +2352,11 @@ func (s *TestSuite) TestUpdateRecords() {
 
 				twapKeeper.SetAmmInterface(ammMock)
 			}
+			if len(tc.poolDenoms) > 0 {
+				ammMock := twapmock.NewProgrammedAmmInterface(s.App.GAMMKeeper)
+				ammMock.ProgramPoolDenomsOverride(tc.poolId, tc.poolDenoms, nil)
+				twapKeeper.SetAmmInterface(ammMock)
+			}
 
 			s.preSetRecords(tc.preSetRecords)
 
@@ -1659,6 +2545,34 @@ func geometricTestCaseFromDeltas1(startAccum, accumDiff sdk.Dec, timeDelta time.
 	return geometricTestCaseFromDeltas0(startAccum, accumDiff, timeDelta, sdk.OneDec().Quo(expectedTwap))
 }
 
+// harmonicTestCaseFromDeltas0 returns the harmonic TWAP test case quoted in asset 0, which is the reciprocal of the
+// arithmetic TWAP of asset 1 over records with the given asset 1 accumulators.
+func harmonicTestCaseFromDeltas0(startAccum, accumDiff sdk.Dec, timeDelta time.Duration, expectedTwap sdk.Dec) computeTwapTestCase {
+	return computeTwapTestCase{
+		newOneSidedRecord(baseTime, startAccum, false),
+		newOneSidedRecord(baseTime.Add(timeDelta), startAccum.Add(accumDiff), false),
+		[]twap.TwapType{twap.HarmonicTwapType},
+		denom0,
+		expectedTwap,
+		false,
+		false,
+	}
+}
+
+// harmonicTestCaseFromDeltas1 returns the harmonic TWAP test case quoted in asset 1, which is the reciprocal of the
+// arithmetic TWAP of asset 0 over records with the given asset 0 accumulators.
+func harmonicTestCaseFromDeltas1(startAccum, accumDiff sdk.Dec, timeDelta time.Duration, expectedTwap sdk.Dec) computeTwapTestCase {
+	return computeTwapTestCase{
+		newOneSidedRecord(baseTime, startAccum, true),
+		newOneSidedRecord(baseTime.Add(timeDelta), startAccum.Add(accumDiff), true),
+		[]twap.TwapType{twap.HarmonicTwapType},
+		denom1,
+		expectedTwap,
+		false,
+		false,
+	}
+}
+
 func testThreeAssetCaseFromDeltas(startAccum, accumDiff sdk.Dec, timeDelta time.Duration, expectedTwap sdk.Dec) computeThreeAssetArithmeticTwapTestCase {
 	return computeThreeAssetArithmeticTwapTestCase{
 		newThreeAssetOneSidedRecord(baseTime, startAccum, true),
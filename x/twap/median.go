@@ -0,0 +1,148 @@
+package twap
+
+import (
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// sampleSpotPrices stores a spot price sample of every denom pair of pool poolId at the block time, for the
+// median spot prices of the pools of the MedianTrackedPools param.
+// Unlike the records, the spot prices are sampled every block, whether or not the pool changed in it, so that
+// every block weighs the same in the median. Pools without records, and quarantined pools, are not sampled.
+// The spot prices are sanitized as for the records, and a sample whose spot prices errored is stored flagged,
+// for the median to ignore it.
+func (k Keeper) sampleSpotPrices(ctx sdk.Context, poolId uint64) {
+	if k.isPoolQuarantined(ctx, poolId) {
+		return
+	}
+	records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return
+	}
+	for _, record := range records {
+		// the error time is only set if getting the spot prices of this block errored.
+		sp0, sp1, errTime := fetchAndSanitizeSpotPrices(ctx, k.ammkeeper, poolId, record.Asset0Denom, record.Asset1Denom, time.Time{})
+		k.storeSpotPriceSample(ctx, types.SpotPriceSample{
+			PoolId:         poolId,
+			Asset0Denom:    record.Asset0Denom,
+			Asset1Denom:    record.Asset1Denom,
+			Height:         ctx.BlockHeight(),
+			Time:           ctx.BlockTime(),
+			P0SpotPrice:    sp0,
+			P1SpotPrice:    sp1,
+			SpotPriceError: !errTime.IsZero(),
+		})
+	}
+}
+
+func (k Keeper) storeSpotPriceSample(ctx sdk.Context, sample types.SpotPriceSample) {
+	store := ctx.KVStore(k.storeKey)
+	key1 := types.FormatSpotPriceSampleTimeIndexKey(sample.Time, sample.PoolId, sample.Asset0Denom, sample.Asset1Denom)
+	key2 := types.FormatSpotPriceSamplePoolIndexKey(sample.PoolId, sample.Asset0Denom, sample.Asset1Denom, sample.Time)
+	osmoutils.MustSet(store, key1, &sample)
+	osmoutils.MustSet(store, key2, &sample)
+}
+
+// pruneSpotPriceSamplesBeforeTime deletes the spot price samples of all pools taken before lastKeptTime.
+// Unlike the records, no sample before lastKeptTime is kept, as the median of a window is only computed from
+// the samples within it.
+func (k Keeper) pruneSpotPriceSamplesBeforeTime(ctx sdk.Context, lastKeptTime time.Time) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(
+		[]byte(types.SpotPriceSampleTimeIndexPrefix),
+		types.FormatSpotPriceSampleTimeIndexKey(lastKeptTime, 0, "", ""))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		sample, err := types.ParseSpotPriceSampleFromBz(iter.Value())
+		if err != nil {
+			return err
+		}
+		store.Delete(iter.Key())
+		store.Delete(types.FormatSpotPriceSamplePoolIndexKey(sample.PoolId, sample.Asset0Denom, sample.Asset1Denom, sample.Time))
+	}
+	return nil
+}
+
+// getSpotPriceSamples returns the spot price samples of the (pool id, asset 0, asset 1) triplet taken in
+// [startTime, endTime], in time order.
+func (k Keeper) getSpotPriceSamples(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string, startTime, endTime time.Time) ([]types.SpotPriceSample, error) {
+	store := ctx.KVStore(k.storeKey)
+	start := types.FormatSpotPriceSamplePoolIndexKey(poolId, asset0Denom, asset1Denom, startTime)
+	// the prefix end of the key of endTime includes the sample taken at endTime.
+	end := sdk.PrefixEndBytes(types.FormatSpotPriceSamplePoolIndexKey(poolId, asset0Denom, asset1Denom, endTime))
+	return osmoutils.GatherValuesFromStore(store, start, end, types.ParseSpotPriceSampleFromBz)
+}
+
+// GetMedianSpotPrice returns the median of the spot prices of baseAssetDenom in units of quoteAssetDenom in pool
+// `poolId`, sampled at the end of every block in [startTime, endTime], along with the number of samples it was
+// computed from. The median of an even number of samples is the mean of the two middle ones.
+// Unlike a TWAP, a price that only lasted a few blocks of the window, e.g. a manipulated spot price, doesn't move
+// the median. The spot prices are only sampled for the pools of the MedianTrackedPools param, and the samples are
+// kept for the record history keep period. The samples whose spot prices errored are ignored.
+//
+// This function will error if:
+// * startTime > endTime
+// * endTime in the future
+// * startTime older than the record history keep period
+// * baseAssetDenom and quoteAssetDenom are the same
+// * no sample of the pair in [startTime, endTime] has spot prices that didn't error, e.g. for an untracked pool
+func (k Keeper) GetMedianSpotPrice(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (median sdk.Dec, numSamples int, err error) {
+	if startTime.After(endTime) {
+		return sdk.Dec{}, 0, types.InvalidTimeRangeError{Start: startTime, End: endTime}
+	}
+	if endTime.After(ctx.BlockTime()) {
+		return sdk.Dec{}, 0, types.EndTimeInFutureError{EndTime: endTime, BlockTime: ctx.BlockTime()}
+	}
+	if tooOld := k.newTimeTooOldError(ctx, startTime); startTime.Before(tooOld.OldestQueryableTime) {
+		return sdk.Dec{}, 0, tooOld
+	}
+	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, 0, err
+	}
+
+	samples, err := k.getSpotPriceSamples(ctx, poolId, asset0Denom, asset1Denom, startTime, endTime)
+	if err != nil {
+		return sdk.Dec{}, 0, err
+	}
+	prices := make([]sdk.Dec, 0, len(samples))
+	for _, sample := range samples {
+		if sample.SpotPriceError {
+			continue
+		}
+		if quoteAssetDenom == asset0Denom {
+			prices = append(prices, sample.P0SpotPrice)
+		} else {
+			prices = append(prices, sample.P1SpotPrice)
+		}
+	}
+	if len(prices) == 0 {
+		return sdk.Dec{}, 0, types.NoSpotPriceSamplesError{
+			PoolId: poolId, Asset0Denom: asset0Denom, Asset1Denom: asset1Denom, StartTime: startTime, EndTime: endTime,
+		}
+	}
+	return medianOf(prices), len(prices), nil
+}
+
+// medianOf returns the median of prices, which must not be empty. prices is sorted in place.
+func medianOf(prices []sdk.Dec) sdk.Dec {
+	sort.Slice(prices, func(i, j int) bool { return prices[i].LT(prices[j]) })
+	mid := len(prices) / 2
+	if len(prices)%2 == 1 {
+		return prices[mid]
+	}
+	return prices[mid-1].Add(prices[mid]).QuoInt64(2)
+}
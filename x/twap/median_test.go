@@ -0,0 +1,185 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// trackMedian adds poolId to the median tracked pools.
+func (s *TestSuite) trackMedian(poolId uint64) {
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.MedianTrackedPools = append(params.MedianTrackedPools, poolId)
+	s.twapkeeper.SetParams(s.Ctx, params)
+}
+
+func newSpotPriceSample(t time.Time, p0SpotPrice, p1SpotPrice sdk.Dec, spotPriceError bool) types.SpotPriceSample {
+	return types.SpotPriceSample{
+		PoolId:         basePoolId,
+		Asset0Denom:    denom0,
+		Asset1Denom:    denom1,
+		Time:           t,
+		P0SpotPrice:    p0SpotPrice,
+		P1SpotPrice:    p1SpotPrice,
+		SpotPriceError: spotPriceError,
+	}
+}
+
+func (s *TestSuite) TestGetMedianSpotPrice() {
+	samples := []types.SpotPriceSample{
+		newSpotPriceSample(baseTime, sdk.NewDec(1), sdk.NewDec(10), false),
+		newSpotPriceSample(tPlusOne, sdk.NewDec(4), sdk.NewDec(40), false),
+		newSpotPriceSample(baseTime.Add(2*time.Second), sdk.NewDec(2), sdk.NewDec(20), false),
+		// errored samples are ignored, whatever their spot prices
+		newSpotPriceSample(baseTime.Add(3*time.Second), sdk.NewDec(100), sdk.NewDec(1000), true),
+	}
+	blockTime := baseTime.Add(10 * time.Second)
+
+	tests := map[string]struct {
+		baseAsset  string
+		quoteAsset string
+		startTime  time.Time
+		endTime    time.Time
+
+		expMedian     sdk.Dec
+		expNumSamples int
+		expErr        error
+	}{
+		"odd number of samples": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: baseTime, endTime: blockTime,
+			expMedian: sdk.NewDec(2), expNumSamples: 3,
+		},
+		"even number of samples, mean of the middle ones": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: baseTime, endTime: tPlusOne,
+			expMedian: sdk.NewDecWithPrec(25, 1), expNumSamples: 2,
+		},
+		"quoted in asset 1": {
+			baseAsset: denom0, quoteAsset: denom1, startTime: baseTime, endTime: blockTime,
+			expMedian: sdk.NewDec(20), expNumSamples: 3,
+		},
+		"start and end times are included": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: tPlusOne, endTime: tPlusOne,
+			expMedian: sdk.NewDec(4), expNumSamples: 1,
+		},
+		"only errored samples": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: baseTime.Add(3 * time.Second), endTime: blockTime,
+			expErr: types.NoSpotPriceSamplesError{
+				PoolId: basePoolId, Asset0Denom: denom0, Asset1Denom: denom1,
+				StartTime: baseTime.Add(3 * time.Second), EndTime: blockTime,
+			},
+		},
+		"start time after end time": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: tPlusOne, endTime: baseTime,
+			expErr: types.InvalidTimeRangeError{Start: tPlusOne, End: baseTime},
+		},
+		"end time in the future": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: baseTime, endTime: blockTime.Add(time.Second),
+			expErr: types.EndTimeInFutureError{EndTime: blockTime.Add(time.Second), BlockTime: blockTime},
+		},
+		"start time before the keep period": {
+			baseAsset: denom1, quoteAsset: denom0, startTime: blockTime.Add(-49 * time.Hour), endTime: blockTime,
+			expErr: types.TimeTooOldError{Time: blockTime.Add(-49 * time.Hour)},
+		},
+		"same denom": {
+			baseAsset: denom1, quoteAsset: denom1, startTime: baseTime, endTime: blockTime,
+			expErr: types.SameDenomError{Denom: denom1},
+		},
+	}
+
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.twapkeeper.SetParams(s.Ctx, basicParams)
+			for _, sample := range samples {
+				s.twapkeeper.StoreSpotPriceSample(s.Ctx, sample)
+			}
+			s.Ctx = s.Ctx.WithBlockTime(blockTime)
+
+			median, numSamples, err := s.twapkeeper.GetMedianSpotPrice(s.Ctx, basePoolId, test.baseAsset, test.quoteAsset, test.startTime, test.endTime)
+			if test.expErr != nil {
+				s.Require().Equal(s.withKeepPeriodDetails(test.expErr), err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(test.expMedian, median)
+			s.Require().Equal(test.expNumSamples, numSamples)
+		})
+	}
+}
+
+// TestMedianSpotPriceIgnoresSpike tests that the spot price of a tracked pool tripling for a single block, which
+// materially moves the arithmetic TWAP over the window, doesn't move the median spot price over it.
+func (s *TestSuite) TestMedianSpotPriceIgnoresSpike() {
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	s.trackMedian(poolId)
+	spotPrice, err := s.App.GAMMKeeper.CalculateSpotPrice(s.Ctx, poolId, denom1, denom0)
+	s.Require().NoError(err)
+
+	// 8 blocks at the spot price, one block at the spike, and 3 blocks back at about the spot price
+	for i := 0; i < 8; i++ {
+		s.EndBlock()
+		s.Commit()
+	}
+	s.ModifySpotPrice(poolId, spotPrice.MulInt64(3), denom0)
+	s.EndBlock()
+	s.Commit()
+	s.ModifySpotPrice(poolId, spotPrice, denom0)
+	for i := 0; i < 3; i++ {
+		s.EndBlock()
+		s.Commit()
+	}
+
+	twap, err := s.twapkeeper.GetArithmeticTwapToNow(s.Ctx, poolId, denom0, denom1, baseTime)
+	s.Require().NoError(err)
+	s.Require().True(twap.Sub(spotPrice).Quo(spotPrice).GT(sdk.NewDecWithPrec(1, 1)), "twap %s", twap)
+
+	median, numSamples, err := s.twapkeeper.GetMedianSpotPrice(s.Ctx, poolId, denom0, denom1, baseTime, s.Ctx.BlockTime())
+	s.Require().NoError(err)
+	s.Require().Equal(12, numSamples)
+	s.Require().Equal(spotPrice, median)
+
+	// untracked pools are not sampled
+	untrackedPoolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	s.EndBlock()
+	s.Commit()
+	_, _, err = s.twapkeeper.GetMedianSpotPrice(s.Ctx, untrackedPoolId, denom0, denom1, baseTime, s.Ctx.BlockTime())
+	s.Require().ErrorIs(err, types.ErrRecordNotFound)
+}
+
+// TestPruneSpotPriceSamples tests that the spot price samples older than the record history keep period are pruned
+// along with the records, from both of their indexes.
+func (s *TestSuite) TestPruneSpotPriceSamples() {
+	s.Ctx = s.Ctx.WithBlockTime(baseTime)
+	poolId := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	s.trackMedian(poolId)
+	s.EndBlock()
+	s.Commit()
+	s.EndBlock()
+
+	keepPeriod := s.twapkeeper.RecordHistoryKeepPeriod(s.Ctx)
+	s.Ctx = s.Ctx.WithBlockTime(tPlusOne.Add(keepPeriod))
+	s.EndBlock()
+	samples, err := s.twapkeeper.GetAllTimeIndexedSpotPriceSamples(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Len(samples, 9)
+
+	// the samples at baseTime are pruned, the ones at the last kept time are kept
+	s.Require().NoError(s.twapkeeper.PruneRecords(s.Ctx))
+	samples, err = s.twapkeeper.GetAllTimeIndexedSpotPriceSamples(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Len(samples, 6)
+	for _, sample := range samples {
+		s.Require().False(sample.Time.Before(tPlusOne), "sample at %s", sample.Time)
+	}
+	for _, pair := range [][2]string{{denom0, denom1}, {denom0, denom2}, {denom1, denom2}} {
+		samples, err := s.twapkeeper.GetSpotPriceSamples(s.Ctx, poolId, pair[0], pair[1], baseTime, s.Ctx.BlockTime())
+		s.Require().NoError(err)
+		s.Require().Len(samples, 2)
+		s.Require().Equal(tPlusOne, samples[0].Time)
+		s.Require().True(samples[0].P0SpotPrice.IsPositive())
+		s.Require().False(samples[0].SpotPriceError)
+	}
+}
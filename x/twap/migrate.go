@@ -1,7 +1,13 @@
 package twap
 
 import (
+	"errors"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
 // MigrateExistingPools iterates through all pools and creates state entry for the twap module.
@@ -14,3 +20,236 @@ func (k Keeper) MigrateExistingPools(ctx sdk.Context, latestPoolId uint64) error
 	}
 	return nil
 }
+
+// MigrateRecordSchemaVersion migrates the store from types.InitialStoreVersion to types.RecordSchemaVersionStoreVersion,
+// from which on records are stamped with a schema version. It is registered as the module's migration from
+// consensus version 1 to 2, so the SDK records the new version in the module version map once it has run.
+// Existing records are left untouched and keep a schema version of 0, so there is no state to migrate.
+func (k Keeper) MigrateRecordSchemaVersion(ctx sdk.Context) error {
+	return nil
+}
+
+// MigratePairPoolIndex migrates the store from types.RecordSchemaVersionStoreVersion to types.PairPoolIndexStoreVersion,
+// from which on the pools are indexed by the denom pairs of their most recent records. It is registered as the module's
+// migration from consensus version 2 to 3, and backfills the index from the existing most recent records.
+func (k Keeper) MigratePairPoolIndex(ctx sdk.Context) error {
+	records, err := types.GetAllMostRecentTwaps(ctx.KVStore(k.storeKey))
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		k.indexPairPool(ctx, record.PoolId, record.Asset0Denom, record.Asset1Denom)
+	}
+	return nil
+}
+
+// MigratePinParams sets the record pinning params, which were added after the twap params were first stored.
+// Pinning stays disabled until governance sets a pin authority.
+func (k Keeper) MigratePinParams(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyPinAuthority, types.DefaultPinAuthority)
+	k.paramSpace.Set(ctx, types.KeyMaxPinnedRecords, types.DefaultMaxPinnedRecords)
+}
+
+// MigrateEndBlockGasBudgetParam sets the EndBlock gas budget param, which was added after the twap params were first
+// stored. The EndBlock has no gas budget until governance sets one.
+func (k Keeper) MigrateEndBlockGasBudgetParam(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyEndBlockGasBudget, types.DefaultEndBlockGasBudget)
+}
+
+// MigrateSpotDeviationAlertParams sets the spot deviation alert params, which were added after the twap params were
+// first stored. The alerts stay disabled until governance sets a threshold.
+func (k Keeper) MigrateSpotDeviationAlertParams(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeySpotDeviationAlertThreshold, types.DefaultSpotDeviationAlertThreshold)
+	k.paramSpace.Set(ctx, types.KeySpotDeviationAlertWindow, types.DefaultSpotDeviationAlertWindow)
+}
+
+// MigrateMedianTrackedPoolsParam sets the median tracked pools param, which was added after the twap params were
+// first stored. No pool is sampled until governance opts pools in.
+func (k Keeper) MigrateMedianTrackedPoolsParam(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyMedianTrackedPools, types.DefaultMedianTrackedPools)
+}
+
+// MigrateCanonicalRouteParams sets the canonical route params, which were added after the twap params were first
+// stored. No canonical route can be set until governance sets a canonical route authority and quote denom.
+func (k Keeper) MigrateCanonicalRouteParams(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyCanonicalRouteAuthority, types.DefaultCanonicalRouteAuthority)
+	k.paramSpace.Set(ctx, types.KeyCanonicalQuoteDenom, types.DefaultCanonicalQuoteDenom)
+}
+
+// MigrateMaxPrunedRecordsPerBlockParam sets the max pruned records per block param, which was added after the twap
+// params were first stored.
+func (k Keeper) MigrateMaxPrunedRecordsPerBlockParam(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyMaxPrunedRecordsPerBlock, types.DefaultMaxPrunedRecordsPerBlock)
+}
+
+// MigrateParamsAuthorityParam sets the params authority param, which was added after the twap params were first
+// stored. The params can't be updated with MsgUpdateParams until governance sets a params authority.
+func (k Keeper) MigrateParamsAuthorityParam(ctx sdk.Context) {
+	k.paramSpace.Set(ctx, types.KeyParamsAuthority, types.DefaultParamsAuthority)
+}
+
+// RepairMostRecentIndex repairs the most recent records of pool poolId that diverge from the newest historical
+// record of their denom pair, so that the TWAPs to now and the TWAPs over past windows read the same records again.
+// For upgrade handlers, after a faulty migration. Of the two records, the newer one is kept:
+// * if the historical record is newer, or as new, the most recent record is rewritten from it.
+// * if the most recent record is newer, it is missing from the history, and is stored as a historical record.
+// Pairs without a most recent record are left as they are, as their records are no longer updated.
+// Returns the number of repaired pairs.
+func (k Keeper) RepairMostRecentIndex(ctx sdk.Context, poolId uint64) (int, error) {
+	records, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return 0, err
+	}
+	repaired := 0
+	for _, record := range records {
+		divergence, err := k.getMostRecentIndexDivergence(ctx, record)
+		if err != nil {
+			return repaired, err
+		}
+		if divergence == nil {
+			continue
+		}
+		ctx.Logger().Info(fmt.Sprintf("repairing twap most recent record index, %s", divergence))
+		if divergence.historicalHead == nil || divergence.historicalHead.Time.Before(record.Time) {
+			k.storeHistoricalTWAP(ctx, record)
+		} else {
+			key := types.FormatMostRecentTWAPKey(record.PoolId, record.Asset0Denom, record.Asset1Denom)
+			osmoutils.MustSet(ctx.KVStore(k.storeKey), key, divergence.historicalHead)
+			k.recordCache.invalidate(record.PoolId, record.Asset0Denom, record.Asset1Denom)
+		}
+		repaired++
+	}
+	return repaired, nil
+}
+
+// RepairMostRecentIndexBatch runs RepairMostRecentIndex on at most maxPools pools with records, from startPoolId on.
+// It returns the id of the pool to start the next batch from, or 0 once every pool is repaired, so that callers
+// can spread the repair across several calls.
+func (k Keeper) RepairMostRecentIndexBatch(ctx sdk.Context, startPoolId uint64, maxPools uint64) (nextPoolId uint64, repaired int, err error) {
+	if maxPools == 0 {
+		return 0, 0, errors.New("max pools must be positive")
+	}
+	poolIds, nextPoolId, err := types.GetMostRecentTwapPoolIds(ctx.KVStore(k.storeKey), startPoolId, maxPools)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, poolId := range poolIds {
+		n, err := k.RepairMostRecentIndex(ctx, poolId)
+		repaired += n
+		if err != nil {
+			return 0, repaired, err
+		}
+	}
+	return nextPoolId, repaired, nil
+}
+
+// RepairAllMostRecentIndexes runs RepairMostRecentIndex on every pool with records, batchSize pools at a time, for
+// upgrade handlers. The pool ids of a batch are read before repairing it, so that the store isn't written while
+// iterating it, and only one batch of pool ids is held at once.
+// Returns the number of repaired pairs.
+func (k Keeper) RepairAllMostRecentIndexes(ctx sdk.Context, batchSize uint64) (int, error) {
+	repaired := 0
+	for startPoolId := uint64(0); ; {
+		nextPoolId, n, err := k.RepairMostRecentIndexBatch(ctx, startPoolId, batchSize)
+		repaired += n
+		if err != nil {
+			return repaired, err
+		}
+		if nextPoolId == 0 {
+			return repaired, nil
+		}
+		startPoolId = nextPoolId
+	}
+}
+
+// MigratePairDenom renames oldDenom to newDenom in the records of every denom pair of pool poolId that contains it,
+// for upgrade handlers that rename a denom of the pool. The most recent, historical and pinned records of the pair
+// are moved to the keys of the renamed pair. When the rename flips the lexicographical order of the pair, the asset 0
+// and asset 1 fields of the records are swapped, so that the TWAPs computed across the migration are unchanged.
+// It errors if no record of the pool has oldDenom, or if the pool already has records for newDenom.
+func (k Keeper) MigratePairDenom(ctx sdk.Context, poolId uint64, oldDenom, newDenom string) error {
+	if oldDenom == newDenom {
+		return fmt.Errorf("cannot migrate denom %s to itself: %w", oldDenom, types.SameDenomError{Denom: oldDenom})
+	}
+	if err := sdk.ValidateDenom(newDenom); err != nil {
+		return err
+	}
+
+	mostRecentRecords, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	pairRecords := []types.TwapRecord{}
+	for _, record := range mostRecentRecords {
+		if record.Asset0Denom == newDenom || record.Asset1Denom == newDenom {
+			return types.DenomAlreadyInPoolError{PoolId: poolId, Denom: newDenom}
+		}
+		if record.Asset0Denom == oldDenom || record.Asset1Denom == oldDenom {
+			pairRecords = append(pairRecords, record)
+		}
+	}
+	if len(pairRecords) == 0 {
+		return types.DenomNotInPoolError{PoolId: poolId, Denom: oldDenom}
+	}
+
+	for _, mostRecentRecord := range pairRecords {
+		if err := k.migratePairRecords(ctx, mostRecentRecord, oldDenom, newDenom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migratePairRecords moves all the records of the denom pair of mostRecentRecord to the keys of the pair
+// with oldDenom renamed to newDenom, and moves the pool to the pool index of the renamed pair.
+func (k Keeper) migratePairRecords(ctx sdk.Context, mostRecentRecord types.TwapRecord, oldDenom, newDenom string) error {
+	store := ctx.KVStore(k.storeKey)
+	poolId, asset0Denom, asset1Denom := mostRecentRecord.PoolId, mostRecentRecord.Asset0Denom, mostRecentRecord.Asset1Denom
+
+	// the records are gathered before any is moved, so that the iterations don't see the moved records
+	historicalRecords, err := osmoutils.GatherValuesFromStorePrefix(store, types.FormatHistoricalPoolIndexTimePrefix(poolId, asset0Denom, asset1Denom), types.ParseTwapFromBz)
+	if err != nil {
+		return err
+	}
+	pinnedRecords, err := osmoutils.GatherValuesFromStorePrefix(store, types.FormatPinnedTWAPTimePrefix(poolId, asset0Denom, asset1Denom), types.ParseTwapFromBz)
+	if err != nil {
+		return err
+	}
+
+	k.deleteMostRecentRecord(ctx, poolId, asset0Denom, asset1Denom)
+	migratedRecord := migrateRecordDenom(mostRecentRecord, oldDenom, newDenom)
+	osmoutils.MustSet(store, types.FormatMostRecentTWAPKey(poolId, migratedRecord.Asset0Denom, migratedRecord.Asset1Denom), &migratedRecord)
+	k.recordCache.invalidate(poolId, migratedRecord.Asset0Denom, migratedRecord.Asset1Denom)
+	k.indexPairPool(ctx, poolId, migratedRecord.Asset0Denom, migratedRecord.Asset1Denom)
+
+	for _, record := range historicalRecords {
+		k.deleteHistoricalRecord(ctx, record)
+		k.storeHistoricalTWAP(ctx, migrateRecordDenom(record, oldDenom, newDenom))
+	}
+	for _, record := range pinnedRecords {
+		k.deletePinnedRecord(ctx, record)
+		k.storePinnedRecord(ctx, migrateRecordDenom(record, oldDenom, newDenom))
+	}
+	return nil
+}
+
+// migrateRecordDenom returns record with oldDenom renamed to newDenom.
+// If the rename flips the lexicographical order of the pair, the denoms, last spot prices and arithmetic
+// accumulators of asset 0 and asset 1 are swapped. The geometric accumulator sums the logarithm of asset 0's
+// spot price, so it is negated, as log(1/p) = -log(p).
+func migrateRecordDenom(record types.TwapRecord, oldDenom, newDenom string) types.TwapRecord {
+	if record.Asset0Denom == oldDenom {
+		record.Asset0Denom = newDenom
+	} else {
+		record.Asset1Denom = newDenom
+	}
+	if record.Asset0Denom < record.Asset1Denom {
+		return record
+	}
+
+	record.Asset0Denom, record.Asset1Denom = record.Asset1Denom, record.Asset0Denom
+	record.P0LastSpotPrice, record.P1LastSpotPrice = record.P1LastSpotPrice, record.P0LastSpotPrice
+	record.P0ArithmeticTwapAccumulator, record.P1ArithmeticTwapAccumulator = record.P1ArithmeticTwapAccumulator, record.P0ArithmeticTwapAccumulator
+	record.GeometricTwapAccumulator = record.GeometricTwapAccumulator.Neg()
+	return record
+}
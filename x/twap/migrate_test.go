@@ -1,11 +1,16 @@
 package twap_test
 
 import (
+	"testing"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
 
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	twapclient "github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
@@ -68,6 +73,108 @@ func (s *TestSuite) TestMigrateExistingPoolsError() {
 	s.Require().Error(err)
 }
 
+func (s *TestSuite) TestMigrateRecordSchemaVersion() {
+	querier := twapclient.Querier{K: *s.twapkeeper}
+	queryModuleVersion := func() *queryproto.ModuleVersionResponse {
+		res, err := querier.ModuleVersion(s.Ctx, queryproto.ModuleVersionRequest{})
+		s.Require().NoError(err)
+		s.Require().Equal(types.ConsensusVersion, res.ConsensusVersion)
+		s.Require().Equal(types.CurrentRecordSchemaVersion, res.RecordSchemaVersion)
+		return res
+	}
+
+	// a store initialized from genesis is already at the latest version
+	res := queryModuleVersion()
+	s.Require().Equal(types.LatestStoreVersion, res.StoreVersion)
+	s.Require().Contains(res.RecordExtensions, types.SchemaVersionExtension)
+
+	// suppose the store has not run any migration yet
+	fromVM := s.App.UpgradeKeeper.GetModuleVersionMap(s.Ctx)
+	fromVM[types.ModuleName] = types.InitialStoreVersion
+	s.App.UpgradeKeeper.SetModuleVersionMap(s.Ctx, fromVM)
+	res = queryModuleVersion()
+	s.Require().Equal(types.InitialStoreVersion, res.StoreVersion)
+	s.Require().Equal([]string{types.GeometricTwapAccumulatorExtension, types.LastErrorTimeExtension}, res.RecordExtensions)
+
+	// a record written before the migration has no schema version
+	legacyRecord := newEmptyPriceRecord(basePoolId, s.Ctx.BlockTime(), denom0, denom1)
+	s.twapkeeper.StoreNewRecord(s.Ctx, legacyRecord)
+
+	// run the registered migrations, as an upgrade handler does
+	toVM, err := s.App.ModuleManager().RunMigrations(s.Ctx, s.App.Configurator(), fromVM)
+	s.Require().NoError(err)
+	s.Require().Equal(types.ConsensusVersion, toVM[types.ModuleName])
+	s.App.UpgradeKeeper.SetModuleVersionMap(s.Ctx, toVM)
+
+	res = queryModuleVersion()
+	s.Require().Equal(types.LatestStoreVersion, res.StoreVersion)
+	s.Require().Equal([]string{types.GeometricTwapAccumulatorExtension, types.LastErrorTimeExtension, types.SchemaVersionExtension}, res.RecordExtensions)
+
+	// existing records are left untouched
+	record, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, basePoolId, denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(0), record.SchemaVersion)
+
+	// updating a record stamps it with the current schema version
+	updatedRecord := s.twapkeeper.UpdateRecord(s.Ctx, legacyRecord)
+	s.Require().Equal(types.CurrentRecordSchemaVersion, updatedRecord.SchemaVersion)
+
+	// so does creating one
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	record, err = s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Equal(types.CurrentRecordSchemaVersion, record.SchemaVersion)
+}
+
+func (s *TestSuite) TestMigratePairPoolIndex() {
+	poolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+
+	// suppose the pools were created before the pools were indexed by pair
+	store := s.Ctx.KVStore(s.App.GetKey(types.StoreKey))
+	iter := sdk.KVStorePrefixIterator(store, []byte(types.PairPoolIndexPrefix))
+	indexKeys := [][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		indexKeys = append(indexKeys, iter.Key())
+	}
+	iter.Close()
+	s.Require().Len(indexKeys, 4)
+	for _, key := range indexKeys {
+		store.Delete(key)
+	}
+	poolIds, err := s.twapkeeper.GetPoolIdsForDenomPair(s.Ctx, denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Empty(poolIds)
+
+	fromVM := s.App.UpgradeKeeper.GetModuleVersionMap(s.Ctx)
+	fromVM[types.ModuleName] = types.RecordSchemaVersionStoreVersion
+	toVM, err := s.App.ModuleManager().RunMigrations(s.Ctx, s.App.Configurator(), fromVM)
+	s.Require().NoError(err)
+	s.Require().Equal(types.PairPoolIndexStoreVersion, toVM[types.ModuleName])
+
+	// the index is backfilled from the most recent records
+	poolIds, err = s.twapkeeper.GetPoolIdsForDenomPair(s.Ctx, denom0, denom1)
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{poolIdAB, poolIdABC}, poolIds)
+	for _, pair := range [][2]string{{denom0, denom2}, {denom1, denom2}} {
+		poolIds, err = s.twapkeeper.GetPoolIdsForDenomPair(s.Ctx, pair[0], pair[1])
+		s.Require().NoError(err)
+		s.Require().Equal([]uint64{poolIdABC}, poolIds)
+	}
+}
+
+func TestRecordExtensionsForStoreVersion(t *testing.T) {
+	require.Equal(t, []string{}, types.RecordExtensionsForStoreVersion(0))
+	require.Equal(t, []string{types.GeometricTwapAccumulatorExtension, types.LastErrorTimeExtension},
+		types.RecordExtensionsForStoreVersion(types.InitialStoreVersion))
+	require.Equal(t, []string{types.GeometricTwapAccumulatorExtension, types.LastErrorTimeExtension, types.SchemaVersionExtension},
+		types.RecordExtensionsForStoreVersion(types.RecordSchemaVersionStoreVersion))
+	require.Equal(t, types.RecordExtensionsForStoreVersion(types.RecordSchemaVersionStoreVersion),
+		types.RecordExtensionsForStoreVersion(types.PairPoolIndexStoreVersion))
+	require.Equal(t, types.RecordExtensionsForStoreVersion(types.LatestStoreVersion),
+		types.RecordExtensionsForStoreVersion(types.LatestStoreVersion+1))
+}
+
 // TestTwapRecord_GeometricTwap_MarshalUnmarshal this test proves that migrations
 // to initialize geometric twap accumulators are not required.
 // This is because proto marshalling will initialize the field to the zero value.
@@ -93,3 +200,112 @@ func (suite *TestSuite) TestTwapRecord_GeometricTwap_MarshalUnmarshal() {
 	suite.Require().False(originalRecord.GeometricTwapAccumulator.IsNil())
 	suite.Require().Equal(sdk.ZeroDec(), originalRecord.GeometricTwapAccumulator)
 }
+
+func (s *TestSuite) TestMigratePairDenom() {
+	// records of pool 1 at three times with different spot prices, the first of which is pinned
+	withSpotPrice := func(record types.TwapRecord, sp0 sdk.Dec) types.TwapRecord {
+		return withSp1(withSp0(record, sp0), sdk.OneDec().Quo(sp0))
+	}
+	firstRecord := newRecord(basePoolId, baseTime, sdk.NewDec(2), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	secondRecord := withSpotPrice(twap.RecordWithUpdatedAccumulators(firstRecord, baseTime.Add(10*time.Second)), sdk.NewDec(4))
+	thirdRecord := withSpotPrice(twap.RecordWithUpdatedAccumulators(secondRecord, baseTime.Add(20*time.Second)), sdk.NewDecWithPrec(5, 1))
+	records := []types.TwapRecord{firstRecord, secondRecord, thirdRecord}
+	startTime, endTime := baseTime.Add(5*time.Second), baseTime.Add(30*time.Second)
+
+	testCases := []struct {
+		name     string
+		oldDenom string
+		newDenom string
+
+		expectFlip  bool
+		expectErr   bool
+		expectedErr error
+	}{
+		{"asset 0 renamed, order kept", denom0, "token/0", false, false, nil},
+		{"asset 0 renamed, order flipped", denom0, "token/D", true, false, nil},
+		{"asset 1 renamed, order kept", denom1, "token/Z", false, false, nil},
+		{"asset 1 renamed, order flipped", denom1, "token/0", true, false, nil},
+		{"denom not in the pool", denom2, "token/D", false, true, types.DenomNotInPoolError{PoolId: basePoolId, Denom: denom2}},
+		{"new denom already in the pool", denom0, denom1, false, true, types.DenomAlreadyInPoolError{PoolId: basePoolId, Denom: denom1}},
+		{"denom renamed to itself", denom0, denom0, false, true, types.SameDenomError{Denom: denom0}},
+		{"invalid new denom", denom0, "", false, true, nil},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.Ctx = s.Ctx.WithBlockTime(endTime)
+			s.preSetRecords(records)
+			s.twapkeeper.StorePinnedRecord(s.Ctx, firstRecord)
+
+			// the TWAPs are quoted in the denom that is not renamed
+			otherDenom := denom1
+			if tc.oldDenom == denom1 {
+				otherDenom = denom0
+			}
+			// the geometric TWAP is compared through the growth of the accumulator it is computed from, which is that of
+			// the logarithm of the spot price of asset 0: twapPow doesn't converge for the negative mean logarithm of one
+			// of the orders of a flipped pair
+			computeTwaps := func(renamedDenom string) (arithmeticTwap, geometricAccumulatorGrowth sdk.Dec) {
+				startRecord, err := s.twapkeeper.GetInterpolatedRecord(s.Ctx, basePoolId, renamedDenom, otherDenom, startTime)
+				s.Require().NoError(err)
+				endRecord, err := s.twapkeeper.GetInterpolatedRecord(s.Ctx, basePoolId, renamedDenom, otherDenom, endTime)
+				s.Require().NoError(err)
+				return twap.ComputeArithmeticTwap(startRecord, endRecord, otherDenom), endRecord.GeometricTwapAccumulator.Sub(startRecord.GeometricTwapAccumulator)
+			}
+			var arithmeticTwapBefore, geometricGrowthBefore sdk.Dec
+			if !tc.expectErr {
+				arithmeticTwapBefore, geometricGrowthBefore = computeTwaps(tc.oldDenom)
+			}
+
+			err := s.twapkeeper.MigratePairDenom(s.Ctx, basePoolId, tc.oldDenom, tc.newDenom)
+			if tc.expectErr {
+				s.Require().Error(err)
+				if tc.expectedErr != nil {
+					s.Require().ErrorIs(err, tc.expectedErr)
+				}
+				_, err = s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, basePoolId, denom0, denom1)
+				s.Require().NoError(err)
+				return
+			}
+			s.Require().NoError(err)
+
+			// no record is left under the old pair
+			_, err = s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, basePoolId, denom0, denom1)
+			s.Require().ErrorIs(err, types.ErrRecordNotFound)
+
+			asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(tc.newDenom, otherDenom)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectFlip, (asset0Denom == tc.newDenom) != (tc.oldDenom == denom0))
+
+			expectRecord := func(record types.TwapRecord) types.TwapRecord {
+				record.Asset0Denom, record.Asset1Denom = asset0Denom, asset1Denom
+				if tc.expectFlip {
+					record.P0LastSpotPrice, record.P1LastSpotPrice = record.P1LastSpotPrice, record.P0LastSpotPrice
+					record.P0ArithmeticTwapAccumulator, record.P1ArithmeticTwapAccumulator = record.P1ArithmeticTwapAccumulator, record.P0ArithmeticTwapAccumulator
+					record.GeometricTwapAccumulator = record.GeometricTwapAccumulator.Neg()
+				}
+				return record
+			}
+			mostRecentRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, basePoolId, asset0Denom, asset1Denom)
+			s.Require().NoError(err)
+			s.Require().Equal(expectRecord(thirdRecord), mostRecentRecord)
+			expectedRecords := []types.TwapRecord{expectRecord(firstRecord), expectRecord(secondRecord), expectRecord(thirdRecord)}
+			s.Require().Equal(expectedRecords, s.getAllHistoricalRecordsForPool(basePoolId))
+			timeIndexedRecords, err := s.twapkeeper.GetAllHistoricalTimeIndexedTWAPs(s.Ctx)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedRecords, timeIndexedRecords)
+			pinnedRecords, err := s.twapkeeper.GetPinnedRecords(s.Ctx)
+			s.Require().NoError(err)
+			s.Require().Equal([]types.TwapRecord{expectRecord(firstRecord)}, pinnedRecords)
+
+			// the TWAPs across the migration are unchanged
+			arithmeticTwapAfter, geometricGrowthAfter := computeTwaps(tc.newDenom)
+			s.Require().Equal(arithmeticTwapBefore, arithmeticTwapAfter)
+			if tc.expectFlip {
+				geometricGrowthAfter = geometricGrowthAfter.Neg()
+			}
+			s.Require().Equal(geometricGrowthBefore, geometricGrowthAfter)
+		})
+	}
+}
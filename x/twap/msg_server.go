@@ -0,0 +1,116 @@
+package twap
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+type msgServer struct {
+	keeper *Keeper
+}
+
+// NewMsgServerImpl returns an implementation of the MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(keeper *Keeper) types.MsgServer {
+	return &msgServer{keeper: keeper}
+}
+
+var _ types.MsgServer = msgServer{}
+
+func (server msgServer) PinTwapRecord(goCtx context.Context, msg *types.MsgPinTwapRecord) (*types.MsgPinTwapRecordResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	record, err := server.keeper.PinRecord(ctx, msg.Sender, msg.PoolId, msg.Denom0, msg.Denom1, msg.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	emitPinEvent(ctx, types.TypeEvtPinTwapRecord, msg.Sender, record)
+	return &types.MsgPinTwapRecordResponse{RecordTime: record.Time}, nil
+}
+
+func (server msgServer) UnpinTwapRecord(goCtx context.Context, msg *types.MsgUnpinTwapRecord) (*types.MsgUnpinTwapRecordResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	record, err := server.keeper.UnpinRecord(ctx, msg.Sender, msg.PoolId, msg.Denom0, msg.Denom1, msg.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	emitPinEvent(ctx, types.TypeEvtUnpinTwapRecord, msg.Sender, record)
+	return &types.MsgUnpinTwapRecordResponse{RecordTime: record.Time}, nil
+}
+
+func (server msgServer) SetCanonicalTwapRoute(goCtx context.Context, msg *types.MsgSetCanonicalTwapRoute) (*types.MsgSetCanonicalTwapRouteResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := server.keeper.SetCanonicalTwapRoute(ctx, msg.Sender, msg.CanonicalRoute); err != nil {
+		return nil, err
+	}
+
+	hops := make([]string, 0, len(msg.CanonicalRoute.Hops))
+	for _, hop := range msg.CanonicalRoute.Hops {
+		hops = append(hops, strconv.FormatUint(hop.PoolId, 10)+":"+hop.QuoteAsset)
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeEvtSetCanonicalTwapRoute,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeDenom, msg.CanonicalRoute.Denom),
+			sdk.NewAttribute(types.AttributeRoute, strings.Join(hops, ",")),
+		),
+	})
+	return &types.MsgSetCanonicalTwapRouteResponse{}, nil
+}
+
+func (server msgServer) RemoveCanonicalTwapRoute(goCtx context.Context, msg *types.MsgRemoveCanonicalTwapRoute) (*types.MsgRemoveCanonicalTwapRouteResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := server.keeper.RemoveCanonicalTwapRoute(ctx, msg.Sender, msg.Denom); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeEvtRemoveCanonicalTwapRoute,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeDenom, msg.Denom),
+		),
+	})
+	return &types.MsgRemoveCanonicalTwapRouteResponse{}, nil
+}
+
+func (server msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := server.keeper.UpdateParams(ctx, msg.Sender, msg.Params); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeEvtUpdateParams,
+			sdk.NewAttribute(types.AttributeSender, msg.Sender),
+			sdk.NewAttribute(types.AttributeRecordHistoryKeepPeriod, msg.Params.RecordHistoryKeepPeriod.String()),
+		),
+	})
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+func emitPinEvent(ctx sdk.Context, eventType string, sender string, record types.TwapRecord) {
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			eventType,
+			sdk.NewAttribute(types.AttributeSender, sender),
+			sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(record.PoolId, 10)),
+			sdk.NewAttribute(types.AttributeDenom0, record.Asset0Denom),
+			sdk.NewAttribute(types.AttributeDenom1, record.Asset1Denom),
+			sdk.NewAttribute(types.AttributeRecordTime, record.Time.String()),
+		),
+	})
+}
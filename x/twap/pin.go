@@ -0,0 +1,77 @@
+package twap
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// PinRecord pins the historical record of the (poolId, denom0, denom1) pair that is at or before time t,
+// so that it is not pruned once it is older than the record history keep period.
+// Returns the pinned record.
+//
+// This function will error if:
+// * sender is not the pin authority, or pinning is disabled
+// * the maximum number of pinned records is reached
+// * there is no record at or before t, e.g. because it was already pruned
+// * the record is already pinned
+func (k Keeper) PinRecord(ctx sdk.Context, sender string, poolId uint64, denom0, denom1 string, t time.Time) (types.TwapRecord, error) {
+	params := k.GetParams(ctx)
+	if err := checkPinAuthority(params, sender); err != nil {
+		return types.TwapRecord{}, err
+	}
+
+	pinnedRecords, err := k.getAllPinnedRecords(ctx)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+	if uint64(len(pinnedRecords)) >= params.MaxPinnedRecords {
+		return types.TwapRecord{}, types.MaxPinnedRecordsError{MaxPinnedRecords: params.MaxPinnedRecords}
+	}
+
+	record, err := k.getRecordAtOrBeforeTime(ctx, poolId, t, denom0, denom1)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+	if k.isRecordPinned(ctx, record) {
+		return types.TwapRecord{}, types.RecordAlreadyPinnedError{PoolId: poolId, RecordTime: record.Time}
+	}
+
+	k.storePinnedRecord(ctx, record)
+	return record, nil
+}
+
+// UnpinRecord unpins the pinned record of the (poolId, denom0, denom1) pair that is at or before time t.
+// The record gets pruned at the next pruning if it is older than the record history keep period.
+// Returns the unpinned record.
+func (k Keeper) UnpinRecord(ctx sdk.Context, sender string, poolId uint64, denom0, denom1 string, t time.Time) (types.TwapRecord, error) {
+	if err := checkPinAuthority(k.GetParams(ctx), sender); err != nil {
+		return types.TwapRecord{}, err
+	}
+
+	denom0, denom1, err := types.LexicographicalOrderDenoms(denom0, denom1)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+	record, err := k.getPinnedRecordAtOrBeforeTime(ctx, poolId, t, denom0, denom1)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+
+	k.deletePinnedRecord(ctx, record)
+	return record, nil
+}
+
+// GetPinnedRecords returns all pinned records.
+func (k Keeper) GetPinnedRecords(ctx sdk.Context) ([]types.TwapRecord, error) {
+	return k.getAllPinnedRecords(ctx)
+}
+
+func checkPinAuthority(params types.Params, sender string) error {
+	if params.PinAuthority == "" || params.PinAuthority != sender {
+		return types.PinUnauthorizedError{Sender: sender, PinAuthority: params.PinAuthority}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// setPinParams enables pinning with the first test account as the pin authority.
+func (s *TestSuite) setPinParams(maxPinnedRecords uint64) string {
+	authority := s.TestAccs[0].String()
+	params := s.twapkeeper.GetParams(s.Ctx)
+	params.PinAuthority = authority
+	params.MaxPinnedRecords = maxPinnedRecords
+	s.twapkeeper.SetParams(s.Ctx, params)
+	return authority
+}
+
+func (s *TestSuite) TestPinRecord() {
+	recordMin2S := newEmptyPriceRecord(1, baseTime.Add(-2*time.Second), denom0, denom1)
+	recordMin1S := newEmptyPriceRecord(1, baseTime.Add(-time.Second), denom0, denom1)
+	recordBase := newEmptyPriceRecord(1, baseTime, denom0, denom1)
+
+	// the test cases are built with the accounts of the current setup, which SetupTest replaces
+	accs := s.TestAccs
+	tests := map[string]struct {
+		alreadyPinned    []types.TwapRecord
+		maxPinnedRecords uint64
+		disablePinning   bool
+		sender           string
+		time             time.Time
+		// flipped denoms must resolve to the same record
+		flipDenoms bool
+
+		expectedRecord types.TwapRecord
+		expectedErr    error
+	}{
+		"exact time": {
+			time:           recordMin1S.Time,
+			expectedRecord: recordMin1S,
+		},
+		"time between records pins the one before": {
+			time:           recordMin1S.Time.Add(time.Millisecond),
+			expectedRecord: recordMin1S,
+		},
+		"flipped denoms": {
+			time:           baseTime,
+			flipDenoms:     true,
+			expectedRecord: recordBase,
+		},
+		"another record already pinned": {
+			alreadyPinned:  []types.TwapRecord{recordMin2S},
+			time:           baseTime,
+			expectedRecord: recordBase,
+		},
+		"error: not the pin authority": {
+			sender:      s.TestAccs[1].String(),
+			time:        baseTime,
+			expectedErr: types.PinUnauthorizedError{Sender: s.TestAccs[1].String(), PinAuthority: s.TestAccs[0].String()},
+		},
+		"error: pinning disabled": {
+			disablePinning: true,
+			time:           baseTime,
+			expectedErr:    types.PinUnauthorizedError{Sender: s.TestAccs[0].String()},
+		},
+		"error: record already pinned": {
+			alreadyPinned: []types.TwapRecord{recordMin1S},
+			time:          recordMin1S.Time.Add(time.Millisecond),
+			expectedErr:   types.RecordAlreadyPinnedError{PoolId: 1, RecordTime: recordMin1S.Time},
+		},
+		"error: max pinned records reached": {
+			alreadyPinned:    []types.TwapRecord{recordMin2S},
+			maxPinnedRecords: 1,
+			time:             baseTime,
+			expectedErr:      types.MaxPinnedRecordsError{MaxPinnedRecords: 1},
+		},
+		"error: no record at or before time": {
+			time:        recordMin2S.Time.Add(-time.Millisecond),
+			expectedErr: types.TimeTooOldError{Time: recordMin2S.Time.Add(-time.Millisecond)},
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.TestAccs = accs
+			maxPinnedRecords := types.DefaultMaxPinnedRecords
+			if tc.maxPinnedRecords != 0 {
+				maxPinnedRecords = tc.maxPinnedRecords
+			}
+			authority := s.setPinParams(maxPinnedRecords)
+			s.preSetRecords([]types.TwapRecord{recordMin2S, recordMin1S, recordBase})
+			for _, record := range tc.alreadyPinned {
+				_, err := s.twapkeeper.PinRecord(s.Ctx, authority, record.PoolId, record.Asset0Denom, record.Asset1Denom, record.Time)
+				s.Require().NoError(err)
+			}
+			if tc.disablePinning {
+				params := s.twapkeeper.GetParams(s.Ctx)
+				params.PinAuthority = ""
+				s.twapkeeper.SetParams(s.Ctx, params)
+			}
+
+			sender := authority
+			if tc.sender != "" {
+				sender = tc.sender
+			}
+			quoteDenom, baseDenom := denom0, denom1
+			if tc.flipDenoms {
+				quoteDenom, baseDenom = denom1, denom0
+			}
+
+			record, err := s.twapkeeper.PinRecord(s.Ctx, sender, 1, quoteDenom, baseDenom, tc.time)
+			if tc.expectedErr != nil {
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(tc.expectedErr))
+				pinned, err := s.twapkeeper.GetPinnedRecords(s.Ctx)
+				s.Require().NoError(err)
+				s.Require().Len(pinned, len(tc.alreadyPinned))
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedRecord, record)
+
+			pinned, err := s.twapkeeper.GetPinnedRecords(s.Ctx)
+			s.Require().NoError(err)
+			s.Require().Contains(pinned, tc.expectedRecord)
+			s.Require().Len(pinned, len(tc.alreadyPinned)+1)
+		})
+	}
+}
+
+func (s *TestSuite) TestUnpinRecord() {
+	recordMin1S := newEmptyPriceRecord(1, baseTime.Add(-time.Second), denom0, denom1)
+	recordBase := newEmptyPriceRecord(1, baseTime, denom0, denom1)
+
+	s.SetupTest()
+	authority := s.setPinParams(types.DefaultMaxPinnedRecords)
+	s.preSetRecords([]types.TwapRecord{recordMin1S, recordBase})
+
+	_, err := s.twapkeeper.PinRecord(s.Ctx, authority, 1, denom0, denom1, recordMin1S.Time)
+	s.Require().NoError(err)
+
+	// only the pin authority may unpin.
+	_, err = s.twapkeeper.UnpinRecord(s.Ctx, s.TestAccs[1].String(), 1, denom0, denom1, baseTime)
+	s.Require().ErrorIs(err, types.PinUnauthorizedError{Sender: s.TestAccs[1].String(), PinAuthority: authority})
+
+	// no pinned record at or before the given time.
+	tooEarly := recordMin1S.Time.Add(-time.Millisecond)
+	_, err = s.twapkeeper.UnpinRecord(s.Ctx, authority, 1, denom0, denom1, tooEarly)
+	s.Require().ErrorIs(err, types.PinnedRecordNotFoundError{PoolId: 1, Time: tooEarly})
+
+	// a time after the pinned record resolves to it, even though recordBase is unpinned.
+	unpinned, err := s.twapkeeper.UnpinRecord(s.Ctx, authority, 1, denom1, denom0, baseTime)
+	s.Require().NoError(err)
+	s.Require().Equal(recordMin1S, unpinned)
+
+	pinned, err := s.twapkeeper.GetPinnedRecords(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(pinned)
+
+	// unpinning twice fails.
+	_, err = s.twapkeeper.UnpinRecord(s.Ctx, authority, 1, denom0, denom1, baseTime)
+	s.Require().ErrorIs(err, types.PinnedRecordNotFoundError{PoolId: 1, Time: baseTime})
+}
+
+// TestPruneRecords_PinnedRecords tests that pruning preserves pinned records
+// while deleting their unpinned neighbors, and that unpinned records
+// are pruned again at the next pruning.
+func (s *TestSuite) TestPruneRecords_PinnedRecords() {
+	recordMin3S := newEmptyPriceRecord(1, baseTime.Add(-3*time.Second), denom0, denom1)
+	recordMin2S := newEmptyPriceRecord(1, baseTime.Add(-2*time.Second), denom0, denom1)
+	recordMin1S := newEmptyPriceRecord(1, baseTime.Add(-time.Second), denom0, denom1)
+	recordBase := newEmptyPriceRecord(1, baseTime, denom0, denom1)
+	otherPoolMin2S := newEmptyPriceRecord(2, baseTime.Add(-2*time.Second), denom0, denom1)
+	otherPoolMin1S := newEmptyPriceRecord(2, baseTime.Add(-time.Second), denom0, denom1)
+	otherPoolBase := newEmptyPriceRecord(2, baseTime, denom0, denom1)
+
+	s.SetupTest()
+	authority := s.setPinParams(types.DefaultMaxPinnedRecords)
+	s.preSetRecords([]types.TwapRecord{recordMin3S, recordMin2S, recordMin1S, recordBase, otherPoolMin2S, otherPoolMin1S, otherPoolBase})
+
+	_, err := s.twapkeeper.PinRecord(s.Ctx, authority, 1, denom0, denom1, recordMin2S.Time)
+	s.Require().NoError(err)
+
+	// recordMin1S is kept as the newest record before the last kept time,
+	// recordMin2S is kept since it is pinned, and the other pool is unaffected by the pin.
+	err = s.twapkeeper.PruneRecordsBeforeTimeButNewest(s.Ctx, baseTime)
+	s.Require().NoError(err)
+	s.validateExpectedRecords([]types.TwapRecord{recordMin2S, recordMin1S, otherPoolMin1S, recordBase, otherPoolBase})
+
+	// pinned records are also kept when they are the oldest candidates on the next pruning.
+	err = s.twapkeeper.PruneRecordsBeforeTimeButNewest(s.Ctx, baseTime.Add(time.Second))
+	s.Require().NoError(err)
+	s.validateExpectedRecords([]types.TwapRecord{recordMin2S, recordBase, otherPoolBase})
+
+	pinned, err := s.twapkeeper.GetPinnedRecords(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().Equal([]types.TwapRecord{recordMin2S}, pinned)
+
+	// once unpinned, the record is pruned.
+	_, err = s.twapkeeper.UnpinRecord(s.Ctx, authority, 1, denom0, denom1, recordMin2S.Time)
+	s.Require().NoError(err)
+	err = s.twapkeeper.PruneRecordsBeforeTimeButNewest(s.Ctx, baseTime.Add(time.Second))
+	s.Require().NoError(err)
+	s.validateExpectedRecords([]types.TwapRecord{recordBase, otherPoolBase})
+}
+
+func (s *TestSuite) TestPinMsgServerAndQuery() {
+	record := newEmptyPriceRecord(1, baseTime, denom0, denom1)
+
+	s.SetupTest()
+	authority := s.setPinParams(types.DefaultMaxPinnedRecords)
+	s.preSetRecords([]types.TwapRecord{record})
+
+	msgServer := twap.NewMsgServerImpl(s.twapkeeper)
+	querier := client.Querier{K: *s.twapkeeper}
+	ctx := sdk.WrapSDKContext(s.Ctx)
+
+	pinRes, err := msgServer.PinTwapRecord(ctx, types.NewMsgPinTwapRecord(authority, 1, denom0, denom1, baseTime.Add(time.Second)))
+	s.Require().NoError(err)
+	s.Require().Equal(record.Time, pinRes.RecordTime)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtPinTwapRecord, 1)
+
+	queryRes, err := querier.PinnedRecords(s.Ctx, queryproto.PinnedRecordsRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal([]types.TwapRecord{record}, queryRes.Records)
+
+	unpinRes, err := msgServer.UnpinTwapRecord(ctx, types.NewMsgUnpinTwapRecord(authority, 1, denom0, denom1, baseTime))
+	s.Require().NoError(err)
+	s.Require().Equal(record.Time, unpinRes.RecordTime)
+	s.AssertEventEmitted(s.Ctx, types.TypeEvtUnpinTwapRecord, 1)
+
+	queryRes, err = querier.PinnedRecords(s.Ctx, queryproto.PinnedRecordsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(queryRes.Records)
+}
@@ -0,0 +1,190 @@
+package twap
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// recordDenoms returns the sorted denoms of the given records, without duplicates.
+func recordDenoms(records []types.TwapRecord) []string {
+	denoms := make([]string, 0, 2*len(records))
+	for _, record := range records {
+		denoms = append(denoms, record.Asset0Denom, record.Asset1Denom)
+	}
+	return sortedDenoms(denoms)
+}
+
+// sortedDenoms returns a sorted copy of denoms, without duplicates.
+func sortedDenoms(denoms []string) []string {
+	sorted := []string{}
+	seen := map[string]bool{}
+	for _, denom := range denoms {
+		if !seen[denom] {
+			seen[denom] = true
+			sorted = append(sorted, denom)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func denomsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// quarantinePool stops the record updates of pool poolId, since the denoms its AmmInterface reports
+// no longer match the denoms of its most recent records.
+func (k Keeper) quarantinePool(ctx sdk.Context, poolId uint64, recordDenoms, poolDenoms []string) {
+	quarantined := types.QuarantinedPool{
+		PoolId:       poolId,
+		Height:       ctx.BlockHeight(),
+		Time:         ctx.BlockTime(),
+		RecordDenoms: recordDenoms,
+		PoolDenoms:   poolDenoms,
+	}
+	osmoutils.MustSet(ctx.KVStore(k.storeKey), types.FormatQuarantinedPoolKey(poolId), &quarantined)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtQuarantinePool,
+		sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(types.AttributeRecordDenoms, strings.Join(recordDenoms, ",")),
+		sdk.NewAttribute(types.AttributePoolDenoms, strings.Join(poolDenoms, ",")),
+	))
+}
+
+// GetQuarantinedPool returns the quarantine of pool poolId, and false if the pool is not quarantined.
+func (k Keeper) GetQuarantinedPool(ctx sdk.Context, poolId uint64) (types.QuarantinedPool, bool) {
+	quarantined := types.QuarantinedPool{}
+	found, err := osmoutils.Get(ctx.KVStore(k.storeKey), types.FormatQuarantinedPoolKey(poolId), &quarantined)
+	if err != nil {
+		panic(err)
+	}
+	return quarantined, found
+}
+
+func (k Keeper) isPoolQuarantined(ctx sdk.Context, poolId uint64) bool {
+	return ctx.KVStore(k.storeKey).Has(types.FormatQuarantinedPoolKey(poolId))
+}
+
+// RepairQuarantinedPool rebuilds the records of quarantined pool poolId for the denoms the pool currently has,
+// and lifts its quarantine. The quarantine is recorded as a tracking gap of the pool, see GetTrackingGaps.
+// Denom pairs the pool still has keep their records, which are updated to the current block.
+// New denom pairs get baseline records with the pool's current spot prices, so their TWAPs can be queried
+// from the current block time on. The most recent records of the denom pairs the pool no longer has are
+// deleted along with their entries in the pair pool index, their historical records are left to pruning.
+// Returns an error if the pool is not quarantined, or if the spot prices of a new denom pair can't be computed.
+func (k Keeper) RepairQuarantinedPool(ctx sdk.Context, poolId uint64) error {
+	quarantined, found := k.GetQuarantinedPool(ctx, poolId)
+	if !found {
+		return types.PoolNotQuarantinedError{PoolId: poolId}
+	}
+
+	oldRecords, err := k.getAllMostRecentRecordsForPool(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	baselineRecords, err := k.newRecordsForPool(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	oldRecordsByPair := map[types.DenomPair]types.TwapRecord{}
+	for _, record := range oldRecords {
+		oldRecordsByPair[types.DenomPair{Denom0: record.Asset0Denom, Denom1: record.Asset1Denom}] = record
+	}
+	keptPairs := map[types.DenomPair]bool{}
+	for _, record := range baselineRecords {
+		pair := types.DenomPair{Denom0: record.Asset0Denom, Denom1: record.Asset1Denom}
+		if oldRecord, ok := oldRecordsByPair[pair]; ok {
+			record = k.updateRecord(ctx, oldRecord)
+			keptPairs[pair] = true
+		}
+		k.storeNewRecord(ctx, record)
+	}
+
+	for _, record := range oldRecords {
+		if !keptPairs[types.DenomPair{Denom0: record.Asset0Denom, Denom1: record.Asset1Denom}] {
+			k.deleteMostRecentRecord(ctx, poolId, record.Asset0Denom, record.Asset1Denom)
+		}
+	}
+	ctx.KVStore(k.storeKey).Delete(types.FormatQuarantinedPoolKey(poolId))
+	k.storeTrackingGap(ctx, types.TrackingGap{PoolId: poolId, From: quarantined.Time, To: ctx.BlockTime()})
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.TypeEvtRepairPool,
+		sdk.NewAttribute(types.AttributePoolId, strconv.FormatUint(poolId, 10)),
+	))
+	return nil
+}
+
+func (k Keeper) storeTrackingGap(ctx sdk.Context, gap types.TrackingGap) {
+	ctx.KVStore(k.storeKey).Set(types.FormatTrackingGapKey(gap.PoolId, gap.From), []byte(osmoutils.FormatTimeString(gap.To)))
+}
+
+// GetTrackingGaps returns the periods during which the records of pool poolId were not updated, from the start of
+// each of its quarantines to its repair, in time order. The quarantine in progress, if any, is not included: the
+// TWAPs of a quarantined pool can't be queried.
+func (k Keeper) GetTrackingGaps(ctx sdk.Context, poolId uint64) ([]types.TrackingGap, error) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.FormatTrackingGapPrefix(poolId))
+	defer iterator.Close()
+	gaps := []types.TrackingGap{}
+	for ; iterator.Valid(); iterator.Next() {
+		gap, err := types.ParseTrackingGap(poolId, iterator.Key(), iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps, nil
+}
+
+// checkTrackingGaps returns a TrackingGapError for the first tracking gap of pool poolId overlapping the window
+// from startTime to endTime, if any.
+func (k Keeper) checkTrackingGaps(ctx sdk.Context, poolId uint64, startTime, endTime time.Time) error {
+	gaps, err := k.GetTrackingGaps(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	for _, gap := range gaps {
+		if gap.Overlaps(startTime, endTime) {
+			return types.TrackingGapError{PoolId: poolId, From: gap.From, To: gap.To}
+		}
+	}
+	return nil
+}
+
+// trackedFraction returns the fraction of the window from startTime to endTime outside of the tracking gaps of
+// pool poolId. An empty window is either tracked or within a gap.
+func (k Keeper) trackedFraction(ctx sdk.Context, poolId uint64, startTime, endTime time.Time) (sdk.Dec, error) {
+	gaps, err := k.GetTrackingGaps(ctx, poolId)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	window := types.AccumulatorTimeDelta(startTime, endTime)
+	untracked := time.Duration(0)
+	for _, gap := range gaps {
+		if window == 0 && gap.Overlaps(startTime, endTime) {
+			return sdk.ZeroDec(), nil
+		}
+		untracked += gap.OverlapDuration(startTime, endTime)
+	}
+	if window == 0 {
+		return sdk.OneDec(), nil
+	}
+	return sdk.NewDec((window - untracked).Milliseconds()).QuoInt64(window.Milliseconds()), nil
+}
@@ -0,0 +1,208 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	twapclient "github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types/twapmock"
+)
+
+// TestQuarantineAndRepairPool tests that a pool whose denoms no longer match its records is quarantined
+// instead of updated, that its TWAPs can't be queried, and that repairing it rebuilds its records.
+func (s *TestSuite) TestQuarantineAndRepairPool() {
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	creationRecord, err := s.twapkeeper.GetMostRecentRecordStoreRepresentation(s.Ctx, poolId, denom0, denom1)
+	s.Require().NoError(err)
+	querier := twapclient.Querier{K: *s.twapkeeper}
+
+	// the pool now reports a third denom
+	mockAMMI := twapmock.NewProgrammedAmmInterface(s.App.TwapKeeper.GetAmmInterface())
+	mockAMMI.ProgramPoolDenomsOverride(poolId, []string{denom2, denom1, denom0}, nil)
+	mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom0, denom2, sdk.NewDec(2), nil)
+	mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom2, denom0, sdk.NewDecWithPrec(5, 1), nil)
+	mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom1, denom2, sdk.NewDec(4), nil)
+	mockAMMI.ProgramPoolSpotPriceOverride(poolId, denom2, denom1, sdk.NewDecWithPrec(25, 2), nil)
+	s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+
+	quarantineCtx := s.Ctx.WithBlockTime(s.Ctx.BlockTime().Add(time.Second)).WithBlockHeight(s.Ctx.BlockHeight() + 1).WithEventManager(sdk.NewEventManager())
+	s.Require().NoError(s.twapkeeper.UpdateRecords(quarantineCtx, poolId))
+
+	expectedQuarantine := types.QuarantinedPool{
+		PoolId:       poolId,
+		Height:       quarantineCtx.BlockHeight(),
+		Time:         quarantineCtx.BlockTime(),
+		RecordDenoms: []string{denom0, denom1},
+		PoolDenoms:   []string{denom0, denom1, denom2},
+	}
+	quarantined, found := s.twapkeeper.GetQuarantinedPool(s.Ctx, poolId)
+	s.Require().True(found)
+	s.Require().Equal(expectedQuarantine, quarantined)
+	s.Require().Len(quarantineCtx.EventManager().Events(), 1)
+	s.Require().Equal(types.TypeEvtQuarantinePool, quarantineCtx.EventManager().Events()[0].Type)
+
+	health, err := querier.PoolHealth(s.Ctx, queryproto.PoolHealthRequest{PoolId: poolId})
+	s.Require().NoError(err)
+	s.Require().Equal(&queryproto.PoolHealthResponse{Quarantined: true, Quarantine: &expectedQuarantine}, health)
+
+	// the records of a quarantined pool are not updated anymore
+	laterCtx := quarantineCtx.WithBlockTime(quarantineCtx.BlockTime().Add(time.Second)).WithBlockHeight(quarantineCtx.BlockHeight() + 1)
+	s.Require().NoError(s.twapkeeper.UpdateRecords(laterCtx, poolId))
+	s.Require().Equal([]types.TwapRecord{creationRecord}, s.getAllHistoricalRecordsForPool(poolId))
+	// which its last update info tells
+	info, err := s.twapkeeper.GetLastUpdateInfo(laterCtx, poolId, denom0, denom1, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(types.LastUpdateInfo{
+		PoolId: poolId, Asset0Denom: denom0, Asset1Denom: denom1, Height: creationRecord.Height, Time: creationRecord.Time,
+		BlocksSinceUpdate: 2, Stale: true, Quarantined: true,
+	}, info)
+
+	// and its TWAPs can't be queried
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(laterCtx, poolId, denom0, denom1, creationRecord.Time)
+	s.Require().ErrorIs(err, types.PoolQuarantinedError{PoolId: poolId})
+	_, err = s.twapkeeper.GetBeginBlockAccumulatorRecord(laterCtx, poolId, denom0, denom1)
+	s.Require().ErrorIs(err, types.PoolQuarantinedError{PoolId: poolId})
+
+	// repairing the pool keeps updating the existing pair, and starts the new pairs from their current spot prices
+	repairCtx := laterCtx.WithBlockTime(laterCtx.BlockTime().Add(time.Second)).WithBlockHeight(laterCtx.BlockHeight() + 1)
+	s.Require().NoError(s.twapkeeper.RepairQuarantinedPool(repairCtx, poolId))
+	_, found = s.twapkeeper.GetQuarantinedPool(s.Ctx, poolId)
+	s.Require().False(found)
+
+	records, err := s.twapkeeper.GetAllMostRecentRecordsForPool(s.Ctx, poolId)
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+	s.Require().Equal(s.twapkeeper.UpdateRecord(repairCtx, creationRecord), records[0])
+	for _, record := range records[1:] {
+		s.Require().Equal(repairCtx.BlockTime(), record.Time)
+		s.Require().Equal(sdk.ZeroDec(), record.P0ArithmeticTwapAccumulator)
+		s.Require().Equal(denom2, record.Asset1Denom)
+	}
+
+	twap, err := s.twapkeeper.GetArithmeticTwapToNow(repairCtx.WithBlockTime(repairCtx.BlockTime().Add(time.Hour)), poolId, denom2, denom0, repairCtx.BlockTime())
+	s.Require().NoError(err)
+	s.Require().Equal(sdk.NewDec(2), twap)
+
+	health, err = querier.PoolHealth(s.Ctx, queryproto.PoolHealthRequest{PoolId: poolId})
+	s.Require().NoError(err)
+	s.Require().Equal(&queryproto.PoolHealthResponse{}, health)
+
+	// a pool can only be repaired while it is quarantined
+	err = s.twapkeeper.RepairQuarantinedPool(repairCtx, poolId)
+	s.Require().ErrorIs(err, types.PoolNotQuarantinedError{PoolId: poolId})
+}
+
+// TestTrackingGaps tests that a repaired quarantine is recorded as a tracking gap of the pool, and that the TWAPs of
+// windows overlapping it error unless gaps are allowed, in which case the fraction of the window outside of it is
+// returned.
+func (s *TestSuite) TestTrackingGaps() {
+	poolId := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	creationTime := s.Ctx.BlockTime()
+	querier := twapclient.Querier{K: *s.twapkeeper}
+
+	// the pool is quarantined 10 seconds after its creation, and repaired 10 seconds later
+	ammInterface := s.App.TwapKeeper.GetAmmInterface()
+	mockAMMI := twapmock.NewProgrammedAmmInterface(ammInterface)
+	mockAMMI.ProgramPoolDenomsOverride(poolId, []string{denom2, denom1, denom0}, nil)
+	s.App.TwapKeeper.SetAmmInterface(mockAMMI)
+	quarantineCtx := s.Ctx.WithBlockTime(creationTime.Add(10 * time.Second)).WithBlockHeight(s.Ctx.BlockHeight() + 1)
+	s.Require().NoError(s.twapkeeper.UpdateRecords(quarantineCtx, poolId))
+
+	s.App.TwapKeeper.SetAmmInterface(ammInterface)
+	repairCtx := quarantineCtx.WithBlockTime(creationTime.Add(20 * time.Second)).WithBlockHeight(quarantineCtx.BlockHeight() + 1)
+	s.Require().NoError(s.twapkeeper.RepairQuarantinedPool(repairCtx, poolId))
+
+	expectedGap := types.TrackingGap{PoolId: poolId, From: quarantineCtx.BlockTime(), To: repairCtx.BlockTime()}
+	gaps, err := s.twapkeeper.GetTrackingGaps(s.Ctx, poolId)
+	s.Require().NoError(err)
+	s.Require().Equal([]types.TrackingGap{expectedGap}, gaps)
+
+	queryCtx := repairCtx.WithBlockTime(creationTime.Add(40 * time.Second)).WithBlockHeight(repairCtx.BlockHeight() + 1)
+	spotPrice, _, _, err := s.twapkeeper.GetHistoricalSpotPrice(queryCtx, poolId, denom0, denom1, creationTime)
+	s.Require().NoError(err)
+
+	tests := map[string]struct {
+		startTime       time.Time
+		endTime         time.Time
+		expectGapErr    bool
+		coveredFraction sdk.Dec
+	}{
+		"window fully inside the gap": {
+			startTime:       creationTime.Add(12 * time.Second),
+			endTime:         creationTime.Add(18 * time.Second),
+			expectGapErr:    true,
+			coveredFraction: sdk.ZeroDec(),
+		},
+		"empty window inside the gap": {
+			startTime:       creationTime.Add(15 * time.Second),
+			endTime:         creationTime.Add(15 * time.Second),
+			expectGapErr:    true,
+			coveredFraction: sdk.ZeroDec(),
+		},
+		"window overlapping the start of the gap": {
+			startTime:       creationTime.Add(5 * time.Second),
+			endTime:         creationTime.Add(15 * time.Second),
+			expectGapErr:    true,
+			coveredFraction: sdk.NewDecWithPrec(5, 1),
+		},
+		"window overlapping the end of the gap": {
+			startTime:       creationTime.Add(18 * time.Second),
+			endTime:         creationTime.Add(26 * time.Second),
+			expectGapErr:    true,
+			coveredFraction: sdk.NewDecWithPrec(75, 2),
+		},
+		"window spanning the gap": {
+			startTime:       creationTime,
+			endTime:         creationTime.Add(40 * time.Second),
+			expectGapErr:    true,
+			coveredFraction: sdk.NewDecWithPrec(75, 2),
+		},
+		"window ending when the gap starts": {
+			startTime:       creationTime,
+			endTime:         creationTime.Add(10 * time.Second),
+			coveredFraction: sdk.OneDec(),
+		},
+		"window starting when the gap ends": {
+			startTime:       creationTime.Add(20 * time.Second),
+			endTime:         creationTime.Add(30 * time.Second),
+			coveredFraction: sdk.OneDec(),
+		},
+	}
+	for name, tc := range tests {
+		s.Run(name, func() {
+			_, err := s.twapkeeper.GetArithmeticTwap(queryCtx, poolId, denom0, denom1, tc.startTime, tc.endTime)
+			if tc.expectGapErr {
+				s.Require().ErrorIs(err, types.TrackingGapError{PoolId: poolId, From: expectedGap.From, To: expectedGap.To})
+			} else {
+				s.Require().NoError(err)
+			}
+
+			// the pool had no swaps, so its TWAP is its spot price, gaps included
+			twap, coveredFraction, err := s.twapkeeper.GetArithmeticTwapAllowingGaps(queryCtx, poolId, denom0, denom1, tc.startTime, tc.endTime)
+			s.Require().NoError(err)
+			s.Require().Equal(spotPrice, twap.Price)
+			s.Require().Equal(tc.coveredFraction, coveredFraction)
+
+			endTime := tc.endTime
+			res, err := querier.ArithmeticTwap(queryCtx, queryproto.ArithmeticTwapRequest{
+				PoolId:     poolId,
+				BaseAsset:  denom0,
+				QuoteAsset: denom1,
+				StartTime:  tc.startTime,
+				EndTime:    &endTime,
+				AllowGaps:  true,
+			})
+			s.Require().NoError(err)
+			s.Require().Equal(tc.coveredFraction, res.CoveredFraction)
+		})
+	}
+
+	// TWAPs to now check the gaps as well
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(queryCtx, poolId, denom0, denom1, creationTime)
+	s.Require().ErrorIs(err, types.TrackingGapError{PoolId: poolId, From: expectedGap.From, To: expectedGap.To})
+	_, err = s.twapkeeper.GetArithmeticTwapToNow(queryCtx, poolId, denom0, denom1, repairCtx.BlockTime())
+	s.Require().NoError(err)
+}
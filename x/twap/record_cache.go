@@ -0,0 +1,148 @@
+package twap
+
+import (
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// recordCache is an in-memory cache of the most recent records, for the TWAP queries of query nodes.
+// It is not part of the state machine: only contexts set up with WithRecordCache read through it, which only the
+// query server does, and it is configured per node in app.toml.
+//
+// Each entry is tagged with the height of the state it was read from, and only served to queries at that height.
+// This keeps a query that reads the previous block's state while a block is being executed from caching a record
+// that the block is overwriting. Writes to the most recent records invalidate their entries on top of that.
+// Entries hold the encoded records, so that callers can't share a record's decimals.
+type recordCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[recordCacheKey]recordCacheEntry
+	stats   RecordCacheStats
+}
+
+type recordCacheKey struct {
+	poolId      uint64
+	asset0Denom string
+	asset1Denom string
+}
+
+type recordCacheEntry struct {
+	height int64
+	bz     []byte
+}
+
+// RecordCacheStats are the counters of the record cache since the node started
+type RecordCacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+	Entries       int
+}
+
+type recordCacheContextKey struct{}
+
+// WithRecordCache returns ctx set to read the most recent records through the record cache, if the node enabled it.
+// Only queries should use it, so that the state machine never depends on the cache.
+func WithRecordCache(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(recordCacheContextKey{}, true)
+}
+
+func usesRecordCache(ctx sdk.Context) bool {
+	uses, _ := ctx.Value(recordCacheContextKey{}).(bool)
+	return uses
+}
+
+// SetRecordCacheSize enables the record cache with room for size records, or disables it if size is 0, dropping its
+// entries and counters. The keeper copies made before share the cache.
+func (k *Keeper) SetRecordCacheSize(size int) {
+	if k.recordCache == nil {
+		k.recordCache = &recordCache{}
+	}
+	k.recordCache.resize(size)
+}
+
+// RecordCacheStats returns the counters of the record cache, which are all zero if it is disabled
+func (k Keeper) RecordCacheStats() RecordCacheStats {
+	if k.recordCache == nil {
+		return RecordCacheStats{}
+	}
+	k.recordCache.mu.Lock()
+	defer k.recordCache.mu.Unlock()
+	stats := k.recordCache.stats
+	stats.Entries = len(k.recordCache.entries)
+	return stats
+}
+
+// resize empties the cache and gives it room for size records, disabling it if size is 0
+func (c *recordCache) resize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	c.entries = make(map[recordCacheKey]recordCacheEntry, size)
+	c.stats = RecordCacheStats{}
+}
+
+// enabled returns whether the cache has room for any record. It is false for a nil cache.
+func (c *recordCache) enabled() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size > 0
+}
+
+// get returns the encoded most recent record of the denom pair of pool poolId at height, if it is cached
+func (c *recordCache) get(height int64, poolId uint64, asset0Denom, asset1Denom string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[recordCacheKey{poolId: poolId, asset0Denom: asset0Denom, asset1Denom: asset1Denom}]
+	if !ok || entry.height != height {
+		c.stats.Misses++
+		telemetry.IncrCounter(1, types.ModuleName, types.MetricKeyRecordCache, types.MetricKeyMisses)
+		return nil, false
+	}
+	c.stats.Hits++
+	telemetry.IncrCounter(1, types.ModuleName, types.MetricKeyRecordCache, types.MetricKeyHits)
+	return entry.bz, true
+}
+
+// add caches bz, the encoded most recent record of the denom pair of pool poolId, as read at height.
+// When the cache is full, the entries of other heights are dropped, and then every entry if it is still full.
+func (c *recordCache) add(height int64, poolId uint64, asset0Denom, asset1Denom string, bz []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.size {
+		for key, entry := range c.entries {
+			if entry.height != height {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= c.size {
+			c.entries = make(map[recordCacheKey]recordCacheEntry, c.size)
+		}
+	}
+	c.entries[recordCacheKey{poolId: poolId, asset0Denom: asset0Denom, asset1Denom: asset1Denom}] = recordCacheEntry{height: height, bz: bz}
+}
+
+// invalidate drops the cached most recent record of the denom pair of pool poolId. It is a no-op on a nil cache.
+func (c *recordCache) invalidate(poolId uint64, asset0Denom, asset1Denom string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := recordCacheKey{poolId: poolId, asset0Denom: asset0Denom, asset1Denom: asset1Denom}
+	if _, ok := c.entries[key]; ok {
+		delete(c.entries, key)
+		c.stats.Invalidations++
+		telemetry.IncrCounter(1, types.ModuleName, types.MetricKeyRecordCache, types.MetricKeyInvalidations)
+	}
+}
@@ -0,0 +1,77 @@
+package twap_test
+
+import (
+	"time"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	twapclient "github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// TestRecordCache tests that the record cache serves the most recent records of every pair of a multi-asset pool
+// to queries, that writing the records invalidates them, and that entries are only served at their height. The
+// copies of the keeper share the cache.
+func (s *TestSuite) TestRecordCache() {
+	// a copy of the keeper made before the cache is enabled, as the modules hold, shares it
+	keeperCopy := *s.twapkeeper
+	s.twapkeeper.SetRecordCacheSize(10)
+	poolId := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	s.EndBlock()
+	s.Commit()
+
+	pairs := []types.DenomPair{{Denom0: denom0, Denom1: denom1}, {Denom0: denom0, Denom1: denom2}, {Denom0: denom1, Denom1: denom2}}
+	readAll := func(cached bool) []types.TwapRecord {
+		ctx := s.Ctx
+		if cached {
+			ctx = twap.WithRecordCache(ctx)
+		}
+		records := []types.TwapRecord{}
+		for _, pair := range pairs {
+			record, err := s.twapkeeper.GetBeginBlockAccumulatorRecord(ctx, poolId, pair.Denom0, pair.Denom1)
+			s.Require().NoError(err)
+			records = append(records, record)
+		}
+		return records
+	}
+
+	// reads that don't use the cache leave it alone
+	stored := readAll(false)
+	s.Require().Equal(twap.RecordCacheStats{}, s.twapkeeper.RecordCacheStats())
+
+	// the first cached reads miss and fill the cache, the next ones hit, and both return the stored records
+	s.Require().Equal(stored, readAll(true))
+	s.Require().Equal(twap.RecordCacheStats{Misses: 3, Entries: 3}, s.twapkeeper.RecordCacheStats())
+	s.Require().Equal(stored, readAll(true))
+	s.Require().Equal(twap.RecordCacheStats{Hits: 3, Misses: 3, Entries: 3}, s.twapkeeper.RecordCacheStats())
+
+	// the query server reads through the cache
+	querier := twapclient.Querier{K: keeperCopy}
+	_, err := querier.ArithmeticTwapToNow(s.Ctx, queryproto.ArithmeticTwapToNowRequest{PoolId: poolId, BaseAsset: denom0, QuoteAsset: denom1, StartTime: s.Ctx.BlockTime().Add(-time.Second)})
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(4), s.twapkeeper.RecordCacheStats().Hits)
+
+	// a swap makes the EndBlock write the records of every pair of the pool, which invalidates all of them
+	s.RunBasicSwap(poolId)
+	s.EndBlock()
+	s.Require().Equal(twap.RecordCacheStats{Hits: 4, Misses: 3, Invalidations: 3}, s.twapkeeper.RecordCacheStats())
+	updated := readAll(false)
+	s.Require().NotEqual(stored, updated)
+	s.Require().Equal(updated, readAll(true))
+	s.Require().Equal(twap.RecordCacheStats{Hits: 4, Misses: 6, Invalidations: 3, Entries: 3}, s.twapkeeper.RecordCacheStats())
+
+	// the entries read at the previous height are not served at the next one
+	s.Commit()
+	s.Require().Equal(readAll(false), readAll(true))
+	s.Require().Equal(twap.RecordCacheStats{Hits: 4, Misses: 9, Invalidations: 3, Entries: 3}, s.twapkeeper.RecordCacheStats())
+
+	// a full cache drops its entries to make room
+	s.twapkeeper.SetRecordCacheSize(2)
+	s.Require().Equal(readAll(false), readAll(true))
+	s.Require().Equal(1, s.twapkeeper.RecordCacheStats().Entries)
+
+	// a disabled cache is never read
+	s.twapkeeper.SetRecordCacheSize(0)
+	s.Require().Equal(readAll(false), readAll(true))
+	s.Require().Equal(twap.RecordCacheStats{}, s.twapkeeper.RecordCacheStats())
+}
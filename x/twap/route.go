@@ -0,0 +1,73 @@
+package twap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// MaxTwapRouteHops is the maximum number of hops of the routes GetArithmeticTwapForRoute computes TWAPs over.
+const MaxTwapRouteHops = types.MaxTwapRouteHops
+
+// GetArithmeticTwapForRoute returns the arithmetic TWAP over [startTime, endTime] of the base asset of the first hop
+// of route in units of the quote asset of its last hop, for assets that don't share a pool with the quote asset.
+// Every hop is a pool, and the base and quote asset of the TWAP of the pool, and the quote asset of a hop must be
+// the base asset of the next hop. The TWAP is the product of the arithmetic TWAPs of the hops, as GetArithmeticTwap
+// would return them.
+//
+// Like for GetArithmeticTwapResult, if the spot price of the pool of a hop errored within the window, the TWAP is
+// still returned along with types.ErrSpotPriceErrorInWindow, and its LastErrorTime is the last error time of all of
+// the hops. Any other error of a hop is returned as is, without a result.
+//
+// This function will error if:
+// * route has no hops, more than MaxTwapRouteHops hops, or its hops don't chain
+// * any hop errors, see GetArithmeticTwap
+func (k Keeper) GetArithmeticTwapForRoute(
+	ctx sdk.Context,
+	route []types.TwapRoutePoolPair,
+	startTime time.Time,
+	endTime time.Time,
+) (types.RouteTwapResult, error) {
+	if err := validateTwapRoute(route); err != nil {
+		return types.RouteTwapResult{}, err
+	}
+
+	result := types.RouteTwapResult{Price: sdk.OneDec(), Hops: make([]types.TwapResult, 0, len(route))}
+	var spotPriceErr error
+	for _, hop := range route {
+		hopResult, err := k.GetArithmeticTwapResult(ctx, hop.PoolId, hop.BaseAsset, hop.QuoteAsset, startTime, endTime)
+		if errors.Is(err, types.ErrSpotPriceErrorInWindow) {
+			spotPriceErr = err
+		} else if err != nil {
+			return types.RouteTwapResult{}, err
+		}
+		result.Price = result.Price.Mul(hopResult.Price)
+		if hopResult.LastErrorTime.After(result.LastErrorTime) {
+			result.LastErrorTime = hopResult.LastErrorTime
+		}
+		result.Hops = append(result.Hops, hopResult)
+	}
+	return result, spotPriceErr
+}
+
+// validateTwapRoute returns an error if route has no hops, more than MaxTwapRouteHops hops, or a hop whose quote
+// asset isn't the base asset of the next hop.
+func validateTwapRoute(route []types.TwapRoutePoolPair) error {
+	if len(route) == 0 {
+		return types.InvalidRouteError{Reason: "the route has no hops"}
+	}
+	if len(route) > MaxTwapRouteHops {
+		return types.TooManyHopsError{NumHops: len(route), MaxHops: MaxTwapRouteHops}
+	}
+	for i := 1; i < len(route); i++ {
+		if route[i-1].QuoteAsset != route[i].BaseAsset {
+			return types.InvalidRouteError{Reason: fmt.Sprintf("the quote asset %s of hop %d is not the base asset %s of hop %d",
+				route[i-1].QuoteAsset, i-1, route[i].BaseAsset, i)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+const denom3 = "token/D"
+
+// newRouteHopRecords returns the records of a denom pair of a pool, whose spot price of asset 1 in units of asset 0
+// is sp0s[i] from baseTime + 10s * i, with the accumulators of every record grown from the previous one.
+func newRouteHopRecords(poolId uint64, asset0Denom, asset1Denom string, sp0s ...sdk.Dec) []types.TwapRecord {
+	records := make([]types.TwapRecord, 0, len(sp0s))
+	record := types.TwapRecord{
+		PoolId:                      poolId,
+		Asset0Denom:                 asset0Denom,
+		Asset1Denom:                 asset1Denom,
+		Time:                        baseTime,
+		P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+		P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+		GeometricTwapAccumulator:    sdk.ZeroDec(),
+	}
+	for i, sp0 := range sp0s {
+		if i > 0 {
+			record = twap.RecordWithUpdatedAccumulators(record, baseTime.Add(time.Duration(i)*10*time.Second))
+		}
+		record.Height = int64(i + 1)
+		record.P0LastSpotPrice = sp0
+		record.P1LastSpotPrice = sdk.OneDec().Quo(sp0)
+		records = append(records, record)
+	}
+	return records
+}
+
+func (s *TestSuite) TestGetArithmeticTwapForRoute() {
+	tPlus10 := baseTime.Add(10 * time.Second)
+	tPlus20 := baseTime.Add(20 * time.Second)
+	// over [baseTime, tPlus20], the TWAP of B in A is 7.5, of C in B 2, and of D in C 4.
+	poolABRecords := newRouteHopRecords(1, denom0, denom1, sdk.NewDec(10), sdk.NewDec(5), sdk.NewDec(5))
+	poolBCRecords := newRouteHopRecords(2, denom1, denom2, sdk.NewDec(2), sdk.NewDec(2), sdk.NewDec(2))
+	poolCDRecords := newRouteHopRecords(3, denom2, denom3, sdk.NewDec(4), sdk.NewDec(4), sdk.NewDec(4))
+	// the spot price of pool 2 errored in the block of its second record,
+	// and the third record carries that error time over.
+	erroringPoolBCRecords := []types.TwapRecord{
+		poolBCRecords[0],
+		withLastErrTime(poolBCRecords[1], tPlus10),
+		withLastErrTime(poolBCRecords[2], tPlus10),
+	}
+
+	hopBA := types.TwapRoutePoolPair{PoolId: 1, BaseAsset: denom1, QuoteAsset: denom0}
+	hopAB := types.TwapRoutePoolPair{PoolId: 1, BaseAsset: denom0, QuoteAsset: denom1}
+	hopCB := types.TwapRoutePoolPair{PoolId: 2, BaseAsset: denom2, QuoteAsset: denom1}
+	hopBC := types.TwapRoutePoolPair{PoolId: 2, BaseAsset: denom1, QuoteAsset: denom2}
+	hopDC := types.TwapRoutePoolPair{PoolId: 3, BaseAsset: denom3, QuoteAsset: denom2}
+
+	tests := map[string]struct {
+		poolBCRecords []types.TwapRecord
+		route         []types.TwapRoutePoolPair
+
+		expTwap          sdk.Dec
+		expLastErrorTime time.Time
+		expErr           error
+	}{
+		"two hops": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopCB, hopBA},
+			expTwap:       sdk.NewDec(15),
+		},
+		"three hops": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopDC, hopCB, hopBA},
+			expTwap:       sdk.NewDec(60),
+		},
+		"two hops, quoted in asset 1 of the pools": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopAB, hopBC},
+			// (0.1 + 0.2) / 2 * 0.5
+			expTwap: sdk.MustNewDecFromStr("0.075"),
+		},
+		"spot price error of the middle hop within the window": {
+			poolBCRecords:    erroringPoolBCRecords,
+			route:            []types.TwapRoutePoolPair{hopDC, hopCB, hopBA},
+			expTwap:          sdk.NewDec(60),
+			expLastErrorTime: tPlus10,
+			expErr:           spotPriceError,
+		},
+		"no hops": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{},
+			expErr:        types.InvalidRouteError{Reason: "the route has no hops"},
+		},
+		"hops don't chain": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopBA, hopCB},
+			expErr:        types.InvalidRouteError{Reason: "the quote asset token/A of hop 0 is not the base asset token/C of hop 1"},
+		},
+		"too many hops": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopAB, hopBA, hopAB, hopBA, hopAB, hopBA},
+			expErr:        types.TooManyHopsError{NumHops: 6, MaxHops: twap.MaxTwapRouteHops},
+		},
+		"hop pair not in its pool": {
+			poolBCRecords: poolBCRecords,
+			route:         []types.TwapRoutePoolPair{hopDC, {PoolId: 1, BaseAsset: denom2, QuoteAsset: denom0}},
+			expErr:        types.PairNotInPoolError{PoolId: 1, Asset0Denom: denom0, Asset1Denom: denom2},
+		},
+	}
+
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords(poolABRecords)
+			s.preSetRecords(test.poolBCRecords)
+			s.preSetRecords(poolCDRecords)
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			result, err := s.twapkeeper.GetArithmeticTwapForRoute(s.Ctx, test.route, baseTime, tPlus20)
+			if test.expErr != nil {
+				s.Require().Equal(test.expErr, err)
+			} else {
+				s.Require().NoError(err)
+			}
+			if test.expTwap.IsNil() {
+				s.Require().Equal(types.RouteTwapResult{}, result)
+				return
+			}
+			s.Require().Equal(test.expTwap, result.Price)
+			s.Require().Equal(test.expLastErrorTime, result.LastErrorTime)
+			s.Require().Len(result.Hops, len(test.route))
+
+			// every hop is the TWAP of its pool
+			for i, hop := range test.route {
+				hopResult, _ := s.twapkeeper.GetArithmeticTwapResult(s.Ctx, hop.PoolId, hop.BaseAsset, hop.QuoteAsset, baseTime, tPlus20)
+				s.Require().Equal(hopResult, result.Hops[i])
+			}
+		})
+	}
+}
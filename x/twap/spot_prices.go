@@ -0,0 +1,161 @@
+package twap
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmoutils"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+// MaxSpotPricesAtTimePairs is the maximum number of pairs GetSpotPricesAtTime resolves at once.
+const MaxSpotPricesAtTimePairs = 100
+
+// GetSpotPricesAtTime returns the spot price of every pair of pairs at t, in the order of pairs, as
+// GetHistoricalSpotPrice would return it. An error resolving a pair, e.g. for a pair that isn't in its pool or
+// a quarantined pool, is returned in the pair's result rather than failing the others.
+//
+// The pairs are resolved pool by pool, for valuations of many assets at once: the quarantine and the most recent
+// records of a pool are read once for all of its pairs, and a denom pair requested several times, e.g. in both
+// directions, is looked up once. A denom pair whose most recent record is at or before t is resolved from it, and
+// only the others are looked up in the historical records.
+//
+// This function will error if:
+// * there are more than MaxSpotPricesAtTimePairs pairs
+// * t is in the future
+// * t is before the record history keep period
+func (k Keeper) GetSpotPricesAtTime(ctx sdk.Context, t time.Time, pairs []types.SpotPricePair) ([]types.SpotPriceAtTime, error) {
+	if len(pairs) > MaxSpotPricesAtTimePairs {
+		return nil, types.TooManyPairsError{NumPairs: len(pairs), MaxPairs: MaxSpotPricesAtTimePairs}
+	}
+	if t.After(ctx.BlockTime()) {
+		return nil, types.TimeInFutureError{Time: t, BlockTime: ctx.BlockTime()}
+	}
+	if tooOld := k.newTimeTooOldError(ctx, t); t.Before(tooOld.OldestQueryableTime) {
+		return nil, tooOld
+	}
+
+	resolver := newSpotPriceResolver(ctx, k, t)
+	prices := make([]types.SpotPriceAtTime, 0, len(pairs))
+	for _, pair := range pairs {
+		prices = append(prices, resolver.spotPriceAtTime(pair))
+	}
+	return prices, nil
+}
+
+// spotPriceResolver resolves the records at or before a time of the pairs of GetSpotPricesAtTime, keeping what it
+// read for the next pairs.
+type spotPriceResolver struct {
+	ctx sdk.Context
+	k   Keeper
+	t   time.Time
+
+	// pools are the pools read so far, by pool id
+	pools map[uint64]resolvedPool
+	// records are the denom pairs looked up so far, by pool id and denom pair
+	records map[uint64]map[types.DenomPair]resolvedRecord
+}
+
+// resolvedPool is the most recent records of a pool, by denom pair, or the error reading them
+type resolvedPool struct {
+	mostRecentRecords map[types.DenomPair]types.TwapRecord
+	err               error
+}
+
+// resolvedRecord is the record of a denom pair at or before the time of the resolver, or the error looking it up
+type resolvedRecord struct {
+	record types.TwapRecord
+	err    error
+}
+
+func newSpotPriceResolver(ctx sdk.Context, k Keeper, t time.Time) *spotPriceResolver {
+	return &spotPriceResolver{
+		ctx:     ctx,
+		k:       k,
+		t:       t,
+		pools:   map[uint64]resolvedPool{},
+		records: map[uint64]map[types.DenomPair]resolvedRecord{},
+	}
+}
+
+// spotPriceAtTime returns the spot price of pair stored by the last record at or before the time of the resolver
+func (r *spotPriceResolver) spotPriceAtTime(pair types.SpotPricePair) types.SpotPriceAtTime {
+	record, err := r.recordAtTime(pair)
+	if err != nil {
+		return types.SpotPriceAtTime{Pair: pair, Err: err}
+	}
+	return types.SpotPriceAtTime{
+		Pair:        pair,
+		SpotPrice:   lastSpotPriceForQuoteAsset(record, record.Asset0Denom, pair.QuoteAsset),
+		RecordTime:  record.Time,
+		ErrorActive: isSpotPriceErrorActive(record),
+	}
+}
+
+// recordAtTime returns the last record of the denom pair of pair at or before the time of the resolver, looking it
+// up only if it wasn't already.
+func (r *spotPriceResolver) recordAtTime(pair types.SpotPricePair) (types.TwapRecord, error) {
+	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(pair.BaseAsset, pair.QuoteAsset)
+	if err != nil {
+		return types.TwapRecord{}, err
+	}
+	denomPair := types.DenomPair{Denom0: asset0Denom, Denom1: asset1Denom}
+	if resolved, ok := r.records[pair.PoolId][denomPair]; ok {
+		return resolved.record, resolved.err
+	}
+	record, err := r.lookupRecordAtTime(pair.PoolId, denomPair)
+	if r.records[pair.PoolId] == nil {
+		r.records[pair.PoolId] = map[types.DenomPair]resolvedRecord{}
+	}
+	r.records[pair.PoolId][denomPair] = resolvedRecord{record: record, err: err}
+	return record, err
+}
+
+// lookupRecordAtTime returns the last record of denomPair of pool poolId at or before the time of the resolver.
+// It errors as getRecordAtOrBeforeTime does.
+func (r *spotPriceResolver) lookupRecordAtTime(poolId uint64, denomPair types.DenomPair) (types.TwapRecord, error) {
+	pool := r.pool(poolId)
+	if pool.err != nil {
+		return types.TwapRecord{}, pool.err
+	}
+	mostRecent, found := pool.mostRecentRecords[denomPair]
+	if !found {
+		return types.TwapRecord{}, r.k.newPairNotInPoolError(r.ctx, poolId, denomPair.Denom0, denomPair.Denom1)
+	}
+	// the most recent record is the newest of the historical records, so no older one can be more recent at t
+	if !mostRecent.Time.After(r.t) {
+		return mostRecent, nil
+	}
+
+	store := r.ctx.KVStore(r.k.storeKey)
+	prefix := types.FormatHistoricalPoolIndexTimePrefix(poolId, denomPair.Denom0, denomPair.Denom1)
+	key := types.FormatHistoricalPoolIndexTWAPKey(poolId, denomPair.Denom0, denomPair.Denom1, r.t)
+	record, err := osmoutils.GetLastValueBeforeOrAtKey(store, prefix, key, types.ParseTwapFromBz)
+	if err != nil {
+		// the pair has records, all of them after t
+		return types.TwapRecord{}, r.k.newTimeTooOldError(r.ctx, r.t)
+	}
+	return record, nil
+}
+
+// pool returns the most recent records of pool poolId, reading them only if they weren't already.
+// A quarantined pool has the error of its quarantine instead.
+func (r *spotPriceResolver) pool(poolId uint64) resolvedPool {
+	if pool, ok := r.pools[poolId]; ok {
+		return pool
+	}
+	pool := resolvedPool{}
+	if r.k.isPoolQuarantined(r.ctx, poolId) {
+		pool.err = types.PoolQuarantinedError{PoolId: poolId}
+	} else {
+		records, err := r.k.getAllMostRecentRecordsForPool(r.ctx, poolId)
+		pool.err = err
+		pool.mostRecentRecords = make(map[types.DenomPair]types.TwapRecord, len(records))
+		for _, record := range records {
+			pool.mostRecentRecords[types.DenomPair{Denom0: record.Asset0Denom, Denom1: record.Asset1Denom}] = record
+		}
+	}
+	r.pools[poolId] = pool
+	return pool
+}
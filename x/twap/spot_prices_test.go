@@ -0,0 +1,162 @@
+package twap_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+func (s *TestSuite) TestGetSpotPricesAtTime() {
+	// pool 1 has the denom pair A/B, whose price of B is 10 A, then 5 A, then 2 A with a spot price error.
+	// pool 2 has the denoms A, B and C, and records from 10s on, made by newThreeAssetRecord with a spot price of 5.
+	errRecord := withLastErrTime(tPlus20sp2Record, tPlus20sp2Record.Time)
+	threeAssetRecords := newThreeAssetRecord(2, baseTime.Add(10*time.Second), sdk.NewDec(5), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	oneFifth := sdk.NewDecWithPrec(2, 1)
+
+	pairs := []types.SpotPricePair{
+		{PoolId: 1, BaseAsset: denom1, QuoteAsset: denom0},
+		{PoolId: 1, BaseAsset: denom0, QuoteAsset: denom1},
+		{PoolId: 2, BaseAsset: denom1, QuoteAsset: denom0},
+		{PoolId: 2, BaseAsset: denom0, QuoteAsset: denom2},
+		{PoolId: 2, BaseAsset: denom2, QuoteAsset: denom1},
+		// pool 1 has no history for the pair
+		{PoolId: 1, BaseAsset: denom2, QuoteAsset: denom0},
+		// and pool 3 doesn't exist
+		{PoolId: 3, BaseAsset: denom1, QuoteAsset: denom0},
+		{PoolId: 2, BaseAsset: denom0, QuoteAsset: denom0},
+	}
+	pairNotInPoolErrs := []types.SpotPriceAtTime{
+		{Pair: pairs[5], Err: types.PairNotInPoolError{PoolId: 1, Asset0Denom: denom0, Asset1Denom: denom2}},
+		{Pair: pairs[6], Err: types.PairNotInPoolError{PoolId: 3, Asset0Denom: denom0, Asset1Denom: denom1}},
+		{Pair: pairs[7], Err: types.SameDenomError{Denom: denom0}},
+	}
+
+	tests := map[string]struct {
+		t     time.Time
+		pairs []types.SpotPricePair
+
+		expPrices []types.SpotPriceAtTime
+		expErr    error
+	}{
+		"t between records": {
+			t:     baseTime.Add(15 * time.Second),
+			pairs: pairs,
+			expPrices: append([]types.SpotPriceAtTime{
+				{Pair: pairs[0], SpotPrice: sdk.NewDec(5), RecordTime: tPlus10sp5Record.Time},
+				{Pair: pairs[1], SpotPrice: oneFifth, RecordTime: tPlus10sp5Record.Time},
+				{Pair: pairs[2], SpotPrice: sdk.NewDec(5), RecordTime: threeAssetRecords[0].Time},
+				{Pair: pairs[3], SpotPrice: sdk.NewDec(10), RecordTime: threeAssetRecords[0].Time},
+				{Pair: pairs[4], SpotPrice: oneFifth, RecordTime: threeAssetRecords[0].Time},
+			}, pairNotInPoolErrs...),
+		},
+		"t after the last records": {
+			t:     tPlusOneMin,
+			pairs: pairs,
+			expPrices: append([]types.SpotPriceAtTime{
+				{Pair: pairs[0], SpotPrice: sdk.NewDec(2), RecordTime: errRecord.Time, ErrorActive: true},
+				{Pair: pairs[1], SpotPrice: sdk.NewDecWithPrec(5, 1), RecordTime: errRecord.Time, ErrorActive: true},
+				{Pair: pairs[2], SpotPrice: sdk.NewDec(5), RecordTime: threeAssetRecords[0].Time},
+				{Pair: pairs[3], SpotPrice: sdk.NewDec(10), RecordTime: threeAssetRecords[0].Time},
+				{Pair: pairs[4], SpotPrice: oneFifth, RecordTime: threeAssetRecords[0].Time},
+			}, pairNotInPoolErrs...),
+		},
+		"t before the records of a pool": {
+			t:     baseTime.Add(5 * time.Second),
+			pairs: pairs[:5],
+			expPrices: []types.SpotPriceAtTime{
+				{Pair: pairs[0], SpotPrice: sdk.NewDec(10), RecordTime: baseTime},
+				{Pair: pairs[1], SpotPrice: sdk.NewDecWithPrec(1, 1), RecordTime: baseTime},
+				{Pair: pairs[2], Err: types.TimeTooOldError{Time: baseTime.Add(5 * time.Second)}},
+				{Pair: pairs[3], Err: types.TimeTooOldError{Time: baseTime.Add(5 * time.Second)}},
+				{Pair: pairs[4], Err: types.TimeTooOldError{Time: baseTime.Add(5 * time.Second)}},
+			},
+		},
+		"no pairs": {
+			t:         baseTime,
+			pairs:     []types.SpotPricePair{},
+			expPrices: []types.SpotPriceAtTime{},
+		},
+
+		// error catching
+		"t in the future": {
+			t:      tPlusOneMin.Add(time.Second),
+			pairs:  pairs,
+			expErr: types.TimeInFutureError{Time: tPlusOneMin.Add(time.Second), BlockTime: tPlusOneMin},
+		},
+		"too many pairs": {
+			t:      baseTime,
+			pairs:  make([]types.SpotPricePair, twap.MaxSpotPricesAtTimePairs+1),
+			expErr: types.TooManyPairsError{NumPairs: twap.MaxSpotPricesAtTimePairs + 1, MaxPairs: twap.MaxSpotPricesAtTimePairs},
+		},
+	}
+
+	for name, tc := range tests {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.preSetRecords(append([]types.TwapRecord{baseRecord, tPlus10sp5Record, errRecord}, threeAssetRecords...))
+			s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+
+			prices, err := s.twapkeeper.GetSpotPricesAtTime(s.Ctx, tc.t, tc.pairs)
+			if tc.expErr != nil {
+				s.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().Len(prices, len(tc.expPrices))
+			for i, expPrice := range tc.expPrices {
+				s.Require().Equal(expPrice.Pair, prices[i].Pair)
+				if expPrice.Err != nil {
+					s.Require().ErrorIs(prices[i].Err, s.withKeepPeriodDetails(expPrice.Err))
+					continue
+				}
+				s.Require().NoError(prices[i].Err)
+				s.Require().Equal(expPrice.SpotPrice.String(), prices[i].SpotPrice.String())
+				s.Require().Equal(expPrice.RecordTime, prices[i].RecordTime)
+				s.Require().Equal(expPrice.ErrorActive, prices[i].ErrorActive)
+
+				// each spot price is the one GetHistoricalSpotPrice returns for the pair
+				spotPrice, recordTime, errorActive, err := s.twapkeeper.GetHistoricalSpotPrice(s.Ctx, expPrice.Pair.PoolId, expPrice.Pair.BaseAsset, expPrice.Pair.QuoteAsset, tc.t)
+				s.Require().NoError(err)
+				s.Require().Equal(spotPrice, prices[i].SpotPrice)
+				s.Require().Equal(recordTime, prices[i].RecordTime)
+				s.Require().Equal(errorActive, prices[i].ErrorActive)
+			}
+		})
+	}
+}
+
+// TestGetSpotPricesAtTimeSharesLookups tests that resolving the pairs of a pool at once costs less gas than resolving
+// them one by one, as the pool and the records of each denom pair are only read once.
+func (s *TestSuite) TestGetSpotPricesAtTimeSharesLookups() {
+	s.preSetRecords(newThreeAssetRecord(2, baseTime, sdk.NewDec(10), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()))
+	s.preSetRecords(newThreeAssetRecord(2, baseTime.Add(10*time.Second), sdk.NewDec(5), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()))
+	s.Ctx = s.Ctx.WithBlockTime(tPlusOneMin)
+	t := baseTime.Add(5 * time.Second)
+
+	// every pair of the pool, in both directions
+	pairs := []types.SpotPricePair{}
+	for _, base := range []string{denom0, denom1, denom2} {
+		for _, quote := range []string{denom0, denom1, denom2} {
+			if base != quote {
+				pairs = append(pairs, types.SpotPricePair{PoolId: 2, BaseAsset: base, QuoteAsset: quote})
+			}
+		}
+	}
+
+	oneByOneCtx := s.Ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	for _, pair := range pairs {
+		_, _, _, err := s.twapkeeper.GetHistoricalSpotPrice(oneByOneCtx, pair.PoolId, pair.BaseAsset, pair.QuoteAsset, t)
+		s.Require().NoError(err)
+	}
+	batchCtx := s.Ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	prices, err := s.twapkeeper.GetSpotPricesAtTime(batchCtx, t, pairs)
+	s.Require().NoError(err)
+	for _, price := range prices {
+		s.Require().NoError(price.Err)
+		s.Require().Equal(baseTime, price.RecordTime)
+	}
+	s.Require().Less(batchCtx.GasMeter().GasConsumed(), oneByOneCtx.GasMeter().GasConsumed())
+}
@@ -1,23 +1,36 @@
 package twap
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 
 	"github.com/osmosis-labs/osmosis/v13/osmoutils"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
-type timeTooOldError struct {
-	Time time.Time
+// newTimeTooOldError returns the TimeTooOldError of t, with the keep period and oldest queryable time at the block
+// time of ctx
+func (k Keeper) newTimeTooOldError(ctx sdk.Context, t time.Time) types.TimeTooOldError {
+	keepPeriod := k.GetParams(ctx).RecordHistoryKeepPeriod
+	return types.TimeTooOldError{Time: t, KeepPeriod: keepPeriod, OldestQueryableTime: ctx.BlockTime().Add(-keepPeriod)}
 }
 
-func (e timeTooOldError) Error() string {
-	return fmt.Sprintf("looking for a time thats too old, not in the historical index. "+
-		" Try storing the accumulator value. (requested time %s)", e.Time)
+// newPairNotInPoolError returns the error of a lookup of a denom pair pool poolId has no records for, with the current
+// denoms of the pool. They are left empty if the pool doesn't exist.
+func (k Keeper) newPairNotInPoolError(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string) types.PairNotInPoolError {
+	poolDenoms, err := k.ammkeeper.GetPoolDenoms(ctx, poolId)
+	if err != nil {
+		poolDenoms = nil
+	}
+	return types.PairNotInPoolError{PoolId: poolId, Asset0Denom: asset0Denom, Asset1Denom: asset1Denom, PoolDenoms: poolDenoms}
 }
 
 // just has to not be empty, for store to work / not register as a delete.
@@ -51,6 +64,33 @@ func (k Keeper) getChangedPools(ctx sdk.Context) []uint64 {
 	return alteredPoolIds
 }
 
+// deferPool marks the pool as changed, for EndBlock to update its records in a later block
+// once it deferred them past its gas budget.
+func (k Keeper) deferPool(ctx sdk.Context, poolId uint64) {
+	ctx.KVStore(k.storeKey).Set(types.FormatDeferredPoolKey(poolId), sentinelExistsValue)
+}
+
+func (k Keeper) deleteDeferredPool(ctx sdk.Context, poolId uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.FormatDeferredPoolKey(poolId))
+}
+
+// getDeferredPools returns the ids of the pools whose records EndBlock deferred to a later block,
+// in ascending order.
+func (k Keeper) getDeferredPools(ctx sdk.Context) []uint64 {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), []byte(types.DeferredPoolPrefix))
+	defer iter.Close()
+
+	deferredPoolIds := []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		poolId, err := types.ParseDeferredPoolKey(iter.Key())
+		if err != nil {
+			panic(err)
+		}
+		deferredPoolIds = append(deferredPoolIds, poolId)
+	}
+	return deferredPoolIds
+}
+
 // storeHistoricalTWAP writes a twap to the store, in all needed indexing.
 func (k Keeper) storeHistoricalTWAP(ctx sdk.Context, twap types.TwapRecord) {
 	store := ctx.KVStore(k.storeKey)
@@ -58,9 +98,10 @@ func (k Keeper) storeHistoricalTWAP(ctx sdk.Context, twap types.TwapRecord) {
 	key2 := types.FormatHistoricalPoolIndexTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom, twap.Time)
 	osmoutils.MustSet(store, key1, &twap)
 	osmoutils.MustSet(store, key2, &twap)
+	k.markForArchive(ctx, twap)
 }
 
-// pruneRecordsBeforeTimeButNewest prunes all records for each pool before the given time but the newest
+// pruneRecordsBeforeTimeButNewest prunes all records for each pool before state.LastKeptTime but the newest
 // record. The reason for preserving at least one record earlier than the keep period is
 // to ensure that we have a record to interpolate from in case there is only one or no records
 // within the keep period.
@@ -73,17 +114,14 @@ func (k Keeper) storeHistoricalTWAP(ctx sdk.Context, twap types.TwapRecord) {
 // So, in order to have correct behavior for the desired guarantee,
 // we keep the newest record that is older than the pruning time.
 // This is why we would keep the -50 hour and -1hour twaps despite a 48hr pruning period
-func (k Keeper) pruneRecordsBeforeTimeButNewest(ctx sdk.Context, lastKeptTime time.Time) error {
+//
+// The records are gone over from the newest to the oldest, and at most limit records are pruned, zero meaning no
+// limit. Once the limit is reached, the state to resume the pruning from in the next block is returned, whose
+// LastKeySeen is the key of the last pruned record. The pruning then resumes before it. Once all the records before
+// state.LastKeptTime have been gone over, nil is returned.
+func (k Keeper) pruneRecordsBeforeTimeButNewest(ctx sdk.Context, state types.PruningState, limit uint64) (*types.PruningState, error) {
 	store := ctx.KVStore(k.storeKey)
 
-	// Reverse iterator guarantees that we iterate through the newest per pool first.
-	// Due to how it is indexed, we will only iterate times starting from
-	// lastKeptTime exclusively down to the oldest record.
-	iter := store.ReverseIterator(
-		[]byte(types.HistoricalTWAPTimeIndexPrefix),
-		types.FormatHistoricalTimeIndexTWAPKey(lastKeptTime, 0, "", ""))
-	defer iter.Close()
-
 	// We mark what (pool id, asset 0, asset 1) triplets we've seen.
 	// We prune all records for a triplet that we haven't already seen.
 	type uniqueTriplet struct {
@@ -93,10 +131,38 @@ func (k Keeper) pruneRecordsBeforeTimeButNewest(ctx sdk.Context, lastKeptTime ti
 	}
 	seenPoolAssetTriplets := map[uniqueTriplet]struct{}{}
 
+	// Reverse iterator guarantees that we iterate through the newest per pool first.
+	// Due to how it is indexed, we will only iterate times starting from
+	// lastKeptTime exclusively down to the oldest record, or from the last record
+	// the pruning went over in the previous block exclusively when it resumes.
+	end := types.FormatHistoricalTimeIndexTWAPKey(state.LastKeptTime, 0, "", "")
+	if state.LastKeySeen != nil {
+		// The records the pruning went over in the previous blocks that are left are the newest ones of their
+		// triplets and the pinned ones, so the triplets that have one have been seen. They are read before the
+		// pruning iterator is opened, as an iterator can't be opened on the store while another one is.
+		seenIter := store.Iterator(state.LastKeySeen, end)
+		for ; seenIter.Valid(); seenIter.Next() {
+			record, err := types.ParseTwapFromBz(seenIter.Value())
+			if err != nil {
+				seenIter.Close()
+				return nil, err
+			}
+			seenPoolAssetTriplets[uniqueTriplet{poolId: record.PoolId, asset0: record.Asset0Denom, asset1: record.Asset1Denom}] = struct{}{}
+		}
+		seenIter.Close()
+		end = state.LastKeySeen
+	}
+	iter := store.ReverseIterator([]byte(types.HistoricalTWAPTimeIndexPrefix), end)
+	defer iter.Close()
+
+	pruned := uint64(0)
+	defer func() {
+		telemetry.IncrCounter(float32(pruned), types.ModuleName, types.MetricKeyPrune, types.MetricKeyRecordsPruned)
+	}()
 	for ; iter.Valid(); iter.Next() {
 		twapToRemove, err := types.ParseTwapFromBz(iter.Value())
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		poolKey := uniqueTriplet{
@@ -110,9 +176,35 @@ func (k Keeper) pruneRecordsBeforeTimeButNewest(ctx sdk.Context, lastKeptTime ti
 			continue
 		}
 
+		// pinned records are kept until they get unpinned.
+		if k.isRecordPinned(ctx, twapToRemove) {
+			continue
+		}
+
 		k.deleteHistoricalRecord(ctx, twapToRemove)
+		pruned++
+		if limit != 0 && pruned >= limit {
+			state.LastKeySeen = append([]byte{}, iter.Key()...)
+			return &state, nil
+		}
 	}
-	return nil
+	return nil, nil
+}
+
+// setPruningState stores the state of the pruning in progress, for EndBlock to prune the records in the next blocks.
+func (k Keeper) setPruningState(ctx sdk.Context, state types.PruningState) {
+	osmoutils.MustSet(ctx.KVStore(k.storeKey), types.PruningStateKey, &state)
+}
+
+// getPruningState returns the state of the pruning in progress, and whether there is one.
+func (k Keeper) getPruningState(ctx sdk.Context) (types.PruningState, bool, error) {
+	var state types.PruningState
+	found, err := osmoutils.Get(ctx.KVStore(k.storeKey), types.PruningStateKey, &state)
+	return state, found, err
+}
+
+func (k Keeper) deletePruningState(ctx sdk.Context) {
+	ctx.KVStore(k.storeKey).Delete(types.PruningStateKey)
 }
 
 func (k Keeper) deleteHistoricalRecord(ctx sdk.Context, twap types.TwapRecord) {
@@ -123,6 +215,41 @@ func (k Keeper) deleteHistoricalRecord(ctx sdk.Context, twap types.TwapRecord) {
 	store.Delete(key2)
 }
 
+// storePinnedRecord marks the historical record as pinned, which exempts it from pruning.
+func (k Keeper) storePinnedRecord(ctx sdk.Context, twap types.TwapRecord) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.FormatPinnedTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom, twap.Time)
+	osmoutils.MustSet(store, key, &twap)
+}
+
+func (k Keeper) deletePinnedRecord(ctx sdk.Context, twap types.TwapRecord) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.FormatPinnedTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom, twap.Time))
+}
+
+func (k Keeper) isRecordPinned(ctx sdk.Context, twap types.TwapRecord) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.FormatPinnedTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom, twap.Time))
+}
+
+// getAllPinnedRecords returns all pinned records, ordered by pool id and denoms, then by time.
+func (k Keeper) getAllPinnedRecords(ctx sdk.Context) ([]types.TwapRecord, error) {
+	return osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(types.PinnedTWAPPrefix), types.ParseTwapFromBz)
+}
+
+// getPinnedRecordAtOrBeforeTime returns the most recent pinned record of the (pool, asset0, asset1) triplet
+// whose time is at or before t.
+func (k Keeper) getPinnedRecordAtOrBeforeTime(ctx sdk.Context, poolId uint64, t time.Time, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.FormatPinnedTWAPTimePrefix(poolId, asset0Denom, asset1Denom)
+	key := types.FormatPinnedTWAPKey(poolId, asset0Denom, asset1Denom, t)
+	twap, err := osmoutils.GetLastValueBeforeOrAtKey(store, prefix, key, types.ParseTwapFromBz)
+	if err != nil {
+		return types.TwapRecord{}, types.PinnedRecordNotFoundError{PoolId: poolId, Time: t}
+	}
+	return twap, nil
+}
+
 // getMostRecentRecordStoreRepresentation returns the most recent twap record in the store
 // for the provided (pool, asset0, asset1) triplet.
 // Its called store representation, because most recent record can refer to it being
@@ -133,15 +260,32 @@ func (k Keeper) getMostRecentRecordStoreRepresentation(ctx sdk.Context, poolId u
 	if err != nil {
 		return types.TwapRecord{}, err
 	}
+	cache := k.recordCache
+	if !usesRecordCache(ctx) || !cache.enabled() {
+		cache = nil
+	}
+	if cache != nil {
+		if bz, ok := cache.get(ctx.BlockHeight(), poolId, asset0Denom, asset1Denom); ok {
+			return types.ParseTwapFromBz(bz)
+		}
+	}
+
 	store := ctx.KVStore(k.storeKey)
 	key := types.FormatMostRecentTWAPKey(poolId, asset0Denom, asset1Denom)
 	bz := store.Get(key)
+	if bz == nil {
+		return types.TwapRecord{}, k.newPairNotInPoolError(ctx, poolId, asset0Denom, asset1Denom)
+	}
 	twap, err := types.ParseTwapFromBz(bz)
 	if err != nil {
 		err = fmt.Errorf("error in get most recent twap, likely that asset 0 or asset 1 were wrong: %s %s."+
 			" Underlying error: %w", asset0Denom, asset1Denom, err)
+		return twap, err
+	}
+	if cache != nil {
+		cache.add(ctx.BlockHeight(), poolId, asset0Denom, asset1Denom, bz)
 	}
-	return twap, err
+	return twap, nil
 }
 
 // getAllMostRecentRecordsForPool returns all most recent twap records
@@ -151,6 +295,44 @@ func (k Keeper) getAllMostRecentRecordsForPool(ctx sdk.Context, poolId uint64) (
 	return types.GetAllMostRecentTwapsForPool(store, poolId)
 }
 
+// mostRecentIndexDivergence is a denom pair whose most recent record isn't the newest of its historical records
+type mostRecentIndexDivergence struct {
+	mostRecent types.TwapRecord
+	// historicalHead is the newest historical record of the pair, or nil if the pair has none
+	historicalHead *types.TwapRecord
+}
+
+func (d mostRecentIndexDivergence) String() string {
+	head := "none"
+	if d.historicalHead != nil {
+		head = d.historicalHead.Time.String()
+	}
+	return fmt.Sprintf("pool %d, denoms %s %s: most recent record at %s, newest historical record at %s",
+		d.mostRecent.PoolId, d.mostRecent.Asset0Denom, d.mostRecent.Asset1Denom, d.mostRecent.Time, head)
+}
+
+// getMostRecentIndexDivergence returns how the most recent record of the denom pair of mostRecent diverges from the
+// newest historical record of the pair, or nil if both are the same record.
+// Every record is stored in both, so they only diverge if the store was corrupted, e.g. by a faulty migration.
+func (k Keeper) getMostRecentIndexDivergence(ctx sdk.Context, mostRecent types.TwapRecord) (*mostRecentIndexDivergence, error) {
+	store := ctx.KVStore(k.storeKey)
+	mostRecentBz := store.Get(types.FormatMostRecentTWAPKey(mostRecent.PoolId, mostRecent.Asset0Denom, mostRecent.Asset1Denom))
+
+	iter := sdk.KVStoreReversePrefixIterator(store, types.FormatHistoricalPoolIndexTimePrefix(mostRecent.PoolId, mostRecent.Asset0Denom, mostRecent.Asset1Denom))
+	defer iter.Close()
+	if !iter.Valid() {
+		return &mostRecentIndexDivergence{mostRecent: mostRecent}, nil
+	}
+	if bytes.Equal(iter.Value(), mostRecentBz) {
+		return nil, nil
+	}
+	head, err := types.ParseTwapFromBz(iter.Value())
+	if err != nil {
+		return nil, err
+	}
+	return &mostRecentIndexDivergence{mostRecent: mostRecent, historicalHead: &head}, nil
+}
+
 // getAllHistoricalTimeIndexedTWAPs returns all historical TWAPs indexed by time.
 func (k Keeper) getAllHistoricalTimeIndexedTWAPs(ctx sdk.Context) ([]types.TwapRecord, error) {
 	return osmoutils.GatherValuesFromStorePrefix(ctx.KVStore(k.storeKey), []byte(types.HistoricalTWAPTimeIndexPrefix), types.ParseTwapFromBz)
@@ -163,13 +345,54 @@ func (k Keeper) getAllHistoricalPoolIndexedTWAPs(ctx sdk.Context) ([]types.TwapR
 }
 
 // storeNewRecord stores a record, in both the most recent record store and historical stores.
+// The pool is added to the pool index of the record's denom pair, if it isn't already.
 func (k Keeper) storeNewRecord(ctx sdk.Context, twap types.TwapRecord) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.FormatMostRecentTWAPKey(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom)
 	osmoutils.MustSet(store, key, &twap)
+	k.recordCache.invalidate(twap.PoolId, twap.Asset0Denom, twap.Asset1Denom)
+	k.indexPairPool(ctx, twap.PoolId, twap.Asset0Denom, twap.Asset1Denom)
 	k.storeHistoricalTWAP(ctx, twap)
 }
 
+// deleteMostRecentRecord deletes the most recent record of the (pool, asset0, asset1) triplet, and removes
+// the pool from the pool index of the denom pair. The historical records are left to pruning.
+func (k Keeper) deleteMostRecentRecord(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.FormatMostRecentTWAPKey(poolId, asset0Denom, asset1Denom))
+	store.Delete(types.FormatPairPoolIndexKey(asset0Denom, asset1Denom, poolId))
+	k.recordCache.invalidate(poolId, asset0Denom, asset1Denom)
+}
+
+// indexPairPool adds the pool to the pool index of the denom pair (asset0, asset1).
+// Most recent records are stored every block their pool changes, so the index is only written
+// when the pool isn't in it yet.
+func (k Keeper) indexPairPool(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.FormatPairPoolIndexKey(asset0Denom, asset1Denom, poolId)
+	if !store.Has(key) {
+		store.Set(key, sentinelExistsValue)
+	}
+}
+
+// getPoolIdsForPair returns the ids of the pools with most recent records for the denom pair
+// (asset0, asset1), in increasing order.
+func (k Keeper) getPoolIdsForPair(ctx sdk.Context, asset0Denom, asset1Denom string) ([]uint64, error) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.FormatPairPoolIndexPrefix(asset0Denom, asset1Denom))
+	defer iter.Close()
+
+	poolIds := []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		poolId, err := types.ParsePairPoolIndexKey(iter.Key(), asset0Denom, asset1Denom)
+		if err != nil {
+			return nil, err
+		}
+		poolIds = append(poolIds, poolId)
+	}
+	return poolIds, nil
+}
+
 // getRecordAtOrBeforeTime on a given input (id, t, asset0, asset1)
 // returns the TWAP record from state for (id, t', asset0, asset1),
 // where t' is such that:
@@ -182,27 +405,30 @@ func (k Keeper) storeNewRecord(ctx sdk.Context, twap types.TwapRecord) {
 //
 // * there is no record for the asset pair (asset0, asset1) in particular
 //   - e.g. asset not in pool, or provided in wrong order.
+//
+// * the pool is quarantined.
 func (k Keeper) getRecordAtOrBeforeTime(ctx sdk.Context, poolId uint64, t time.Time, asset0Denom string, asset1Denom string) (types.TwapRecord, error) {
+	if k.isPoolQuarantined(ctx, poolId) {
+		return types.TwapRecord{}, types.PoolQuarantinedError{PoolId: poolId}
+	}
 	asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(asset0Denom, asset1Denom)
 	if err != nil {
 		return types.TwapRecord{}, err
 	}
 	store := ctx.KVStore(k.storeKey)
-	// We make an iteration from time=t, to time=0 for this pool.
-	startKey := types.FormatHistoricalPoolIndexTimePrefix(poolId, asset0Denom, asset1Denom)
-	endKey := types.FormatHistoricalPoolIndexTimeSuffix(poolId, asset0Denom, asset1Denom, t)
-	reverseIterate := true
+	// We get the last record of this pool at or before time t.
+	prefix := types.FormatHistoricalPoolIndexTimePrefix(poolId, asset0Denom, asset1Denom)
+	key := types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, t)
 
-	twap, err := osmoutils.GetFirstValueInRange(store, startKey, endKey, reverseIterate, types.ParseTwapFromBz)
+	twap, err := osmoutils.GetLastValueBeforeOrAtKey(store, prefix, key, types.ParseTwapFromBz)
 	if err != nil {
 		// diagnose why we have no results by seeing what happens for getMostRecentRecord for this pool id
+		// (a most recent record after the block time means the pool's records are all after t)
 		_, errDiagnose := k.getMostRecentRecord(ctx, poolId, asset0Denom, asset1Denom)
-		if errDiagnose != nil {
-			return types.TwapRecord{}, fmt.Errorf(
-				"getTwapRecord: querying for assets %s %s that are not in pool id %d",
-				asset0Denom, asset1Denom, poolId)
+		if errDiagnose != nil && !errors.As(errDiagnose, &types.RecordAfterTargetTimeError{}) {
+			return types.TwapRecord{}, k.newPairNotInPoolError(ctx, poolId, asset0Denom, asset1Denom)
 		} else {
-			return types.TwapRecord{}, timeTooOldError{Time: t}
+			return types.TwapRecord{}, k.newTimeTooOldError(ctx, t)
 		}
 	}
 	if twap.Asset0Denom != asset0Denom || twap.Asset1Denom != asset1Denom || twap.PoolId != poolId {
@@ -211,3 +437,226 @@ func (k Keeper) getRecordAtOrBeforeTime(ctx sdk.Context, poolId uint64, t time.T
 
 	return twap, nil
 }
+
+// getFirstAndLastRecordsInTimeRange returns the first and last historical records of the denom pair
+// (asset0Denom, asset1Denom) of pool poolId written at or after startTime and before endTime.
+// found is false if there is no such record.
+func (k Keeper) getFirstAndLastRecordsInTimeRange(ctx sdk.Context, poolId uint64, asset0Denom, asset1Denom string, startTime, endTime time.Time) (first, last types.TwapRecord, found bool, err error) {
+	store := ctx.KVStore(k.storeKey)
+	start := types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, startTime)
+	end := types.FormatHistoricalPoolIndexTWAPKey(poolId, asset0Denom, asset1Denom, endTime)
+
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	if !iter.Valid() {
+		return types.TwapRecord{}, types.TwapRecord{}, false, nil
+	}
+	first, err = types.ParseTwapFromBz(iter.Value())
+	if err != nil {
+		return types.TwapRecord{}, types.TwapRecord{}, false, err
+	}
+
+	reverseIter := store.ReverseIterator(start, end)
+	defer reverseIter.Close()
+	last, err = types.ParseTwapFromBz(reverseIter.Value())
+	if err != nil {
+		return types.TwapRecord{}, types.TwapRecord{}, false, err
+	}
+	return first, last, true, nil
+}
+
+// IterateHistoricalRecords calls cb, in time order, for every historical record written at or after
+// startTime and before endTime, or up to the most recent records if endTime is nil.
+// If poolId is not 0, only the records of that pool are visited.
+// Records are read from the store as the iteration progresses, and it stops as soon as cb returns true.
+func (k Keeper) IterateHistoricalRecords(ctx sdk.Context, poolId uint64, startTime time.Time, endTime *time.Time, cb func(record types.TwapRecord) (stop bool)) error {
+	if poolId != 0 {
+		return k.iteratePoolHistoricalRecords(ctx, poolId, startTime, endTime, cb)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	// pool ids are not fixed length in the time index, but every key with a given time sorts
+	// after the one with pool id 0 and empty denoms.
+	start := types.FormatHistoricalTimeIndexTWAPKey(startTime, 0, "", "")
+	end := sdk.PrefixEndBytes([]byte(types.HistoricalTWAPTimeIndexPrefix))
+	if endTime != nil {
+		end = types.FormatHistoricalTimeIndexTWAPKey(*endTime, 0, "", "")
+	}
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		record, err := types.ParseTwapFromBz(iter.Value())
+		if err != nil {
+			return err
+		}
+		if cb(record) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// GetHistoricalRecordsPage returns a page of the historical records of pool poolId written at or after startTime and
+// before endTime, where a nil time leaves that side of the range open. If assetA and assetB are set, only the records
+// of their denom pair are returned, otherwise those of every denom pair of the pool.
+// Records are sorted by denom pair, then by time, as in the pool index. The index is iterated by the SDK pagination
+// helpers, so only the records of the page are kept in memory.
+func (k Keeper) GetHistoricalRecordsPage(
+	ctx sdk.Context,
+	poolId uint64,
+	assetA, assetB string,
+	startTime, endTime *time.Time,
+	pageReq *query.PageRequest,
+) ([]types.TwapRecord, *query.PageResponse, error) {
+	keyPrefix := types.FormatHistoricalPoolIndexPoolPrefix(poolId)
+	if assetA != "" || assetB != "" {
+		asset0Denom, asset1Denom, err := types.LexicographicalOrderDenoms(assetA, assetB)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyPrefix = types.FormatHistoricalPoolIndexTimePrefix(poolId, asset0Denom, asset1Denom)
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), keyPrefix)
+
+	records := []types.TwapRecord{}
+	pageRes, err := query.FilteredPaginate(store, pageReq, func(_, value []byte, accumulate bool) (bool, error) {
+		record, err := types.ParseTwapFromBz(value)
+		if err != nil {
+			return false, err
+		}
+		if (startTime != nil && record.Time.Before(*startTime)) || (endTime != nil && !record.Time.Before(*endTime)) {
+			return false, nil
+		}
+		if accumulate {
+			records = append(records, record)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, pageRes, nil
+}
+
+// GetLastUpdateInfoPage returns a page of the LastUpdateInfos of the most recent records of every denom pair of every
+// pool, as GetLastUpdateInfo, sorted by pool id then by denom pair. If staleOnly, only those of the stale pairs are
+// returned, and the offset and total of the page only count those. The most recent index is iterated by the SDK
+// pagination helpers, so the record history is never read.
+func (k Keeper) GetLastUpdateInfoPage(
+	ctx sdk.Context,
+	maxBlockAge uint64,
+	staleOnly bool,
+	pageReq *query.PageRequest,
+) ([]types.LastUpdateInfo, *query.PageResponse, error) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.FormatMostRecentTWAPsPrefix())
+
+	infos := []types.LastUpdateInfo{}
+	pageRes, err := query.FilteredPaginate(store, pageReq, func(_, value []byte, accumulate bool) (bool, error) {
+		record, err := types.ParseTwapFromBz(value)
+		if err != nil {
+			return false, err
+		}
+		info := types.NewLastUpdateInfo(record, ctx.BlockHeight(), maxBlockAge, k.isPoolQuarantined(ctx, record.PoolId))
+		if staleOnly && !info.Stale {
+			return false, nil
+		}
+		if accumulate {
+			infos = append(infos, info)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return infos, pageRes, nil
+}
+
+// iteratePoolHistoricalRecords is IterateHistoricalRecords for the records of a single pool.
+// Rather than scanning the records of all pools in the time index, it iterates the pool index of every
+// denom pair of the pool, and merges them by time. Records written at the same time are visited in the
+// order of their denoms, as in the time index.
+func (k Keeper) iteratePoolHistoricalRecords(ctx sdk.Context, poolId uint64, startTime time.Time, endTime *time.Time, cb func(record types.TwapRecord) (stop bool)) error {
+	store := ctx.KVStore(k.storeKey)
+	// the most recent records have one entry per denom pair of the pool, sorted by denoms
+	mostRecentRecords, err := types.GetAllMostRecentTwapsForPool(store, poolId)
+	if err != nil {
+		return err
+	}
+
+	type pairIterator struct {
+		iter   sdk.Iterator
+		record types.TwapRecord
+	}
+	// next parses the record the iterator is at, or returns false if it is exhausted.
+	next := func(it *pairIterator) (bool, error) {
+		if !it.iter.Valid() {
+			return false, nil
+		}
+		record, err := types.ParseTwapFromBz(it.iter.Value())
+		if err != nil {
+			return false, err
+		}
+		it.record = record
+		return true, nil
+	}
+
+	pairIterators := make([]*pairIterator, 0, len(mostRecentRecords))
+	defer func() {
+		for _, it := range pairIterators {
+			it.iter.Close()
+		}
+	}()
+	active := make([]*pairIterator, 0, len(mostRecentRecords))
+	for _, mostRecent := range mostRecentRecords {
+		start := types.FormatHistoricalPoolIndexTWAPKey(poolId, mostRecent.Asset0Denom, mostRecent.Asset1Denom, startTime)
+		end := sdk.PrefixEndBytes(types.FormatHistoricalPoolIndexTimePrefix(poolId, mostRecent.Asset0Denom, mostRecent.Asset1Denom))
+		if endTime != nil {
+			end = types.FormatHistoricalPoolIndexTWAPKey(poolId, mostRecent.Asset0Denom, mostRecent.Asset1Denom, *endTime)
+		}
+		it := &pairIterator{iter: store.Iterator(start, end)}
+		pairIterators = append(pairIterators, it)
+		ok, err := next(it)
+		if err != nil {
+			return err
+		}
+		if ok {
+			active = append(active, it)
+		}
+	}
+
+	for len(active) > 0 {
+		// pools have few denom pairs, so a linear scan for the earliest record is enough.
+		// On ties, the first pair in denom order wins.
+		earliest := 0
+		for i := 1; i < len(active); i++ {
+			if active[i].record.Time.Before(active[earliest].record.Time) {
+				earliest = i
+			}
+		}
+		if cb(active[earliest].record) {
+			return nil
+		}
+
+		active[earliest].iter.Next()
+		ok, err := next(active[earliest])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			active = append(active[:earliest], active[earliest+1:]...)
+		}
+	}
+	return nil
+}
+
+// GetStoreVersion returns the version of the twap store, which is the twap module's version in the
+// module version map of x/upgrade. It is bumped by the SDK as the registered migrations are run.
+// Stores without a version in the map are at types.InitialStoreVersion.
+func (k Keeper) GetStoreVersion(ctx sdk.Context) uint64 {
+	version, ok := k.upgradeKeeper.GetModuleVersionMap(ctx)[types.ModuleName]
+	if !ok {
+		return types.InitialStoreVersion
+	}
+	return version
+}
@@ -6,8 +6,7 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-
-	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/cosmos/cosmos-sdk/types/query"
 
 	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
@@ -174,9 +173,9 @@ func (s *TestSuite) TestGetRecordAtOrBeforeTime() {
 		expectedRecord types.TwapRecord
 		expErr         error
 	}{
-		"no entries": {[]types.TwapRecord{}, defaultInputAt(baseTime), baseRecord, fmt.Errorf(
-			"getTwapRecord: querying for assets %s %s that are not in pool id %d",
-			baseRecord.Asset0Denom, baseRecord.Asset1Denom, 1)},
+		"no entries": {[]types.TwapRecord{}, defaultInputAt(baseTime), baseRecord, types.PairNotInPoolError{
+			PoolId: 1, Asset0Denom: baseRecord.Asset0Denom, Asset1Denom: baseRecord.Asset1Denom,
+		}},
 		"get at latest (exact)": {[]types.TwapRecord{baseRecord}, defaultInputAt(baseTime), baseRecord, nil},
 		"rev at latest (exact)": {[]types.TwapRecord{baseRecord}, defaultRevInputAt(baseTime), baseRecord, nil},
 
@@ -213,14 +212,14 @@ func (s *TestSuite) TestGetRecordAtOrBeforeTime() {
 			[]types.TwapRecord{tMin1Record, baseRecord, tPlus1Record},
 			defaultInputAt(baseTime.Add(-time.Second * 2)),
 			baseRecord,
-			twap.TimeTooOldError{Time: baseTime.Add(-time.Second * 2)},
+			types.TimeTooOldError{Time: baseTime.Add(-time.Second * 2)},
 		},
 
 		"non-existent pool ID": {
 			[]types.TwapRecord{tMin1Record, baseRecord, tPlus1Record},
-			wrongPoolIdInputAt(baseTime), baseRecord, fmt.Errorf(
-				"getTwapRecord: querying for assets %s %s that are not in pool id %d",
-				baseRecord.Asset0Denom, baseRecord.Asset1Denom, 2),
+			wrongPoolIdInputAt(baseTime), baseRecord, types.PairNotInPoolError{
+				PoolId: 2, Asset0Denom: baseRecord.Asset0Denom, Asset1Denom: baseRecord.Asset1Denom,
+			},
 		},
 		"pool2 record get": {
 			recordsToSet:   []types.TwapRecord{newEmptyPriceRecord(2, baseTime, denom0, denom1)},
@@ -239,7 +238,7 @@ func (s *TestSuite) TestGetRecordAtOrBeforeTime() {
 				s.Ctx,
 				test.input.poolId, test.input.t, test.input.asset0Denom, test.input.asset1Denom)
 			if test.expErr != nil {
-				s.Require().Equal(test.expErr, err)
+				s.Require().ErrorIs(err, s.withKeepPeriodDetails(test.expErr))
 				return
 			}
 			s.Require().NoError(err)
@@ -530,6 +529,172 @@ func (s *TestSuite) TestGetAllHistoricalPoolIndexedTWAPs() {
 	}
 }
 
+// TestIterateHistoricalRecords checks that iterating the records of a single pool, which merges the pool index
+// of every denom pair of the pool, visits the same records in the same order as filtering the time index.
+func (s *TestSuite) TestIterateHistoricalRecords() {
+	s.SetupTest()
+	records := []types.TwapRecord{}
+	for i := 0; i < 5; i++ {
+		recordTime := baseTime.Add(time.Duration(i) * time.Minute)
+		// the second pair of the three asset pool is written a second later than the two others,
+		// at the same time as pool 2's record
+		for j, record := range newThreeAssetRecord(1, recordTime, sdk.OneDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()) {
+			record.Time = record.Time.Add(time.Duration(j%2) * time.Second)
+			records = append(records, record)
+		}
+		records = append(records, newEmptyPriceRecord(2, recordTime.Add(time.Second), denom0, denom1))
+	}
+	s.preSetRecords(records)
+
+	iterate := func(poolId uint64, startTime time.Time, endTime *time.Time) []types.TwapRecord {
+		visited := []types.TwapRecord{}
+		err := s.twapkeeper.IterateHistoricalRecords(s.Ctx, poolId, startTime, endTime, func(record types.TwapRecord) bool {
+			visited = append(visited, record)
+			return false
+		})
+		s.Require().NoError(err)
+		return visited
+	}
+
+	startTime := baseTime.Add(time.Minute)
+	endTime := baseTime.Add(3 * time.Minute)
+	for _, poolId := range []uint64{1, 2} {
+		for _, end := range []*time.Time{nil, &endTime} {
+			expected := []types.TwapRecord{}
+			for _, record := range iterate(0, startTime, end) {
+				if record.PoolId == poolId {
+					expected = append(expected, record)
+				}
+			}
+			s.Require().NotEmpty(expected)
+			s.Require().Equal(expected, iterate(poolId, startTime, end), "pool %d", poolId)
+		}
+	}
+	s.Require().Empty(iterate(3, baseTime, nil))
+
+	// the iteration stops as soon as cb returns true
+	visited := 0
+	err := s.twapkeeper.IterateHistoricalRecords(s.Ctx, 1, baseTime, nil, func(types.TwapRecord) bool {
+		visited++
+		return visited == 2
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(2, visited)
+}
+
+// TestGetHistoricalRecordsPage pages through several hundred records of pools with one and three denom pairs,
+// checking the page boundaries against the records sorted by denom pair then time, and the time range filter.
+func (s *TestSuite) TestGetHistoricalRecordsPage() {
+	s.SetupTest()
+	minute := func(i int) time.Time { return baseTime.Add(time.Duration(i) * time.Minute) }
+	pool1Records, pool10Records := []types.TwapRecord{}, []types.TwapRecord{}
+	pool2Records := [3][]types.TwapRecord{}
+	for i := 0; i < 150; i++ {
+		pool1Records = append(pool1Records, newEmptyPriceRecord(1, minute(i), denom0, denom1))
+	}
+	for i := 0; i < 100; i++ {
+		for j, record := range newThreeAssetRecord(2, minute(i), sdk.OneDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()) {
+			pool2Records[j] = append(pool2Records[j], record)
+		}
+	}
+	// pool 10's index keys start with pool 1's
+	for i := 0; i < 50; i++ {
+		pool10Records = append(pool10Records, newEmptyPriceRecord(10, minute(i), denom0, denom1))
+	}
+	// the records are written block by block, interleaving the pools and denom pairs
+	for i := 0; i < 150; i++ {
+		s.preSetRecords(pool1Records[i : i+1])
+		if i < 100 {
+			s.preSetRecords([]types.TwapRecord{pool2Records[0][i], pool2Records[1][i], pool2Records[2][i]})
+		}
+		if i < 50 {
+			s.preSetRecords(pool10Records[i : i+1])
+		}
+	}
+	allPool2Records := append(append(append([]types.TwapRecord{}, pool2Records[0]...), pool2Records[1]...), pool2Records[2]...)
+
+	// pageAll follows the next keys of pages of limit records until the last page
+	pageAll := func(poolId uint64, assetA, assetB string, startTime, endTime *time.Time, limit uint64) []types.TwapRecord {
+		all := []types.TwapRecord{}
+		pageReq := &query.PageRequest{Limit: limit}
+		for {
+			records, pageRes, err := s.twapkeeper.GetHistoricalRecordsPage(s.Ctx, poolId, assetA, assetB, startTime, endTime, pageReq)
+			s.Require().NoError(err)
+			s.Require().LessOrEqual(uint64(len(records)), limit)
+			all = append(all, records...)
+			if pageRes.NextKey == nil {
+				return all
+			}
+			s.Require().Len(records, int(limit))
+			pageReq = &query.PageRequest{Key: pageRes.NextKey, Limit: limit}
+		}
+	}
+
+	s.Run("every record of each pool", func() {
+		s.Require().Equal(pool1Records, pageAll(1, "", "", nil, nil, 7))
+		s.Require().Equal(allPool2Records, pageAll(2, "", "", nil, nil, 7))
+		s.Require().Equal(pool10Records, pageAll(10, "", "", nil, nil, 7))
+		s.Require().Empty(pageAll(3, "", "", nil, nil, 7))
+	})
+
+	s.Run("page boundaries", func() {
+		// pages of a single record, and a page of every record
+		s.Require().Equal(allPool2Records, pageAll(2, "", "", nil, nil, 1))
+		s.Require().Equal(allPool2Records, pageAll(2, "", "", nil, nil, 300))
+		s.Require().Equal(allPool2Records, pageAll(2, "", "", nil, nil, 1000))
+
+		// a page by offset, across the boundary of two denom pairs
+		records, pageRes, err := s.twapkeeper.GetHistoricalRecordsPage(s.Ctx, 2, "", "", nil, nil,
+			&query.PageRequest{Offset: 95, Limit: 10, CountTotal: true})
+		s.Require().NoError(err)
+		s.Require().Equal(allPool2Records[95:105], records)
+		s.Require().Equal(uint64(300), pageRes.Total)
+
+		// the last page by offset
+		records, pageRes, err = s.twapkeeper.GetHistoricalRecordsPage(s.Ctx, 1, "", "", nil, nil,
+			&query.PageRequest{Offset: 145, Limit: 10})
+		s.Require().NoError(err)
+		s.Require().Equal(pool1Records[145:], records)
+		s.Require().Nil(pageRes.NextKey)
+	})
+
+	s.Run("denom pair", func() {
+		s.Require().Equal(pool2Records[1], pageAll(2, denom0, denom2, nil, nil, 9))
+		s.Require().Equal(pool2Records[1], pageAll(2, denom2, denom0, nil, nil, 9))
+		s.Require().Equal(pool2Records[2], pageAll(2, denom1, denom2, nil, nil, 9))
+		s.Require().Empty(pageAll(1, denom0, denom2, nil, nil, 9))
+
+		_, _, err := s.twapkeeper.GetHistoricalRecordsPage(s.Ctx, 1, denom0, denom0, nil, nil, nil)
+		s.Require().Error(err)
+	})
+
+	s.Run("time range", func() {
+		startTime, endTime := minute(10), minute(40)
+		// the start time is inclusive and the end time exclusive
+		s.Require().Equal(pool1Records[10:40], pageAll(1, denom0, denom1, &startTime, &endTime, 8))
+		s.Require().Equal(pool1Records[10:], pageAll(1, "", "", &startTime, nil, 8))
+		s.Require().Equal(pool1Records[:40], pageAll(1, "", "", nil, &endTime, 8))
+
+		expected := []types.TwapRecord{}
+		for _, records := range pool2Records {
+			expected = append(expected, records[10:40]...)
+		}
+		s.Require().Equal(expected, pageAll(2, "", "", &startTime, &endTime, 8))
+
+		// the offset and total only count the records within the range
+		records, pageRes, err := s.twapkeeper.GetHistoricalRecordsPage(s.Ctx, 2, "", "", &startTime, &endTime,
+			&query.PageRequest{Offset: 25, Limit: 10, CountTotal: true})
+		s.Require().NoError(err)
+		s.Require().Equal(expected[25:35], records)
+		s.Require().Equal(uint64(90), pageRes.Total)
+
+		// an empty range
+		s.Require().Empty(pageAll(1, "", "", &endTime, &startTime, 8))
+		afterLastRecord := minute(150)
+		s.Require().Empty(pageAll(1, "", "", &afterLastRecord, nil, 8))
+	})
+}
+
 func (s *TestSuite) TestAccumulatorOverflow() {
 	maxSpotPrice := gammtypes.MaxSpotPrice
 	tests := map[string]struct {
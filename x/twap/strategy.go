@@ -6,7 +6,7 @@ import (
 )
 
 // twapStrategy is an interface for computing TWAPs.
-// We have two strategies implementing the interface - arithmetic and geometric.
+// We have three strategies implementing the interface - arithmetic, geometric and harmonic.
 // We expose a common TWAP API to reduce duplication and avoid complexity.
 type twapStrategy interface {
 	// computeTwap calculates the TWAP with specific startRecord and endRecord.
@@ -22,3 +22,23 @@ var _ twapStrategy = &arithmetic{}
 func (s *arithmetic) computeTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) (sdk.Dec, error) {
 	return computeTwap(startRecord, endRecord, quoteAsset, arithmeticTwapType)
 }
+
+type geometric struct {
+	keeper Keeper
+}
+
+var _ twapStrategy = &geometric{}
+
+func (s *geometric) computeTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) (sdk.Dec, error) {
+	return computeTwap(startRecord, endRecord, quoteAsset, geometricTwapType)
+}
+
+type harmonic struct {
+	keeper Keeper
+}
+
+var _ twapStrategy = &harmonic{}
+
+func (s *harmonic) computeTwap(startRecord types.TwapRecord, endRecord types.TwapRecord, quoteAsset string) (sdk.Dec, error) {
+	return computeTwap(startRecord, endRecord, quoteAsset, harmonicTwapType)
+}
@@ -0,0 +1,92 @@
+package twap_test
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types/twapmock"
+)
+
+// setupInmemMetrics makes an in-memory sink the sink of the global metrics until the end of the test
+func (s *TestSuite) setupInmemMetrics() *metrics.InmemSink {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	config := metrics.DefaultConfig("")
+	config.EnableHostname = false
+	config.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(config, sink)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() {
+		_, _ = metrics.NewGlobal(config, &metrics.BlackholeSink{})
+	})
+	return sink
+}
+
+// metricSum returns the sum of the counter or the samples of key in sink, and their count
+func metricSum(sink *metrics.InmemSink, isCounter bool, key ...string) (sum float64, count int) {
+	name := types.ModuleName
+	for _, k := range key {
+		name += "." + k
+	}
+	for _, interval := range sink.Data() {
+		values := interval.Samples
+		if isCounter {
+			values = interval.Counters
+		}
+		if value, ok := values[name]; ok {
+			sum += value.Sum
+			count += value.Count
+		}
+	}
+	return sum, count
+}
+
+func (s *TestSuite) TestTelemetry() {
+	sink := s.setupInmemMetrics()
+
+	// the records written in EndBlock, with and without spot price errors
+	poolIdAB := s.PrepareBalancerPoolWithCoins(defaultTwoAssetCoins...)
+	poolIdABC := s.PrepareBalancerPoolWithCoins(defaultThreeAssetCoins...)
+	s.twapkeeper.EndBlock(s.Ctx)
+	written, _ := metricSum(sink, true, types.MetricKeyEndBlock, types.MetricKeyRecordsWritten)
+	s.Require().Equal(float64(4), written)
+	_, errorCount := metricSum(sink, true, types.MetricKeyEndBlock, types.MetricKeySpotPriceErrors)
+	s.Require().Zero(errorCount)
+
+	s.Commit()
+	mockAMMI := twapmock.NewProgrammedAmmInterface(s.twapkeeper.GetAmmInterface())
+	mockAMMI.ProgramPoolSpotPriceOverride(poolIdAB, denom0, denom1, sdk.Dec{}, errors.New("spot price error"))
+	s.twapkeeper.SetAmmInterface(mockAMMI)
+	s.RunBasicSwap(poolIdAB)
+	s.RunBasicSwap(poolIdABC)
+	s.twapkeeper.EndBlock(s.Ctx)
+	written, _ = metricSum(sink, true, types.MetricKeyEndBlock, types.MetricKeyRecordsWritten)
+	s.Require().Equal(float64(8), written)
+	spotPriceErrors, _ := metricSum(sink, true, types.MetricKeyEndBlock, types.MetricKeySpotPriceErrors)
+	s.Require().Equal(float64(1), spotPriceErrors)
+
+	// the records pruned
+	s.SetupTest()
+	keepPeriod := s.twapkeeper.RecordHistoryKeepPeriod(s.Ctx)
+	oldRecord := newRecord(1, baseTime.Add(-2*keepPeriod), sdk.OneDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	olderRecord := newRecord(1, baseTime.Add(-3*keepPeriod), sdk.OneDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	oldestRecord := newRecord(1, baseTime.Add(-4*keepPeriod), sdk.OneDec(), sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	s.preSetRecords([]types.TwapRecord{oldestRecord, olderRecord, oldRecord})
+	s.Require().NoError(s.twapkeeper.PruneRecords(s.Ctx.WithBlockTime(baseTime)))
+	pruned, _ := metricSum(sink, true, types.MetricKeyPrune, types.MetricKeyRecordsPruned)
+	s.Require().Equal(float64(2), pruned)
+
+	// the latencies of the queries
+	querier := client.Querier{K: *s.twapkeeper}
+	_, err := querier.Params(s.Ctx, queryproto.ParamsRequest{})
+	s.Require().NoError(err)
+	_, err = querier.Params(s.Ctx, queryproto.ParamsRequest{})
+	s.Require().NoError(err)
+	_, count := metricSum(sink, false, types.MetricKeyQuery, "Params")
+	s.Require().Equal(2, count)
+}
@@ -0,0 +1,160 @@
+package testutil
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/x/twap"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+var (
+	// minWalkPrice and maxWalkPrice bound the spot prices of the price walks, so that neither direction of a
+	// pair's spot price ever rounds to zero or nears types.MaxSpotPrice.
+	minWalkPrice = sdk.NewDecWithPrec(1, 12)
+	maxWalkPrice = sdk.NewDec(1_000_000_000_000)
+)
+
+// TimeSpacingDist is the distribution of the times of the generated records of a pool.
+// The time between two consecutive records is drawn uniformly in [MinSpacing, MaxSpacing], truncated to
+// milliseconds, the precision of the accumulators.
+type TimeSpacingDist struct {
+	// FirstRecordTime is the time of the first record of every pool.
+	FirstRecordTime time.Time
+	MinSpacing      time.Duration
+	MaxSpacing      time.Duration
+}
+
+// PriceWalkParams are the parameters of the geometric Brownian motion the spot price of every denom pair follows.
+// Over a time delta of dt seconds, the spot price is multiplied by 1 + Drift * dt + Volatility * sqrt(dt) * Z,
+// for Z drawn from a standard normal distribution.
+type PriceWalkParams struct {
+	// InitialPrice is the spot price of asset 1 in units of asset 0 of every pair at the pool's first record.
+	InitialPrice sdk.Dec
+	// Drift is the expected relative change of the spot price per second.
+	Drift float64
+	// Volatility is the standard deviation of the relative change of the spot price over a second.
+	Volatility float64
+}
+
+// GenerateRecordHistory returns the records of pools 1 to `pools`, each with `pairsPerPool` denom pairs of
+// `recordsPerPair` records, for load testing the pruning and queries of the TWAP module.
+// The same seed and parameters always generate the same records, sorted by pool id, denom pair and then time.
+//
+// The records of a pool are all written at the same times, at the same heights, as in EndBlock. The spot price
+// of every pair walks independently, see PriceWalkParams, so the spot prices of the pairs of a pool aren't
+// consistent with one another. The first record of a pair has zero accumulators, and every following one is
+// its predecessor interpolated to its time with twap.RecordWithUpdatedAccumulators, as the module does.
+func GenerateRecordHistory(
+	seed int64,
+	pools uint64,
+	pairsPerPool int,
+	recordsPerPair int,
+	timeSpacingDist TimeSpacingDist,
+	priceWalkParams PriceWalkParams,
+) []types.TwapRecord {
+	if timeSpacingDist.MinSpacing < time.Millisecond || timeSpacingDist.MaxSpacing < timeSpacingDist.MinSpacing {
+		panic(fmt.Sprintf("invalid time spacing distribution [%s, %s]", timeSpacingDist.MinSpacing, timeSpacingDist.MaxSpacing))
+	}
+	if !priceWalkParams.InitialPrice.IsPositive() {
+		panic(fmt.Sprintf("invalid initial price %s", priceWalkParams.InitialPrice))
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	pairs := generatePairs(pairsPerPool)
+	records := make([]types.TwapRecord, 0, int(pools)*pairsPerPool*recordsPerPair)
+	for poolId := uint64(1); poolId <= pools; poolId++ {
+		times := generateTimes(r, timeSpacingDist, recordsPerPair)
+		for _, pair := range pairs {
+			records = append(records, generatePairHistory(r, poolId, pair, times, priceWalkParams)...)
+		}
+	}
+	return records
+}
+
+// generatePairs returns n distinct denom pairs, in lexicographical order, as the pairs of the fewest assets
+// that have at least n of them.
+func generatePairs(n int) [][2]string {
+	pairs := make([][2]string, 0, n)
+	for numAssets := 2; len(pairs) < n; numAssets++ {
+		// the pairs of the new asset with every previous one
+		for i := 0; i < numAssets-1 && len(pairs) < n; i++ {
+			denom0, denom1, err := types.LexicographicalOrderDenoms(assetDenom(i), assetDenom(numAssets-1))
+			if err != nil {
+				panic(err)
+			}
+			pairs = append(pairs, [2]string{denom0, denom1})
+		}
+	}
+	return pairs
+}
+
+func assetDenom(i int) string {
+	return fmt.Sprintf("denom%03d", i)
+}
+
+// generateTimes returns n record times following timeSpacingDist.
+func generateTimes(r *rand.Rand, timeSpacingDist TimeSpacingDist, n int) []time.Time {
+	times := make([]time.Time, n)
+	t := timeSpacingDist.FirstRecordTime
+	spacingRange := int64(timeSpacingDist.MaxSpacing - timeSpacingDist.MinSpacing)
+	for i := range times {
+		if i > 0 {
+			spacing := timeSpacingDist.MinSpacing + time.Duration(r.Int63n(spacingRange+1))
+			t = t.Add(spacing.Truncate(time.Millisecond))
+		}
+		times[i] = t
+	}
+	return times
+}
+
+// generatePairHistory returns the records of the pair of pool poolId at the given times.
+func generatePairHistory(r *rand.Rand, poolId uint64, pair [2]string, times []time.Time, priceWalkParams PriceWalkParams) []types.TwapRecord {
+	records := make([]types.TwapRecord, len(times))
+	price := priceWalkParams.InitialPrice
+	for i, t := range times {
+		var record types.TwapRecord
+		if i == 0 {
+			record = types.TwapRecord{
+				PoolId:                      poolId,
+				Asset0Denom:                 pair[0],
+				Asset1Denom:                 pair[1],
+				P0ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				P1ArithmeticTwapAccumulator: sdk.ZeroDec(),
+				GeometricTwapAccumulator:    sdk.ZeroDec(),
+				SchemaVersion:               types.CurrentRecordSchemaVersion,
+			}
+		} else {
+			// the accumulators grow by the spot prices of the previous record until this one
+			record = twap.RecordWithUpdatedAccumulators(records[i-1], t)
+			price = walkPrice(r, price, t.Sub(times[i-1]), priceWalkParams)
+		}
+		record.Height = int64(i + 1)
+		record.Time = t
+		record.P0LastSpotPrice = price
+		record.P1LastSpotPrice = sdk.OneDec().Quo(price)
+		records[i] = record
+	}
+	return records
+}
+
+// walkPrice returns the next spot price of a price walk at price, after the time delta dt.
+func walkPrice(r *rand.Rand, price sdk.Dec, dt time.Duration, priceWalkParams PriceWalkParams) sdk.Dec {
+	seconds := dt.Seconds()
+	factor := 1 + priceWalkParams.Drift*seconds + priceWalkParams.Volatility*math.Sqrt(seconds)*r.NormFloat64()
+	// the factor is rounded to 9 decimals, and kept positive so that the price never crosses zero
+	factor = math.Max(factor, 1e-9)
+	price = price.Mul(sdk.MustNewDecFromStr(strconv.FormatFloat(factor, 'f', 9, 64)))
+	if price.LT(minWalkPrice) {
+		return minWalkPrice
+	}
+	if price.GT(maxWalkPrice) {
+		return maxWalkPrice
+	}
+	return price
+}
@@ -0,0 +1,80 @@
+package testutil_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v13/osmomath"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/testutil"
+	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
+)
+
+var (
+	testTimeSpacingDist = testutil.TimeSpacingDist{
+		FirstRecordTime: time.Unix(1257894000, 0).UTC(),
+		MinSpacing:      time.Second,
+		MaxSpacing:      time.Minute,
+	}
+	testPriceWalkParams = testutil.PriceWalkParams{
+		InitialPrice: sdk.NewDec(2),
+		Drift:        0.0001,
+		Volatility:   0.01,
+	}
+)
+
+// TestGenerateRecordHistory tests that the generated records have the requested shape, and that their
+// accumulators match a brute force sum of their spot prices over time.
+func TestGenerateRecordHistory(t *testing.T) {
+	const (
+		pools          = 2
+		pairsPerPool   = 4
+		recordsPerPair = 50
+	)
+	records := testutil.GenerateRecordHistory(1, pools, pairsPerPool, recordsPerPair, testTimeSpacingDist, testPriceWalkParams)
+	require.Len(t, records, pools*pairsPerPool*recordsPerPair)
+
+	// the same seed generates the same records, another seed doesn't
+	require.Equal(t, records, testutil.GenerateRecordHistory(1, pools, pairsPerPool, recordsPerPair, testTimeSpacingDist, testPriceWalkParams))
+	require.NotEqual(t, records, testutil.GenerateRecordHistory(2, pools, pairsPerPool, recordsPerPair, testTimeSpacingDist, testPriceWalkParams))
+
+	pairs := map[uint64]map[[2]string]bool{}
+	for start := 0; start < len(records); start += recordsPerPair {
+		history := records[start : start+recordsPerPair]
+		first := history[0]
+		require.Less(t, first.Asset0Denom, first.Asset1Denom)
+		if pairs[first.PoolId] == nil {
+			pairs[first.PoolId] = map[[2]string]bool{}
+		}
+		pairs[first.PoolId][[2]string{first.Asset0Denom, first.Asset1Denom}] = true
+
+		p0Accum, p1Accum, geometricAccum := sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()
+		for i, record := range history {
+			require.Equal(t, first.PoolId, record.PoolId)
+			require.Equal(t, first.Asset0Denom, record.Asset0Denom)
+			require.Equal(t, first.Asset1Denom, record.Asset1Denom)
+			require.Equal(t, types.CurrentRecordSchemaVersion, record.SchemaVersion)
+			require.True(t, record.P0LastSpotPrice.IsPositive())
+			require.True(t, record.P1LastSpotPrice.IsPositive())
+
+			if i > 0 {
+				previous := history[i-1]
+				require.True(t, record.Time.After(previous.Time))
+				deltaMS := record.Time.Sub(previous.Time).Milliseconds()
+				p0Accum = p0Accum.Add(previous.P0LastSpotPrice.MulInt64(deltaMS))
+				p1Accum = p1Accum.Add(previous.P1LastSpotPrice.MulInt64(deltaMS))
+				log := osmomath.BigDecFromSDKDec(previous.P0LastSpotPrice).LogBase2().SDKDec()
+				geometricAccum = geometricAccum.Add(log.MulInt64(deltaMS))
+			}
+			require.Equal(t, p0Accum, record.P0ArithmeticTwapAccumulator, "record %d", i)
+			require.Equal(t, p1Accum, record.P1ArithmeticTwapAccumulator, "record %d", i)
+			require.Equal(t, geometricAccum, record.GeometricTwapAccumulator, "record %d", i)
+		}
+	}
+	require.Len(t, pairs, pools)
+	for _, poolPairs := range pairs {
+		require.Len(t, poolPairs, pairsPerPool)
+	}
+}
@@ -34,6 +34,7 @@ type AppModuleBasic struct{}
 func (AppModuleBasic) Name() string { return types.ModuleName }
 
 func (AppModuleBasic) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	types.RegisterCodec(cdc)
 }
 
 func (AppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
@@ -59,8 +60,7 @@ func (b AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, mux
 }
 
 func (b AppModuleBasic) GetTxCmd() *cobra.Command {
-	return nil
-	// return cli.NewTxCmd()
+	return twapcli.GetTxCmd()
 }
 
 func (b AppModuleBasic) GetQueryCmd() *cobra.Command {
@@ -69,26 +69,41 @@ func (b AppModuleBasic) GetQueryCmd() *cobra.Command {
 
 // RegisterInterfaces registers interfaces and implementations of the gamm module.
 func (AppModuleBasic) RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	types.RegisterInterfaces(registry)
 }
 
 type AppModule struct {
 	AppModuleBasic
 
-	k twap.Keeper
+	k               twap.Keeper
+	newQueryContext func(height int64) (sdk.Context, error)
 }
 
 func (am AppModule) RegisterServices(cfg module.Configurator) {
-	queryproto.RegisterQueryServer(cfg.QueryServer(), grpc.Querier{Q: twapclient.Querier{K: am.k}})
+	types.RegisterMsgServer(cfg.MsgServer(), twap.NewMsgServerImpl(&am.k))
+	queryproto.RegisterQueryServer(cfg.QueryServer(), grpc.Querier{Q: twapclient.Querier{K: am.k, NewQueryContext: am.newQueryContext}})
+
+	if err := cfg.RegisterMigration(types.ModuleName, types.InitialStoreVersion, am.k.MigrateRecordSchemaVersion); err != nil {
+		panic(fmt.Sprintf("failed to register the twap migration to store version %d: %s", types.RecordSchemaVersionStoreVersion, err))
+	}
+	if err := cfg.RegisterMigration(types.ModuleName, types.RecordSchemaVersionStoreVersion, am.k.MigratePairPoolIndex); err != nil {
+		panic(fmt.Sprintf("failed to register the twap migration to store version %d: %s", types.PairPoolIndexStoreVersion, err))
+	}
 }
 
-func NewAppModule(twapKeeper twap.Keeper) AppModule {
+// NewAppModule returns the twap module. newQueryContext returns a context on the state committed at a height,
+// or at the latest height if it is 0, and is used to serve streaming queries. They are disabled if it is nil.
+func NewAppModule(twapKeeper twap.Keeper, newQueryContext func(height int64) (sdk.Context, error)) AppModule {
 	return AppModule{
-		AppModuleBasic: AppModuleBasic{},
-		k:              twapKeeper,
+		AppModuleBasic:  AppModuleBasic{},
+		k:               twapKeeper,
+		newQueryContext: newQueryContext,
 	}
 }
 
+// RegisterInvariants registers the twap module's invariants.
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	twap.RegisterInvariants(ir, am.k)
 }
 
 func (am AppModule) Route() sdk.Route {
@@ -134,4 +149,4 @@ func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.Val
 }
 
 // ConsensusVersion implements AppModule/ConsensusVersion.
-func (AppModule) ConsensusVersion() uint64 { return 1 }
+func (AppModule) ConsensusVersion() uint64 { return types.ConsensusVersion }
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	authzcodec "github.com/cosmos/cosmos-sdk/x/authz/codec"
+)
+
+func RegisterCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&MsgPinTwapRecord{}, "osmosis/twap/pin-twap-record", nil)
+	cdc.RegisterConcrete(&MsgUnpinTwapRecord{}, "osmosis/twap/unpin-twap-record", nil)
+	cdc.RegisterConcrete(&MsgSetCanonicalTwapRoute{}, "osmosis/twap/set-canonical-twap-route", nil)
+	cdc.RegisterConcrete(&MsgRemoveCanonicalTwapRoute{}, "osmosis/twap/remove-canonical-twap-route", nil)
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "osmosis/twap/update-params", nil)
+}
+
+func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
+	registry.RegisterImplementations(
+		(*sdk.Msg)(nil),
+		&MsgPinTwapRecord{},
+		&MsgUnpinTwapRecord{},
+		&MsgSetCanonicalTwapRoute{},
+		&MsgRemoveCanonicalTwapRoute{},
+		&MsgUpdateParams{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+var (
+	amino     = codec.NewLegacyAmino()
+	ModuleCdc = codec.NewProtoCodec(cdctypes.NewInterfaceRegistry())
+)
+
+func init() {
+	RegisterCodec(amino)
+	// Register all Amino interfaces and concrete types on the authz Amino codec so that this can later be
+	// used to properly serialize MsgGrant and MsgExec instances
+	sdk.RegisterLegacyAminoCodec(amino)
+	RegisterCodec(authzcodec.Amino)
+
+	amino.Seal()
+}
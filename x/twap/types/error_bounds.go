@@ -0,0 +1,49 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmomath"
+)
+
+// TwapType is the kind of mean a TWAP is computed as
+type TwapType int
+
+const (
+	// ArithmeticTwapType is the time weighted arithmetic mean of the spot prices
+	ArithmeticTwapType TwapType = iota
+	// GeometricTwapType is the time weighted geometric mean of the spot prices
+	GeometricTwapType
+)
+
+// GeometricTwapPowPrecision is the precision of the power approximation computing geometric TWAPs from the mean of
+// the logarithms of the spot prices. The approximation stops at the first term of its series below it.
+var GeometricTwapPowPrecision = sdk.MustNewDecFromStr("0.00000001")
+
+// TwapErrorBounds returns the tolerance within which a TWAP of twapType over windowDuration, of at least
+// priceMagnitude, is of the exact time weighted mean of the spot prices recorded over its window. The window is the
+// one the accumulators account for, with both of its ends truncated to the millisecond. priceMagnitude must be
+// positive. Use it as TwapErrorBounds(...).CompareBigDec(exact, osmomath.BigDecFromSDKDec(twap)) == 0.
+//
+// An empty window returns the last spot price, so it has no error.
+// An arithmetic TWAP is the difference of exact accumulators divided by the window, truncated to 10^-18.
+// A geometric TWAP is 2 to the power of the mean of the base 2 logarithms of the asset 0 spot prices. The logarithms
+// and their mean are kept to 10^-18, so its error comes from the power approximation, whose absolute error is
+// below GeometricTwapPowPrecision. The asset 1 TWAP is the reciprocal of the asset 0 TWAP, rounded to 10^-18.
+// As the approximation only converges for non-negative exponents, the geometric bounds hold for pools whose asset 0
+// TWAP is at least 1, quoted in either asset.
+func TwapErrorBounds(twapType TwapType, windowDuration time.Duration, priceMagnitude sdk.Dec) osmomath.ErrTolerance {
+	if windowDuration == 0 {
+		return osmomath.ErrTolerance{AdditiveTolerance: sdk.ZeroDec()}
+	}
+	if twapType == ArithmeticTwapType {
+		return osmomath.ErrTolerance{AdditiveTolerance: sdk.SmallestDec(), RoundingDir: osmomath.RoundDown}
+	}
+	// The power approximation's error is relative to asset 0 TWAPs of at least 1, and twice its precision leaves room
+	// for the rounding of its terms. The reciprocal's rounding is relative to the asset 1 TWAP.
+	return osmomath.ErrTolerance{
+		MultiplicativeTolerance: GeometricTwapPowPrecision.MulInt64(2).Add(sdk.SmallestDec().Quo(priceMagnitude)),
+	}
+}
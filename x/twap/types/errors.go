@@ -1,10 +1,86 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	time "time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The errors of the module are either one of the sentinel errors below, or one of the error types of this file, so
+// that callers can tell them apart with errors.Is and errors.As rather than by their message. Errors giving context
+// to another one wrap it.
+var (
+	// ErrRecordNotFound is the error of a lookup of a twap record that isn't in state, e.g. for a pool or a denom
+	// pair without records. The errors giving the pool and denoms of the lookup wrap it.
+	ErrRecordNotFound = errors.New("twap not found")
+	// ErrSpotPriceErrorInWindow is returned along with a TWAP whose window contains an error of the pool's spot
+	// price. The TWAP is still computed, but may be faulty.
+	ErrSpotPriceErrorInWindow = errors.New("twap: error in pool spot price occurred between start and end time, twap result may be faulty")
+)
+
+// The gRPC status of a query for a time older than the record history keep period has an errdetails.ErrorInfo with
+// this reason, in the module's domain, whose metadata holds the requested time, the keep period and the oldest
+// queryable time at these keys.
+const (
+	ErrorInfoReasonTimeTooOld       = "TIME_TOO_OLD"
+	ErrorInfoKeyRequestedTime       = "requested_time"
+	ErrorInfoKeyKeepPeriod          = "keep_period"
+	ErrorInfoKeyOldestQueryableTime = "oldest_queryable_time"
 )
 
+// TimeTooOldError is returned for a time before the records kept in state. It carries the record history keep
+// period and the oldest time that can be queried, i.e. the pruning boundary at the block time, so that a client
+// doesn't need a params query to know how far back it may go.
+type TimeTooOldError struct {
+	Time                time.Time
+	KeepPeriod          time.Duration
+	OldestQueryableTime time.Time
+}
+
+func (e TimeTooOldError) Error() string {
+	return fmt.Sprintf("looking for a time thats too old, not in the historical index. "+
+		" Try storing the accumulator value. (requested time %s, keep period %s, oldest queryable time %s)",
+		e.Time, e.KeepPeriod, e.OldestQueryableTime)
+}
+
+// GRPCStatus returns the OutOfRange status of the error, with an errdetails.ErrorInfo holding its times and keep
+// period in the protobuf JSON format of timestamps and durations, for gRPC clients to read without parsing the
+// message.
+func (e TimeTooOldError) GRPCStatus() *status.Status {
+	st := status.New(codes.OutOfRange, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ErrorInfoReasonTimeTooOld,
+		Domain: ModuleName,
+		Metadata: map[string]string{
+			ErrorInfoKeyRequestedTime:       e.Time.UTC().Format(time.RFC3339Nano),
+			ErrorInfoKeyKeepPeriod:          strconv.FormatFloat(e.KeepPeriod.Seconds(), 'f', -1, 64) + "s",
+			ErrorInfoKeyOldestQueryableTime: e.OldestQueryableTime.UTC().Format(time.RFC3339Nano),
+		},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+type TimeInFutureError struct {
+	Time      time.Time
+	BlockTime time.Time
+}
+
+func (e TimeInFutureError) Error() string {
+	return fmt.Sprintf("called GetHistoricalSpotPrice with a time in the future."+
+		" (time %s, current time %s)", e.Time, e.BlockTime)
+}
+
 type EndTimeInFutureError struct {
 	EndTime   time.Time
 	BlockTime time.Time
@@ -15,14 +91,53 @@ func (e EndTimeInFutureError) Error() string {
 		" (end time %s, current time %s)", e.EndTime, e.BlockTime)
 }
 
-type StartTimeAfterEndTimeError struct {
-	StartTime time.Time
-	EndTime   time.Time
+// InvalidTimeRangeError is returned for a time range whose start is after its end, e.g. a TWAP queried with its start
+// and end times reversed. It is checked before any record is read. A TWAP over an empty window, whose start equals its
+// end, is still valid, being the last spot price, whereas ranges split into intervals must not be empty.
+type InvalidTimeRangeError struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (e InvalidTimeRangeError) Error() string {
+	return fmt.Sprintf("invalid time range, the start time must be before the end time."+
+		" (start time %s, end time %s)", e.Start, e.End)
 }
 
-func (e StartTimeAfterEndTimeError) Error() string {
-	return fmt.Sprintf("called GetArithmeticTwap with a start time that is after the end time."+
-		" (start time %s, end time %s)", e.StartTime, e.EndTime)
+// GRPCStatus returns the InvalidArgument status of the error, as the range is invalid whatever the state.
+func (e InvalidTimeRangeError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// ZeroArithmeticTwapError is returned for a harmonic TWAP whose window has a zero arithmetic TWAP of the other asset
+// of the pair, e.g. when the spot prices of that asset are too small to change its accumulator. The harmonic TWAP is
+// the reciprocal of that arithmetic TWAP, so it has no value.
+type ZeroArithmeticTwapError struct {
+	PoolId     uint64
+	QuoteAsset string
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+func (e ZeroArithmeticTwapError) Error() string {
+	return fmt.Sprintf("harmonic twap of pool %d quoted in %s is undefined, the arithmetic twap of the other asset is zero."+
+		" (start time %s, end time %s)", e.PoolId, e.QuoteAsset, e.StartTime, e.EndTime)
+}
+
+// GRPCStatus returns the FailedPrecondition status of the error, as the TWAP depends on the records of the window.
+func (e ZeroArithmeticTwapError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+type RecordAfterTargetTimeError struct {
+	PoolId     uint64
+	RecordTime time.Time
+	TargetTime time.Time
+}
+
+func (e RecordAfterTargetTimeError) Error() string {
+	return fmt.Sprintf("twap record of pool %d at time %s is after the time %s it is interpolated to,"+
+		" accumulators can't be interpolated back in time", e.PoolId, e.RecordTime, e.TargetTime)
 }
 
 type KeySeparatorLengthError struct {
@@ -62,3 +177,317 @@ type InvalidRecordCountError struct {
 func (e InvalidRecordCountError) Error() string {
 	return fmt.Sprintf("The number of records do not match, expected: %d\n got: %d", e.Expected, e.Actual)
 }
+
+type PinUnauthorizedError struct {
+	Sender       string
+	PinAuthority string
+}
+
+func (e PinUnauthorizedError) Error() string {
+	if e.PinAuthority == "" {
+		return "twap record pinning is disabled, no pin authority is set"
+	}
+	return fmt.Sprintf("sender %s is not the pin authority %s", e.Sender, e.PinAuthority)
+}
+
+type MaxPinnedRecordsError struct {
+	MaxPinnedRecords uint64
+}
+
+func (e MaxPinnedRecordsError) Error() string {
+	return fmt.Sprintf("cannot pin more than %d records, unpin a record first", e.MaxPinnedRecords)
+}
+
+type RecordAlreadyPinnedError struct {
+	PoolId     uint64
+	RecordTime time.Time
+}
+
+func (e RecordAlreadyPinnedError) Error() string {
+	return fmt.Sprintf("twap record of pool %d at time %s is already pinned", e.PoolId, e.RecordTime)
+}
+
+type PinnedRecordNotFoundError struct {
+	PoolId uint64
+	Time   time.Time
+}
+
+func (e PinnedRecordNotFoundError) Error() string {
+	return fmt.Sprintf("no pinned twap record of pool %d at or before time %s", e.PoolId, e.Time)
+}
+
+type PoolQuarantinedError struct {
+	PoolId uint64
+}
+
+func (e PoolQuarantinedError) Error() string {
+	return fmt.Sprintf("twap records of pool %d are quarantined, the pool's denoms do not match its records", e.PoolId)
+}
+
+type PoolNotQuarantinedError struct {
+	PoolId uint64
+}
+
+func (e PoolNotQuarantinedError) Error() string {
+	return fmt.Sprintf("twap records of pool %d are not quarantined", e.PoolId)
+}
+
+type TrackingGapError struct {
+	PoolId uint64
+	From   time.Time
+	To     time.Time
+}
+
+func (e TrackingGapError) Error() string {
+	return fmt.Sprintf("twap records of pool %d were not updated from %s to %s, which overlaps the requested window", e.PoolId, e.From, e.To)
+}
+
+// SameDenomError is returned when the two denoms of a pair, e.g. the base and quote assets of a TWAP, are the same
+type SameDenomError struct {
+	Denom string
+}
+
+func (e SameDenomError) Error() string {
+	return fmt.Sprintf("both assets cannot be of the same denom: assetA: %s, assetB: %s", e.Denom, e.Denom)
+}
+
+// PairNotInPoolError is returned when a pool has no records for a denom pair, because the pool doesn't exist or
+// doesn't have both denoms. It carries the current denoms of the pool, if it exists, so that a query for the denoms
+// of another pool tells which ones the pool has. It wraps ErrRecordNotFound.
+type PairNotInPoolError struct {
+	PoolId      uint64
+	Asset0Denom string
+	Asset1Denom string
+	PoolDenoms  []string
+}
+
+func (e PairNotInPoolError) Error() string {
+	msg := fmt.Sprintf("getTwapRecord: querying for assets %s %s that are not in pool id %d",
+		e.Asset0Denom, e.Asset1Denom, e.PoolId)
+	if len(e.PoolDenoms) > 0 {
+		msg += fmt.Sprintf(", the pool has denoms %s", strings.Join(e.PoolDenoms, ", "))
+	}
+	return msg
+}
+
+// Is reports whether target is a PairNotInPoolError of the same pool, denoms and pool denoms, as the error isn't
+// comparable.
+func (e PairNotInPoolError) Is(target error) bool {
+	t, ok := target.(PairNotInPoolError)
+	return ok && reflect.DeepEqual(e, t)
+}
+
+func (e PairNotInPoolError) Unwrap() error {
+	return ErrRecordNotFound
+}
+
+// DenomNotInPoolError is returned when no record of a pool has a denom. It wraps ErrRecordNotFound.
+type DenomNotInPoolError struct {
+	PoolId uint64
+	Denom  string
+}
+
+func (e DenomNotInPoolError) Error() string {
+	return fmt.Sprintf("pool %d has no twap records for denom %s", e.PoolId, e.Denom)
+}
+
+func (e DenomNotInPoolError) Unwrap() error {
+	return ErrRecordNotFound
+}
+
+// DenomAlreadyInPoolError is returned when records are about to be written for a denom the pool already has
+// records for
+type DenomAlreadyInPoolError struct {
+	PoolId uint64
+	Denom  string
+}
+
+func (e DenomAlreadyInPoolError) Error() string {
+	return fmt.Sprintf("pool %d already has twap records for denom %s", e.PoolId, e.Denom)
+}
+
+// SpotPriceUncomputableError is returned when the records of a pool can't be created, as the spot price of one of
+// its denom pairs errors
+type SpotPriceUncomputableError struct {
+	PoolId      uint64
+	Asset0Denom string
+	Asset1Denom string
+}
+
+func (e SpotPriceUncomputableError) Error() string {
+	return fmt.Sprintf("spot price of %s and %s could not be computed", e.Asset0Denom, e.Asset1Denom)
+}
+
+// NonPositiveDurationError is returned when a duration argument, e.g. a TWAP window, is zero or negative
+type NonPositiveDurationError struct {
+	Name     string
+	Duration time.Duration
+}
+
+func (e NonPositiveDurationError) Error() string {
+	return fmt.Sprintf("%s must be positive, was %s", e.Name, e.Duration)
+}
+
+// NegativeDurationError is returned when a duration argument that may be zero, e.g. the window of a TWAP to
+// combine, is negative
+type NegativeDurationError struct {
+	Name     string
+	Duration time.Duration
+}
+
+func (e NegativeDurationError) Error() string {
+	return fmt.Sprintf("%s must not be negative, was %s", e.Name, e.Duration)
+}
+
+// NonPositiveTwapError is returned when a geometric TWAP to combine is zero or negative, as it has no logarithm
+type NonPositiveTwapError struct {
+	Twap sdk.Dec
+}
+
+func (e NonPositiveTwapError) Error() string {
+	return fmt.Sprintf("geometric twap must be positive, was %s", e.Twap)
+}
+
+// ZeroTwapError is returned when a TWAP that a value is divided by is zero
+type ZeroTwapError struct {
+	PoolId     uint64
+	BaseAsset  string
+	QuoteAsset string
+	Window     time.Duration
+}
+
+func (e ZeroTwapError) Error() string {
+	return fmt.Sprintf("the TWAP of %s in %s over the last %s in pool %d is zero", e.BaseAsset, e.QuoteAsset, e.Window, e.PoolId)
+}
+
+type TooManyCandlesError struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	Interval   time.Duration
+	NumCandles int64
+	MaxCandles int64
+}
+
+func (e TooManyCandlesError) Error() string {
+	return fmt.Sprintf("[%s, %s] spans %d intervals of %s, the maximum is %d", e.StartTime, e.EndTime, e.NumCandles, e.Interval, e.MaxCandles)
+}
+
+// TooManyPairsError is returned when more pairs are requested at once than a query resolves.
+type TooManyPairsError struct {
+	NumPairs int
+	MaxPairs int
+}
+
+func (e TooManyPairsError) Error() string {
+	return fmt.Sprintf("%d pairs were requested, the maximum is %d", e.NumPairs, e.MaxPairs)
+}
+
+// NoSpotPriceSamplesError is returned for a median spot price over a window without a spot price sample of the pair
+// that didn't error, e.g. because the pool isn't in the median tracked pools param. It wraps ErrRecordNotFound.
+type NoSpotPriceSamplesError struct {
+	PoolId      uint64
+	Asset0Denom string
+	Asset1Denom string
+	StartTime   time.Time
+	EndTime     time.Time
+}
+
+func (e NoSpotPriceSamplesError) Error() string {
+	return fmt.Sprintf("no spot price samples of assets %s %s of pool %d in [%s, %s],"+
+		" the pool must be in the median tracked pools param", e.Asset0Denom, e.Asset1Denom, e.PoolId, e.StartTime, e.EndTime)
+}
+
+func (e NoSpotPriceSamplesError) Unwrap() error {
+	return ErrRecordNotFound
+}
+
+// ArchiveDisabledError is returned by the archive queries of a node that didn't enable its archive in app.toml.
+type ArchiveDisabledError struct{}
+
+func (e ArchiveDisabledError) Error() string {
+	return "the twap archive is not enabled on this node, see osmosis-twap.archive in app.toml"
+}
+
+// GRPCStatus returns the FailedPrecondition status of the error, as the query may succeed against another node.
+func (e ArchiveDisabledError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ArchivedRecordNotFoundError is returned for a time before the first archived record of a denom pair of a pool,
+// i.e. before the pool was created or the node enabled its archive. It wraps ErrRecordNotFound.
+type ArchivedRecordNotFoundError struct {
+	PoolId      uint64
+	Asset0Denom string
+	Asset1Denom string
+	Time        time.Time
+}
+
+func (e ArchivedRecordNotFoundError) Error() string {
+	return fmt.Sprintf("no archived twap record of assets %s %s of pool %d at or before time %s",
+		e.Asset0Denom, e.Asset1Denom, e.PoolId, e.Time)
+}
+
+func (e ArchivedRecordNotFoundError) Unwrap() error {
+	return ErrRecordNotFound
+}
+
+// InvalidRouteError is returned for a TWAP route without hops, or whose hops don't chain, i.e. a hop whose quote
+// asset isn't the base asset of the next hop.
+type InvalidRouteError struct {
+	Reason string
+}
+
+func (e InvalidRouteError) Error() string {
+	return fmt.Sprintf("invalid twap route: %s", e.Reason)
+}
+
+// TooManyHopsError is returned for a TWAP route with more hops than a route TWAP is computed over.
+type TooManyHopsError struct {
+	NumHops int
+	MaxHops int
+}
+
+func (e TooManyHopsError) Error() string {
+	return fmt.Sprintf("the route has %d hops, the maximum is %d", e.NumHops, e.MaxHops)
+}
+
+// CanonicalRouteUnauthorizedError is returned for a canonical route msg not sent by the canonical route authority,
+// or sent while setting the canonical routes is disabled.
+type CanonicalRouteUnauthorizedError struct {
+	Sender    string
+	Authority string
+}
+
+func (e CanonicalRouteUnauthorizedError) Error() string {
+	if e.Authority == "" {
+		return "canonical twap routes are disabled, no canonical route authority is set"
+	}
+	return fmt.Sprintf("sender %s is not the canonical route authority %s", e.Sender, e.Authority)
+}
+
+// CanonicalRouteNotFoundError is returned for a denom without a canonical route.
+type CanonicalRouteNotFoundError struct {
+	Denom string
+}
+
+func (e CanonicalRouteNotFoundError) Error() string {
+	return fmt.Sprintf("denom %s has no canonical twap route", e.Denom)
+}
+
+func (e CanonicalRouteNotFoundError) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ParamsUnauthorizedError is returned for a MsgUpdateParams not sent by the params authority, or sent while updating
+// the params with it is disabled.
+type ParamsUnauthorizedError struct {
+	Sender    string
+	Authority string
+}
+
+func (e ParamsUnauthorizedError) Error() string {
+	if e.Authority == "" {
+		return "updating the twap params is disabled, no params authority is set"
+	}
+	return fmt.Sprintf("sender %s is not the params authority %s", e.Sender, e.Authority)
+}
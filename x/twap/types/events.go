@@ -0,0 +1,58 @@
+package types
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// event types
+const (
+	TypeEvtPinTwapRecord      = "pin_twap_record"
+	TypeEvtUnpinTwapRecord    = "unpin_twap_record"
+	TypeEvtSkipGenesisPool    = "skip_genesis_pool_twap_records"
+	TypeEvtQuarantinePool     = "quarantine_twap_pool"
+	TypeEvtRepairPool         = "repair_twap_pool"
+	TypeEvtSpotDeviationAlert = "twap_spot_deviation_alert"
+	TypeEvtTwapRecordUpdate   = "twap_record_update"
+
+	TypeEvtSetCanonicalTwapRoute    = "set_canonical_twap_route"
+	TypeEvtRemoveCanonicalTwapRoute = "remove_canonical_twap_route"
+	TypeEvtUpdateParams             = "update_twap_params"
+
+	AttributeSender       = "sender"
+	AttributePoolId       = "pool_id"
+	AttributeDenom0       = "denom0"
+	AttributeDenom1       = "denom1"
+	AttributeRecordTime   = "record_time"
+	AttributeReason       = "reason"
+	AttributeRecordDenoms = "record_denoms"
+	AttributePoolDenoms   = "pool_denoms"
+	AttributeDeviation    = "deviation"
+	AttributeThreshold    = "threshold"
+	// the attributes of the record update events
+	AttributeP0LastSpotPrice = "p0_last_spot_price"
+	AttributeP1LastSpotPrice = "p1_last_spot_price"
+	AttributeHeight          = "height"
+	AttributeSpotPriceError  = "spot_price_error"
+	// the attributes of the canonical route events
+	AttributeDenom = "denom"
+	AttributeRoute = "route"
+	// the attributes of the params update events
+	AttributeRecordHistoryKeepPeriod = "record_history_keep_period"
+)
+
+// EmitTwapRecordUpdateEvent emits the event of the update of a record in EndBlock, with the pool id, the denom pair,
+// both last spot prices and the height of the record, and whether the pool's spot price errored when it was written.
+func EmitTwapRecordUpdateEvent(ctx sdk.Context, record TwapRecord) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		TypeEvtTwapRecordUpdate,
+		sdk.NewAttribute(AttributePoolId, strconv.FormatUint(record.PoolId, 10)),
+		sdk.NewAttribute(AttributeDenom0, record.Asset0Denom),
+		sdk.NewAttribute(AttributeDenom1, record.Asset1Denom),
+		sdk.NewAttribute(AttributeP0LastSpotPrice, record.P0LastSpotPrice.String()),
+		sdk.NewAttribute(AttributeP1LastSpotPrice, record.P1LastSpotPrice.String()),
+		sdk.NewAttribute(AttributeHeight, strconv.FormatInt(record.Height, 10)),
+		sdk.NewAttribute(AttributeSpotPriceError, strconv.FormatBool(!record.LastErrorTime.Before(record.Time))),
+	))
+}
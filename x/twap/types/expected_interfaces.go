@@ -1,10 +1,18 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
+)
 
 // AmmInterface is the functionality needed from a given pool ID, in order to maintain records and serve TWAPs.
 type AmmInterface interface {
 	GetPoolDenoms(ctx sdk.Context, poolId uint64) (denoms []string, err error)
+	// GetPoolsAndPoke returns all the pools, which is used to create the records of pools
+	// that were imported via genesis.
+	GetPoolsAndPoke(ctx sdk.Context) (res []gammtypes.CFMMPoolI, err error)
 	// CalculateSpotPrice returns the spot price of the quote asset in terms of the base asset,
 	// using the specified pool.
 	// E.g. if pool 1 traded 2 atom for 3 osmo, the quote asset was atom, and the base asset was osmo,
@@ -16,3 +24,8 @@ type AmmInterface interface {
 		baseAssetDenom string,
 	) (price sdk.Dec, err error)
 }
+
+// UpgradeKeeper is the functionality needed from x/upgrade to tell which migrations of the twap store have run.
+type UpgradeKeeper interface {
+	GetModuleVersionMap(ctx sdk.Context) module.VersionMap
+}
@@ -29,6 +29,26 @@ func (g *GenesisState) Validate() error {
 			return err
 		}
 	}
+
+	if uint64(len(g.PinnedRecords)) > g.Params.MaxPinnedRecords {
+		return MaxPinnedRecordsError{MaxPinnedRecords: g.Params.MaxPinnedRecords}
+	}
+	for _, twap := range g.PinnedRecords {
+		if err := twap.validate(); err != nil {
+			return err
+		}
+	}
+
+	routeDenoms := make(map[string]bool, len(g.CanonicalRoutes))
+	for _, route := range g.CanonicalRoutes {
+		if err := route.Validate(); err != nil {
+			return err
+		}
+		if routeDenoms[route.Denom] {
+			return fmt.Errorf("denom %s has more than one canonical twap route", route.Denom)
+		}
+		routeDenoms[route.Denom] = true
+	}
 	return nil
 }
 
@@ -54,6 +74,10 @@ func (t TwapRecord) validate() error {
 		return errors.New("twap record time cannot be 0")
 	}
 
+	if t.LastErrorTime.After(t.Time) {
+		return fmt.Errorf("twap record last error time (%s) cannot be after record time (%s)", t.LastErrorTime, t.Time)
+	}
+
 	// if there was an error in this record, the spot prices should be 0.
 	// else, the the spot prices must be positive.
 	if t.LastErrorTime.Equal(t.Time) {
@@ -7,6 +7,7 @@ import (
 	fmt "fmt"
 	_ "github.com/cosmos/cosmos-proto"
 	_ "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
@@ -33,6 +34,40 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Params struct {
 	PruneEpochIdentifier    string        `protobuf:"bytes,1,opt,name=prune_epoch_identifier,json=pruneEpochIdentifier,proto3" json:"prune_epoch_identifier,omitempty"`
 	RecordHistoryKeepPeriod time.Duration `protobuf:"bytes,2,opt,name=record_history_keep_period,json=recordHistoryKeepPeriod,proto3,stdduration" json:"record_history_keep_period" yaml:"record_history_keep_period"`
+	// pin_authority is the address allowed to pin and unpin records. Pinning is
+	// disabled if it is empty.
+	PinAuthority string `protobuf:"bytes,3,opt,name=pin_authority,json=pinAuthority,proto3" json:"pin_authority,omitempty" yaml:"pin_authority"`
+	// max_pinned_records is the maximum number of records that can be pinned at
+	// the same time.
+	MaxPinnedRecords uint64 `protobuf:"varint,4,opt,name=max_pinned_records,json=maxPinnedRecords,proto3" json:"max_pinned_records,omitempty" yaml:"max_pinned_records"`
+	// end_block_gas_budget is the soft gas budget of updating the records of the
+	// changed pools at the end of a block. Once it is exceeded, the remaining
+	// pools are deferred to the next block. Zero means no budget.
+	EndBlockGasBudget uint64 `protobuf:"varint,5,opt,name=end_block_gas_budget,json=endBlockGasBudget,proto3" json:"end_block_gas_budget,omitempty" yaml:"end_block_gas_budget"`
+	// spot_deviation_alert_threshold is the deviation of the spot price of a pool
+	// from its TWAP over spot_deviation_alert_window above which its EndBlock
+	// update emits an alert event. Zero disables the alerts.
+	SpotDeviationAlertThreshold github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,6,opt,name=spot_deviation_alert_threshold,json=spotDeviationAlertThreshold,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"spot_deviation_alert_threshold" yaml:"spot_deviation_alert_threshold"`
+	// spot_deviation_alert_window is the window of the TWAP the spot prices are
+	// compared to for the alerts.
+	SpotDeviationAlertWindow time.Duration `protobuf:"bytes,7,opt,name=spot_deviation_alert_window,json=spotDeviationAlertWindow,proto3,stdduration" json:"spot_deviation_alert_window" yaml:"spot_deviation_alert_window"`
+	// median_tracked_pools are the pools whose spot prices are sampled at the end
+	// of every block, for the MedianSpotPrice query. The samples are kept for
+	// record_history_keep_period.
+	MedianTrackedPools []uint64 `protobuf:"varint,8,rep,name=median_tracked_pools,json=medianTrackedPools,proto3" json:"median_tracked_pools,omitempty" yaml:"median_tracked_pools"`
+	// canonical_route_authority is the address allowed to set and remove the
+	// canonical routes. Setting routes is disabled if it is empty.
+	CanonicalRouteAuthority string `protobuf:"bytes,9,opt,name=canonical_route_authority,json=canonicalRouteAuthority,proto3" json:"canonical_route_authority,omitempty" yaml:"canonical_route_authority"`
+	// canonical_quote_denom is the stable denom the canonical routes end in, and
+	// the CanonicalTwap query quotes TWAPs in.
+	CanonicalQuoteDenom string `protobuf:"bytes,10,opt,name=canonical_quote_denom,json=canonicalQuoteDenom,proto3" json:"canonical_quote_denom,omitempty" yaml:"canonical_quote_denom"`
+	// max_pruned_records_per_block is the maximum number of records pruned in a
+	// block. The pruning resumes in the next block once it is reached. Zero
+	// prunes all the records at once.
+	MaxPrunedRecordsPerBlock uint64 `protobuf:"varint,11,opt,name=max_pruned_records_per_block,json=maxPrunedRecordsPerBlock,proto3" json:"max_pruned_records_per_block,omitempty" yaml:"max_pruned_records_per_block"`
+	// params_authority is the address allowed to update the params with
+	// MsgUpdateParams. Updating the params that way is disabled if it is empty.
+	ParamsAuthority string `protobuf:"bytes,12,opt,name=params_authority,json=paramsAuthority,proto3" json:"params_authority,omitempty" yaml:"params_authority"`
 }
 
 func (m *Params) Reset()         { *m = Params{} }
@@ -82,12 +117,79 @@ func (m *Params) GetRecordHistoryKeepPeriod() time.Duration {
 	return 0
 }
 
+func (m *Params) GetPinAuthority() string {
+	if m != nil {
+		return m.PinAuthority
+	}
+	return ""
+}
+
+func (m *Params) GetMaxPinnedRecords() uint64 {
+	if m != nil {
+		return m.MaxPinnedRecords
+	}
+	return 0
+}
+
+func (m *Params) GetEndBlockGasBudget() uint64 {
+	if m != nil {
+		return m.EndBlockGasBudget
+	}
+	return 0
+}
+
+func (m *Params) GetSpotDeviationAlertWindow() time.Duration {
+	if m != nil {
+		return m.SpotDeviationAlertWindow
+	}
+	return 0
+}
+
+func (m *Params) GetMedianTrackedPools() []uint64 {
+	if m != nil {
+		return m.MedianTrackedPools
+	}
+	return nil
+}
+
+func (m *Params) GetCanonicalRouteAuthority() string {
+	if m != nil {
+		return m.CanonicalRouteAuthority
+	}
+	return ""
+}
+
+func (m *Params) GetCanonicalQuoteDenom() string {
+	if m != nil {
+		return m.CanonicalQuoteDenom
+	}
+	return ""
+}
+
+func (m *Params) GetMaxPrunedRecordsPerBlock() uint64 {
+	if m != nil {
+		return m.MaxPrunedRecordsPerBlock
+	}
+	return 0
+}
+
+func (m *Params) GetParamsAuthority() string {
+	if m != nil {
+		return m.ParamsAuthority
+	}
+	return ""
+}
+
 // GenesisState defines the twap module's genesis state.
 type GenesisState struct {
 	// twaps is the collection of all twap records.
 	Twaps []TwapRecord `protobuf:"bytes,1,rep,name=twaps,proto3" json:"twaps"`
 	// params is the container of twap parameters.
 	Params Params `protobuf:"bytes,2,opt,name=params,proto3" json:"params"`
+	// pinned_records are the records that are exempt from pruning.
+	PinnedRecords []TwapRecord `protobuf:"bytes,3,rep,name=pinned_records,json=pinnedRecords,proto3" json:"pinned_records"`
+	// canonical_routes are the canonical routes of the CanonicalTwap query.
+	CanonicalRoutes []CanonicalTwapRoute `protobuf:"bytes,4,rep,name=canonical_routes,json=canonicalRoutes,proto3" json:"canonical_routes"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -137,6 +239,20 @@ func (m *GenesisState) GetParams() Params {
 	return Params{}
 }
 
+func (m *GenesisState) GetPinnedRecords() []TwapRecord {
+	if m != nil {
+		return m.PinnedRecords
+	}
+	return nil
+}
+
+func (m *GenesisState) GetCanonicalRoutes() []CanonicalTwapRoute {
+	if m != nil {
+		return m.CanonicalRoutes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Params)(nil), "osmosis.twap.v1beta1.Params")
 	proto.RegisterType((*GenesisState)(nil), "osmosis.twap.v1beta1.GenesisState")
@@ -147,32 +263,58 @@ func init() {
 }
 
 var fileDescriptor_3f4bdf49b69bd63c = []byte{
-	// 397 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xbf, 0xce, 0xd3, 0x30,
-	0x14, 0xc5, 0x63, 0xfe, 0x54, 0x22, 0x1f, 0x53, 0x54, 0x41, 0xbf, 0x0a, 0xa5, 0x21, 0x03, 0xea,
-	0x52, 0x9b, 0xb6, 0x4c, 0x15, 0x53, 0x05, 0xe2, 0xdf, 0x52, 0x05, 0x26, 0x96, 0xc8, 0x49, 0x6e,
-	0x53, 0x8b, 0x26, 0xb6, 0x6c, 0xa7, 0x25, 0x0f, 0x80, 0xc4, 0xc8, 0xc8, 0x13, 0xa1, 0x8e, 0x1d,
-	0x99, 0x0a, 0x6a, 0xdf, 0x80, 0x27, 0x40, 0x89, 0x5d, 0x06, 0xd4, 0x6f, 0xcb, 0xd5, 0xef, 0x9c,
-	0xa3, 0x93, 0x7b, 0xed, 0x86, 0x5c, 0x15, 0x5c, 0x31, 0x45, 0xf4, 0x96, 0x0a, 0xb2, 0x19, 0x27,
-	0xa0, 0xe9, 0x98, 0xe4, 0x50, 0x82, 0x62, 0x0a, 0x0b, 0xc9, 0x35, 0xf7, 0xba, 0x56, 0x83, 0x1b,
-	0x0d, 0xb6, 0x9a, 0x7e, 0x37, 0xe7, 0x39, 0x6f, 0x05, 0xa4, 0xf9, 0x32, 0xda, 0xfe, 0x93, 0x8b,
-	0x79, 0xcd, 0x10, 0x4b, 0x48, 0xb9, 0xcc, 0xac, 0xee, 0x3a, 0xe7, 0x3c, 0x5f, 0x03, 0x69, 0xa7,
-	0xa4, 0x5a, 0x12, 0x5a, 0xd6, 0x67, 0x94, 0xb6, 0x19, 0xb1, 0xc9, 0x36, 0x83, 0x45, 0xfe, 0xff,
-	0xae, 0xac, 0x92, 0x54, 0x33, 0x5e, 0x1a, 0x1e, 0xfe, 0x40, 0x6e, 0x67, 0x41, 0x25, 0x2d, 0x94,
-	0xf7, 0xcc, 0x7d, 0x20, 0x64, 0x55, 0x42, 0x0c, 0x82, 0xa7, 0xab, 0x98, 0x65, 0x50, 0x6a, 0xb6,
-	0x64, 0x20, 0x7b, 0x28, 0x40, 0xc3, 0x7b, 0x51, 0xb7, 0xa5, 0x2f, 0x1b, 0xf8, 0xe6, 0x1f, 0xf3,
-	0xbe, 0x20, 0xb7, 0x6f, 0x7a, 0xc6, 0x2b, 0xa6, 0x34, 0x97, 0x75, 0xfc, 0x09, 0x40, 0xc4, 0x02,
-	0x24, 0xe3, 0x59, 0xef, 0x56, 0x80, 0x86, 0x57, 0x93, 0x6b, 0x6c, 0x6a, 0xe0, 0x73, 0x0d, 0xfc,
-	0xc2, 0xd6, 0x98, 0x8f, 0x76, 0x87, 0x81, 0xf3, 0xe7, 0x30, 0x78, 0x5c, 0xd3, 0x62, 0x3d, 0x0b,
-	0x6f, 0x8e, 0x0a, 0xbf, 0xff, 0x1a, 0xa0, 0xe8, 0xa1, 0x11, 0xbc, 0x36, 0xfc, 0x1d, 0x80, 0x58,
-	0x18, 0xfa, 0x15, 0xb9, 0xf7, 0x5f, 0x99, 0x23, 0xbc, 0xd7, 0x54, 0x83, 0xf7, 0xdc, 0xbd, 0xdb,
-	0x2c, 0x51, 0xf5, 0x50, 0x70, 0x7b, 0x78, 0x35, 0x09, 0xf0, 0xa5, 0x9b, 0xe0, 0x0f, 0x5b, 0x2a,
-	0xa2, 0x36, 0x72, 0x7e, 0xa7, 0x69, 0x12, 0x19, 0x93, 0x37, 0x73, 0x3b, 0xa2, 0x5d, 0x8b, 0xfd,
-	0x83, 0x47, 0x97, 0xed, 0x66, 0x75, 0xd6, 0x6a, 0x1d, 0xf3, 0xb7, 0xbb, 0xa3, 0x8f, 0xf6, 0x47,
-	0x1f, 0xfd, 0x3e, 0xfa, 0xe8, 0xdb, 0xc9, 0x77, 0xf6, 0x27, 0xdf, 0xf9, 0x79, 0xf2, 0x9d, 0x8f,
-	0x4f, 0x73, 0xa6, 0x57, 0x55, 0x82, 0x53, 0x5e, 0x10, 0x9b, 0x37, 0x5a, 0xd3, 0x44, 0x9d, 0x07,
-	0xb2, 0x19, 0x4f, 0xc9, 0x67, 0xf3, 0x12, 0x74, 0x2d, 0x40, 0x25, 0x9d, 0x76, 0x63, 0xd3, 0xbf,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0xf4, 0xba, 0xd9, 0x22, 0x76, 0x02, 0x00, 0x00,
+	// 815 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x95, 0x55, 0x4d, 0x53, 0xd3, 0x40,
+	0x18, 0xa6, 0x14, 0xaa, 0x2c, 0x20, 0xb8, 0x56, 0x49, 0x29, 0xb6, 0x35, 0x2a, 0x72, 0x21, 0x11,
+	0xf1, 0xc4, 0xe8, 0x81, 0x5a, 0xc5, 0x8f, 0x71, 0xa6, 0xc4, 0xce, 0x30, 0x7a, 0x59, 0xb7, 0xc9,
+	0x92, 0x66, 0xda, 0x66, 0x63, 0xb2, 0x01, 0xfa, 0x03, 0x9c, 0x71, 0x3c, 0x79, 0xf4, 0xe0, 0xaf,
+	0xf0, 0x57, 0x70, 0xe4, 0xe8, 0x78, 0x40, 0x47, 0xff, 0x81, 0xbf, 0xc0, 0xfd, 0x48, 0x4b, 0x5b,
+	0x0a, 0x8e, 0x87, 0x9d, 0x76, 0x9f, 0xe7, 0x79, 0x9f, 0xbc, 0xdd, 0x3e, 0xfb, 0x06, 0xe8, 0x34,
+	0x6a, 0xd3, 0xc8, 0x8b, 0x4c, 0xb6, 0x8f, 0x03, 0x73, 0x6f, 0xad, 0x4e, 0x18, 0x5e, 0x33, 0x5d,
+	0xe2, 0x13, 0x0e, 0x1a, 0x41, 0x48, 0x19, 0x85, 0xd9, 0x44, 0x63, 0x08, 0x8d, 0x91, 0x68, 0x16,
+	0xb3, 0x2e, 0x75, 0xa9, 0x14, 0x98, 0xe2, 0x9b, 0xd2, 0x2e, 0x2e, 0x8f, 0xf4, 0x13, 0x1b, 0x14,
+	0x12, 0x9b, 0x86, 0x4e, 0xa2, 0xcb, 0xb9, 0x94, 0xba, 0x2d, 0x62, 0xca, 0x5d, 0x3d, 0xde, 0x35,
+	0xb1, 0xdf, 0xe9, 0x52, 0xb6, 0xf4, 0x40, 0xca, 0x5b, 0x6d, 0x12, 0xaa, 0x30, 0x5c, 0xe5, 0xc4,
+	0x21, 0x66, 0x1e, 0xf5, 0x15, 0xaf, 0x7f, 0x9c, 0x02, 0x99, 0x2a, 0x0e, 0x71, 0x3b, 0x82, 0xf7,
+	0xc1, 0xb5, 0x20, 0x8c, 0x7d, 0x82, 0x48, 0x40, 0xed, 0x06, 0xf2, 0x1c, 0xe2, 0x33, 0x6f, 0xd7,
+	0x23, 0xa1, 0x96, 0x2a, 0xa5, 0x56, 0xa6, 0xac, 0xac, 0x64, 0x1f, 0x0b, 0xf2, 0x59, 0x8f, 0x83,
+	0xef, 0x53, 0x60, 0x51, 0xf5, 0x89, 0x1a, 0x5e, 0xc4, 0x68, 0xd8, 0x41, 0x4d, 0x42, 0x02, 0x14,
+	0x90, 0xd0, 0xa3, 0x8e, 0x36, 0xce, 0x4b, 0xa7, 0xef, 0xe5, 0x0c, 0xd5, 0x86, 0xd1, 0x6d, 0xc3,
+	0xa8, 0x24, 0x6d, 0x94, 0x57, 0x0f, 0x8f, 0x8b, 0x63, 0x7f, 0x8e, 0x8b, 0x37, 0x3a, 0xb8, 0xdd,
+	0xda, 0xd0, 0xcf, 0xb6, 0xd2, 0x3f, 0xff, 0x28, 0xa6, 0xac, 0x05, 0x25, 0x78, 0xaa, 0xf8, 0x17,
+	0x9c, 0xae, 0x4a, 0x16, 0x3e, 0x04, 0xb3, 0x81, 0xe7, 0x23, 0x1c, 0xb3, 0x06, 0x0d, 0x3d, 0xd6,
+	0xd1, 0xd2, 0xa2, 0xe9, 0xb2, 0xc6, 0xad, 0xb3, 0xca, 0x7a, 0x80, 0xd6, 0xad, 0x19, 0xbe, 0xdf,
+	0xec, 0x6e, 0xe1, 0x0b, 0x00, 0xdb, 0xf8, 0x00, 0x71, 0xcc, 0x27, 0x4e, 0x72, 0xf0, 0x91, 0x36,
+	0xc1, 0x3d, 0x26, 0xca, 0xd7, 0xb9, 0x47, 0x4e, 0x79, 0x9c, 0xd6, 0xe8, 0xd6, 0x3c, 0x07, 0xab,
+	0x12, 0xb3, 0x14, 0x04, 0xab, 0x20, 0x4b, 0x7c, 0x07, 0xd5, 0x5b, 0xd4, 0x6e, 0x22, 0x17, 0x47,
+	0xa8, 0x1e, 0x3b, 0x2e, 0x61, 0xda, 0xa4, 0xb4, 0x2b, 0x72, 0xbb, 0xbc, 0xb2, 0x1b, 0xa5, 0xd2,
+	0xad, 0xcb, 0x1c, 0x2e, 0x0b, 0x74, 0x0b, 0x47, 0x65, 0x89, 0xc1, 0x2f, 0x29, 0x50, 0x88, 0x02,
+	0xca, 0x90, 0x43, 0xf6, 0x3c, 0x79, 0x70, 0x08, 0xb7, 0x48, 0xc8, 0x10, 0x6b, 0x84, 0x24, 0x6a,
+	0xd0, 0x96, 0xa3, 0x65, 0xe4, 0xef, 0xdd, 0x11, 0xc7, 0xf9, 0xfd, 0xb8, 0xb8, 0xec, 0x7a, 0xac,
+	0x11, 0xd7, 0x0d, 0x9b, 0xb6, 0x93, 0x40, 0x24, 0x1f, 0xab, 0x91, 0xd3, 0x34, 0x59, 0x27, 0x20,
+	0x91, 0x51, 0x21, 0x36, 0x6f, 0xe5, 0xb6, 0x6a, 0xe5, 0x7c, 0x77, 0xdd, 0xca, 0x0b, 0x41, 0xa5,
+	0xcb, 0x6f, 0x0a, 0xba, 0xd6, 0x65, 0xe1, 0x87, 0x14, 0xc8, 0x8f, 0x34, 0xd8, 0xf7, 0x7c, 0x87,
+	0xee, 0x6b, 0x17, 0xfe, 0x95, 0x02, 0x23, 0x49, 0x81, 0x7e, 0x4e, 0x33, 0xca, 0x4b, 0xc5, 0x40,
+	0x3b, 0xdd, 0xcd, 0x8e, 0xa4, 0xe1, 0x36, 0xc8, 0xb6, 0x89, 0xe3, 0x61, 0x1f, 0xb1, 0x10, 0xdb,
+	0x4d, 0xfe, 0x47, 0x05, 0x94, 0xb6, 0x22, 0xed, 0x62, 0x29, 0x3d, 0x78, 0xf6, 0xa3, 0x54, 0xba,
+	0x05, 0x15, 0x5c, 0x53, 0x68, 0x55, 0x80, 0xf0, 0x2d, 0xc8, 0xd9, 0xd8, 0xa7, 0xbe, 0x67, 0xe3,
+	0x16, 0x0a, 0x69, 0xcc, 0x48, 0x5f, 0xcc, 0xa6, 0xe4, 0xb1, 0xdf, 0xe2, 0xbe, 0x25, 0xe5, 0x7b,
+	0xa6, 0x54, 0xb7, 0x16, 0x7a, 0x9c, 0x25, 0xa8, 0x93, 0xf4, 0xd5, 0xc0, 0xd5, 0x93, 0xb2, 0x77,
+	0x31, 0xe5, 0x65, 0xfc, 0x86, 0xd1, 0xb6, 0x06, 0xa4, 0x7b, 0x89, 0xbb, 0x2f, 0x0d, 0xbb, 0xf7,
+	0xc9, 0x74, 0xeb, 0x4a, 0x0f, 0xdf, 0x16, 0x70, 0x45, 0xa0, 0xd0, 0x05, 0x4b, 0x32, 0xaf, 0xe2,
+	0xda, 0xf6, 0xf2, 0x2a, 0x6e, 0x93, 0xca, 0x9c, 0x36, 0x2d, 0xe3, 0x78, 0x87, 0x9b, 0xdf, 0xec,
+	0x4b, 0xf7, 0x19, 0x6a, 0xdd, 0xd2, 0x44, 0xce, 0x25, 0x9b, 0xe4, 0x9c, 0xdf, 0x3c, 0x19, 0x53,
+	0xf8, 0x04, 0xcc, 0x07, 0x72, 0x86, 0xf4, 0x9d, 0xcb, 0x8c, 0xec, 0x3c, 0xcf, 0xcd, 0x17, 0x92,
+	0xeb, 0x37, 0xa4, 0xd0, 0xad, 0x39, 0x05, 0xf5, 0x8e, 0x41, 0xff, 0x3a, 0x0e, 0x66, 0xb6, 0xd4,
+	0x20, 0x7d, 0xc5, 0x30, 0x23, 0xf0, 0x01, 0x98, 0x14, 0x83, 0x30, 0xe2, 0x13, 0x28, 0xcd, 0x03,
+	0x54, 0x32, 0x46, 0xcd, 0x55, 0xa3, 0xc6, 0x37, 0xaa, 0xa5, 0xf2, 0x84, 0xc8, 0x91, 0xa5, 0x8a,
+	0xe0, 0x06, 0xc8, 0xa8, 0x27, 0x24, 0x53, 0x68, 0x69, 0x74, 0xb9, 0x1a, 0x7f, 0x49, 0x69, 0x52,
+	0x01, 0x5f, 0x82, 0x4b, 0x43, 0xb3, 0x20, 0xfd, 0x5f, 0x2d, 0xcc, 0x06, 0x03, 0x13, 0xe1, 0x35,
+	0x98, 0x1f, 0xca, 0x85, 0x18, 0x2e, 0xc2, 0x70, 0x65, 0xb4, 0xe1, 0xa3, 0xae, 0x5a, 0x3a, 0x8b,
+	0x82, 0xc4, 0x78, 0x6e, 0x30, 0x43, 0x51, 0xf9, 0xf9, 0xe1, 0xaf, 0x42, 0xea, 0x88, 0xaf, 0x9f,
+	0x7c, 0x7d, 0xfa, 0x5d, 0x18, 0x3b, 0xe2, 0xeb, 0x1b, 0x5f, 0x6f, 0xee, 0xf6, 0xcd, 0x80, 0xe4,
+	0x21, 0xab, 0x2d, 0x5c, 0x8f, 0xba, 0x1b, 0xfe, 0xb2, 0x59, 0x37, 0x0f, 0xd4, 0x7b, 0x47, 0x4e,
+	0x84, 0x7a, 0x46, 0xde, 0xcc, 0xf5, 0xbf, 0xed, 0xf9, 0x2e, 0xf9, 0xe4, 0x06, 0x00, 0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -195,12 +337,92 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RecordHistoryKeepPeriod, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.RecordHistoryKeepPeriod):])
-	if err1 != nil {
-		return 0, err1
+	if len(m.ParamsAuthority) > 0 {
+		i -= len(m.ParamsAuthority)
+		copy(dAtA[i:], m.ParamsAuthority)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ParamsAuthority)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.MaxPrunedRecordsPerBlock != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.MaxPrunedRecordsPerBlock))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.CanonicalQuoteDenom) > 0 {
+		i -= len(m.CanonicalQuoteDenom)
+		copy(dAtA[i:], m.CanonicalQuoteDenom)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.CanonicalQuoteDenom)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.CanonicalRouteAuthority) > 0 {
+		i -= len(m.CanonicalRouteAuthority)
+		copy(dAtA[i:], m.CanonicalRouteAuthority)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.CanonicalRouteAuthority)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	i -= n1
-	i = encodeVarintGenesis(dAtA, i, uint64(n1))
+	if len(m.MedianTrackedPools) > 0 {
+		dAtA18 := make([]byte, len(m.MedianTrackedPools)*10)
+		var j17 int
+		for _, num1 := range m.MedianTrackedPools {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j17++
+			}
+			dAtA18[j17] = uint8(num)
+			j17++
+		}
+		i -= j17
+		copy(dAtA[i:], dAtA18[:j17])
+		i = encodeVarintGenesis(dAtA, i, uint64(j17))
+		i--
+		dAtA[i] = 0x42
+	}
+	n19, err19 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.SpotDeviationAlertWindow, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.SpotDeviationAlertWindow):])
+	if err19 != nil {
+		return 0, err19
+	}
+	i -= n19
+	i = encodeVarintGenesis(dAtA, i, uint64(n19))
+	i--
+	dAtA[i] = 0x3a
+	{
+		size := m.SpotDeviationAlertThreshold.Size()
+		i -= size
+		if _, err := m.SpotDeviationAlertThreshold.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if m.EndBlockGasBudget != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.EndBlockGasBudget))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MaxPinnedRecords != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.MaxPinnedRecords))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.PinAuthority) > 0 {
+		i -= len(m.PinAuthority)
+		copy(dAtA[i:], m.PinAuthority)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.PinAuthority)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	n20, err20 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RecordHistoryKeepPeriod, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.RecordHistoryKeepPeriod):])
+	if err20 != nil {
+		return 0, err20
+	}
+	i -= n20
+	i = encodeVarintGenesis(dAtA, i, uint64(n20))
 	i--
 	dAtA[i] = 0x12
 	if len(m.PruneEpochIdentifier) > 0 {
@@ -233,6 +455,34 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CanonicalRoutes) > 0 {
+		for iNdEx := len(m.CanonicalRoutes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CanonicalRoutes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.PinnedRecords) > 0 {
+		for iNdEx := len(m.PinnedRecords) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PinnedRecords[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	{
 		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -283,6 +533,42 @@ func (m *Params) Size() (n int) {
 	}
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RecordHistoryKeepPeriod)
 	n += 1 + l + sovGenesis(uint64(l))
+	l = len(m.PinAuthority)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.MaxPinnedRecords != 0 {
+		n += 1 + sovGenesis(uint64(m.MaxPinnedRecords))
+	}
+	if m.EndBlockGasBudget != 0 {
+		n += 1 + sovGenesis(uint64(m.EndBlockGasBudget))
+	}
+	l = m.SpotDeviationAlertThreshold.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.SpotDeviationAlertWindow)
+	n += 1 + l + sovGenesis(uint64(l))
+	if len(m.MedianTrackedPools) > 0 {
+		l = 0
+		for _, e := range m.MedianTrackedPools {
+			l += sovGenesis(uint64(e))
+		}
+		n += 1 + sovGenesis(uint64(l)) + l
+	}
+	l = len(m.CanonicalRouteAuthority)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.CanonicalQuoteDenom)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.MaxPrunedRecordsPerBlock != 0 {
+		n += 1 + sovGenesis(uint64(m.MaxPrunedRecordsPerBlock))
+	}
+	l = len(m.ParamsAuthority)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
 	return n
 }
 
@@ -300,6 +586,18 @@ func (m *GenesisState) Size() (n int) {
 	}
 	l = m.Params.Size()
 	n += 1 + l + sovGenesis(uint64(l))
+	if len(m.PinnedRecords) > 0 {
+		for _, e := range m.PinnedRecords {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.CanonicalRoutes) > 0 {
+		for _, e := range m.CanonicalRoutes {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -403,6 +701,334 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PinAuthority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PinAuthority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPinnedRecords", wireType)
+			}
+			m.MaxPinnedRecords = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPinnedRecords |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockGasBudget", wireType)
+			}
+			m.EndBlockGasBudget = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndBlockGasBudget |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpotDeviationAlertThreshold", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SpotDeviationAlertThreshold.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpotDeviationAlertWindow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.SpotDeviationAlertWindow, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.MedianTrackedPools = append(m.MedianTrackedPools, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.MedianTrackedPools) == 0 {
+					m.MedianTrackedPools = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.MedianTrackedPools = append(m.MedianTrackedPools, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field MedianTrackedPools", wireType)
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CanonicalRouteAuthority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CanonicalRouteAuthority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CanonicalQuoteDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CanonicalQuoteDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPrunedRecordsPerBlock", wireType)
+			}
+			m.MaxPrunedRecordsPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPrunedRecordsPerBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParamsAuthority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParamsAuthority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -424,6 +1050,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func (m *GenesisState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -520,6 +1147,74 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PinnedRecords", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PinnedRecords = append(m.PinnedRecords, TwapRecord{})
+			if err := m.PinnedRecords[len(m.PinnedRecords)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CanonicalRoutes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CanonicalRoutes = append(m.CanonicalRoutes, CanonicalTwapRoute{})
+			if err := m.CanonicalRoutes[len(m.CanonicalRoutes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -541,6 +1236,7 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func skipGenesis(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
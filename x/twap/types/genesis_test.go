@@ -109,6 +109,70 @@ func TestGenesisState_Validate(t *testing.T) {
 
 			expectedErr: true,
 		},
+		"valid pinned records": {
+			twapGenesis: &GenesisState{
+				Params:        basicParams,
+				Twaps:         []TwapRecord{baseRecord},
+				PinnedRecords: []TwapRecord{baseRecord},
+			},
+		},
+		"invalid pinned records - more than max pinned records": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.MaxPinnedRecords = 0
+				return &GenesisState{
+					Params:        params,
+					Twaps:         []TwapRecord{baseRecord},
+					PinnedRecords: []TwapRecord{baseRecord},
+				}
+			}(),
+
+			expectedErr: true,
+		},
+		"invalid pinned records - invalid record": {
+			twapGenesis: func() *GenesisState {
+				pinnedRecord := baseRecord
+				pinnedRecord.PoolId = 0
+				return &GenesisState{
+					Params:        basicParams,
+					Twaps:         []TwapRecord{baseRecord},
+					PinnedRecords: []TwapRecord{pinnedRecord},
+				}
+			}(),
+
+			expectedErr: true,
+		},
+		"valid canonical routes": {
+			twapGenesis: &GenesisState{
+				Params: basicParams,
+				Twaps:  []TwapRecord{baseRecord},
+				CanonicalRoutes: []CanonicalTwapRoute{
+					{Denom: "test1", Hops: []CanonicalTwapRouteHop{{PoolId: 1, QuoteAsset: "test2"}}},
+					{Denom: "test3", Hops: []CanonicalTwapRouteHop{{PoolId: 2, QuoteAsset: "test1"}, {PoolId: 1, QuoteAsset: "test2"}}},
+				},
+			},
+		},
+		"invalid canonical routes - two routes of a denom": {
+			twapGenesis: &GenesisState{
+				Params: basicParams,
+				Twaps:  []TwapRecord{baseRecord},
+				CanonicalRoutes: []CanonicalTwapRoute{
+					{Denom: "test1", Hops: []CanonicalTwapRouteHop{{PoolId: 1, QuoteAsset: "test2"}}},
+					{Denom: "test1", Hops: []CanonicalTwapRouteHop{{PoolId: 2, QuoteAsset: "test2"}}},
+				},
+			},
+
+			expectedErr: true,
+		},
+		"invalid canonical routes - invalid route": {
+			twapGenesis: &GenesisState{
+				Params:          basicParams,
+				Twaps:           []TwapRecord{baseRecord},
+				CanonicalRoutes: []CanonicalTwapRoute{{Denom: "test1"}},
+			},
+
+			expectedErr: true,
+		},
 		"invalid pruneEpochIdentifier - error": {
 			twapGenesis: NewGenesisState(
 				NewParams("", 48*time.Hour), // invalid empty string
@@ -125,6 +189,100 @@ func TestGenesisState_Validate(t *testing.T) {
 					baseRecord,
 				}),
 
+			expectedErr: true,
+		},
+		"recordHistoryKeepPeriod at the max": {
+			twapGenesis: NewGenesisState(
+				NewParams("week", MaxRecordHistoryKeepPeriod),
+				[]TwapRecord{
+					baseRecord,
+				}),
+		},
+		"recordHistoryKeepPeriod over the max - error": {
+			twapGenesis: NewGenesisState(
+				NewParams("week", MaxRecordHistoryKeepPeriod+time.Nanosecond),
+				[]TwapRecord{
+					baseRecord,
+				}),
+
+			expectedErr: true,
+		},
+		"invalid params authority - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.ParamsAuthority = "authority"
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"valid spot deviation alert params": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.SpotDeviationAlertThreshold = sdk.NewDecWithPrec(5, 1)
+				params.SpotDeviationAlertWindow = 48 * time.Hour
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+		},
+		"negative spot deviation alert threshold - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.SpotDeviationAlertThreshold = sdk.NewDecWithPrec(-5, 1)
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"nil spot deviation alert threshold - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.SpotDeviationAlertThreshold = sdk.Dec{}
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"zero spot deviation alert window - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.SpotDeviationAlertWindow = 0
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"spot deviation alert window longer than the keep period - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.SpotDeviationAlertWindow = 48*time.Hour + time.Second
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"valid median tracked pools": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.MedianTrackedPools = []uint64{1, 3, 2}
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+		},
+		"zero median tracked pool - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.MedianTrackedPools = []uint64{1, 0}
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
+			expectedErr: true,
+		},
+		"duplicate median tracked pool - error": {
+			twapGenesis: func() *GenesisState {
+				params := basicParams
+				params.MedianTrackedPools = []uint64{1, 2, 1}
+				return NewGenesisState(params, []TwapRecord{baseRecord})
+			}(),
+
 			expectedErr: true,
 		},
 	}
@@ -221,6 +379,22 @@ func TestTWAPRecord_Validate(t *testing.T) {
 
 			expectedErr: true,
 		},
+		"valid last error time before record time": {
+			twapRecord: func() TwapRecord {
+				r := baseRecord
+				r.LastErrorTime = baseTime
+				return r
+			}(),
+		},
+		"invalid last error time after record time": {
+			twapRecord: func() TwapRecord {
+				r := baseRecord
+				r.LastErrorTime = r.Time.Add(time.Second)
+				return r
+			}(),
+
+			expectedErr: true,
+		},
 		"invalid p0 last spot price: nil": {
 			twapRecord: func() TwapRecord {
 				r := TwapRecord{
@@ -0,0 +1,27 @@
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// TwapHooks are notified of the updates of the twap records of pools, for modules that react to a pool's TWAP
+// changing rather than polling every block.
+type TwapHooks interface {
+	// AfterTwapRecordUpdate is called in EndBlock for every denom pair of a pool that changed in the block, after
+	// the pool's new records are stored. record is the new record of the pair.
+	AfterTwapRecordUpdate(ctx sdk.Context, poolId uint64, denom0, denom1 string, record TwapRecord)
+}
+
+var _ TwapHooks = MultiTwapHooks{}
+
+// combine multiple twap hooks, all hook functions are run in array sequence.
+type MultiTwapHooks []TwapHooks
+
+// Creates hooks for the Twap Module.
+func NewMultiTwapHooks(hooks ...TwapHooks) MultiTwapHooks {
+	return hooks
+}
+
+func (h MultiTwapHooks) AfterTwapRecordUpdate(ctx sdk.Context, poolId uint64, denom0, denom1 string, record TwapRecord) {
+	for i := range h {
+		h[i].AfterTwapRecordUpdate(ctx, poolId, denom0, denom1, record)
+	}
+}
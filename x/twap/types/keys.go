@@ -1,8 +1,9 @@
 package types
 
 import (
-	"errors"
 	fmt "fmt"
+	"strconv"
+	"strings"
 	time "time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,15 +19,28 @@ const (
 	TransientStoreKey = "transient_" + ModuleName // this is silly we have to do this
 	RouterKey         = ModuleName
 
+	// ArchiveTransientStoreKey is the key of the transient store holding the records written in the block, for the
+	// node to copy them into its archive at the end of the block, see Keeper.SetArchive. It must not start with
+	// TransientStoreKey, as the SDK rejects store keys prefixing one another.
+	ArchiveTransientStoreKey = "archive_" + ModuleName
+
 	QuerierRoute = ModuleName
 	// Contract: Coin denoms cannot contain this character
 	KeySeparator = "|"
 )
 
 var (
-	mostRecentTWAPsNoSeparator         = "recent_twap"
-	historicalTWAPTimeIndexNoSeparator = "historical_time_index"
-	historicalTWAPPoolIndexNoSeparator = "historical_pool_index"
+	mostRecentTWAPsNoSeparator          = "recent_twap"
+	historicalTWAPTimeIndexNoSeparator  = "historical_time_index"
+	historicalTWAPPoolIndexNoSeparator  = "historical_pool_index"
+	pinnedTWAPNoSeparator               = "pinned_twap"
+	quarantinedPoolNoSeparator          = "quarantined_pool"
+	pairPoolIndexNoSeparator            = "pair_pool_index"
+	trackingGapNoSeparator              = "tracking_gap"
+	deferredPoolNoSeparator             = "deferred_pool"
+	spotPriceSampleTimeIndexNoSeparator = "spot_price_sample_time_index"
+	spotPriceSamplePoolIndexNoSeparator = "spot_price_sample_pool_index"
+	canonicalTwapRouteNoSeparator       = "canonical_twap_route"
 
 	// We do key management to let us easily meet the goals of (AKA minimal iteration):
 	// * Get most recent twap for a (pool id, asset 1, asset 2) with no iteration
@@ -42,10 +56,41 @@ var (
 	// format is pool id | denom1 | denom2 | time
 	// made for efficiently getting records given (pool id, denom1, denom2) and time bounds
 	HistoricalTWAPPoolIndexPrefix = historicalTWAPPoolIndexNoSeparator + KeySeparator
+	// format is pool id | denom1 | denom2 | time
+	// marks the historical record with the same key suffix as exempt from pruning
+	PinnedTWAPPrefix = pinnedTWAPNoSeparator + KeySeparator
+	// format is pool id
+	// marks the pool as quarantined, see QuarantinedPool
+	QuarantinedPoolPrefix = quarantinedPoolNoSeparator + KeySeparator
+	// format is denom1 | denom2 | pool id
+	// made for getting all the pools with records for a denom pair
+	PairPoolIndexPrefix = pairPoolIndexNoSeparator + KeySeparator
+	// format is pool id | gap start time, the value is the gap end time
+	// made for getting the periods during which the records of a pool were not updated, see TrackingGap
+	TrackingGapPrefix = trackingGapNoSeparator + KeySeparator
+	// format is pool id
+	// marks the pool as changed in a block whose EndBlock deferred updating its records past its gas budget
+	DeferredPoolPrefix = deferredPoolNoSeparator + KeySeparator
+	// format is time | pool id | denom1 | denom2
+	// made for efficiently deleting spot price samples by time in pruning, as for the historical records
+	SpotPriceSampleTimeIndexPrefix = spotPriceSampleTimeIndexNoSeparator + KeySeparator
+	// format is pool id | denom1 | denom2 | time
+	// made for getting the spot price samples of a (pool id, denom1, denom2) within a time range, see SpotPriceSample
+	SpotPriceSamplePoolIndexPrefix = spotPriceSamplePoolIndexNoSeparator + KeySeparator
+	// format is denom
+	// made for getting the canonical route of a denom, see CanonicalTwapRoute
+	CanonicalTwapRoutePrefix = canonicalTwapRouteNoSeparator + KeySeparator
+	// holds the state of the pruning that is in progress, see PruningState
+	PruningStateKey = []byte("pruning_state")
 )
 
 // TODO: make utility command to automatically interlace separators
 
+// FormatMostRecentTWAPsPrefix returns the prefix of the keys of the most recent records of every pool.
+func FormatMostRecentTWAPsPrefix() []byte {
+	return []byte(mostRecentTWAPsPrefix)
+}
+
 func FormatMostRecentTWAPKey(poolId uint64, denom1, denom2 string) []byte {
 	poolIdS := osmoutils.FormatFixedLengthU64(poolId)
 	return []byte(fmt.Sprintf("%s%s%s%s%s%s", mostRecentTWAPsPrefix, poolIdS, KeySeparator, denom1, KeySeparator, denom2))
@@ -62,6 +107,12 @@ func FormatHistoricalPoolIndexTWAPKey(poolId uint64, denom1, denom2 string, accu
 	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s%s", HistoricalTWAPPoolIndexPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator, timeS))
 }
 
+// FormatHistoricalPoolIndexPoolPrefix returns the prefix of the pool index keys of the records of every denom pair of
+// pool poolId.
+func FormatHistoricalPoolIndexPoolPrefix(poolId uint64) []byte {
+	return []byte(fmt.Sprintf("%s%d%s", HistoricalTWAPPoolIndexPrefix, poolId, KeySeparator))
+}
+
 func FormatHistoricalPoolIndexTimePrefix(poolId uint64, denom1, denom2 string) []byte {
 	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s", HistoricalTWAPPoolIndexPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator))
 }
@@ -72,6 +123,87 @@ func FormatHistoricalPoolIndexTimeSuffix(poolId uint64, denom1, denom2 string, a
 	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s%s.", HistoricalTWAPPoolIndexPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator, timeS))
 }
 
+func FormatPinnedTWAPKey(poolId uint64, denom1, denom2 string, accumulatorWriteTime time.Time) []byte {
+	timeS := osmoutils.FormatTimeString(accumulatorWriteTime)
+	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s%s", PinnedTWAPPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator, timeS))
+}
+
+func FormatPinnedTWAPTimePrefix(poolId uint64, denom1, denom2 string) []byte {
+	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s", PinnedTWAPPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator))
+}
+
+func FormatQuarantinedPoolKey(poolId uint64) []byte {
+	return []byte(fmt.Sprintf("%s%d", QuarantinedPoolPrefix, poolId))
+}
+
+func FormatPairPoolIndexKey(denom1, denom2 string, poolId uint64) []byte {
+	poolIdS := osmoutils.FormatFixedLengthU64(poolId)
+	return []byte(fmt.Sprintf("%s%s%s%s%s%s", PairPoolIndexPrefix, denom1, KeySeparator, denom2, KeySeparator, poolIdS))
+}
+
+func FormatPairPoolIndexPrefix(denom1, denom2 string) []byte {
+	return []byte(fmt.Sprintf("%s%s%s%s%s", PairPoolIndexPrefix, denom1, KeySeparator, denom2, KeySeparator))
+}
+
+func FormatTrackingGapKey(poolId uint64, from time.Time) []byte {
+	return []byte(fmt.Sprintf("%s%s", FormatTrackingGapPrefix(poolId), osmoutils.FormatTimeString(from)))
+}
+
+func FormatTrackingGapPrefix(poolId uint64) []byte {
+	poolIdS := osmoutils.FormatFixedLengthU64(poolId)
+	return []byte(fmt.Sprintf("%s%s%s", TrackingGapPrefix, poolIdS, KeySeparator))
+}
+
+func FormatDeferredPoolKey(poolId uint64) []byte {
+	poolIdS := osmoutils.FormatFixedLengthU64(poolId)
+	return []byte(fmt.Sprintf("%s%s", DeferredPoolPrefix, poolIdS))
+}
+
+func FormatSpotPriceSampleTimeIndexKey(sampleTime time.Time, poolId uint64, denom1, denom2 string) []byte {
+	timeS := osmoutils.FormatTimeString(sampleTime)
+	return []byte(fmt.Sprintf("%s%s%s%d%s%s%s%s", SpotPriceSampleTimeIndexPrefix, timeS, KeySeparator, poolId, KeySeparator, denom1, KeySeparator, denom2))
+}
+
+func FormatSpotPriceSamplePoolIndexKey(poolId uint64, denom1, denom2 string, sampleTime time.Time) []byte {
+	timeS := osmoutils.FormatTimeString(sampleTime)
+	return []byte(fmt.Sprintf("%s%s", FormatSpotPriceSamplePoolIndexTimePrefix(poolId, denom1, denom2), timeS))
+}
+
+func FormatSpotPriceSamplePoolIndexTimePrefix(poolId uint64, denom1, denom2 string) []byte {
+	return []byte(fmt.Sprintf("%s%d%s%s%s%s%s", SpotPriceSamplePoolIndexPrefix, poolId, KeySeparator, denom1, KeySeparator, denom2, KeySeparator))
+}
+
+// ParseDeferredPoolKey returns the pool id of a key formatted with FormatDeferredPoolKey.
+func FormatCanonicalTwapRouteKey(denom string) []byte {
+	return []byte(CanonicalTwapRoutePrefix + denom)
+}
+
+func ParseDeferredPoolKey(key []byte) (uint64, error) {
+	poolIdS := strings.TrimPrefix(string(key), DeferredPoolPrefix)
+	return strconv.ParseUint(poolIdS, 10, 64)
+}
+
+// ParseTrackingGap returns the tracking gap of pool poolId stored at a key formatted with FormatTrackingGapKey.
+func ParseTrackingGap(poolId uint64, key, value []byte) (TrackingGap, error) {
+	fromS := strings.TrimPrefix(string(key), string(FormatTrackingGapPrefix(poolId)))
+	from, err := osmoutils.ParseTimeString(fromS)
+	if err != nil {
+		return TrackingGap{}, TimeStringKeyFormatError{Key: string(key), Err: err}
+	}
+	to, err := osmoutils.ParseTimeString(string(value))
+	if err != nil {
+		return TrackingGap{}, TimeStringKeyFormatError{Key: string(key), Err: err}
+	}
+	return TrackingGap{PoolId: poolId, From: from, To: to}, nil
+}
+
+// ParsePairPoolIndexKey returns the pool id of a key formatted with FormatPairPoolIndexKey
+// for the pair (denom1, denom2).
+func ParsePairPoolIndexKey(key []byte, denom1, denom2 string) (uint64, error) {
+	poolIdS := strings.TrimPrefix(string(key), string(FormatPairPoolIndexPrefix(denom1, denom2)))
+	return strconv.ParseUint(poolIdS, 10, 64)
+}
+
 // GetAllMostRecentTwapsForPool returns all of the most recent twap records for a pool id.
 // if the pool id doesn't exist, then this returns a blank list.
 func GetAllMostRecentTwapsForPool(store sdk.KVStore, poolId uint64) ([]TwapRecord, error) {
@@ -82,10 +214,49 @@ func GetAllMostRecentTwapsForPool(store sdk.KVStore, poolId uint64) ([]TwapRecor
 	return osmoutils.GatherValuesFromStore(store, []byte(startPrefix), []byte(endPrefix), ParseTwapFromBz)
 }
 
+// GetMostRecentTwapPoolIds returns the ids of at most limit pools with most recent twap records, from startPoolId on,
+// in ascending order. next is the id of the following pool with records, or 0 if there is none.
+func GetMostRecentTwapPoolIds(store sdk.KVStore, startPoolId uint64, limit uint64) (poolIds []uint64, next uint64, err error) {
+	start := fmt.Sprintf("%s%s%s", mostRecentTWAPsPrefix, osmoutils.FormatFixedLengthU64(startPoolId), KeySeparator)
+	iter := store.Iterator([]byte(start), sdk.PrefixEndBytes([]byte(mostRecentTWAPsPrefix)))
+	defer iter.Close()
+
+	poolIds = []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		record, err := ParseTwapFromBz(iter.Value())
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(poolIds) > 0 && poolIds[len(poolIds)-1] == record.PoolId {
+			continue
+		}
+		if uint64(len(poolIds)) == limit {
+			return poolIds, record.PoolId, nil
+		}
+		poolIds = append(poolIds, record.PoolId)
+	}
+	return poolIds, 0, nil
+}
+
+// GetAllMostRecentTwaps returns the most recent twap records of every pool, sorted by pool id and denoms.
+func GetAllMostRecentTwaps(store sdk.KVStore) ([]TwapRecord, error) {
+	return osmoutils.GatherValuesFromStorePrefix(store, []byte(mostRecentTWAPsPrefix), ParseTwapFromBz)
+}
+
+func ParseSpotPriceSampleFromBz(bz []byte) (sample SpotPriceSample, err error) {
+	err = proto.Unmarshal(bz, &sample)
+	return sample, err
+}
+
 func ParseTwapFromBz(bz []byte) (twap TwapRecord, err error) {
 	if len(bz) == 0 {
-		return TwapRecord{}, errors.New("twap not found")
+		return TwapRecord{}, ErrRecordNotFound
 	}
 	err = proto.Unmarshal(bz, &twap)
 	return twap, err
 }
+
+func ParseCanonicalTwapRouteFromBz(bz []byte) (route CanonicalTwapRoute, err error) {
+	err = proto.Unmarshal(bz, &route)
+	return route, err
+}
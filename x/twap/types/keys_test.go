@@ -29,6 +29,28 @@ func TestFormatMostRecentTWAPKey(t *testing.T) {
 	}
 }
 
+func TestFormatPairPoolIndexKey(t *testing.T) {
+	tests := map[string]struct {
+		poolId uint64
+		denom1 string
+		denom2 string
+		want   string
+	}{
+		"standard":  {poolId: 1, denom1: "A", denom2: "B", want: "pair_pool_index|A|B|00000000000000000001"},
+		"maxPoolId": {poolId: ^uint64(0), denom1: "A", denom2: "B", want: "pair_pool_index|A|B|18446744073709551615"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FormatPairPoolIndexKey(tt.denom1, tt.denom2, tt.poolId)
+			require.Equal(t, tt.want, string(got))
+			require.True(t, strings.HasPrefix(string(got), string(FormatPairPoolIndexPrefix(tt.denom1, tt.denom2))))
+			poolId, err := ParsePairPoolIndexKey(got, tt.denom1, tt.denom2)
+			require.NoError(t, err)
+			require.Equal(t, tt.poolId, poolId)
+		})
+	}
+}
+
 func TestFormatHistoricalTwapKeys(t *testing.T) {
 	// go playground default time
 	// 2009-11-10 23:00:00 +0000 UTC m=+0.000000001
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// constants
+const (
+	TypeMsgPinTwapRecord   = "pin_twap_record"
+	TypeMsgUnpinTwapRecord = "unpin_twap_record"
+
+	TypeMsgSetCanonicalTwapRoute    = "set_canonical_twap_route"
+	TypeMsgRemoveCanonicalTwapRoute = "remove_canonical_twap_route"
+
+	TypeMsgUpdateParams = "update_params"
+)
+
+var (
+	_ sdk.Msg = &MsgPinTwapRecord{}
+	_ sdk.Msg = &MsgUnpinTwapRecord{}
+	_ sdk.Msg = &MsgSetCanonicalTwapRoute{}
+	_ sdk.Msg = &MsgRemoveCanonicalTwapRoute{}
+	_ sdk.Msg = &MsgUpdateParams{}
+)
+
+// NewMsgPinTwapRecord creates a msg to pin the record of a pool's denom pair at or before the given time
+func NewMsgPinTwapRecord(sender string, poolId uint64, denom0, denom1 string, t time.Time) *MsgPinTwapRecord {
+	return &MsgPinTwapRecord{
+		Sender: sender,
+		PoolId: poolId,
+		Denom0: denom0,
+		Denom1: denom1,
+		Time:   t,
+	}
+}
+
+func (m MsgPinTwapRecord) Route() string { return RouterKey }
+func (m MsgPinTwapRecord) Type() string  { return TypeMsgPinTwapRecord }
+func (m MsgPinTwapRecord) ValidateBasic() error {
+	return validatePinMsg(m.Sender, m.PoolId, m.Denom0, m.Denom1, m.Time)
+}
+
+func (m MsgPinTwapRecord) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgPinTwapRecord) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgUnpinTwapRecord creates a msg to unpin the pinned record of a pool's denom pair at or before the given time
+func NewMsgUnpinTwapRecord(sender string, poolId uint64, denom0, denom1 string, t time.Time) *MsgUnpinTwapRecord {
+	return &MsgUnpinTwapRecord{
+		Sender: sender,
+		PoolId: poolId,
+		Denom0: denom0,
+		Denom1: denom1,
+		Time:   t,
+	}
+}
+
+func (m MsgUnpinTwapRecord) Route() string { return RouterKey }
+func (m MsgUnpinTwapRecord) Type() string  { return TypeMsgUnpinTwapRecord }
+func (m MsgUnpinTwapRecord) ValidateBasic() error {
+	return validatePinMsg(m.Sender, m.PoolId, m.Denom0, m.Denom1, m.Time)
+}
+
+func (m MsgUnpinTwapRecord) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgUnpinTwapRecord) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgSetCanonicalTwapRoute creates a msg to set the canonical route of a denom
+func NewMsgSetCanonicalTwapRoute(sender string, route CanonicalTwapRoute) *MsgSetCanonicalTwapRoute {
+	return &MsgSetCanonicalTwapRoute{
+		Sender:         sender,
+		CanonicalRoute: route,
+	}
+}
+
+func (m MsgSetCanonicalTwapRoute) Route() string { return RouterKey }
+func (m MsgSetCanonicalTwapRoute) Type() string  { return TypeMsgSetCanonicalTwapRoute }
+func (m MsgSetCanonicalTwapRoute) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Sender); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+
+	return m.CanonicalRoute.Validate()
+}
+
+func (m MsgSetCanonicalTwapRoute) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgSetCanonicalTwapRoute) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgRemoveCanonicalTwapRoute creates a msg to remove the canonical route of a denom
+func NewMsgRemoveCanonicalTwapRoute(sender string, denom string) *MsgRemoveCanonicalTwapRoute {
+	return &MsgRemoveCanonicalTwapRoute{
+		Sender: sender,
+		Denom:  denom,
+	}
+}
+
+func (m MsgRemoveCanonicalTwapRoute) Route() string { return RouterKey }
+func (m MsgRemoveCanonicalTwapRoute) Type() string  { return TypeMsgRemoveCanonicalTwapRoute }
+func (m MsgRemoveCanonicalTwapRoute) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Sender); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+
+	return sdk.ValidateDenom(m.Denom)
+}
+
+func (m MsgRemoveCanonicalTwapRoute) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgRemoveCanonicalTwapRoute) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+// NewMsgUpdateParams creates a msg to replace the params of the module with params
+func NewMsgUpdateParams(sender string, params Params) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Sender: sender,
+		Params: params,
+	}
+}
+
+func (m MsgUpdateParams) Route() string { return RouterKey }
+func (m MsgUpdateParams) Type() string  { return TypeMsgUpdateParams }
+func (m MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Sender); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+
+	return m.Params.Validate()
+}
+
+func (m MsgUpdateParams) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&m))
+}
+
+func (m MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	sender, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{sender}
+}
+
+func validatePinMsg(sender string, poolId uint64, denom0, denom1 string, t time.Time) error {
+	_, err := sdk.AccAddressFromBech32(sender)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "Invalid sender address (%s)", err)
+	}
+
+	if poolId == 0 {
+		return errors.New("pool id cannot be 0")
+	}
+
+	if _, _, err := LexicographicalOrderDenoms(denom0, denom1); err != nil {
+		return err
+	}
+
+	if t.IsZero() {
+		return errors.New("time cannot be 0")
+	}
+
+	return nil
+}
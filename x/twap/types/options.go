@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// DefaultRecordCacheSize is the number of most recent records the query record cache holds when app.toml doesn't
+// configure it. The cache is disabled by default, as only query nodes serving many TWAP queries benefit from it.
+const DefaultRecordCacheSize = 0
+
+// ParseRecordCacheSize returns the osmosis-twap.record-cache-size option, the number of most recent records the
+// node caches in memory for TWAP queries. 0 disables the cache.
+func ParseRecordCacheSize(opts servertypes.AppOptions) int {
+	valueInterface := opts.Get("osmosis-twap.record-cache-size")
+	if valueInterface == nil {
+		return DefaultRecordCacheSize
+	}
+	value, err := cast.ToIntE(valueInterface)
+	if err != nil || value < 0 {
+		panic(fmt.Sprintf("invalidly configured osmosis-twap.record-cache-size: %v", valueInterface))
+	}
+	return value
+}
+
+// ParseArchive returns the osmosis-twap.archive option, whether the node keeps every record it writes in an
+// archive outside of the state, for queries of TWAPs older than the record history keep period.
+// It is disabled by default.
+func ParseArchive(opts servertypes.AppOptions) bool {
+	valueInterface := opts.Get("osmosis-twap.archive")
+	if valueInterface == nil {
+		return false
+	}
+	value, err := cast.ToBoolE(valueInterface)
+	if err != nil {
+		panic(fmt.Sprintf("invalidly configured osmosis-twap.archive: %v", valueInterface))
+	}
+	return value
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
 	epochtypes "github.com/osmosis-labs/osmosis/v13/x/epochs/types"
@@ -11,8 +12,18 @@ import (
 
 // Parameter store keys.
 var (
-	KeyPruneEpochIdentifier    = []byte("PruneEpochIdentifier")
-	KeyRecordHistoryKeepPeriod = []byte("RecordHistoryKeepPeriod")
+	KeyPruneEpochIdentifier        = []byte("PruneEpochIdentifier")
+	KeyRecordHistoryKeepPeriod     = []byte("RecordHistoryKeepPeriod")
+	KeyPinAuthority                = []byte("PinAuthority")
+	KeyMaxPinnedRecords            = []byte("MaxPinnedRecords")
+	KeyEndBlockGasBudget           = []byte("EndBlockGasBudget")
+	KeySpotDeviationAlertThreshold = []byte("SpotDeviationAlertThreshold")
+	KeySpotDeviationAlertWindow    = []byte("SpotDeviationAlertWindow")
+	KeyMedianTrackedPools          = []byte("MedianTrackedPools")
+	KeyCanonicalRouteAuthority     = []byte("CanonicalRouteAuthority")
+	KeyCanonicalQuoteDenom         = []byte("CanonicalQuoteDenom")
+	KeyMaxPrunedRecordsPerBlock    = []byte("MaxPrunedRecordsPerBlock")
+	KeyParamsAuthority             = []byte("ParamsAuthority")
 
 	_ paramtypes.ParamSet = &Params{}
 )
@@ -20,6 +31,32 @@ var (
 const (
 	defaultPruneEpochIdentifier    = "day"
 	defaultRecordHistoryKeepPeriod = 48 * time.Hour
+	// pinning is disabled until governance sets a pin authority.
+	DefaultPinAuthority     = ""
+	DefaultMaxPinnedRecords = uint64(100)
+	// the end block has no gas budget until governance sets one.
+	DefaultEndBlockGasBudget = uint64(0)
+	// the spot prices are compared to the TWAP of the last 10 minutes.
+	DefaultSpotDeviationAlertWindow = 10 * time.Minute
+	// the canonical routes can't be set until governance sets a canonical route authority and quote denom.
+	DefaultCanonicalRouteAuthority = ""
+	DefaultCanonicalQuoteDenom     = ""
+	// the pruning of the records resumes in the next block after pruning 1000 records.
+	DefaultMaxPrunedRecordsPerBlock = uint64(1000)
+	// the params can't be updated with MsgUpdateParams until governance sets a params authority.
+	DefaultParamsAuthority = ""
+
+	// MaxRecordHistoryKeepPeriod is the longest record history keep period. The records of every pool's pairs are
+	// kept for the whole period, so the store grows with it.
+	MaxRecordHistoryKeepPeriod = 365 * 24 * time.Hour
+)
+
+var (
+	// DefaultSpotDeviationAlertThreshold is the default spot deviation alert threshold, which disables the alerts.
+	DefaultSpotDeviationAlertThreshold = sdk.ZeroDec()
+	// DefaultMedianTrackedPools is the default median tracked pools, no pool is sampled until governance opts
+	// pools in.
+	DefaultMedianTrackedPools []uint64
 )
 
 // ParamTable for twap module.
@@ -29,16 +66,36 @@ func ParamKeyTable() paramtypes.KeyTable {
 
 func NewParams(pruneEpochIdentifier string, recordHistoryKeepPeriod time.Duration) Params {
 	return Params{
-		PruneEpochIdentifier:    pruneEpochIdentifier,
-		RecordHistoryKeepPeriod: recordHistoryKeepPeriod,
+		PruneEpochIdentifier:        pruneEpochIdentifier,
+		RecordHistoryKeepPeriod:     recordHistoryKeepPeriod,
+		PinAuthority:                DefaultPinAuthority,
+		MaxPinnedRecords:            DefaultMaxPinnedRecords,
+		EndBlockGasBudget:           DefaultEndBlockGasBudget,
+		SpotDeviationAlertThreshold: DefaultSpotDeviationAlertThreshold,
+		SpotDeviationAlertWindow:    DefaultSpotDeviationAlertWindow,
+		MedianTrackedPools:          DefaultMedianTrackedPools,
+		CanonicalRouteAuthority:     DefaultCanonicalRouteAuthority,
+		CanonicalQuoteDenom:         DefaultCanonicalQuoteDenom,
+		MaxPrunedRecordsPerBlock:    DefaultMaxPrunedRecordsPerBlock,
+		ParamsAuthority:             DefaultParamsAuthority,
 	}
 }
 
 // default twap module parameters.
 func DefaultParams() Params {
 	return Params{
-		PruneEpochIdentifier:    defaultPruneEpochIdentifier,
-		RecordHistoryKeepPeriod: defaultRecordHistoryKeepPeriod,
+		PruneEpochIdentifier:        defaultPruneEpochIdentifier,
+		RecordHistoryKeepPeriod:     defaultRecordHistoryKeepPeriod,
+		PinAuthority:                DefaultPinAuthority,
+		MaxPinnedRecords:            DefaultMaxPinnedRecords,
+		EndBlockGasBudget:           DefaultEndBlockGasBudget,
+		SpotDeviationAlertThreshold: DefaultSpotDeviationAlertThreshold,
+		SpotDeviationAlertWindow:    DefaultSpotDeviationAlertWindow,
+		MedianTrackedPools:          DefaultMedianTrackedPools,
+		CanonicalRouteAuthority:     DefaultCanonicalRouteAuthority,
+		CanonicalQuoteDenom:         DefaultCanonicalQuoteDenom,
+		MaxPrunedRecordsPerBlock:    DefaultMaxPrunedRecordsPerBlock,
+		ParamsAuthority:             DefaultParamsAuthority,
 	}
 }
 
@@ -48,10 +105,44 @@ func (p Params) Validate() error {
 		return err
 	}
 
-	if err := validatePeriod(p.RecordHistoryKeepPeriod); err != nil {
+	if err := validateRecordHistoryKeepPeriod(p.RecordHistoryKeepPeriod); err != nil {
+		return err
+	}
+
+	if err := validatePinAuthority(p.PinAuthority); err != nil {
+		return err
+	}
+
+	if err := validateSpotDeviationAlertThreshold(p.SpotDeviationAlertThreshold); err != nil {
+		return err
+	}
+
+	if err := validatePeriod(p.SpotDeviationAlertWindow); err != nil {
+		return err
+	}
+
+	if err := validateMedianTrackedPools(p.MedianTrackedPools); err != nil {
+		return err
+	}
+
+	if err := validateCanonicalRouteAuthority(p.CanonicalRouteAuthority); err != nil {
 		return err
 	}
 
+	if err := validateCanonicalQuoteDenom(p.CanonicalQuoteDenom); err != nil {
+		return err
+	}
+
+	if err := validateParamsAuthority(p.ParamsAuthority); err != nil {
+		return err
+	}
+
+	// the alert window's TWAP can't be computed from pruned records.
+	if p.SpotDeviationAlertWindow > p.RecordHistoryKeepPeriod {
+		return fmt.Errorf("spot deviation alert window %s must not exceed the record history keep period %s",
+			p.SpotDeviationAlertWindow, p.RecordHistoryKeepPeriod)
+	}
+
 	return nil
 }
 
@@ -59,7 +150,17 @@ func (p Params) Validate() error {
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
 		paramtypes.NewParamSetPair(KeyPruneEpochIdentifier, &p.PruneEpochIdentifier, epochtypes.ValidateEpochIdentifierInterface),
-		paramtypes.NewParamSetPair(KeyRecordHistoryKeepPeriod, &p.RecordHistoryKeepPeriod, validatePeriod),
+		paramtypes.NewParamSetPair(KeyRecordHistoryKeepPeriod, &p.RecordHistoryKeepPeriod, validateRecordHistoryKeepPeriod),
+		paramtypes.NewParamSetPair(KeyPinAuthority, &p.PinAuthority, validatePinAuthority),
+		paramtypes.NewParamSetPair(KeyMaxPinnedRecords, &p.MaxPinnedRecords, validateMaxPinnedRecords),
+		paramtypes.NewParamSetPair(KeyEndBlockGasBudget, &p.EndBlockGasBudget, validateEndBlockGasBudget),
+		paramtypes.NewParamSetPair(KeySpotDeviationAlertThreshold, &p.SpotDeviationAlertThreshold, validateSpotDeviationAlertThreshold),
+		paramtypes.NewParamSetPair(KeySpotDeviationAlertWindow, &p.SpotDeviationAlertWindow, validatePeriod),
+		paramtypes.NewParamSetPair(KeyMedianTrackedPools, &p.MedianTrackedPools, validateMedianTrackedPools),
+		paramtypes.NewParamSetPair(KeyCanonicalRouteAuthority, &p.CanonicalRouteAuthority, validateCanonicalRouteAuthority),
+		paramtypes.NewParamSetPair(KeyCanonicalQuoteDenom, &p.CanonicalQuoteDenom, validateCanonicalQuoteDenom),
+		paramtypes.NewParamSetPair(KeyMaxPrunedRecordsPerBlock, &p.MaxPrunedRecordsPerBlock, validateMaxPrunedRecordsPerBlock),
+		paramtypes.NewParamSetPair(KeyParamsAuthority, &p.ParamsAuthority, validateParamsAuthority),
 	}
 }
 
@@ -75,3 +176,147 @@ func validatePeriod(i interface{}) error {
 
 	return nil
 }
+
+func validateRecordHistoryKeepPeriod(i interface{}) error {
+	if err := validatePeriod(i); err != nil {
+		return err
+	}
+
+	if v := i.(time.Duration); v > MaxRecordHistoryKeepPeriod {
+		return fmt.Errorf("record history keep period %s must not exceed %s", v, MaxRecordHistoryKeepPeriod)
+	}
+
+	return nil
+}
+
+func validatePinAuthority(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty pin authority disables pinning.
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return fmt.Errorf("invalid pin authority address (%s): %w", v, err)
+	}
+
+	return nil
+}
+
+func validateMaxPinnedRecords(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateEndBlockGasBudget(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateMaxPrunedRecordsPerBlock(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateSpotDeviationAlertThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() {
+		return fmt.Errorf("spot deviation alert threshold must be set")
+	}
+
+	if v.IsNegative() {
+		return fmt.Errorf("spot deviation alert threshold must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateMedianTrackedPools(i interface{}) error {
+	v, ok := i.([]uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[uint64]bool, len(v))
+	for _, poolId := range v {
+		if poolId == 0 {
+			return fmt.Errorf("median tracked pool id must be positive")
+		}
+		if seen[poolId] {
+			return fmt.Errorf("median tracked pool %d is duplicated", poolId)
+		}
+		seen[poolId] = true
+	}
+
+	return nil
+}
+
+func validateCanonicalRouteAuthority(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty canonical route authority disables setting the canonical routes.
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return fmt.Errorf("invalid canonical route authority address (%s): %w", v, err)
+	}
+
+	return nil
+}
+
+func validateCanonicalQuoteDenom(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty canonical quote denom disables setting the canonical routes.
+	if v == "" {
+		return nil
+	}
+
+	return sdk.ValidateDenom(v)
+}
+
+func validateParamsAuthority(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// an empty params authority disables MsgUpdateParams.
+	if v == "" {
+		return nil
+	}
+
+	if _, err := sdk.AccAddressFromBech32(v); err != nil {
+		return fmt.Errorf("invalid params authority address (%s): %w", v, err)
+	}
+
+	return nil
+}
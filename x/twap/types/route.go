@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxTwapRouteHops is the maximum number of hops of the routes TWAPs are computed over.
+const MaxTwapRouteHops = 5
+
+// TwapRoutePoolPair is a hop of a TWAP route: the base and quote asset of a pool.
+type TwapRoutePoolPair struct {
+	PoolId     uint64
+	BaseAsset  string
+	QuoteAsset string
+}
+
+// RouteTwapResult is the TWAP of the base asset of the first hop of a route in units of the quote asset of its last
+// hop, the product of the TWAPs of its hops.
+type RouteTwapResult struct {
+	Price sdk.Dec
+	// LastErrorTime is the last time the spot price of a pool of the route errored, up to the end of the window, or
+	// zero if none did. The TWAP may be faulty if it is at or after the start of the window.
+	LastErrorTime time.Time
+	// Hops are the TWAP results of the hops, in the order of the route.
+	Hops []TwapResult
+}
+
+// Validate returns an error if the canonical route has an invalid denom, no hops or more than MaxTwapRouteHops hops,
+// or a hop with a zero pool id, an invalid quote asset or the same quote asset as its base asset. It doesn't check
+// that the pools of the hops hold their assets, nor the denom the route ends in.
+func (r CanonicalTwapRoute) Validate() error {
+	if err := sdk.ValidateDenom(r.Denom); err != nil {
+		return err
+	}
+	if len(r.Hops) == 0 {
+		return InvalidRouteError{Reason: "the route has no hops"}
+	}
+	if len(r.Hops) > MaxTwapRouteHops {
+		return TooManyHopsError{NumHops: len(r.Hops), MaxHops: MaxTwapRouteHops}
+	}
+	baseAsset := r.Denom
+	for i, hop := range r.Hops {
+		if hop.PoolId == 0 {
+			return InvalidRouteError{Reason: fmt.Sprintf("the pool id of hop %d is 0", i)}
+		}
+		if err := sdk.ValidateDenom(hop.QuoteAsset); err != nil {
+			return InvalidRouteError{Reason: fmt.Sprintf("invalid quote asset of hop %d: %s", i, err)}
+		}
+		if hop.QuoteAsset == baseAsset {
+			return InvalidRouteError{Reason: fmt.Sprintf("hop %d quotes %s in itself", i, baseAsset)}
+		}
+		baseAsset = hop.QuoteAsset
+	}
+	return nil
+}
+
+// QuoteDenom returns the denom the route ends in, the quote asset of its last hop.
+func (r CanonicalTwapRoute) QuoteDenom() string {
+	if len(r.Hops) == 0 {
+		return ""
+	}
+	return r.Hops[len(r.Hops)-1].QuoteAsset
+}
+
+// TwapRoute returns the hops of the route as a TWAP route, the base asset of every hop being the quote asset of the
+// previous one, and the denom of the route for the first one.
+func (r CanonicalTwapRoute) TwapRoute() []TwapRoutePoolPair {
+	route := make([]TwapRoutePoolPair, 0, len(r.Hops))
+	baseAsset := r.Denom
+	for _, hop := range r.Hops {
+		route = append(route, TwapRoutePoolPair{PoolId: hop.PoolId, BaseAsset: baseAsset, QuoteAsset: hop.QuoteAsset})
+		baseAsset = hop.QuoteAsset
+	}
+	return route
+}
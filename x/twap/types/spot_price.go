@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SpotPricePair is the base and quote asset of a pool to get the spot price of.
+type SpotPricePair struct {
+	PoolId     uint64
+	BaseAsset  string
+	QuoteAsset string
+}
+
+// SpotPriceAtTime is the spot price of the base asset of Pair in units of its quote asset, as stored by the last
+// record of the pool at or before a time.
+type SpotPriceAtTime struct {
+	Pair       SpotPricePair
+	SpotPrice  sdk.Dec
+	RecordTime time.Time
+	// ErrorActive is true if the pool's spot price errored when the record was written, in which case SpotPrice
+	// may be faulty.
+	ErrorActive bool
+	// Err is the error resolving the pair, in which case the other fields are unset.
+	Err error
+}
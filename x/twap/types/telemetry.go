@@ -0,0 +1,35 @@
+package types
+
+// The keys of the module's metrics. Every metric is emitted under the module name first, e.g. the number of records
+// written in EndBlock is the counter twap.end_block.records_written.
+const (
+	// MetricKeyEndBlock prefixes the metrics of EndBlock
+	MetricKeyEndBlock = "end_block"
+	// MetricKeyRecordsWritten counts the records written by the updates of the pools
+	MetricKeyRecordsWritten = "records_written"
+	// MetricKeySpotPriceErrors counts the records written with a spot price that errored in their block
+	MetricKeySpotPriceErrors = "spot_price_errors"
+	// MetricKeyDeferredPools counts the pools deferred to the next block by the gas budget
+	MetricKeyDeferredPools = "deferred_pools"
+	// MetricKeyGasUsed is the gauge of the gas used by the last EndBlock
+	MetricKeyGasUsed = "gas_used"
+
+	// MetricKeyPrune prefixes the metrics of the pruning of the records
+	MetricKeyPrune = "prune"
+	// MetricKeyRecordsPruned counts the historical records deleted by the pruning
+	MetricKeyRecordsPruned = "records_pruned"
+
+	// MetricKeyQuery prefixes the latencies of the queries, followed by the name of the query,
+	// e.g. twap.query.ArithmeticTwap
+	MetricKeyQuery = "query"
+
+	// MetricKeyRecordCache prefixes the hits, misses and invalidations of the most recent record cache
+	MetricKeyRecordCache   = "record_cache"
+	MetricKeyHits          = "hits"
+	MetricKeyMisses        = "misses"
+	MetricKeyInvalidations = "invalidations"
+
+	// MetricKeyArchive prefixes the number of records copied into the archive
+	MetricKeyArchive = "archive"
+	MetricKeyRecords = "records"
+)
@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// TrackingGap is a period during which the records of a pool were not updated, from the block its quarantine started
+// to the block it was repaired. The accumulators interpolated within it use the last spot prices recorded before it,
+// which may be stale.
+type TrackingGap struct {
+	PoolId uint64
+	From   time.Time
+	To     time.Time
+}
+
+// Overlaps returns whether the window from start to end overlaps the gap by more than its bounds.
+// A window ending when the gap starts, or starting when it ends, doesn't overlap it.
+func (g TrackingGap) Overlaps(start, end time.Time) bool {
+	return start.Before(g.To) && end.After(g.From)
+}
+
+// OverlapDuration returns the duration of the part of the window from start to end within the gap, as accounted
+// for by the accumulators.
+func (g TrackingGap) OverlapDuration(start, end time.Time) time.Duration {
+	if !g.Overlaps(start, end) {
+		return 0
+	}
+	if start.Before(g.From) {
+		start = g.From
+	}
+	if end.After(g.To) {
+		end = g.To
+	}
+	return AccumulatorTimeDelta(start, end)
+}
@@ -60,6 +60,11 @@ type TwapRecord struct {
 	// It is used to alert the caller if they are getting a potentially erroneous
 	// TWAP, due to an unforeseen underlying error.
 	LastErrorTime time.Time `protobuf:"bytes,11,opt,name=last_error_time,json=lastErrorTime,proto3,stdtime" json:"last_error_time" yaml:"last_error_time"`
+	// The schema version of the module that wrote this record. Records written
+	// before schema versioning was introduced have a schema version of 0.
+	// Clients can use it, together with the ModuleVersion query, to detect
+	// which fields of the record are populated.
+	SchemaVersion uint32 `protobuf:"varint,12,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty" yaml:"schema_version"`
 }
 
 func (m *TwapRecord) Reset()         { *m = TwapRecord{} }
@@ -137,8 +142,478 @@ func (m *TwapRecord) GetLastErrorTime() time.Time {
 	return time.Time{}
 }
 
+func (m *TwapRecord) GetSchemaVersion() uint32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+// TwapCandle is a compact summary of the records of a pool's denom pair over
+// an interval, for clients charting prices. Records only hold spot prices at
+// the times they were written, so it has the arithmetic TWAP over the
+// interval rather than true high and low prices.
+type TwapCandle struct {
+	StartTime time.Time `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time" yaml:"start_time"`
+	EndTime   time.Time `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3,stdtime" json:"end_time" yaml:"end_time"`
+	// arithmetic_twap is the arithmetic TWAP over [start_time, end_time].
+	ArithmeticTwap github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,3,opt,name=arithmetic_twap,json=arithmeticTwap,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"arithmetic_twap" yaml:"arithmetic_twap"`
+	// first_spot_price is the spot price of the first record written in the
+	// interval.
+	FirstSpotPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,4,opt,name=first_spot_price,json=firstSpotPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"first_spot_price" yaml:"first_spot_price"`
+	// last_spot_price is the spot price of the last record written in the
+	// interval.
+	LastSpotPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,5,opt,name=last_spot_price,json=lastSpotPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"last_spot_price" yaml:"last_spot_price"`
+	// has_records is false if no record was written in the interval, in which
+	// case both spot prices are carried forward from the last record before it.
+	HasRecords bool `protobuf:"varint,6,opt,name=has_records,json=hasRecords,proto3" json:"has_records,omitempty" yaml:"has_records"`
+	// error_active is true if the pool's spot price errored during the
+	// interval, in which case arithmetic_twap may be faulty.
+	ErrorActive bool `protobuf:"varint,7,opt,name=error_active,json=errorActive,proto3" json:"error_active,omitempty" yaml:"error_active"`
+	// start_interpolated is true if no record was written at start_time, in
+	// which case the TWAP starts from the last record before it, extended to
+	// start_time.
+	StartInterpolated bool `protobuf:"varint,8,opt,name=start_interpolated,json=startInterpolated,proto3" json:"start_interpolated,omitempty" yaml:"start_interpolated"`
+	// end_interpolated is true if no record was written at end_time, in which
+	// case the TWAP ends at the last record before it, extended to end_time.
+	EndInterpolated bool `protobuf:"varint,9,opt,name=end_interpolated,json=endInterpolated,proto3" json:"end_interpolated,omitempty" yaml:"end_interpolated"`
+}
+
+func (m *TwapCandle) Reset()         { *m = TwapCandle{} }
+func (m *TwapCandle) String() string { return proto.CompactTextString(m) }
+func (*TwapCandle) ProtoMessage()    {}
+func (*TwapCandle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{1}
+}
+func (m *TwapCandle) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TwapCandle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TwapCandle.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TwapCandle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TwapCandle.Merge(m, src)
+}
+func (m *TwapCandle) XXX_Size() int {
+	return m.Size()
+}
+func (m *TwapCandle) XXX_DiscardUnknown() {
+	xxx_messageInfo_TwapCandle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TwapCandle proto.InternalMessageInfo
+
+func (m *TwapCandle) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *TwapCandle) GetEndTime() time.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return time.Time{}
+}
+
+func (m *TwapCandle) GetHasRecords() bool {
+	if m != nil {
+		return m.HasRecords
+	}
+	return false
+}
+
+func (m *TwapCandle) GetErrorActive() bool {
+	if m != nil {
+		return m.ErrorActive
+	}
+	return false
+}
+
+func (m *TwapCandle) GetStartInterpolated() bool {
+	if m != nil {
+		return m.StartInterpolated
+	}
+	return false
+}
+
+func (m *TwapCandle) GetEndInterpolated() bool {
+	if m != nil {
+		return m.EndInterpolated
+	}
+	return false
+}
+
+// QuarantinedPool is a pool whose denoms don't match the denoms of its most
+// recent twap records. Its records are not updated, and its TWAPs can't be
+// queried, until it is repaired.
+type QuarantinedPool struct {
+	PoolId uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	// height is the height the mismatch was detected at.
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty" yaml:"height"`
+	// time is the block time the mismatch was detected at.
+	Time time.Time `protobuf:"bytes,3,opt,name=time,proto3,stdtime" json:"time" yaml:"time"`
+	// record_denoms are the denoms of the pool's most recent records, sorted.
+	RecordDenoms []string `protobuf:"bytes,4,rep,name=record_denoms,json=recordDenoms,proto3" json:"record_denoms,omitempty" yaml:"record_denoms"`
+	// pool_denoms are the denoms the pool reported, sorted.
+	PoolDenoms []string `protobuf:"bytes,5,rep,name=pool_denoms,json=poolDenoms,proto3" json:"pool_denoms,omitempty" yaml:"pool_denoms"`
+}
+
+func (m *QuarantinedPool) Reset()         { *m = QuarantinedPool{} }
+func (m *QuarantinedPool) String() string { return proto.CompactTextString(m) }
+func (*QuarantinedPool) ProtoMessage()    {}
+func (*QuarantinedPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{2}
+}
+func (m *QuarantinedPool) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuarantinedPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuarantinedPool.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuarantinedPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedPool.Merge(m, src)
+}
+func (m *QuarantinedPool) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuarantinedPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedPool proto.InternalMessageInfo
+
+func (m *QuarantinedPool) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *QuarantinedPool) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *QuarantinedPool) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+func (m *QuarantinedPool) GetRecordDenoms() []string {
+	if m != nil {
+		return m.RecordDenoms
+	}
+	return nil
+}
+
+func (m *QuarantinedPool) GetPoolDenoms() []string {
+	if m != nil {
+		return m.PoolDenoms
+	}
+	return nil
+}
+
+// SpotPriceSample is the spot prices of a denom pair of a pool at the end of a
+// block, sampled for the pools of the median_tracked_pools param.
+type SpotPriceSample struct {
+	PoolId uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	// Lexicographically smaller denom of the pair
+	Asset0Denom string `protobuf:"bytes,2,opt,name=asset0_denom,json=asset0Denom,proto3" json:"asset0_denom,omitempty" yaml:"asset0_denom"`
+	// Lexicographically larger denom of the pair
+	Asset1Denom string `protobuf:"bytes,3,opt,name=asset1_denom,json=asset1Denom,proto3" json:"asset1_denom,omitempty" yaml:"asset1_denom"`
+	// height is the height of the block the sample was taken at.
+	Height int64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty" yaml:"height"`
+	// time is the block time the sample was taken at.
+	Time time.Time `protobuf:"bytes,5,opt,name=time,proto3,stdtime" json:"time" yaml:"time"`
+	// p0_spot_price is the spot price of asset 1 in units of asset 0, as in
+	// TwapRecord.
+	P0SpotPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,6,opt,name=p0_spot_price,json=p0SpotPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"p0_spot_price" yaml:"p0_spot_price"`
+	// p1_spot_price is the spot price of asset 0 in units of asset 1, as in
+	// TwapRecord.
+	P1SpotPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,7,opt,name=p1_spot_price,json=p1SpotPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"p1_spot_price" yaml:"p1_spot_price"`
+	// spot_price_error is true if getting the spot prices errored, in which case
+	// the sample is ignored by the median.
+	SpotPriceError bool `protobuf:"varint,8,opt,name=spot_price_error,json=spotPriceError,proto3" json:"spot_price_error,omitempty" yaml:"spot_price_error"`
+}
+
+func (m *SpotPriceSample) Reset()         { *m = SpotPriceSample{} }
+func (m *SpotPriceSample) String() string { return proto.CompactTextString(m) }
+func (*SpotPriceSample) ProtoMessage()    {}
+func (*SpotPriceSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{3}
+}
+func (m *SpotPriceSample) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpotPriceSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpotPriceSample.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SpotPriceSample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpotPriceSample.Merge(m, src)
+}
+func (m *SpotPriceSample) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpotPriceSample) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpotPriceSample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpotPriceSample proto.InternalMessageInfo
+
+func (m *SpotPriceSample) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *SpotPriceSample) GetAsset0Denom() string {
+	if m != nil {
+		return m.Asset0Denom
+	}
+	return ""
+}
+
+func (m *SpotPriceSample) GetAsset1Denom() string {
+	if m != nil {
+		return m.Asset1Denom
+	}
+	return ""
+}
+
+func (m *SpotPriceSample) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SpotPriceSample) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+func (m *SpotPriceSample) GetSpotPriceError() bool {
+	if m != nil {
+		return m.SpotPriceError
+	}
+	return false
+}
+
+// CanonicalTwapRoute is the route of pools the TWAP of denom in units of the
+// canonical_quote_denom param is computed over, see the CanonicalTwap query.
+type CanonicalTwapRoute struct {
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty" yaml:"denom"`
+	// hops are the hops of the route, at most 5. The first hop quotes denom, each
+	// following hop quotes the quote asset of the previous one, and the last hop
+	// quotes denom in the canonical_quote_denom param.
+	Hops []CanonicalTwapRouteHop `protobuf:"bytes,2,rep,name=hops,proto3" json:"hops" yaml:"hops"`
+}
+
+func (m *CanonicalTwapRoute) Reset()         { *m = CanonicalTwapRoute{} }
+func (m *CanonicalTwapRoute) String() string { return proto.CompactTextString(m) }
+func (*CanonicalTwapRoute) ProtoMessage()    {}
+func (*CanonicalTwapRoute) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{4}
+}
+func (m *CanonicalTwapRoute) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalTwapRoute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalTwapRoute.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalTwapRoute) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalTwapRoute.Merge(m, src)
+}
+func (m *CanonicalTwapRoute) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalTwapRoute) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalTwapRoute.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalTwapRoute proto.InternalMessageInfo
+
+func (m *CanonicalTwapRoute) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *CanonicalTwapRoute) GetHops() []CanonicalTwapRouteHop {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+// CanonicalTwapRouteHop is a hop of a canonical route: the pool the base asset
+// of the hop is quoted in quote_asset in.
+type CanonicalTwapRouteHop struct {
+	PoolId     uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	QuoteAsset string `protobuf:"bytes,2,opt,name=quote_asset,json=quoteAsset,proto3" json:"quote_asset,omitempty" yaml:"quote_asset"`
+}
+
+func (m *CanonicalTwapRouteHop) Reset()         { *m = CanonicalTwapRouteHop{} }
+func (m *CanonicalTwapRouteHop) String() string { return proto.CompactTextString(m) }
+func (*CanonicalTwapRouteHop) ProtoMessage()    {}
+func (*CanonicalTwapRouteHop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{5}
+}
+func (m *CanonicalTwapRouteHop) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalTwapRouteHop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalTwapRouteHop.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalTwapRouteHop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalTwapRouteHop.Merge(m, src)
+}
+func (m *CanonicalTwapRouteHop) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalTwapRouteHop) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalTwapRouteHop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalTwapRouteHop proto.InternalMessageInfo
+
+func (m *CanonicalTwapRouteHop) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *CanonicalTwapRouteHop) GetQuoteAsset() string {
+	if m != nil {
+		return m.QuoteAsset
+	}
+	return ""
+}
+
+// PruningState is the state of the pruning of the records older than the
+// record history keep period. The records are pruned over as many blocks as it
+// takes to prune them at most max_pruned_records_per_block at a time, from the
+// newest to the oldest.
+type PruningState struct {
+	// last_kept_time is the time the records before which are pruned, but the
+	// newest of each denom pair of a pool.
+	LastKeptTime time.Time `protobuf:"bytes,1,opt,name=last_kept_time,json=lastKeptTime,proto3,stdtime" json:"last_kept_time" yaml:"last_kept_time"`
+	// last_key_seen is the time index key of the last record the pruning went
+	// over, which it resumes before in the next block.
+	LastKeySeen []byte `protobuf:"bytes,2,opt,name=last_key_seen,json=lastKeySeen,proto3" json:"last_key_seen,omitempty" yaml:"last_key_seen"`
+}
+
+func (m *PruningState) Reset()         { *m = PruningState{} }
+func (m *PruningState) String() string { return proto.CompactTextString(m) }
+func (*PruningState) ProtoMessage()    {}
+func (*PruningState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf5c78678e601aa, []int{6}
+}
+func (m *PruningState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PruningState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PruningState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PruningState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruningState.Merge(m, src)
+}
+func (m *PruningState) XXX_Size() int {
+	return m.Size()
+}
+func (m *PruningState) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruningState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruningState proto.InternalMessageInfo
+
+func (m *PruningState) GetLastKeptTime() time.Time {
+	if m != nil {
+		return m.LastKeptTime
+	}
+	return time.Time{}
+}
+
+func (m *PruningState) GetLastKeySeen() []byte {
+	if m != nil {
+		return m.LastKeySeen
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TwapRecord)(nil), "osmosis.twap.v1beta1.TwapRecord")
+	proto.RegisterType((*TwapCandle)(nil), "osmosis.twap.v1beta1.TwapCandle")
+	proto.RegisterType((*QuarantinedPool)(nil), "osmosis.twap.v1beta1.QuarantinedPool")
+	proto.RegisterType((*SpotPriceSample)(nil), "osmosis.twap.v1beta1.SpotPriceSample")
+	proto.RegisterType((*CanonicalTwapRoute)(nil), "osmosis.twap.v1beta1.CanonicalTwapRoute")
+	proto.RegisterType((*CanonicalTwapRouteHop)(nil), "osmosis.twap.v1beta1.CanonicalTwapRouteHop")
+	proto.RegisterType((*PruningState)(nil), "osmosis.twap.v1beta1.PruningState")
 }
 
 func init() {
@@ -146,41 +621,81 @@ func init() {
 }
 
 var fileDescriptor_dbf5c78678e601aa = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xbf, 0x6f, 0xd3, 0x40,
-	0x14, 0xc7, 0x63, 0x1a, 0x52, 0x7a, 0xa1, 0xaa, 0x64, 0x45, 0x60, 0x82, 0x64, 0x87, 0x0c, 0x55,
-	0x18, 0xea, 0x1f, 0x74, 0x63, 0x4b, 0x54, 0x06, 0x10, 0x42, 0xc8, 0x74, 0x82, 0xe1, 0x74, 0xb6,
-	0xaf, 0x8e, 0x85, 0x9d, 0x3b, 0xdd, 0x5d, 0x5a, 0xf2, 0x5f, 0xf4, 0xcf, 0xea, 0xd8, 0x11, 0x31,
-	0x18, 0x94, 0x6c, 0x8c, 0x9d, 0x18, 0xd1, 0xfd, 0x48, 0x68, 0xc2, 0x2f, 0x29, 0x53, 0xf2, 0xde,
-	0xfb, 0xbe, 0xcf, 0xf7, 0x3d, 0xfb, 0xc9, 0xe0, 0x90, 0xf0, 0x8a, 0xf0, 0x82, 0x07, 0xe2, 0x02,
-	0xd1, 0xe0, 0x3c, 0x4a, 0xb0, 0x40, 0x91, 0x0a, 0x20, 0xc3, 0x29, 0x61, 0x99, 0x4f, 0x19, 0x11,
-	0xc4, 0xee, 0x18, 0x9d, 0x2f, 0x4b, 0xbe, 0xd1, 0x75, 0x3b, 0x39, 0xc9, 0x89, 0x12, 0x04, 0xf2,
-	0x9f, 0xd6, 0x76, 0x1f, 0xe5, 0x84, 0xe4, 0x25, 0x0e, 0x54, 0x94, 0x4c, 0xcf, 0x02, 0x34, 0x99,
-	0x2d, 0x4b, 0xa9, 0xe2, 0x40, 0xdd, 0xa3, 0x03, 0x53, 0x72, 0x75, 0x14, 0x24, 0x88, 0xe3, 0xd5,
-	0x20, 0x29, 0x29, 0x26, 0xa6, 0xee, 0x6d, 0x52, 0x45, 0x51, 0x61, 0x2e, 0x50, 0x45, 0xb5, 0xa0,
-	0xff, 0xa3, 0x05, 0xc0, 0xe9, 0x05, 0xa2, 0xb1, 0x9a, 0xdb, 0x7e, 0x08, 0x76, 0x29, 0x21, 0x25,
-	0x2c, 0x32, 0xc7, 0xea, 0x59, 0x83, 0x66, 0xdc, 0x92, 0xe1, 0xcb, 0xcc, 0x7e, 0x02, 0xee, 0x23,
-	0xce, 0xb1, 0x08, 0x61, 0x86, 0x27, 0xa4, 0x72, 0xee, 0xf4, 0xac, 0xc1, 0x5e, 0xdc, 0xd6, 0xb9,
-	0x13, 0x99, 0x5a, 0x49, 0x22, 0x23, 0xd9, 0xb9, 0x25, 0x89, 0xb4, 0x64, 0x08, 0x5a, 0x63, 0x5c,
-	0xe4, 0x63, 0xe1, 0x34, 0x7b, 0xd6, 0x60, 0x67, 0xf4, 0xf4, 0x7b, 0xed, 0xed, 0xeb, 0x47, 0x06,
-	0x75, 0xe1, 0xa6, 0xf6, 0x3a, 0x33, 0x54, 0x95, 0xcf, 0xfb, 0x6b, 0xe9, 0x7e, 0x6c, 0x1a, 0xed,
-	0x37, 0xa0, 0x29, 0x77, 0x70, 0xee, 0xf6, 0xac, 0x41, 0xfb, 0x59, 0xd7, 0xd7, 0x0b, 0xfa, 0xcb,
-	0x05, 0xfd, 0xd3, 0xe5, 0x82, 0x23, 0xf7, 0xaa, 0xf6, 0x1a, 0x37, 0xb5, 0x67, 0xaf, 0xf1, 0x64,
-	0x73, 0xff, 0xf2, 0xab, 0x67, 0xc5, 0x8a, 0x63, 0x7f, 0x00, 0x36, 0x0d, 0x61, 0x89, 0xb8, 0x80,
-	0x9c, 0x12, 0x01, 0x29, 0x2b, 0x52, 0xec, 0xb4, 0xe4, 0xec, 0x23, 0x5f, 0x12, 0xbe, 0xd4, 0xde,
-	0x61, 0x5e, 0x88, 0xf1, 0x34, 0xf1, 0x53, 0x52, 0x99, 0xc7, 0x6f, 0x7e, 0x8e, 0x78, 0xf6, 0x31,
-	0x10, 0x33, 0x8a, 0xb9, 0x7f, 0x82, 0xd3, 0xf8, 0x80, 0x86, 0xaf, 0x11, 0x17, 0xef, 0x28, 0x11,
-	0x6f, 0x25, 0x46, 0xc1, 0xa3, 0xdf, 0xe0, 0xbb, 0x5b, 0xc2, 0xa3, 0x75, 0x38, 0x07, 0x2e, 0x0d,
-	0x21, 0x62, 0x85, 0x18, 0x57, 0x58, 0x14, 0x29, 0x54, 0x07, 0x88, 0xd2, 0x74, 0x5a, 0x4d, 0x4b,
-	0x24, 0x08, 0x73, 0xee, 0x6d, 0x65, 0xf4, 0x98, 0x86, 0xc3, 0x15, 0x54, 0xde, 0xc6, 0xf0, 0x17,
-	0x52, 0x99, 0x46, 0xff, 0x34, 0xdd, 0xdb, 0xd2, 0x34, 0xfa, 0xbb, 0x69, 0x09, 0xba, 0x39, 0x26,
-	0x15, 0x16, 0xec, 0x4f, 0x86, 0x60, 0x2b, 0x43, 0x67, 0x45, 0xdc, 0x74, 0x3b, 0x03, 0x07, 0xea,
-	0x8d, 0x61, 0xc6, 0x08, 0x53, 0xf7, 0xe2, 0xb4, 0xff, 0x7b, 0x6c, 0x7d, 0x73, 0x6c, 0x0f, 0xf4,
-	0xb1, 0x6d, 0x00, 0xf4, 0xc1, 0xed, 0xcb, 0xec, 0x0b, 0x99, 0x94, 0x7d, 0xa3, 0x57, 0x57, 0x73,
-	0xd7, 0xba, 0x9e, 0xbb, 0xd6, 0xb7, 0xb9, 0x6b, 0x5d, 0x2e, 0xdc, 0xc6, 0xf5, 0xc2, 0x6d, 0x7c,
-	0x5e, 0xb8, 0x8d, 0xf7, 0xe1, 0xad, 0x1d, 0xcc, 0x27, 0xe4, 0xa8, 0x44, 0x09, 0x5f, 0x06, 0xc1,
-	0x79, 0x74, 0x1c, 0x7c, 0xd2, 0x5f, 0x1f, 0xb5, 0x51, 0xd2, 0x52, 0x23, 0x1d, 0xff, 0x0c, 0x00,
-	0x00, 0xff, 0xff, 0xa4, 0x13, 0xe4, 0xcf, 0x9a, 0x04, 0x00, 0x00,
+	// 1173 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xaf, 0xe3, 0x3f, 0x71, 0xc6, 0x76, 0x9c, 0x6c, 0x93, 0xc6, 0x49, 0xd4, 0x98, 0xce, 0x21,
+	0x22, 0xaa, 0xba, 0xf6, 0xd2, 0x03, 0x52, 0x04, 0x12, 0x5e, 0xda, 0xd2, 0x40, 0x85, 0xd2, 0x4d,
+	0x84, 0x10, 0x1c, 0x56, 0xe3, 0xf5, 0xc4, 0x5e, 0x6a, 0xef, 0x6c, 0x77, 0xd6, 0x01, 0x1f, 0xf8,
+	0x0e, 0x15, 0x5f, 0x80, 0x3b, 0x37, 0xae, 0x7c, 0x82, 0x72, 0xeb, 0x11, 0x71, 0x08, 0x08, 0x6e,
+	0x1c, 0xf9, 0x04, 0xbc, 0x9d, 0x19, 0xdb, 0xbb, 0x6b, 0xd3, 0xd4, 0xee, 0x61, 0x95, 0xbc, 0x7f,
+	0xbf, 0x37, 0x33, 0xef, 0xf7, 0xe6, 0x8d, 0xd1, 0x21, 0xe3, 0x03, 0xc6, 0x5d, 0xde, 0x08, 0xbf,
+	0x25, 0x7e, 0xe3, 0xd2, 0x68, 0xd3, 0x90, 0x18, 0x42, 0xb0, 0x03, 0xea, 0xb0, 0xa0, 0xa3, 0xfb,
+	0x01, 0x0b, 0x99, 0xb6, 0xa5, 0xfc, 0xf4, 0xc8, 0xa4, 0x2b, 0xbf, 0xbd, 0xad, 0x2e, 0xeb, 0x32,
+	0xe1, 0xd0, 0x88, 0xfe, 0x93, 0xbe, 0x7b, 0xbb, 0x5d, 0xc6, 0xba, 0x7d, 0xda, 0x10, 0x52, 0x7b,
+	0x78, 0xd1, 0x20, 0xde, 0x68, 0x6c, 0x72, 0x04, 0x8e, 0x2d, 0x63, 0xa4, 0xa0, 0x4c, 0x07, 0x52,
+	0x6a, 0xb4, 0x09, 0xa7, 0x93, 0x85, 0x38, 0xcc, 0xf5, 0x94, 0xbd, 0x9e, 0x46, 0x0d, 0xdd, 0x01,
+	0xe5, 0x21, 0x19, 0xf8, 0xd2, 0x01, 0xff, 0xb2, 0x8a, 0xd0, 0x39, 0xac, 0xce, 0x12, 0xeb, 0xd6,
+	0x76, 0xd0, 0xaa, 0xcf, 0x58, 0xdf, 0x76, 0x3b, 0xb5, 0xcc, 0x3b, 0x99, 0x77, 0x73, 0x56, 0x21,
+	0x12, 0x4f, 0x3a, 0xda, 0x1d, 0x54, 0x26, 0x9c, 0xd3, 0xb0, 0x69, 0x77, 0xa8, 0xc7, 0x06, 0xb5,
+	0x15, 0xb0, 0xae, 0x59, 0x25, 0xa9, 0x7b, 0x10, 0xa9, 0x26, 0x2e, 0x86, 0x72, 0xc9, 0xc6, 0x5c,
+	0x0c, 0xe9, 0xd2, 0x42, 0x85, 0x1e, 0x75, 0xbb, 0xbd, 0xb0, 0x96, 0x03, 0x63, 0xd6, 0x3c, 0xfa,
+	0xe7, 0xaa, 0x5e, 0x91, 0x47, 0x66, 0x4b, 0xc3, 0xbf, 0x57, 0xf5, 0xad, 0x11, 0x19, 0xf4, 0x8f,
+	0x71, 0x42, 0x8d, 0x2d, 0x15, 0xa8, 0x7d, 0x8e, 0x72, 0xd1, 0x1e, 0x6a, 0x79, 0x00, 0x28, 0xbd,
+	0xb7, 0xa7, 0xcb, 0x0d, 0xea, 0xe3, 0x0d, 0xea, 0xe7, 0xe3, 0x0d, 0x9a, 0x07, 0x2f, 0xaf, 0xea,
+	0x37, 0x00, 0x4f, 0x4b, 0xe0, 0x45, 0xc1, 0xf8, 0xc5, 0x1f, 0xf5, 0x8c, 0x25, 0x70, 0xb4, 0xaf,
+	0x91, 0xe6, 0x37, 0xed, 0x3e, 0xe1, 0xa1, 0xcd, 0x7d, 0x16, 0xc2, 0x21, 0xbb, 0x0e, 0xad, 0x15,
+	0xa2, 0xb5, 0x9b, 0x7a, 0x84, 0xf0, 0xfb, 0x55, 0xfd, 0xb0, 0xeb, 0x86, 0xbd, 0x61, 0x5b, 0x77,
+	0xd8, 0x40, 0x1d, 0xbf, 0xfa, 0x73, 0x8f, 0x77, 0x9e, 0x35, 0xc2, 0x91, 0x4f, 0xb9, 0xfe, 0x80,
+	0x3a, 0x56, 0xd5, 0x6f, 0x3e, 0x01, 0xa0, 0x33, 0xc0, 0x39, 0x8d, 0x60, 0x04, 0xb8, 0x31, 0x03,
+	0xbe, 0xba, 0x24, 0xb8, 0x91, 0x04, 0xe7, 0xe8, 0x00, 0x56, 0x4e, 0x02, 0x08, 0x1f, 0xd0, 0xd0,
+	0x75, 0x6c, 0x41, 0x40, 0xe2, 0x38, 0xc3, 0xc1, 0xb0, 0x4f, 0x42, 0x16, 0xd4, 0x8a, 0x4b, 0x25,
+	0xda, 0xf7, 0x9b, 0xad, 0x09, 0x68, 0xc4, 0x8d, 0xd6, 0x14, 0x52, 0x24, 0x35, 0x5e, 0x9b, 0x74,
+	0x6d, 0xc9, 0xa4, 0xc6, 0xff, 0x27, 0xed, 0xa3, 0xbd, 0x2e, 0x65, 0x60, 0x0a, 0xe6, 0x25, 0x44,
+	0x4b, 0x25, 0xac, 0x4d, 0x10, 0xd3, 0xd9, 0x2e, 0x50, 0x55, 0x54, 0x8c, 0x06, 0x01, 0x0b, 0x04,
+	0x5f, 0x6a, 0xa5, 0x6b, 0xc9, 0x86, 0x15, 0xd9, 0x6e, 0x49, 0xb2, 0xa5, 0x00, 0x24, 0xe1, 0x2a,
+	0x91, 0xf6, 0x61, 0xa4, 0x8c, 0xe2, 0xb4, 0x8f, 0xd0, 0x3a, 0x77, 0x7a, 0x74, 0x40, 0xec, 0x4b,
+	0x1a, 0x70, 0x97, 0x79, 0xb5, 0x32, 0xa4, 0xa9, 0x98, 0xbb, 0x00, 0xb3, 0x2d, 0x61, 0x92, 0x76,
+	0x6c, 0x55, 0xa4, 0xe2, 0x0b, 0x25, 0xff, 0x58, 0x90, 0xcd, 0xfb, 0x31, 0xf1, 0x3a, 0x7d, 0xaa,
+	0x7d, 0x89, 0x10, 0x2c, 0x26, 0x08, 0xe5, 0x9a, 0x33, 0xd7, 0xae, 0xf9, 0xb6, 0x5a, 0xf3, 0xa6,
+	0x4a, 0x36, 0x89, 0x95, 0xcb, 0x5d, 0x13, 0x0a, 0xb1, 0x54, 0x0b, 0x15, 0xa9, 0x27, 0x7b, 0x47,
+	0x74, 0xfe, 0xeb, 0x71, 0xf7, 0x15, 0x6e, 0x55, 0xe2, 0x8e, 0x23, 0x25, 0xea, 0x2a, 0x88, 0x02,
+	0xf3, 0x39, 0xaa, 0xa6, 0x68, 0x24, 0x6f, 0x0c, 0xf3, 0xf1, 0x62, 0x95, 0x9c, 0x1e, 0x7a, 0x0a,
+	0x0e, 0x5b, 0xeb, 0x24, 0x41, 0x29, 0x20, 0xef, 0xc6, 0x85, 0x1b, 0x24, 0x9b, 0x31, 0x27, 0x72,
+	0x9e, 0x2c, 0x9c, 0x73, 0x47, 0xe6, 0x4c, 0xe3, 0x41, 0x52, 0xa1, 0x9a, 0xb6, 0xa9, 0xaf, 0xe8,
+	0x14, 0xcb, 0x99, 0x7f, 0xbb, 0x7d, 0xa6, 0xe0, 0xb0, 0x24, 0xd6, 0x34, 0xe3, 0xfb, 0xa8, 0xd4,
+	0x23, 0x5c, 0x8d, 0x22, 0x2e, 0xee, 0xb2, 0xa2, 0x79, 0x6b, 0x7a, 0x13, 0xc6, 0x8c, 0xd8, 0x42,
+	0x20, 0xc9, 0xcb, 0x9f, 0x6b, 0xc7, 0xa8, 0x2c, 0x39, 0x4b, 0x9c, 0xd0, 0xbd, 0x94, 0x17, 0x55,
+	0xd1, 0xdc, 0x81, 0xc8, 0x9b, 0xaa, 0x94, 0x31, 0x2b, 0xb6, 0x4a, 0x42, 0x6c, 0x09, 0x49, 0x7b,
+	0x82, 0x34, 0x49, 0x20, 0xd7, 0x0b, 0x69, 0xe0, 0x33, 0xe8, 0x25, 0xda, 0x11, 0x37, 0x50, 0xd1,
+	0xbc, 0x0d, 0x08, 0xbb, 0x71, 0x92, 0xc5, 0x7d, 0xb0, 0xb5, 0x29, 0x94, 0x27, 0x31, 0x9d, 0xf6,
+	0x08, 0x6d, 0x44, 0xb4, 0x49, 0x60, 0xad, 0x09, 0xac, 0xfd, 0xe9, 0xd9, 0xa7, 0x3d, 0xb0, 0x55,
+	0x05, 0x55, 0x1c, 0x07, 0xff, 0xb4, 0x82, 0xaa, 0x4f, 0x87, 0x24, 0x20, 0x5e, 0xe8, 0x7a, 0xb4,
+	0x73, 0x0a, 0xc3, 0x4c, 0xbb, 0x9b, 0x9a, 0x71, 0xa6, 0x06, 0x90, 0xeb, 0x12, 0x52, 0x19, 0xf0,
+	0x64, 0xee, 0x1d, 0x4d, 0x26, 0xd6, 0x8a, 0x98, 0x58, 0x9b, 0xe0, 0x5b, 0x51, 0xc7, 0x98, 0x9a,
+	0x4c, 0x9f, 0xa8, 0xc9, 0x94, 0xbd, 0xb6, 0x41, 0x76, 0x54, 0x83, 0x94, 0x24, 0x50, 0x7a, 0x24,
+	0x7d, 0x88, 0xc6, 0x33, 0x51, 0x0c, 0x52, 0x0e, 0x1c, 0xcd, 0x02, 0x5f, 0x6a, 0x33, 0xb3, 0x51,
+	0x9a, 0xb1, 0x55, 0x96, 0xb2, 0x98, 0xb1, 0x3c, 0x2a, 0xbf, 0xd8, 0x86, 0x0a, 0xce, 0x8b, 0xe0,
+	0x58, 0xf9, 0x63, 0x46, 0x28, 0x7f, 0x24, 0xc9, 0x40, 0xfc, 0x6b, 0x0e, 0x55, 0x27, 0x2c, 0x3a,
+	0x83, 0x85, 0xc2, 0x9d, 0xb2, 0xd0, 0x61, 0x1d, 0xcf, 0x7b, 0x24, 0xc4, 0xf9, 0x13, 0xb7, 0xe2,
+	0xe4, 0xeb, 0xe1, 0x78, 0xde, 0xeb, 0x61, 0x26, 0xd6, 0x48, 0xc6, 0xaa, 0x67, 0xc5, 0x51, 0xea,
+	0x59, 0xf1, 0x06, 0x45, 0xca, 0xbf, 0x6d, 0x91, 0xbe, 0x41, 0x15, 0x98, 0xbe, 0x33, 0x4f, 0x86,
+	0x47, 0x0b, 0x37, 0xb5, 0x2a, 0x69, 0x02, 0x0c, 0xf6, 0xe7, 0x37, 0xa7, 0x0d, 0x1d, 0xe5, 0x32,
+	0x66, 0x5f, 0x10, 0xcb, 0xe7, 0x32, 0x52, 0xb9, 0x8c, 0x69, 0xae, 0x87, 0x68, 0x63, 0x6a, 0x93,
+	0x23, 0x4c, 0x75, 0x71, 0xac, 0xf3, 0xd2, 0x1e, 0x70, 0xeb, 0xf1, 0x31, 0x82, 0x18, 0x70, 0xf8,
+	0x87, 0x0c, 0xd2, 0x60, 0x2c, 0x31, 0xcf, 0x75, 0x48, 0x5f, 0x3c, 0x30, 0xd9, 0x30, 0xa4, 0xda,
+	0x21, 0xca, 0xcb, 0xf2, 0x66, 0xc4, 0x0e, 0x36, 0x00, 0xb2, 0x2c, 0x21, 0x55, 0x5d, 0xa5, 0x59,
+	0x3b, 0x47, 0xb9, 0x1e, 0xf3, 0x39, 0x30, 0x28, 0x0b, 0x65, 0xba, 0xab, 0xcf, 0x7b, 0x48, 0xeb,
+	0xb3, 0xf8, 0x8f, 0x99, 0x6f, 0xde, 0x4c, 0xd6, 0x2d, 0x82, 0xc1, 0x96, 0x40, 0xc3, 0xdf, 0xa3,
+	0xed, 0xb9, 0x31, 0x8b, 0xb1, 0x1c, 0xfa, 0xeb, 0xf9, 0x90, 0x85, 0xd4, 0x16, 0x14, 0x54, 0x24,
+	0x8f, 0xf5, 0x57, 0xcc, 0x08, 0xfd, 0x25, 0xa4, 0x96, 0x10, 0x7e, 0xce, 0xa0, 0xf2, 0x69, 0x30,
+	0xf4, 0x5c, 0xaf, 0x7b, 0x16, 0xc2, 0xf5, 0xa4, 0x39, 0x68, 0x5d, 0xdc, 0xe5, 0xcf, 0xa8, 0xff,
+	0xc6, 0x43, 0xfb, 0x8e, 0xda, 0xde, 0x76, 0x6c, 0x16, 0x4c, 0xe2, 0x25, 0x41, 0xcb, 0x91, 0xf2,
+	0x33, 0xd0, 0x89, 0x39, 0xfb, 0x01, 0xaa, 0x28, 0xa7, 0x91, 0xcd, 0x29, 0xf5, 0xc4, 0x82, 0xcb,
+	0xf1, 0xdb, 0x24, 0x61, 0x06, 0x3a, 0xc8, 0xf0, 0xd1, 0x19, 0x48, 0xe6, 0xa7, 0x2f, 0xff, 0x3a,
+	0xc8, 0xbc, 0x82, 0xef, 0x4f, 0xf8, 0x5e, 0xfc, 0x7d, 0x70, 0xe3, 0x15, 0x7c, 0xbf, 0xc1, 0xf7,
+	0x55, 0x33, 0xc6, 0x3a, 0x55, 0x9e, 0x7b, 0x7d, 0xd2, 0xe6, 0x63, 0x01, 0x7e, 0x8e, 0xdc, 0x6f,
+	0x7c, 0x27, 0x7f, 0x22, 0x09, 0x0e, 0xb6, 0x0b, 0x62, 0x3b, 0xf7, 0xff, 0x03, 0x47, 0xbc, 0xa7,
+	0x6b, 0x3f, 0x0d, 0x00, 0x00,
 }
 
 func (m *TwapRecord) Marshal() (dAtA []byte, err error) {
@@ -203,6 +718,11 @@ func (m *TwapRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SchemaVersion != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.SchemaVersion))
+		i--
+		dAtA[i] = 0x60
+	}
 	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastErrorTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastErrorTime):])
 	if err1 != nil {
 		return 0, err1
@@ -296,8 +816,378 @@ func (m *TwapRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTwapRecord(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTwapRecord(v)
+func (m *TwapCandle) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TwapCandle) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TwapCandle) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EndInterpolated {
+		i--
+		if m.EndInterpolated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.StartInterpolated {
+		i--
+		if m.StartInterpolated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ErrorActive {
+		i--
+		if m.ErrorActive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.HasRecords {
+		i--
+		if m.HasRecords {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	{
+		size := m.LastSpotPrice.Size()
+		i -= size
+		if _, err := m.LastSpotPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	{
+		size := m.FirstSpotPrice.Size()
+		i -= size
+		if _, err := m.FirstSpotPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size := m.ArithmeticTwap.Size()
+		i -= size
+		if _, err := m.ArithmeticTwap.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintTwapRecord(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x12
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintTwapRecord(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QuarantinedPool) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuarantinedPool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuarantinedPool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PoolDenoms) > 0 {
+		for iNdEx := len(m.PoolDenoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PoolDenoms[iNdEx])
+			copy(dAtA[i:], m.PoolDenoms[iNdEx])
+			i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.PoolDenoms[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.RecordDenoms) > 0 {
+		for iNdEx := len(m.RecordDenoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RecordDenoms[iNdEx])
+			copy(dAtA[i:], m.RecordDenoms[iNdEx])
+			i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.RecordDenoms[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err5 != nil {
+		return 0, err5
+	}
+	i -= n5
+	i = encodeVarintTwapRecord(dAtA, i, uint64(n5))
+	i--
+	dAtA[i] = 0x1a
+	if m.Height != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SpotPriceSample) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpotPriceSample) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpotPriceSample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SpotPriceError {
+		i--
+		if m.SpotPriceError {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	{
+		size := m.P1SpotPrice.Size()
+		i -= size
+		if _, err := m.P1SpotPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	{
+		size := m.P0SpotPrice.Size()
+		i -= size
+		if _, err := m.P0SpotPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintTwapRecord(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x2a
+	if m.Height != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Asset1Denom) > 0 {
+		i -= len(m.Asset1Denom)
+		copy(dAtA[i:], m.Asset1Denom)
+		i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.Asset1Denom)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Asset0Denom) > 0 {
+		i -= len(m.Asset0Denom)
+		copy(dAtA[i:], m.Asset0Denom)
+		i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.Asset0Denom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CanonicalTwapRoute) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalTwapRoute) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalTwapRoute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hops) > 0 {
+		for iNdEx := len(m.Hops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTwapRecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CanonicalTwapRouteHop) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalTwapRouteHop) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalTwapRouteHop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.QuoteAsset) > 0 {
+		i -= len(m.QuoteAsset)
+		copy(dAtA[i:], m.QuoteAsset)
+		i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.QuoteAsset)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintTwapRecord(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PruningState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PruningState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PruningState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LastKeySeen) > 0 {
+		i -= len(m.LastKeySeen)
+		copy(dAtA[i:], m.LastKeySeen)
+		i = encodeVarintTwapRecord(dAtA, i, uint64(len(m.LastKeySeen)))
+		i--
+		dAtA[i] = 0x12
+	}
+	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastKeptTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastKeptTime):])
+	if err7 != nil {
+		return 0, err7
+	}
+	i -= n7
+	i = encodeVarintTwapRecord(dAtA, i, uint64(n7))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTwapRecord(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTwapRecord(v)
 	base := offset
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -341,16 +1231,1051 @@ func (m *TwapRecord) Size() (n int) {
 	n += 1 + l + sovTwapRecord(uint64(l))
 	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastErrorTime)
 	n += 1 + l + sovTwapRecord(uint64(l))
+	if m.SchemaVersion != 0 {
+		n += 1 + sovTwapRecord(uint64(m.SchemaVersion))
+	}
 	return n
 }
 
-func sovTwapRecord(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTwapRecord(x uint64) (n int) {
-	return sovTwapRecord(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *TwapCandle) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = m.ArithmeticTwap.Size()
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = m.FirstSpotPrice.Size()
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = m.LastSpotPrice.Size()
+	n += 1 + l + sovTwapRecord(uint64(l))
+	if m.HasRecords {
+		n += 2
+	}
+	if m.ErrorActive {
+		n += 2
+	}
+	if m.StartInterpolated {
+		n += 2
+	}
+	if m.EndInterpolated {
+		n += 2
+	}
+	return n
+}
+
+func (m *QuarantinedPool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovTwapRecord(uint64(m.PoolId))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTwapRecord(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTwapRecord(uint64(l))
+	if len(m.RecordDenoms) > 0 {
+		for _, s := range m.RecordDenoms {
+			l = len(s)
+			n += 1 + l + sovTwapRecord(uint64(l))
+		}
+	}
+	if len(m.PoolDenoms) > 0 {
+		for _, s := range m.PoolDenoms {
+			l = len(s)
+			n += 1 + l + sovTwapRecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SpotPriceSample) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovTwapRecord(uint64(m.PoolId))
+	}
+	l = len(m.Asset0Denom)
+	if l > 0 {
+		n += 1 + l + sovTwapRecord(uint64(l))
+	}
+	l = len(m.Asset1Denom)
+	if l > 0 {
+		n += 1 + l + sovTwapRecord(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTwapRecord(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = m.P0SpotPrice.Size()
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = m.P1SpotPrice.Size()
+	n += 1 + l + sovTwapRecord(uint64(l))
+	if m.SpotPriceError {
+		n += 2
+	}
+	return n
+}
+
+func (m *CanonicalTwapRoute) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovTwapRecord(uint64(l))
+	}
+	if len(m.Hops) > 0 {
+		for _, e := range m.Hops {
+			l = e.Size()
+			n += 1 + l + sovTwapRecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CanonicalTwapRouteHop) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovTwapRecord(uint64(m.PoolId))
+	}
+	l = len(m.QuoteAsset)
+	if l > 0 {
+		n += 1 + l + sovTwapRecord(uint64(l))
+	}
+	return n
+}
+
+func (m *PruningState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastKeptTime)
+	n += 1 + l + sovTwapRecord(uint64(l))
+	l = len(m.LastKeySeen)
+	if l > 0 {
+		n += 1 + l + sovTwapRecord(uint64(l))
+	}
+	return n
+}
+
+func sovTwapRecord(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTwapRecord(x uint64) (n int) {
+	return sovTwapRecord(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TwapRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TwapRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TwapRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Asset0Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Asset0Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Asset1Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Asset1Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field P0LastSpotPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.P0LastSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field P1LastSpotPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.P1LastSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field P0ArithmeticTwapAccumulator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.P0ArithmeticTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field P1ArithmeticTwapAccumulator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.P1ArithmeticTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GeometricTwapAccumulator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GeometricTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastErrorTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastErrorTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaVersion", wireType)
+			}
+			m.SchemaVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TwapCandle) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TwapCandle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TwapCandle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EndTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ArithmeticTwap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ArithmeticTwap.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstSpotPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FirstSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSpotPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LastSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasRecords", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasRecords = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorActive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ErrorActive = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartInterpolated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StartInterpolated = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndInterpolated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EndInterpolated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuarantinedPool) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuarantinedPool: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuarantinedPool: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordDenoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordDenoms = append(m.RecordDenoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolDenoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolDenoms = append(m.PoolDenoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *TwapRecord) Unmarshal(dAtA []byte) error {
+func (m *SpotPriceSample) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -373,10 +2298,10 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TwapRecord: wiretype end group for non-group")
+			return fmt.Errorf("proto: SpotPriceSample: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TwapRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SpotPriceSample: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -516,7 +2441,7 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field P0LastSpotPrice", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field P0SpotPrice", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -544,13 +2469,13 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.P0LastSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.P0SpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field P1LastSpotPrice", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field P1SpotPrice", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -578,13 +2503,83 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.P1LastSpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.P1SpotPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpotPriceError", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SpotPriceError = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CanonicalTwapRoute) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalTwapRoute: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalTwapRoute: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field P0ArithmeticTwapAccumulator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -612,15 +2607,13 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.P0ArithmeticTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field P1ArithmeticTwapAccumulator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hops", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTwapRecord
@@ -630,29 +2623,98 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTwapRecord
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTwapRecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.P1ArithmeticTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hops = append(m.Hops, CanonicalTwapRouteHop{})
+			if err := m.Hops[len(m.Hops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CanonicalTwapRouteHop) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalTwapRouteHop: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalTwapRouteHop: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GeometricTwapAccumulator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QuoteAsset", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -680,13 +2742,61 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GeometricTwapAccumulator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.QuoteAsset = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTwapRecord(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 11:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PruningState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTwapRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PruningState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PruningState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastErrorTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastKeptTime", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -713,10 +2823,44 @@ func (m *TwapRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastErrorTime, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastKeptTime, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastKeySeen", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTwapRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTwapRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastKeySeen = append(m.LastKeySeen[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastKeySeen == nil {
+				m.LastKeySeen = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTwapRecord(dAtA[iNdEx:])
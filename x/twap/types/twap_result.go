@@ -0,0 +1,38 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TwapResult is a TWAP along with the records it was computed from, so that callers can tell how trustworthy it is.
+type TwapResult struct {
+	Price sdk.Dec
+	// StartRecordTime and EndRecordTime are the times of the records that the accumulators at the start and the end
+	// of the window were interpolated from.
+	StartRecordTime time.Time
+	EndRecordTime   time.Time
+	// StartInterpolated is whether no record was written at the start of the window, in which case the accumulators
+	// at the start were interpolated from the record at StartRecordTime.
+	StartInterpolated bool
+	// LastErrorTime is the last time the spot price of the pool errored, up to the end of the window, or zero if it
+	// never did. The spot price errored within the window if it is at or after the start of the window.
+	LastErrorTime time.Time
+}
+
+// NewTwapResult returns the TwapResult of price, computed from startRecord and endRecord interpolated at the start
+// and the end of the window from the records written at startRecordTime and endRecordTime.
+func NewTwapResult(price sdk.Dec, startRecord, endRecord TwapRecord, startRecordTime, endRecordTime time.Time) TwapResult {
+	lastErrorTime := endRecord.LastErrorTime
+	if startRecord.LastErrorTime.After(lastErrorTime) {
+		lastErrorTime = startRecord.LastErrorTime
+	}
+	return TwapResult{
+		Price:             price,
+		StartRecordTime:   startRecordTime,
+		EndRecordTime:     endRecordTime,
+		StartInterpolated: !startRecordTime.Equal(startRecord.Time),
+		LastErrorTime:     lastErrorTime,
+	}
+}
@@ -3,6 +3,7 @@ package twapmock
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	gammtypes "github.com/osmosis-labs/osmosis/v13/x/gamm/types"
 	"github.com/osmosis-labs/osmosis/v13/x/twap/types"
 )
 
@@ -69,6 +70,10 @@ func (p *ProgrammedAmmInterface) GetPoolDenoms(ctx sdk.Context, poolId uint64) (
 	return p.underlyingKeeper.GetPoolDenoms(ctx, poolId)
 }
 
+func (p *ProgrammedAmmInterface) GetPoolsAndPoke(ctx sdk.Context) ([]gammtypes.CFMMPoolI, error) {
+	return p.underlyingKeeper.GetPoolsAndPoke(ctx)
+}
+
 func (p *ProgrammedAmmInterface) CalculateSpotPrice(ctx sdk.Context,
 	poolId uint64,
 	quoteDenom,
@@ -1,15 +1,27 @@
 package types
 
 import (
-	"fmt"
 	"sort"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v13/osmomath"
 )
 
 var MaxSpotPrice = sdk.NewDec(2).Power(128).Sub(sdk.OneDec())
 
+// GeometricTwapMathBase is the base of the logarithms accumulated by the geometric TWAP accumulators.
+var GeometricTwapMathBase = sdk.NewDec(2)
+
+// InversionTolerance bounds the rounding error of twap(A/B) * twap(B/A), the product of the TWAPs of a pair quoted
+// both ways over the same window, for spot prices between 10^-6 and 10^6.
+// The geometric TWAP quoted in asset 1 is the reciprocal of the one quoted in asset 0, so their product is 1.
+// The arithmetic TWAPs are each computed from their own accumulator, so their product is the mean of the spot prices
+// times the mean of their reciprocals. It is 1 if the spot price didn't change over the window, and higher otherwise,
+// up to (m + M)^2 / 4mM for spot prices between m and M. This is inherent to arithmetic means, not a rounding error.
+var InversionTolerance = sdk.NewDecWithPrec(1, 10)
+
 // GetAllUniqueDenomPairs returns all unique pairs of denoms, where for every pair
 // (X, Y), X < Y.
 // The pair (X,Y) should only appear once in the list. Denoms are lexicographically sorted.
@@ -34,6 +46,15 @@ func GetAllUniqueDenomPairs(denoms []string) []DenomPair {
 	return denomPairs
 }
 
+// AccumulatorTimeDelta returns the time between start and end, as accounted for by the accumulators.
+// Both times are truncated to the millisecond before being subtracted, rather than truncating their
+// difference. This way, the deltas of consecutive intervals add up exactly to the delta of the whole
+// interval, so that an accumulator interpolated to some time is identical to one that got there through
+// records written in between. Every computation on accumulators must use it.
+func AccumulatorTimeDelta(start, end time.Time) time.Duration {
+	return time.Duration(end.UnixMilli()-start.UnixMilli()) * time.Millisecond
+}
+
 // SpotPriceMulDuration returns the spot price multiplied by the time delta,
 // that is the spot price between the current and last TWAP record.
 // A single second accounts for 1_000_000_000 when converted to int64.
@@ -49,12 +70,72 @@ func AccumDiffDivDuration(accumDiff sdk.Dec, timeDelta time.Duration) sdk.Dec {
 	return accumDiff.QuoInt64(deltaMS)
 }
 
+// CombineArithmeticTwaps returns the arithmetic TWAP over the window [a, c] from the arithmetic TWAPs over the
+// adjacent windows [a, b] and [b, c], without re-querying the records. The durations are those of the windows as
+// accounted for by the accumulators, i.e. AccumulatorTimeDelta(a, b) and AccumulatorTimeDelta(b, c).
+// It is the mean of both TWAPs weighted by the milliseconds of their windows. As the TWAPs it is given are truncated
+// to 10^-18, it is within 10^-18 of the TWAP the keeper computes over [a, c].
+// An empty window returns the last spot price, so if both windows are empty, twapBC is returned.
+func CombineArithmeticTwaps(twapAB sdk.Dec, durationAB time.Duration, twapBC sdk.Dec, durationBC time.Duration) (sdk.Dec, error) {
+	msAB, msBC, err := combinedWindowMilliseconds(durationAB, durationBC)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if msAB+msBC == 0 {
+		return twapBC, nil
+	}
+	return twapAB.MulInt64(msAB).Add(twapBC.MulInt64(msBC)).QuoInt64(msAB + msBC), nil
+}
+
+// CombineGeometricTwaps returns the geometric TWAP over the window [a, c] from the geometric TWAPs over the
+// adjacent windows [a, b] and [b, c], without re-querying the records. The durations are those of
+// CombineArithmeticTwaps.
+// It is 2 to the power of the mean of the base 2 logarithms of both TWAPs, weighted by the milliseconds of their
+// windows, as the keeper computes it from the accumulators. A negative mean, i.e. a TWAP quoted in asset 1 of a pool
+// whose asset 0 TWAP is at least 1, is exponentiated as the reciprocal of its opposite, as the keeper does.
+// Both TWAPs, the result and the keeper's TWAP over [a, c] being power approximations, each within
+// 2 * GeometricTwapPowPrecision of the exact mean, the result is within 6 * GeometricTwapPowPrecision of the keeper's,
+// relative to the asset 0 TWAP, under the conditions of TwapErrorBounds.
+func CombineGeometricTwaps(twapAB sdk.Dec, durationAB time.Duration, twapBC sdk.Dec, durationBC time.Duration) (sdk.Dec, error) {
+	msAB, msBC, err := combinedWindowMilliseconds(durationAB, durationBC)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if msAB+msBC == 0 {
+		return twapBC, nil
+	}
+	if !twapAB.IsPositive() {
+		return sdk.Dec{}, NonPositiveTwapError{Twap: twapAB}
+	}
+	if !twapBC.IsPositive() {
+		return sdk.Dec{}, NonPositiveTwapError{Twap: twapBC}
+	}
+	logAB := osmomath.BigDecFromSDKDec(twapAB).LogBase2().SDKDec()
+	logBC := osmomath.BigDecFromSDKDec(twapBC).LogBase2().SDKDec()
+	meanOfLogs := logAB.MulInt64(msAB).Add(logBC.MulInt64(msBC)).QuoInt64(msAB + msBC)
+	if meanOfLogs.IsNegative() {
+		return sdk.OneDec().Quo(osmomath.PowApprox(GeometricTwapMathBase, meanOfLogs.Neg(), GeometricTwapPowPrecision)), nil
+	}
+	return osmomath.PowApprox(GeometricTwapMathBase, meanOfLogs, GeometricTwapPowPrecision), nil
+}
+
+// combinedWindowMilliseconds returns the milliseconds of the windows of the TWAPs to combine, which can't be negative.
+func combinedWindowMilliseconds(durationAB, durationBC time.Duration) (int64, int64, error) {
+	if durationAB < 0 {
+		return 0, 0, NegativeDurationError{Name: "durationAB", Duration: durationAB}
+	}
+	if durationBC < 0 {
+		return 0, 0, NegativeDurationError{Name: "durationBC", Duration: durationBC}
+	}
+	return durationAB.Milliseconds(), durationBC.Milliseconds(), nil
+}
+
 // LexicographicalOrderDenoms takes two denoms and returns them to be in lexicographically ascending order.
 // In other words, the first returned denom string will be the lexicographically smaller of the two denoms.
 // If the denoms are equal, an error will be returned.
 func LexicographicalOrderDenoms(denom0, denom1 string) (string, string, error) {
 	if denom0 == denom1 {
-		return "", "", fmt.Errorf("both assets cannot be of the same denom: assetA: %s, assetB: %s", denom0, denom1)
+		return "", "", SameDenomError{Denom: denom0}
 	}
 	if denom0 > denom1 {
 		denom0, denom1 = denom1, denom0
@@ -67,3 +148,38 @@ type DenomPair struct {
 	Denom0 string
 	Denom1 string
 }
+
+// LastUpdateInfo is when the most recent record of a denom pair of a pool was written, for liveness monitoring.
+type LastUpdateInfo struct {
+	PoolId      uint64
+	Asset0Denom string
+	Asset1Denom string
+	// Height and Time are those of the block the record was written at the end of.
+	Height int64
+	Time   time.Time
+	// BlocksSinceUpdate is the number of blocks from Height to the height the info was got at.
+	BlocksSinceUpdate uint64
+	// Stale is whether BlocksSinceUpdate is over the max block age the info was got with.
+	Stale bool
+	// Quarantined is whether the pool is quarantined, in which case its records are not updated until it is repaired.
+	Quarantined bool
+}
+
+// NewLastUpdateInfo returns the LastUpdateInfo of the most recent record of a denom pair at height blockHeight, stale
+// if it was written more than maxBlockAge blocks before.
+func NewLastUpdateInfo(record TwapRecord, blockHeight int64, maxBlockAge uint64, quarantined bool) LastUpdateInfo {
+	var blocksSinceUpdate uint64
+	if blockHeight > record.Height {
+		blocksSinceUpdate = uint64(blockHeight - record.Height)
+	}
+	return LastUpdateInfo{
+		PoolId:            record.PoolId,
+		Asset0Denom:       record.Asset0Denom,
+		Asset1Denom:       record.Asset1Denom,
+		Height:            record.Height,
+		Time:              record.Time,
+		BlocksSinceUpdate: blocksSinceUpdate,
+		Stale:             blocksSinceUpdate > maxBlockAge,
+		Quarantined:       quarantined,
+	}
+}
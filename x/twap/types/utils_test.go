@@ -1,12 +1,14 @@
 package types
 
 import (
-	"fmt"
 	"testing"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/osmosis-labs/osmosis/v13/app/apptesting/osmoassert"
+	"github.com/osmosis-labs/osmosis/v13/osmomath"
 	"github.com/osmosis-labs/osmosis/v13/x/gamm/types"
 )
 
@@ -51,14 +53,14 @@ func TestLexicographicalOrderDenoms(t *testing.T) {
 			"uosmo", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
 			"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", "uosmo", nil,
 		},
-		"sameDenom": {"A", "A", "", "", fmt.Errorf("both assets cannot be of the same denom: assetA: %s, assetB: %s", "A", "A")},
+		"sameDenom": {"A", "A", "", "", SameDenomError{Denom: "A"}},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			// system under test
 			denomA, denomB, err := LexicographicalOrderDenoms(tt.firstDenom, tt.secondDenom)
 			if tt.expectedErr != nil {
-				require.Equal(t, tt.expectedErr, err)
+				require.ErrorIs(t, err, tt.expectedErr)
 			} else {
 				require.Equal(t, denomA, tt.expectedDenomA)
 				require.Equal(t, denomB, tt.expectedDenomB)
@@ -66,3 +68,115 @@ func TestLexicographicalOrderDenoms(t *testing.T) {
 		})
 	}
 }
+
+func TestAccumulatorTimeDelta(t *testing.T) {
+	base := time.Unix(1257894000, 0).UTC()
+	tests := map[string]struct {
+		start    time.Time
+		end      time.Time
+		expDelta time.Duration
+	}{
+		"whole seconds":                {base, base.Add(time.Second), time.Second},
+		"same time":                    {base, base, 0},
+		"sub millisecond, same ms":     {base.Add(100 * time.Microsecond), base.Add(900 * time.Microsecond), 0},
+		"sub millisecond, crossing ms": {base.Add(900 * time.Microsecond), base.Add(1100 * time.Microsecond), time.Millisecond},
+		// the difference is 1.5ms, but both times are truncated first
+		"1.5ms from a whole ms": {base, base.Add(1500 * time.Microsecond), time.Millisecond},
+		"1.5ms from a half ms":  {base.Add(500 * time.Microsecond), base.Add(2 * time.Millisecond), 2 * time.Millisecond},
+		"reversed":              {base.Add(time.Second), base, -time.Second},
+		"before the unix epoch": {time.Unix(-1, 500_000), time.Unix(0, 0), time.Second},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.expDelta, AccumulatorTimeDelta(tt.start, tt.end))
+		})
+	}
+
+	// consecutive deltas add up to the delta of the whole interval
+	t0, t1, t2 := base.Add(300*time.Microsecond), base.Add(1700*time.Microsecond), base.Add(3100*time.Microsecond)
+	require.Equal(t, AccumulatorTimeDelta(t0, t2), AccumulatorTimeDelta(t0, t1)+AccumulatorTimeDelta(t1, t2))
+}
+
+func TestCombineArithmeticTwaps(t *testing.T) {
+	tests := map[string]struct {
+		twapAB      sdk.Dec
+		durationAB  time.Duration
+		twapBC      sdk.Dec
+		durationBC  time.Duration
+		expTwap     sdk.Dec
+		expectedErr error
+	}{
+		"equal windows":           {sdk.NewDec(10), time.Second, sdk.NewDec(20), time.Second, sdk.NewDec(15), nil},
+		"weighted by duration":    {sdk.NewDec(10), 3 * time.Second, sdk.NewDec(20), time.Second, sdk.MustNewDecFromStr("12.5"), nil},
+		"same twap":               {sdk.NewDec(7), time.Minute, sdk.NewDec(7), time.Hour, sdk.NewDec(7), nil},
+		"empty first window":      {sdk.NewDec(10), 0, sdk.NewDec(20), time.Second, sdk.NewDec(20), nil},
+		"empty second window":     {sdk.NewDec(10), time.Second, sdk.NewDec(20), 0, sdk.NewDec(10), nil},
+		"both windows empty":      {sdk.NewDec(10), 0, sdk.NewDec(20), 0, sdk.NewDec(20), nil},
+		"sub millisecond ignored": {sdk.NewDec(10), time.Second + 999*time.Microsecond, sdk.NewDec(20), time.Second, sdk.NewDec(15), nil},
+		// 10 / 3 is truncated
+		"rounded": {sdk.ZeroDec(), 2 * time.Millisecond, sdk.NewDec(10), time.Millisecond, sdk.MustNewDecFromStr("3.333333333333333333"), nil},
+		"negative first window": {
+			sdk.NewDec(10), -time.Second, sdk.NewDec(20), time.Second, sdk.Dec{},
+			NegativeDurationError{Name: "durationAB", Duration: -time.Second},
+		},
+		"negative second window": {
+			sdk.NewDec(10), time.Second, sdk.NewDec(20), -time.Second, sdk.Dec{},
+			NegativeDurationError{Name: "durationBC", Duration: -time.Second},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			twap, err := CombineArithmeticTwaps(tt.twapAB, tt.durationAB, tt.twapBC, tt.durationBC)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expTwap, twap)
+		})
+	}
+}
+
+func TestCombineGeometricTwaps(t *testing.T) {
+	tolerance := osmomath.ErrTolerance{MultiplicativeTolerance: GeometricTwapPowPrecision.MulInt64(6)}
+	tests := map[string]struct {
+		twapAB      sdk.Dec
+		durationAB  time.Duration
+		twapBC      sdk.Dec
+		durationBC  time.Duration
+		expTwap     sdk.Dec
+		expectedErr error
+	}{
+		// sqrt(2 * 8)
+		"equal windows": {sdk.NewDec(2), time.Second, sdk.NewDec(8), time.Second, sdk.NewDec(4), nil},
+		// (2^3 * 16)^(1/4) = 2^(7/4)
+		"weighted by duration": {sdk.NewDec(2), 3 * time.Second, sdk.NewDec(16), time.Second, sdk.MustNewDecFromStr("3.363585661014858172"), nil},
+		"same twap":            {sdk.NewDec(5), time.Minute, sdk.NewDec(5), time.Hour, sdk.NewDec(5), nil},
+		// sqrt(1/2 * 1/8), a negative mean of logarithms
+		"asset 1 quote":       {sdk.MustNewDecFromStr("0.5"), time.Second, sdk.MustNewDecFromStr("0.125"), time.Second, sdk.MustNewDecFromStr("0.25"), nil},
+		"empty first window":  {sdk.NewDec(2), 0, sdk.NewDec(8), time.Second, sdk.NewDec(8), nil},
+		"empty second window": {sdk.NewDec(2), time.Second, sdk.NewDec(8), 0, sdk.NewDec(2), nil},
+		"both windows empty":  {sdk.NewDec(2), 0, sdk.NewDec(8), 0, sdk.NewDec(8), nil},
+		"zero twap": {
+			sdk.ZeroDec(), time.Second, sdk.NewDec(8), time.Second, sdk.Dec{},
+			NonPositiveTwapError{Twap: sdk.ZeroDec()},
+		},
+		"negative first window": {
+			sdk.NewDec(2), -time.Second, sdk.NewDec(8), time.Second, sdk.Dec{},
+			NegativeDurationError{Name: "durationAB", Duration: -time.Second},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			twap, err := CombineGeometricTwaps(tt.twapAB, tt.durationAB, tt.twapBC, tt.durationBC)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectedErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 0, tolerance.CompareBigDec(osmomath.BigDecFromSDKDec(tt.expTwap), osmomath.BigDecFromSDKDec(twap)),
+				"expected %s, got %s", tt.expTwap, twap)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+const (
+	// ConsensusVersion is the consensus version of the twap module.
+	ConsensusVersion = LatestStoreVersion
+
+	// CurrentRecordSchemaVersion is stamped on every record created by this version of the module.
+	// Records created before schema versioning was introduced have a schema version of 0.
+	CurrentRecordSchemaVersion uint32 = 1
+)
+
+// Store versions of the twap module. They are the consensus versions of the module, as kept in the
+// module version map of x/upgrade, so the store version is bumped by every registered migration.
+// It determines which record extensions are populated.
+const (
+	// InitialStoreVersion is the store version of a twap store that has not run any migration.
+	InitialStoreVersion uint64 = 1
+	// RecordSchemaVersionStoreVersion is the store version at which records started being
+	// stamped with a schema version.
+	RecordSchemaVersionStoreVersion uint64 = 2
+	// PairPoolIndexStoreVersion is the store version at which pools started being indexed
+	// by the denom pairs of their records.
+	PairPoolIndexStoreVersion uint64 = 3
+
+	LatestStoreVersion = PairPoolIndexStoreVersion
+)
+
+// Record extensions are the optional fields of a TwapRecord that were added after its
+// initial definition.
+const (
+	GeometricTwapAccumulatorExtension = "geometric_twap_accumulator"
+	LastErrorTimeExtension            = "last_error_time"
+	SchemaVersionExtension            = "schema_version"
+)
+
+// recordExtensionsByStoreVersion lists the record extensions enabled at each store version,
+// starting from InitialStoreVersion.
+var recordExtensionsByStoreVersion = [][]string{
+	{GeometricTwapAccumulatorExtension, LastErrorTimeExtension},
+	{SchemaVersionExtension},
+	// the pair pool index doesn't change the records
+	{},
+}
+
+// RecordExtensionsForStoreVersion returns all record extensions that are enabled at the given store version.
+func RecordExtensionsForStoreVersion(storeVersion uint64) []string {
+	extensions := []string{}
+	for i, versionExtensions := range recordExtensionsByStoreVersion {
+		if uint64(i)+InitialStoreVersion > storeVersion {
+			break
+		}
+		extensions = append(extensions, versionExtensions...)
+	}
+	return extensions
+}